@@ -28,11 +28,13 @@ import (
 
 	_ "github.com/dafibh/fortuna/fortuna-backend/docs"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/config"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/handler"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/repository/postgres"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/repository/storage"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/version"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/websocket"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
@@ -71,7 +73,9 @@ func main() {
 	// Initialize repositories
 	userRepo := postgres.NewUserRepository(pool)
 	workspaceRepo := postgres.NewWorkspaceRepository(pool)
+	workspaceInviteRepo := postgres.NewWorkspaceInviteRepository(pool)
 	accountRepo := postgres.NewAccountRepository(pool)
+	accountGroupRepo := postgres.NewAccountGroupRepository(pool)
 	transactionRepo := postgres.NewTransactionRepository(pool)
 	monthRepo := postgres.NewMonthRepository(pool)
 	budgetCategoryRepo := postgres.NewBudgetCategoryRepository(pool)
@@ -106,16 +110,26 @@ func main() {
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, workspaceRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, workspaceInviteRepo, userRepo)
+	workspaceService.SetAccountRepository(accountRepo)
 	profileService := service.NewProfileService(userRepo)
 	accountService := service.NewAccountService(accountRepo)
+	accountService.SetGroupRepository(accountGroupRepo)
+	accountGroupService := service.NewAccountGroupService(accountGroupRepo, accountRepo)
 	transactionService := service.NewTransactionService(transactionRepo, accountRepo, budgetCategoryRepo)
+	transactionService.SetMaxDecimalPlaces(cfg.MaxDecimalPlaces)
+	transactionService.SetAllowZeroAmount(cfg.AllowZeroAmount)
 	calculationService := service.NewCalculationService(accountRepo, transactionRepo)
 	monthService := service.NewMonthService(monthRepo, transactionRepo, calculationService)
+	monthService.SetLoanRepository(loanRepo)
 	dashboardService := service.NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calculationService)
 	budgetCategoryService := service.NewBudgetCategoryService(budgetCategoryRepo)
+	budgetCategoryService.SetTransactionRepository(transactionRepo)
 	budgetAllocationService := service.NewBudgetAllocationService(budgetAllocationRepo, budgetCategoryRepo)
 	ccService := service.NewCCService(transactionRepo, accountRepo)
+	ccService.SetLoanRepository(loanRepo)
 	settlementService := service.NewSettlementService(transactionRepo, accountRepo)
+	settlementService.SetLoanRepository(loanRepo)
 	recurringTemplateRepo := postgres.NewRecurringTemplateRepository(pool)
 	recurringTemplateService := service.NewRecurringTemplateService(recurringTemplateRepo, transactionRepo, accountRepo, budgetCategoryRepo)
 
@@ -127,21 +141,43 @@ func main() {
 
 	// Link exclusion repository to recurring template service for projection exclusion tracking
 	recurringTemplateService.SetExclusionRepository(exclusionRepo)
+	recurringTemplateService.SetWorkspaceRepository(workspaceRepo)
 	transactionService.SetExclusionRepository(exclusionRepo)
 
 	transactionGroupService := service.NewTransactionGroupService(transactionGroupRepo, transactionRepo)
 
 	// Link transaction group repository to transaction service for auto-ungroup on date change
 	transactionService.SetTransactionGroupRepository(transactionGroupRepo)
+	transactionService.SetWorkspaceRepository(workspaceRepo)
+	transactionService.SetLoanRepository(loanRepo)
 	loanProviderService := service.NewLoanProviderService(loanProviderRepo)
+	loanProviderService.SetMaxDecimalPlaces(cfg.MaxDecimalPlaces)
+	loanProviderService.SetMaxLoanMonths(cfg.MaxLoanMonths)
 	loanService := service.NewLoanService(pool, loanRepo, loanProviderRepo, transactionRepo, accountRepo)
+	loanService.SetMaxDecimalPlaces(cfg.MaxDecimalPlaces)
+	loanService.SetMaxLoanMonths(cfg.MaxLoanMonths)
+	loanService.SetTransactionService(transactionService)
+	loanService.SetMonthRepository(monthRepo)
+	loanService.SetPaymentRepository(loanPaymentRepo)
+	dashboardService.SetLoanService(loanService)
+	dashboardService.SetTransactionService(transactionService)
+	if len(cfg.SpendableTemplates) > 0 {
+		spendableTemplates, err := domain.ParseSpendableTemplates(cfg.SpendableTemplates)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid SPENDABLE_ACCOUNT_TEMPLATES")
+		}
+		dashboardService.SetSpendableTemplates(spendableTemplates)
+	}
 	loanPaymentService := service.NewLoanPaymentService(pool, loanPaymentRepo, loanRepo, loanProviderRepo)
+	loanPaymentService.SetTransactionRepository(transactionRepo)
+	loanPaymentService.SetTransactionService(transactionService)
 	wishlistService := service.NewWishlistService(wishlistRepo)
 	wishlistItemService := service.NewWishlistItemService(wishlistItemRepo, wishlistRepo)
 	wishlistPriceService := service.NewWishlistPriceService(wishlistPriceRepo, wishlistItemRepo)
 	wishlistNoteService := service.NewWishlistNoteService(wishlistNoteRepo, wishlistItemRepo)
 	imageService := service.NewImageService(imageRepo)
 	apiTokenService := service.NewAPITokenService(apiTokenRepo)
+	searchService := service.NewSearchService(transactionRepo, loanRepo, budgetCategoryRepo)
 
 	// Link image service for cleanup on delete
 	wishlistNoteService.SetImageService(imageService)
@@ -150,13 +186,19 @@ func main() {
 	workspaceProvider := &workspaceProviderAdapter{authService: authService}
 
 	// Initialize JWT auth middleware
-	jwtAuthMiddleware, err := middleware.NewAuthMiddleware(cfg.Auth0Domain, cfg.Auth0Audience, workspaceProvider)
+	jwtAuthMiddleware, err := middleware.NewAuthMiddleware(cfg.Auth0Domain, cfg.Auth0Audience, workspaceProvider, middleware.JWKSOptions{
+		CacheTTL:        cfg.JWKSCacheTTL,
+		RefreshInterval: cfg.JWKSRefreshInterval,
+		MaxStale:        cfg.JWKSMaxStale,
+	})
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create JWT auth middleware")
 	}
+	defer jwtAuthMiddleware.Stop()
 
 	// Initialize API token auth middleware
 	apiTokenAuthMiddleware := middleware.NewAPITokenAuthMiddleware(apiTokenService)
+	apiTokenAuthMiddleware.SetRoleProvider(workspaceRepo)
 
 	// Initialize dual auth middleware (supports both JWT and API token)
 	dualAuthMiddleware := middleware.NewDualAuthMiddleware(jwtAuthMiddleware, apiTokenAuthMiddleware)
@@ -183,6 +225,7 @@ func main() {
 	authHandler := handler.NewAuthHandler(authService)
 	profileHandler := handler.NewProfileHandler(profileService)
 	accountHandler := handler.NewAccountHandler(accountService, calculationService)
+	accountGroupHandler := handler.NewAccountGroupHandler(accountGroupService)
 	transactionHandler := handler.NewTransactionHandler(transactionService)
 	transactionHandler.SetTransactionGroupService(transactionGroupService)
 	monthHandler := handler.NewMonthHandler(monthService)
@@ -202,17 +245,30 @@ func main() {
 	imageHandler := handler.NewImageHandler(imageService)
 	wsHandler := handler.NewWebSocketHandler(wsHub, wsJWTValidator, cfg.CORSOrigins)
 	transactionGroupHandler := handler.NewTransactionGroupHandler(transactionGroupService)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceService)
+	searchHandler := handler.NewSearchHandler(searchService)
 	apiTokenHandler := handler.NewAPITokenHandler(apiTokenService, authService)
 
 	// Initialize projection sync service for daily background sync
 	projectionSyncService := service.NewProjectionSyncService(recurringTemplateRepo, transactionRepo)
 	projectionSyncService.SetExclusionRepository(exclusionRepo)
+	projectionSyncService.SetBudgetCategoryRepository(budgetCategoryRepo)
 	projectionSyncService.SetEventPublisher(wsHub)
 
 	// Start projection sync goroutine with context for graceful shutdown
 	projectionCtx, projectionCancel := context.WithCancel(context.Background())
 	go startProjectionSync(projectionCtx, projectionSyncService)
 
+	// Start daily promotion of due scheduled transactions
+	scheduledCtx, scheduledCancel := context.WithCancel(context.Background())
+	go startScheduledPromotion(scheduledCtx, transactionService)
+
+	// Start daily auto-close of stale months when configured
+	monthCloseCtx, monthCloseCancel := context.WithCancel(context.Background())
+	if cfg.MonthAutoCloseAfter > 0 {
+		go startMonthAutoClose(monthCloseCtx, monthService, cfg.MonthAutoCloseAfter)
+	}
+
 	// Create Echo instance
 	e := echo.New()
 	e.HideBanner = true
@@ -225,7 +281,7 @@ func main() {
 	e.Use(echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
 		AllowOrigins:     cfg.CORSOrigins,
 		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, middleware.WorkspaceHeader},
 		AllowCredentials: true,
 		MaxAge:           86400,
 	}))
@@ -246,10 +302,11 @@ func main() {
 	// Recovery middleware
 	e.Use(echomiddleware.Recover())
 
+	// Bound database work per request
+	e.Use(middleware.DBTimeout(cfg.DBQueryTimeout))
+
 	// Health check endpoint
-	e.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
-	})
+	e.GET("/health", handler.Health)
 
 	// Swagger API documentation
 	// Override default doc.json with OpenAPI 3.0 spec (multiple servers)
@@ -258,11 +315,11 @@ func main() {
 	e.GET("/api/docs/*", echoSwagger.WrapHandler)
 
 	// Register API routes
-	handler.RegisterRoutes(e, dualAuthMiddleware, rateLimiter, authHandler, profileHandler, accountHandler, transactionHandler, monthHandler, dashboardHandler, budgetCategoryHandler, budgetHandler, ccHandler, recurringTemplateHandler, loanProviderHandler, loanHandler, loanPaymentHandler, wishlistHandler, wishlistItemHandler, wishlistPriceHandler, wishlistNoteHandler, imageHandler, wsHandler, apiTokenHandler, settlementHandler, transactionGroupHandler)
+	handler.RegisterRoutes(e, dualAuthMiddleware, rateLimiter, authHandler, profileHandler, accountHandler, accountGroupHandler, transactionHandler, monthHandler, dashboardHandler, budgetCategoryHandler, budgetHandler, ccHandler, recurringTemplateHandler, loanProviderHandler, loanHandler, loanPaymentHandler, wishlistHandler, wishlistItemHandler, wishlistPriceHandler, wishlistNoteHandler, imageHandler, wsHandler, apiTokenHandler, settlementHandler, transactionGroupHandler, workspaceHandler, searchHandler)
 
 	// Start server in goroutine
 	go func() {
-		log.Info().Str("port", cfg.Port).Msg("Starting server")
+		log.Info().Str("port", cfg.Port).Str("version", version.Get().Version).Msg("Starting server")
 		if err := e.Start(":" + cfg.Port); err != nil && err != http.ErrServerClosed {
 			log.Fatal().Err(err).Msg("Server failed")
 		}
@@ -275,8 +332,10 @@ func main() {
 
 	log.Info().Msg("Shutting down server...")
 
-	// Stop background projection sync goroutine
+	// Stop background projection sync and scheduled promotion goroutines
 	projectionCancel()
+	scheduledCancel()
+	monthCloseCancel()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -318,6 +377,64 @@ func startProjectionSync(ctx context.Context, syncService *service.ProjectionSyn
 	}
 }
 
+// startScheduledPromotion activates due scheduled transactions on startup and every 24 hours
+func startScheduledPromotion(ctx context.Context, transactionService *service.TransactionService) {
+	promote := func() {
+		count, err := transactionService.PromoteAllScheduled()
+		if err != nil {
+			log.Error().Err(err).Msg("Scheduled transaction promotion failed")
+			return
+		}
+		log.Info().Int64("promoted", count).Msg("Scheduled transaction promotion completed")
+	}
+
+	// Run immediately on startup
+	promote()
+
+	// Run every 24 hours
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Scheduled promotion goroutine stopping")
+			return
+		case <-ticker.C:
+			promote()
+		}
+	}
+}
+
+// startMonthAutoClose closes months older than olderThanMonths on startup and every 24 hours
+func startMonthAutoClose(ctx context.Context, monthService *service.MonthService, olderThanMonths int) {
+	autoClose := func() {
+		count, err := monthService.AutoCloseAllStaleMonths(olderThanMonths)
+		if err != nil {
+			log.Error().Err(err).Msg("Month auto-close failed")
+			return
+		}
+		log.Info().Int64("closed", count).Int("older_than_months", olderThanMonths).Msg("Month auto-close completed")
+	}
+
+	// Run immediately on startup
+	autoClose()
+
+	// Run every 24 hours
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Month auto-close goroutine stopping")
+			return
+		case <-ticker.C:
+			autoClose()
+		}
+	}
+}
+
 // workspaceProviderAdapter adapts AuthService to middleware.WorkspaceProvider
 type workspaceProviderAdapter struct {
 	authService *service.AuthService
@@ -332,6 +449,20 @@ func (a *workspaceProviderAdapter) GetWorkspaceByAuth0ID(auth0ID string) (int32,
 	return workspace.ID, nil
 }
 
+// GetSelectedWorkspace implements middleware.WorkspaceProvider
+func (a *workspaceProviderAdapter) GetSelectedWorkspace(auth0ID string, workspaceID int32) (int32, error) {
+	workspace, err := a.authService.GetSelectedWorkspace(auth0ID, workspaceID)
+	if err != nil {
+		return 0, err
+	}
+	return workspace.ID, nil
+}
+
+// GetWorkspaceRole implements middleware.WorkspaceProvider
+func (a *workspaceProviderAdapter) GetWorkspaceRole(auth0ID string, workspaceID int32) (domain.WorkspaceRole, error) {
+	return a.authService.GetWorkspaceRole(auth0ID, workspaceID)
+}
+
 // zerologMiddleware returns a middleware that logs requests using zerolog
 func zerologMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
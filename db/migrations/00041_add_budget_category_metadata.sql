@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add optional cosmetic metadata to budget categories (used by clients for charts)
+ALTER TABLE budget_categories ADD COLUMN description TEXT NULL;
+ALTER TABLE budget_categories ADD COLUMN color VARCHAR(7) NULL;
+
+-- Color must be a #RRGGBB hex string when present
+ALTER TABLE budget_categories ADD CONSTRAINT chk_budget_categories_color
+    CHECK (color IS NULL OR color ~ '^#[0-9A-Fa-f]{6}$');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE budget_categories DROP CONSTRAINT IF EXISTS chk_budget_categories_color;
+ALTER TABLE budget_categories DROP COLUMN IF EXISTS color;
+ALTER TABLE budget_categories DROP COLUMN IF EXISTS description;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users may now own more than one workspace; the earliest one remains their default
+ALTER TABLE workspaces DROP CONSTRAINT IF EXISTS workspaces_user_id_key;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces ADD CONSTRAINT workspaces_user_id_key UNIQUE (user_id);
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Workspace sharing: members link users to workspaces with a role
+CREATE TABLE workspace_members (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_workspace_members_role CHECK (role IN ('owner', 'editor', 'viewer')),
+    UNIQUE(workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- Existing workspace creators become owners
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, user_id, 'owner' FROM workspaces;
+
+-- Pending invitations, accepted via a single-use token
+CREATE TABLE workspace_invites (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_workspace_invites_role CHECK (role IN ('editor', 'viewer'))
+);
+
+CREATE INDEX idx_workspace_invites_workspace_id ON workspace_invites(workspace_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workspace_invites;
+DROP TABLE IF EXISTS workspace_members;
+-- +goose StatementEnd
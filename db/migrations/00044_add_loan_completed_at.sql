@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Records when a loan was fully paid, which may be before its last scheduled month
+ALTER TABLE loans ADD COLUMN completed_at TIMESTAMPTZ NULL;
+
+-- Backfill loans whose transactions are already all paid
+UPDATE loans l
+SET completed_at = NOW()
+WHERE l.deleted_at IS NULL
+  AND EXISTS (SELECT 1 FROM transactions t WHERE t.loan_id = l.id AND t.deleted_at IS NULL)
+  AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.loan_id = l.id AND t.deleted_at IS NULL AND t.is_paid = false);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE loans DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
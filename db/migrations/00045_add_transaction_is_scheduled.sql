@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Future-dated transactions that should not affect balances until their date
+ALTER TABLE transactions ADD COLUMN is_scheduled BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN transactions.is_scheduled IS 'Future-dated transaction excluded from balances until its date passes or it is promoted.';
+
+CREATE INDEX idx_transactions_is_scheduled ON transactions(workspace_id, transaction_date) WHERE is_scheduled = true AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_is_scheduled;
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_scheduled;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Date a transaction was actually settled, recorded when marking it paid
+ALTER TABLE transactions ADD COLUMN paid_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN transactions.paid_at IS 'When the transaction was actually paid; may predate the time it was marked paid.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS paid_at;
+-- +goose StatementEnd
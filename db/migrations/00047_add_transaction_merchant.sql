@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Normalized merchant, kept separate from the free-text transaction name
+ALTER TABLE transactions ADD COLUMN merchant VARCHAR(255) NULL;
+
+COMMENT ON COLUMN transactions.merchant IS 'Normalized merchant used for spend reporting; falls back to name when NULL.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS merchant;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Links a reversal transfer to the transfer pair it reverses
+ALTER TABLE transactions ADD COLUMN reverses_transfer_pair_id UUID NULL;
+
+COMMENT ON COLUMN transactions.reverses_transfer_pair_id IS 'Transfer pair reversed by this counter-transfer; the original is kept for audit.';
+
+-- A transfer can be reversed at most once (one expense and one income leg per reversal)
+CREATE UNIQUE INDEX idx_transactions_transfer_reversal ON transactions(reverses_transfer_pair_id, type) WHERE reverses_transfer_pair_id IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_transfer_reversal;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reverses_transfer_pair_id;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Account groups: user-defined folders for organizing accounts (e.g. "Personal", "Business")
+CREATE TABLE account_groups (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL
+);
+
+-- Unique name per workspace for active (non-deleted) groups
+CREATE UNIQUE INDEX idx_account_groups_unique_name
+ON account_groups(workspace_id, name)
+WHERE deleted_at IS NULL;
+
+-- Accounts without a group fall into the default (ungrouped) bucket
+ALTER TABLE accounts ADD COLUMN account_group_id INTEGER NULL REFERENCES account_groups(id);
+
+CREATE INDEX idx_accounts_account_group
+ON accounts(account_group_id)
+WHERE account_group_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_accounts_account_group;
+ALTER TABLE accounts DROP COLUMN IF EXISTS account_group_id;
+DROP INDEX IF EXISTS idx_account_groups_unique_name;
+DROP TABLE IF EXISTS account_groups;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Marks an income transaction as a refund of an expense
+ALTER TABLE transactions ADD COLUMN is_refund BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN transactions.is_refund IS 'Refunds are stored as income but reduce expense totals instead of counting as income.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_refund;
+-- +goose StatementEnd
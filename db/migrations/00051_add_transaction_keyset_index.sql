@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Supports cursor pagination on (transaction_date, id) without an offset scan
+CREATE INDEX idx_transactions_keyset ON transactions(workspace_id, transaction_date, id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_keyset;
+-- +goose StatementEnd
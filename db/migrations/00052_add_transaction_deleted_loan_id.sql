@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Remembers which loan a transaction was unlinked from when that loan was deleted, so a restore can re-link it
+ALTER TABLE transactions ADD COLUMN deleted_loan_id INTEGER NULL REFERENCES loans(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_deleted_loan_id ON transactions(deleted_loan_id) WHERE deleted_loan_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_deleted_loan_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS deleted_loan_id;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Flat monthly processing fee charged by some providers on top of each loan payment
+ALTER TABLE loan_providers ADD COLUMN monthly_fee NUMERIC(12,2) NOT NULL DEFAULT 0.00;
+ALTER TABLE loan_providers ADD CONSTRAINT monthly_fee_non_negative CHECK (monthly_fee >= 0);
+
+-- How the fee appears in generated loan transactions:
+-- 'separate' adds a fee line per month, 'folded' adds it to the monthly payment
+ALTER TABLE loan_providers ADD COLUMN fee_mode TEXT NOT NULL DEFAULT 'separate';
+ALTER TABLE loan_providers ADD CONSTRAINT fee_mode_check
+    CHECK (fee_mode IN ('separate', 'folded'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE loan_providers DROP CONSTRAINT IF EXISTS fee_mode_check;
+ALTER TABLE loan_providers DROP COLUMN IF EXISTS fee_mode;
+ALTER TABLE loan_providers DROP CONSTRAINT IF EXISTS monthly_fee_non_negative;
+ALTER TABLE loan_providers DROP COLUMN IF EXISTS monthly_fee;
+-- +goose StatementEnd
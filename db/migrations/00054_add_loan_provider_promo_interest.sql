@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Promotional interest (e.g. 0% for the first N months) applied before the default rate
+ALTER TABLE loan_providers ADD COLUMN promo_interest_rate NUMERIC(5,2) NOT NULL DEFAULT 0.00;
+ALTER TABLE loan_providers ADD COLUMN promo_months INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_providers ADD CONSTRAINT promo_months_non_negative CHECK (promo_months >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE loan_providers DROP CONSTRAINT IF EXISTS promo_months_non_negative;
+ALTER TABLE loan_providers DROP COLUMN IF EXISTS promo_months;
+ALTER TABLE loan_providers DROP COLUMN IF EXISTS promo_interest_rate;
+-- +goose StatementEnd
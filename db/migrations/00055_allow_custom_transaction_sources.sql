@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Allow custom transaction sources (e.g. 'import') beyond the built-in ones
+-- Known sources are validated in the application layer instead
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_source;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Restore the built-in constraint (will fail if any custom source transactions exist)
+ALTER TABLE transactions ADD CONSTRAINT chk_source
+    CHECK (source IN ('manual', 'recurring', 'loan'));
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Subcategories: a category may have one parent (e.g. Food > Groceries)
+-- Nesting is limited to a single level and enforced in the application layer
+ALTER TABLE budget_categories ADD COLUMN parent_id INTEGER REFERENCES budget_categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_budget_categories_parent_id ON budget_categories(parent_id) WHERE parent_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_budget_categories_parent_id;
+ALTER TABLE budget_categories DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keeps a transaction out of analytical views (e.g. a reimbursed expense)
+ALTER TABLE transactions ADD COLUMN exclude_from_reports BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN transactions.exclude_from_reports IS 'Excluded from insights and spending breakdowns; still counts towards balances.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS exclude_from_reports;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lets clients format amounts for the workspace instead of assuming RM
+ALTER TABLE workspaces ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'MYR';
+
+COMMENT ON COLUMN workspaces.currency IS 'ISO 4217 currency code used to display amounts.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
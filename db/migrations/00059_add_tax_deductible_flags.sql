@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Marks spending that can be claimed as tax relief; categories supply the default for new transactions
+ALTER TABLE transactions ADD COLUMN is_tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE budget_categories ADD COLUMN is_tax_deductible BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_transactions_tax_deductible ON transactions(workspace_id, transaction_date)
+    WHERE is_tax_deductible = true AND deleted_at IS NULL;
+
+COMMENT ON COLUMN transactions.is_tax_deductible IS 'Included in the annual tax report.';
+COMMENT ON COLUMN budget_categories.is_tax_deductible IS 'Default is_tax_deductible for new transactions in this category.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_tax_deductible;
+ALTER TABLE budget_categories DROP COLUMN IF EXISTS is_tax_deductible;
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_tax_deductible;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Interest method new loans inherit from their provider unless the loan overrides it
+ALTER TABLE loan_providers ADD COLUMN default_interest_method VARCHAR(20) NOT NULL DEFAULT 'flat';
+ALTER TABLE loan_providers ADD CONSTRAINT default_interest_method_valid
+    CHECK (default_interest_method IN ('flat', 'reducing_balance'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE loan_providers DROP CONSTRAINT IF EXISTS default_interest_method_valid;
+ALTER TABLE loan_providers DROP COLUMN IF EXISTS default_interest_method;
+-- +goose StatementEnd
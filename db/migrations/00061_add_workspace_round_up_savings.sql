@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Account that receives the round-up of each expense; NULL disables round-up savings
+ALTER TABLE workspaces ADD COLUMN round_up_savings_account_id INTEGER REFERENCES accounts(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN workspaces.round_up_savings_account_id IS 'Savings account credited with each expense rounded up to a whole unit.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces DROP COLUMN IF EXISTS round_up_savings_account_id;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Counterparty who was paid, kept separate from the transaction name and merchant
+ALTER TABLE transactions ADD COLUMN payee VARCHAR(255) NULL;
+
+COMMENT ON COLUMN transactions.payee IS 'Counterparty who was paid; used for spend-by-payee reporting.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS payee;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Flags template-sourced transactions whose amount or category was edited as a one-off
+ALTER TABLE transactions ADD COLUMN modified_from_template BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN transactions.modified_from_template IS 'Set when a template-sourced transaction was edited away from its template amount or category.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS modified_from_template;
+-- +goose StatementEnd
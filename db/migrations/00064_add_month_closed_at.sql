@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Months locked against further edits, set by the auto-close of stale months
+ALTER TABLE months ADD COLUMN closed_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN months.closed_at IS 'When the month was closed; NULL while still open.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE months DROP COLUMN IF EXISTS closed_at;
+-- +goose StatementEnd
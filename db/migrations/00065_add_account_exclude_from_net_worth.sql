@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Accounts (e.g. a shared account) left out of personal net worth
+ALTER TABLE accounts ADD COLUMN exclude_from_net_worth BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN accounts.exclude_from_net_worth IS 'When true the account is still listed but its balance is left out of net worth.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts DROP COLUMN IF EXISTS exclude_from_net_worth;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Optional geo metadata so spending can be mapped
+ALTER TABLE transactions ADD COLUMN latitude NUMERIC(9, 6);
+ALTER TABLE transactions ADD COLUMN longitude NUMERIC(9, 6);
+ALTER TABLE transactions ADD COLUMN location TEXT;
+
+COMMENT ON COLUMN transactions.latitude IS 'Latitude in degrees (-90 to 90); set together with longitude.';
+COMMENT ON COLUMN transactions.longitude IS 'Longitude in degrees (-180 to 180); set together with latitude.';
+COMMENT ON COLUMN transactions.location IS 'Free-form place name, with or without coordinates.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS location;
+ALTER TABLE transactions DROP COLUMN IF EXISTS longitude;
+ALTER TABLE transactions DROP COLUMN IF EXISTS latitude;
+-- +goose StatementEnd
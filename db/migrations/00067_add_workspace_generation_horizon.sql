@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How many months past the current one recurring generation covers; 0 keeps it to the current month
+ALTER TABLE workspaces ADD COLUMN generation_horizon_months INTEGER NOT NULL DEFAULT 0
+    CHECK (generation_horizon_months BETWEEN 0 AND 12);
+
+COMMENT ON COLUMN workspaces.generation_horizon_months IS 'Months ahead of the current month that recurring projections are generated for (0-12).';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces DROP COLUMN IF EXISTS generation_horizon_months;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Links each round-up transfer leg to the expense it rounds up, so edits and deletes can follow it
+ALTER TABLE transactions ADD COLUMN round_up_source_id INTEGER REFERENCES transactions(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_transactions_round_up_source_id ON transactions(round_up_source_id) WHERE round_up_source_id IS NOT NULL;
+
+COMMENT ON COLUMN transactions.round_up_source_id IS 'Expense whose round-up this transfer leg moves to savings, if any.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_round_up_source_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS round_up_source_id;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Marks a provider's separate monthly fee line so it is not counted as a loan payment
+ALTER TABLE transactions ADD COLUMN is_loan_fee BOOLEAN NOT NULL DEFAULT false;
+
+-- Fee lines generated so far are named after the item with a " (fee)" suffix
+UPDATE transactions SET is_loan_fee = true
+WHERE loan_id IS NOT NULL AND name LIKE '% (fee)';
+
+COMMENT ON COLUMN transactions.is_loan_fee IS 'True for the separate monthly fee line generated alongside a loan payment.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_loan_fee;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keeps the down payment on the loan so the item's full price is total_amount + down_payment_amount
+ALTER TABLE loans ADD COLUMN down_payment_amount NUMERIC(12,2) NOT NULL DEFAULT 0;
+
+-- Down payments recorded so far are unlinked expenses named after the item with a " (down payment)" suffix
+UPDATE transactions t
+SET loan_id = l.id,
+    source = 'loan_down_payment',
+    updated_at = NOW()
+FROM loans l
+WHERE t.workspace_id = l.workspace_id
+  AND t.loan_id IS NULL
+  AND t.deleted_at IS NULL
+  AND t.name = l.item_name || ' (down payment)'
+  AND t.transaction_date = l.purchase_date;
+
+UPDATE loans l
+SET down_payment_amount = t.amount
+FROM transactions t
+WHERE t.loan_id = l.id
+  AND t.source = 'loan_down_payment'
+  AND t.deleted_at IS NULL;
+
+COMMENT ON COLUMN loans.down_payment_amount IS 'Paid at purchase outside the installments; total_amount is the financed remainder.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE transactions SET loan_id = NULL, source = 'manual'
+WHERE source = 'loan_down_payment';
+
+ALTER TABLE loans DROP COLUMN IF EXISTS down_payment_amount;
+-- +goose StatementEnd
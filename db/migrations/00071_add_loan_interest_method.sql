@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Interest method the loan was created with, so regenerating its schedule keeps the same payments
+ALTER TABLE loans ADD COLUMN interest_method VARCHAR(20) NOT NULL DEFAULT 'flat';
+ALTER TABLE loans ADD CONSTRAINT interest_method_valid
+    CHECK (interest_method IN ('flat', 'reducing_balance'));
+
+-- Overrides were never stored, so existing loans take their provider's default
+UPDATE loans l
+SET interest_method = lp.default_interest_method
+FROM loan_providers lp
+WHERE lp.id = l.provider_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE loans DROP CONSTRAINT IF EXISTS interest_method_valid;
+ALTER TABLE loans DROP COLUMN IF EXISTS interest_method;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- name: CreateAccountGroup :one
+INSERT INTO account_groups (workspace_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetAccountGroupByID :one
+SELECT * FROM account_groups
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
+
+-- name: GetAccountGroupsByWorkspace :many
+SELECT * FROM account_groups
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY name ASC;
+
+-- name: UpdateAccountGroup :one
+UPDATE account_groups
+SET name = $3, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteAccountGroup :exec
+UPDATE account_groups
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
@@ -1,6 +1,6 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (workspace_id, name, account_type, template, initial_balance)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO accounts (workspace_id, name, account_type, template, initial_balance, exclude_from_net_worth)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetAccountByID :one
@@ -66,3 +66,34 @@ WHERE a.workspace_id = $1
     AND a.deleted_at IS NULL
 GROUP BY a.id, a.name
 ORDER BY a.name;
+
+-- name: SetAccountGroup :one
+-- Assign an account to a group (NULL moves it to the ungrouped bucket)
+UPDATE accounts
+SET account_group_id = $3, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SetAccountExcludeFromNetWorth :one
+-- Include or exclude an account's balance from net worth
+UPDATE accounts
+SET exclude_from_net_worth = $3, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UngroupAccountsByGroup :exec
+-- Move all accounts of a group to the ungrouped bucket (used when deleting a group)
+UPDATE accounts
+SET account_group_id = NULL, updated_at = NOW()
+WHERE workspace_id = $1 AND account_group_id = $2;
+
+-- name: CountAccountTransactions :one
+-- Count every transaction on an account, including soft-deleted ones that can still be restored
+SELECT COUNT(*) FROM transactions
+WHERE workspace_id = $1 AND account_id = $2;
+
+-- name: UpdateAccountTemplate :one
+UPDATE accounts
+SET template = $3, account_type = $4, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING *;
@@ -34,26 +34,28 @@ ORDER BY bc.name ASC;
 
 -- name: GetSpendingByCategory :many
 -- Returns total spending per category for a specific month
+-- Refunds are netted out of the category's spending; transactions excluded from reports are skipped
 SELECT
     t.category_id,
-    COALESCE(SUM(t.amount), 0) AS spent
+    COALESCE(SUM(CASE WHEN t.is_refund THEN -t.amount ELSE t.amount END), 0) AS spent
 FROM transactions t
 WHERE t.workspace_id = @workspace_id
     AND t.category_id IS NOT NULL
-    AND t.type = 'expense'
+    AND (t.type = 'expense' OR t.is_refund = true)
     AND t.deleted_at IS NULL
+    AND t.exclude_from_reports = false
     AND EXTRACT(YEAR FROM t.transaction_date) = @year::int
     AND EXTRACT(MONTH FROM t.transaction_date) = @month::int
 GROUP BY t.category_id;
 
 -- name: GetCategoryTransactions :many
--- Returns all transactions for a specific category in a month
+-- Returns all transactions for a specific category in a month (including refunds)
 SELECT t.*, a.name AS account_name
 FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = @workspace_id
     AND t.category_id = @category_id
-    AND t.type = 'expense'
+    AND (t.type = 'expense' OR t.is_refund = true)
     AND t.deleted_at IS NULL
     AND EXTRACT(YEAR FROM t.transaction_date) = @year::int
     AND EXTRACT(MONTH FROM t.transaction_date) = @month::int
@@ -1,6 +1,6 @@
 -- name: CreateBudgetCategory :one
-INSERT INTO budget_categories (workspace_id, name)
-VALUES ($1, $2)
+INSERT INTO budget_categories (workspace_id, name, description, color, parent_id, is_tax_deductible)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetBudgetCategoryByID :one
@@ -14,7 +14,7 @@ ORDER BY name ASC;
 
 -- name: UpdateBudgetCategory :one
 UPDATE budget_categories
-SET name = $3, updated_at = NOW()
+SET name = $3, description = $4, color = $5, parent_id = $6, is_tax_deductible = $7, updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 RETURNING *;
 
@@ -23,6 +23,12 @@ UPDATE budget_categories
 SET deleted_at = NOW(), updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
 
+-- name: DetachBudgetCategoryChildren :exec
+-- Promotes a deleted category's subcategories to top level
+UPDATE budget_categories
+SET parent_id = NULL, updated_at = NOW()
+WHERE workspace_id = $1 AND parent_id = $2;
+
 -- name: CountTransactionsByCategory :one
 -- Count transactions assigned to a specific category
 SELECT COUNT(*)::bigint AS count
@@ -34,3 +40,25 @@ WHERE workspace_id = $1
 -- name: GetBudgetCategoryByName :one
 SELECT * FROM budget_categories
 WHERE workspace_id = $1 AND name = $2 AND deleted_at IS NULL;
+
+-- name: GetBudgetCategoriesWithUsage :many
+-- Every category with its all-time transaction count and net spend; unused categories report zero.
+-- Refunds are netted out of spend and projections are not counted as spend.
+SELECT
+    bc.*,
+    COUNT(t.id)::bigint AS transaction_count,
+    COALESCE(SUM(
+        CASE
+            WHEN t.is_projected THEN 0
+            WHEN t.is_refund THEN -t.amount
+            WHEN t.type = 'expense' THEN t.amount
+            ELSE 0
+        END
+    ), 0)::NUMERIC(12,2) AS total_spent
+FROM budget_categories bc
+LEFT JOIN transactions t ON t.category_id = bc.id
+    AND t.workspace_id = bc.workspace_id
+    AND t.deleted_at IS NULL
+WHERE bc.workspace_id = $1 AND bc.deleted_at IS NULL
+GROUP BY bc.id
+ORDER BY bc.name ASC;
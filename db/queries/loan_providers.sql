@@ -3,9 +3,14 @@ INSERT INTO loan_providers (
     workspace_id,
     name,
     cutoff_day,
-    default_interest_rate
+    default_interest_rate,
+    monthly_fee,
+    fee_mode,
+    promo_interest_rate,
+    promo_months,
+    default_interest_method
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetLoanProviderByID :one
@@ -24,6 +29,11 @@ SET
     cutoff_day = $4,
     default_interest_rate = $5,
     payment_mode = COALESCE(NULLIF(@payment_mode::text, ''), payment_mode),
+    monthly_fee = @monthly_fee,
+    fee_mode = COALESCE(NULLIF(@fee_mode::text, ''), fee_mode),
+    promo_interest_rate = @promo_interest_rate,
+    promo_months = @promo_months,
+    default_interest_method = COALESCE(NULLIF(@default_interest_method::text, ''), default_interest_method),
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 RETURNING *;
@@ -12,9 +12,11 @@ INSERT INTO loans (
     first_payment_month,
     account_id,
     settlement_intent,
-    notes
+    notes,
+    down_payment_amount,
+    interest_method
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 RETURNING *;
 
@@ -23,14 +25,34 @@ SELECT * FROM loans
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL;
 
 -- name: ListLoans :many
+-- Provider name is joined so callers can label loans without a second lookup
+SELECT l.*, lp.name AS provider_name
+FROM loans l
+JOIN loan_providers lp ON lp.id = l.provider_id
+WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
+ORDER BY l.created_at DESC;
+
+-- name: GetDeletedLoanByID :one
 SELECT * FROM loans
-WHERE workspace_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC;
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedLoans :many
+-- Soft-deleted loans (trash), most recently deleted first
+SELECT * FROM loans
+WHERE workspace_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreLoan :one
+UPDATE loans
+SET deleted_at = NULL, updated_at = NOW()
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+RETURNING *;
 
 -- name: ListActiveLoans :many
 SELECT l.* FROM loans l
 WHERE l.workspace_id = $1
   AND l.deleted_at IS NULL
+  AND l.completed_at IS NULL
   AND (
     -- Loan is active if there are remaining payments
     -- Current month is before or equal to last payment month
@@ -49,8 +71,10 @@ SELECT l.* FROM loans l
 WHERE l.workspace_id = $1
   AND l.deleted_at IS NULL
   AND (
-    -- Loan is completed if current month is past the last payment month
-    (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) < $2
+    -- Loan is completed once fully paid, even ahead of schedule
+    l.completed_at IS NOT NULL
+    -- Otherwise completed if current month is past the last payment month
+    OR (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) < $2
     OR (
       (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) = $2
       AND (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1) < $3
@@ -58,6 +82,19 @@ WHERE l.workspace_id = $1
   )
 ORDER BY l.created_at DESC;
 
+-- name: ListLoansWithoutSchedule :many
+-- Loans that never had a scheduled transaction. Soft-deleted rows still count, so a fully
+-- refunded loan is not mistaken for one that was imported without a schedule
+SELECT l.* FROM loans l
+WHERE l.workspace_id = $1
+  AND l.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM transactions t
+    WHERE t.loan_id = l.id
+      AND t.source <> 'loan_down_payment'
+  )
+ORDER BY l.created_at;
+
 -- name: UpdateLoan :one
 UPDATE loans
 SET item_name = $3,
@@ -93,6 +130,12 @@ SET item_name = $3,
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SetLoanCompletedAt :exec
+-- Records (or clears, when NULL) the time a loan became fully paid
+UPDATE loans
+SET completed_at = $3, updated_at = NOW()
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL;
+
 -- name: DeleteLoan :exec
 UPDATE loans
 SET deleted_at = NOW(), updated_at = NOW()
@@ -101,6 +144,7 @@ WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL;
 -- name: CountActiveLoansByProvider :one
 SELECT COUNT(*) FROM loans
 WHERE provider_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+  AND completed_at IS NULL
   AND (
     (first_payment_year + ((first_payment_month - 1 + num_months - 1) / 12)) > $3
     OR (
@@ -113,6 +157,7 @@ WHERE provider_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 
 -- name: GetLoansWithStats :many
 -- Get all loans with payment stats calculated from transactions
+-- Soft-deleted loans are listed only when include_deleted is set
 SELECT
     l.id,
     l.workspace_id,
@@ -131,16 +176,20 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     -- Calculated last payment month/year
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
     -- Payment stats from transactions
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
-WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
+WHERE l.workspace_id = @workspace_id
+  AND (l.deleted_at IS NULL OR @include_deleted::BOOLEAN)
 GROUP BY l.id
 ORDER BY l.created_at DESC;
 
@@ -164,16 +213,19 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
 GROUP BY l.id
-HAVING COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) > 0
+HAVING l.completed_at IS NULL AND COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) > 0
 ORDER BY l.created_at DESC;
 
 -- name: GetCompletedLoansWithStats :many
@@ -196,16 +248,19 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
 GROUP BY l.id
-HAVING COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) = 0
+HAVING l.completed_at IS NOT NULL OR COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) = 0
 ORDER BY l.created_at DESC;
 
 -- name: GetLoansWithStatsByProvider :many
@@ -229,13 +284,16 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.provider_id = $2 AND l.deleted_at IS NULL
 GROUP BY l.id
 ORDER BY (COUNT(t.id) FILTER (WHERE t.is_paid = false) > 0) DESC, l.item_name ASC;
@@ -22,3 +22,14 @@ ORDER BY year DESC, month DESC;
 UPDATE months
 SET starting_balance = $3, updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2;
+
+-- name: CloseMonth :execrows
+UPDATE months
+SET closed_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND closed_at IS NULL;
+
+-- name: CloseMonthsBefore :execrows
+-- Close every open month, across workspaces, whose year * 12 + month is below the index
+UPDATE months
+SET closed_at = NOW(), updated_at = NOW()
+WHERE closed_at IS NULL AND year * 12 + month < @month_index::int;
@@ -12,6 +12,10 @@ SELECT EXISTS(
 -- name: DeleteExclusionsByTemplate :exec
 DELETE FROM projection_exclusions WHERE template_id = $1;
 
+-- name: DeleteProjectionExclusion :exec
+DELETE FROM projection_exclusions
+WHERE workspace_id = $1 AND template_id = $2 AND excluded_month = $3;
+
 -- name: GetExclusionsByTemplate :many
 SELECT * FROM projection_exclusions
 WHERE workspace_id = $1 AND template_id = $2
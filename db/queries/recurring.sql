@@ -23,8 +23,12 @@ SELECT * FROM recurring_templates
 WHERE id = $1 AND workspace_id = $2;
 
 -- name: ListRecurringTemplatesByWorkspace :many
+-- Null filters are ignored; active compares against the same end_date rule as GetActiveRecurringTemplates
 SELECT * FROM recurring_templates
-WHERE workspace_id = $1
+WHERE workspace_id = @workspace_id
+  AND (sqlc.narg('account_id')::INTEGER IS NULL OR account_id = sqlc.narg('account_id'))
+  AND (sqlc.narg('category_id')::INTEGER IS NULL OR category_id = sqlc.narg('category_id'))
+  AND (sqlc.narg('active')::BOOLEAN IS NULL OR (end_date IS NULL OR end_date >= CURRENT_DATE) = sqlc.narg('active'))
 ORDER BY created_at DESC;
 
 -- name: GetActiveRecurringTemplates :many
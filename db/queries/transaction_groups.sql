@@ -74,6 +74,11 @@ SELECT COUNT(*)::INTEGER as child_count
 FROM transactions
 WHERE group_id = $1 AND workspace_id = $2 AND deleted_at IS NULL;
 
+-- name: GetGroupChildren :many
+SELECT * FROM transactions
+WHERE group_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC;
+
 -- name: GetAutoDetectedGroupByProviderMonth :one
 SELECT tg.id, tg.workspace_id, tg.name, tg.month,
        tg.auto_detected, tg.loan_provider_id,
@@ -96,6 +101,7 @@ JOIN loan_providers lp ON l.provider_id = lp.id
 WHERE t.workspace_id = @workspace_id
   AND lp.payment_mode = 'consolidated_monthly'
   AND t.group_id IS NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND TO_CHAR(t.transaction_date, 'YYYY-MM') = @month::TEXT
 GROUP BY lp.id, lp.name
@@ -108,5 +114,6 @@ JOIN loans l ON t.loan_id = l.id
 WHERE t.workspace_id = @workspace_id
   AND l.provider_id = @provider_id
   AND t.group_id IS NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND TO_CHAR(t.transaction_date, 'YYYY-MM') = @month::TEXT;
@@ -3,9 +3,13 @@ INSERT INTO transactions (
     workspace_id, account_id, name, amount, type,
     transaction_date, is_paid, notes, transfer_pair_id, category_id, is_cc_payment,
     billed_at, settlement_intent,
-    source, template_id, is_projected, loan_id
+    source, template_id, is_projected, loan_id, is_scheduled, merchant,
+    reverses_transfer_pair_id, is_refund, exclude_from_reports, is_tax_deductible, payee,
+    latitude, longitude, location, round_up_source_id, is_loan_fee, paid_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+    $25, $26, $27, $28, $29,
+    CASE WHEN $7::BOOLEAN THEN NOW() END
 ) RETURNING *;
 
 -- name: GetTransactionByID :one
@@ -26,15 +30,30 @@ LIMIT $6 OFFSET $7;
 -- name: CountTransactionsByWorkspace :one
 SELECT COUNT(*) FROM transactions
 WHERE workspace_id = @workspace_id
-  AND deleted_at IS NULL
+  AND (deleted_at IS NULL OR @include_deleted::BOOLEAN)
   AND (sqlc.narg('account_id')::INTEGER IS NULL OR account_id = sqlc.narg('account_id'))
   AND (sqlc.narg('start_date')::DATE IS NULL OR transaction_date >= sqlc.narg('start_date'))
   AND (sqlc.narg('end_date')::DATE IS NULL OR transaction_date <= sqlc.narg('end_date'))
-  AND (sqlc.narg('type')::VARCHAR IS NULL OR type = sqlc.narg('type'));
+  AND (sqlc.narg('type')::VARCHAR IS NULL OR type = sqlc.narg('type'))
+  AND (sqlc.narg('source')::TEXT IS NULL OR source = sqlc.narg('source'))
+  AND (sqlc.narg('paid_start')::DATE IS NULL OR paid_at >= sqlc.narg('paid_start'))
+  AND (sqlc.narg('paid_end')::DATE IS NULL OR paid_at < sqlc.narg('paid_end')::DATE + 1)
+  AND (sqlc.narg('has_location')::BOOLEAN IS NULL OR ((latitude IS NOT NULL OR location IS NOT NULL) = sqlc.narg('has_location')));
+
+-- name: ListTransactionsByCursor :many
+-- Keyset page ordered by (transaction_date, id) for large exports; null after_date starts from the beginning
+SELECT * FROM transactions
+WHERE workspace_id = @workspace_id
+  AND deleted_at IS NULL
+  AND (sqlc.narg('after_date')::DATE IS NULL OR (transaction_date, id) > (sqlc.narg('after_date')::DATE, @after_id::INTEGER))
+ORDER BY transaction_date ASC, id ASC
+LIMIT @row_limit;
 
 -- name: ToggleTransactionPaidStatus :one
 UPDATE transactions
-SET is_paid = NOT is_paid, updated_at = NOW()
+SET is_paid = NOT is_paid,
+    paid_at = CASE WHEN is_paid THEN NULL ELSE NOW() END,
+    updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 RETURNING *;
 
@@ -49,11 +68,22 @@ SET
     notes = $8,
     category_id = $9,
     is_paid = $10,
+    paid_at = CASE WHEN NOT $10::BOOLEAN THEN NULL WHEN is_paid THEN paid_at ELSE NOW() END,
     billed_at = $11,
     settlement_intent = $12,
     source = $13,
     template_id = $14,
     is_projected = $15,
+    is_scheduled = $16,
+    merchant = $17,
+    is_refund = $18,
+    exclude_from_reports = $19,
+    is_tax_deductible = $20,
+    payee = $21,
+    modified_from_template = $22,
+    latitude = $23,
+    longitude = $24,
+    location = $25,
     updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 RETURNING *;
@@ -63,6 +93,17 @@ UPDATE transactions
 SET deleted_at = NOW(), updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
 
+-- name: SoftDeleteTransactionsByIDs :execrows
+UPDATE transactions
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND id = ANY($2::int[]) AND deleted_at IS NULL;
+
+-- name: SoftDeleteRoundUpsBySource :execrows
+-- Removes the round-up transfer legs created for an expense
+UPDATE transactions
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND round_up_source_id = $2 AND deleted_at IS NULL;
+
 -- name: SoftDeleteTransferPair :execrows
 UPDATE transactions
 SET deleted_at = NOW(), updated_at = NOW()
@@ -71,6 +112,7 @@ WHERE workspace_id = $1 AND transfer_pair_id = $2 AND deleted_at IS NULL;
 -- name: GetAccountTransactionSummaries :many
 -- For regular accounts: only count paid transactions
 -- For CC accounts: count all expenses (isPaid means settled, not whether purchase happened)
+-- Scheduled transactions are excluded until their date arrives
 SELECT
     account_id,
     COALESCE(SUM(CASE WHEN type = 'income' AND is_paid = true THEN amount ELSE 0 END), 0) AS sum_income,
@@ -79,28 +121,47 @@ SELECT
     COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) AS sum_all_expenses
 FROM transactions
 WHERE workspace_id = $1 AND deleted_at IS NULL
+  AND NOT (is_scheduled = true AND transaction_date > CURRENT_DATE)
 GROUP BY account_id;
 
+-- name: GetAccountTransactionSummaryAsOf :one
+-- Same sums as GetAccountTransactionSummaries for one account, limited to transactions dated on or before $3
+SELECT
+    COALESCE(SUM(CASE WHEN type = 'income' AND is_paid = true THEN amount ELSE 0 END), 0) AS sum_income,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true THEN amount ELSE 0 END), 0) AS sum_expenses,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = false THEN amount ELSE 0 END), 0) AS sum_unpaid_expenses,
+    COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) AS sum_all_expenses
+FROM transactions
+WHERE workspace_id = $1 AND account_id = $2 AND deleted_at IS NULL
+  AND transaction_date <= $3
+  AND NOT (is_scheduled = true AND transaction_date > CURRENT_DATE);
+
 -- name: SumTransactionsByTypeAndDateRange :one
 -- Only count paid transactions, excludes transfers
-SELECT COALESCE(SUM(amount), 0)::NUMERIC(12,2) as total
+-- Refunds never count as income; they reduce the expense total
+-- A NULL $5 counts every account; otherwise only the listed accounts
+SELECT COALESCE(SUM(CASE WHEN is_refund THEN -amount ELSE amount END), 0)::NUMERIC(12,2) as total
 FROM transactions
 WHERE workspace_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
-  AND type = $4
+  AND ((type = $4 AND is_refund = false) OR (is_refund = true AND $4 = 'expense'))
   AND is_paid = true
   AND transfer_pair_id IS NULL
-  AND deleted_at IS NULL;
+  AND deleted_at IS NULL
+  AND ($5::INTEGER[] IS NULL OR account_id = ANY($5::INTEGER[]));
 
 -- name: GetMonthlyTransactionSummaries :many
 -- Batch query to get income/expense totals grouped by year/month for N+1 prevention
 -- Only count paid transactions, excludes transfers
+-- Refunds never count as income; they reduce the expense total
 SELECT
     EXTRACT(YEAR FROM transaction_date)::INTEGER AS year,
     EXTRACT(MONTH FROM transaction_date)::INTEGER AS month,
-    COALESCE(SUM(CASE WHEN type = 'income' AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_income,
-    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_expenses
+    COALESCE(SUM(CASE WHEN type = 'income' AND is_refund = false AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_income,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true AND transfer_pair_id IS NULL THEN amount
+                      WHEN is_refund = true AND is_paid = true AND transfer_pair_id IS NULL THEN -amount
+                      ELSE 0 END), 0)::NUMERIC(12,2) AS total_expenses
 FROM transactions
 WHERE workspace_id = $1
   AND deleted_at IS NULL
@@ -110,15 +171,18 @@ ORDER BY year DESC, month DESC;
 -- name: SumPaidExpensesByDateRange :one
 -- Sum paid expenses within a date range for in-hand balance calculation
 -- Excludes transfers (they move money between accounts, not actual spending)
-SELECT COALESCE(SUM(amount), 0)::NUMERIC(12,2) as total
+-- Refunds are netted out of spending
+-- A NULL $4 counts every account; otherwise only the listed accounts
+SELECT COALESCE(SUM(CASE WHEN is_refund THEN -amount ELSE amount END), 0)::NUMERIC(12,2) as total
 FROM transactions
 WHERE workspace_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
-  AND type = 'expense'
+  AND (type = 'expense' OR is_refund = true)
   AND is_paid = true
   AND transfer_pair_id IS NULL
-  AND deleted_at IS NULL;
+  AND deleted_at IS NULL
+  AND ($4::INTEGER[] IS NULL OR account_id = ANY($4::INTEGER[]));
 
 -- name: SumUnpaidExpensesByDateRange :one
 -- Sum unpaid expenses within a date range (ALL unpaid, including deferred CC)
@@ -139,6 +203,7 @@ WHERE workspace_id = $1
 -- EXCLUDES deferred CC transactions (those are for next month)
 -- EXCLUDES transfers (they move money between accounts, not actual spending)
 -- Includes: non-CC unpaid expenses + immediate CC expenses
+-- A NULL $4 counts every account; otherwise only the listed accounts
 SELECT COALESCE(SUM(t.amount), 0)::NUMERIC(12,2) as total
 FROM transactions t
 LEFT JOIN accounts a ON t.account_id = a.id
@@ -149,11 +214,13 @@ WHERE t.workspace_id = $1
   AND t.is_paid = false
   AND t.transfer_pair_id IS NULL
   AND t.deleted_at IS NULL
-  AND NOT (a.template = 'credit_card' AND t.settlement_intent = 'deferred');
+  AND NOT (a.template = 'credit_card' AND t.settlement_intent = 'deferred')
+  AND ($4::INTEGER[] IS NULL OR t.account_id = ANY($4::INTEGER[]));
 
 -- name: SumDeferredCCByDateRange :one
 -- Sum deferred CC expenses within a date range
 -- Used for next month projections
+-- A NULL $4 counts every account; otherwise only the listed accounts
 SELECT COALESCE(SUM(t.amount), 0)::NUMERIC(12,2) as total
 FROM transactions t
 JOIN accounts a ON t.account_id = a.id
@@ -164,7 +231,8 @@ WHERE t.workspace_id = $1
   AND t.is_paid = false
   AND t.settlement_intent = 'deferred'
   AND a.template = 'credit_card'
-  AND t.deleted_at IS NULL;
+  AND t.deleted_at IS NULL
+  AND ($4::INTEGER[] IS NULL OR t.account_id = ANY($4::INTEGER[]));
 
 -- name: GetTransactionsWithCategory :many
 -- Returns transactions with category name and group name joined for display
@@ -191,17 +259,33 @@ SELECT
     t.is_projected,
     t.loan_id,
     t.group_id,
+    t.is_scheduled,
+    t.paid_at,
+    t.merchant,
+    t.reverses_transfer_pair_id,
+    t.exclude_from_reports,
+    t.is_tax_deductible,
+    t.payee,
+    t.modified_from_template,
+    t.latitude,
+    t.longitude,
+    t.location,
+    t.is_refund,
     bc.name AS category_name,
     tg.name AS group_name
 FROM transactions t
 LEFT JOIN budget_categories bc ON t.category_id = bc.id AND bc.deleted_at IS NULL
 LEFT JOIN transaction_groups tg ON t.group_id = tg.id
 WHERE t.workspace_id = @workspace_id
-  AND t.deleted_at IS NULL
+  AND (t.deleted_at IS NULL OR @include_deleted::BOOLEAN)
   AND (sqlc.narg('account_id')::INTEGER IS NULL OR t.account_id = sqlc.narg('account_id'))
   AND (sqlc.narg('start_date')::DATE IS NULL OR t.transaction_date >= sqlc.narg('start_date'))
   AND (sqlc.narg('end_date')::DATE IS NULL OR t.transaction_date <= sqlc.narg('end_date'))
   AND (sqlc.narg('type')::VARCHAR IS NULL OR t.type = sqlc.narg('type'))
+  AND (sqlc.narg('source')::TEXT IS NULL OR t.source = sqlc.narg('source'))
+  AND (sqlc.narg('paid_start')::DATE IS NULL OR t.paid_at >= sqlc.narg('paid_start'))
+  AND (sqlc.narg('paid_end')::DATE IS NULL OR t.paid_at < sqlc.narg('paid_end')::DATE + 1)
+  AND (sqlc.narg('has_location')::BOOLEAN IS NULL OR ((t.latitude IS NOT NULL OR t.location IS NOT NULL) = sqlc.narg('has_location')))
 ORDER BY t.transaction_date DESC, t.created_at DESC
 LIMIT @page_size OFFSET @page_offset;
 
@@ -220,6 +304,15 @@ GROUP BY bc.id, bc.name
 ORDER BY last_used DESC
 LIMIT 5;
 
+-- name: GetDistinctTransactionSources :many
+-- Returns every source value in use in the workspace, for filter dropdowns
+SELECT DISTINCT source::TEXT AS source
+FROM transactions
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+  AND source IS NOT NULL
+ORDER BY source;
+
 -- name: GetTransactionsByIDs :many
 -- Get multiple transactions by their IDs
 SELECT * FROM transactions
@@ -279,10 +372,24 @@ WHERE t.workspace_id = $1
   AND t.deleted_at IS NULL
 ORDER BY t.billed_at ASC;
 
+-- name: GetPastDueUnpaidTransactions :many
+-- Unpaid expenses dated before the given day, oldest first
+-- Excludes transfers and deferred CC (those are tracked by GetOverdueCC)
+SELECT * FROM transactions
+WHERE workspace_id = $1
+  AND type = 'expense'
+  AND is_paid = false
+  AND transaction_date < $2
+  AND transfer_pair_id IS NULL
+  AND (settlement_intent IS NULL OR settlement_intent <> 'deferred')
+  AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC;
+
 -- name: BulkSettleTransactions :many
 -- Bulk update multiple transactions to settled state (is_paid = true)
 UPDATE transactions
 SET is_paid = true,
+    paid_at = NOW(),
     updated_at = NOW()
 WHERE id = ANY($1::int[])
   AND workspace_id = $2
@@ -302,6 +409,20 @@ WHERE id = ANY($1::int[])
   AND deleted_at IS NULL
 RETURNING *;
 
+-- name: BillPendingCCTransactions :many
+-- Close a CC billing cycle: pending purchases dated on/before the cutoff become billed
+UPDATE transactions
+SET billed_at = $3::DATE,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND account_id = $2
+  AND type = 'expense'
+  AND billed_at IS NULL
+  AND is_paid = false
+  AND transaction_date <= $3::DATE
+  AND deleted_at IS NULL
+RETURNING *;
+
 -- name: GetCCMetrics :one
 -- Get CC metrics (pending, outstanding, purchases) for a month range
 -- Simplified: pending = billed_at IS NULL, billed = billed_at IS NOT NULL AND is_paid = false, settled = is_paid = true
@@ -330,6 +451,22 @@ WHERE t.workspace_id = $1
   )
   AND t.deleted_at IS NULL;
 
+-- name: GetCCStateBreakdown :one
+-- Count and sum CC purchases on one account per CC state (pending, billed, settled)
+-- Payments into the card are income and not part of any state bucket
+SELECT
+    COUNT(*) FILTER (WHERE billed_at IS NULL AND is_paid = false)::INTEGER as pending_count,
+    COALESCE(SUM(amount) FILTER (WHERE billed_at IS NULL AND is_paid = false), 0)::NUMERIC(12,2) as pending_total,
+    COUNT(*) FILTER (WHERE billed_at IS NOT NULL AND is_paid = false)::INTEGER as billed_count,
+    COALESCE(SUM(amount) FILTER (WHERE billed_at IS NOT NULL AND is_paid = false), 0)::NUMERIC(12,2) as billed_total,
+    COUNT(*) FILTER (WHERE is_paid = true)::INTEGER as settled_count,
+    COALESCE(SUM(amount) FILTER (WHERE is_paid = true), 0)::NUMERIC(12,2) as settled_total
+FROM transactions
+WHERE workspace_id = $1
+  AND account_id = $2
+  AND type = 'expense'
+  AND deleted_at IS NULL;
+
 -- ========================================
 -- Projection Management (v2)
 -- ========================================
@@ -343,6 +480,14 @@ WHERE workspace_id = $1
   AND deleted_at IS NULL
 ORDER BY transaction_date;
 
+-- name: GetTransactionsByTemplate :many
+-- Get all generated transactions (projected and actual) for a template, newest first
+SELECT * FROM transactions
+WHERE workspace_id = $1
+  AND template_id = $2
+  AND deleted_at IS NULL
+ORDER BY transaction_date DESC, id DESC;
+
 -- name: DeleteProjectionsByTemplate :exec
 -- Delete unpaid projected transactions for a template (used when deleting template)
 -- Paid projections are preserved and orphaned instead
@@ -360,6 +505,18 @@ WHERE workspace_id = $1
   AND is_projected = true
   AND transaction_date > $3;
 
+-- name: DeleteFutureUnpaidActualsByTemplate :exec
+-- Soft delete unpaid actual transactions dated after a cutoff (used when deleting template without keeping generated)
+UPDATE transactions
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND template_id = $2
+  AND is_projected = false
+  AND is_paid = false
+  AND transaction_date > $3
+  AND deleted_at IS NULL;
+
 -- name: OrphanActualsByTemplate :exec
 -- Unlink actual transactions from template (keep them, clear template_id)
 UPDATE transactions
@@ -372,6 +529,7 @@ WHERE workspace_id = $1
 
 -- name: GetDeferredForSettlement :many
 -- Get all billed, deferred transactions that need settlement (ordered by date)
+-- A NULL $2 covers every account; otherwise only the listed accounts
 SELECT * FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
@@ -380,6 +538,7 @@ WHERE t.workspace_id = $1
   AND t.is_paid = false
   AND t.settlement_intent = 'deferred'
   AND t.deleted_at IS NULL
+  AND ($2::INTEGER[] IS NULL OR t.account_id = ANY($2::INTEGER[]))
 ORDER BY t.transaction_date ASC;
 
 -- name: GetImmediateForSettlement :many
@@ -424,8 +583,20 @@ WHERE workspace_id = @workspace_id
   AND EXTRACT(MONTH FROM transaction_date)::INTEGER = @month::INTEGER
   AND deleted_at IS NULL
   AND is_paid = false
+  AND source <> 'loan_down_payment'
 ORDER BY transaction_date;
 
+-- name: GetAllLoanTransactionsByMonth :many
+-- Get all loan transactions (paid and unpaid) for a month, used for commitments aggregation
+SELECT * FROM transactions
+WHERE workspace_id = @workspace_id
+  AND loan_id IS NOT NULL
+  AND source <> 'loan_down_payment'
+  AND EXTRACT(YEAR FROM transaction_date)::INTEGER = @year::INTEGER
+  AND EXTRACT(MONTH FROM transaction_date)::INTEGER = @month::INTEGER
+  AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC;
+
 -- name: GetTransactionsByLoanID :many
 -- Get all transactions for a specific loan (both paid and unpaid) for item-based modal
 SELECT * FROM transactions
@@ -438,17 +609,30 @@ ORDER BY transaction_date ASC;
 -- Bulk mark transactions as paid by IDs (works for both bank and CC transactions)
 -- For CC transactions, this also effectively sets cc_state to 'settled' since it's computed from is_paid
 UPDATE transactions
-SET is_paid = true, updated_at = NOW()
+SET is_paid = true, paid_at = $3, updated_at = NOW()
+WHERE workspace_id = $1
+  AND id = ANY($2::int[])
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: BulkUpdateTransactionAccount :many
+-- Reassign transactions to another account in a single statement
+-- Transfer legs stay put (moving one would break the pair) and rows already on the target are skipped
+UPDATE transactions
+SET account_id = $3, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
+  AND account_id <> $3
+  AND transfer_pair_id IS NULL
   AND deleted_at IS NULL
 RETURNING *;
 
 -- name: OrphanPaidTransactionsByLoan :exec
 -- Unlink paid transactions from loan (keep them, clear loan_id)
--- Used when deleting a loan to preserve payment history
+-- Used when deleting a loan to preserve payment history; deleted_loan_id allows a later restore
 UPDATE transactions
-SET loan_id = NULL,
+SET deleted_loan_id = loan_id,
+    loan_id = NULL,
     updated_at = NOW()
 WHERE workspace_id = $1
   AND loan_id = $2
@@ -456,12 +640,39 @@ WHERE workspace_id = $1
   AND deleted_at IS NULL;
 
 -- name: DeleteUnpaidTransactionsByLoan :exec
--- Hard delete unpaid transactions for a loan
--- Used when deleting a loan (unpaid future payments are removed)
-DELETE FROM transactions
+-- Soft delete unpaid transactions for a loan
+-- Used when deleting a loan (unpaid future payments are removed); deleted_loan_id allows a later restore
+UPDATE transactions
+SET deleted_at = NOW(),
+    deleted_loan_id = loan_id,
+    loan_id = NULL,
+    updated_at = NOW()
 WHERE workspace_id = $1
   AND loan_id = $2
-  AND is_paid = false;
+  AND is_paid = false
+  AND deleted_at IS NULL;
+
+-- name: ClearUnpaidTransactionsByLoan :exec
+-- Soft delete unpaid transactions still linked to a loan
+-- Used when a loan schedule is regenerated; unlike DeleteUnpaidTransactionsByLoan nothing is kept for restore
+UPDATE transactions
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id = $2
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL;
+
+-- name: RestoreTransactionsByDeletedLoan :exec
+-- Re-link transactions unlinked by a loan deletion; unpaid ones were soft-deleted with the loan and come back
+UPDATE transactions
+SET loan_id = deleted_loan_id,
+    deleted_loan_id = NULL,
+    deleted_at = CASE WHEN is_paid THEN deleted_at ELSE NULL END,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND deleted_loan_id = $2;
 
 -- name: UpdateTransactionPayeesByLoan :execrows
 -- Cascade item name/provider change to transaction payees
@@ -471,8 +682,25 @@ SET name = $3,
     updated_at = NOW()
 WHERE workspace_id = $1
   AND loan_id = $2
+  AND source <> 'loan_down_payment'
   AND deleted_at IS NULL;
 
+-- name: SettleTransactionsByLoanExternally :many
+-- Mark every unpaid payment of a loan paid outside the app, tagging it with the external source
+-- The note replaces the existing notes when given
+UPDATE transactions
+SET is_paid = true,
+    paid_at = $3,
+    source = 'external',
+    notes = COALESCE(NULLIF(@notes::text, ''), notes),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id = $2
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL
+RETURNING *;
+
 -- name: HasPaidTransactionsByLoan :one
 -- Check if any transactions for this loan are paid (for provider change validation)
 SELECT EXISTS (
@@ -480,6 +708,7 @@ SELECT EXISTS (
     WHERE workspace_id = $1
       AND loan_id = $2
       AND is_paid = true
+      AND source <> 'loan_down_payment'
       AND deleted_at IS NULL
 )::BOOLEAN as has_paid;
 
@@ -493,11 +722,13 @@ SELECT
 FROM transactions
 WHERE workspace_id = $1
   AND loan_id = $2
+  AND source <> 'loan_down_payment'
   AND deleted_at IS NULL;
 
 -- name: GetLoanPaymentsFromTransactions :many
 -- Convert loan transactions to LoanPayment format for frontend compatibility
 -- Derives payment_number from row order, extracts year/month from transaction_date
+-- Separate fee lines are not payments and are left out of the numbering
 -- Note: Only uses loan_id since service layer already verified loan ownership
 SELECT
     t.id,
@@ -512,6 +743,8 @@ SELECT
     t.updated_at
 FROM transactions t
 WHERE t.loan_id = $1
+  AND t.is_loan_fee = false
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC, t.id ASC;
 
@@ -530,6 +763,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 JOIN loan_providers lp ON l.provider_id = lp.id AND lp.deleted_at IS NULL
 WHERE t.workspace_id = @workspace_id
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND (
     -- Filter by date range: from start to end month (inclusive)
@@ -556,6 +790,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 WHERE t.workspace_id = $1
   AND l.provider_id = $2
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.is_paid = false
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC
@@ -571,6 +806,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 WHERE t.workspace_id = $1
   AND l.provider_id = $2
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.is_paid = true
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date DESC
@@ -596,6 +832,7 @@ WHERE t.workspace_id = @workspace_id
   AND EXTRACT(YEAR FROM t.transaction_date)::INTEGER = CAST(@year AS INTEGER)
   AND EXTRACT(MONTH FROM t.transaction_date)::INTEGER = CAST(@month AS INTEGER)
   AND t.is_paid = false
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC, t.id ASC;
 
@@ -619,13 +856,14 @@ WHERE t.workspace_id = @workspace_id
   AND EXTRACT(YEAR FROM t.transaction_date)::INTEGER = CAST(@year AS INTEGER)
   AND EXTRACT(MONTH FROM t.transaction_date)::INTEGER = CAST(@month AS INTEGER)
   AND t.is_paid = true
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC, t.id ASC;
 
 -- name: BatchMarkLoanTransactionsPaid :many
 -- Bulk mark loan transactions as paid by IDs with timestamp
 UPDATE transactions
-SET is_paid = true, updated_at = NOW()
+SET is_paid = true, paid_at = CASE WHEN is_paid THEN paid_at ELSE NOW() END, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND loan_id IS NOT NULL
@@ -635,7 +873,7 @@ RETURNING *;
 -- name: BatchMarkLoanTransactionsUnpaid :many
 -- Bulk mark loan transactions as unpaid by IDs
 UPDATE transactions
-SET is_paid = false, updated_at = NOW()
+SET is_paid = false, paid_at = NULL, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND loan_id IS NOT NULL
@@ -668,6 +906,18 @@ SELECT
     t.is_projected,
     t.loan_id,
     t.group_id,
+    t.is_scheduled,
+    t.paid_at,
+    t.merchant,
+    t.reverses_transfer_pair_id,
+    t.exclude_from_reports,
+    t.is_tax_deductible,
+    t.payee,
+    t.modified_from_template,
+    t.latitude,
+    t.longitude,
+    t.location,
+    t.is_refund,
     bc.name AS category_name,
     tg.name AS group_name
 FROM transactions t
@@ -677,4 +927,99 @@ WHERE t.workspace_id = @workspace_id
   AND t.deleted_at IS NULL
   AND t.transaction_date >= @start_date::DATE
   AND t.transaction_date <= @end_date::DATE
+  AND (sqlc.narg('account_ids')::INTEGER[] IS NULL OR t.account_id = ANY(sqlc.narg('account_ids')::INTEGER[]))
 ORDER BY t.transaction_date DESC, t.created_at DESC;
+
+-- name: PromoteDueScheduledTransactions :many
+-- Activates a workspace's scheduled transactions whose date has arrived
+UPDATE transactions
+SET is_scheduled = false, updated_at = NOW()
+WHERE workspace_id = $1
+  AND is_scheduled = true
+  AND transaction_date <= $2
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: PromoteAllDueScheduledTransactions :many
+-- Activates due scheduled transactions across all workspaces (daily job)
+UPDATE transactions
+SET is_scheduled = false, updated_at = NOW()
+WHERE is_scheduled = true
+  AND transaction_date <= $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetOrphanedLoanTransactions :many
+-- Transactions linked to a loan that no longer exists or was deleted
+SELECT * FROM transactions
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+ORDER BY transaction_date ASC, id ASC;
+
+-- name: GetUnpaidLoanTransactions :many
+-- Unpaid payments of live loans, oldest first, for due/overdue classification
+SELECT * FROM transactions
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+ORDER BY transaction_date ASC, id ASC;
+
+-- name: ClearOrphanedLoanTransactions :many
+-- Unlink transactions whose loan no longer exists or was deleted
+UPDATE transactions
+SET loan_id = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+RETURNING *;
+
+-- name: GetTransferPairTransactions :many
+-- Returns both legs of a transfer
+SELECT * FROM transactions
+WHERE workspace_id = $1
+  AND transfer_pair_id = $2
+  AND deleted_at IS NULL
+ORDER BY id ASC;
+
+-- name: IsTransferPairReversed :one
+-- Check if a reversal transfer already exists for this transfer pair
+SELECT EXISTS (
+    SELECT 1 FROM transactions
+    WHERE workspace_id = $1
+      AND reverses_transfer_pair_id = $2
+      AND deleted_at IS NULL
+)::BOOLEAN as is_reversed;
+
+-- name: SearchTransactions :many
+-- Case-insensitive substring match on name, merchant, or payee for global search, newest first.
+-- The query must have its LIKE wildcards escaped with a backslash so they match literally
+SELECT * FROM transactions
+WHERE workspace_id = @workspace_id
+  AND deleted_at IS NULL
+  AND (name ILIKE '%' || @query::text || '%' ESCAPE '\'
+    OR merchant ILIKE '%' || @query::text || '%' ESCAPE '\'
+    OR payee ILIKE '%' || @query::text || '%' ESCAPE '\')
+ORDER BY transaction_date DESC, id DESC
+LIMIT @max_results::int;
@@ -0,0 +1,27 @@
+-- name: AddWorkspaceMember :one
+INSERT INTO workspace_members (workspace_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = CASE
+    WHEN 'owner' IN (workspace_members.role, EXCLUDED.role) THEN 'owner'
+    WHEN 'editor' IN (workspace_members.role, EXCLUDED.role) THEN 'editor'
+    ELSE workspace_members.role
+END
+RETURNING *;
+
+-- name: GetWorkspaceMemberRole :one
+SELECT role FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: CreateWorkspaceInvite :one
+INSERT INTO workspace_invites (workspace_id, email, role, token, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWorkspaceInviteByToken :one
+SELECT * FROM workspace_invites WHERE token = $1;
+
+-- name: MarkWorkspaceInviteAccepted :one
+UPDATE workspace_invites
+SET accepted_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL
+RETURNING *;
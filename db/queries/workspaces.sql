@@ -2,12 +2,24 @@
 SELECT * FROM workspaces WHERE id = $1;
 
 -- name: GetWorkspaceByUserID :one
-SELECT * FROM workspaces WHERE user_id = $1;
+SELECT * FROM workspaces WHERE user_id = $1
+ORDER BY id
+LIMIT 1;
 
 -- name: GetWorkspaceByUserAuth0ID :one
 SELECT w.* FROM workspaces w
 INNER JOIN users u ON w.user_id = u.id
-WHERE u.auth0_id = $1;
+WHERE u.auth0_id = $1
+ORDER BY w.id
+LIMIT 1;
+
+-- name: ListWorkspacesByUserAuth0ID :many
+-- Includes workspaces shared with the user, with the user's role in each
+SELECT w.*, wm.role FROM workspaces w
+INNER JOIN workspace_members wm ON wm.workspace_id = w.id
+INNER JOIN users u ON wm.user_id = u.id
+WHERE u.auth0_id = $1
+ORDER BY w.id;
 
 -- name: CreateWorkspace :one
 INSERT INTO workspaces (user_id, name)
@@ -20,5 +32,23 @@ SET name = $2, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateWorkspaceCurrency :one
+UPDATE workspaces
+SET currency = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateWorkspaceRoundUpSavingsAccount :one
+UPDATE workspaces
+SET round_up_savings_account_id = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateWorkspaceGenerationHorizon :one
+UPDATE workspaces
+SET generation_horizon_months = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteWorkspace :exec
 DELETE FROM workspaces WHERE id = $1;
@@ -11,18 +11,37 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countAccountTransactions = `-- name: CountAccountTransactions :one
+SELECT COUNT(*) FROM transactions
+WHERE workspace_id = $1 AND account_id = $2
+`
+
+type CountAccountTransactionsParams struct {
+	WorkspaceID int32 `json:"workspace_id"`
+	AccountID   int32 `json:"account_id"`
+}
+
+// Count every transaction on an account, including soft-deleted ones that can still be restored
+func (q *Queries) CountAccountTransactions(ctx context.Context, arg CountAccountTransactionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccountTransactions, arg.WorkspaceID, arg.AccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (workspace_id, name, account_type, template, initial_balance)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at
+INSERT INTO accounts (workspace_id, name, account_type, template, initial_balance, exclude_from_net_worth)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth
 `
 
 type CreateAccountParams struct {
-	WorkspaceID    int32          `json:"workspace_id"`
-	Name           string         `json:"name"`
-	AccountType    string         `json:"account_type"`
-	Template       string         `json:"template"`
-	InitialBalance pgtype.Numeric `json:"initial_balance"`
+	WorkspaceID         int32          `json:"workspace_id"`
+	Name                string         `json:"name"`
+	AccountType         string         `json:"account_type"`
+	Template            string         `json:"template"`
+	InitialBalance      pgtype.Numeric `json:"initial_balance"`
+	ExcludeFromNetWorth bool           `json:"exclude_from_net_worth"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
@@ -32,6 +51,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		arg.AccountType,
 		arg.Template,
 		arg.InitialBalance,
+		arg.ExcludeFromNetWorth,
 	)
 	var i Account
 	err := row.Scan(
@@ -44,12 +64,14 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
 	)
 	return i, err
 }
 
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at FROM accounts
+SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth FROM accounts
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 `
 
@@ -71,12 +93,14 @@ func (q *Queries) GetAccountByID(ctx context.Context, arg GetAccountByIDParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
 	)
 	return i, err
 }
 
 const getAccountByIDIncludeDeleted = `-- name: GetAccountByIDIncludeDeleted :one
-SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at FROM accounts
+SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth FROM accounts
 WHERE workspace_id = $1 AND id = $2
 `
 
@@ -98,12 +122,14 @@ func (q *Queries) GetAccountByIDIncludeDeleted(ctx context.Context, arg GetAccou
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
 	)
 	return i, err
 }
 
 const getAccountsByWorkspace = `-- name: GetAccountsByWorkspace :many
-SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at FROM accounts
+SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth FROM accounts
 WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -127,6 +153,8 @@ func (q *Queries) GetAccountsByWorkspace(ctx context.Context, workspaceID int32)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.AccountGroupID,
+			&i.ExcludeFromNetWorth,
 		); err != nil {
 			return nil, err
 		}
@@ -139,7 +167,7 @@ func (q *Queries) GetAccountsByWorkspace(ctx context.Context, workspaceID int32)
 }
 
 const getAccountsByWorkspaceAll = `-- name: GetAccountsByWorkspaceAll :many
-SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at FROM accounts
+SELECT id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth FROM accounts
 WHERE workspace_id = $1
 ORDER BY deleted_at NULLS FIRST, created_at DESC
 `
@@ -163,6 +191,8 @@ func (q *Queries) GetAccountsByWorkspaceAll(ctx context.Context, workspaceID int
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.AccountGroupID,
+			&i.ExcludeFromNetWorth,
 		); err != nil {
 			return nil, err
 		}
@@ -260,6 +290,72 @@ func (q *Queries) HardDeleteAccount(ctx context.Context, arg HardDeleteAccountPa
 	return err
 }
 
+const setAccountExcludeFromNetWorth = `-- name: SetAccountExcludeFromNetWorth :one
+UPDATE accounts
+SET exclude_from_net_worth = $3, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth
+`
+
+type SetAccountExcludeFromNetWorthParams struct {
+	WorkspaceID         int32 `json:"workspace_id"`
+	ID                  int32 `json:"id"`
+	ExcludeFromNetWorth bool  `json:"exclude_from_net_worth"`
+}
+
+// Include or exclude an account's balance from net worth
+func (q *Queries) SetAccountExcludeFromNetWorth(ctx context.Context, arg SetAccountExcludeFromNetWorthParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountExcludeFromNetWorth, arg.WorkspaceID, arg.ID, arg.ExcludeFromNetWorth)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.AccountType,
+		&i.Template,
+		&i.InitialBalance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
+	)
+	return i, err
+}
+
+const setAccountGroup = `-- name: SetAccountGroup :one
+UPDATE accounts
+SET account_group_id = $3, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth
+`
+
+type SetAccountGroupParams struct {
+	WorkspaceID    int32       `json:"workspace_id"`
+	ID             int32       `json:"id"`
+	AccountGroupID pgtype.Int4 `json:"account_group_id"`
+}
+
+// Assign an account to a group (NULL moves it to the ungrouped bucket)
+func (q *Queries) SetAccountGroup(ctx context.Context, arg SetAccountGroupParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountGroup, arg.WorkspaceID, arg.ID, arg.AccountGroupID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.AccountType,
+		&i.Template,
+		&i.InitialBalance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
+	)
+	return i, err
+}
+
 const softDeleteAccount = `-- name: SoftDeleteAccount :execrows
 UPDATE accounts
 SET deleted_at = NOW(), updated_at = NOW()
@@ -279,11 +375,28 @@ func (q *Queries) SoftDeleteAccount(ctx context.Context, arg SoftDeleteAccountPa
 	return result.RowsAffected(), nil
 }
 
+const ungroupAccountsByGroup = `-- name: UngroupAccountsByGroup :exec
+UPDATE accounts
+SET account_group_id = NULL, updated_at = NOW()
+WHERE workspace_id = $1 AND account_group_id = $2
+`
+
+type UngroupAccountsByGroupParams struct {
+	WorkspaceID    int32       `json:"workspace_id"`
+	AccountGroupID pgtype.Int4 `json:"account_group_id"`
+}
+
+// Move all accounts of a group to the ungrouped bucket (used when deleting a group)
+func (q *Queries) UngroupAccountsByGroup(ctx context.Context, arg UngroupAccountsByGroupParams) error {
+	_, err := q.db.Exec(ctx, ungroupAccountsByGroup, arg.WorkspaceID, arg.AccountGroupID)
+	return err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET name = $3, updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at
+RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth
 `
 
 type UpdateAccountParams struct {
@@ -305,6 +418,46 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
+	)
+	return i, err
+}
+
+const updateAccountTemplate = `-- name: UpdateAccountTemplate :one
+UPDATE accounts
+SET template = $3, account_type = $4, updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+RETURNING id, workspace_id, name, account_type, template, initial_balance, created_at, updated_at, deleted_at, account_group_id, exclude_from_net_worth
+`
+
+type UpdateAccountTemplateParams struct {
+	WorkspaceID int32  `json:"workspace_id"`
+	ID          int32  `json:"id"`
+	Template    string `json:"template"`
+	AccountType string `json:"account_type"`
+}
+
+func (q *Queries) UpdateAccountTemplate(ctx context.Context, arg UpdateAccountTemplateParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountTemplate,
+		arg.WorkspaceID,
+		arg.ID,
+		arg.Template,
+		arg.AccountType,
+	)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.AccountType,
+		&i.Template,
+		&i.InitialBalance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountGroupID,
+		&i.ExcludeFromNetWorth,
 	)
 	return i, err
 }
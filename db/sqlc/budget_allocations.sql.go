@@ -219,12 +219,12 @@ func (q *Queries) GetCategoriesWithAllocations(ctx context.Context, arg GetCateg
 }
 
 const getCategoryTransactions = `-- name: GetCategoryTransactions :many
-SELECT t.id, t.workspace_id, t.account_id, t.name, t.amount, t.type, t.transaction_date, t.is_paid, t.notes, t.created_at, t.updated_at, t.deleted_at, t.transfer_pair_id, t.category_id, t.is_cc_payment, t.billed_at, t.settlement_intent, t.source, t.template_id, t.is_projected, t.loan_id, t.group_id, a.name AS account_name
+SELECT t.id, t.workspace_id, t.account_id, t.name, t.amount, t.type, t.transaction_date, t.is_paid, t.notes, t.created_at, t.updated_at, t.deleted_at, t.transfer_pair_id, t.category_id, t.is_cc_payment, t.billed_at, t.settlement_intent, t.source, t.template_id, t.is_projected, t.loan_id, t.group_id, t.is_scheduled, t.paid_at, t.merchant, t.reverses_transfer_pair_id, t.is_refund, t.deleted_loan_id, t.exclude_from_reports, t.is_tax_deductible, t.payee, t.modified_from_template, t.latitude, t.longitude, t.location, t.round_up_source_id, t.is_loan_fee, a.name AS account_name
 FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
     AND t.category_id = $2
-    AND t.type = 'expense'
+    AND (t.type = 'expense' OR t.is_refund = true)
     AND t.deleted_at IS NULL
     AND EXTRACT(YEAR FROM t.transaction_date) = $3::int
     AND EXTRACT(MONTH FROM t.transaction_date) = $4::int
@@ -239,32 +239,47 @@ type GetCategoryTransactionsParams struct {
 }
 
 type GetCategoryTransactionsRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	AccountName      string             `json:"account_name"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	AccountName            string             `json:"account_name"`
 }
 
-// Returns all transactions for a specific category in a month
+// Returns all transactions for a specific category in a month (including refunds)
 func (q *Queries) GetCategoryTransactions(ctx context.Context, arg GetCategoryTransactionsParams) ([]GetCategoryTransactionsRow, error) {
 	rows, err := q.db.Query(ctx, getCategoryTransactions,
 		arg.WorkspaceID,
@@ -302,6 +317,21 @@ func (q *Queries) GetCategoryTransactions(ctx context.Context, arg GetCategoryTr
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.AccountName,
 		); err != nil {
 			return nil, err
@@ -317,12 +347,13 @@ func (q *Queries) GetCategoryTransactions(ctx context.Context, arg GetCategoryTr
 const getSpendingByCategory = `-- name: GetSpendingByCategory :many
 SELECT
     t.category_id,
-    COALESCE(SUM(t.amount), 0) AS spent
+    COALESCE(SUM(CASE WHEN t.is_refund THEN -t.amount ELSE t.amount END), 0) AS spent
 FROM transactions t
 WHERE t.workspace_id = $1
     AND t.category_id IS NOT NULL
-    AND t.type = 'expense'
+    AND (t.type = 'expense' OR t.is_refund = true)
     AND t.deleted_at IS NULL
+    AND t.exclude_from_reports = false
     AND EXTRACT(YEAR FROM t.transaction_date) = $2::int
     AND EXTRACT(MONTH FROM t.transaction_date) = $3::int
 GROUP BY t.category_id
@@ -340,6 +371,7 @@ type GetSpendingByCategoryRow struct {
 }
 
 // Returns total spending per category for a specific month
+// Refunds are netted out of the category's spending; transactions excluded from reports are skipped
 func (q *Queries) GetSpendingByCategory(ctx context.Context, arg GetSpendingByCategoryParams) ([]GetSpendingByCategoryRow, error) {
 	rows, err := q.db.Query(ctx, getSpendingByCategory, arg.WorkspaceID, arg.Year, arg.Month)
 	if err != nil {
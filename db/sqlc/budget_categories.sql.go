@@ -33,18 +33,29 @@ func (q *Queries) CountTransactionsByCategory(ctx context.Context, arg CountTran
 }
 
 const createBudgetCategory = `-- name: CreateBudgetCategory :one
-INSERT INTO budget_categories (workspace_id, name)
-VALUES ($1, $2)
-RETURNING id, workspace_id, name, created_at, updated_at, deleted_at
+INSERT INTO budget_categories (workspace_id, name, description, color, parent_id, is_tax_deductible)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, workspace_id, name, created_at, updated_at, deleted_at, description, color, parent_id, is_tax_deductible
 `
 
 type CreateBudgetCategoryParams struct {
-	WorkspaceID int32  `json:"workspace_id"`
-	Name        string `json:"name"`
+	WorkspaceID     int32       `json:"workspace_id"`
+	Name            string      `json:"name"`
+	Description     pgtype.Text `json:"description"`
+	Color           pgtype.Text `json:"color"`
+	ParentID        pgtype.Int4 `json:"parent_id"`
+	IsTaxDeductible bool        `json:"is_tax_deductible"`
 }
 
 func (q *Queries) CreateBudgetCategory(ctx context.Context, arg CreateBudgetCategoryParams) (BudgetCategory, error) {
-	row := q.db.QueryRow(ctx, createBudgetCategory, arg.WorkspaceID, arg.Name)
+	row := q.db.QueryRow(ctx, createBudgetCategory,
+		arg.WorkspaceID,
+		arg.Name,
+		arg.Description,
+		arg.Color,
+		arg.ParentID,
+		arg.IsTaxDeductible,
+	)
 	var i BudgetCategory
 	err := row.Scan(
 		&i.ID,
@@ -53,12 +64,33 @@ func (q *Queries) CreateBudgetCategory(ctx context.Context, arg CreateBudgetCate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Description,
+		&i.Color,
+		&i.ParentID,
+		&i.IsTaxDeductible,
 	)
 	return i, err
 }
 
+const detachBudgetCategoryChildren = `-- name: DetachBudgetCategoryChildren :exec
+UPDATE budget_categories
+SET parent_id = NULL, updated_at = NOW()
+WHERE workspace_id = $1 AND parent_id = $2
+`
+
+type DetachBudgetCategoryChildrenParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	ParentID    pgtype.Int4 `json:"parent_id"`
+}
+
+// Promotes a deleted category's subcategories to top level
+func (q *Queries) DetachBudgetCategoryChildren(ctx context.Context, arg DetachBudgetCategoryChildrenParams) error {
+	_, err := q.db.Exec(ctx, detachBudgetCategoryChildren, arg.WorkspaceID, arg.ParentID)
+	return err
+}
+
 const getAllBudgetCategories = `-- name: GetAllBudgetCategories :many
-SELECT id, workspace_id, name, created_at, updated_at, deleted_at FROM budget_categories
+SELECT id, workspace_id, name, created_at, updated_at, deleted_at, description, color, parent_id, is_tax_deductible FROM budget_categories
 WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY name ASC
 `
@@ -79,6 +111,81 @@ func (q *Queries) GetAllBudgetCategories(ctx context.Context, workspaceID int32)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Description,
+			&i.Color,
+			&i.ParentID,
+			&i.IsTaxDeductible,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBudgetCategoriesWithUsage = `-- name: GetBudgetCategoriesWithUsage :many
+SELECT
+    bc.id, bc.workspace_id, bc.name, bc.created_at, bc.updated_at, bc.deleted_at, bc.description, bc.color, bc.parent_id, bc.is_tax_deductible,
+    COUNT(t.id)::bigint AS transaction_count,
+    COALESCE(SUM(
+        CASE
+            WHEN t.is_projected THEN 0
+            WHEN t.is_refund THEN -t.amount
+            WHEN t.type = 'expense' THEN t.amount
+            ELSE 0
+        END
+    ), 0)::NUMERIC(12,2) AS total_spent
+FROM budget_categories bc
+LEFT JOIN transactions t ON t.category_id = bc.id
+    AND t.workspace_id = bc.workspace_id
+    AND t.deleted_at IS NULL
+WHERE bc.workspace_id = $1 AND bc.deleted_at IS NULL
+GROUP BY bc.id
+ORDER BY bc.name ASC
+`
+
+type GetBudgetCategoriesWithUsageRow struct {
+	ID               int32              `json:"id"`
+	WorkspaceID      int32              `json:"workspace_id"`
+	Name             string             `json:"name"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	Description      pgtype.Text        `json:"description"`
+	Color            pgtype.Text        `json:"color"`
+	ParentID         pgtype.Int4        `json:"parent_id"`
+	IsTaxDeductible  bool               `json:"is_tax_deductible"`
+	TransactionCount int64              `json:"transaction_count"`
+	TotalSpent       pgtype.Numeric     `json:"total_spent"`
+}
+
+// Every category with its all-time transaction count and net spend; unused categories report zero.
+// Refunds are netted out of spend and projections are not counted as spend.
+func (q *Queries) GetBudgetCategoriesWithUsage(ctx context.Context, workspaceID int32) ([]GetBudgetCategoriesWithUsageRow, error) {
+	rows, err := q.db.Query(ctx, getBudgetCategoriesWithUsage, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBudgetCategoriesWithUsageRow{}
+	for rows.Next() {
+		var i GetBudgetCategoriesWithUsageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Description,
+			&i.Color,
+			&i.ParentID,
+			&i.IsTaxDeductible,
+			&i.TransactionCount,
+			&i.TotalSpent,
 		); err != nil {
 			return nil, err
 		}
@@ -91,7 +198,7 @@ func (q *Queries) GetAllBudgetCategories(ctx context.Context, workspaceID int32)
 }
 
 const getBudgetCategoryByID = `-- name: GetBudgetCategoryByID :one
-SELECT id, workspace_id, name, created_at, updated_at, deleted_at FROM budget_categories
+SELECT id, workspace_id, name, created_at, updated_at, deleted_at, description, color, parent_id, is_tax_deductible FROM budget_categories
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 `
 
@@ -110,12 +217,16 @@ func (q *Queries) GetBudgetCategoryByID(ctx context.Context, arg GetBudgetCatego
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Description,
+		&i.Color,
+		&i.ParentID,
+		&i.IsTaxDeductible,
 	)
 	return i, err
 }
 
 const getBudgetCategoryByName = `-- name: GetBudgetCategoryByName :one
-SELECT id, workspace_id, name, created_at, updated_at, deleted_at FROM budget_categories
+SELECT id, workspace_id, name, created_at, updated_at, deleted_at, description, color, parent_id, is_tax_deductible FROM budget_categories
 WHERE workspace_id = $1 AND name = $2 AND deleted_at IS NULL
 `
 
@@ -134,6 +245,10 @@ func (q *Queries) GetBudgetCategoryByName(ctx context.Context, arg GetBudgetCate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Description,
+		&i.Color,
+		&i.ParentID,
+		&i.IsTaxDeductible,
 	)
 	return i, err
 }
@@ -156,19 +271,31 @@ func (q *Queries) SoftDeleteBudgetCategory(ctx context.Context, arg SoftDeleteBu
 
 const updateBudgetCategory = `-- name: UpdateBudgetCategory :one
 UPDATE budget_categories
-SET name = $3, updated_at = NOW()
+SET name = $3, description = $4, color = $5, parent_id = $6, is_tax_deductible = $7, updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, name, created_at, updated_at, deleted_at
+RETURNING id, workspace_id, name, created_at, updated_at, deleted_at, description, color, parent_id, is_tax_deductible
 `
 
 type UpdateBudgetCategoryParams struct {
-	WorkspaceID int32  `json:"workspace_id"`
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
+	WorkspaceID     int32       `json:"workspace_id"`
+	ID              int32       `json:"id"`
+	Name            string      `json:"name"`
+	Description     pgtype.Text `json:"description"`
+	Color           pgtype.Text `json:"color"`
+	ParentID        pgtype.Int4 `json:"parent_id"`
+	IsTaxDeductible bool        `json:"is_tax_deductible"`
 }
 
 func (q *Queries) UpdateBudgetCategory(ctx context.Context, arg UpdateBudgetCategoryParams) (BudgetCategory, error) {
-	row := q.db.QueryRow(ctx, updateBudgetCategory, arg.WorkspaceID, arg.ID, arg.Name)
+	row := q.db.QueryRow(ctx, updateBudgetCategory,
+		arg.WorkspaceID,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.Color,
+		arg.ParentID,
+		arg.IsTaxDeductible,
+	)
 	var i BudgetCategory
 	err := row.Scan(
 		&i.ID,
@@ -177,6 +304,10 @@ func (q *Queries) UpdateBudgetCategory(ctx context.Context, arg UpdateBudgetCate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Description,
+		&i.Color,
+		&i.ParentID,
+		&i.IsTaxDeductible,
 	)
 	return i, err
 }
@@ -16,17 +16,27 @@ INSERT INTO loan_providers (
     workspace_id,
     name,
     cutoff_day,
-    default_interest_rate
+    default_interest_rate,
+    monthly_fee,
+    fee_mode,
+    promo_interest_rate,
+    promo_months,
+    default_interest_method
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode, monthly_fee, fee_mode, promo_interest_rate, promo_months, default_interest_method
 `
 
 type CreateLoanProviderParams struct {
-	WorkspaceID         int32          `json:"workspace_id"`
-	Name                string         `json:"name"`
-	CutoffDay           int32          `json:"cutoff_day"`
-	DefaultInterestRate pgtype.Numeric `json:"default_interest_rate"`
+	WorkspaceID           int32          `json:"workspace_id"`
+	Name                  string         `json:"name"`
+	CutoffDay             int32          `json:"cutoff_day"`
+	DefaultInterestRate   pgtype.Numeric `json:"default_interest_rate"`
+	MonthlyFee            pgtype.Numeric `json:"monthly_fee"`
+	FeeMode               string         `json:"fee_mode"`
+	PromoInterestRate     pgtype.Numeric `json:"promo_interest_rate"`
+	PromoMonths           int32          `json:"promo_months"`
+	DefaultInterestMethod string         `json:"default_interest_method"`
 }
 
 func (q *Queries) CreateLoanProvider(ctx context.Context, arg CreateLoanProviderParams) (LoanProvider, error) {
@@ -35,6 +45,11 @@ func (q *Queries) CreateLoanProvider(ctx context.Context, arg CreateLoanProvider
 		arg.Name,
 		arg.CutoffDay,
 		arg.DefaultInterestRate,
+		arg.MonthlyFee,
+		arg.FeeMode,
+		arg.PromoInterestRate,
+		arg.PromoMonths,
+		arg.DefaultInterestMethod,
 	)
 	var i LoanProvider
 	err := row.Scan(
@@ -47,6 +62,11 @@ func (q *Queries) CreateLoanProvider(ctx context.Context, arg CreateLoanProvider
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.PaymentMode,
+		&i.MonthlyFee,
+		&i.FeeMode,
+		&i.PromoInterestRate,
+		&i.PromoMonths,
+		&i.DefaultInterestMethod,
 	)
 	return i, err
 }
@@ -68,7 +88,7 @@ func (q *Queries) DeleteLoanProvider(ctx context.Context, arg DeleteLoanProvider
 }
 
 const getLoanProviderByID = `-- name: GetLoanProviderByID :one
-SELECT id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode FROM loan_providers
+SELECT id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode, monthly_fee, fee_mode, promo_interest_rate, promo_months, default_interest_method FROM loan_providers
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 `
 
@@ -90,12 +110,17 @@ func (q *Queries) GetLoanProviderByID(ctx context.Context, arg GetLoanProviderBy
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.PaymentMode,
+		&i.MonthlyFee,
+		&i.FeeMode,
+		&i.PromoInterestRate,
+		&i.PromoMonths,
+		&i.DefaultInterestMethod,
 	)
 	return i, err
 }
 
 const listLoanProviders = `-- name: ListLoanProviders :many
-SELECT id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode FROM loan_providers
+SELECT id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode, monthly_fee, fee_mode, promo_interest_rate, promo_months, default_interest_method FROM loan_providers
 WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY name ASC
 `
@@ -119,6 +144,11 @@ func (q *Queries) ListLoanProviders(ctx context.Context, workspaceID int32) ([]L
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.PaymentMode,
+			&i.MonthlyFee,
+			&i.FeeMode,
+			&i.PromoInterestRate,
+			&i.PromoMonths,
+			&i.DefaultInterestMethod,
 		); err != nil {
 			return nil, err
 		}
@@ -137,18 +167,28 @@ SET
     cutoff_day = $4,
     default_interest_rate = $5,
     payment_mode = COALESCE(NULLIF($6::text, ''), payment_mode),
+    monthly_fee = $7,
+    fee_mode = COALESCE(NULLIF($8::text, ''), fee_mode),
+    promo_interest_rate = $9,
+    promo_months = $10,
+    default_interest_method = COALESCE(NULLIF($11::text, ''), default_interest_method),
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode
+RETURNING id, workspace_id, name, cutoff_day, default_interest_rate, created_at, updated_at, deleted_at, payment_mode, monthly_fee, fee_mode, promo_interest_rate, promo_months, default_interest_method
 `
 
 type UpdateLoanProviderParams struct {
-	ID                  int32          `json:"id"`
-	WorkspaceID         int32          `json:"workspace_id"`
-	Name                string         `json:"name"`
-	CutoffDay           int32          `json:"cutoff_day"`
-	DefaultInterestRate pgtype.Numeric `json:"default_interest_rate"`
-	PaymentMode         string         `json:"payment_mode"`
+	ID                    int32          `json:"id"`
+	WorkspaceID           int32          `json:"workspace_id"`
+	Name                  string         `json:"name"`
+	CutoffDay             int32          `json:"cutoff_day"`
+	DefaultInterestRate   pgtype.Numeric `json:"default_interest_rate"`
+	PaymentMode           string         `json:"payment_mode"`
+	MonthlyFee            pgtype.Numeric `json:"monthly_fee"`
+	FeeMode               string         `json:"fee_mode"`
+	PromoInterestRate     pgtype.Numeric `json:"promo_interest_rate"`
+	PromoMonths           int32          `json:"promo_months"`
+	DefaultInterestMethod string         `json:"default_interest_method"`
 }
 
 func (q *Queries) UpdateLoanProvider(ctx context.Context, arg UpdateLoanProviderParams) (LoanProvider, error) {
@@ -159,6 +199,11 @@ func (q *Queries) UpdateLoanProvider(ctx context.Context, arg UpdateLoanProvider
 		arg.CutoffDay,
 		arg.DefaultInterestRate,
 		arg.PaymentMode,
+		arg.MonthlyFee,
+		arg.FeeMode,
+		arg.PromoInterestRate,
+		arg.PromoMonths,
+		arg.DefaultInterestMethod,
 	)
 	var i LoanProvider
 	err := row.Scan(
@@ -171,6 +216,11 @@ func (q *Queries) UpdateLoanProvider(ctx context.Context, arg UpdateLoanProvider
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.PaymentMode,
+		&i.MonthlyFee,
+		&i.FeeMode,
+		&i.PromoInterestRate,
+		&i.PromoMonths,
+		&i.DefaultInterestMethod,
 	)
 	return i, err
 }
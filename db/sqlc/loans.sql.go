@@ -14,6 +14,7 @@ import (
 const countActiveLoansByProvider = `-- name: CountActiveLoansByProvider :one
 SELECT COUNT(*) FROM loans
 WHERE provider_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+  AND completed_at IS NULL
   AND (
     (first_payment_year + ((first_payment_month - 1 + num_months - 1) / 12)) > $3
     OR (
@@ -56,11 +57,13 @@ INSERT INTO loans (
     first_payment_month,
     account_id,
     settlement_intent,
-    notes
+    notes,
+    down_payment_amount,
+    interest_method
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
-RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent
+RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method
 `
 
 type CreateLoanParams struct {
@@ -77,6 +80,8 @@ type CreateLoanParams struct {
 	AccountID         pgtype.Int4    `json:"account_id"`
 	SettlementIntent  pgtype.Text    `json:"settlement_intent"`
 	Notes             pgtype.Text    `json:"notes"`
+	DownPaymentAmount pgtype.Numeric `json:"down_payment_amount"`
+	InterestMethod    string         `json:"interest_method"`
 }
 
 func (q *Queries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error) {
@@ -94,6 +99,8 @@ func (q *Queries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, e
 		arg.AccountID,
 		arg.SettlementIntent,
 		arg.Notes,
+		arg.DownPaymentAmount,
+		arg.InterestMethod,
 	)
 	var i Loan
 	err := row.Scan(
@@ -114,6 +121,9 @@ func (q *Queries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, e
 		&i.DeletedAt,
 		&i.AccountID,
 		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
 	)
 	return i, err
 }
@@ -153,16 +163,19 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
 GROUP BY l.id
-HAVING COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) > 0
+HAVING l.completed_at IS NULL AND COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) > 0
 ORDER BY l.created_at DESC
 `
 
@@ -184,6 +197,9 @@ type GetActiveLoansWithStatsRow struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
 	LastPaymentYear   int32              `json:"last_payment_year"`
 	LastPaymentMonth  int32              `json:"last_payment_month"`
 	TotalCount        int32              `json:"total_count"`
@@ -219,6 +235,9 @@ func (q *Queries) GetActiveLoansWithStats(ctx context.Context, workspaceID int32
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 			&i.LastPaymentYear,
 			&i.LastPaymentMonth,
 			&i.TotalCount,
@@ -254,16 +273,19 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
 GROUP BY l.id
-HAVING COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) = 0
+HAVING l.completed_at IS NOT NULL OR COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0) = 0
 ORDER BY l.created_at DESC
 `
 
@@ -285,6 +307,9 @@ type GetCompletedLoansWithStatsRow struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
 	LastPaymentYear   int32              `json:"last_payment_year"`
 	LastPaymentMonth  int32              `json:"last_payment_month"`
 	TotalCount        int32              `json:"total_count"`
@@ -320,6 +345,9 @@ func (q *Queries) GetCompletedLoansWithStats(ctx context.Context, workspaceID in
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 			&i.LastPaymentYear,
 			&i.LastPaymentMonth,
 			&i.TotalCount,
@@ -336,8 +364,46 @@ func (q *Queries) GetCompletedLoansWithStats(ctx context.Context, workspaceID in
 	return items, nil
 }
 
+const getDeletedLoanByID = `-- name: GetDeletedLoanByID :one
+SELECT id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method FROM loans
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+`
+
+type GetDeletedLoanByIDParams struct {
+	ID          int32 `json:"id"`
+	WorkspaceID int32 `json:"workspace_id"`
+}
+
+func (q *Queries) GetDeletedLoanByID(ctx context.Context, arg GetDeletedLoanByIDParams) (Loan, error) {
+	row := q.db.QueryRow(ctx, getDeletedLoanByID, arg.ID, arg.WorkspaceID)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ProviderID,
+		&i.ItemName,
+		&i.TotalAmount,
+		&i.NumMonths,
+		&i.PurchaseDate,
+		&i.InterestRate,
+		&i.MonthlyPayment,
+		&i.FirstPaymentYear,
+		&i.FirstPaymentMonth,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountID,
+		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
+	)
+	return i, err
+}
+
 const getLoanByID = `-- name: GetLoanByID :one
-SELECT id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent FROM loans
+SELECT id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method FROM loans
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 `
 
@@ -367,6 +433,9 @@ func (q *Queries) GetLoanByID(ctx context.Context, arg GetLoanByIDParams) (Loan,
 		&i.DeletedAt,
 		&i.AccountID,
 		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
 	)
 	return i, err
 }
@@ -391,20 +460,29 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     -- Calculated last payment month/year
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
     -- Payment stats from transactions
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
-WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
+WHERE l.workspace_id = $1
+  AND (l.deleted_at IS NULL OR $2::BOOLEAN)
 GROUP BY l.id
 ORDER BY l.created_at DESC
 `
 
+type GetLoansWithStatsParams struct {
+	WorkspaceID    int32 `json:"workspace_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+}
+
 type GetLoansWithStatsRow struct {
 	ID                int32              `json:"id"`
 	WorkspaceID       int32              `json:"workspace_id"`
@@ -423,6 +501,9 @@ type GetLoansWithStatsRow struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
 	LastPaymentYear   int32              `json:"last_payment_year"`
 	LastPaymentMonth  int32              `json:"last_payment_month"`
 	TotalCount        int32              `json:"total_count"`
@@ -432,8 +513,9 @@ type GetLoansWithStatsRow struct {
 
 // CL v2: Use transactions with loan_id instead of loan_payments table
 // Get all loans with payment stats calculated from transactions
-func (q *Queries) GetLoansWithStats(ctx context.Context, workspaceID int32) ([]GetLoansWithStatsRow, error) {
-	rows, err := q.db.Query(ctx, getLoansWithStats, workspaceID)
+// Soft-deleted loans are listed only when include_deleted is set
+func (q *Queries) GetLoansWithStats(ctx context.Context, arg GetLoansWithStatsParams) ([]GetLoansWithStatsRow, error) {
+	rows, err := q.db.Query(ctx, getLoansWithStats, arg.WorkspaceID, arg.IncludeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -459,6 +541,9 @@ func (q *Queries) GetLoansWithStats(ctx context.Context, workspaceID int32) ([]G
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 			&i.LastPaymentYear,
 			&i.LastPaymentMonth,
 			&i.TotalCount,
@@ -494,13 +579,16 @@ SELECT
     l.created_at,
     l.updated_at,
     l.deleted_at,
+    l.completed_at,
+    l.down_payment_amount,
+    l.interest_method,
     (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12))::INTEGER as last_payment_year,
     (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1)::INTEGER as last_payment_month,
-    COUNT(t.id)::INTEGER as total_count,
-    COUNT(t.id) FILTER (WHERE t.is_paid = true)::INTEGER as paid_count,
+    COUNT(t.id) FILTER (WHERE t.is_loan_fee = false)::INTEGER as total_count,
+    COUNT(t.id) FILTER (WHERE t.is_paid = true AND t.is_loan_fee = false)::INTEGER as paid_count,
     COALESCE(SUM(t.amount) FILTER (WHERE t.is_paid = false), 0)::NUMERIC(12,2) as remaining_balance
 FROM loans l
-LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL
+LEFT JOIN transactions t ON t.loan_id = l.id AND t.deleted_at IS NULL AND t.source <> 'loan_down_payment'
 WHERE l.workspace_id = $1 AND l.provider_id = $2 AND l.deleted_at IS NULL
 GROUP BY l.id
 ORDER BY (COUNT(t.id) FILTER (WHERE t.is_paid = false) > 0) DESC, l.item_name ASC
@@ -529,6 +617,9 @@ type GetLoansWithStatsByProviderRow struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
 	LastPaymentYear   int32              `json:"last_payment_year"`
 	LastPaymentMonth  int32              `json:"last_payment_month"`
 	TotalCount        int32              `json:"total_count"`
@@ -565,6 +656,9 @@ func (q *Queries) GetLoansWithStatsByProvider(ctx context.Context, arg GetLoansW
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 			&i.LastPaymentYear,
 			&i.LastPaymentMonth,
 			&i.TotalCount,
@@ -582,9 +676,10 @@ func (q *Queries) GetLoansWithStatsByProvider(ctx context.Context, arg GetLoansW
 }
 
 const listActiveLoans = `-- name: ListActiveLoans :many
-SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent FROM loans l
+SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent, l.completed_at, l.down_payment_amount, l.interest_method FROM loans l
 WHERE l.workspace_id = $1
   AND l.deleted_at IS NULL
+  AND l.completed_at IS NULL
   AND (
     -- Loan is active if there are remaining payments
     -- Current month is before or equal to last payment month
@@ -632,6 +727,9 @@ func (q *Queries) ListActiveLoans(ctx context.Context, arg ListActiveLoansParams
 			&i.DeletedAt,
 			&i.AccountID,
 			&i.SettlementIntent,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 		); err != nil {
 			return nil, err
 		}
@@ -644,12 +742,14 @@ func (q *Queries) ListActiveLoans(ctx context.Context, arg ListActiveLoansParams
 }
 
 const listCompletedLoans = `-- name: ListCompletedLoans :many
-SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent FROM loans l
+SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent, l.completed_at, l.down_payment_amount, l.interest_method FROM loans l
 WHERE l.workspace_id = $1
   AND l.deleted_at IS NULL
   AND (
-    -- Loan is completed if current month is past the last payment month
-    (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) < $2
+    -- Loan is completed once fully paid, even ahead of schedule
+    l.completed_at IS NOT NULL
+    -- Otherwise completed if current month is past the last payment month
+    OR (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) < $2
     OR (
       (l.first_payment_year + ((l.first_payment_month - 1 + l.num_months - 1) / 12)) = $2
       AND (((l.first_payment_month - 1 + l.num_months - 1) % 12) + 1) < $3
@@ -691,6 +791,57 @@ func (q *Queries) ListCompletedLoans(ctx context.Context, arg ListCompletedLoans
 			&i.DeletedAt,
 			&i.AccountID,
 			&i.SettlementIntent,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedLoans = `-- name: ListDeletedLoans :many
+SELECT id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method FROM loans
+WHERE workspace_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+// Soft-deleted loans (trash), most recently deleted first
+func (q *Queries) ListDeletedLoans(ctx context.Context, workspaceID int32) ([]Loan, error) {
+	rows, err := q.db.Query(ctx, listDeletedLoans, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Loan{}
+	for rows.Next() {
+		var i Loan
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ProviderID,
+			&i.ItemName,
+			&i.TotalAmount,
+			&i.NumMonths,
+			&i.PurchaseDate,
+			&i.InterestRate,
+			&i.MonthlyPayment,
+			&i.FirstPaymentYear,
+			&i.FirstPaymentMonth,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AccountID,
+			&i.SettlementIntent,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 		); err != nil {
 			return nil, err
 		}
@@ -703,17 +854,102 @@ func (q *Queries) ListCompletedLoans(ctx context.Context, arg ListCompletedLoans
 }
 
 const listLoans = `-- name: ListLoans :many
-SELECT id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent FROM loans
-WHERE workspace_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC
+
+SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent, l.completed_at, l.down_payment_amount, l.interest_method, lp.name AS provider_name
+FROM loans l
+JOIN loan_providers lp ON lp.id = l.provider_id
+WHERE l.workspace_id = $1 AND l.deleted_at IS NULL
+ORDER BY l.created_at DESC
 `
 
-func (q *Queries) ListLoans(ctx context.Context, workspaceID int32) ([]Loan, error) {
+type ListLoansRow struct {
+	ID                int32              `json:"id"`
+	WorkspaceID       int32              `json:"workspace_id"`
+	ProviderID        int32              `json:"provider_id"`
+	ItemName          string             `json:"item_name"`
+	TotalAmount       pgtype.Numeric     `json:"total_amount"`
+	NumMonths         int32              `json:"num_months"`
+	PurchaseDate      pgtype.Date        `json:"purchase_date"`
+	InterestRate      pgtype.Numeric     `json:"interest_rate"`
+	MonthlyPayment    pgtype.Numeric     `json:"monthly_payment"`
+	FirstPaymentYear  int32              `json:"first_payment_year"`
+	FirstPaymentMonth int32              `json:"first_payment_month"`
+	Notes             pgtype.Text        `json:"notes"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	AccountID         pgtype.Int4        `json:"account_id"`
+	SettlementIntent  pgtype.Text        `json:"settlement_intent"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
+	ProviderName      string             `json:"provider_name"`
+}
+
+// Provider name is joined so callers can label loans without a second lookup
+func (q *Queries) ListLoans(ctx context.Context, workspaceID int32) ([]ListLoansRow, error) {
 	rows, err := q.db.Query(ctx, listLoans, workspaceID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	items := []ListLoansRow{}
+	for rows.Next() {
+		var i ListLoansRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ProviderID,
+			&i.ItemName,
+			&i.TotalAmount,
+			&i.NumMonths,
+			&i.PurchaseDate,
+			&i.InterestRate,
+			&i.MonthlyPayment,
+			&i.FirstPaymentYear,
+			&i.FirstPaymentMonth,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AccountID,
+			&i.SettlementIntent,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
+			&i.ProviderName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoansWithoutSchedule = `-- name: ListLoansWithoutSchedule :many
+
+SELECT l.id, l.workspace_id, l.provider_id, l.item_name, l.total_amount, l.num_months, l.purchase_date, l.interest_rate, l.monthly_payment, l.first_payment_year, l.first_payment_month, l.notes, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.settlement_intent, l.completed_at, l.down_payment_amount, l.interest_method FROM loans l
+WHERE l.workspace_id = $1
+  AND l.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM transactions t
+    WHERE t.loan_id = l.id
+      AND t.source <> 'loan_down_payment'
+  )
+ORDER BY l.created_at
+`
+
+// Loans that never had a scheduled transaction. Soft-deleted rows still count, so a fully
+// refunded loan is not mistaken for one that was imported without a schedule
+func (q *Queries) ListLoansWithoutSchedule(ctx context.Context, workspaceID int32) ([]Loan, error) {
+	rows, err := q.db.Query(ctx, listLoansWithoutSchedule, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	items := []Loan{}
 	for rows.Next() {
 		var i Loan
@@ -735,6 +971,9 @@ func (q *Queries) ListLoans(ctx context.Context, workspaceID int32) ([]Loan, err
 			&i.DeletedAt,
 			&i.AccountID,
 			&i.SettlementIntent,
+			&i.CompletedAt,
+			&i.DownPaymentAmount,
+			&i.InterestMethod,
 		); err != nil {
 			return nil, err
 		}
@@ -746,6 +985,64 @@ func (q *Queries) ListLoans(ctx context.Context, workspaceID int32) ([]Loan, err
 	return items, nil
 }
 
+const restoreLoan = `-- name: RestoreLoan :one
+UPDATE loans
+SET deleted_at = NULL, updated_at = NOW()
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method
+`
+
+type RestoreLoanParams struct {
+	ID          int32 `json:"id"`
+	WorkspaceID int32 `json:"workspace_id"`
+}
+
+func (q *Queries) RestoreLoan(ctx context.Context, arg RestoreLoanParams) (Loan, error) {
+	row := q.db.QueryRow(ctx, restoreLoan, arg.ID, arg.WorkspaceID)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ProviderID,
+		&i.ItemName,
+		&i.TotalAmount,
+		&i.NumMonths,
+		&i.PurchaseDate,
+		&i.InterestRate,
+		&i.MonthlyPayment,
+		&i.FirstPaymentYear,
+		&i.FirstPaymentMonth,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountID,
+		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
+	)
+	return i, err
+}
+
+const setLoanCompletedAt = `-- name: SetLoanCompletedAt :exec
+UPDATE loans
+SET completed_at = $3, updated_at = NOW()
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+`
+
+type SetLoanCompletedAtParams struct {
+	ID          int32              `json:"id"`
+	WorkspaceID int32              `json:"workspace_id"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+// Records (or clears, when NULL) the time a loan became fully paid
+func (q *Queries) SetLoanCompletedAt(ctx context.Context, arg SetLoanCompletedAtParams) error {
+	_, err := q.db.Exec(ctx, setLoanCompletedAt, arg.ID, arg.WorkspaceID, arg.CompletedAt)
+	return err
+}
+
 const updateLoan = `-- name: UpdateLoan :one
 UPDATE loans
 SET item_name = $3,
@@ -759,7 +1056,7 @@ SET item_name = $3,
     notes = $11,
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent
+RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method
 `
 
 type UpdateLoanParams struct {
@@ -809,6 +1106,9 @@ func (q *Queries) UpdateLoan(ctx context.Context, arg UpdateLoanParams) (Loan, e
 		&i.DeletedAt,
 		&i.AccountID,
 		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
 	)
 	return i, err
 }
@@ -820,7 +1120,7 @@ SET item_name = $3,
     notes = $5,
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent
+RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method
 `
 
 type UpdateLoanEditableFieldsParams struct {
@@ -860,6 +1160,9 @@ func (q *Queries) UpdateLoanEditableFields(ctx context.Context, arg UpdateLoanEd
 		&i.DeletedAt,
 		&i.AccountID,
 		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
 	)
 	return i, err
 }
@@ -870,7 +1173,7 @@ SET item_name = $3,
     notes = $4,
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent
+RETURNING id, workspace_id, provider_id, item_name, total_amount, num_months, purchase_date, interest_rate, monthly_payment, first_payment_year, first_payment_month, notes, created_at, updated_at, deleted_at, account_id, settlement_intent, completed_at, down_payment_amount, interest_method
 `
 
 type UpdateLoanPartialParams struct {
@@ -907,6 +1210,9 @@ func (q *Queries) UpdateLoanPartial(ctx context.Context, arg UpdateLoanPartialPa
 		&i.DeletedAt,
 		&i.AccountID,
 		&i.SettlementIntent,
+		&i.CompletedAt,
+		&i.DownPaymentAmount,
+		&i.InterestMethod,
 	)
 	return i, err
 }
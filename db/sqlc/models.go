@@ -18,6 +18,18 @@ type Account struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	AccountGroupID pgtype.Int4        `json:"account_group_id"`
+	// When true the account is still listed but its balance is left out of net worth.
+	ExcludeFromNetWorth bool `json:"exclude_from_net_worth"`
+}
+
+type AccountGroup struct {
+	ID          int32              `json:"id"`
+	WorkspaceID int32              `json:"workspace_id"`
+	Name        string             `json:"name"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 }
 
 type ApiToken struct {
@@ -44,12 +56,16 @@ type BudgetAllocation struct {
 }
 
 type BudgetCategory struct {
-	ID          int32              `json:"id"`
-	WorkspaceID int32              `json:"workspace_id"`
-	Name        string             `json:"name"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ID              int32              `json:"id"`
+	WorkspaceID     int32              `json:"workspace_id"`
+	Name            string             `json:"name"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	Description     pgtype.Text        `json:"description"`
+	Color           pgtype.Text        `json:"color"`
+	ParentID        pgtype.Int4        `json:"parent_id"`
+	IsTaxDeductible bool               `json:"is_tax_deductible"`
 }
 
 type Loan struct {
@@ -70,18 +86,26 @@ type Loan struct {
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
 	AccountID         pgtype.Int4        `json:"account_id"`
 	SettlementIntent  pgtype.Text        `json:"settlement_intent"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	DownPaymentAmount pgtype.Numeric     `json:"down_payment_amount"`
+	InterestMethod    string             `json:"interest_method"`
 }
 
 type LoanProvider struct {
-	ID                  int32              `json:"id"`
-	WorkspaceID         int32              `json:"workspace_id"`
-	Name                string             `json:"name"`
-	CutoffDay           int32              `json:"cutoff_day"`
-	DefaultInterestRate pgtype.Numeric     `json:"default_interest_rate"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
-	PaymentMode         string             `json:"payment_mode"`
+	ID                    int32              `json:"id"`
+	WorkspaceID           int32              `json:"workspace_id"`
+	Name                  string             `json:"name"`
+	CutoffDay             int32              `json:"cutoff_day"`
+	DefaultInterestRate   pgtype.Numeric     `json:"default_interest_rate"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt             pgtype.Timestamptz `json:"deleted_at"`
+	PaymentMode           string             `json:"payment_mode"`
+	MonthlyFee            pgtype.Numeric     `json:"monthly_fee"`
+	FeeMode               string             `json:"fee_mode"`
+	PromoInterestRate     pgtype.Numeric     `json:"promo_interest_rate"`
+	PromoMonths           int32              `json:"promo_months"`
+	DefaultInterestMethod string             `json:"default_interest_method"`
 }
 
 type Month struct {
@@ -94,6 +118,8 @@ type Month struct {
 	StartingBalance pgtype.Numeric     `json:"starting_balance"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	// When the month was closed; NULL while still open.
+	ClosedAt pgtype.Timestamptz `json:"closed_at"`
 }
 
 type ProjectionExclusion struct {
@@ -146,6 +172,32 @@ type Transaction struct {
 	IsProjected      pgtype.Bool        `json:"is_projected"`
 	LoanID           pgtype.Int4        `json:"loan_id"`
 	GroupID          pgtype.Int4        `json:"group_id"`
+	// Future-dated transaction excluded from balances until its date passes or it is promoted.
+	IsScheduled bool `json:"is_scheduled"`
+	// When the payment actually occurred; may differ from transaction_date for late settlements.
+	PaidAt pgtype.Timestamptz `json:"paid_at"`
+	// Normalized merchant for reporting, separate from the free-text name.
+	Merchant pgtype.Text `json:"merchant"`
+	// Original transfer pair that this transfer reverses, if any.
+	ReversesTransferPairID pgtype.UUID `json:"reverses_transfer_pair_id"`
+	IsRefund               bool        `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4 `json:"deleted_loan_id"`
+	ExcludeFromReports     bool        `json:"exclude_from_reports"`
+	IsTaxDeductible        bool        `json:"is_tax_deductible"`
+	// Counterparty who was paid; used for spend-by-payee reporting.
+	Payee pgtype.Text `json:"payee"`
+	// Set when a template-sourced transaction was edited away from its template amount or category.
+	ModifiedFromTemplate bool `json:"modified_from_template"`
+	// Latitude in degrees (-90 to 90); set together with longitude.
+	Latitude pgtype.Numeric `json:"latitude"`
+	// Longitude in degrees (-180 to 180); set together with latitude.
+	Longitude pgtype.Numeric `json:"longitude"`
+	// Free-form place name, with or without coordinates.
+	Location pgtype.Text `json:"location"`
+	// Expense whose round-up this transfer leg moves to savings, if any.
+	RoundUpSourceID pgtype.Int4 `json:"round_up_source_id"`
+	// True for the separate monthly fee line generated alongside a loan payment.
+	IsLoanFee bool `json:"is_loan_fee"`
 }
 
 type TransactionGroup struct {
@@ -214,9 +266,33 @@ type WishlistItemPrice struct {
 }
 
 type Workspace struct {
-	ID        int32              `json:"id"`
-	UserID    pgtype.UUID        `json:"user_id"`
-	Name      string             `json:"name"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID                      int32              `json:"id"`
+	UserID                  pgtype.UUID        `json:"user_id"`
+	Name                    string             `json:"name"`
+	CreatedAt               pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+	Currency                string             `json:"currency"`
+	RoundUpSavingsAccountID pgtype.Int4        `json:"round_up_savings_account_id"`
+	// Months ahead of the current month that recurring projections are generated for (0-12).
+	GenerationHorizonMonths int32 `json:"generation_horizon_months"`
+}
+
+type WorkspaceInvite struct {
+	ID          int32              `json:"id"`
+	WorkspaceID int32              `json:"workspace_id"`
+	Email       string             `json:"email"`
+	Role        string             `json:"role"`
+	Token       string             `json:"token"`
+	InvitedBy   pgtype.UUID        `json:"invited_by"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	AcceptedAt  pgtype.Timestamptz `json:"accepted_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	ID          int32              `json:"id"`
+	WorkspaceID int32              `json:"workspace_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Role        string             `json:"role"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
@@ -11,10 +11,44 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const closeMonth = `-- name: CloseMonth :execrows
+UPDATE months
+SET closed_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND id = $2 AND closed_at IS NULL
+`
+
+type CloseMonthParams struct {
+	WorkspaceID int32 `json:"workspace_id"`
+	ID          int32 `json:"id"`
+}
+
+func (q *Queries) CloseMonth(ctx context.Context, arg CloseMonthParams) (int64, error) {
+	result, err := q.db.Exec(ctx, closeMonth, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const closeMonthsBefore = `-- name: CloseMonthsBefore :execrows
+UPDATE months
+SET closed_at = NOW(), updated_at = NOW()
+WHERE closed_at IS NULL AND year * 12 + month < $1::int
+`
+
+// Close every open month, across workspaces, whose year * 12 + month is below the index
+func (q *Queries) CloseMonthsBefore(ctx context.Context, monthIndex int32) (int64, error) {
+	result, err := q.db.Exec(ctx, closeMonthsBefore, monthIndex)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createMonth = `-- name: CreateMonth :one
 INSERT INTO months (workspace_id, year, month, start_date, end_date, starting_balance)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at
+RETURNING id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at, closed_at
 `
 
 type CreateMonthParams struct {
@@ -46,12 +80,13 @@ func (q *Queries) CreateMonth(ctx context.Context, arg CreateMonthParams) (Month
 		&i.StartingBalance,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const getAllMonths = `-- name: GetAllMonths :many
-SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at FROM months
+SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at, closed_at FROM months
 WHERE workspace_id = $1
 ORDER BY year DESC, month DESC
 `
@@ -75,6 +110,7 @@ func (q *Queries) GetAllMonths(ctx context.Context, workspaceID int32) ([]Month,
 			&i.StartingBalance,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -87,7 +123,7 @@ func (q *Queries) GetAllMonths(ctx context.Context, workspaceID int32) ([]Month,
 }
 
 const getLatestMonth = `-- name: GetLatestMonth :one
-SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at FROM months
+SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at, closed_at FROM months
 WHERE workspace_id = $1
 ORDER BY year DESC, month DESC
 LIMIT 1
@@ -106,12 +142,13 @@ func (q *Queries) GetLatestMonth(ctx context.Context, workspaceID int32) (Month,
 		&i.StartingBalance,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const getMonthByYearMonth = `-- name: GetMonthByYearMonth :one
-SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at FROM months
+SELECT id, workspace_id, year, month, start_date, end_date, starting_balance, created_at, updated_at, closed_at FROM months
 WHERE workspace_id = $1 AND year = $2 AND month = $3
 `
 
@@ -134,6 +171,7 @@ func (q *Queries) GetMonthByYearMonth(ctx context.Context, arg GetMonthByYearMon
 		&i.StartingBalance,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ClosedAt,
 	)
 	return i, err
 }
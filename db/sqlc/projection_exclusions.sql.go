@@ -37,6 +37,22 @@ func (q *Queries) DeleteExclusionsByTemplate(ctx context.Context, templateID int
 	return err
 }
 
+const deleteProjectionExclusion = `-- name: DeleteProjectionExclusion :exec
+DELETE FROM projection_exclusions
+WHERE workspace_id = $1 AND template_id = $2 AND excluded_month = $3
+`
+
+type DeleteProjectionExclusionParams struct {
+	WorkspaceID   int32       `json:"workspace_id"`
+	TemplateID    int32       `json:"template_id"`
+	ExcludedMonth pgtype.Date `json:"excluded_month"`
+}
+
+func (q *Queries) DeleteProjectionExclusion(ctx context.Context, arg DeleteProjectionExclusionParams) error {
+	_, err := q.db.Exec(ctx, deleteProjectionExclusion, arg.WorkspaceID, arg.TemplateID, arg.ExcludedMonth)
+	return err
+}
+
 const getExclusionsByTemplate = `-- name: GetExclusionsByTemplate :many
 SELECT id, workspace_id, template_id, excluded_month, created_at FROM projection_exclusions
 WHERE workspace_id = $1 AND template_id = $2
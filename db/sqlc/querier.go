@@ -11,6 +11,7 @@ import (
 )
 
 type Querier interface {
+	AddWorkspaceMember(ctx context.Context, arg AddWorkspaceMemberParams) (WorkspaceMember, error)
 	AssignGroupToTransactions(ctx context.Context, arg AssignGroupToTransactionsParams) error
 	// Bulk mark loan transactions as paid by IDs with timestamp
 	BatchMarkLoanTransactionsPaid(ctx context.Context, arg BatchMarkLoanTransactionsPaidParams) ([]Transaction, error)
@@ -18,13 +19,28 @@ type Querier interface {
 	BatchMarkLoanTransactionsUnpaid(ctx context.Context, arg BatchMarkLoanTransactionsUnpaidParams) ([]Transaction, error)
 	// Batch toggle multiple transactions from pending to billed
 	BatchToggleToBilled(ctx context.Context, arg BatchToggleToBilledParams) ([]Transaction, error)
+	// Close a CC billing cycle: pending purchases dated on/before the cutoff become billed
+	BillPendingCCTransactions(ctx context.Context, arg BillPendingCCTransactionsParams) ([]Transaction, error)
 	// Bulk mark transactions as paid by IDs (works for both bank and CC transactions)
 	// For CC transactions, this also effectively sets cc_state to 'settled' since it's computed from is_paid
 	BulkMarkTransactionsPaid(ctx context.Context, arg BulkMarkTransactionsPaidParams) ([]Transaction, error)
 	// Bulk update multiple transactions to settled state (is_paid = true)
 	BulkSettleTransactions(ctx context.Context, arg BulkSettleTransactionsParams) ([]Transaction, error)
+	// Reassign transactions to another account in a single statement
+	// Transfer legs stay put (moving one would break the pair) and rows already on the target are skipped
+	BulkUpdateTransactionAccount(ctx context.Context, arg BulkUpdateTransactionAccountParams) ([]Transaction, error)
+	// Unlink transactions whose loan no longer exists or was deleted
+	ClearOrphanedLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error)
+	// Soft delete unpaid transactions still linked to a loan
+	// Used when a loan schedule is regenerated; unlike DeleteUnpaidTransactionsByLoan nothing is kept for restore
+	ClearUnpaidTransactionsByLoan(ctx context.Context, arg ClearUnpaidTransactionsByLoanParams) error
+	CloseMonth(ctx context.Context, arg CloseMonthParams) (int64, error)
+	// Close every open month, across workspaces, whose year * 12 + month is below the index
+	CloseMonthsBefore(ctx context.Context, monthIndex int32) (int64, error)
 	// Copies all allocations from one month to another (atomic, skips deleted categories)
 	CopyAllocationsToMonth(ctx context.Context, arg CopyAllocationsToMonthParams) error
+	// Count every transaction on an account, including soft-deleted ones that can still be restored
+	CountAccountTransactions(ctx context.Context, arg CountAccountTransactionsParams) (int64, error)
 	CountActiveLoansByProvider(ctx context.Context, arg CountActiveLoansByProviderParams) (int64, error)
 	// Returns the count of allocations for a specific month (for lazy initialization check)
 	CountAllocationsForMonth(ctx context.Context, arg CountAllocationsForMonthParams) (int64, error)
@@ -36,6 +52,7 @@ type Querier interface {
 	CountWishlistItems(ctx context.Context, arg CountWishlistItemsParams) (int64, error)
 	CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountGroup(ctx context.Context, arg CreateAccountGroupParams) (AccountGroup, error)
 	CreateBudgetCategory(ctx context.Context, arg CreateBudgetCategoryParams) (BudgetCategory, error)
 	CreateGroup(ctx context.Context, arg CreateGroupParams) (TransactionGroup, error)
 	CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error)
@@ -52,33 +69,43 @@ type Querier interface {
 	CreateWishlistItemNote(ctx context.Context, arg CreateWishlistItemNoteParams) (WishlistItemNote, error)
 	CreateWishlistItemPrice(ctx context.Context, arg CreateWishlistItemPriceParams) (WishlistItemPrice, error)
 	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
+	CreateWorkspaceInvite(ctx context.Context, arg CreateWorkspaceInviteParams) (WorkspaceInvite, error)
 	DeleteBudgetAllocation(ctx context.Context, arg DeleteBudgetAllocationParams) error
 	DeleteExclusionsByTemplate(ctx context.Context, templateID int32) error
+	// Soft delete unpaid actual transactions dated after a cutoff (used when deleting template without keeping generated)
+	DeleteFutureUnpaidActualsByTemplate(ctx context.Context, arg DeleteFutureUnpaidActualsByTemplateParams) error
 	DeleteGroup(ctx context.Context, arg DeleteGroupParams) error
 	DeleteLoan(ctx context.Context, arg DeleteLoanParams) error
 	DeleteLoanProvider(ctx context.Context, arg DeleteLoanProviderParams) error
+	DeleteProjectionExclusion(ctx context.Context, arg DeleteProjectionExclusionParams) error
 	// Delete projections beyond a specific date (used when changing template end_date)
 	DeleteProjectionsBeyondDate(ctx context.Context, arg DeleteProjectionsBeyondDateParams) error
 	// Delete unpaid projected transactions for a template (used when deleting template)
 	// Paid projections are preserved and orphaned instead
 	DeleteProjectionsByTemplate(ctx context.Context, arg DeleteProjectionsByTemplateParams) error
 	DeleteRecurringTemplate(ctx context.Context, arg DeleteRecurringTemplateParams) error
-	// Hard delete unpaid transactions for a loan
-	// Used when deleting a loan (unpaid future payments are removed)
+	// Soft delete unpaid transactions for a loan
+	// Used when deleting a loan (unpaid future payments are removed); deleted_loan_id allows a later restore
 	DeleteUnpaidTransactionsByLoan(ctx context.Context, arg DeleteUnpaidTransactionsByLoanParams) error
 	DeleteWishlist(ctx context.Context, arg DeleteWishlistParams) error
 	DeleteWishlistItem(ctx context.Context, arg DeleteWishlistItemParams) error
 	DeleteWishlistItemNote(ctx context.Context, arg DeleteWishlistItemNoteParams) error
 	DeleteWishlistItemPrice(ctx context.Context, arg DeleteWishlistItemPriceParams) error
 	DeleteWorkspace(ctx context.Context, id int32) error
+	// Promotes a deleted category's subcategories to top level
+	DetachBudgetCategoryChildren(ctx context.Context, arg DetachBudgetCategoryChildrenParams) error
 	GetAPITokenByHash(ctx context.Context, tokenHash string) (ApiToken, error)
 	GetAPITokenByID(ctx context.Context, arg GetAPITokenByIDParams) (ApiToken, error)
 	GetAPITokensByWorkspace(ctx context.Context, workspaceID int32) ([]ApiToken, error)
 	GetAccountByID(ctx context.Context, arg GetAccountByIDParams) (Account, error)
 	GetAccountByIDIncludeDeleted(ctx context.Context, arg GetAccountByIDIncludeDeletedParams) (Account, error)
+	GetAccountGroupByID(ctx context.Context, arg GetAccountGroupByIDParams) (AccountGroup, error)
+	GetAccountGroupsByWorkspace(ctx context.Context, workspaceID int32) ([]AccountGroup, error)
 	// For regular accounts: only count paid transactions
 	// For CC accounts: count all expenses (isPaid means settled, not whether purchase happened)
 	GetAccountTransactionSummaries(ctx context.Context, workspaceID int32) ([]GetAccountTransactionSummariesRow, error)
+	// Same sums as GetAccountTransactionSummaries for one account, limited to transactions dated on or before $3
+	GetAccountTransactionSummaryAsOf(ctx context.Context, arg GetAccountTransactionSummaryAsOfParams) (GetAccountTransactionSummaryAsOfRow, error)
 	GetAccountsByWorkspace(ctx context.Context, workspaceID int32) ([]Account, error)
 	GetAccountsByWorkspaceAll(ctx context.Context, workspaceID int32) ([]Account, error)
 	// Get active loans (with remaining balance) with payment stats calculated from transactions
@@ -87,6 +114,8 @@ type Querier interface {
 	// Get all active templates across all workspaces (for daily sync goroutine)
 	GetAllActiveTemplates(ctx context.Context) ([]RecurringTemplate, error)
 	GetAllBudgetCategories(ctx context.Context, workspaceID int32) ([]BudgetCategory, error)
+	// Get all loan transactions (paid and unpaid) for a month, used for commitments aggregation
+	GetAllLoanTransactionsByMonth(ctx context.Context, arg GetAllLoanTransactionsByMonthParams) ([]Transaction, error)
 	GetAllMonths(ctx context.Context, workspaceID int32) ([]Month, error)
 	GetAutoDetectedGroupByProviderMonth(ctx context.Context, arg GetAutoDetectedGroupByProviderMonthParams) (GetAutoDetectedGroupByProviderMonthRow, error)
 	GetBestPriceForItem(ctx context.Context, arg GetBestPriceForItemParams) (string, error)
@@ -94,6 +123,9 @@ type Querier interface {
 	GetBilledCCByMonth(ctx context.Context, arg GetBilledCCByMonthParams) ([]GetBilledCCByMonthRow, error)
 	GetBudgetAllocationByCategory(ctx context.Context, arg GetBudgetAllocationByCategoryParams) (BudgetAllocation, error)
 	GetBudgetAllocationsByMonth(ctx context.Context, arg GetBudgetAllocationsByMonthParams) ([]GetBudgetAllocationsByMonthRow, error)
+	// Every category with its all-time transaction count and net spend; unused categories report zero.
+	// Refunds are netted out of spend and projections are not counted as spend.
+	GetBudgetCategoriesWithUsage(ctx context.Context, workspaceID int32) ([]GetBudgetCategoriesWithUsageRow, error)
 	GetBudgetCategoryByID(ctx context.Context, arg GetBudgetCategoryByIDParams) (BudgetCategory, error)
 	GetBudgetCategoryByName(ctx context.Context, arg GetBudgetCategoryByNameParams) (BudgetCategory, error)
 	// Get CC metrics (pending, outstanding, purchases) for a month range
@@ -104,6 +136,9 @@ type Querier interface {
 	//   1. deferred intent from previous months (billed)
 	//   2. immediate intent from current month (billed)
 	GetCCMetrics(ctx context.Context, arg GetCCMetricsParams) (GetCCMetricsRow, error)
+	// Count and sum CC purchases on one account per CC state (pending, billed, settled)
+	// Payments into the card are income and not part of any state bucket
+	GetCCStateBreakdown(ctx context.Context, arg GetCCStateBreakdownParams) (GetCCStateBreakdownRow, error)
 	// Get total outstanding balance across all CC accounts (sum of unpaid expenses)
 	GetCCOutstandingSummary(ctx context.Context, workspaceID int32) (GetCCOutstandingSummaryRow, error)
 	// Returns all categories with their allocation for a specific month (0 if not set)
@@ -115,12 +150,17 @@ type Querier interface {
 	GetConsolidatedProvidersByMonth(ctx context.Context, arg GetConsolidatedProvidersByMonthParams) ([]GetConsolidatedProvidersByMonthRow, error)
 	GetCurrentPricesByItem(ctx context.Context, arg GetCurrentPricesByItemParams) ([]GetCurrentPricesByItemRow, error)
 	// Get all billed, deferred transactions that need settlement (ordered by date)
-	GetDeferredForSettlement(ctx context.Context, workspaceID int32) ([]GetDeferredForSettlementRow, error)
+	// A NULL $2 covers every account; otherwise only the listed accounts
+	GetDeferredForSettlement(ctx context.Context, arg GetDeferredForSettlementParams) ([]GetDeferredForSettlementRow, error)
+	GetDeletedLoanByID(ctx context.Context, arg GetDeletedLoanByIDParams) (Loan, error)
+	// Returns every source value in use in the workspace, for filter dropdowns
+	GetDistinctTransactionSources(ctx context.Context, workspaceID int32) ([]string, error)
 	// Get earliest unpaid month for a provider (for sequential enforcement)
 	GetEarliestUnpaidLoanMonth(ctx context.Context, arg GetEarliestUnpaidLoanMonthParams) (GetEarliestUnpaidLoanMonthRow, error)
 	GetExclusionsByTemplate(ctx context.Context, arg GetExclusionsByTemplateParams) ([]ProjectionExclusion, error)
 	GetFirstItemImage(ctx context.Context, arg GetFirstItemImageParams) (pgtype.Text, error)
 	GetGroupByID(ctx context.Context, arg GetGroupByIDParams) (GetGroupByIDRow, error)
+	GetGroupChildren(ctx context.Context, arg GetGroupChildrenParams) ([]Transaction, error)
 	GetGroupsByMonth(ctx context.Context, arg GetGroupsByMonthParams) ([]GetGroupsByMonthRow, error)
 	// Get billed transactions with immediate intent for the current month
 	GetImmediateForSettlement(ctx context.Context, arg GetImmediateForSettlementParams) ([]GetImmediateForSettlementRow, error)
@@ -130,6 +170,7 @@ type Querier interface {
 	GetLoanByID(ctx context.Context, arg GetLoanByIDParams) (Loan, error)
 	// Convert loan transactions to LoanPayment format for frontend compatibility
 	// Derives payment_number from row order, extracts year/month from transaction_date
+	// Separate fee lines are not payments and are left out of the numbering
 	// Note: Only uses loan_id since service layer already verified loan ownership
 	GetLoanPaymentsFromTransactions(ctx context.Context, loanID pgtype.Int4) ([]GetLoanPaymentsFromTransactionsRow, error)
 	GetLoanProviderByID(ctx context.Context, arg GetLoanProviderByIDParams) (LoanProvider, error)
@@ -145,7 +186,8 @@ type Querier interface {
 	GetLoanTrendData(ctx context.Context, arg GetLoanTrendDataParams) ([]GetLoanTrendDataRow, error)
 	// CL v2: Use transactions with loan_id instead of loan_payments table
 	// Get all loans with payment stats calculated from transactions
-	GetLoansWithStats(ctx context.Context, workspaceID int32) ([]GetLoansWithStatsRow, error)
+	// Soft-deleted loans are listed only when include_deleted is set
+	GetLoansWithStats(ctx context.Context, arg GetLoansWithStatsParams) ([]GetLoansWithStatsRow, error)
 	// Get all loans for a specific provider with payment stats calculated from transactions
 	// Orders unpaid items first, then by item name
 	GetLoansWithStatsByProvider(ctx context.Context, arg GetLoansWithStatsByProviderParams) ([]GetLoansWithStatsByProviderRow, error)
@@ -153,10 +195,15 @@ type Querier interface {
 	// Batch query to get income/expense totals grouped by year/month for N+1 prevention
 	// Only count paid transactions, excludes transfers
 	GetMonthlyTransactionSummaries(ctx context.Context, workspaceID int32) ([]GetMonthlyTransactionSummariesRow, error)
+	// Transactions linked to a loan that no longer exists or was deleted
+	GetOrphanedLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error)
 	// Get CC transactions that are billed but overdue (2+ months old)
 	GetOverdueCC(ctx context.Context, workspaceID int32) ([]GetOverdueCCRow, error)
 	// Get paid loan payments for a specific provider and month (for unpay-month action)
 	GetPaidLoanPaymentsByProviderMonth(ctx context.Context, arg GetPaidLoanPaymentsByProviderMonthParams) ([]GetPaidLoanPaymentsByProviderMonthRow, error)
+	// Unpaid expenses dated before the given day, oldest first
+	// Excludes transfers and deferred CC (those are tracked by GetOverdueCC)
+	GetPastDueUnpaidTransactions(ctx context.Context, arg GetPastDueUnpaidTransactionsParams) ([]Transaction, error)
 	// Get pending CC transactions (billed_at IS NULL) for a specific month range
 	GetPendingCCByMonth(ctx context.Context, arg GetPendingCCByMonthParams) ([]GetPendingCCByMonthRow, error)
 	// Get pending (not yet billed) deferred CC transactions for visibility
@@ -180,16 +227,22 @@ type Querier interface {
 	GetTransactionsByIDs(ctx context.Context, arg GetTransactionsByIDsParams) ([]Transaction, error)
 	// Get all transactions for a specific loan (both paid and unpaid) for item-based modal
 	GetTransactionsByLoanID(ctx context.Context, arg GetTransactionsByLoanIDParams) ([]Transaction, error)
+	// Get all generated transactions (projected and actual) for a template, newest first
+	GetTransactionsByTemplate(ctx context.Context, arg GetTransactionsByTemplateParams) ([]Transaction, error)
 	GetTransactionsByWorkspace(ctx context.Context, arg GetTransactionsByWorkspaceParams) ([]Transaction, error)
 	// Returns all transactions in a date range with category name for aggregation (no pagination)
 	// Used by dashboard future spending calculations
 	GetTransactionsForAggregation(ctx context.Context, arg GetTransactionsForAggregationParams) ([]GetTransactionsForAggregationRow, error)
 	// Returns transactions with category name and group name joined for display
 	GetTransactionsWithCategory(ctx context.Context, arg GetTransactionsWithCategoryParams) ([]GetTransactionsWithCategoryRow, error)
+	// Returns both legs of a transfer
+	GetTransferPairTransactions(ctx context.Context, arg GetTransferPairTransactionsParams) ([]Transaction, error)
 	GetUngroupedTransactionIDsByProviderMonth(ctx context.Context, arg GetUngroupedTransactionIDsByProviderMonthParams) ([]int32, error)
 	GetUngroupedTransactionsByMonth(ctx context.Context, arg GetUngroupedTransactionsByMonthParams) ([]Transaction, error)
 	// Get unpaid loan payments for a specific provider and month (for pay-month action)
 	GetUnpaidLoanPaymentsByProviderMonth(ctx context.Context, arg GetUnpaidLoanPaymentsByProviderMonthParams) ([]GetUnpaidLoanPaymentsByProviderMonthRow, error)
+	// Unpaid payments of live loans, oldest first, for due/overdue classification
+	GetUnpaidLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error)
 	GetUserByAuth0ID(ctx context.Context, auth0ID string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	GetWishlistByID(ctx context.Context, arg GetWishlistByIDParams) (Wishlist, error)
@@ -200,40 +253,84 @@ type Querier interface {
 	GetWorkspaceByID(ctx context.Context, id int32) (Workspace, error)
 	GetWorkspaceByUserAuth0ID(ctx context.Context, auth0ID string) (Workspace, error)
 	GetWorkspaceByUserID(ctx context.Context, userID pgtype.UUID) (Workspace, error)
+	GetWorkspaceInviteByToken(ctx context.Context, token string) (WorkspaceInvite, error)
+	GetWorkspaceMemberRole(ctx context.Context, arg GetWorkspaceMemberRoleParams) (string, error)
 	HardDeleteAccount(ctx context.Context, arg HardDeleteAccountParams) error
 	// Check if any transactions for this loan are paid (for provider change validation)
 	HasPaidTransactionsByLoan(ctx context.Context, arg HasPaidTransactionsByLoanParams) (bool, error)
 	IsMonthExcluded(ctx context.Context, arg IsMonthExcludedParams) (bool, error)
+	// Check if a reversal transfer already exists for this transfer pair
+	IsTransferPairReversed(ctx context.Context, arg IsTransferPairReversedParams) (bool, error)
 	ListActiveLoans(ctx context.Context, arg ListActiveLoansParams) ([]Loan, error)
 	ListCompletedLoans(ctx context.Context, arg ListCompletedLoansParams) ([]Loan, error)
+	// Soft-deleted loans (trash), most recently deleted first
+	ListDeletedLoans(ctx context.Context, workspaceID int32) ([]Loan, error)
 	ListLoanProviders(ctx context.Context, workspaceID int32) ([]LoanProvider, error)
-	ListLoans(ctx context.Context, workspaceID int32) ([]Loan, error)
+	// Provider name is joined so callers can label loans without a second lookup
+	ListLoans(ctx context.Context, workspaceID int32) ([]ListLoansRow, error)
+	// Loans that never had a scheduled transaction. Soft-deleted rows still count, so a fully
+	// refunded loan is not mistaken for one that was imported without a schedule
+	ListLoansWithoutSchedule(ctx context.Context, workspaceID int32) ([]Loan, error)
 	ListNotesByItemAsc(ctx context.Context, arg ListNotesByItemAscParams) ([]WishlistItemNote, error)
 	ListNotesByItemDesc(ctx context.Context, arg ListNotesByItemDescParams) ([]WishlistItemNote, error)
 	ListPricesByItem(ctx context.Context, arg ListPricesByItemParams) ([]WishlistItemPrice, error)
-	ListRecurringTemplatesByWorkspace(ctx context.Context, workspaceID int32) ([]RecurringTemplate, error)
+	// Null filters are ignored; active compares against the same end_date rule as GetActiveRecurringTemplates
+	ListRecurringTemplatesByWorkspace(ctx context.Context, arg ListRecurringTemplatesByWorkspaceParams) ([]RecurringTemplate, error)
+	// Keyset page ordered by (transaction_date, id) for large exports; null after_date starts from the beginning
+	ListTransactionsByCursor(ctx context.Context, arg ListTransactionsByCursorParams) ([]Transaction, error)
 	ListWishlistItems(ctx context.Context, arg ListWishlistItemsParams) ([]WishlistItem, error)
 	ListWishlistItemsWithStats(ctx context.Context, arg ListWishlistItemsWithStatsParams) ([]ListWishlistItemsWithStatsRow, error)
 	ListWishlists(ctx context.Context, workspaceID int32) ([]Wishlist, error)
+	// Includes workspaces shared with the user, with the user's role in each
+	ListWorkspacesByUserAuth0ID(ctx context.Context, auth0ID string) ([]ListWorkspacesByUserAuth0IDRow, error)
+	MarkWorkspaceInviteAccepted(ctx context.Context, id int32) (WorkspaceInvite, error)
 	MoveWishlistItem(ctx context.Context, arg MoveWishlistItemParams) (WishlistItem, error)
 	// Unlink actual transactions from template (keep them, clear template_id)
 	OrphanActualsByTemplate(ctx context.Context, arg OrphanActualsByTemplateParams) error
 	// Unlink paid transactions from loan (keep them, clear loan_id)
-	// Used when deleting a loan to preserve payment history
+	// Used when deleting a loan to preserve payment history; deleted_loan_id allows a later restore
 	OrphanPaidTransactionsByLoan(ctx context.Context, arg OrphanPaidTransactionsByLoanParams) error
+	// Activates due scheduled transactions across all workspaces (daily job)
+	PromoteAllDueScheduledTransactions(ctx context.Context, transactionDate pgtype.Date) ([]Transaction, error)
+	// Activates a workspace's scheduled transactions whose date has arrived
+	PromoteDueScheduledTransactions(ctx context.Context, arg PromoteDueScheduledTransactionsParams) ([]Transaction, error)
+	RestoreLoan(ctx context.Context, arg RestoreLoanParams) (Loan, error)
+	// Re-link transactions unlinked by a loan deletion; unpaid ones were soft-deleted with the loan and come back
+	RestoreTransactionsByDeletedLoan(ctx context.Context, arg RestoreTransactionsByDeletedLoanParams) error
 	RevokeAPIToken(ctx context.Context, arg RevokeAPITokenParams) (int64, error)
+	// Case-insensitive substring match on name, merchant, or payee for global search, newest first.
+	// The query must have its LIKE wildcards escaped with a backslash so they match literally
+	SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]Transaction, error)
+	// Include or exclude an account's balance from net worth
+	SetAccountExcludeFromNetWorth(ctx context.Context, arg SetAccountExcludeFromNetWorthParams) (Account, error)
+	// Assign an account to a group (NULL moves it to the ungrouped bucket)
+	SetAccountGroup(ctx context.Context, arg SetAccountGroupParams) (Account, error)
+	// Records (or clears, when NULL) the time a loan became fully paid
+	SetLoanCompletedAt(ctx context.Context, arg SetLoanCompletedAtParams) error
+	// Mark every unpaid payment of a loan paid outside the app, tagging it with the external source
+	// The note replaces the existing notes when given
+	SettleTransactionsByLoanExternally(ctx context.Context, arg SettleTransactionsByLoanExternallyParams) ([]Transaction, error)
 	SoftDeleteAccount(ctx context.Context, arg SoftDeleteAccountParams) (int64, error)
+	SoftDeleteAccountGroup(ctx context.Context, arg SoftDeleteAccountGroupParams) error
 	SoftDeleteBudgetCategory(ctx context.Context, arg SoftDeleteBudgetCategoryParams) error
+	// Removes the round-up transfer legs created for an expense
+	SoftDeleteRoundUpsBySource(ctx context.Context, arg SoftDeleteRoundUpsBySourceParams) (int64, error)
 	SoftDeleteTransaction(ctx context.Context, arg SoftDeleteTransactionParams) (int64, error)
 	SoftDeleteTransactionsByGroupID(ctx context.Context, arg SoftDeleteTransactionsByGroupIDParams) (int64, error)
+	SoftDeleteTransactionsByIDs(ctx context.Context, arg SoftDeleteTransactionsByIDsParams) (int64, error)
 	SoftDeleteTransferPair(ctx context.Context, arg SoftDeleteTransferPairParams) (int64, error)
 	// Sum deferred CC expenses within a date range
 	// Used for next month projections
+	// A NULL $4 counts every account; otherwise only the listed accounts
 	SumDeferredCCByDateRange(ctx context.Context, arg SumDeferredCCByDateRangeParams) (pgtype.Numeric, error)
 	// Sum paid expenses within a date range for in-hand balance calculation
 	// Excludes transfers (they move money between accounts, not actual spending)
+	// Refunds are netted out of spending
+	// A NULL $4 counts every account; otherwise only the listed accounts
 	SumPaidExpensesByDateRange(ctx context.Context, arg SumPaidExpensesByDateRangeParams) (pgtype.Numeric, error)
 	// Only count paid transactions, excludes transfers
+	// Refunds never count as income; they reduce the expense total
+	// A NULL $5 counts every account; otherwise only the listed accounts
 	SumTransactionsByTypeAndDateRange(ctx context.Context, arg SumTransactionsByTypeAndDateRangeParams) (pgtype.Numeric, error)
 	// Sum unpaid expenses within a date range (ALL unpaid, including deferred CC)
 	// Used for balance calculations where all obligations matter
@@ -243,6 +340,7 @@ type Querier interface {
 	// EXCLUDES deferred CC transactions (those are for next month)
 	// EXCLUDES transfers (they move money between accounts, not actual spending)
 	// Includes: non-CC unpaid expenses + immediate CC expenses
+	// A NULL $4 counts every account; otherwise only the listed accounts
 	SumUnpaidExpensesForDisposable(ctx context.Context, arg SumUnpaidExpensesForDisposableParams) (pgtype.Numeric, error)
 	// ========================================
 	// CC Lifecycle Operations (v2 - Simplified)
@@ -253,8 +351,12 @@ type Querier interface {
 	ToggleTransactionPaidStatus(ctx context.Context, arg ToggleTransactionPaidStatusParams) (Transaction, error)
 	UnassignAllFromGroup(ctx context.Context, arg UnassignAllFromGroupParams) (int64, error)
 	UnassignGroupFromTransactions(ctx context.Context, arg UnassignGroupFromTransactionsParams) error
+	// Move all accounts of a group to the ungrouped bucket (used when deleting a group)
+	UngroupAccountsByGroup(ctx context.Context, arg UngroupAccountsByGroupParams) error
 	UpdateAPITokenLastUsed(ctx context.Context, id pgtype.UUID) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountGroup(ctx context.Context, arg UpdateAccountGroupParams) (AccountGroup, error)
+	UpdateAccountTemplate(ctx context.Context, arg UpdateAccountTemplateParams) (Account, error)
 	UpdateBudgetCategory(ctx context.Context, arg UpdateBudgetCategoryParams) (BudgetCategory, error)
 	UpdateGroupName(ctx context.Context, arg UpdateGroupNameParams) (TransactionGroup, error)
 	UpdateLoan(ctx context.Context, arg UpdateLoanParams) (Loan, error)
@@ -276,6 +378,9 @@ type Querier interface {
 	UpdateWishlistItem(ctx context.Context, arg UpdateWishlistItemParams) (WishlistItem, error)
 	UpdateWishlistItemNote(ctx context.Context, arg UpdateWishlistItemNoteParams) (WishlistItemNote, error)
 	UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams) (Workspace, error)
+	UpdateWorkspaceCurrency(ctx context.Context, arg UpdateWorkspaceCurrencyParams) (Workspace, error)
+	UpdateWorkspaceGenerationHorizon(ctx context.Context, arg UpdateWorkspaceGenerationHorizonParams) (Workspace, error)
+	UpdateWorkspaceRoundUpSavingsAccount(ctx context.Context, arg UpdateWorkspaceRoundUpSavingsAccountParams) (Workspace, error)
 	UpsertBudgetAllocation(ctx context.Context, arg UpsertBudgetAllocationParams) (BudgetAllocation, error)
 }
 
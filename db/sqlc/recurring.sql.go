@@ -197,11 +197,27 @@ func (q *Queries) GetRecurringTemplateByID(ctx context.Context, arg GetRecurring
 const listRecurringTemplatesByWorkspace = `-- name: ListRecurringTemplatesByWorkspace :many
 SELECT id, workspace_id, description, amount, category_id, account_id, frequency, start_date, end_date, created_at, updated_at, settlement_intent, notes FROM recurring_templates
 WHERE workspace_id = $1
+  AND ($2::INTEGER IS NULL OR account_id = $2)
+  AND ($3::INTEGER IS NULL OR category_id = $3)
+  AND ($4::BOOLEAN IS NULL OR (end_date IS NULL OR end_date >= CURRENT_DATE) = $4)
 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListRecurringTemplatesByWorkspace(ctx context.Context, workspaceID int32) ([]RecurringTemplate, error) {
-	rows, err := q.db.Query(ctx, listRecurringTemplatesByWorkspace, workspaceID)
+type ListRecurringTemplatesByWorkspaceParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	AccountID   pgtype.Int4 `json:"account_id"`
+	CategoryID  pgtype.Int4 `json:"category_id"`
+	Active      pgtype.Bool `json:"active"`
+}
+
+// Null filters are ignored; active compares against the same end_date rule as GetActiveRecurringTemplates
+func (q *Queries) ListRecurringTemplatesByWorkspace(ctx context.Context, arg ListRecurringTemplatesByWorkspaceParams) ([]RecurringTemplate, error) {
+	rows, err := q.db.Query(ctx, listRecurringTemplatesByWorkspace,
+		arg.WorkspaceID,
+		arg.AccountID,
+		arg.CategoryID,
+		arg.Active,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +161,7 @@ JOIN loan_providers lp ON l.provider_id = lp.id
 WHERE t.workspace_id = $1
   AND lp.payment_mode = 'consolidated_monthly'
   AND t.group_id IS NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND TO_CHAR(t.transaction_date, 'YYYY-MM') = $2::TEXT
 GROUP BY lp.id, lp.name
@@ -246,6 +247,75 @@ func (q *Queries) GetGroupByID(ctx context.Context, arg GetGroupByIDParams) (Get
 	return i, err
 }
 
+const getGroupChildren = `-- name: GetGroupChildren :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE group_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC
+`
+
+type GetGroupChildrenParams struct {
+	GroupID     pgtype.Int4 `json:"group_id"`
+	WorkspaceID int32       `json:"workspace_id"`
+}
+
+func (q *Queries) GetGroupChildren(ctx context.Context, arg GetGroupChildrenParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getGroupChildren, arg.GroupID, arg.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getGroupsByMonth = `-- name: GetGroupsByMonth :many
 SELECT tg.id, tg.workspace_id, tg.name, tg.month,
        tg.auto_detected, tg.loan_provider_id,
@@ -315,6 +385,7 @@ JOIN loans l ON t.loan_id = l.id
 WHERE t.workspace_id = $1
   AND l.provider_id = $2
   AND t.group_id IS NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND TO_CHAR(t.transaction_date, 'YYYY-MM') = $3::TEXT
 `
@@ -346,7 +417,7 @@ func (q *Queries) GetUngroupedTransactionIDsByProviderMonth(ctx context.Context,
 }
 
 const getUngroupedTransactionsByMonth = `-- name: GetUngroupedTransactionsByMonth :many
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND group_id IS NULL
   AND transaction_date >= $2
@@ -393,6 +464,21 @@ func (q *Queries) GetUngroupedTransactionsByMonth(ctx context.Context, arg GetUn
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -13,12 +13,12 @@ import (
 
 const batchMarkLoanTransactionsPaid = `-- name: BatchMarkLoanTransactionsPaid :many
 UPDATE transactions
-SET is_paid = true, updated_at = NOW()
+SET is_paid = true, paid_at = CASE WHEN is_paid THEN paid_at ELSE NOW() END, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND loan_id IS NOT NULL
   AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type BatchMarkLoanTransactionsPaidParams struct {
@@ -59,6 +59,21 @@ func (q *Queries) BatchMarkLoanTransactionsPaid(ctx context.Context, arg BatchMa
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -72,12 +87,12 @@ func (q *Queries) BatchMarkLoanTransactionsPaid(ctx context.Context, arg BatchMa
 
 const batchMarkLoanTransactionsUnpaid = `-- name: BatchMarkLoanTransactionsUnpaid :many
 UPDATE transactions
-SET is_paid = false, updated_at = NOW()
+SET is_paid = false, paid_at = NULL, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND loan_id IS NOT NULL
   AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type BatchMarkLoanTransactionsUnpaidParams struct {
@@ -118,6 +133,21 @@ func (q *Queries) BatchMarkLoanTransactionsUnpaid(ctx context.Context, arg Batch
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -137,7 +167,7 @@ WHERE id = ANY($1::int[])
   AND workspace_id = $2
   AND billed_at IS NULL
   AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type BatchToggleToBilledParams struct {
@@ -178,6 +208,100 @@ func (q *Queries) BatchToggleToBilled(ctx context.Context, arg BatchToggleToBill
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const billPendingCCTransactions = `-- name: BillPendingCCTransactions :many
+UPDATE transactions
+SET billed_at = $3::DATE,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND account_id = $2
+  AND type = 'expense'
+  AND billed_at IS NULL
+  AND is_paid = false
+  AND transaction_date <= $3::DATE
+  AND deleted_at IS NULL
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
+`
+
+type BillPendingCCTransactionsParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	AccountID   int32       `json:"account_id"`
+	Column3     pgtype.Date `json:"column_3"`
+}
+
+// Close a CC billing cycle: pending purchases dated on/before the cutoff become billed
+func (q *Queries) BillPendingCCTransactions(ctx context.Context, arg BillPendingCCTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, billPendingCCTransactions, arg.WorkspaceID, arg.AccountID, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -191,22 +315,23 @@ func (q *Queries) BatchToggleToBilled(ctx context.Context, arg BatchToggleToBill
 
 const bulkMarkTransactionsPaid = `-- name: BulkMarkTransactionsPaid :many
 UPDATE transactions
-SET is_paid = true, updated_at = NOW()
+SET is_paid = true, paid_at = $3, updated_at = NOW()
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type BulkMarkTransactionsPaidParams struct {
-	WorkspaceID int32   `json:"workspace_id"`
-	Column2     []int32 `json:"column_2"`
+	WorkspaceID int32              `json:"workspace_id"`
+	Column2     []int32            `json:"column_2"`
+	PaidAt      pgtype.Timestamptz `json:"paid_at"`
 }
 
 // Bulk mark transactions as paid by IDs (works for both bank and CC transactions)
 // For CC transactions, this also effectively sets cc_state to 'settled' since it's computed from is_paid
 func (q *Queries) BulkMarkTransactionsPaid(ctx context.Context, arg BulkMarkTransactionsPaidParams) ([]Transaction, error) {
-	rows, err := q.db.Query(ctx, bulkMarkTransactionsPaid, arg.WorkspaceID, arg.Column2)
+	rows, err := q.db.Query(ctx, bulkMarkTransactionsPaid, arg.WorkspaceID, arg.Column2, arg.PaidAt)
 	if err != nil {
 		return nil, err
 	}
@@ -237,6 +362,21 @@ func (q *Queries) BulkMarkTransactionsPaid(ctx context.Context, arg BulkMarkTran
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -251,13 +391,14 @@ func (q *Queries) BulkMarkTransactionsPaid(ctx context.Context, arg BulkMarkTran
 const bulkSettleTransactions = `-- name: BulkSettleTransactions :many
 UPDATE transactions
 SET is_paid = true,
+    paid_at = NOW(),
     updated_at = NOW()
 WHERE id = ANY($1::int[])
   AND workspace_id = $2
   AND billed_at IS NOT NULL
   AND is_paid = false
   AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type BulkSettleTransactionsParams struct {
@@ -298,6 +439,173 @@ func (q *Queries) BulkSettleTransactions(ctx context.Context, arg BulkSettleTran
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const bulkUpdateTransactionAccount = `-- name: BulkUpdateTransactionAccount :many
+UPDATE transactions
+SET account_id = $3, updated_at = NOW()
+WHERE workspace_id = $1
+  AND id = ANY($2::int[])
+  AND account_id <> $3
+  AND transfer_pair_id IS NULL
+  AND deleted_at IS NULL
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
+`
+
+type BulkUpdateTransactionAccountParams struct {
+	WorkspaceID int32   `json:"workspace_id"`
+	Column2     []int32 `json:"column_2"`
+	AccountID   int32   `json:"account_id"`
+}
+
+// Reassign transactions to another account in a single statement
+// Transfer legs stay put (moving one would break the pair) and rows already on the target are skipped
+func (q *Queries) BulkUpdateTransactionAccount(ctx context.Context, arg BulkUpdateTransactionAccountParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, bulkUpdateTransactionAccount, arg.WorkspaceID, arg.Column2, arg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearOrphanedLoanTransactions = `-- name: ClearOrphanedLoanTransactions :many
+UPDATE transactions
+SET loan_id = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
+`
+
+// Unlink transactions whose loan no longer exists or was deleted
+func (q *Queries) ClearOrphanedLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, clearOrphanedLoanTransactions, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -309,31 +617,68 @@ func (q *Queries) BulkSettleTransactions(ctx context.Context, arg BulkSettleTran
 	return items, nil
 }
 
+const clearUnpaidTransactionsByLoan = `-- name: ClearUnpaidTransactionsByLoan :exec
+UPDATE transactions
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id = $2
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL
+`
+
+type ClearUnpaidTransactionsByLoanParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	LoanID      pgtype.Int4 `json:"loan_id"`
+}
+
+// Soft delete unpaid transactions still linked to a loan
+// Used when a loan schedule is regenerated; unlike DeleteUnpaidTransactionsByLoan nothing is kept for restore
+func (q *Queries) ClearUnpaidTransactionsByLoan(ctx context.Context, arg ClearUnpaidTransactionsByLoanParams) error {
+	_, err := q.db.Exec(ctx, clearUnpaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
+	return err
+}
+
 const countTransactionsByWorkspace = `-- name: CountTransactionsByWorkspace :one
 SELECT COUNT(*) FROM transactions
 WHERE workspace_id = $1
-  AND deleted_at IS NULL
-  AND ($2::INTEGER IS NULL OR account_id = $2)
-  AND ($3::DATE IS NULL OR transaction_date >= $3)
-  AND ($4::DATE IS NULL OR transaction_date <= $4)
-  AND ($5::VARCHAR IS NULL OR type = $5)
+  AND (deleted_at IS NULL OR $2::BOOLEAN)
+  AND ($3::INTEGER IS NULL OR account_id = $3)
+  AND ($4::DATE IS NULL OR transaction_date >= $4)
+  AND ($5::DATE IS NULL OR transaction_date <= $5)
+  AND ($6::VARCHAR IS NULL OR type = $6)
+  AND ($7::TEXT IS NULL OR source = $7)
+  AND ($8::DATE IS NULL OR paid_at >= $8)
+  AND ($9::DATE IS NULL OR paid_at < $9::DATE + 1)
+  AND ($10::BOOLEAN IS NULL OR ((latitude IS NOT NULL OR location IS NOT NULL) = $10))
 `
 
 type CountTransactionsByWorkspaceParams struct {
-	WorkspaceID int32       `json:"workspace_id"`
-	AccountID   pgtype.Int4 `json:"account_id"`
-	StartDate   pgtype.Date `json:"start_date"`
-	EndDate     pgtype.Date `json:"end_date"`
-	Type        pgtype.Text `json:"type"`
+	WorkspaceID    int32       `json:"workspace_id"`
+	IncludeDeleted bool        `json:"include_deleted"`
+	AccountID      pgtype.Int4 `json:"account_id"`
+	StartDate      pgtype.Date `json:"start_date"`
+	EndDate        pgtype.Date `json:"end_date"`
+	Type           pgtype.Text `json:"type"`
+	Source         pgtype.Text `json:"source"`
+	PaidStart      pgtype.Date `json:"paid_start"`
+	PaidEnd        pgtype.Date `json:"paid_end"`
+	HasLocation    pgtype.Bool `json:"has_location"`
 }
 
 func (q *Queries) CountTransactionsByWorkspace(ctx context.Context, arg CountTransactionsByWorkspaceParams) (int64, error) {
 	row := q.db.QueryRow(ctx, countTransactionsByWorkspace,
 		arg.WorkspaceID,
+		arg.IncludeDeleted,
 		arg.AccountID,
 		arg.StartDate,
 		arg.EndDate,
 		arg.Type,
+		arg.Source,
+		arg.PaidStart,
+		arg.PaidEnd,
+		arg.HasLocation,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -345,30 +690,46 @@ INSERT INTO transactions (
     workspace_id, account_id, name, amount, type,
     transaction_date, is_paid, notes, transfer_pair_id, category_id, is_cc_payment,
     billed_at, settlement_intent,
-    source, template_id, is_projected, loan_id
+    source, template_id, is_projected, loan_id, is_scheduled, merchant,
+    reverses_transfer_pair_id, is_refund, exclude_from_reports, is_tax_deductible, payee,
+    latitude, longitude, location, round_up_source_id, is_loan_fee, paid_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
-) RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+    $25, $26, $27, $28, $29,
+    CASE WHEN $7::BOOLEAN THEN NOW() END
+) RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type CreateTransactionParams struct {
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -390,6 +751,18 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.TemplateID,
 		arg.IsProjected,
 		arg.LoanID,
+		arg.IsScheduled,
+		arg.Merchant,
+		arg.ReversesTransferPairID,
+		arg.IsRefund,
+		arg.ExcludeFromReports,
+		arg.IsTaxDeductible,
+		arg.Payee,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Location,
+		arg.RoundUpSourceID,
+		arg.IsLoanFee,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -415,10 +788,49 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.IsProjected,
 		&i.LoanID,
 		&i.GroupID,
+		&i.IsScheduled,
+		&i.PaidAt,
+		&i.Merchant,
+		&i.ReversesTransferPairID,
+		&i.IsRefund,
+		&i.DeletedLoanID,
+		&i.ExcludeFromReports,
+		&i.IsTaxDeductible,
+		&i.Payee,
+		&i.ModifiedFromTemplate,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Location,
+		&i.RoundUpSourceID,
+		&i.IsLoanFee,
 	)
 	return i, err
 }
 
+const deleteFutureUnpaidActualsByTemplate = `-- name: DeleteFutureUnpaidActualsByTemplate :exec
+UPDATE transactions
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND template_id = $2
+  AND is_projected = false
+  AND is_paid = false
+  AND transaction_date > $3
+  AND deleted_at IS NULL
+`
+
+type DeleteFutureUnpaidActualsByTemplateParams struct {
+	WorkspaceID     int32       `json:"workspace_id"`
+	TemplateID      pgtype.Int4 `json:"template_id"`
+	TransactionDate pgtype.Date `json:"transaction_date"`
+}
+
+// Soft delete unpaid actual transactions dated after a cutoff (used when deleting template without keeping generated)
+func (q *Queries) DeleteFutureUnpaidActualsByTemplate(ctx context.Context, arg DeleteFutureUnpaidActualsByTemplateParams) error {
+	_, err := q.db.Exec(ctx, deleteFutureUnpaidActualsByTemplate, arg.WorkspaceID, arg.TemplateID, arg.TransactionDate)
+	return err
+}
+
 const deleteProjectionsBeyondDate = `-- name: DeleteProjectionsBeyondDate :exec
 DELETE FROM transactions
 WHERE workspace_id = $1
@@ -460,10 +872,15 @@ func (q *Queries) DeleteProjectionsByTemplate(ctx context.Context, arg DeletePro
 }
 
 const deleteUnpaidTransactionsByLoan = `-- name: DeleteUnpaidTransactionsByLoan :exec
-DELETE FROM transactions
+UPDATE transactions
+SET deleted_at = NOW(),
+    deleted_loan_id = loan_id,
+    loan_id = NULL,
+    updated_at = NOW()
 WHERE workspace_id = $1
   AND loan_id = $2
   AND is_paid = false
+  AND deleted_at IS NULL
 `
 
 type DeleteUnpaidTransactionsByLoanParams struct {
@@ -471,8 +888,8 @@ type DeleteUnpaidTransactionsByLoanParams struct {
 	LoanID      pgtype.Int4 `json:"loan_id"`
 }
 
-// Hard delete unpaid transactions for a loan
-// Used when deleting a loan (unpaid future payments are removed)
+// Soft delete unpaid transactions for a loan
+// Used when deleting a loan (unpaid future payments are removed); deleted_loan_id allows a later restore
 func (q *Queries) DeleteUnpaidTransactionsByLoan(ctx context.Context, arg DeleteUnpaidTransactionsByLoanParams) error {
 	_, err := q.db.Exec(ctx, deleteUnpaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
 	return err
@@ -487,6 +904,7 @@ SELECT
     COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) AS sum_all_expenses
 FROM transactions
 WHERE workspace_id = $1 AND deleted_at IS NULL
+  AND NOT (is_scheduled = true AND transaction_date > CURRENT_DATE)
 GROUP BY account_id
 `
 
@@ -500,6 +918,7 @@ type GetAccountTransactionSummariesRow struct {
 
 // For regular accounts: only count paid transactions
 // For CC accounts: count all expenses (isPaid means settled, not whether purchase happened)
+// Scheduled transactions are excluded until their date arrives
 func (q *Queries) GetAccountTransactionSummaries(ctx context.Context, workspaceID int32) ([]GetAccountTransactionSummariesRow, error) {
 	rows, err := q.db.Query(ctx, getAccountTransactionSummaries, workspaceID)
 	if err != nil {
@@ -526,67 +945,200 @@ func (q *Queries) GetAccountTransactionSummaries(ctx context.Context, workspaceI
 	return items, nil
 }
 
-const getBilledCCByMonth = `-- name: GetBilledCCByMonth :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
-JOIN accounts a ON t.account_id = a.id
-WHERE t.workspace_id = $1
-  AND a.template = 'credit_card'
-  AND t.billed_at IS NOT NULL
-  AND t.is_paid = false
-  AND t.settlement_intent = 'deferred'
-  AND t.transaction_date >= $2 AND t.transaction_date < $3
-  AND t.deleted_at IS NULL
-ORDER BY t.transaction_date DESC
+const getAccountTransactionSummaryAsOf = `-- name: GetAccountTransactionSummaryAsOf :one
+SELECT
+    COALESCE(SUM(CASE WHEN type = 'income' AND is_paid = true THEN amount ELSE 0 END), 0) AS sum_income,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true THEN amount ELSE 0 END), 0) AS sum_expenses,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = false THEN amount ELSE 0 END), 0) AS sum_unpaid_expenses,
+    COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) AS sum_all_expenses
+FROM transactions
+WHERE workspace_id = $1 AND account_id = $2 AND deleted_at IS NULL
+  AND transaction_date <= $3
+  AND NOT (is_scheduled = true AND transaction_date > CURRENT_DATE)
 `
 
-type GetBilledCCByMonthParams struct {
-	WorkspaceID       int32       `json:"workspace_id"`
-	TransactionDate   pgtype.Date `json:"transaction_date"`
-	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+type GetAccountTransactionSummaryAsOfParams struct {
+	WorkspaceID     int32       `json:"workspace_id"`
+	AccountID       int32       `json:"account_id"`
+	TransactionDate pgtype.Date `json:"transaction_date"`
 }
 
-type GetBilledCCByMonthRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+type GetAccountTransactionSummaryAsOfRow struct {
+	SumIncome         interface{} `json:"sum_income"`
+	SumExpenses       interface{} `json:"sum_expenses"`
+	SumUnpaidExpenses interface{} `json:"sum_unpaid_expenses"`
+	SumAllExpenses    interface{} `json:"sum_all_expenses"`
 }
 
-// Get billed CC transactions with deferred settlement intent for a month range
-func (q *Queries) GetBilledCCByMonth(ctx context.Context, arg GetBilledCCByMonthParams) ([]GetBilledCCByMonthRow, error) {
-	rows, err := q.db.Query(ctx, getBilledCCByMonth, arg.WorkspaceID, arg.TransactionDate, arg.TransactionDate_2)
+// Same sums as GetAccountTransactionSummaries for one account, limited to transactions dated on or before $3
+func (q *Queries) GetAccountTransactionSummaryAsOf(ctx context.Context, arg GetAccountTransactionSummaryAsOfParams) (GetAccountTransactionSummaryAsOfRow, error) {
+	row := q.db.QueryRow(ctx, getAccountTransactionSummaryAsOf, arg.WorkspaceID, arg.AccountID, arg.TransactionDate)
+	var i GetAccountTransactionSummaryAsOfRow
+	err := row.Scan(
+		&i.SumIncome,
+		&i.SumExpenses,
+		&i.SumUnpaidExpenses,
+		&i.SumAllExpenses,
+	)
+	return i, err
+}
+
+const getAllLoanTransactionsByMonth = `-- name: GetAllLoanTransactionsByMonth :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND source <> 'loan_down_payment'
+  AND EXTRACT(YEAR FROM transaction_date)::INTEGER = $2::INTEGER
+  AND EXTRACT(MONTH FROM transaction_date)::INTEGER = $3::INTEGER
+  AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC
+`
+
+type GetAllLoanTransactionsByMonthParams struct {
+	WorkspaceID int32 `json:"workspace_id"`
+	Year        int32 `json:"year"`
+	Month       int32 `json:"month"`
+}
+
+// Get all loan transactions (paid and unpaid) for a month, used for commitments aggregation
+func (q *Queries) GetAllLoanTransactionsByMonth(ctx context.Context, arg GetAllLoanTransactionsByMonthParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getAllLoanTransactionsByMonth,
+		arg.WorkspaceID,
+		arg.Year,
+		arg.Month,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetBilledCCByMonthRow{}
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBilledCCByMonth = `-- name: GetBilledCCByMonth :many
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+JOIN accounts a ON t.account_id = a.id
+WHERE t.workspace_id = $1
+  AND a.template = 'credit_card'
+  AND t.billed_at IS NOT NULL
+  AND t.is_paid = false
+  AND t.settlement_intent = 'deferred'
+  AND t.transaction_date >= $2 AND t.transaction_date < $3
+  AND t.deleted_at IS NULL
+ORDER BY t.transaction_date DESC
+`
+
+type GetBilledCCByMonthParams struct {
+	WorkspaceID       int32       `json:"workspace_id"`
+	TransactionDate   pgtype.Date `json:"transaction_date"`
+	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+}
+
+type GetBilledCCByMonthRow struct {
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
+}
+
+// Get billed CC transactions with deferred settlement intent for a month range
+func (q *Queries) GetBilledCCByMonth(ctx context.Context, arg GetBilledCCByMonthParams) ([]GetBilledCCByMonthRow, error) {
+	rows, err := q.db.Query(ctx, getBilledCCByMonth, arg.WorkspaceID, arg.TransactionDate, arg.TransactionDate_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBilledCCByMonthRow{}
 	for rows.Next() {
 		var i GetBilledCCByMonthRow
 		if err := rows.Scan(
@@ -612,6 +1164,21 @@ func (q *Queries) GetBilledCCByMonth(ctx context.Context, arg GetBilledCCByMonth
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -680,8 +1247,53 @@ func (q *Queries) GetCCMetrics(ctx context.Context, arg GetCCMetricsParams) (Get
 	return i, err
 }
 
+const getCCStateBreakdown = `-- name: GetCCStateBreakdown :one
+SELECT
+    COUNT(*) FILTER (WHERE billed_at IS NULL AND is_paid = false)::INTEGER as pending_count,
+    COALESCE(SUM(amount) FILTER (WHERE billed_at IS NULL AND is_paid = false), 0)::NUMERIC(12,2) as pending_total,
+    COUNT(*) FILTER (WHERE billed_at IS NOT NULL AND is_paid = false)::INTEGER as billed_count,
+    COALESCE(SUM(amount) FILTER (WHERE billed_at IS NOT NULL AND is_paid = false), 0)::NUMERIC(12,2) as billed_total,
+    COUNT(*) FILTER (WHERE is_paid = true)::INTEGER as settled_count,
+    COALESCE(SUM(amount) FILTER (WHERE is_paid = true), 0)::NUMERIC(12,2) as settled_total
+FROM transactions
+WHERE workspace_id = $1
+  AND account_id = $2
+  AND type = 'expense'
+  AND deleted_at IS NULL
+`
+
+type GetCCStateBreakdownParams struct {
+	WorkspaceID int32 `json:"workspace_id"`
+	AccountID   int32 `json:"account_id"`
+}
+
+type GetCCStateBreakdownRow struct {
+	PendingCount int32          `json:"pending_count"`
+	PendingTotal pgtype.Numeric `json:"pending_total"`
+	BilledCount  int32          `json:"billed_count"`
+	BilledTotal  pgtype.Numeric `json:"billed_total"`
+	SettledCount int32          `json:"settled_count"`
+	SettledTotal pgtype.Numeric `json:"settled_total"`
+}
+
+// Count and sum CC purchases on one account per CC state (pending, billed, settled)
+// Payments into the card are income and not part of any state bucket
+func (q *Queries) GetCCStateBreakdown(ctx context.Context, arg GetCCStateBreakdownParams) (GetCCStateBreakdownRow, error) {
+	row := q.db.QueryRow(ctx, getCCStateBreakdown, arg.WorkspaceID, arg.AccountID)
+	var i GetCCStateBreakdownRow
+	err := row.Scan(
+		&i.PendingCount,
+		&i.PendingTotal,
+		&i.BilledCount,
+		&i.BilledTotal,
+		&i.SettledCount,
+		&i.SettledTotal,
+	)
+	return i, err
+}
+
 const getDeferredForSettlement = `-- name: GetDeferredForSettlement :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
   AND a.template = 'credit_card'
@@ -689,46 +1301,68 @@ WHERE t.workspace_id = $1
   AND t.is_paid = false
   AND t.settlement_intent = 'deferred'
   AND t.deleted_at IS NULL
+  AND ($2::INTEGER[] IS NULL OR t.account_id = ANY($2::INTEGER[]))
 ORDER BY t.transaction_date ASC
 `
 
+type GetDeferredForSettlementParams struct {
+	WorkspaceID int32   `json:"workspace_id"`
+	Column2     []int32 `json:"column_2"`
+}
+
 type GetDeferredForSettlementRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
 }
 
 // Get all billed, deferred transactions that need settlement (ordered by date)
-func (q *Queries) GetDeferredForSettlement(ctx context.Context, workspaceID int32) ([]GetDeferredForSettlementRow, error) {
-	rows, err := q.db.Query(ctx, getDeferredForSettlement, workspaceID)
+// A NULL $2 covers every account; otherwise only the listed accounts
+func (q *Queries) GetDeferredForSettlement(ctx context.Context, arg GetDeferredForSettlementParams) ([]GetDeferredForSettlementRow, error) {
+	rows, err := q.db.Query(ctx, getDeferredForSettlement, arg.WorkspaceID, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -759,6 +1393,21 @@ func (q *Queries) GetDeferredForSettlement(ctx context.Context, workspaceID int3
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -779,6 +1428,36 @@ func (q *Queries) GetDeferredForSettlement(ctx context.Context, workspaceID int3
 	return items, nil
 }
 
+const getDistinctTransactionSources = `-- name: GetDistinctTransactionSources :many
+SELECT DISTINCT source::TEXT AS source
+FROM transactions
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+  AND source IS NOT NULL
+ORDER BY source
+`
+
+// Returns every source value in use in the workspace, for filter dropdowns
+func (q *Queries) GetDistinctTransactionSources(ctx context.Context, workspaceID int32) ([]string, error) {
+	rows, err := q.db.Query(ctx, getDistinctTransactionSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var source string
+		if err := rows.Scan(&source); err != nil {
+			return nil, err
+		}
+		items = append(items, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEarliestUnpaidLoanMonth = `-- name: GetEarliestUnpaidLoanMonth :one
 SELECT
     EXTRACT(YEAR FROM t.transaction_date)::INTEGER as year,
@@ -788,6 +1467,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 WHERE t.workspace_id = $1
   AND l.provider_id = $2
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.is_paid = false
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC
@@ -813,7 +1493,7 @@ func (q *Queries) GetEarliestUnpaidLoanMonth(ctx context.Context, arg GetEarlies
 }
 
 const getImmediateForSettlement = `-- name: GetImmediateForSettlement :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
   AND a.template = 'credit_card'
@@ -833,37 +1513,52 @@ type GetImmediateForSettlementParams struct {
 }
 
 type GetImmediateForSettlementRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
 }
 
 // Get billed transactions with immediate intent for the current month
@@ -899,6 +1594,21 @@ func (q *Queries) GetImmediateForSettlement(ctx context.Context, arg GetImmediat
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -928,6 +1638,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 WHERE t.workspace_id = $1
   AND l.provider_id = $2
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.is_paid = true
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date DESC
@@ -966,6 +1677,8 @@ SELECT
     t.updated_at
 FROM transactions t
 WHERE t.loan_id = $1
+  AND t.is_loan_fee = false
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC, t.id ASC
 `
@@ -985,6 +1698,7 @@ type GetLoanPaymentsFromTransactionsRow struct {
 
 // Convert loan transactions to LoanPayment format for frontend compatibility
 // Derives payment_number from row order, extracts year/month from transaction_date
+// Separate fee lines are not payments and are left out of the numbering
 // Note: Only uses loan_id since service layer already verified loan ownership
 func (q *Queries) GetLoanPaymentsFromTransactions(ctx context.Context, loanID pgtype.Int4) ([]GetLoanPaymentsFromTransactionsRow, error) {
 	rows, err := q.db.Query(ctx, getLoanPaymentsFromTransactions, loanID)
@@ -1026,6 +1740,7 @@ SELECT
 FROM transactions
 WHERE workspace_id = $1
   AND loan_id = $2
+  AND source <> 'loan_down_payment'
   AND deleted_at IS NULL
 `
 
@@ -1056,13 +1771,14 @@ func (q *Queries) GetLoanTransactionStats(ctx context.Context, arg GetLoanTransa
 
 const getLoanTransactionsByMonth = `-- name: GetLoanTransactionsByMonth :many
 
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND loan_id = $2
   AND EXTRACT(YEAR FROM transaction_date)::INTEGER = $3::INTEGER
   AND EXTRACT(MONTH FROM transaction_date)::INTEGER = $4::INTEGER
   AND deleted_at IS NULL
   AND is_paid = false
+  AND source <> 'loan_down_payment'
 ORDER BY transaction_date
 `
 
@@ -1114,6 +1830,21 @@ func (q *Queries) GetLoanTransactionsByMonth(ctx context.Context, arg GetLoanTra
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -1138,6 +1869,7 @@ JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
 JOIN loan_providers lp ON l.provider_id = lp.id AND lp.deleted_at IS NULL
 WHERE t.workspace_id = $1
   AND t.loan_id IS NOT NULL
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
   AND (
     -- Filter by date range: from start to end month (inclusive)
@@ -1211,8 +1943,10 @@ const getMonthlyTransactionSummaries = `-- name: GetMonthlyTransactionSummaries
 SELECT
     EXTRACT(YEAR FROM transaction_date)::INTEGER AS year,
     EXTRACT(MONTH FROM transaction_date)::INTEGER AS month,
-    COALESCE(SUM(CASE WHEN type = 'income' AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_income,
-    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_expenses
+    COALESCE(SUM(CASE WHEN type = 'income' AND is_refund = false AND is_paid = true AND transfer_pair_id IS NULL THEN amount ELSE 0 END), 0)::NUMERIC(12,2) AS total_income,
+    COALESCE(SUM(CASE WHEN type = 'expense' AND is_paid = true AND transfer_pair_id IS NULL THEN amount
+                      WHEN is_refund = true AND is_paid = true AND transfer_pair_id IS NULL THEN -amount
+                      ELSE 0 END), 0)::NUMERIC(12,2) AS total_expenses
 FROM transactions
 WHERE workspace_id = $1
   AND deleted_at IS NULL
@@ -1229,6 +1963,7 @@ type GetMonthlyTransactionSummariesRow struct {
 
 // Batch query to get income/expense totals grouped by year/month for N+1 prevention
 // Only count paid transactions, excludes transfers
+// Refunds never count as income; they reduce the expense total
 func (q *Queries) GetMonthlyTransactionSummaries(ctx context.Context, workspaceID int32) ([]GetMonthlyTransactionSummariesRow, error) {
 	rows, err := q.db.Query(ctx, getMonthlyTransactionSummaries, workspaceID)
 	if err != nil {
@@ -1254,8 +1989,81 @@ func (q *Queries) GetMonthlyTransactionSummaries(ctx context.Context, workspaceI
 	return items, nil
 }
 
+const getOrphanedLoanTransactions = `-- name: GetOrphanedLoanTransactions :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+ORDER BY transaction_date ASC, id ASC
+`
+
+// Transactions linked to a loan that no longer exists or was deleted
+func (q *Queries) GetOrphanedLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getOrphanedLoanTransactions, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOverdueCC = `-- name: GetOverdueCC :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
   AND a.template = 'credit_card'
@@ -1268,37 +2076,52 @@ ORDER BY t.billed_at ASC
 `
 
 type GetOverdueCCRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
 }
 
 // Get CC transactions that are billed but overdue (2+ months old)
@@ -1334,6 +2157,21 @@ func (q *Queries) GetOverdueCC(ctx context.Context, workspaceID int32) ([]GetOve
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -1373,6 +2211,7 @@ WHERE t.workspace_id = $1
   AND EXTRACT(YEAR FROM t.transaction_date)::INTEGER = CAST($3 AS INTEGER)
   AND EXTRACT(MONTH FROM t.transaction_date)::INTEGER = CAST($4 AS INTEGER)
   AND t.is_paid = true
+  AND t.source <> 'loan_down_payment'
   AND t.deleted_at IS NULL
 ORDER BY t.transaction_date ASC, t.id ASC
 `
@@ -1434,8 +2273,85 @@ func (q *Queries) GetPaidLoanPaymentsByProviderMonth(ctx context.Context, arg Ge
 	return items, nil
 }
 
+const getPastDueUnpaidTransactions = `-- name: GetPastDueUnpaidTransactions :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND type = 'expense'
+  AND is_paid = false
+  AND transaction_date < $2
+  AND transfer_pair_id IS NULL
+  AND (settlement_intent IS NULL OR settlement_intent <> 'deferred')
+  AND deleted_at IS NULL
+ORDER BY transaction_date ASC, id ASC
+`
+
+type GetPastDueUnpaidTransactionsParams struct {
+	WorkspaceID     int32       `json:"workspace_id"`
+	TransactionDate pgtype.Date `json:"transaction_date"`
+}
+
+// Unpaid expenses dated before the given day, oldest first
+// Excludes transfers and deferred CC (those are tracked by GetOverdueCC)
+func (q *Queries) GetPastDueUnpaidTransactions(ctx context.Context, arg GetPastDueUnpaidTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getPastDueUnpaidTransactions, arg.WorkspaceID, arg.TransactionDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPendingCCByMonth = `-- name: GetPendingCCByMonth :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
   AND a.template = 'credit_card'
@@ -1453,37 +2369,52 @@ type GetPendingCCByMonthParams struct {
 }
 
 type GetPendingCCByMonthRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
 }
 
 // Get pending CC transactions (billed_at IS NULL) for a specific month range
@@ -1519,6 +2450,21 @@ func (q *Queries) GetPendingCCByMonth(ctx context.Context, arg GetPendingCCByMon
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -1540,7 +2486,7 @@ func (q *Queries) GetPendingCCByMonth(ctx context.Context, arg GetPendingCCByMon
 }
 
 const getPendingDeferredCC = `-- name: GetPendingDeferredCC :many
-SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
+SELECT t.id, t.workspace_id, account_id, t.name, amount, type, transaction_date, is_paid, notes, t.created_at, t.updated_at, t.deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee, a.id, a.workspace_id, a.name, account_type, template, initial_balance, a.created_at, a.updated_at, a.deleted_at FROM transactions t
 JOIN accounts a ON t.account_id = a.id
 WHERE t.workspace_id = $1
   AND a.template = 'credit_card'
@@ -1560,37 +2506,52 @@ type GetPendingDeferredCCParams struct {
 }
 
 type GetPendingDeferredCCRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	ID_2             int32              `json:"id_2"`
-	WorkspaceID_2    int32              `json:"workspace_id_2"`
-	Name_2           string             `json:"name_2"`
-	AccountType      string             `json:"account_type"`
-	Template         string             `json:"template"`
-	InitialBalance   pgtype.Numeric     `json:"initial_balance"`
-	CreatedAt_2      pgtype.Timestamptz `json:"created_at_2"`
-	UpdatedAt_2      pgtype.Timestamptz `json:"updated_at_2"`
-	DeletedAt_2      pgtype.Timestamptz `json:"deleted_at_2"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	IsRefund               bool               `json:"is_refund"`
+	DeletedLoanID          pgtype.Int4        `json:"deleted_loan_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	RoundUpSourceID        pgtype.Int4        `json:"round_up_source_id"`
+	IsLoanFee              bool               `json:"is_loan_fee"`
+	ID_2                   int32              `json:"id_2"`
+	WorkspaceID_2          int32              `json:"workspace_id_2"`
+	Name_2                 string             `json:"name_2"`
+	AccountType            string             `json:"account_type"`
+	Template               string             `json:"template"`
+	InitialBalance         pgtype.Numeric     `json:"initial_balance"`
+	CreatedAt_2            pgtype.Timestamptz `json:"created_at_2"`
+	UpdatedAt_2            pgtype.Timestamptz `json:"updated_at_2"`
+	DeletedAt_2            pgtype.Timestamptz `json:"deleted_at_2"`
 }
 
 // Get pending (not yet billed) deferred CC transactions for visibility
@@ -1627,6 +2588,21 @@ func (q *Queries) GetPendingDeferredCC(ctx context.Context, arg GetPendingDeferr
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 			&i.ID_2,
 			&i.WorkspaceID_2,
 			&i.Name_2,
@@ -1649,7 +2625,7 @@ func (q *Queries) GetPendingDeferredCC(ctx context.Context, arg GetPendingDeferr
 
 const getProjectionsByTemplate = `-- name: GetProjectionsByTemplate :many
 
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND template_id = $2
   AND is_projected = true
@@ -1698,6 +2674,21 @@ func (q *Queries) GetProjectionsByTemplate(ctx context.Context, arg GetProjectio
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -1752,7 +2743,7 @@ func (q *Queries) GetRecentlyUsedCategories(ctx context.Context, workspaceID int
 }
 
 const getTransactionByID = `-- name: GetTransactionByID :one
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
 `
 
@@ -1787,12 +2778,27 @@ func (q *Queries) GetTransactionByID(ctx context.Context, arg GetTransactionByID
 		&i.IsProjected,
 		&i.LoanID,
 		&i.GroupID,
+		&i.IsScheduled,
+		&i.PaidAt,
+		&i.Merchant,
+		&i.ReversesTransferPairID,
+		&i.IsRefund,
+		&i.DeletedLoanID,
+		&i.ExcludeFromReports,
+		&i.IsTaxDeductible,
+		&i.Payee,
+		&i.ModifiedFromTemplate,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Location,
+		&i.RoundUpSourceID,
+		&i.IsLoanFee,
 	)
 	return i, err
 }
 
 const getTransactionsByIDs = `-- name: GetTransactionsByIDs :many
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND id = ANY($2::int[])
   AND deleted_at IS NULL
@@ -1837,6 +2843,21 @@ func (q *Queries) GetTransactionsByIDs(ctx context.Context, arg GetTransactionsB
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -1849,7 +2870,7 @@ func (q *Queries) GetTransactionsByIDs(ctx context.Context, arg GetTransactionsB
 }
 
 const getTransactionsByLoanID = `-- name: GetTransactionsByLoanID :many
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND loan_id = $2
   AND deleted_at IS NULL
@@ -1894,6 +2915,94 @@ func (q *Queries) GetTransactionsByLoanID(ctx context.Context, arg GetTransactio
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsByTemplate = `-- name: GetTransactionsByTemplate :many
+
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND template_id = $2
+  AND deleted_at IS NULL
+ORDER BY transaction_date DESC, id DESC
+`
+
+type GetTransactionsByTemplateParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	TemplateID  pgtype.Int4 `json:"template_id"`
+}
+
+// Get all generated transactions (projected and actual) for a template, newest first
+func (q *Queries) GetTransactionsByTemplate(ctx context.Context, arg GetTransactionsByTemplateParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getTransactionsByTemplate, arg.WorkspaceID, arg.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -1906,7 +3015,7 @@ func (q *Queries) GetTransactionsByLoanID(ctx context.Context, arg GetTransactio
 }
 
 const getTransactionsByWorkspace = `-- name: GetTransactionsByWorkspace :many
-SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id FROM transactions
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
 WHERE workspace_id = $1
   AND deleted_at IS NULL
   AND ($2::INTEGER IS NULL OR account_id = $2)
@@ -1967,6 +3076,21 @@ func (q *Queries) GetTransactionsByWorkspace(ctx context.Context, arg GetTransac
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -2002,6 +3126,18 @@ SELECT
     t.is_projected,
     t.loan_id,
     t.group_id,
+    t.is_scheduled,
+    t.paid_at,
+    t.merchant,
+    t.reverses_transfer_pair_id,
+    t.exclude_from_reports,
+    t.is_tax_deductible,
+    t.payee,
+    t.modified_from_template,
+    t.latitude,
+    t.longitude,
+    t.location,
+    t.is_refund,
     bc.name AS category_name,
     tg.name AS group_name
 FROM transactions t
@@ -2011,6 +3147,7 @@ WHERE t.workspace_id = $1
   AND t.deleted_at IS NULL
   AND t.transaction_date >= $2::DATE
   AND t.transaction_date <= $3::DATE
+  AND ($4::INTEGER[] IS NULL OR t.account_id = ANY($4::INTEGER[]))
 ORDER BY t.transaction_date DESC, t.created_at DESC
 `
 
@@ -2018,39 +3155,57 @@ type GetTransactionsForAggregationParams struct {
 	WorkspaceID int32       `json:"workspace_id"`
 	StartDate   pgtype.Date `json:"start_date"`
 	EndDate     pgtype.Date `json:"end_date"`
+	AccountIds  []int32     `json:"account_ids"`
 }
 
 type GetTransactionsForAggregationRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	CategoryName     pgtype.Text        `json:"category_name"`
-	GroupName        pgtype.Text        `json:"group_name"`
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	IsRefund               bool               `json:"is_refund"`
+	CategoryName           pgtype.Text        `json:"category_name"`
+	GroupName              pgtype.Text        `json:"group_name"`
 }
 
 // Returns all transactions in a date range with category name for aggregation (no pagination)
 // Used by dashboard future spending calculations
 func (q *Queries) GetTransactionsForAggregation(ctx context.Context, arg GetTransactionsForAggregationParams) ([]GetTransactionsForAggregationRow, error) {
-	rows, err := q.db.Query(ctx, getTransactionsForAggregation, arg.WorkspaceID, arg.StartDate, arg.EndDate)
+	rows, err := q.db.Query(ctx, getTransactionsForAggregation,
+		arg.WorkspaceID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.AccountIds,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -2068,12 +3223,737 @@ func (q *Queries) GetTransactionsForAggregation(ctx context.Context, arg GetTran
 			&i.TransactionDate,
 			&i.IsPaid,
 			&i.Notes,
-			&i.TransferPairID,
-			&i.CategoryID,
-			&i.IsCcPayment,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.IsRefund,
+			&i.CategoryName,
+			&i.GroupName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsWithCategory = `-- name: GetTransactionsWithCategory :many
+SELECT
+    t.id,
+    t.workspace_id,
+    t.account_id,
+    t.name,
+    t.amount,
+    t.type,
+    t.transaction_date,
+    t.is_paid,
+    t.notes,
+    t.transfer_pair_id,
+    t.category_id,
+    t.is_cc_payment,
+    t.created_at,
+    t.updated_at,
+    t.deleted_at,
+    t.billed_at,
+    t.settlement_intent,
+    t.source,
+    t.template_id,
+    t.is_projected,
+    t.loan_id,
+    t.group_id,
+    t.is_scheduled,
+    t.paid_at,
+    t.merchant,
+    t.reverses_transfer_pair_id,
+    t.exclude_from_reports,
+    t.is_tax_deductible,
+    t.payee,
+    t.modified_from_template,
+    t.latitude,
+    t.longitude,
+    t.location,
+    t.is_refund,
+    bc.name AS category_name,
+    tg.name AS group_name
+FROM transactions t
+LEFT JOIN budget_categories bc ON t.category_id = bc.id AND bc.deleted_at IS NULL
+LEFT JOIN transaction_groups tg ON t.group_id = tg.id
+WHERE t.workspace_id = $1
+  AND (t.deleted_at IS NULL OR $2::BOOLEAN)
+  AND ($3::INTEGER IS NULL OR t.account_id = $3)
+  AND ($4::DATE IS NULL OR t.transaction_date >= $4)
+  AND ($5::DATE IS NULL OR t.transaction_date <= $5)
+  AND ($6::VARCHAR IS NULL OR t.type = $6)
+  AND ($7::TEXT IS NULL OR t.source = $7)
+  AND ($8::DATE IS NULL OR t.paid_at >= $8)
+  AND ($9::DATE IS NULL OR t.paid_at < $9::DATE + 1)
+  AND ($10::BOOLEAN IS NULL OR ((t.latitude IS NOT NULL OR t.location IS NOT NULL) = $10))
+ORDER BY t.transaction_date DESC, t.created_at DESC
+LIMIT $12 OFFSET $11
+`
+
+type GetTransactionsWithCategoryParams struct {
+	WorkspaceID    int32       `json:"workspace_id"`
+	IncludeDeleted bool        `json:"include_deleted"`
+	AccountID      pgtype.Int4 `json:"account_id"`
+	StartDate      pgtype.Date `json:"start_date"`
+	EndDate        pgtype.Date `json:"end_date"`
+	Type           pgtype.Text `json:"type"`
+	Source         pgtype.Text `json:"source"`
+	PaidStart      pgtype.Date `json:"paid_start"`
+	PaidEnd        pgtype.Date `json:"paid_end"`
+	HasLocation    pgtype.Bool `json:"has_location"`
+	PageOffset     int32       `json:"page_offset"`
+	PageSize       int32       `json:"page_size"`
+}
+
+type GetTransactionsWithCategoryRow struct {
+	ID                     int32              `json:"id"`
+	WorkspaceID            int32              `json:"workspace_id"`
+	AccountID              int32              `json:"account_id"`
+	Name                   string             `json:"name"`
+	Amount                 pgtype.Numeric     `json:"amount"`
+	Type                   string             `json:"type"`
+	TransactionDate        pgtype.Date        `json:"transaction_date"`
+	IsPaid                 bool               `json:"is_paid"`
+	Notes                  pgtype.Text        `json:"notes"`
+	TransferPairID         pgtype.UUID        `json:"transfer_pair_id"`
+	CategoryID             pgtype.Int4        `json:"category_id"`
+	IsCcPayment            bool               `json:"is_cc_payment"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt              pgtype.Timestamptz `json:"deleted_at"`
+	BilledAt               pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent       pgtype.Text        `json:"settlement_intent"`
+	Source                 pgtype.Text        `json:"source"`
+	TemplateID             pgtype.Int4        `json:"template_id"`
+	IsProjected            pgtype.Bool        `json:"is_projected"`
+	LoanID                 pgtype.Int4        `json:"loan_id"`
+	GroupID                pgtype.Int4        `json:"group_id"`
+	IsScheduled            bool               `json:"is_scheduled"`
+	PaidAt                 pgtype.Timestamptz `json:"paid_at"`
+	Merchant               pgtype.Text        `json:"merchant"`
+	ReversesTransferPairID pgtype.UUID        `json:"reverses_transfer_pair_id"`
+	ExcludeFromReports     bool               `json:"exclude_from_reports"`
+	IsTaxDeductible        bool               `json:"is_tax_deductible"`
+	Payee                  pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate   bool               `json:"modified_from_template"`
+	Latitude               pgtype.Numeric     `json:"latitude"`
+	Longitude              pgtype.Numeric     `json:"longitude"`
+	Location               pgtype.Text        `json:"location"`
+	IsRefund               bool               `json:"is_refund"`
+	CategoryName           pgtype.Text        `json:"category_name"`
+	GroupName              pgtype.Text        `json:"group_name"`
+}
+
+// Returns transactions with category name and group name joined for display
+func (q *Queries) GetTransactionsWithCategory(ctx context.Context, arg GetTransactionsWithCategoryParams) ([]GetTransactionsWithCategoryRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsWithCategory,
+		arg.WorkspaceID,
+		arg.IncludeDeleted,
+		arg.AccountID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Type,
+		arg.Source,
+		arg.PaidStart,
+		arg.PaidEnd,
+		arg.HasLocation,
+		arg.PageOffset,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsWithCategoryRow{}
+	for rows.Next() {
+		var i GetTransactionsWithCategoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.IsRefund,
+			&i.CategoryName,
+			&i.GroupName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransferPairTransactions = `-- name: GetTransferPairTransactions :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND transfer_pair_id = $2
+  AND deleted_at IS NULL
+ORDER BY id ASC
+`
+
+type GetTransferPairTransactionsParams struct {
+	WorkspaceID    int32       `json:"workspace_id"`
+	TransferPairID pgtype.UUID `json:"transfer_pair_id"`
+}
+
+// Returns both legs of a transfer
+func (q *Queries) GetTransferPairTransactions(ctx context.Context, arg GetTransferPairTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getTransferPairTransactions,
+		arg.WorkspaceID,
+		arg.TransferPairID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnpaidLoanPaymentsByProviderMonth = `-- name: GetUnpaidLoanPaymentsByProviderMonth :many
+SELECT
+    t.id,
+    t.loan_id,
+    ROW_NUMBER() OVER (PARTITION BY t.loan_id ORDER BY t.transaction_date ASC, t.id ASC)::INTEGER as payment_number,
+    t.amount,
+    EXTRACT(YEAR FROM t.transaction_date)::INTEGER as due_year,
+    EXTRACT(MONTH FROM t.transaction_date)::INTEGER as due_month,
+    t.is_paid as paid,
+    CASE WHEN t.is_paid = true THEN t.updated_at ELSE NULL END as paid_date,
+    t.created_at,
+    t.updated_at
+FROM transactions t
+JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
+WHERE t.workspace_id = $1
+  AND l.provider_id = $2
+  AND EXTRACT(YEAR FROM t.transaction_date)::INTEGER = CAST($3 AS INTEGER)
+  AND EXTRACT(MONTH FROM t.transaction_date)::INTEGER = CAST($4 AS INTEGER)
+  AND t.is_paid = false
+  AND t.source <> 'loan_down_payment'
+  AND t.deleted_at IS NULL
+ORDER BY t.transaction_date ASC, t.id ASC
+`
+
+type GetUnpaidLoanPaymentsByProviderMonthParams struct {
+	WorkspaceID int32 `json:"workspace_id"`
+	ProviderID  int32 `json:"provider_id"`
+	Year        int32 `json:"year"`
+	Month       int32 `json:"month"`
+}
+
+type GetUnpaidLoanPaymentsByProviderMonthRow struct {
+	ID            int32              `json:"id"`
+	LoanID        pgtype.Int4        `json:"loan_id"`
+	PaymentNumber int32              `json:"payment_number"`
+	Amount        pgtype.Numeric     `json:"amount"`
+	DueYear       int32              `json:"due_year"`
+	DueMonth      int32              `json:"due_month"`
+	Paid          bool               `json:"paid"`
+	PaidDate      interface{}        `json:"paid_date"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Get unpaid loan payments for a specific provider and month (for pay-month action)
+func (q *Queries) GetUnpaidLoanPaymentsByProviderMonth(ctx context.Context, arg GetUnpaidLoanPaymentsByProviderMonthParams) ([]GetUnpaidLoanPaymentsByProviderMonthRow, error) {
+	rows, err := q.db.Query(ctx, getUnpaidLoanPaymentsByProviderMonth,
+		arg.WorkspaceID,
+		arg.ProviderID,
+		arg.Year,
+		arg.Month,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUnpaidLoanPaymentsByProviderMonthRow{}
+	for rows.Next() {
+		var i GetUnpaidLoanPaymentsByProviderMonthRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LoanID,
+			&i.PaymentNumber,
+			&i.Amount,
+			&i.DueYear,
+			&i.DueMonth,
+			&i.Paid,
+			&i.PaidDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnpaidLoanTransactions = `-- name: GetUnpaidLoanTransactions :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND loan_id IS NOT NULL
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM loans l
+    WHERE l.id = transactions.loan_id
+      AND l.workspace_id = transactions.workspace_id
+      AND l.deleted_at IS NULL
+  )
+ORDER BY transaction_date ASC, id ASC
+`
+
+// Unpaid payments of live loans, oldest first, for due/overdue classification
+func (q *Queries) GetUnpaidLoanTransactions(ctx context.Context, workspaceID int32) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, getUnpaidLoanTransactions, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasPaidTransactionsByLoan = `-- name: HasPaidTransactionsByLoan :one
+SELECT EXISTS (
+    SELECT 1 FROM transactions
+    WHERE workspace_id = $1
+      AND loan_id = $2
+      AND is_paid = true
+      AND source <> 'loan_down_payment'
+      AND deleted_at IS NULL
+)::BOOLEAN as has_paid
+`
+
+type HasPaidTransactionsByLoanParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	LoanID      pgtype.Int4 `json:"loan_id"`
+}
+
+// Check if any transactions for this loan are paid (for provider change validation)
+func (q *Queries) HasPaidTransactionsByLoan(ctx context.Context, arg HasPaidTransactionsByLoanParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasPaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
+	var has_paid bool
+	err := row.Scan(&has_paid)
+	return has_paid, err
+}
+
+const isTransferPairReversed = `-- name: IsTransferPairReversed :one
+SELECT EXISTS (
+    SELECT 1 FROM transactions
+    WHERE workspace_id = $1
+      AND reverses_transfer_pair_id = $2
+      AND deleted_at IS NULL
+)::BOOLEAN as is_reversed
+`
+
+type IsTransferPairReversedParams struct {
+	WorkspaceID            int32       `json:"workspace_id"`
+	ReversesTransferPairID pgtype.UUID `json:"reverses_transfer_pair_id"`
+}
+
+// Check if a reversal transfer already exists for this transfer pair
+func (q *Queries) IsTransferPairReversed(ctx context.Context, arg IsTransferPairReversedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isTransferPairReversed, arg.WorkspaceID, arg.ReversesTransferPairID)
+	var is_reversed bool
+	err := row.Scan(&is_reversed)
+	return is_reversed, err
+}
+
+const listTransactionsByCursor = `-- name: ListTransactionsByCursor :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+  AND ($2::DATE IS NULL OR (transaction_date, id) > ($2::DATE, $3::INTEGER))
+ORDER BY transaction_date ASC, id ASC
+LIMIT $4
+`
+
+type ListTransactionsByCursorParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	AfterDate   pgtype.Date `json:"after_date"`
+	AfterID     int32       `json:"after_id"`
+	RowLimit    int32       `json:"row_limit"`
+}
+
+// Keyset page ordered by (transaction_date, id) for large exports; null after_date starts from the beginning
+func (q *Queries) ListTransactionsByCursor(ctx context.Context, arg ListTransactionsByCursorParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactionsByCursor,
+		arg.WorkspaceID,
+		arg.AfterDate,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const orphanActualsByTemplate = `-- name: OrphanActualsByTemplate :exec
+UPDATE transactions
+SET template_id = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND template_id = $2
+  AND is_projected = false
+  AND deleted_at IS NULL
+`
+
+type OrphanActualsByTemplateParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	TemplateID  pgtype.Int4 `json:"template_id"`
+}
+
+// Unlink actual transactions from template (keep them, clear template_id)
+func (q *Queries) OrphanActualsByTemplate(ctx context.Context, arg OrphanActualsByTemplateParams) error {
+	_, err := q.db.Exec(ctx, orphanActualsByTemplate, arg.WorkspaceID, arg.TemplateID)
+	return err
+}
+
+const orphanPaidTransactionsByLoan = `-- name: OrphanPaidTransactionsByLoan :exec
+UPDATE transactions
+SET deleted_loan_id = loan_id,
+    loan_id = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id = $2
+  AND is_paid = true
+  AND deleted_at IS NULL
+`
+
+type OrphanPaidTransactionsByLoanParams struct {
+	WorkspaceID int32       `json:"workspace_id"`
+	LoanID      pgtype.Int4 `json:"loan_id"`
+}
+
+// Unlink paid transactions from loan (keep them, clear loan_id)
+// Used when deleting a loan to preserve payment history; deleted_loan_id allows a later restore
+func (q *Queries) OrphanPaidTransactionsByLoan(ctx context.Context, arg OrphanPaidTransactionsByLoanParams) error {
+	_, err := q.db.Exec(ctx, orphanPaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
+	return err
+}
+
+const promoteAllDueScheduledTransactions = `-- name: PromoteAllDueScheduledTransactions :many
+UPDATE transactions
+SET is_scheduled = false, updated_at = NOW()
+WHERE is_scheduled = true
+  AND transaction_date <= $1
+  AND deleted_at IS NULL
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
+`
+
+// Activates due scheduled transactions across all workspaces (daily job)
+func (q *Queries) PromoteAllDueScheduledTransactions(ctx context.Context, transactionDate pgtype.Date) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, promoteAllDueScheduledTransactions, transactionDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const promoteDueScheduledTransactions = `-- name: PromoteDueScheduledTransactions :many
+UPDATE transactions
+SET is_scheduled = false, updated_at = NOW()
+WHERE workspace_id = $1
+  AND is_scheduled = true
+  AND transaction_date <= $2
+  AND deleted_at IS NULL
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
+`
+
+type PromoteDueScheduledTransactionsParams struct {
+	WorkspaceID     int32       `json:"workspace_id"`
+	TransactionDate pgtype.Date `json:"transaction_date"`
+}
+
+// Activates a workspace's scheduled transactions whose date has arrived
+func (q *Queries) PromoteDueScheduledTransactions(ctx context.Context, arg PromoteDueScheduledTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, promoteDueScheduledTransactions,
+		arg.WorkspaceID,
+		arg.TransactionDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
+			&i.Amount,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
 			&i.BilledAt,
 			&i.SettlementIntent,
 			&i.Source,
@@ -2081,8 +3961,21 @@ func (q *Queries) GetTransactionsForAggregation(ctx context.Context, arg GetTran
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
-			&i.CategoryName,
-			&i.GroupName,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -2094,100 +3987,59 @@ func (q *Queries) GetTransactionsForAggregation(ctx context.Context, arg GetTran
 	return items, nil
 }
 
-const getTransactionsWithCategory = `-- name: GetTransactionsWithCategory :many
-SELECT
-    t.id,
-    t.workspace_id,
-    t.account_id,
-    t.name,
-    t.amount,
-    t.type,
-    t.transaction_date,
-    t.is_paid,
-    t.notes,
-    t.transfer_pair_id,
-    t.category_id,
-    t.is_cc_payment,
-    t.created_at,
-    t.updated_at,
-    t.deleted_at,
-    t.billed_at,
-    t.settlement_intent,
-    t.source,
-    t.template_id,
-    t.is_projected,
-    t.loan_id,
-    t.group_id,
-    bc.name AS category_name,
-    tg.name AS group_name
-FROM transactions t
-LEFT JOIN budget_categories bc ON t.category_id = bc.id AND bc.deleted_at IS NULL
-LEFT JOIN transaction_groups tg ON t.group_id = tg.id
-WHERE t.workspace_id = $1
-  AND t.deleted_at IS NULL
-  AND ($2::INTEGER IS NULL OR t.account_id = $2)
-  AND ($3::DATE IS NULL OR t.transaction_date >= $3)
-  AND ($4::DATE IS NULL OR t.transaction_date <= $4)
-  AND ($5::VARCHAR IS NULL OR t.type = $5)
-ORDER BY t.transaction_date DESC, t.created_at DESC
-LIMIT $7 OFFSET $6
+const restoreTransactionsByDeletedLoan = `-- name: RestoreTransactionsByDeletedLoan :exec
+UPDATE transactions
+SET loan_id = deleted_loan_id,
+    deleted_loan_id = NULL,
+    deleted_at = CASE WHEN is_paid THEN deleted_at ELSE NULL END,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND deleted_loan_id = $2
 `
 
-type GetTransactionsWithCategoryParams struct {
-	WorkspaceID int32       `json:"workspace_id"`
-	AccountID   pgtype.Int4 `json:"account_id"`
-	StartDate   pgtype.Date `json:"start_date"`
-	EndDate     pgtype.Date `json:"end_date"`
-	Type        pgtype.Text `json:"type"`
-	PageOffset  int32       `json:"page_offset"`
-	PageSize    int32       `json:"page_size"`
+type RestoreTransactionsByDeletedLoanParams struct {
+	WorkspaceID   int32       `json:"workspace_id"`
+	DeletedLoanID pgtype.Int4 `json:"deleted_loan_id"`
 }
 
-type GetTransactionsWithCategoryRow struct {
-	ID               int32              `json:"id"`
-	WorkspaceID      int32              `json:"workspace_id"`
-	AccountID        int32              `json:"account_id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	IsPaid           bool               `json:"is_paid"`
-	Notes            pgtype.Text        `json:"notes"`
-	TransferPairID   pgtype.UUID        `json:"transfer_pair_id"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsCcPayment      bool               `json:"is_cc_payment"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
-	LoanID           pgtype.Int4        `json:"loan_id"`
-	GroupID          pgtype.Int4        `json:"group_id"`
-	CategoryName     pgtype.Text        `json:"category_name"`
-	GroupName        pgtype.Text        `json:"group_name"`
+// Re-link transactions unlinked by a loan deletion; unpaid ones were soft-deleted with the loan and come back
+func (q *Queries) RestoreTransactionsByDeletedLoan(ctx context.Context, arg RestoreTransactionsByDeletedLoanParams) error {
+	_, err := q.db.Exec(ctx, restoreTransactionsByDeletedLoan, arg.WorkspaceID, arg.DeletedLoanID)
+	return err
 }
 
-// Returns transactions with category name and group name joined for display
-func (q *Queries) GetTransactionsWithCategory(ctx context.Context, arg GetTransactionsWithCategoryParams) ([]GetTransactionsWithCategoryRow, error) {
-	rows, err := q.db.Query(ctx, getTransactionsWithCategory,
+const searchTransactions = `-- name: SearchTransactions :many
+SELECT id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee FROM transactions
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+  AND (name ILIKE '%' || $2::text || '%' ESCAPE '\'
+    OR merchant ILIKE '%' || $2::text || '%' ESCAPE '\'
+    OR payee ILIKE '%' || $2::text || '%' ESCAPE '\')
+ORDER BY transaction_date DESC, id DESC
+LIMIT $3::int
+`
+
+type SearchTransactionsParams struct {
+	WorkspaceID int32  `json:"workspace_id"`
+	Query       string `json:"query"`
+	MaxResults  int32  `json:"max_results"`
+}
+
+// Case-insensitive substring match on name, merchant, or payee for global search, newest first.
+// The query must have its LIKE wildcards escaped with a backslash so they match literally
+func (q *Queries) SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, searchTransactions,
 		arg.WorkspaceID,
-		arg.AccountID,
-		arg.StartDate,
-		arg.EndDate,
-		arg.Type,
-		arg.PageOffset,
-		arg.PageSize,
+		arg.Query,
+		arg.MaxResults,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetTransactionsWithCategoryRow{}
+	items := []Transaction{}
 	for rows.Next() {
-		var i GetTransactionsWithCategoryRow
+		var i Transaction
 		if err := rows.Scan(
 			&i.ID,
 			&i.WorkspaceID,
@@ -2198,12 +4050,12 @@ func (q *Queries) GetTransactionsWithCategory(ctx context.Context, arg GetTransa
 			&i.TransactionDate,
 			&i.IsPaid,
 			&i.Notes,
-			&i.TransferPairID,
-			&i.CategoryID,
-			&i.IsCcPayment,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
 			&i.BilledAt,
 			&i.SettlementIntent,
 			&i.Source,
@@ -2211,8 +4063,21 @@ func (q *Queries) GetTransactionsWithCategory(ctx context.Context, arg GetTransa
 			&i.IsProjected,
 			&i.LoanID,
 			&i.GroupID,
-			&i.CategoryName,
-			&i.GroupName,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -2224,75 +4089,82 @@ func (q *Queries) GetTransactionsWithCategory(ctx context.Context, arg GetTransa
 	return items, nil
 }
 
-const getUnpaidLoanPaymentsByProviderMonth = `-- name: GetUnpaidLoanPaymentsByProviderMonth :many
-SELECT
-    t.id,
-    t.loan_id,
-    ROW_NUMBER() OVER (PARTITION BY t.loan_id ORDER BY t.transaction_date ASC, t.id ASC)::INTEGER as payment_number,
-    t.amount,
-    EXTRACT(YEAR FROM t.transaction_date)::INTEGER as due_year,
-    EXTRACT(MONTH FROM t.transaction_date)::INTEGER as due_month,
-    t.is_paid as paid,
-    CASE WHEN t.is_paid = true THEN t.updated_at ELSE NULL END as paid_date,
-    t.created_at,
-    t.updated_at
-FROM transactions t
-JOIN loans l ON t.loan_id = l.id AND l.deleted_at IS NULL
-WHERE t.workspace_id = $1
-  AND l.provider_id = $2
-  AND EXTRACT(YEAR FROM t.transaction_date)::INTEGER = CAST($3 AS INTEGER)
-  AND EXTRACT(MONTH FROM t.transaction_date)::INTEGER = CAST($4 AS INTEGER)
-  AND t.is_paid = false
-  AND t.deleted_at IS NULL
-ORDER BY t.transaction_date ASC, t.id ASC
+const settleTransactionsByLoanExternally = `-- name: SettleTransactionsByLoanExternally :many
+UPDATE transactions
+SET is_paid = true,
+    paid_at = $3,
+    source = 'external',
+    notes = COALESCE(NULLIF($4::text, ''), notes),
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND loan_id = $2
+  AND is_paid = false
+  AND source <> 'loan_down_payment'
+  AND deleted_at IS NULL
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
-type GetUnpaidLoanPaymentsByProviderMonthParams struct {
-	WorkspaceID int32 `json:"workspace_id"`
-	ProviderID  int32 `json:"provider_id"`
-	Year        int32 `json:"year"`
-	Month       int32 `json:"month"`
-}
-
-type GetUnpaidLoanPaymentsByProviderMonthRow struct {
-	ID            int32              `json:"id"`
-	LoanID        pgtype.Int4        `json:"loan_id"`
-	PaymentNumber int32              `json:"payment_number"`
-	Amount        pgtype.Numeric     `json:"amount"`
-	DueYear       int32              `json:"due_year"`
-	DueMonth      int32              `json:"due_month"`
-	Paid          bool               `json:"paid"`
-	PaidDate      interface{}        `json:"paid_date"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+type SettleTransactionsByLoanExternallyParams struct {
+	WorkspaceID int32              `json:"workspace_id"`
+	LoanID      pgtype.Int4        `json:"loan_id"`
+	PaidAt      pgtype.Timestamptz `json:"paid_at"`
+	Notes       string             `json:"notes"`
 }
 
-// Get unpaid loan payments for a specific provider and month (for pay-month action)
-func (q *Queries) GetUnpaidLoanPaymentsByProviderMonth(ctx context.Context, arg GetUnpaidLoanPaymentsByProviderMonthParams) ([]GetUnpaidLoanPaymentsByProviderMonthRow, error) {
-	rows, err := q.db.Query(ctx, getUnpaidLoanPaymentsByProviderMonth,
+// Mark every unpaid payment of a loan paid outside the app, tagging it with the external source
+// The note replaces the existing notes when given
+func (q *Queries) SettleTransactionsByLoanExternally(ctx context.Context, arg SettleTransactionsByLoanExternallyParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, settleTransactionsByLoanExternally,
 		arg.WorkspaceID,
-		arg.ProviderID,
-		arg.Year,
-		arg.Month,
+		arg.LoanID,
+		arg.PaidAt,
+		arg.Notes,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetUnpaidLoanPaymentsByProviderMonthRow{}
+	items := []Transaction{}
 	for rows.Next() {
-		var i GetUnpaidLoanPaymentsByProviderMonthRow
+		var i Transaction
 		if err := rows.Scan(
 			&i.ID,
-			&i.LoanID,
-			&i.PaymentNumber,
+			&i.WorkspaceID,
+			&i.AccountID,
+			&i.Name,
 			&i.Amount,
-			&i.DueYear,
-			&i.DueMonth,
-			&i.Paid,
-			&i.PaidDate,
+			&i.Type,
+			&i.TransactionDate,
+			&i.IsPaid,
+			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TransferPairID,
+			&i.CategoryID,
+			&i.IsCcPayment,
+			&i.BilledAt,
+			&i.SettlementIntent,
+			&i.Source,
+			&i.TemplateID,
+			&i.IsProjected,
+			&i.LoanID,
+			&i.GroupID,
+			&i.IsScheduled,
+			&i.PaidAt,
+			&i.Merchant,
+			&i.ReversesTransferPairID,
+			&i.IsRefund,
+			&i.DeletedLoanID,
+			&i.ExcludeFromReports,
+			&i.IsTaxDeductible,
+			&i.Payee,
+			&i.ModifiedFromTemplate,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Location,
+			&i.RoundUpSourceID,
+			&i.IsLoanFee,
 		); err != nil {
 			return nil, err
 		}
@@ -2304,70 +4176,24 @@ func (q *Queries) GetUnpaidLoanPaymentsByProviderMonth(ctx context.Context, arg
 	return items, nil
 }
 
-const hasPaidTransactionsByLoan = `-- name: HasPaidTransactionsByLoan :one
-SELECT EXISTS (
-    SELECT 1 FROM transactions
-    WHERE workspace_id = $1
-      AND loan_id = $2
-      AND is_paid = true
-      AND deleted_at IS NULL
-)::BOOLEAN as has_paid
-`
-
-type HasPaidTransactionsByLoanParams struct {
-	WorkspaceID int32       `json:"workspace_id"`
-	LoanID      pgtype.Int4 `json:"loan_id"`
-}
-
-// Check if any transactions for this loan are paid (for provider change validation)
-func (q *Queries) HasPaidTransactionsByLoan(ctx context.Context, arg HasPaidTransactionsByLoanParams) (bool, error) {
-	row := q.db.QueryRow(ctx, hasPaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
-	var has_paid bool
-	err := row.Scan(&has_paid)
-	return has_paid, err
-}
-
-const orphanActualsByTemplate = `-- name: OrphanActualsByTemplate :exec
-UPDATE transactions
-SET template_id = NULL,
-    updated_at = NOW()
-WHERE workspace_id = $1
-  AND template_id = $2
-  AND is_projected = false
-  AND deleted_at IS NULL
-`
-
-type OrphanActualsByTemplateParams struct {
-	WorkspaceID int32       `json:"workspace_id"`
-	TemplateID  pgtype.Int4 `json:"template_id"`
-}
-
-// Unlink actual transactions from template (keep them, clear template_id)
-func (q *Queries) OrphanActualsByTemplate(ctx context.Context, arg OrphanActualsByTemplateParams) error {
-	_, err := q.db.Exec(ctx, orphanActualsByTemplate, arg.WorkspaceID, arg.TemplateID)
-	return err
-}
-
-const orphanPaidTransactionsByLoan = `-- name: OrphanPaidTransactionsByLoan :exec
+const softDeleteRoundUpsBySource = `-- name: SoftDeleteRoundUpsBySource :execrows
 UPDATE transactions
-SET loan_id = NULL,
-    updated_at = NOW()
-WHERE workspace_id = $1
-  AND loan_id = $2
-  AND is_paid = true
-  AND deleted_at IS NULL
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND round_up_source_id = $2 AND deleted_at IS NULL
 `
 
-type OrphanPaidTransactionsByLoanParams struct {
-	WorkspaceID int32       `json:"workspace_id"`
-	LoanID      pgtype.Int4 `json:"loan_id"`
+type SoftDeleteRoundUpsBySourceParams struct {
+	WorkspaceID     int32       `json:"workspace_id"`
+	RoundUpSourceID pgtype.Int4 `json:"round_up_source_id"`
 }
 
-// Unlink paid transactions from loan (keep them, clear loan_id)
-// Used when deleting a loan to preserve payment history
-func (q *Queries) OrphanPaidTransactionsByLoan(ctx context.Context, arg OrphanPaidTransactionsByLoanParams) error {
-	_, err := q.db.Exec(ctx, orphanPaidTransactionsByLoan, arg.WorkspaceID, arg.LoanID)
-	return err
+// Removes the round-up transfer legs created for an expense
+func (q *Queries) SoftDeleteRoundUpsBySource(ctx context.Context, arg SoftDeleteRoundUpsBySourceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteRoundUpsBySource, arg.WorkspaceID, arg.RoundUpSourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const softDeleteTransaction = `-- name: SoftDeleteTransaction :execrows
@@ -2389,6 +4215,25 @@ func (q *Queries) SoftDeleteTransaction(ctx context.Context, arg SoftDeleteTrans
 	return result.RowsAffected(), nil
 }
 
+const softDeleteTransactionsByIDs = `-- name: SoftDeleteTransactionsByIDs :execrows
+UPDATE transactions
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE workspace_id = $1 AND id = ANY($2::int[]) AND deleted_at IS NULL
+`
+
+type SoftDeleteTransactionsByIDsParams struct {
+	WorkspaceID int32   `json:"workspace_id"`
+	Column2     []int32 `json:"column_2"`
+}
+
+func (q *Queries) SoftDeleteTransactionsByIDs(ctx context.Context, arg SoftDeleteTransactionsByIDsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteTransactionsByIDs, arg.WorkspaceID, arg.Column2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const softDeleteTransferPair = `-- name: SoftDeleteTransferPair :execrows
 UPDATE transactions
 SET deleted_at = NOW(), updated_at = NOW()
@@ -2420,60 +4265,78 @@ WHERE t.workspace_id = $1
   AND t.settlement_intent = 'deferred'
   AND a.template = 'credit_card'
   AND t.deleted_at IS NULL
+  AND ($4::INTEGER[] IS NULL OR t.account_id = ANY($4::INTEGER[]))
 `
 
 type SumDeferredCCByDateRangeParams struct {
 	WorkspaceID       int32       `json:"workspace_id"`
 	TransactionDate   pgtype.Date `json:"transaction_date"`
 	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+	Column4           []int32     `json:"column_4"`
 }
 
 // Sum deferred CC expenses within a date range
 // Used for next month projections
+// A NULL $4 counts every account; otherwise only the listed accounts
 func (q *Queries) SumDeferredCCByDateRange(ctx context.Context, arg SumDeferredCCByDateRangeParams) (pgtype.Numeric, error) {
-	row := q.db.QueryRow(ctx, sumDeferredCCByDateRange, arg.WorkspaceID, arg.TransactionDate, arg.TransactionDate_2)
+	row := q.db.QueryRow(ctx, sumDeferredCCByDateRange,
+		arg.WorkspaceID,
+		arg.TransactionDate,
+		arg.TransactionDate_2,
+		arg.Column4,
+	)
 	var total pgtype.Numeric
 	err := row.Scan(&total)
 	return total, err
 }
 
 const sumPaidExpensesByDateRange = `-- name: SumPaidExpensesByDateRange :one
-SELECT COALESCE(SUM(amount), 0)::NUMERIC(12,2) as total
+SELECT COALESCE(SUM(CASE WHEN is_refund THEN -amount ELSE amount END), 0)::NUMERIC(12,2) as total
 FROM transactions
 WHERE workspace_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
-  AND type = 'expense'
+  AND (type = 'expense' OR is_refund = true)
   AND is_paid = true
   AND transfer_pair_id IS NULL
   AND deleted_at IS NULL
+  AND ($4::INTEGER[] IS NULL OR account_id = ANY($4::INTEGER[]))
 `
 
 type SumPaidExpensesByDateRangeParams struct {
 	WorkspaceID       int32       `json:"workspace_id"`
 	TransactionDate   pgtype.Date `json:"transaction_date"`
 	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+	Column4           []int32     `json:"column_4"`
 }
 
 // Sum paid expenses within a date range for in-hand balance calculation
 // Excludes transfers (they move money between accounts, not actual spending)
+// Refunds are netted out of spending
+// A NULL $4 counts every account; otherwise only the listed accounts
 func (q *Queries) SumPaidExpensesByDateRange(ctx context.Context, arg SumPaidExpensesByDateRangeParams) (pgtype.Numeric, error) {
-	row := q.db.QueryRow(ctx, sumPaidExpensesByDateRange, arg.WorkspaceID, arg.TransactionDate, arg.TransactionDate_2)
+	row := q.db.QueryRow(ctx, sumPaidExpensesByDateRange,
+		arg.WorkspaceID,
+		arg.TransactionDate,
+		arg.TransactionDate_2,
+		arg.Column4,
+	)
 	var total pgtype.Numeric
 	err := row.Scan(&total)
 	return total, err
 }
 
 const sumTransactionsByTypeAndDateRange = `-- name: SumTransactionsByTypeAndDateRange :one
-SELECT COALESCE(SUM(amount), 0)::NUMERIC(12,2) as total
+SELECT COALESCE(SUM(CASE WHEN is_refund THEN -amount ELSE amount END), 0)::NUMERIC(12,2) as total
 FROM transactions
 WHERE workspace_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
-  AND type = $4
+  AND ((type = $4 AND is_refund = false) OR (is_refund = true AND $4 = 'expense'))
   AND is_paid = true
   AND transfer_pair_id IS NULL
   AND deleted_at IS NULL
+  AND ($5::INTEGER[] IS NULL OR account_id = ANY($5::INTEGER[]))
 `
 
 type SumTransactionsByTypeAndDateRangeParams struct {
@@ -2481,15 +4344,19 @@ type SumTransactionsByTypeAndDateRangeParams struct {
 	TransactionDate   pgtype.Date `json:"transaction_date"`
 	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
 	Type              string      `json:"type"`
+	Column5           []int32     `json:"column_5"`
 }
 
 // Only count paid transactions, excludes transfers
+// Refunds never count as income; they reduce the expense total
+// A NULL $5 counts every account; otherwise only the listed accounts
 func (q *Queries) SumTransactionsByTypeAndDateRange(ctx context.Context, arg SumTransactionsByTypeAndDateRangeParams) (pgtype.Numeric, error) {
 	row := q.db.QueryRow(ctx, sumTransactionsByTypeAndDateRange,
 		arg.WorkspaceID,
 		arg.TransactionDate,
 		arg.TransactionDate_2,
 		arg.Type,
+		arg.Column5,
 	)
 	var total pgtype.Numeric
 	err := row.Scan(&total)
@@ -2536,20 +4403,28 @@ WHERE t.workspace_id = $1
   AND t.transfer_pair_id IS NULL
   AND t.deleted_at IS NULL
   AND NOT (a.template = 'credit_card' AND t.settlement_intent = 'deferred')
+  AND ($4::INTEGER[] IS NULL OR t.account_id = ANY($4::INTEGER[]))
 `
 
 type SumUnpaidExpensesForDisposableParams struct {
 	WorkspaceID       int32       `json:"workspace_id"`
 	TransactionDate   pgtype.Date `json:"transaction_date"`
 	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+	Column4           []int32     `json:"column_4"`
 }
 
 // Sum unpaid expenses for disposable income calculation
 // EXCLUDES deferred CC transactions (those are for next month)
 // EXCLUDES transfers (they move money between accounts, not actual spending)
 // Includes: non-CC unpaid expenses + immediate CC expenses
+// A NULL $4 counts every account; otherwise only the listed accounts
 func (q *Queries) SumUnpaidExpensesForDisposable(ctx context.Context, arg SumUnpaidExpensesForDisposableParams) (pgtype.Numeric, error) {
-	row := q.db.QueryRow(ctx, sumUnpaidExpensesForDisposable, arg.WorkspaceID, arg.TransactionDate, arg.TransactionDate_2)
+	row := q.db.QueryRow(ctx, sumUnpaidExpensesForDisposable,
+		arg.WorkspaceID,
+		arg.TransactionDate,
+		arg.TransactionDate_2,
+		arg.Column4,
+	)
 	var total pgtype.Numeric
 	err := row.Scan(&total)
 	return total, err
@@ -2561,7 +4436,7 @@ UPDATE transactions
 SET billed_at = CASE WHEN billed_at IS NULL THEN NOW() ELSE NULL END,
     updated_at = NOW()
 WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type ToggleBilledStatusParams struct {
@@ -2600,15 +4475,32 @@ func (q *Queries) ToggleBilledStatus(ctx context.Context, arg ToggleBilledStatus
 		&i.IsProjected,
 		&i.LoanID,
 		&i.GroupID,
+		&i.IsScheduled,
+		&i.PaidAt,
+		&i.Merchant,
+		&i.ReversesTransferPairID,
+		&i.IsRefund,
+		&i.DeletedLoanID,
+		&i.ExcludeFromReports,
+		&i.IsTaxDeductible,
+		&i.Payee,
+		&i.ModifiedFromTemplate,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Location,
+		&i.RoundUpSourceID,
+		&i.IsLoanFee,
 	)
 	return i, err
 }
 
 const toggleTransactionPaidStatus = `-- name: ToggleTransactionPaidStatus :one
 UPDATE transactions
-SET is_paid = NOT is_paid, updated_at = NOW()
+SET is_paid = NOT is_paid,
+    paid_at = CASE WHEN is_paid THEN NULL ELSE NOW() END,
+    updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type ToggleTransactionPaidStatusParams struct {
@@ -2642,6 +4534,21 @@ func (q *Queries) ToggleTransactionPaidStatus(ctx context.Context, arg ToggleTra
 		&i.IsProjected,
 		&i.LoanID,
 		&i.GroupID,
+		&i.IsScheduled,
+		&i.PaidAt,
+		&i.Merchant,
+		&i.ReversesTransferPairID,
+		&i.IsRefund,
+		&i.DeletedLoanID,
+		&i.ExcludeFromReports,
+		&i.IsTaxDeductible,
+		&i.Payee,
+		&i.ModifiedFromTemplate,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Location,
+		&i.RoundUpSourceID,
+		&i.IsLoanFee,
 	)
 	return i, err
 }
@@ -2657,32 +4564,53 @@ SET
     notes = $8,
     category_id = $9,
     is_paid = $10,
+    paid_at = CASE WHEN NOT $10::BOOLEAN THEN NULL WHEN is_paid THEN paid_at ELSE NOW() END,
     billed_at = $11,
     settlement_intent = $12,
     source = $13,
     template_id = $14,
     is_projected = $15,
+    is_scheduled = $16,
+    merchant = $17,
+    is_refund = $18,
+    exclude_from_reports = $19,
+    is_tax_deductible = $20,
+    payee = $21,
+    modified_from_template = $22,
+    latitude = $23,
+    longitude = $24,
+    location = $25,
     updated_at = NOW()
 WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
-RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id
+RETURNING id, workspace_id, account_id, name, amount, type, transaction_date, is_paid, notes, created_at, updated_at, deleted_at, transfer_pair_id, category_id, is_cc_payment, billed_at, settlement_intent, source, template_id, is_projected, loan_id, group_id, is_scheduled, paid_at, merchant, reverses_transfer_pair_id, is_refund, deleted_loan_id, exclude_from_reports, is_tax_deductible, payee, modified_from_template, latitude, longitude, location, round_up_source_id, is_loan_fee
 `
 
 type UpdateTransactionParams struct {
-	WorkspaceID      int32              `json:"workspace_id"`
-	ID               int32              `json:"id"`
-	Name             string             `json:"name"`
-	Amount           pgtype.Numeric     `json:"amount"`
-	Type             string             `json:"type"`
-	TransactionDate  pgtype.Date        `json:"transaction_date"`
-	AccountID        int32              `json:"account_id"`
-	Notes            pgtype.Text        `json:"notes"`
-	CategoryID       pgtype.Int4        `json:"category_id"`
-	IsPaid           bool               `json:"is_paid"`
-	BilledAt         pgtype.Timestamptz `json:"billed_at"`
-	SettlementIntent pgtype.Text        `json:"settlement_intent"`
-	Source           pgtype.Text        `json:"source"`
-	TemplateID       pgtype.Int4        `json:"template_id"`
-	IsProjected      pgtype.Bool        `json:"is_projected"`
+	WorkspaceID          int32              `json:"workspace_id"`
+	ID                   int32              `json:"id"`
+	Name                 string             `json:"name"`
+	Amount               pgtype.Numeric     `json:"amount"`
+	Type                 string             `json:"type"`
+	TransactionDate      pgtype.Date        `json:"transaction_date"`
+	AccountID            int32              `json:"account_id"`
+	Notes                pgtype.Text        `json:"notes"`
+	CategoryID           pgtype.Int4        `json:"category_id"`
+	IsPaid               bool               `json:"is_paid"`
+	BilledAt             pgtype.Timestamptz `json:"billed_at"`
+	SettlementIntent     pgtype.Text        `json:"settlement_intent"`
+	Source               pgtype.Text        `json:"source"`
+	TemplateID           pgtype.Int4        `json:"template_id"`
+	IsProjected          pgtype.Bool        `json:"is_projected"`
+	IsScheduled          bool               `json:"is_scheduled"`
+	Merchant             pgtype.Text        `json:"merchant"`
+	IsRefund             bool               `json:"is_refund"`
+	ExcludeFromReports   bool               `json:"exclude_from_reports"`
+	IsTaxDeductible      bool               `json:"is_tax_deductible"`
+	Payee                pgtype.Text        `json:"payee"`
+	ModifiedFromTemplate bool               `json:"modified_from_template"`
+	Latitude             pgtype.Numeric     `json:"latitude"`
+	Longitude            pgtype.Numeric     `json:"longitude"`
+	Location             pgtype.Text        `json:"location"`
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
@@ -2702,6 +4630,16 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		arg.Source,
 		arg.TemplateID,
 		arg.IsProjected,
+		arg.IsScheduled,
+		arg.Merchant,
+		arg.IsRefund,
+		arg.ExcludeFromReports,
+		arg.IsTaxDeductible,
+		arg.Payee,
+		arg.ModifiedFromTemplate,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Location,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -2727,6 +4665,21 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		&i.IsProjected,
 		&i.LoanID,
 		&i.GroupID,
+		&i.IsScheduled,
+		&i.PaidAt,
+		&i.Merchant,
+		&i.ReversesTransferPairID,
+		&i.IsRefund,
+		&i.DeletedLoanID,
+		&i.ExcludeFromReports,
+		&i.IsTaxDeductible,
+		&i.Payee,
+		&i.ModifiedFromTemplate,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Location,
+		&i.RoundUpSourceID,
+		&i.IsLoanFee,
 	)
 	return i, err
 }
@@ -2737,6 +4690,7 @@ SET name = $3,
     updated_at = NOW()
 WHERE workspace_id = $1
   AND loan_id = $2
+  AND source <> 'loan_down_payment'
   AND deleted_at IS NULL
 `
 
@@ -14,7 +14,7 @@ import (
 const createWorkspace = `-- name: CreateWorkspace :one
 INSERT INTO workspaces (user_id, name)
 VALUES ($1, $2)
-RETURNING id, user_id, name, created_at, updated_at
+RETURNING id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months
 `
 
 type CreateWorkspaceParams struct {
@@ -31,6 +31,9 @@ func (q *Queries) CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
 	)
 	return i, err
 }
@@ -45,7 +48,7 @@ func (q *Queries) DeleteWorkspace(ctx context.Context, id int32) error {
 }
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
-SELECT id, user_id, name, created_at, updated_at FROM workspaces WHERE id = $1
+SELECT id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months FROM workspaces WHERE id = $1
 `
 
 func (q *Queries) GetWorkspaceByID(ctx context.Context, id int32) (Workspace, error) {
@@ -57,14 +60,19 @@ func (q *Queries) GetWorkspaceByID(ctx context.Context, id int32) (Workspace, er
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
 	)
 	return i, err
 }
 
 const getWorkspaceByUserAuth0ID = `-- name: GetWorkspaceByUserAuth0ID :one
-SELECT w.id, w.user_id, w.name, w.created_at, w.updated_at FROM workspaces w
+SELECT w.id, w.user_id, w.name, w.created_at, w.updated_at, w.currency, w.round_up_savings_account_id, w.generation_horizon_months FROM workspaces w
 INNER JOIN users u ON w.user_id = u.id
 WHERE u.auth0_id = $1
+ORDER BY w.id
+LIMIT 1
 `
 
 func (q *Queries) GetWorkspaceByUserAuth0ID(ctx context.Context, auth0ID string) (Workspace, error) {
@@ -76,12 +84,17 @@ func (q *Queries) GetWorkspaceByUserAuth0ID(ctx context.Context, auth0ID string)
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
 	)
 	return i, err
 }
 
 const getWorkspaceByUserID = `-- name: GetWorkspaceByUserID :one
-SELECT id, user_id, name, created_at, updated_at FROM workspaces WHERE user_id = $1
+SELECT id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months FROM workspaces WHERE user_id = $1
+ORDER BY id
+LIMIT 1
 `
 
 func (q *Queries) GetWorkspaceByUserID(ctx context.Context, userID pgtype.UUID) (Workspace, error) {
@@ -93,15 +106,69 @@ func (q *Queries) GetWorkspaceByUserID(ctx context.Context, userID pgtype.UUID)
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
 	)
 	return i, err
 }
 
+const listWorkspacesByUserAuth0ID = `-- name: ListWorkspacesByUserAuth0ID :many
+SELECT w.id, w.user_id, w.name, w.created_at, w.updated_at, w.currency, w.round_up_savings_account_id, w.generation_horizon_months, wm.role FROM workspaces w
+INNER JOIN workspace_members wm ON wm.workspace_id = w.id
+INNER JOIN users u ON wm.user_id = u.id
+WHERE u.auth0_id = $1
+ORDER BY w.id
+`
+
+type ListWorkspacesByUserAuth0IDRow struct {
+	ID                      int32              `json:"id"`
+	UserID                  pgtype.UUID        `json:"user_id"`
+	Name                    string             `json:"name"`
+	CreatedAt               pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+	Currency                string             `json:"currency"`
+	RoundUpSavingsAccountID pgtype.Int4        `json:"round_up_savings_account_id"`
+	GenerationHorizonMonths int32              `json:"generation_horizon_months"`
+	Role                    string             `json:"role"`
+}
+
+// Includes workspaces shared with the user, with the user's role in each
+func (q *Queries) ListWorkspacesByUserAuth0ID(ctx context.Context, auth0ID string) ([]ListWorkspacesByUserAuth0IDRow, error) {
+	rows, err := q.db.Query(ctx, listWorkspacesByUserAuth0ID, auth0ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWorkspacesByUserAuth0IDRow{}
+	for rows.Next() {
+		var i ListWorkspacesByUserAuth0IDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Currency,
+			&i.RoundUpSavingsAccountID,
+			&i.GenerationHorizonMonths,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateWorkspace = `-- name: UpdateWorkspace :one
 UPDATE workspaces
 SET name = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, name, created_at, updated_at
+RETURNING id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months
 `
 
 type UpdateWorkspaceParams struct {
@@ -118,6 +185,93 @@ func (q *Queries) UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
+	)
+	return i, err
+}
+
+const updateWorkspaceCurrency = `-- name: UpdateWorkspaceCurrency :one
+UPDATE workspaces
+SET currency = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months
+`
+
+type UpdateWorkspaceCurrencyParams struct {
+	ID       int32  `json:"id"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) UpdateWorkspaceCurrency(ctx context.Context, arg UpdateWorkspaceCurrencyParams) (Workspace, error) {
+	row := q.db.QueryRow(ctx, updateWorkspaceCurrency, arg.ID, arg.Currency)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
+	)
+	return i, err
+}
+
+const updateWorkspaceGenerationHorizon = `-- name: UpdateWorkspaceGenerationHorizon :one
+UPDATE workspaces
+SET generation_horizon_months = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months
+`
+
+type UpdateWorkspaceGenerationHorizonParams struct {
+	ID                      int32 `json:"id"`
+	GenerationHorizonMonths int32 `json:"generation_horizon_months"`
+}
+
+func (q *Queries) UpdateWorkspaceGenerationHorizon(ctx context.Context, arg UpdateWorkspaceGenerationHorizonParams) (Workspace, error) {
+	row := q.db.QueryRow(ctx, updateWorkspaceGenerationHorizon, arg.ID, arg.GenerationHorizonMonths)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
+	)
+	return i, err
+}
+
+const updateWorkspaceRoundUpSavingsAccount = `-- name: UpdateWorkspaceRoundUpSavingsAccount :one
+UPDATE workspaces
+SET round_up_savings_account_id = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, name, created_at, updated_at, currency, round_up_savings_account_id, generation_horizon_months
+`
+
+type UpdateWorkspaceRoundUpSavingsAccountParams struct {
+	ID                      int32       `json:"id"`
+	RoundUpSavingsAccountID pgtype.Int4 `json:"round_up_savings_account_id"`
+}
+
+func (q *Queries) UpdateWorkspaceRoundUpSavingsAccount(ctx context.Context, arg UpdateWorkspaceRoundUpSavingsAccountParams) (Workspace, error) {
+	row := q.db.QueryRow(ctx, updateWorkspaceRoundUpSavingsAccount, arg.ID, arg.RoundUpSavingsAccountID)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Currency,
+		&i.RoundUpSavingsAccountID,
+		&i.GenerationHorizonMonths,
 	)
 	return i, err
 }
@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/joho/godotenv"
 )
 
@@ -12,17 +16,36 @@ import (
 type Config struct {
 	// Database
 	DatabaseURL string
+	// DBQueryTimeout bounds the database work of a single API request; 0 disables it
+	DBQueryTimeout time.Duration
 
 	// Auth0
 	Auth0Domain   string
 	Auth0Audience string
 	Auth0ClientID string
 
+	// JWKS caching for Auth0 signing keys (picks up key rotation without a restart)
+	JWKSCacheTTL        time.Duration
+	JWKSRefreshInterval time.Duration
+	JWKSMaxStale        time.Duration // How long past the TTL cached keys are served while Auth0 is unreachable
+
 	// Server
 	Port        string
 	CORSOrigins []string
 	Env         string
 
+	// Validation
+	MaxDecimalPlaces int32
+	MaxLoanMonths    int32
+	// Transactions
+	AllowZeroAmount bool // Accept zero-amount transactions (placeholders); amounts are otherwise positive
+
+	// Dashboard
+	SpendableTemplates []string // Account templates counted as spendable cash; empty keeps the defaults
+
+	// Months
+	MonthAutoCloseAfter int // Daily job closes months older than this many months; 0 disables it
+
 	// S3 Storage
 	S3 S3Config
 }
@@ -42,13 +65,19 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		DatabaseURL:   getEnv("DATABASE_URL", ""),
-		Auth0Domain:   getEnv("AUTH0_DOMAIN", ""),
-		Auth0Audience: getEnv("AUTH0_AUDIENCE", ""),
-		Auth0ClientID: getEnv("AUTH0_CLIENT_ID", ""),
-		Port:          getEnv("PORT", "8080"),
-		CORSOrigins:   strings.Split(getEnv("CORS_ORIGINS", "http://localhost:3000"), ","),
-		Env:           getEnv("ENV", "development"),
+		DatabaseURL:         getEnv("DATABASE_URL", ""),
+		DBQueryTimeout:      middleware.DefaultDBQueryTimeout,
+		Auth0Domain:         getEnv("AUTH0_DOMAIN", ""),
+		Auth0Audience:       getEnv("AUTH0_AUDIENCE", ""),
+		Auth0ClientID:       getEnv("AUTH0_CLIENT_ID", ""),
+		JWKSCacheTTL:        middleware.DefaultJWKSCacheTTL,
+		JWKSRefreshInterval: middleware.DefaultJWKSRefreshInterval,
+		JWKSMaxStale:        middleware.DefaultJWKSMaxStale,
+		Port:                getEnv("PORT", "8080"),
+		CORSOrigins:         strings.Split(getEnv("CORS_ORIGINS", "http://localhost:3000"), ","),
+		Env:                 getEnv("ENV", "development"),
+		MaxDecimalPlaces:    domain.DefaultMaxDecimalPlaces,
+		MaxLoanMonths:       domain.DefaultMaxLoanMonths,
 		S3: S3Config{
 			Region:          getEnv("S3_REGION", "us-east-1"),
 			Bucket:          getEnv("S3_BUCKET", "fortuna-images"),
@@ -58,6 +87,78 @@ func Load() (*Config, error) {
 		},
 	}
 
+	if v := getEnv("MAX_DECIMAL_PLACES", ""); v != "" {
+		places, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || places < 0 {
+			return nil, fmt.Errorf("MAX_DECIMAL_PLACES must be a non-negative integer")
+		}
+		cfg.MaxDecimalPlaces = int32(places)
+	}
+
+	if v := getEnv("MAX_LOAN_MONTHS", ""); v != "" {
+		months, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || months < 1 {
+			return nil, fmt.Errorf("MAX_LOAN_MONTHS must be a positive integer")
+		}
+		cfg.MaxLoanMonths = int32(months)
+	}
+
+	if v := getEnv("ALLOW_ZERO_AMOUNT_TRANSACTIONS", ""); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("ALLOW_ZERO_AMOUNT_TRANSACTIONS must be a boolean")
+		}
+		cfg.AllowZeroAmount = allow
+	}
+
+	if v := getEnv("AUTH0_JWKS_CACHE_TTL", ""); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("AUTH0_JWKS_CACHE_TTL must be a positive duration (e.g. 5m)")
+		}
+		cfg.JWKSCacheTTL = ttl
+	}
+
+	if v := getEnv("AUTH0_JWKS_REFRESH_INTERVAL", ""); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("AUTH0_JWKS_REFRESH_INTERVAL must be a non-negative duration (e.g. 1m, 0 disables)")
+		}
+		cfg.JWKSRefreshInterval = interval
+	}
+
+	if v := getEnv("AUTH0_JWKS_MAX_STALE", ""); v != "" {
+		maxStale, err := time.ParseDuration(v)
+		if err != nil || maxStale <= 0 {
+			return nil, fmt.Errorf("AUTH0_JWKS_MAX_STALE must be a positive duration (e.g. 1h)")
+		}
+		cfg.JWKSMaxStale = maxStale
+	}
+
+	if v := getEnv("SPENDABLE_ACCOUNT_TEMPLATES", ""); v != "" {
+		for _, template := range strings.Split(v, ",") {
+			if template = strings.TrimSpace(template); template != "" {
+				cfg.SpendableTemplates = append(cfg.SpendableTemplates, template)
+			}
+		}
+	}
+
+	if v := getEnv("MONTH_AUTO_CLOSE_AFTER_MONTHS", ""); v != "" {
+		months, err := strconv.Atoi(v)
+		if err != nil || months < 0 {
+			return nil, fmt.Errorf("MONTH_AUTO_CLOSE_AFTER_MONTHS must be a non-negative integer (0 disables)")
+		}
+		cfg.MonthAutoCloseAfter = months
+	}
+
+	if v := getEnv("DB_QUERY_TIMEOUT", ""); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("DB_QUERY_TIMEOUT must be a non-negative duration (e.g. 30s, 0 disables)")
+		}
+		cfg.DBQueryTimeout = timeout
+	}
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -29,6 +30,41 @@ var TemplateToType = map[AccountTemplate]AccountType{
 	TemplateCreditCard: AccountTypeLiability,
 }
 
+// SpendableTemplates flags which account templates count towards spendable cash.
+// Templates that are absent or flagged false (credit cards, investment-style accounts) are excluded.
+type SpendableTemplates map[AccountTemplate]bool
+
+// DefaultSpendableTemplates returns the built-in flags: bank, cash and e-wallet balances count
+func DefaultSpendableTemplates() SpendableTemplates {
+	return SpendableTemplates{
+		TemplateBank:       true,
+		TemplateCash:       true,
+		TemplateEwallet:    true,
+		TemplateCreditCard: false,
+	}
+}
+
+// ParseSpendableTemplates builds flags where only the named templates count
+func ParseSpendableTemplates(templates []string) (SpendableTemplates, error) {
+	flags := make(SpendableTemplates, len(TemplateToType))
+	for template := range TemplateToType {
+		flags[template] = false
+	}
+	for _, name := range templates {
+		template := AccountTemplate(name)
+		if _, ok := TemplateToType[template]; !ok {
+			return nil, fmt.Errorf("unknown account template %q", name)
+		}
+		flags[template] = true
+	}
+	return flags, nil
+}
+
+// Includes reports whether the account's balance counts towards spendable cash
+func (s SpendableTemplates) Includes(account *Account) bool {
+	return s[account.Template]
+}
+
 type Account struct {
 	ID             int32           `json:"id"`
 	WorkspaceID    int32           `json:"workspaceId"`
@@ -39,6 +75,14 @@ type Account struct {
 	CreatedAt      time.Time       `json:"createdAt"`
 	UpdatedAt      time.Time       `json:"updatedAt"`
 	DeletedAt      *time.Time      `json:"deletedAt,omitempty"`
+	AccountGroupID *int32          `json:"accountGroupId,omitempty"` // nil when ungrouped
+	// ExcludeFromNetWorth keeps the account listed but leaves its balance out of net worth
+	ExcludeFromNetWorth bool `json:"excludeFromNetWorth"`
+}
+
+// IncludeInNetWorth reports whether the account's balance counts towards net worth
+func (a *Account) IncludeInNetWorth() bool {
+	return !a.ExcludeFromNetWorth
 }
 
 // CCOutstandingSummary holds total CC outstanding across all accounts
@@ -54,13 +98,28 @@ type PerAccountOutstanding struct {
 	OutstandingBalance decimal.Decimal `json:"outstandingBalance"`
 }
 
+// ReconcileResult compares an account's computed balance with an externally reported one
+type ReconcileResult struct {
+	AccountID       int32           `json:"accountId"`
+	AsOf            time.Time       `json:"asOf"`
+	ComputedBalance decimal.Decimal `json:"computedBalance"`
+	ExternalBalance decimal.Decimal `json:"externalBalance"`
+	Difference      decimal.Decimal `json:"difference"`           // external - computed; zero when reconciled
+	Adjustment      *Transaction    `json:"adjustment,omitempty"` // Correction transaction, set only when one was created
+}
+
 type AccountRepository interface {
 	Create(account *Account) (*Account, error)
 	GetByID(workspaceID int32, id int32) (*Account, error)
-	GetAllByWorkspace(workspaceID int32, includeArchived bool) ([]*Account, error)
+	GetAllByWorkspace(workspaceID int32, opts ListOptions) ([]*Account, error)
 	Update(workspaceID int32, id int32, name string) (*Account, error)
+	UpdateTemplate(workspaceID int32, id int32, template AccountTemplate, accountType AccountType) (*Account, error)
+	CountTransactions(workspaceID int32, id int32) (int64, error) // Includes soft-deleted transactions
 	SoftDelete(workspaceID int32, id int32) error
 	HardDelete(workspaceID int32, id int32) error
+	SetGroup(workspaceID int32, id int32, groupID *int32) (*Account, error)
+	UngroupByGroup(workspaceID int32, groupID int32) error
+	SetExcludeFromNetWorth(workspaceID int32, id int32, exclude bool) (*Account, error)
 	GetCCOutstandingSummary(workspaceID int32) (*CCOutstandingSummary, error)
 	GetPerAccountOutstanding(workspaceID int32) ([]*PerAccountOutstanding, error)
 }
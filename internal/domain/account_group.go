@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAccountGroupNotFound      = errors.New("account group not found")
+	ErrAccountGroupAlreadyExists = errors.New("account group with this name already exists")
+)
+
+// MaxAccountGroupNameLength is the maximum length of an account group name
+const MaxAccountGroupNameLength = 100
+
+// AccountGroup is a user-defined folder for organizing accounts
+type AccountGroup struct {
+	ID          int32      `json:"id"`
+	WorkspaceID int32      `json:"workspaceId"`
+	Name        string     `json:"name"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+}
+
+type AccountGroupRepository interface {
+	Create(group *AccountGroup) (*AccountGroup, error)
+	GetByID(workspaceID int32, id int32) (*AccountGroup, error)
+	GetAllByWorkspace(workspaceID int32) ([]*AccountGroup, error)
+	Update(group *AccountGroup) (*AccountGroup, error)
+	SoftDelete(workspaceID int32, id int32) error
+}
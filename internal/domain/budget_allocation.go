@@ -69,6 +69,7 @@ type CategoryTransaction struct {
 	Amount          decimal.Decimal `json:"amount"`
 	TransactionDate string          `json:"transactionDate"`
 	AccountName     string          `json:"accountName"`
+	IsRefund        bool            `json:"isRefund"`
 }
 
 // CategoryTransactionsResponse contains transactions for a specific category
@@ -1,22 +1,54 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// colorPattern matches a #RRGGBB hex color string
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
 
 type BudgetCategory struct {
-	ID          int32      `json:"id"`
-	WorkspaceID int32      `json:"workspaceId"`
-	Name        string     `json:"name"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	ID              int32      `json:"id"`
+	WorkspaceID     int32      `json:"workspaceId"`
+	Name            string     `json:"name"`
+	Description     *string    `json:"description,omitempty"`
+	Color           *string    `json:"color,omitempty"`
+	ParentID        *int32     `json:"parentId,omitempty"` // Parent category; subcategories nest one level only
+	IsTaxDeductible bool       `json:"isTaxDeductible"`    // Default for new transactions in this category
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	DeletedAt       *time.Time `json:"deletedAt,omitempty"`
+}
+
+// BudgetCategoryNode is a top-level category with its subcategories
+type BudgetCategoryNode struct {
+	Category *BudgetCategory
+	Children []*BudgetCategory
+}
+
+// CategoryUsage is a category with its all-time transaction count and net spend
+type CategoryUsage struct {
+	Category         *BudgetCategory
+	TransactionCount int64
+	TotalSpent       decimal.Decimal // Expenses less refunds; projections excluded
 }
 
 type BudgetCategoryRepository interface {
 	Create(category *BudgetCategory) (*BudgetCategory, error)
-	GetByID(workspaceID int32, id int32) (*BudgetCategory, error)
+	GetByID(ctx context.Context, workspaceID int32, id int32) (*BudgetCategory, error)
 	GetByName(workspaceID int32, name string) (*BudgetCategory, error)
 	GetAllByWorkspace(workspaceID int32) ([]*BudgetCategory, error)
-	Update(workspaceID int32, id int32, name string) (*BudgetCategory, error)
-	SoftDelete(workspaceID int32, id int32) error
+	Update(category *BudgetCategory) (*BudgetCategory, error)
+	SoftDelete(workspaceID int32, id int32) error // Also promotes the category's children to top level
 	HasTransactions(workspaceID int32, id int32) (bool, error)
+	GetAllWithUsage(workspaceID int32) ([]CategoryUsage, error) // Includes categories with no transactions
+}
+
+// IsValidColor reports whether color is a #RRGGBB hex string
+func IsValidColor(color string) bool {
+	return colorPattern.MatchString(color)
 }
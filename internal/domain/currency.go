@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultCurrency is the currency a workspace uses until one is configured
+const DefaultCurrency = "MYR"
+
+// ErrInvalidCurrency is returned for codes that are not ISO 4217 currencies
+var ErrInvalidCurrency = errors.New("currency must be an ISO 4217 code")
+
+// iso4217Codes lists the circulating currencies in ISO 4217
+// Fund, precious metal and testing codes (e.g. XAU, XTS) are not accepted
+var iso4217Codes = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true,
+	"AWG": true, "AZN": true, "BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true,
+	"BMD": true, "BND": true, "BOB": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true,
+	"BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true, "COP": true, "CRC": true,
+	"CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true,
+	"ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true,
+	"HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true, "JMD": true,
+	"JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true,
+	"KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true,
+	"LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true, "MMK": true, "MNT": true, "MOP": true,
+	"MRU": true, "MUR": true, "MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true,
+	"NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true, "PAB": true, "PEN": true,
+	"PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true, "RON": true, "RSD": true,
+	"RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true, "SYP": true,
+	"SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true, "TTD": true,
+	"TWD": true, "TZS": true, "UAH": true, "UGX": true, "USD": true, "UYU": true, "UZS": true, "VED": true,
+	"VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XCG": true, "XOF": true,
+	"XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWG": true,
+}
+
+// currencySymbols maps currency codes to their display symbol; other codes display as the code itself
+var currencySymbols = map[string]string{
+	"MYR": "RM",
+	"USD": "$",
+	"SGD": "S$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"KRW": "₩",
+	"IDR": "Rp",
+	"THB": "฿",
+	"PHP": "₱",
+	"VND": "₫",
+	"INR": "₹",
+	"AUD": "A$",
+	"NZD": "NZ$",
+	"CAD": "CA$",
+	"HKD": "HK$",
+	"TWD": "NT$",
+	"BND": "B$",
+}
+
+// defaultMinorUnits is the number of decimal places for currencies not listed in currencyMinorUnits
+const defaultMinorUnits int32 = 2
+
+// currencyMinorUnits lists the currencies whose amounts are not shown with 2 decimal places
+// IDR is kept at 0: its ISO minor unit is 2 but it is not used in practice
+var currencyMinorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IDR": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0,
+	"XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// NormalizeCurrency upper-cases and trims a currency code, returning ErrInvalidCurrency if it is not ISO 4217
+func NormalizeCurrency(code string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if !iso4217Codes[normalized] {
+		return "", ErrInvalidCurrency
+	}
+	return normalized, nil
+}
+
+// CurrencySymbol returns the display symbol for a currency code, falling back to the code
+func CurrencySymbol(code string) string {
+	if symbol, ok := currencySymbols[code]; ok {
+		return symbol
+	}
+	return code
+}
+
+// CurrencyMinorUnits returns the number of decimal places amounts in a currency are shown with,
+// matching the code case-insensitively. Unknown codes use 2
+func CurrencyMinorUnits(code string) int32 {
+	if places, ok := currencyMinorUnits[strings.ToUpper(strings.TrimSpace(code))]; ok {
+		return places
+	}
+	return defaultMinorUnits
+}
@@ -37,6 +37,76 @@ type AccountAmount struct {
 	Amount string `json:"amount"`
 }
 
+// MerchantSpending represents a month's expense total for one merchant
+type MerchantSpending struct {
+	Merchant         string          `json:"merchant"`
+	Total            decimal.Decimal `json:"total"`
+	TransactionCount int             `json:"transactionCount"`
+}
+
+// MerchantSummary contains a month's spending grouped by merchant, largest first.
+// Transactions without a merchant are grouped under their name.
+type MerchantSummary struct {
+	Month     string             `json:"month"` // Format: "YYYY-MM"
+	Merchants []MerchantSpending `json:"merchants"`
+}
+
+// TaxCategoryTotal is a year's tax-deductible spending in one category
+type TaxCategoryTotal struct {
+	CategoryID       *int32          `json:"categoryId"` // nil for uncategorized transactions
+	CategoryName     string          `json:"categoryName"`
+	Total            decimal.Decimal `json:"total"`
+	TransactionCount int             `json:"transactionCount"`
+}
+
+// TaxReport aggregates a year's tax-deductible transactions by category, largest first
+type TaxReport struct {
+	Year             int                `json:"year"`
+	Total            decimal.Decimal    `json:"total"`
+	TransactionCount int                `json:"transactionCount"`
+	Categories       []TaxCategoryTotal `json:"categories"`
+}
+
+// ObligationsBreakdown splits a month's unpaid obligations by where they come from
+type ObligationsBreakdown struct {
+	LoanCommitments   decimal.Decimal `json:"loanCommitments"`
+	RecurringExpenses decimal.Decimal `json:"recurringExpenses"`
+	CCStatements      decimal.Decimal `json:"ccStatements"`
+}
+
+// ObligationsTotal is everything still owed for a month as a single figure
+type ObligationsTotal struct {
+	Year      int                  `json:"year"`
+	Month     int                  `json:"month"`
+	Total     decimal.Decimal      `json:"total"`
+	Breakdown ObligationsBreakdown `json:"breakdown"`
+}
+
+// SpendingVelocity is a month's spending run rate and where it leads by month end
+type SpendingVelocity struct {
+	Year              int             `json:"year"`
+	Month             int             `json:"month"`
+	DaysElapsed       int             `json:"daysElapsed"` // Days counted so far, including today
+	DaysInMonth       int             `json:"daysInMonth"`
+	SpentSoFar        decimal.Decimal `json:"spentSoFar"`
+	AverageDailySpend decimal.Decimal `json:"averageDailySpend"`
+	ProjectedTotal    decimal.Decimal `json:"projectedTotal"` // Run rate extended to the whole month
+}
+
+// PayeeSpending represents a month's expense total paid to one payee
+type PayeeSpending struct {
+	Payee            string          `json:"payee"`
+	Total            decimal.Decimal `json:"total"`
+	TransactionCount int             `json:"transactionCount"`
+}
+
+// PayeeSummary contains a month's spending grouped by payee, largest first.
+// Transactions without a payee are left out.
+type PayeeSummary struct {
+	Month  string          `json:"month"` // Format: "YYYY-MM"
+	Payees []PayeeSpending `json:"payees"`
+}
+
 // ProjectionDetails contains projected financial data for future months
 type ProjectionDetails struct {
 	RecurringIncome   decimal.Decimal `json:"recurringIncome"`
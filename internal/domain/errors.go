@@ -20,25 +20,56 @@ var (
 	ErrInvalidTransactionType       = errors.New("invalid transaction type")
 	ErrInvalidAmount                = errors.New("amount must be positive")
 	ErrNotesTooLong                 = errors.New("notes exceed maximum length")
+	ErrMerchantTooLong              = errors.New("merchant exceeds maximum length")
+	ErrPayeeTooLong                 = errors.New("payee exceeds maximum length")
+	ErrInvalidCoordinates           = errors.New("latitude must be between -90 and 90 and longitude between -180 and 180, both set together")
+	ErrLocationTooLong              = errors.New("location exceeds maximum length")
 	ErrInvalidSettlementIntent      = errors.New("invalid settlement intent")
 	ErrSettlementIntentNotApplicable = errors.New("settlement intent only applies to credit card transactions")
 	ErrTransactionAlreadyPaid       = errors.New("cannot change settlement intent for paid transactions")
 	ErrSameAccountTransfer          = errors.New("cannot transfer to the same account")
+	ErrNotTransfer                  = errors.New("transaction is not part of a transfer")
+	ErrTransferAlreadyReversed      = errors.New("transfer has already been reversed")
+	ErrInvalidRefund                = errors.New("only non-transfer income transactions can be refunds")
+	ErrInvalidCursor                = errors.New("invalid pagination cursor")
+	ErrInvalidTransactionSource     = errors.New("invalid transaction source")
 	ErrMonthNotFound                = errors.New("month not found")
 	ErrMonthAlreadyExists           = errors.New("month already exists")
+	ErrMonthClosed                  = errors.New("cannot modify transactions in a closed month")
+	ErrGeneratedTransactionsInMonth = errors.New("month contains loan or recurring transactions")
 	ErrBudgetCategoryNotFound       = errors.New("budget category not found")
 	ErrBudgetCategoryAlreadyExists  = errors.New("budget category with this name already exists")
+	ErrInvalidCategoryHierarchy     = errors.New("invalid category hierarchy: parent must be a different top-level category")
 	ErrBudgetAllocationNotFound     = errors.New("budget allocation not found")
+	ErrInvalidColor                 = errors.New("color must be a #RRGGBB hex string")
+	ErrDescriptionTooLong           = errors.New("description exceeds maximum length")
 	ErrInvalidAccountType           = errors.New("invalid account type for this operation")
 	ErrInvalidSourceAccount         = errors.New("cannot use a credit card as source account for CC payment")
 	ErrRecurringTemplateNotFound = errors.New("recurring template not found")
 	ErrInvalidFrequency             = errors.New("invalid frequency")
 	ErrInvalidDueDay                = errors.New("due day must be between 1 and 31")
+	ErrInvalidOccurrenceMonth       = errors.New("occurrence month must be between 1 and 12")
 	ErrInvalidDateRange             = errors.New("end date must be after start date")
 	ErrAPITokenNotFound             = errors.New("API token not found")
 	ErrTooManyAPITokens             = errors.New("maximum number of API tokens reached")
 	ErrNotCCTransaction             = errors.New("transaction is not a credit card transaction")
 	ErrInvalidCCStateTransition     = errors.New("invalid CC state transition")
+	ErrNotCreditCard                = errors.New("account is not a credit card")
+
+	// Account template errors
+	ErrCannotChangeTemplateWithTransactions = errors.New("cannot change the template of an account that has transactions")
+	ErrCannotMoveAcrossCreditCard           = errors.New("cannot move transactions between credit card and other accounts")
+	ErrCannotMoveLoanTransaction            = errors.New("cannot move loan transactions to another account")
+
+	// Round-up savings errors
+	ErrInvalidRoundUpAccount = errors.New("round-up savings account cannot be a credit card")
+
+	// Recurring generation errors
+	ErrInvalidGenerationHorizon = errors.New("generation horizon must be between 0 and 12 months")
+
+	// Search errors
+	ErrEmptySearchQuery   = errors.New("search query is required")
+	ErrSearchQueryTooLong = errors.New("search query exceeds maximum length")
 
 	// Settlement errors
 	ErrTransactionsNotFound   = errors.New("one or more transactions not found")
@@ -46,6 +77,7 @@ var (
 	ErrTransactionNotSettleable = errors.New("transaction must be a credit card transaction with settlement intent")
 	ErrInvalidTargetAccount   = errors.New("target account must be a credit card")
 	ErrEmptySettlement        = errors.New("at least one transaction must be selected for settlement")
+	ErrInvalidSettlementMonth = errors.New("settlement month must be in YYYY-MM format")
 )
 
 // Validation constants
@@ -53,5 +85,9 @@ const (
 	MaxAccountNameLength        = 255
 	MaxTransactionNameLength    = 255
 	MaxTransactionNotesLength   = 1000
+	MaxMerchantLength           = 255
+	MaxPayeeLength              = 255
+	MaxLocationLength           = 255
 	MaxBudgetCategoryNameLength = 100
+	MaxBudgetCategoryDescLength = 500
 )
@@ -0,0 +1,8 @@
+package domain
+
+// ListOptions are the options shared by workspace list queries.
+// Soft-deleted rows are excluded unless IncludeDeleted is set; aggregates
+// (balances, stats, summaries) always exclude them and ignore these options.
+type ListOptions struct {
+	IncludeDeleted bool
+}
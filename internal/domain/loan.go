@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -13,22 +15,58 @@ var (
 	ErrLoanItemNameTooLong               = errors.New("loan item name must be 200 characters or less")
 	ErrLoanAmountInvalid                 = errors.New("loan amount must be positive")
 	ErrLoanMonthsInvalid                 = errors.New("number of months must be at least 1")
+	ErrLoanMonthsExceedsMax              = errors.New("number of months exceeds the maximum allowed")
 	ErrLoanProviderInvalid               = errors.New("loan provider is required")
 	ErrLoanAccountInvalid                = errors.New("account is required")
 	ErrNoTransactionsToSettle            = errors.New("no unpaid transactions found for this month")
 	ErrLoanPaymentAtomicityFailed        = errors.New("failed to settle all transactions atomically")
+	ErrLoanPaidDateInFuture              = errors.New("paid date cannot be in the future")
 	ErrCannotChangeProviderAfterPayments = errors.New("cannot change provider after payments are made")
+	ErrLoanRestoreClosedMonth            = errors.New("cannot restore a loan whose first payment is in a closed month")
+	ErrRefundAmountInvalid               = errors.New("refund amount must be positive")
+	ErrRefundExceedsPayment              = errors.New("refund amount exceeds the payment amount")
+	ErrLoanPaymentAmountsCount           = errors.New("custom payment amounts must have one amount per month")
+	ErrLoanPaymentAmountsInvalid         = errors.New("custom payment amounts must be positive")
+	ErrLoanPaymentAmountsSum             = errors.New("custom payment amounts must sum to the total with interest")
+	ErrLoanFirstPaymentInvalid           = errors.New("first payment month is invalid or too far in the past")
+	ErrLoanAlreadySettled                = errors.New("loan has no unpaid payments to settle")
+	ErrLoanDownPaymentInvalid            = errors.New("down payment must be zero or more and less than the total amount")
+	ErrLoanDownPaymentAccountInvalid     = errors.New("down payment account not found")
 )
 
+// DefaultMaxLoanMonths is the default cap on a loan's number of monthly payments
+const DefaultMaxLoanMonths int32 = 120
+
+// FirstPaymentOverrideToleranceMonths is how many months before the current one an explicit
+// first payment month may fall, so a loan entered just after its first due date still fits
+const FirstPaymentOverrideToleranceMonths = 1
+
+// ErrLoanMonthsLimit wraps a loan term validation error with the month cap it was checked against
+type ErrLoanMonthsLimit struct {
+	Err       error
+	MaxMonths int32
+}
+
+func (e ErrLoanMonthsLimit) Error() string {
+	return fmt.Sprintf("%s (max %d)", e.Err, e.MaxMonths)
+}
+
+func (e ErrLoanMonthsLimit) Unwrap() error {
+	return e.Err
+}
+
 type Loan struct {
 	ID                int32           `json:"id"`
 	WorkspaceID       int32           `json:"workspaceId"`
 	ProviderID        int32           `json:"providerId"`
+	ProviderName      string          `json:"providerName,omitempty"` // Joined by the workspace listing only
 	ItemName          string          `json:"itemName"`
-	TotalAmount       decimal.Decimal `json:"totalAmount"`
+	TotalAmount       decimal.Decimal `json:"totalAmount"`       // Financed amount; the item's price is TotalAmount + DownPaymentAmount
+	DownPaymentAmount decimal.Decimal `json:"downPaymentAmount"` // Paid at purchase, outside the installments
 	NumMonths         int32           `json:"numMonths"`
 	PurchaseDate      time.Time       `json:"purchaseDate"`
 	InterestRate      decimal.Decimal `json:"interestRate"`
+	InterestMethod    string          `json:"interestMethod"` // Resolved at creation: the override or the provider default
 	MonthlyPayment    decimal.Decimal `json:"monthlyPayment"`
 	FirstPaymentYear  int32           `json:"firstPaymentYear"`
 	FirstPaymentMonth int32           `json:"firstPaymentMonth"`
@@ -38,6 +76,7 @@ type Loan struct {
 	CreatedAt         time.Time       `json:"createdAt"`
 	UpdatedAt         time.Time       `json:"updatedAt"`
 	DeletedAt         *time.Time      `json:"deletedAt,omitempty"`
+	CompletedAt       *time.Time      `json:"completedAt,omitempty"` // Set once every payment is settled
 }
 
 // LoanWithStats includes loan data plus payment statistics
@@ -48,7 +87,16 @@ type LoanWithStats struct {
 	TotalCount       int32           `json:"totalCount"`
 	PaidCount        int32           `json:"paidCount"`
 	RemainingBalance decimal.Decimal `json:"remainingBalance"`
-	Progress         float64         `json:"progress"` // Calculated: paidCount/totalCount * 100
+	Progress         float64         `json:"progress"` // Calculated: paidCount/totalCount * 100 via ComputeProgress
+}
+
+// IsCompleted reports whether the loan is fully paid. Actual payment completion
+// takes precedence over the payment schedule, so loans paid off early count.
+func (l *LoanWithStats) IsCompleted() bool {
+	if l.CompletedAt != nil {
+		return true
+	}
+	return l.TotalCount > 0 && l.RemainingBalance.IsZero()
 }
 
 // LoanFilter defines the filter options for listing loans
@@ -82,8 +130,12 @@ func (l *Loan) Validate() error {
 	return nil
 }
 
-// IsActive returns true if the loan still has remaining payments based on current year/month
+// IsActive returns true if the loan still has remaining payments based on current year/month.
+// A loan marked completed is never active, even if scheduled months remain.
 func (l *Loan) IsActive(currentYear, currentMonth int) bool {
+	if l.CompletedAt != nil {
+		return false
+	}
 	lastPaymentYear, lastPaymentMonth := l.GetLastPaymentYearMonth()
 	if lastPaymentYear > currentYear {
 		return true
@@ -112,6 +164,24 @@ type ProviderBreakdown struct {
 	IsPaid bool            `json:"isPaid"`
 }
 
+// ProviderLoanGroup groups a provider's loans with their combined outstanding balance
+// Loans whose provider was deleted share one group with ProviderDeleted set and ProviderID 0
+type ProviderLoanGroup struct {
+	ProviderID       int32            `json:"providerId"`
+	ProviderName     string           `json:"providerName"`
+	ProviderDeleted  bool             `json:"providerDeleted"`
+	TotalOutstanding decimal.Decimal  `json:"totalOutstanding"`
+	Loans            []*LoanWithStats `json:"loans"`
+}
+
+// ProviderPayoff is the amount needed to clear every active loan under a provider
+type ProviderPayoff struct {
+	ProviderID   int32            `json:"providerId"`
+	ProviderName string           `json:"providerName"`
+	TotalPayoff  decimal.Decimal  `json:"totalPayoff"`
+	Loans        []*LoanWithStats `json:"loans"` // Active loans contributing to the payoff
+}
+
 // MonthlyTrend represents aggregated loan data for a single month
 type MonthlyTrend struct {
 	Month     string              `json:"month"` // Format: "YYYY-MM"
@@ -135,6 +205,79 @@ type TrendRawRow struct {
 	IsPaid       bool
 }
 
+// LoanPaymentBucket totals the unpaid loan payments in one due-date bucket; Total also covers fee lines
+type LoanPaymentBucket struct {
+	Total    decimal.Decimal `json:"total"`
+	Count    int             `json:"count"`
+	Payments []*Transaction  `json:"payments"`
+}
+
+// LoanPaymentBuckets classifies unpaid loan payments by their due month relative to the current month
+type LoanPaymentBuckets struct {
+	Overdue      LoanPaymentBucket `json:"overdue"`      // Due in a month before the current one
+	DueThisMonth LoanPaymentBucket `json:"dueThisMonth"` // Due in the current month
+	Upcoming     LoanPaymentBucket `json:"upcoming"`     // Due in a later month
+}
+
+// LoanDetail bundles a loan with its stats, payment schedule and provider for a single detail view
+type LoanDetail struct {
+	Loan     LoanWithStats  `json:"loan"`
+	Provider *LoanProvider  `json:"provider"` // nil when the provider has been deleted
+	Schedule []*Transaction `json:"schedule"` // Loan payment transactions ordered by due date
+}
+
+// ScheduledPayment is one outstanding loan payment, as exported to calendars
+type ScheduledPayment struct {
+	TransactionID int32           `json:"transactionId"`
+	DueDate       time.Time       `json:"dueDate"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+// PlanScheduleEntry is one month of a validated loan plan's payment schedule
+type PlanScheduleEntry struct {
+	PaymentNumber int             `json:"paymentNumber"`
+	Year          int             `json:"year"`
+	Month         int             `json:"month"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+// PlanValidation is the dry-run result of a loan plan: the schedule it would generate
+// and every problem that would make CreateLoan reject it
+type PlanValidation struct {
+	InterestRate      decimal.Decimal     `json:"interestRate"`
+	InterestMethod    string              `json:"interestMethod"`
+	FinancedAmount    decimal.Decimal     `json:"financedAmount"` // Total amount less any down payment
+	MonthlyPayment    decimal.Decimal     `json:"monthlyPayment"`
+	ExpectedTotal     decimal.Decimal     `json:"expectedTotal"` // Total amount plus interest under InterestMethod, promo-aware
+	FirstPaymentYear  int                 `json:"firstPaymentYear"`
+	FirstPaymentMonth int                 `json:"firstPaymentMonth"`
+	Schedule          []PlanScheduleEntry `json:"schedule"` // Empty when the plan is too broken to schedule
+	Issues            []error             `json:"-"`
+}
+
+// Valid reports whether the plan has no issues
+func (v *PlanValidation) Valid() bool {
+	return len(v.Issues) == 0
+}
+
+// InterestComparison is the same loan plan priced under both interest methods
+type InterestComparison struct {
+	InterestRate    decimal.Decimal
+	FinancedAmount  decimal.Decimal // Total amount less any down payment
+	NumMonths       int32
+	Flat            InterestMethodQuote
+	ReducingBalance InterestMethodQuote
+}
+
+// InterestMethodQuote is what a loan plan costs under one interest method
+type InterestMethodQuote struct {
+	InterestMethod string
+	MonthlyPayment decimal.Decimal
+	TotalRepaid    decimal.Decimal // Sum of the scheduled payments, including any promo months
+	TotalInterest  decimal.Decimal // TotalRepaid less the financed amount
+	Schedule       []PlanScheduleEntry
+}
+
 type LoanRepository interface {
 	Create(loan *Loan) (*Loan, error)
 	CreateTx(tx interface{}, loan *Loan) (*Loan, error) // Transactional create
@@ -142,15 +285,22 @@ type LoanRepository interface {
 	GetAllByWorkspace(workspaceID int32) ([]*Loan, error)
 	GetActiveByWorkspace(workspaceID int32, currentYear, currentMonth int) ([]*Loan, error)
 	GetCompletedByWorkspace(workspaceID int32, currentYear, currentMonth int) ([]*Loan, error)
+	GetWithoutSchedule(workspaceID int32) ([]*Loan, error) // Loans that never had a scheduled transaction, soft-deleted ones included
 	Update(loan *Loan) (*Loan, error)
 	UpdatePartial(workspaceID int32, id int32, itemName string, notes *string) (*Loan, error)
 	UpdateEditableFields(workspaceID int32, id int32, itemName string, providerID int32, notes *string) (*Loan, error)
 	SoftDelete(workspaceID int32, id int32) error
+	// Trash: soft-deleted loans
+	GetDeletedByID(workspaceID int32, id int32) (*Loan, error)
+	GetDeletedByWorkspace(workspaceID int32) ([]*Loan, error)
+	Restore(workspaceID int32, id int32) (*Loan, error)
+	RestoreTx(tx interface{}, workspaceID int32, id int32) (*Loan, error) // Transactional restore
+	SetCompletedAt(workspaceID int32, id int32, completedAt *time.Time) error // nil clears
 	CountActiveLoansByProvider(workspaceID int32, providerID int32, currentYear, currentMonth int) (int64, error)
 	// Stats methods - joins with loan_payments for aggregated data
-	GetAllWithStats(workspaceID int32) ([]*LoanWithStats, error)
-	GetActiveWithStats(workspaceID int32) ([]*LoanWithStats, error)
-	GetCompletedWithStats(workspaceID int32) ([]*LoanWithStats, error)
+	GetAllWithStats(ctx context.Context, workspaceID int32, opts ListOptions) ([]*LoanWithStats, error)
+	GetActiveWithStats(ctx context.Context, workspaceID int32) ([]*LoanWithStats, error)
+	GetCompletedWithStats(ctx context.Context, workspaceID int32) ([]*LoanWithStats, error)
 	// Get loans by provider with stats (for item-based modal)
-	GetByProviderWithStats(workspaceID int32, providerID int32) ([]*LoanWithStats, error)
+	GetByProviderWithStats(ctx context.Context, workspaceID int32, providerID int32) ([]*LoanWithStats, error)
 }
@@ -96,6 +96,31 @@ type LoanDeleteStats struct {
 	TotalAmount decimal.Decimal `json:"totalAmount"`
 }
 
+// PaymentStatus describes how much of a loan payment (or month) has been paid
+type PaymentStatus string
+
+const (
+	PaymentStatusNone    PaymentStatus = "none" // Nothing is due
+	PaymentStatusUnpaid  PaymentStatus = "unpaid"
+	PaymentStatusPartial PaymentStatus = "partial"
+	PaymentStatusPaid    PaymentStatus = "paid"
+)
+
+// ComputePaymentStatus derives a PaymentStatus from paid/total transaction counts.
+// A month with nothing due (totalCount = 0) is PaymentStatusNone rather than paid.
+func ComputePaymentStatus(paidCount, totalCount int) PaymentStatus {
+	if totalCount == 0 {
+		return PaymentStatusNone
+	}
+	if paidCount >= totalCount {
+		return PaymentStatusPaid
+	}
+	if paidCount == 0 {
+		return PaymentStatusUnpaid
+	}
+	return PaymentStatusPartial
+}
+
 // MonthlyPaymentDetail contains payment details with loan info for monthly aggregation
 type MonthlyPaymentDetail struct {
 	ID            int32           `json:"id"`
@@ -105,6 +130,15 @@ type MonthlyPaymentDetail struct {
 	TotalPayments int32           `json:"totalPayments"`
 	Amount        decimal.Decimal `json:"amount"`
 	Paid          bool            `json:"paid"`
+	Status        PaymentStatus   `json:"status"`
+}
+
+// ProviderCommitment contains a provider's paid and unpaid subtotals for a month
+type ProviderCommitment struct {
+	ProviderID   int32           `json:"providerId"`
+	ProviderName string          `json:"providerName"`
+	TotalUnpaid  decimal.Decimal `json:"totalUnpaid"`
+	TotalPaid    decimal.Decimal `json:"totalPaid"`
 }
 
 // PayMonthResult contains the result of a batch pay month operation
@@ -116,6 +150,24 @@ type PayMonthResult struct {
 	NextPayableMonth *string         `json:"nextPayableMonth"` // Next month that can be paid (nil if none)
 }
 
+// ConsolidatedPreviewItem is one loan's contribution to a consolidated monthly payment
+type ConsolidatedPreviewItem struct {
+	PaymentID     int32           `json:"paymentId"`
+	LoanID        int32           `json:"loanId"`
+	ItemName      string          `json:"itemName"`
+	PaymentNumber int32           `json:"paymentNumber"`
+	TotalPayments int32           `json:"totalPayments"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+// ConsolidatedPreview lists the unpaid payments a pay-month call would settle for a provider-month
+type ConsolidatedPreview struct {
+	ProviderID  int32                      `json:"providerId"`
+	Month       string                     `json:"month"` // Format: "YYYY-MM"
+	Items       []*ConsolidatedPreviewItem `json:"items"`
+	TotalAmount decimal.Decimal            `json:"totalAmount"`
+}
+
 // PayRangeResult contains the result of a multi-month batch pay operation
 type PayRangeResult struct {
 	MonthsPaid       []string        `json:"monthsPaid"`       // List of months paid (e.g., ["2026-02", "2026-03"])
@@ -125,6 +177,20 @@ type PayRangeResult struct {
 	NextPayableMonth *string         `json:"nextPayableMonth"` // Next month that can be paid (nil if none)
 }
 
+// PayRangePreviewMonth is one month's share of a pay-range preview
+type PayRangePreviewMonth struct {
+	Month       string          `json:"month"`      // Format: "YYYY-MM"
+	PaymentIDs  []int32         `json:"paymentIds"` // Unpaid payments that would be settled
+	TotalAmount decimal.Decimal `json:"totalAmount"`
+}
+
+// PayRangePreview shows what a pay-range call would settle, without settling it
+type PayRangePreview struct {
+	Months       []PayRangePreviewMonth `json:"months"`
+	PaymentCount int                    `json:"paymentCount"`
+	TotalAmount  decimal.Decimal        `json:"totalAmount"`
+}
+
 // UnpayMonthResult contains the result of an unpay month operation
 type UnpayMonthResult struct {
 	Month           string          `json:"month"`           // Format: "YYYY-MM"
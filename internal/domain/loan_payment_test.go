@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestComputePaymentStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		paidCount  int
+		totalCount int
+		want       PaymentStatus
+	}{
+		{"nothing due", 0, 0, PaymentStatusNone},
+		{"none paid", 0, 3, PaymentStatusUnpaid},
+		{"some paid", 1, 3, PaymentStatusPartial},
+		{"all paid", 3, 3, PaymentStatusPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputePaymentStatus(tt.paidCount, tt.totalCount); got != tt.want {
+				t.Errorf("ComputePaymentStatus(%d, %d) = %s, want %s", tt.paidCount, tt.totalCount, got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,18 @@ const (
 	PaymentModeConsolidatedMonthly = "consolidated_monthly"
 )
 
+// FeeMode constants for how a provider's monthly fee appears in loan transactions
+const (
+	FeeModeSeparate = "separate" // Fee generated as its own transaction each month
+	FeeModeFolded   = "folded"   // Fee added to the monthly payment transaction
+)
+
+// InterestMethod constants for how a loan's interest is charged over its term
+const (
+	InterestMethodFlat            = "flat"             // Interest on the original amount, spread evenly
+	InterestMethodReducingBalance = "reducing_balance" // Same monthly rate charged on the outstanding balance
+)
+
 var (
 	ErrLoanProviderNotFound    = errors.New("loan provider not found")
 	ErrLoanProviderHasLoans    = errors.New("loan provider has active loans")
@@ -23,6 +35,11 @@ var (
 	ErrLoanProviderNameEmpty   = errors.New("loan provider name is required")
 	ErrLoanProviderNameTooLong = errors.New("loan provider name must be 100 characters or less")
 	ErrInvalidPaymentMode      = errors.New("payment mode must be 'per_item' or 'consolidated_monthly'")
+	ErrInvalidMonthlyFee       = errors.New("monthly fee must be non-negative")
+	ErrInvalidFeeMode          = errors.New("fee mode must be 'separate' or 'folded'")
+	ErrInvalidPromoRate        = errors.New("promo interest rate must be between 0 and 100")
+	ErrInvalidPromoMonths      = errors.New("promo months must be between 0 and the maximum loan term")
+	ErrInvalidInterestMethod   = errors.New("interest method must be 'flat' or 'reducing_balance'")
 )
 
 type LoanProvider struct {
@@ -32,12 +49,18 @@ type LoanProvider struct {
 	CutoffDay           int32           `json:"cutoffDay"`
 	DefaultInterestRate decimal.Decimal `json:"defaultInterestRate"`
 	PaymentMode         string          `json:"paymentMode"`
-	CreatedAt           time.Time       `json:"createdAt"`
-	UpdatedAt           time.Time       `json:"updatedAt"`
-	DeletedAt           *time.Time      `json:"deletedAt,omitempty"`
+	MonthlyFee          decimal.Decimal `json:"monthlyFee"`
+	FeeMode             string          `json:"feeMode"`
+	PromoInterestRate   decimal.Decimal `json:"promoInterestRate"` // Rate applied to the first PromoMonths payments
+	PromoMonths         int32           `json:"promoMonths"`
+	// Interest method inherited by loans created under this provider unless overridden
+	DefaultInterestMethod string     `json:"defaultInterestMethod"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             time.Time  `json:"updatedAt"`
+	DeletedAt             *time.Time `json:"deletedAt,omitempty"`
 }
 
-func (lp *LoanProvider) Validate() error {
+func (lp *LoanProvider) Validate(maxLoanMonths int32) error {
 	if lp.Name == "" {
 		return ErrLoanProviderNameEmpty
 	}
@@ -50,6 +73,21 @@ func (lp *LoanProvider) Validate() error {
 	if lp.PaymentMode != "" && !IsValidPaymentMode(lp.PaymentMode) {
 		return ErrInvalidPaymentMode
 	}
+	if lp.MonthlyFee.LessThan(decimal.Zero) {
+		return ErrInvalidMonthlyFee
+	}
+	if lp.FeeMode != "" && !IsValidFeeMode(lp.FeeMode) {
+		return ErrInvalidFeeMode
+	}
+	if lp.PromoInterestRate.LessThan(decimal.Zero) || lp.PromoInterestRate.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrInvalidPromoRate
+	}
+	if lp.PromoMonths < 0 || lp.PromoMonths > maxLoanMonths {
+		return ErrLoanMonthsLimit{Err: ErrInvalidPromoMonths, MaxMonths: maxLoanMonths}
+	}
+	if lp.DefaultInterestMethod != "" && !IsValidInterestMethod(lp.DefaultInterestMethod) {
+		return ErrInvalidInterestMethod
+	}
 	return nil
 }
 
@@ -58,6 +96,16 @@ func IsValidPaymentMode(mode string) bool {
 	return mode == PaymentModePerItem || mode == PaymentModeConsolidatedMonthly
 }
 
+// IsValidFeeMode checks if the given fee mode is valid
+func IsValidFeeMode(mode string) bool {
+	return mode == FeeModeSeparate || mode == FeeModeFolded
+}
+
+// IsValidInterestMethod checks if the given interest method is valid
+func IsValidInterestMethod(method string) bool {
+	return method == InterestMethodFlat || method == InterestMethodReducingBalance
+}
+
 type LoanProviderRepository interface {
 	Create(provider *LoanProvider) (*LoanProvider, error)
 	GetByID(workspaceID int32, id int32) (*LoanProvider, error)
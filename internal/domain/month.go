@@ -16,6 +16,7 @@ type Month struct {
 	StartingBalance decimal.Decimal `json:"startingBalance"`
 	CreatedAt       time.Time       `json:"createdAt"`
 	UpdatedAt       time.Time       `json:"updatedAt"`
+	ClosedAt        *time.Time      `json:"closedAt,omitempty"` // Set once the month is closed to edits
 }
 
 // CalculatedMonth extends Month with calculated values
@@ -26,10 +27,27 @@ type CalculatedMonth struct {
 	ClosingBalance decimal.Decimal `json:"closingBalance"`
 }
 
+// MonthSummary is a lightweight month entry for month navigation
+type MonthSummary struct {
+	Year          int             `json:"year"`
+	Month         int             `json:"month"`
+	TotalIncome   decimal.Decimal `json:"totalIncome"`
+	TotalExpenses decimal.Decimal `json:"totalExpenses"`
+	Net           decimal.Decimal `json:"net"` // TotalIncome - TotalExpenses
+	IsClosed      bool            `json:"isClosed"`
+}
+
+// IsClosed reports whether the month has been closed to edits
+func (m *Month) IsClosed() bool {
+	return m.ClosedAt != nil
+}
+
 type MonthRepository interface {
 	Create(workspaceID int32, year, month int, startDate, endDate time.Time, startingBalance decimal.Decimal) (*Month, error)
 	GetByYearMonth(workspaceID int32, year, month int) (*Month, error)
 	GetLatest(workspaceID int32) (*Month, error)
 	GetAll(workspaceID int32) ([]*Month, error)
 	UpdateStartingBalance(workspaceID, id int32, balance decimal.Decimal) error
+	Close(workspaceID, id int32) (bool, error)     // false when the month was already closed
+	CloseAllBefore(year, month int) (int64, error) // Closes open months before year/month in every workspace
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultMaxDecimalPlaces is the default cap on decimal places for amounts and rates,
+// matching the NUMERIC(12,2) columns they are stored in
+const DefaultMaxDecimalPlaces int32 = 2
+
+// ErrExcessivePrecision is returned when a decimal value has more decimal places than allowed
+var ErrExcessivePrecision = errors.New("value has too many decimal places")
+
+// ErrPrecisionExceeded carries the decimal-place limit a value exceeded.
+// It matches ErrExcessivePrecision with errors.Is.
+type ErrPrecisionExceeded struct {
+	MaxPlaces int32
+}
+
+func (e ErrPrecisionExceeded) Error() string {
+	return fmt.Sprintf("value has more than %d decimal places", e.MaxPlaces)
+}
+
+func (e ErrPrecisionExceeded) Is(target error) bool {
+	return target == ErrExcessivePrecision
+}
+
+// ValidatePrecision checks that each value has at most maxPlaces significant decimal places.
+// Trailing zeros don't count, so "1.500" passes with maxPlaces 2.
+func ValidatePrecision(maxPlaces int32, values ...decimal.Decimal) error {
+	for _, v := range values {
+		if !v.Truncate(maxPlaces).Equal(v) {
+			return ErrPrecisionExceeded{MaxPlaces: maxPlaces}
+		}
+	}
+	return nil
+}
+
+// ComputeProgress returns paid as a percentage of total rounded to 2 decimal places.
+// The division is done in decimal so values like 1/3 render as 33.33; a non-positive total yields 0.
+func ComputeProgress(paid, total decimal.Decimal) float64 {
+	if !total.IsPositive() {
+		return 0
+	}
+	return paid.Mul(decimal.NewFromInt(100)).Div(total).Round(2).InexactFloat64()
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidatePrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{"integer", "100", nil},
+		{"two decimal places", "100.25", nil},
+		{"trailing zeros", "1.500", nil},
+		{"three decimal places", "100.257", ErrExcessivePrecision},
+		{"eight decimal places", "100.25750001", ErrExcessivePrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrecision(DefaultMaxDecimalPlaces, decimal.RequireFromString(tt.value))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidatePrecision(%s) = %v, want %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePrecision_MultipleValues(t *testing.T) {
+	values := []decimal.Decimal{
+		decimal.RequireFromString("10.50"),
+		decimal.RequireFromString("0.12345678"),
+	}
+	if err := ValidatePrecision(DefaultMaxDecimalPlaces, values...); !errors.Is(err, ErrExcessivePrecision) {
+		t.Errorf("Expected ErrExcessivePrecision, got %v", err)
+	}
+	if err := ValidatePrecision(DefaultMaxDecimalPlaces); err != nil {
+		t.Errorf("Expected no error for empty input, got %v", err)
+	}
+}
+
+func TestValidatePrecision_Configurable(t *testing.T) {
+	if err := ValidatePrecision(4, decimal.RequireFromString("1.2345")); err != nil {
+		t.Errorf("Expected no error with 4 places, got %v", err)
+	}
+
+	var precisionErr ErrPrecisionExceeded
+	err := ValidatePrecision(4, decimal.RequireFromString("1.23456"))
+	if !errors.As(err, &precisionErr) || precisionErr.MaxPlaces != 4 {
+		t.Errorf("Expected ErrPrecisionExceeded with 4 places, got %v", err)
+	}
+}
+
+func TestComputeProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		paid  int64
+		total int64
+		want  float64
+	}{
+		{"one third", 1, 3, 33.33},
+		{"two thirds", 2, 3, 66.67},
+		{"fully paid", 6, 6, 100},
+		{"nothing paid", 0, 4, 0},
+		{"no payments", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeProgress(decimal.NewFromInt(tt.paid), decimal.NewFromInt(tt.total))
+			if got != tt.want {
+				t.Errorf("ComputeProgress(%d, %d) = %v, want %v", tt.paid, tt.total, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // ProjectionExclusion tracks explicitly deleted projected transactions
 // to prevent them from being regenerated on sync
@@ -18,7 +21,10 @@ type ProjectionExclusionRepository interface {
 	Create(workspaceID int32, templateID int32, excludedMonth time.Time) error
 
 	// IsExcluded checks if a specific month is excluded for a template
-	IsExcluded(workspaceID int32, templateID int32, excludedMonth time.Time) (bool, error)
+	IsExcluded(ctx context.Context, workspaceID int32, templateID int32, excludedMonth time.Time) (bool, error)
+
+	// Delete removes a single month's exclusion so it can be generated again
+	Delete(workspaceID int32, templateID int32, excludedMonth time.Time) error
 
 	// DeleteByTemplate removes all exclusions for a template (used when template is deleted)
 	DeleteByTemplate(templateID int32) error
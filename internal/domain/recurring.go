@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -51,14 +52,83 @@ type UpdateRecurringTemplateInput struct {
 	SettlementIntent *SettlementIntent // For CC accounts: 'immediate' or 'deferred'
 }
 
+// RecurringFilter narrows a recurring template listing; nil fields are ignored
+type RecurringFilter struct {
+	AccountID  *int32
+	CategoryID *int32
+	Active     *bool // true: no end date or ends today or later; false: already ended
+}
+
+// GenerateSkipReason explains why projection generation would not create a transaction for a month
+type GenerateSkipReason string
+
+const (
+	GenerateSkipAlreadyGenerated GenerateSkipReason = "already_generated" // A projection already exists for the month
+	GenerateSkipExcluded         GenerateSkipReason = "excluded"          // The occurrence was skipped or its projection deleted
+	GenerateSkipPreserved        GenerateSkipReason = "preserved"         // A user-edited projection is kept during recalculation
+	GenerateSkipNotStarted       GenerateSkipReason = "not_started"       // The month is before the template's start date
+	GenerateSkipEnded            GenerateSkipReason = "ended"             // The month is after the template's end date
+	GenerateSkipOutsideWindow    GenerateSkipReason = "outside_window"    // Already past, or beyond the 12-month projection window
+)
+
+// GeneratePreviewItem is a projected transaction generation would create
+type GeneratePreviewItem struct {
+	TemplateID int32
+	Name       string
+	Amount     decimal.Decimal
+	Date       time.Time
+}
+
+// GeneratePreviewSkip is a template generation would create nothing for, and why
+type GeneratePreviewSkip struct {
+	TemplateID int32
+	Name       string
+	Date       time.Time
+	Reason     GenerateSkipReason
+}
+
+// GeneratePreview describes what generation would do for one month across a workspace's active templates
+type GeneratePreview struct {
+	Year        int
+	Month       time.Month
+	WouldCreate []*GeneratePreviewItem
+	WouldSkip   []*GeneratePreviewSkip
+}
+
+// GenerateResult reports what generating ahead created and skipped, from the current month
+// through the workspace's generation horizon
+type GenerateResult struct {
+	FromMonth string // YYYY-MM
+	ToMonth   string // YYYY-MM
+	Created   []*GeneratePreviewItem
+	Skipped   []*GeneratePreviewSkip
+}
+
+// Subscription is an active recurring expense with its cost normalized to a month and a year
+type Subscription struct {
+	TemplateID  int32
+	Description string
+	Frequency   string
+	Amount      decimal.Decimal // Amount charged per occurrence
+	MonthlyCost decimal.Decimal
+	AnnualCost  decimal.Decimal
+}
+
+// SubscriptionSummary aggregates a workspace's active recurring expenses
+type SubscriptionSummary struct {
+	Subscriptions []*Subscription
+	MonthlyTotal  decimal.Decimal
+	AnnualTotal   decimal.Decimal
+}
+
 // RecurringTemplateRepository defines the interface for recurring template persistence
 type RecurringTemplateRepository interface {
 	Create(template *RecurringTemplate) (*RecurringTemplate, error)
 	Update(workspaceID int32, id int32, input *UpdateRecurringTemplateInput) (*RecurringTemplate, error)
 	Delete(workspaceID int32, id int32) error
 	GetByID(workspaceID int32, id int32) (*RecurringTemplate, error)
-	ListByWorkspace(workspaceID int32) ([]*RecurringTemplate, error)
-	GetActive(workspaceID int32) ([]*RecurringTemplate, error)
+	ListByWorkspace(workspaceID int32, filter *RecurringFilter) ([]*RecurringTemplate, error)
+	GetActive(ctx context.Context, workspaceID int32) ([]*RecurringTemplate, error)
 	GetAllActive() ([]*RecurringTemplate, error) // For daily sync goroutine
 }
 
@@ -66,7 +136,13 @@ type RecurringTemplateRepository interface {
 type RecurringTemplateService interface {
 	CreateTemplate(workspaceID int32, input CreateRecurringTemplateInput) (*RecurringTemplate, error)
 	UpdateTemplate(workspaceID int32, id int32, input UpdateRecurringTemplateInput) (*RecurringTemplate, error)
-	DeleteTemplate(workspaceID int32, id int32) error
+	DeleteTemplate(workspaceID int32, id int32, keepGenerated bool) error
 	GetTemplate(workspaceID int32, id int32) (*RecurringTemplate, error)
-	ListTemplates(workspaceID int32) ([]*RecurringTemplate, error)
+	ListTemplates(workspaceID int32, filter *RecurringFilter) ([]*RecurringTemplate, error)
+	GetTransactionsByRecurring(workspaceID int32, recurringID int32) ([]*Transaction, error)
+	SkipOccurrence(workspaceID int32, recurringID int32, year int, month time.Month) error
+	UnskipOccurrence(workspaceID int32, recurringID int32, year int, month time.Month) error
+	PreviewGeneration(workspaceID int32, year int, month time.Month) (*GeneratePreview, error)
+	GenerateAhead(workspaceID int32) (*GenerateResult, error)
+	GetSubscriptions(workspaceID int32) (*SubscriptionSummary, error)
 }
@@ -0,0 +1,22 @@
+package domain
+
+// Global search limits
+const (
+	DefaultSearchLimit      = 20
+	MaxSearchLimit          = 50
+	MaxSearchResultsPerType = 10
+	MaxSearchQueryLength    = 100
+)
+
+// SearchResults groups global search matches by entity type
+type SearchResults struct {
+	Query        string
+	Transactions []*Transaction
+	Loans        []*Loan
+	Categories   []*BudgetCategory
+}
+
+// Total returns the number of matches across all types
+func (r *SearchResults) Total() int {
+	return len(r.Transactions) + len(r.Loans) + len(r.Categories)
+}
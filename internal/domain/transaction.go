@@ -1,6 +1,11 @@
 package domain
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +19,34 @@ const (
 	TransactionTypeExpense TransactionType = "expense"
 )
 
+// ValidateTransactionAmount checks a transaction amount is positive, or zero when allowZero is set
+// (e.g. placeholders). Amounts are always stored unsigned; the transaction type determines the direction.
+func ValidateTransactionAmount(amount decimal.Decimal, allowZero bool) error {
+	if amount.IsNegative() || (amount.IsZero() && !allowZero) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// Built-in transaction sources; workspaces may also carry custom ones (e.g. imports)
+const (
+	TransactionSourceManual    = "manual"
+	TransactionSourceRecurring = "recurring"
+	TransactionSourceLoan      = "loan"
+	TransactionSourceExternal  = "external" // Loan payments settled outside the app
+	// A loan's down payment: linked to the loan but not one of its scheduled payments
+	TransactionSourceLoanDownPayment = "loan_down_payment"
+)
+
+// KnownTransactionSources lists the built-in sources, always valid as filters
+var KnownTransactionSources = []string{
+	TransactionSourceManual,
+	TransactionSourceRecurring,
+	TransactionSourceLoan,
+	TransactionSourceExternal,
+	TransactionSourceLoanDownPayment,
+}
+
 // CCState represents the lifecycle state of a credit card transaction
 // This is a computed/virtual state derived from billedAt and isPaid:
 // - pending: billedAt IS NULL AND isPaid = false
@@ -41,6 +74,38 @@ func ComputeCCState(isPaid bool, billedAt *time.Time) *CCState {
 	return &state
 }
 
+// IsValidCCStateTransition reports whether a CC transaction may move between states
+// Forward: pending -> billed -> settled. Reversals step back one state: settled -> billed -> pending.
+func IsValidCCStateTransition(from, to CCState) bool {
+	switch from {
+	case CCStatePending:
+		return to == CCStateBilled
+	case CCStateBilled:
+		return to == CCStateSettled || to == CCStatePending
+	case CCStateSettled:
+		return to == CCStateBilled
+	}
+	return false
+}
+
+// CCTransitionError reports the transactions a batch CC state transition could not move
+type CCTransitionError struct {
+	Failures map[int32]error
+}
+
+func (e *CCTransitionError) Error() string {
+	return fmt.Sprintf("%d transaction(s) could not change CC state", len(e.Failures))
+}
+
+// Unwrap exposes the per-transaction errors to errors.Is
+func (e *CCTransitionError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, err := range e.Failures {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 // SettlementIntent represents when a CC transaction should be settled
 type SettlementIntent string
 
@@ -73,19 +138,74 @@ type Transaction struct {
 	SettlementIntent *SettlementIntent `json:"settlementIntent"` // 'immediate' | 'deferred' | null
 
 	// Recurring/Projection
-	Source      string `json:"source"`      // 'manual' | 'recurring'
+	Source      string `json:"source"`      // 'manual' | 'recurring' | 'loan' | custom
 	TemplateID  *int32 `json:"templateId"`  // FK to recurring_templates, nullable
 	IsProjected bool   `json:"isProjected"` // true = future projection
 	IsModified  bool   `json:"isModified"`  // true if projected instance differs from template
+	// Persisted on edit: amount or category diverged from the template; the template itself is untouched
+	ModifiedFromTemplate bool `json:"modifiedFromTemplate"`
 
 	// Loan Integration (v2)
-	LoanID *int32 `json:"loanId"` // FK to loans, nullable
+	LoanID        *int32 `json:"loanId"`                  // FK to loans, nullable
+	DeletedLoanID *int32 `json:"deletedLoanId,omitempty"` // Loan this was unlinked from when it was deleted
+
+	// Scheduled: future-dated, excluded from balances until its date passes
+	IsScheduled bool `json:"isScheduled"`
+
+	// Settlement: when the payment actually happened (set when marked paid in bulk)
+	PaidAt *time.Time `json:"paidAt,omitempty"`
+
+	// Normalized merchant for reporting; nil falls back to Name
+	Merchant *string `json:"merchant,omitempty"`
+
+	// Counterparty who was paid, distinct from Name and Merchant
+	Payee *string `json:"payee,omitempty"`
+
+	// Optional geo metadata for mapping spending; coordinates are set together
+	Latitude  *decimal.Decimal `json:"latitude,omitempty"`
+	Longitude *decimal.Decimal `json:"longitude,omitempty"`
+	Location  *string          `json:"location,omitempty"` // Free-form place name
+
+	// Transfer reversal: set on both legs of a counter-transfer, pointing at the reversed pair
+	ReversesTransferPairID *uuid.UUID `json:"reversesTransferPairId,omitempty"`
+
+	// Round-up savings: set on both legs of a round-up transfer, pointing at the expense it rounds up
+	RoundUpSourceID *int32 `json:"roundUpSourceId,omitempty"`
+
+	// Refund: an income transaction that reduces spending instead of counting as income
+	IsRefund bool `json:"isRefund"`
+
+	// Loan fee: the provider's separate monthly fee line, linked to the loan but not a payment
+	IsLoanFee bool `json:"isLoanFee"`
+
+	// Kept out of insights and spending breakdowns; balances still include it
+	ExcludeFromReports bool `json:"excludeFromReports"`
+
+	// Counted in the annual tax report; defaults from the category when created
+	IsTaxDeductible bool `json:"isTaxDeductible"`
 
 	// Transaction Grouping
 	GroupID   *int32  `json:"groupId"`
 	GroupName *string `json:"groupName,omitempty"`
 }
 
+// IsPendingSchedule reports whether a scheduled transaction's date is still after asOf's date
+func (t *Transaction) IsPendingSchedule(asOf time.Time) bool {
+	return t.IsScheduled && IsAfterDay(t.TransactionDate, asOf)
+}
+
+// IsLoanDownPayment reports whether the transaction is a loan's down payment rather than a scheduled payment
+func (t *Transaction) IsLoanDownPayment() bool {
+	return t.Source == TransactionSourceLoanDownPayment
+}
+
+// IsAfterDay reports whether date falls on a later calendar day than asOf
+func IsAfterDay(date, asOf time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	return day.After(today)
+}
+
 // TransferResult represents the result of creating a transfer
 type TransferResult struct {
 	FromTransaction *Transaction `json:"fromTransaction"`
@@ -93,13 +213,19 @@ type TransferResult struct {
 }
 
 type TransactionFilters struct {
+	ListOptions
 	AccountID *int32
 	StartDate *time.Time
 	EndDate   *time.Time
 	Type      *TransactionType
-	CCStatus  *CCState // Filter by cc_state (pending, billed, settled)
-	Page      int32
-	PageSize  int32
+	CCStatus  *CCState   // Filter by cc_state (pending, billed, settled)
+	Source    *string    // Filter by source (built-in or custom)
+	PaidStart *time.Time // Paid on or after this date (by paid_at, regardless of transaction date)
+	PaidEnd   *time.Time // Paid on or before this date (inclusive)
+	// Filter by geo-tagging: true keeps transactions with coordinates or a location, false those without
+	HasLocation *bool
+	Page        int32
+	PageSize    int32
 }
 
 const (
@@ -115,6 +241,50 @@ type PaginatedTransactions struct {
 	TotalPages int32          `json:"totalPages"`
 }
 
+// Cursor pagination limits (used for large exports)
+const (
+	DefaultCursorPageSize = 100
+	MaxCursorPageSize     = 500
+)
+
+// TransactionCursor is the keyset position of the last transaction on a page
+type TransactionCursor struct {
+	TransactionDate time.Time
+	ID              int32
+}
+
+// Encode returns the opaque string form of the cursor
+func (c TransactionCursor) Encode() string {
+	raw := fmt.Sprintf("%s:%d", c.TransactionDate.Format("2006-01-02"), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTransactionCursor parses an opaque cursor, rejecting anything Encode would not produce
+func DecodeTransactionCursor(s string) (*TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	datePart, idPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	date, err := time.Parse("2006-01-02", datePart)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(idPart, 10, 32)
+	if err != nil || id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &TransactionCursor{TransactionDate: date, ID: int32(id)}
+	// Round-trip check catches non-canonical (hand-edited) encodings
+	if cursor.Encode() != s {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
 type UpdateTransactionData struct {
 	Name            string
 	Amount          decimal.Decimal
@@ -128,9 +298,52 @@ type UpdateTransactionData struct {
 	BilledAt         *time.Time
 	SettlementIntent *SettlementIntent
 	// Recurring/Projection
-	Source      string
-	TemplateID  *int32
-	IsProjected bool
+	Source               string
+	TemplateID           *int32
+	IsProjected          bool
+	ModifiedFromTemplate bool
+	// Scheduled
+	IsScheduled bool
+	// Reporting
+	Merchant           *string
+	Payee              *string
+	IsRefund           bool
+	ExcludeFromReports bool
+	IsTaxDeductible    bool
+	// Location
+	Latitude  *decimal.Decimal
+	Longitude *decimal.Decimal
+	Location  *string
+}
+
+// ToUpdateData returns update data that rewrites the transaction as it is;
+// callers override only the fields they change so new columns are never dropped
+func (t *Transaction) ToUpdateData() *UpdateTransactionData {
+	return &UpdateTransactionData{
+		Name:                 t.Name,
+		Amount:               t.Amount,
+		Type:                 t.Type,
+		TransactionDate:      t.TransactionDate,
+		AccountID:            t.AccountID,
+		Notes:                t.Notes,
+		CategoryID:           t.CategoryID,
+		IsPaid:               t.IsPaid,
+		BilledAt:             t.BilledAt,
+		SettlementIntent:     t.SettlementIntent,
+		Source:               t.Source,
+		TemplateID:           t.TemplateID,
+		IsProjected:          t.IsProjected,
+		ModifiedFromTemplate: t.ModifiedFromTemplate,
+		IsScheduled:          t.IsScheduled,
+		Merchant:             t.Merchant,
+		Payee:                t.Payee,
+		IsRefund:             t.IsRefund,
+		ExcludeFromReports:   t.ExcludeFromReports,
+		IsTaxDeductible:      t.IsTaxDeductible,
+		Latitude:             t.Latitude,
+		Longitude:            t.Longitude,
+		Location:             t.Location,
+	}
 }
 
 // TransactionSummary holds aggregated transaction data for balance calculations
@@ -179,6 +392,37 @@ type CCMetrics struct {
 	Purchases   decimal.Decimal `json:"purchases"`   // Sum of all CC transactions (pending + billed + settled)
 }
 
+// CCStateTotals holds the count and summed amount of CC transactions in one state
+type CCStateTotals struct {
+	Count int32           `json:"count"`
+	Total decimal.Decimal `json:"total"`
+}
+
+// CCStateBreakdown holds per-state CC transaction totals for a single credit card account
+type CCStateBreakdown struct {
+	AccountID int32         `json:"accountId"`
+	Pending   CCStateTotals `json:"pending"`
+	Billed    CCStateTotals `json:"billed"`
+	Settled   CCStateTotals `json:"settled"`
+}
+
+// BillingCycleResult summarizes the purchases billed when a CC statement closes
+type BillingCycleResult struct {
+	AccountID    int32           `json:"accountId"`
+	CutoffDate   time.Time       `json:"cutoffDate"`
+	BilledCount  int             `json:"billedCount"`
+	TotalBilled  decimal.Decimal `json:"totalBilled"`
+	Transactions []*Transaction  `json:"transactions"`
+}
+
+// SettleResult summarizes the deferred CC transactions settled in bulk for a month
+type SettleResult struct {
+	Month        string          `json:"month"`
+	SettledCount int             `json:"settledCount"`
+	TotalSettled decimal.Decimal `json:"totalSettled"`
+	Transactions []*Transaction  `json:"transactions"`
+}
+
 // OverdueGroup groups overdue CC transactions by month
 type OverdueGroup struct {
 	Month         string          `json:"month"`         // "2025-11"
@@ -208,36 +452,57 @@ type LoanTrendDataRow struct {
 }
 
 type TransactionRepository interface {
-	Create(transaction *Transaction) (*Transaction, error)
+	Create(ctx context.Context, transaction *Transaction) (*Transaction, error)
 	CreateBatchTx(tx interface{}, transactions []*Transaction) ([]*Transaction, error) // Batch create within DB transaction
 	GetByID(workspaceID int32, id int32) (*Transaction, error)
-	GetByWorkspace(workspaceID int32, filters *TransactionFilters) (*PaginatedTransactions, error)
+	GetByWorkspace(ctx context.Context, workspaceID int32, filters *TransactionFilters) (*PaginatedTransactions, error)
+	ListAfterCursor(workspaceID int32, after *TransactionCursor, limit int32) ([]*Transaction, error)
 	TogglePaid(workspaceID int32, id int32) (*Transaction, error)
 	Update(workspaceID int32, id int32, data *UpdateTransactionData) (*Transaction, error)
 	SoftDelete(workspaceID int32, id int32) error
 	CreateTransferPair(fromTx, toTx *Transaction) (*TransferResult, error)
 	SoftDeleteTransferPair(workspaceID int32, pairID uuid.UUID) error
+	// CreateWithRoundUp creates an expense and its round-up transfer atomically, linking both legs to the expense
+	CreateWithRoundUp(ctx context.Context, expense, roundUpFrom, roundUpTo *Transaction) (*Transaction, *TransferResult, error)
+	// ReplaceRoundUp atomically removes an expense's round-up transfer and, when legs are given, creates them in its place
+	ReplaceRoundUp(ctx context.Context, workspaceID int32, sourceID int32, roundUpFrom, roundUpTo *Transaction) (*TransferResult, error)
+	// SoftDeleteByIDs deletes the given transactions in a single statement and returns how many were deleted
+	SoftDeleteByIDs(workspaceID int32, ids []int32) (int, error)
+	// BulkUpdateAccount moves the given transactions to accountID in a single statement, skipping transfer legs
+	BulkUpdateAccount(workspaceID int32, ids []int32, accountID int32) ([]*Transaction, error)
+	GetTransferPair(workspaceID int32, pairID uuid.UUID) ([]*Transaction, error)
+	IsTransferReversed(workspaceID int32, pairID uuid.UUID) (bool, error)
 	GetAccountTransactionSummaries(workspaceID int32) ([]*TransactionSummary, error)
+	GetAccountTransactionSummaryAsOf(workspaceID, accountID int32, asOf time.Time) (*TransactionSummary, error) // Only transactions dated on or before asOf
 	SumByTypeAndDateRange(workspaceID int32, startDate, endDate time.Time, txType TransactionType) (decimal.Decimal, error)
+	SumByTypeAndDateRangeInAccounts(workspaceID int32, startDate, endDate time.Time, txType TransactionType, accountIDs []int32) (decimal.Decimal, error) // Empty accountIDs means every account
 	GetMonthlyTransactionSummaries(workspaceID int32) ([]*MonthlyTransactionSummary, error)
-	SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
+	// The dashboard sums below take the accounts to scope to; empty accountIDs means every account
+	SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
 	SumUnpaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
-	SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
-	SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
+	SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
+	SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
 	GetRecentlyUsedCategories(workspaceID int32) ([]*RecentCategory, error)
+	GetDistinctSources(ctx context.Context, workspaceID int32) ([]string, error)
 	GetCCMetrics(workspaceID int32, startDate, endDate time.Time) (*CCMetrics, error)
+	GetCCStateBreakdown(workspaceID, accountID int32) (*CCStateBreakdown, error)
 	BatchToggleToBilled(workspaceID int32, ids []int32) ([]*Transaction, error)
+	// Bill every pending purchase on a CC account dated on/before cutoff, stamping BilledAt with the cutoff
+	BillPendingUpTo(workspaceID, accountID int32, cutoff time.Time) ([]*Transaction, error)
 
 	// Projection management
-	GetProjectionsByTemplate(workspaceID int32, templateID int32) ([]*Transaction, error)
+	GetProjectionsByTemplate(ctx context.Context, workspaceID int32, templateID int32) ([]*Transaction, error)
+	GetByTemplate(workspaceID int32, templateID int32) ([]*Transaction, error)
 	DeleteProjectionsByTemplate(workspaceID int32, templateID int32) error
 	DeleteProjectionsBeyondDate(workspaceID int32, templateID int32, date time.Time) error
+	DeleteFutureUnpaidActualsByTemplate(workspaceID int32, templateID int32, after time.Time) error
 	OrphanActualsByTemplate(workspaceID int32, templateID int32) error
 
 	// Settlement operations
 	GetByIDs(workspaceID int32, ids []int32) ([]*Transaction, error)
 	BulkSettle(workspaceID int32, ids []int32) ([]*Transaction, error)
 	GetDeferredForSettlement(workspaceID int32) ([]*Transaction, error)
+	GetDeferredForSettlementInAccounts(workspaceID int32, accountIDs []int32) ([]*Transaction, error) // Empty accountIDs means every account
 	GetImmediateForSettlement(workspaceID int32, startDate, endDate time.Time) ([]*Transaction, error)
 	GetPendingDeferredCC(workspaceID int32, startDate, endDate time.Time) ([]*Transaction, error)
 
@@ -247,22 +512,46 @@ type TransactionRepository interface {
 
 	// Overdue detection
 	GetOverdueCC(workspaceID int32) ([]*Transaction, error)
+	// Unpaid expenses dated before asOf, oldest first
+	GetOverdueTransactions(workspaceID int32, asOf time.Time) ([]*Transaction, error)
 
 	// Aggregation operations (no pagination)
 	GetByDateRangeForAggregation(workspaceID int32, startDate, endDate time.Time) ([]*Transaction, error)
+	GetByDateRangeForAggregationInAccounts(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) ([]*Transaction, error) // Empty accountIDs means every account
 
 	// Loan transaction operations (CL v2)
 	GetLoanTransactionsByMonth(workspaceID int32, loanID int32, year, month int) ([]*Transaction, error)
-	BulkMarkPaid(workspaceID int32, ids []int32) ([]*Transaction, error)
+	// Get all loan transactions (paid and unpaid, any loan) for a month
+	GetAllLoanTransactionsByMonth(workspaceID int32, year, month int) ([]*Transaction, error)
+	BulkMarkPaid(workspaceID int32, ids []int32, paidAt time.Time) ([]*Transaction, error)
+	BulkMarkPaidTx(tx interface{}, workspaceID int32, ids []int32, paidAt time.Time) ([]*Transaction, error)
 	// Get all transactions for a loan (for item-based modal)
 	GetByLoanID(workspaceID int32, loanID int32) ([]*Transaction, error)
+	GetByLoanIDTx(tx interface{}, workspaceID int32, loanID int32) ([]*Transaction, error)
 	// Loan deletion operations - orphan paid, delete unpaid
 	OrphanPaidTransactionsByLoan(workspaceID int32, loanID int32) error
 	DeleteUnpaidTransactionsByLoan(workspaceID int32, loanID int32) error
+	RestoreTransactionsByDeletedLoan(workspaceID int32, loanID int32) error
+	RestoreTransactionsByDeletedLoanTx(tx interface{}, workspaceID int32, loanID int32) error
+	// Loan schedule regeneration - drop unpaid payments before recreating them
+	ClearUnpaidTransactionsByLoanTx(tx interface{}, workspaceID int32, loanID int32) error
 	GetLoanTransactionStats(workspaceID int32, loanID int32) (*LoanTransactionStats, error)
 	// Loan edit cascade operations
 	UpdatePayeesByLoan(workspaceID int32, loanID int32, newPayee string) (int64, error)
 	HasPaidTransactionsByLoan(workspaceID int32, loanID int32) (bool, error)
+	// Mark every unpaid payment of a loan paid with the external source; a non-empty note replaces the notes
+	SettleUnpaidByLoanExternally(workspaceID int32, loanID int32, note string, paidAt time.Time) ([]*Transaction, error)
 	// Loan trend data aggregation
 	GetLoanTrendData(workspaceID int32, startYear, startMonth, endYear, endMonth int32) ([]*LoanTrendDataRow, error)
+	// Orphaned loan links: transactions whose loan no longer exists or was deleted
+	GetOrphanedLoanTransactions(workspaceID int32) ([]*Transaction, error)
+	// Unpaid payments of loans that still exist, oldest first
+	GetUnpaidLoanTransactions(workspaceID int32) ([]*Transaction, error)
+	// Global search: case-insensitive match on name, merchant, or payee, newest first
+	Search(workspaceID int32, query string, limit int32) ([]*Transaction, error)
+	ClearOrphanedLoanLinks(workspaceID int32) ([]*Transaction, error)
+
+	// Scheduled transactions: clear the scheduled flag once the date is reached
+	PromoteDueScheduled(workspaceID int32, asOf time.Time) ([]*Transaction, error)
+	PromoteAllDueScheduled(asOf time.Time) ([]*Transaction, error)
 }
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -17,6 +18,9 @@ var (
 	ErrMonthBoundaryViolation = errors.New("all transactions must be in the same month")
 	ErrAlreadyGrouped         = errors.New("one or more transactions already belong to a group")
 	ErrTransactionNotInGroup  = errors.New("one or more transactions do not belong to this group")
+	ErrEmptyGroupFilter       = errors.New("filter must set at least one criterion")
+	// ErrDeleteConfirmationMismatch means the confirmed child count no longer matches the group
+	ErrDeleteConfirmationMismatch = errors.New("delete confirmation does not match the group's current contents")
 )
 
 type TransactionGroup struct {
@@ -33,6 +37,12 @@ type TransactionGroup struct {
 	ChildCount  int32           `json:"childCount"`
 }
 
+// GroupDetail is a transaction group together with its member transactions
+type GroupDetail struct {
+	Group    *TransactionGroup `json:"group"`
+	Children []*Transaction    `json:"children"`
+}
+
 // AutoDetectionCandidate represents a consolidated_monthly provider with ungrouped transactions in a month
 type AutoDetectionCandidate struct {
 	ProviderID   int32
@@ -40,6 +50,13 @@ type AutoDetectionCandidate struct {
 	Count        int32
 }
 
+// GroupDeletePreview describes what deleting a group with its children would remove
+type GroupDeletePreview struct {
+	GroupID     int32           `json:"groupId"`
+	ChildCount  int32           `json:"childCount"`
+	TotalAmount decimal.Decimal `json:"totalAmount"`
+}
+
 // GroupOperationResult represents the result of a group delete/ungroup operation
 type GroupOperationResult struct {
 	GroupID          int32  `json:"groupId"`
@@ -47,6 +64,42 @@ type GroupOperationResult struct {
 	ChildrenAffected int32  `json:"childrenAffected"`
 }
 
+// TransactionFilter selects a month's ungrouped transactions for bulk group assignment.
+// Every criterion that is set must match.
+type TransactionFilter struct {
+	LoanProviderID *int32  // Loan payments to this provider
+	NameContains   *string // Case-insensitive substring of the transaction name
+	AccountID      *int32
+	CategoryID     *int32
+}
+
+// IsEmpty reports whether no criterion is set
+func (f TransactionFilter) IsEmpty() bool {
+	return f.LoanProviderID == nil && f.nameContains() == "" && f.AccountID == nil && f.CategoryID == nil
+}
+
+// Matches checks the name, account and category criteria; the loan provider needs
+// a lookup and is resolved by the caller
+func (f TransactionFilter) Matches(tx *Transaction) bool {
+	if name := f.nameContains(); name != "" && !strings.Contains(strings.ToLower(tx.Name), name) {
+		return false
+	}
+	if f.AccountID != nil && tx.AccountID != *f.AccountID {
+		return false
+	}
+	if f.CategoryID != nil && (tx.CategoryID == nil || *tx.CategoryID != *f.CategoryID) {
+		return false
+	}
+	return true
+}
+
+func (f TransactionFilter) nameContains() string {
+	if f.NameContains == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(*f.NameContains))
+}
+
 func (g *TransactionGroup) Validate() error {
 	if g.Name == "" {
 		return ErrGroupNameEmpty
@@ -69,6 +122,7 @@ type TransactionGroupRepository interface {
 	UnassignAllFromGroup(workspaceID int32, groupID int32) (int64, error)
 	DeleteGroupAndChildren(workspaceID int32, groupID int32) (int32, error)
 	CountGroupChildren(workspaceID int32, groupID int32) (int32, error)
+	GetGroupChildren(workspaceID int32, groupID int32) ([]*Transaction, error)
 	GetUngroupedTransactionsByMonth(workspaceID int32, startDate, endDate time.Time) ([]*Transaction, error)
 	GetConsolidatedProvidersByMonth(workspaceID int32, month string) ([]AutoDetectionCandidate, error)
 	GetUngroupedTransactionIDsByProviderMonth(workspaceID int32, providerID int32, month string) ([]int32, error)
@@ -1,7 +1,12 @@
 package domain
 
 import (
+	"encoding/base64"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestCCStateConstants(t *testing.T) {
@@ -117,3 +122,72 @@ func TestTransactionIsProjectedDefault(t *testing.T) {
 		t.Errorf("Expected default IsProjected to be false, got %v", tx.IsProjected)
 	}
 }
+
+func TestTransactionCursorRoundTrip(t *testing.T) {
+	cursor := TransactionCursor{TransactionDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), ID: 4821}
+
+	decoded, err := DecodeTransactionCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !decoded.TransactionDate.Equal(cursor.TransactionDate) || decoded.ID != cursor.ID {
+		t.Errorf("Expected %+v, got %+v", cursor, *decoded)
+	}
+}
+
+func TestDecodeTransactionCursor_RejectsTampering(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	valid := TransactionCursor{TransactionDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), ID: 42}.Encode()
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "%%%"},
+		{"padded base64", valid + "=="},
+		{"missing separator", encode("2026-03-10")},
+		{"bad date", encode("2026-13-40:42")},
+		{"bad id", encode("2026-03-10:abc")},
+		{"zero id", encode("2026-03-10:0")},
+		{"negative id", encode("2026-03-10:-5")},
+		{"leading zeros", encode("2026-03-10:0042")},
+		{"overflowing id", encode("2026-03-10:99999999999")},
+		{"truncated", valid[:8]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeTransactionCursor(tt.cursor); err != ErrInvalidCursor {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTransactionToUpdateData_CopiesEveryField(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	notes, merchant, payee, location := "n", "m", "p", "l"
+	categoryID, templateID := int32(2), int32(3)
+	intent := SettlementIntentDeferred
+	lat, lng := decimal.NewFromFloat(1.5), decimal.NewFromFloat(2.5)
+
+	txn := &Transaction{
+		Name: "Rent", Amount: decimal.NewFromInt(100), Type: TransactionTypeExpense,
+		TransactionDate: now, AccountID: 1, Notes: &notes, CategoryID: &categoryID,
+		IsPaid: true, BilledAt: &now, SettlementIntent: &intent,
+		Source: "recurring", TemplateID: &templateID, IsProjected: true, ModifiedFromTemplate: true,
+		IsScheduled: true, Merchant: &merchant, Payee: &payee,
+		IsRefund: true, ExcludeFromReports: true, IsTaxDeductible: true,
+		Latitude: &lat, Longitude: &lng, Location: &location,
+	}
+
+	// Every field must be carried over so updates never silently reset a column
+	data := reflect.ValueOf(*txn.ToUpdateData())
+	for i := 0; i < data.NumField(); i++ {
+		if data.Field(i).IsZero() {
+			t.Errorf("UpdateTransactionData.%s was not copied from the transaction", data.Type().Field(i).Name)
+		}
+	}
+}
@@ -6,13 +6,72 @@ import (
 	"github.com/google/uuid"
 )
 
+// WorkspaceRole describes the access a user has to a workspace
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+)
+
+// IsValid returns true if the role is one of the known workspace roles
+func (r WorkspaceRole) IsValid() bool {
+	switch r {
+	case WorkspaceRoleOwner, WorkspaceRoleEditor, WorkspaceRoleViewer:
+		return true
+	}
+	return false
+}
+
+// Outranks returns true if r grants more access than other
+func (r WorkspaceRole) Outranks(other WorkspaceRole) bool {
+	return r.rank() > other.rank()
+}
+
+func (r WorkspaceRole) rank() int {
+	switch r {
+	case WorkspaceRoleOwner:
+		return 3
+	case WorkspaceRoleEditor:
+		return 2
+	case WorkspaceRoleViewer:
+		return 1
+	}
+	return 0
+}
+
 // Workspace represents a user's workspace
 type Workspace struct {
-	ID        int32     `json:"id"`
-	UserID    uuid.UUID `json:"userId"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID                      int32         `json:"id"`
+	UserID                  uuid.UUID     `json:"userId"`
+	Name                    string        `json:"name"`
+	Role                    WorkspaceRole `json:"role,omitempty"`                    // Caller's role; only set when listing a user's workspaces
+	Currency                string        `json:"currency"`                          // ISO 4217 code amounts are displayed in
+	RoundUpSavingsAccountID *int32        `json:"roundUpSavingsAccountId,omitempty"` // Receives expense round-ups; nil disables them
+	GenerationHorizonMonths int32         `json:"generationHorizonMonths"`           // Months past the current one recurring generation covers
+	CreatedAt               time.Time     `json:"createdAt"`
+	UpdatedAt               time.Time     `json:"updatedAt"`
+}
+
+// WorkspaceSettings holds workspace-level display configuration for clients
+type WorkspaceSettings struct {
+	Currency                string `json:"currency"`
+	CurrencySymbol          string `json:"currencySymbol"`
+	RoundUpSavingsAccountID *int32 `json:"roundUpSavingsAccountId,omitempty"`
+	GenerationHorizonMonths int32  `json:"generationHorizonMonths"`
+}
+
+// MaxGenerationHorizonMonths caps how far past the current month recurring generation may run
+const MaxGenerationHorizonMonths = 12
+
+// WorkspaceMember links a user to a workspace with a role
+type WorkspaceMember struct {
+	ID          int32         `json:"id"`
+	WorkspaceID int32         `json:"workspaceId"`
+	UserID      uuid.UUID     `json:"userId"`
+	Role        WorkspaceRole `json:"role"`
+	CreatedAt   time.Time     `json:"createdAt"`
 }
 
 // WorkspaceRepository defines the interface for workspace persistence operations
@@ -20,7 +79,14 @@ type WorkspaceRepository interface {
 	GetByID(id int32) (*Workspace, error)
 	GetByUserID(userID uuid.UUID) (*Workspace, error)
 	GetByUserAuth0ID(auth0ID string) (*Workspace, error)
-	Create(workspace *Workspace) (*Workspace, error)
+	ListByUserAuth0ID(auth0ID string) ([]*Workspace, error)
+	Create(workspace *Workspace) (*Workspace, error) // Also records the creator as owner
 	Update(workspace *Workspace) (*Workspace, error)
+	UpdateCurrency(id int32, currency string) (*Workspace, error)
+	UpdateRoundUpSavingsAccount(id int32, accountID *int32) (*Workspace, error) // nil disables round-up
+	UpdateGenerationHorizon(id int32, months int32) (*Workspace, error)
 	Delete(id int32) error
+	// Membership operations
+	AddMember(member *WorkspaceMember) (*WorkspaceMember, error)
+	GetMemberRole(workspaceID int32, userID uuid.UUID) (WorkspaceRole, error) // ErrWorkspaceMemberNotFound if not a member
 }
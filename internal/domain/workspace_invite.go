@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkspaceMemberNotFound = errors.New("workspace member not found")
+	ErrInviteNotFound          = errors.New("invite not found")
+	ErrInviteExpired           = errors.New("invite has expired")
+	ErrInviteAlreadyAccepted   = errors.New("invite has already been accepted")
+	ErrInviteEmailMismatch     = errors.New("invite was sent to a different email address")
+	ErrInviteEmailRequired     = errors.New("invite email is required")
+	ErrInvalidInviteRole       = errors.New("invite role must be editor or viewer")
+)
+
+// InviteExpiry is how long an invite token stays valid
+const InviteExpiry = 7 * 24 * time.Hour
+
+// WorkspaceInvite is a pending invitation for someone to join a workspace
+type WorkspaceInvite struct {
+	ID          int32         `json:"id"`
+	WorkspaceID int32         `json:"workspaceId"`
+	Email       string        `json:"email"`
+	Role        WorkspaceRole `json:"role"`
+	Token       string        `json:"token"`
+	InvitedBy   uuid.UUID     `json:"invitedBy"`
+	ExpiresAt   time.Time     `json:"expiresAt"`
+	AcceptedAt  *time.Time    `json:"acceptedAt,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+// IsExpired returns true if the invite can no longer be accepted
+func (i *WorkspaceInvite) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+type WorkspaceInviteRepository interface {
+	Create(invite *WorkspaceInvite) (*WorkspaceInvite, error)
+	GetByToken(token string) (*WorkspaceInvite, error)
+	// Accept marks the invite accepted and adds the user as a member atomically
+	Accept(inviteID int32, member *WorkspaceMember) (*WorkspaceMember, error)
+}
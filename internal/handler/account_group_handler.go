@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// AccountGroupHandler handles account group (folder) HTTP requests
+type AccountGroupHandler struct {
+	groupService *service.AccountGroupService
+}
+
+// NewAccountGroupHandler creates a new AccountGroupHandler
+func NewAccountGroupHandler(groupService *service.AccountGroupService) *AccountGroupHandler {
+	return &AccountGroupHandler{groupService: groupService}
+}
+
+// AccountGroupRequest represents the create/update account group request body
+type AccountGroupRequest struct {
+	Name string `json:"name"`
+}
+
+// AccountGroupResponse represents an account group in API responses
+type AccountGroupResponse struct {
+	ID          int32   `json:"id"`
+	WorkspaceID int32   `json:"workspaceId"`
+	Name        string  `json:"name"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+	DeletedAt   *string `json:"deletedAt,omitempty"`
+}
+
+// CreateGroup handles POST /api/v1/account-groups
+func (h *AccountGroupHandler) CreateGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req AccountGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	group, err := h.groupService.CreateGroup(workspaceID, req.Name)
+	if err != nil {
+		if resp := h.handleValidationError(c, err); resp != nil {
+			return resp
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create account group")
+		return NewInternalError(c, "Failed to create account group")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("group_id", group.ID).Str("name", group.Name).Msg("Account group created")
+
+	return c.JSON(http.StatusCreated, toAccountGroupResponse(group))
+}
+
+// GetGroups handles GET /api/v1/account-groups
+func (h *AccountGroupHandler) GetGroups(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	groups, err := h.groupService.GetGroups(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get account groups")
+		return NewInternalError(c, "Failed to get account groups")
+	}
+
+	response := make([]AccountGroupResponse, len(groups))
+	for i, group := range groups {
+		response[i] = toAccountGroupResponse(group)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateGroup handles PUT /api/v1/account-groups/:id
+func (h *AccountGroupHandler) UpdateGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account group ID", nil)
+	}
+
+	var req AccountGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	group, err := h.groupService.UpdateGroup(workspaceID, int32(id), req.Name)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountGroupNotFound) {
+			return NewNotFoundError(c, "Account group not found")
+		}
+		if resp := h.handleValidationError(c, err); resp != nil {
+			return resp
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("group_id", id).Msg("Failed to update account group")
+		return NewInternalError(c, "Failed to update account group")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("group_id", group.ID).Str("name", group.Name).Msg("Account group updated")
+	return c.JSON(http.StatusOK, toAccountGroupResponse(group))
+}
+
+// DeleteGroup handles DELETE /api/v1/account-groups/:id
+// Accounts in the group are moved to the ungrouped bucket
+func (h *AccountGroupHandler) DeleteGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account group ID", nil)
+	}
+
+	if err := h.groupService.DeleteGroup(workspaceID, int32(id)); err != nil {
+		if errors.Is(err, domain.ErrAccountGroupNotFound) {
+			return NewNotFoundError(c, "Account group not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("group_id", id).Msg("Failed to delete account group")
+		return NewInternalError(c, "Failed to delete account group")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("group_id", id).Msg("Account group deleted (soft)")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// handleValidationError maps name validation errors; returns nil if err is not one of them
+func (h *AccountGroupHandler) handleValidationError(c echo.Context, err error) error {
+	if errors.Is(err, domain.ErrNameRequired) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Message: "Name is required"},
+		})
+	}
+	if errors.Is(err, domain.ErrNameTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Message: "Name must be 100 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrAccountGroupAlreadyExists) {
+		return NewConflictError(c, "An account group with this name already exists")
+	}
+	return nil
+}
+
+// Helper function to convert domain.AccountGroup to AccountGroupResponse
+func toAccountGroupResponse(group *domain.AccountGroup) AccountGroupResponse {
+	resp := AccountGroupResponse{
+		ID:          group.ID,
+		WorkspaceID: group.WorkspaceID,
+		Name:        group.Name,
+		CreatedAt:   group.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   group.UpdatedAt.Format(time.RFC3339),
+	}
+	if group.DeletedAt != nil {
+		deletedAt := group.DeletedAt.Format(time.RFC3339)
+		resp.DeletedAt = &deletedAt
+	}
+	return resp
+}
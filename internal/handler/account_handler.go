@@ -33,11 +33,15 @@ type CreateAccountRequest struct {
 	Name           string `json:"name"`
 	Template       string `json:"template"`
 	InitialBalance string `json:"initialBalance,omitempty"`
+	// IncludeInNetWorth defaults to true when omitted
+	IncludeInNetWorth *bool `json:"includeInNetWorth,omitempty"`
 }
 
 // UpdateAccountRequest represents the update account request body
 type UpdateAccountRequest struct {
 	Name string `json:"name"`
+	// IncludeInNetWorth is left unchanged when omitted
+	IncludeInNetWorth *bool `json:"includeInNetWorth,omitempty"`
 }
 
 // AccountResponse represents an account in API responses
@@ -50,11 +54,29 @@ type AccountResponse struct {
 	InitialBalance    string  `json:"initialBalance"`
 	CalculatedBalance string  `json:"calculatedBalance"`
 	CCOutstanding     *string `json:"ccOutstanding,omitempty"`
+	AccountGroupID    *int32  `json:"accountGroupId"`
+	IncludeInNetWorth bool    `json:"includeInNetWorth"`
 	CreatedAt         string  `json:"createdAt"`
 	UpdatedAt         string  `json:"updatedAt"`
 	DeletedAt         *string `json:"deletedAt,omitempty"`
 }
 
+// AssignAccountGroupRequest represents the assign account group request body.
+// A null groupId moves the account to the ungrouped bucket.
+type AssignAccountGroupRequest struct {
+	GroupID *int32 `json:"groupId"`
+}
+
+// AccountFolderResponse represents one folder of accounts when listing with groupBy=folder
+type AccountFolderResponse struct {
+	GroupID  *int32            `json:"groupId"` // null for the ungrouped bucket
+	Name     string            `json:"name"`
+	Accounts []AccountResponse `json:"accounts"`
+}
+
+// UngroupedFolderName is the display name of the default bucket for accounts without a group
+const UngroupedFolderName = "Ungrouped"
+
 // CCOutstandingResponse represents the CC summary API response
 type CCOutstandingResponse struct {
 	TotalOutstanding string                       `json:"totalOutstanding"`
@@ -69,6 +91,36 @@ type PerAccountOutstandingEntry struct {
 	OutstandingBalance string `json:"outstandingBalance"`
 }
 
+// AccountBalanceAsOfResponse represents an account's balance on a given date
+type AccountBalanceAsOfResponse struct {
+	AccountID        int32  `json:"accountId"`
+	AsOf             string `json:"asOf"`
+	Balance          string `json:"balance"`
+	ExcludeUnsettled bool   `json:"excludeUnsettled"`
+}
+
+// ChangeAccountTemplateRequest represents the change account template request body
+type ChangeAccountTemplateRequest struct {
+	Template string `json:"template"`
+}
+
+// ReconcileAccountRequest represents the reconcile account request body
+type ReconcileAccountRequest struct {
+	ExternalBalance string `json:"externalBalance"`
+	AsOf            string `json:"asOf,omitempty"` // YYYY-MM-DD, defaults to today
+	Adjust          bool   `json:"adjust"`         // Create a correction transaction when the balances differ
+}
+
+// ReconcileAccountResponse represents the outcome of reconciling an account
+type ReconcileAccountResponse struct {
+	AccountID       int32                `json:"accountId"`
+	AsOf            string               `json:"asOf"`
+	ComputedBalance string               `json:"computedBalance"`
+	ExternalBalance string               `json:"externalBalance"`
+	Difference      string               `json:"difference"`
+	Adjustment      *TransactionResponse `json:"adjustment,omitempty"`
+}
+
 // CreateAccount godoc
 // @Summary Create a new account
 // @Description Create a new financial account (bank, cash, e-wallet, or credit card)
@@ -109,6 +161,9 @@ func (h *AccountHandler) CreateAccount(c echo.Context) error {
 		Template:       domain.AccountTemplate(req.Template),
 		InitialBalance: initialBalance,
 	}
+	if req.IncludeInNetWorth != nil {
+		input.ExcludeFromNetWorth = !*req.IncludeInNetWorth
+	}
 
 	account, err := h.accountService.CreateAccount(workspaceID, input)
 	if err != nil {
@@ -144,8 +199,11 @@ func (h *AccountHandler) CreateAccount(c echo.Context) error {
 // @Produce json
 // @Security BearerAuth
 // @Param includeArchived query bool false "Include archived accounts"
+// @Param includeDeleted query bool false "Include soft-deleted accounts (owners only)"
+// @Param groupBy query string false "Set to 'folder' to group accounts by account group"
 // @Success 200 {array} AccountResponse
 // @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
 // @Failure 500 {object} ProblemDetails
 // @Router /accounts [get]
 func (h *AccountHandler) GetAccounts(c echo.Context) error {
@@ -154,10 +212,27 @@ func (h *AccountHandler) GetAccounts(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
-	// Check for includeArchived query param
-	includeArchived := c.QueryParam("includeArchived") == "true"
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return NewForbiddenError(c, "Only workspace owners can list deleted accounts")
+	}
+	// Archived accounts are soft-deleted; includeArchived predates includeDeleted and stays open to all roles
+	if c.QueryParam("includeArchived") == "true" {
+		opts.IncludeDeleted = true
+	}
+
+	groupBy := c.QueryParam("groupBy")
+	if groupBy != "" && groupBy != "folder" {
+		return NewValidationError(c, "Invalid groupBy parameter", []ValidationError{
+			{Field: "groupBy", Message: "Must be 'folder'"},
+		})
+	}
 
-	accounts, err := h.accountService.GetAccounts(workspaceID, includeArchived)
+	if groupBy == "folder" {
+		return h.getAccountsByFolder(c, workspaceID, opts)
+	}
+
+	accounts, err := h.accountService.GetAccounts(workspaceID, opts)
 	if err != nil {
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get accounts")
 		return NewInternalError(c, "Failed to get accounts")
@@ -172,23 +247,135 @@ func (h *AccountHandler) GetAccounts(c echo.Context) error {
 
 	response := make([]AccountResponse, len(accounts))
 	for i, account := range accounts {
-		balance := balances[account.ID]
-		if balance == nil {
-			balance = &service.AccountBalanceResult{
-				AccountID:         account.ID,
-				InitialBalance:    account.InitialBalance,
-				CalculatedBalance: account.InitialBalance,
-			}
+		response[i] = toAccountResponseWithBalance(account, accountBalanceOrInitial(balances, account))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// getAccountsByFolder responds with accounts grouped by account group, ungrouped bucket last
+func (h *AccountHandler) getAccountsByFolder(c echo.Context, workspaceID int32, opts domain.ListOptions) error {
+	folders, err := h.accountService.GetAccountsByFolder(workspaceID, opts)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get accounts by folder")
+		return NewInternalError(c, "Failed to get accounts")
+	}
+
+	balances, err := h.calculationService.CalculateAccountBalances(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to calculate balances")
+		return NewInternalError(c, "Failed to calculate balances")
+	}
+
+	response := make([]AccountFolderResponse, len(folders))
+	for i, folder := range folders {
+		resp := AccountFolderResponse{
+			Name:     UngroupedFolderName,
+			Accounts: make([]AccountResponse, len(folder.Accounts)),
+		}
+		if folder.Group != nil {
+			resp.GroupID = &folder.Group.ID
+			resp.Name = folder.Group.Name
+		}
+		for j, account := range folder.Accounts {
+			resp.Accounts[j] = toAccountResponseWithBalance(account, accountBalanceOrInitial(balances, account))
 		}
-		response[i] = toAccountResponseWithBalance(account, balance)
+		response[i] = resp
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// AssignAccountGroup handles PUT /api/v1/accounts/:id/group
+// Moves an account into a group, or to the ungrouped bucket when groupId is null
+func (h *AccountHandler) AssignAccountGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	var req AssignAccountGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	account, err := h.accountService.AssignGroup(workspaceID, int32(id), req.GroupID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		if errors.Is(err, domain.ErrAccountGroupNotFound) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "groupId", Message: "Account group not found"},
+			})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to assign account group")
+		return NewInternalError(c, "Failed to assign account group")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("account_id", account.ID).Msg("Account group assigned")
+	return c.JSON(http.StatusOK, toAccountResponse(account))
+}
+
+// ChangeAccountTemplate handles PUT /api/v1/accounts/:id/template
+// Switches the account's template (and asset/liability type); credit card changes need an account without transactions
+func (h *AccountHandler) ChangeAccountTemplate(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	var req ChangeAccountTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	account, err := h.accountService.ChangeAccountTemplate(workspaceID, int32(id), domain.AccountTemplate(req.Template))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTemplate) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "template", Code: ValidationCodeInvalidChoice, Message: "Template must be one of: bank, cash, ewallet, credit_card"},
+			})
+		}
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		if errors.Is(err, domain.ErrCannotChangeTemplateWithTransactions) {
+			return NewConflictError(c, "Cannot switch an account with transactions to or from credit card")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to change account template")
+		return NewInternalError(c, "Failed to change account template")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("account_id", account.ID).Str("template", string(account.Template)).Msg("Account template changed")
+	return c.JSON(http.StatusOK, toAccountResponse(account))
+}
+
+// accountBalanceOrInitial returns the calculated balance for an account, defaulting to its initial balance
+func accountBalanceOrInitial(balances map[int32]*service.AccountBalanceResult, account *domain.Account) *service.AccountBalanceResult {
+	if balance := balances[account.ID]; balance != nil {
+		return balance
+	}
+	return &service.AccountBalanceResult{
+		AccountID:         account.ID,
+		InitialBalance:    account.InitialBalance,
+		CalculatedBalance: account.InitialBalance,
+	}
+}
+
 // UpdateAccount godoc
 // @Summary Update an account
-// @Description Update an existing financial account's name
+// @Description Update an existing financial account's name and whether it counts towards net worth
 // @Tags accounts
 // @Accept json
 // @Produce json
@@ -235,6 +422,17 @@ func (h *AccountHandler) UpdateAccount(c echo.Context) error {
 		return NewInternalError(c, "Failed to update account")
 	}
 
+	if req.IncludeInNetWorth != nil && *req.IncludeInNetWorth != account.IncludeInNetWorth() {
+		account, err = h.accountService.SetIncludeInNetWorth(workspaceID, int32(id), *req.IncludeInNetWorth)
+		if err != nil {
+			if errors.Is(err, domain.ErrAccountNotFound) {
+				return NewNotFoundError(c, "Account not found")
+			}
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to update account net worth flag")
+			return NewInternalError(c, "Failed to update account")
+		}
+	}
+
 	log.Info().Int32("workspace_id", workspaceID).Int32("account_id", account.ID).Str("name", account.Name).Msg("Account updated")
 	return c.JSON(http.StatusOK, toAccountResponse(account))
 }
@@ -275,6 +473,145 @@ func (h *AccountHandler) DeleteAccount(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetBalanceAsOf godoc
+// @Summary Get account balance as of a date
+// @Description Get an account's balance counting only transactions dated on or before asOf, for statement reconciliation
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Account ID"
+// @Param asOf query string false "Date in YYYY-MM-DD format (defaults to today)"
+// @Param excludeUnsettled query bool false "Leave out unsettled credit card purchases"
+// @Success 200 {object} AccountBalanceAsOfResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /accounts/{id}/balance [get]
+func (h *AccountHandler) GetBalanceAsOf(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	asOf := time.Now()
+	if asOfStr := c.QueryParam("asOf"); asOfStr != "" {
+		asOf, err = time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "asOf", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
+			})
+		}
+	}
+
+	excludeUnsettled := false
+	if excludeParam := c.QueryParam("excludeUnsettled"); excludeParam != "" {
+		excludeUnsettled, err = strconv.ParseBool(excludeParam)
+		if err != nil {
+			return NewValidationError(c, "Invalid excludeUnsettled parameter", []ValidationError{
+				{Field: "excludeUnsettled", Code: ValidationCodeInvalidFormat, Message: "Must be true or false"},
+			})
+		}
+	}
+
+	var balance decimal.Decimal
+	if excludeUnsettled {
+		balance, err = h.calculationService.GetSettledBalanceAsOf(workspaceID, int32(id), asOf)
+	} else {
+		balance, err = h.calculationService.GetBalanceAsOf(workspaceID, int32(id), asOf)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to get account balance as of date")
+		return NewInternalError(c, "Failed to get account balance")
+	}
+
+	return c.JSON(http.StatusOK, AccountBalanceAsOfResponse{
+		AccountID:        int32(id),
+		AsOf:             asOf.Format("2006-01-02"),
+		Balance:          FormatAmount(balance, domain.DefaultCurrency),
+		ExcludeUnsettled: excludeUnsettled,
+	})
+}
+
+// ReconcileAccount godoc
+// @Summary Reconcile an account against an external balance
+// @Description Compare the account's balance as of a date with an externally reported balance (e.g. a bank statement). With adjust=true, a correction transaction is created for any difference.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Account ID"
+// @Param request body ReconcileAccountRequest true "External balance to reconcile against"
+// @Success 200 {object} ReconcileAccountResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /accounts/{id}/reconcile [post]
+func (h *AccountHandler) ReconcileAccount(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	var req ReconcileAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	externalBalance, err := decimal.NewFromString(req.ExternalBalance)
+	if err != nil {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "externalBalance", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+		})
+	}
+
+	asOf := time.Now()
+	if req.AsOf != "" {
+		asOf, err = time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "asOf", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
+			})
+		}
+	}
+
+	result, err := h.calculationService.Reconcile(workspaceID, int32(id), externalBalance, asOf, req.Adjust)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to reconcile account")
+		return NewInternalError(c, "Failed to reconcile account")
+	}
+
+	response := ReconcileAccountResponse{
+		AccountID:       result.AccountID,
+		AsOf:            result.AsOf.Format("2006-01-02"),
+		ComputedBalance: FormatAmount(result.ComputedBalance, domain.DefaultCurrency),
+		ExternalBalance: FormatAmount(result.ExternalBalance, domain.DefaultCurrency),
+		Difference:      FormatAmount(result.Difference, domain.DefaultCurrency),
+	}
+	if result.Adjustment != nil {
+		adjustment := toTransactionResponse(result.Adjustment)
+		response.Adjustment = &adjustment
+		log.Info().Int32("workspace_id", workspaceID).Int("account_id", id).Str("difference", response.Difference).Msg("Account reconciled with adjustment")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // GetCCSummary godoc
 // @Summary Get credit card summary
 // @Description Get total outstanding balance across all credit card accounts
@@ -327,6 +664,8 @@ func toAccountResponse(account *domain.Account) AccountResponse {
 		Template:          string(account.Template),
 		InitialBalance:    account.InitialBalance.StringFixed(2),
 		CalculatedBalance: account.InitialBalance.StringFixed(2), // Default to initial if no calculation
+		AccountGroupID:    account.AccountGroupID,
+		IncludeInNetWorth: account.IncludeInNetWorth(),
 		CreatedAt:         account.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:         account.UpdatedAt.Format(time.RFC3339),
 	}
@@ -347,6 +686,8 @@ func toAccountResponseWithBalance(account *domain.Account, balance *service.Acco
 		Template:          string(account.Template),
 		InitialBalance:    account.InitialBalance.StringFixed(2),
 		CalculatedBalance: balance.CalculatedBalance.StringFixed(2),
+		AccountGroupID:    account.AccountGroupID,
+		IncludeInNetWorth: account.IncludeInNetWorth(),
 		CreatedAt:         account.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:         account.UpdatedAt.Format(time.RFC3339),
 	}
@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
 	"github.com/labstack/echo/v4"
@@ -289,6 +292,96 @@ func TestGetAccounts_Success(t *testing.T) {
 	}
 }
 
+func TestGetAccounts_GroupByFolder(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	groupRepo := testutil.NewMockAccountGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountService := service.NewAccountService(accountRepo)
+	accountService.SetGroupRepository(groupRepo)
+	calculationService := service.NewCalculationService(accountRepo, transactionRepo)
+	handler := NewAccountHandler(accountService, calculationService)
+
+	workspaceID := int32(1)
+	groupID := int32(7)
+	groupRepo.AddGroup(&domain.AccountGroup{ID: groupID, WorkspaceID: workspaceID, Name: "Business"})
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Company Bank",
+		AccountType:    domain.AccountTypeAsset,
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromFloat(500.00),
+		AccountGroupID: &groupID,
+	})
+	accountRepo.AddAccount(&domain.Account{
+		ID:             2,
+		WorkspaceID:    workspaceID,
+		Name:           "Wallet",
+		AccountType:    domain.AccountTypeAsset,
+		Template:       domain.TemplateCash,
+		InitialBalance: decimal.Zero,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts?groupBy=folder", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", workspaceID)
+
+	if err := handler.GetAccounts(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response []AccountFolderResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(response) != 2 {
+		t.Fatalf("Expected 2 folders, got %d", len(response))
+	}
+	if response[0].GroupID == nil || *response[0].GroupID != groupID || response[0].Name != "Business" {
+		t.Errorf("Expected Business folder first, got %+v", response[0])
+	}
+	if len(response[0].Accounts) != 1 || response[0].Accounts[0].Name != "Company Bank" {
+		t.Errorf("Expected Company Bank in Business, got %+v", response[0].Accounts)
+	}
+	if response[1].GroupID != nil || response[1].Name != UngroupedFolderName {
+		t.Errorf("Expected ungrouped bucket last, got %+v", response[1])
+	}
+	if len(response[1].Accounts) != 1 || response[1].Accounts[0].Name != "Wallet" {
+		t.Errorf("Expected Wallet in ungrouped bucket, got %+v", response[1].Accounts)
+	}
+}
+
+func TestGetAccounts_InvalidGroupBy(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountService := service.NewAccountService(accountRepo)
+	calculationService := service.NewCalculationService(accountRepo, transactionRepo)
+	handler := NewAccountHandler(accountService, calculationService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts?groupBy=type", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetAccounts(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
+
 func TestGetAccounts_EmptyList(t *testing.T) {
 	e := echo.New()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -530,3 +623,88 @@ func TestGetCCSummary_NoAccounts(t *testing.T) {
 		t.Errorf("Expected 0 CC accounts, got %d", response.CCAccountCount)
 	}
 }
+
+func TestGetAccounts_IncludeDeletedRequiresOwner(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountService := service.NewAccountService(accountRepo)
+	calculationService := service.NewCalculationService(accountRepo, transactionRepo)
+	handler := NewAccountHandler(accountService, calculationService)
+
+	workspaceID := int32(1)
+	deletedAt := time.Now()
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Live", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: workspaceID, Name: "Closed", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, DeletedAt: &deletedAt})
+
+	for _, tc := range []struct {
+		role         domain.WorkspaceRole
+		wantStatus   int
+		wantAccounts int
+	}{
+		{role: domain.WorkspaceRoleEditor, wantStatus: http.StatusForbidden},
+		{role: domain.WorkspaceRoleOwner, wantStatus: http.StatusOK, wantAccounts: 2},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts?includeDeleted=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", workspaceID)
+		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), middleware.WorkspaceRoleKey, tc.role)))
+
+		if err := handler.GetAccounts(c); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rec.Code != tc.wantStatus {
+			t.Errorf("Role %s: expected status %d, got %d", tc.role, tc.wantStatus, rec.Code)
+			continue
+		}
+		if tc.wantStatus != http.StatusOK {
+			continue
+		}
+
+		var response []AccountResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response) != tc.wantAccounts {
+			t.Errorf("Expected %d accounts, got %d", tc.wantAccounts, len(response))
+		}
+	}
+}
+
+func TestGetAccounts_IncludeArchivedOpenToAllRoles(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountService := service.NewAccountService(accountRepo)
+	calculationService := service.NewCalculationService(accountRepo, transactionRepo)
+	handler := NewAccountHandler(accountService, calculationService)
+
+	workspaceID := int32(1)
+	deletedAt := time.Now()
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Live", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: workspaceID, Name: "Closed", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, DeletedAt: &deletedAt})
+
+	for _, role := range []domain.WorkspaceRole{domain.WorkspaceRoleViewer, domain.WorkspaceRoleEditor, domain.WorkspaceRoleOwner} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts?includeArchived=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", workspaceID)
+		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), middleware.WorkspaceRoleKey, role)))
+
+		if err := handler.GetAccounts(c); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Role %s: expected status %d, got %d", role, http.StatusOK, rec.Code)
+		}
+
+		var response []AccountResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response) != 2 {
+			t.Errorf("Role %s: expected archived account listed, got %d accounts", role, len(response))
+		}
+	}
+}
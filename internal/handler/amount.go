@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// FormatAmount formats a monetary amount to the minor units of the given currency.
+// Unknown currencies fall back to 2 decimal places. Every amount field in API responses
+// goes through it; handlers pass domain.DefaultCurrency until the workspace currency is
+// threaded through to them.
+func FormatAmount(amount decimal.Decimal, currency string) string {
+	return amount.StringFixed(domain.CurrencyMinorUnits(currency))
+}
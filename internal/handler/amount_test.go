@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatAmount_TwoDecimalCurrency(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"100", "100.00"},
+		{"1234.5", "1234.50"},
+		{"99.999", "100.00"},
+		{"-12.345", "-12.35"},
+	}
+
+	for _, tt := range tests {
+		got := FormatAmount(decimal.RequireFromString(tt.amount), "RM")
+		if got != tt.expected {
+			t.Errorf("FormatAmount(%s, RM) = %s, want %s", tt.amount, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatAmount_ZeroDecimalCurrency(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"100", "100"},
+		{"1234.5", "1235"},
+		{"99.4", "99"},
+	}
+
+	for _, tt := range tests {
+		got := FormatAmount(decimal.RequireFromString(tt.amount), "JPY")
+		if got != tt.expected {
+			t.Errorf("FormatAmount(%s, JPY) = %s, want %s", tt.amount, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatAmount_ThreeDecimalCurrency(t *testing.T) {
+	got := FormatAmount(decimal.RequireFromString("1.5"), "kwd")
+	if got != "1.500" {
+		t.Errorf("Expected 1.500, got %s", got)
+	}
+}
+
+func TestFormatAmount_UnknownCurrencyDefaultsToTwoDecimals(t *testing.T) {
+	got := FormatAmount(decimal.RequireFromString("5"), "XYZ")
+	if got != "5.00" {
+		t.Errorf("Expected 5.00, got %s", got)
+	}
+}
+
+func TestFormatAmount_DefaultCurrencyMatchesLoanResponses(t *testing.T) {
+	amount := decimal.RequireFromString("1500.5")
+	if got := FormatAmount(amount, domain.DefaultCurrency); got != "1500.50" {
+		t.Errorf("Expected default currency to format with 2dp, got %s", got)
+	}
+}
@@ -42,6 +42,13 @@ type WorkspaceResponse struct {
 	Name string `json:"name"`
 }
 
+// WorkspaceListItemResponse represents one of the user's workspaces in the listing
+type WorkspaceListItemResponse struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
 // Callback handles the Auth0 callback after successful authentication
 // This endpoint is called by the frontend after receiving the Auth0 token
 // POST /auth/callback
@@ -167,3 +174,30 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 		Message: "Logged out successfully",
 	})
 }
+
+// ListWorkspaces returns every workspace the authenticated user can access.
+// Clients switch between them by sending the X-Workspace-ID header.
+// GET /workspaces
+func (h *AuthHandler) ListWorkspaces(c echo.Context) error {
+	auth0ID := middleware.GetAuth0ID(c)
+	if auth0ID == "" {
+		return NewUnauthorizedError(c, "Authentication required")
+	}
+
+	workspaces, err := h.authService.ListWorkspacesForUser(auth0ID)
+	if err != nil {
+		log.Error().Err(err).Str("auth0_id", auth0ID).Msg("Failed to list workspaces")
+		return NewInternalError(c, "Failed to list workspaces")
+	}
+
+	response := make([]WorkspaceListItemResponse, len(workspaces))
+	for i, ws := range workspaces {
+		response[i] = WorkspaceListItemResponse{
+			ID:   ws.ID,
+			Name: ws.Name,
+			Role: string(ws.Role),
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -354,3 +354,61 @@ func TestLogout_MissingAuth0ID(t *testing.T) {
 		t.Errorf("Expected status %d in body, got %d", http.StatusUnauthorized, problemDetails.Status)
 	}
 }
+
+func TestListWorkspaces_MultipleWorkspaces(t *testing.T) {
+	e := echo.New()
+	userRepo := testutil.NewMockUserRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	authService := service.NewAuthService(userRepo, workspaceRepo)
+	handler := NewAuthHandler(authService)
+
+	auth0ID := "auth0|twoworkspaces"
+	userID := uuid.New()
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: userID, Name: "Personal", Role: domain.WorkspaceRoleOwner}, auth0ID)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 2, UserID: userID, Name: "Household", Role: domain.WorkspaceRoleOwner}, auth0ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, auth0ID, "two@example.com", "Two", "", 1)
+
+	err := handler.ListWorkspaces(c)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response []WorkspaceListItemResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(response) != 2 {
+		t.Fatalf("Expected 2 workspaces, got %d", len(response))
+	}
+	if response[0].Name != "Personal" || response[1].Name != "Household" {
+		t.Errorf("Unexpected workspace names: %s, %s", response[0].Name, response[1].Name)
+	}
+	if response[0].Role != "owner" {
+		t.Errorf("Expected role 'owner', got %s", response[0].Role)
+	}
+}
+
+func TestListWorkspaces_MissingAuth0ID(t *testing.T) {
+	e := echo.New()
+	authService := service.NewAuthService(testutil.NewMockUserRepository(), testutil.NewMockWorkspaceRepository())
+	handler := NewAuthHandler(authService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	_ = handler.ListWorkspaces(c)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", rec.Code)
+	}
+}
@@ -25,22 +25,49 @@ func NewBudgetCategoryHandler(categoryService *service.BudgetCategoryService) *B
 
 // CreateBudgetCategoryRequest represents the create category request body
 type CreateBudgetCategoryRequest struct {
-	Name string `json:"name"`
+	Name            string  `json:"name"`
+	Description     *string `json:"description,omitempty"`
+	Color           *string `json:"color,omitempty"`
+	ParentID        *int32  `json:"parentId,omitempty"`
+	IsTaxDeductible bool    `json:"isTaxDeductible,omitempty"` // Default for new transactions in the category
 }
 
-// UpdateBudgetCategoryRequest represents the update category request body
+// UpdateBudgetCategoryRequest represents the update category request body.
+// Omitting description/color/parentId keeps the stored value; sending null (or an
+// empty description/color) clears it. Omitting isTaxDeductible keeps the category default.
 type UpdateBudgetCategoryRequest struct {
-	Name string `json:"name"`
+	Name            string           `json:"name"`
+	Description     Optional[string] `json:"description"`
+	Color           Optional[string] `json:"color"`
+	ParentID        Optional[int32]  `json:"parentId"`
+	IsTaxDeductible *bool            `json:"isTaxDeductible"`
 }
 
 // BudgetCategoryResponse represents a budget category in API responses
 type BudgetCategoryResponse struct {
-	ID          int32   `json:"id"`
-	WorkspaceID int32   `json:"workspaceId"`
-	Name        string  `json:"name"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   string  `json:"updatedAt"`
-	DeletedAt   *string `json:"deletedAt,omitempty"`
+	ID              int32   `json:"id"`
+	WorkspaceID     int32   `json:"workspaceId"`
+	Name            string  `json:"name"`
+	Description     *string `json:"description"`
+	Color           *string `json:"color"`
+	ParentID        *int32  `json:"parentId"`
+	IsTaxDeductible bool    `json:"isTaxDeductible"`
+	CreatedAt       string  `json:"createdAt"`
+	UpdatedAt       string  `json:"updatedAt"`
+	DeletedAt       *string `json:"deletedAt,omitempty"`
+}
+
+// BudgetCategoryUsageResponse represents a category with its usage in API responses
+type BudgetCategoryUsageResponse struct {
+	BudgetCategoryResponse
+	TransactionCount int64  `json:"transactionCount"`
+	TotalSpent       string `json:"totalSpent"`
+}
+
+// BudgetCategoryTreeResponse represents a top-level category with its subcategories
+type BudgetCategoryTreeResponse struct {
+	BudgetCategoryResponse
+	Children []BudgetCategoryResponse `json:"children"`
 }
 
 // CanDeleteResponse represents the can-delete check response
@@ -61,7 +88,13 @@ func (h *BudgetCategoryHandler) CreateCategory(c echo.Context) error {
 		return NewValidationError(c, "Invalid request body", nil)
 	}
 
-	category, err := h.categoryService.CreateCategory(workspaceID, req.Name)
+	category, err := h.categoryService.CreateCategory(workspaceID, service.BudgetCategoryInput{
+		Name:            req.Name,
+		Description:     req.Description,
+		Color:           req.Color,
+		ParentID:        req.ParentID,
+		IsTaxDeductible: &req.IsTaxDeductible,
+	})
 	if err != nil {
 		if errors.Is(err, domain.ErrNameRequired) {
 			return NewValidationError(c, "Category name is required", []ValidationError{
@@ -73,6 +106,21 @@ func (h *BudgetCategoryHandler) CreateCategory(c echo.Context) error {
 				{Field: "name", Message: "Name must be 100 characters or less"},
 			})
 		}
+		if errors.Is(err, domain.ErrInvalidColor) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "color", Message: "Color must be a hex string like #RRGGBB"},
+			})
+		}
+		if errors.Is(err, domain.ErrDescriptionTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "description", Message: "Description must be 500 characters or less"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidCategoryHierarchy) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "parentId", Message: "Parent must be an existing top-level category other than this one"},
+			})
+		}
 		if errors.Is(err, domain.ErrBudgetCategoryAlreadyExists) {
 			return NewConflictError(c, "A category with this name already exists")
 		}
@@ -86,12 +134,53 @@ func (h *BudgetCategoryHandler) CreateCategory(c echo.Context) error {
 }
 
 // GetCategories handles GET /api/v1/budget-categories
+// With ?tree=true, subcategories are nested under their parents.
+// With ?withUsage=true, each category includes its all-time transaction count and spend.
 func (h *BudgetCategoryHandler) GetCategories(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
 	if workspaceID == 0 {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
+	if c.QueryParam("withUsage") == "true" {
+		usages, err := h.categoryService.ListCategoriesWithUsage(workspaceID)
+		if err != nil {
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get budget category usage")
+			return NewInternalError(c, "Failed to get categories")
+		}
+
+		response := make([]BudgetCategoryUsageResponse, len(usages))
+		for i, usage := range usages {
+			response[i] = BudgetCategoryUsageResponse{
+				BudgetCategoryResponse: toBudgetCategoryResponse(usage.Category),
+				TransactionCount:       usage.TransactionCount,
+				TotalSpent:             FormatAmount(usage.TotalSpent, domain.DefaultCurrency),
+			}
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+
+	if c.QueryParam("tree") == "true" {
+		tree, err := h.categoryService.GetCategoryTree(workspaceID)
+		if err != nil {
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get budget category tree")
+			return NewInternalError(c, "Failed to get categories")
+		}
+
+		response := make([]BudgetCategoryTreeResponse, len(tree))
+		for i, node := range tree {
+			children := make([]BudgetCategoryResponse, len(node.Children))
+			for j, child := range node.Children {
+				children[j] = toBudgetCategoryResponse(child)
+			}
+			response[i] = BudgetCategoryTreeResponse{
+				BudgetCategoryResponse: toBudgetCategoryResponse(node.Category),
+				Children:               children,
+			}
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+
 	categories, err := h.categoryService.GetCategories(workspaceID)
 	if err != nil {
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get budget categories")
@@ -123,7 +212,16 @@ func (h *BudgetCategoryHandler) UpdateCategory(c echo.Context) error {
 		return NewValidationError(c, "Invalid request body", nil)
 	}
 
-	category, err := h.categoryService.UpdateCategory(workspaceID, int32(id), req.Name)
+	category, err := h.categoryService.UpdateCategory(workspaceID, int32(id), service.BudgetCategoryInput{
+		Name:            req.Name,
+		Description:     req.Description.Value,
+		Color:           req.Color.Value,
+		ParentID:        req.ParentID.Value,
+		IsTaxDeductible: req.IsTaxDeductible,
+		KeepDescription: !req.Description.Set,
+		KeepColor:       !req.Color.Set,
+		KeepParent:      !req.ParentID.Set,
+	})
 	if err != nil {
 		if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
 			return NewNotFoundError(c, "Category not found")
@@ -138,6 +236,21 @@ func (h *BudgetCategoryHandler) UpdateCategory(c echo.Context) error {
 				{Field: "name", Message: "Name must be 100 characters or less"},
 			})
 		}
+		if errors.Is(err, domain.ErrInvalidColor) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "color", Message: "Color must be a hex string like #RRGGBB"},
+			})
+		}
+		if errors.Is(err, domain.ErrDescriptionTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "description", Message: "Description must be 500 characters or less"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidCategoryHierarchy) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "parentId", Message: "Parent must be an existing top-level category other than this one"},
+			})
+		}
 		if errors.Is(err, domain.ErrBudgetCategoryAlreadyExists) {
 			return NewConflictError(c, "A category with this name already exists")
 		}
@@ -200,14 +313,65 @@ func (h *BudgetCategoryHandler) CanDeleteCategory(c echo.Context) error {
 	})
 }
 
+// GetCategoryTransactions handles GET /api/v1/budget-categories/:id/transactions?start=YYYY-MM&end=YYYY-MM
+// Both months default to the current month
+func (h *BudgetCategoryHandler) GetCategoryTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid category ID", nil)
+	}
+
+	currentMonth := time.Now().Format("2006-01")
+	start := c.QueryParam("start")
+	if start == "" {
+		start = currentMonth
+	}
+	end := c.QueryParam("end")
+	if end == "" {
+		end = currentMonth
+	}
+
+	transactions, err := h.categoryService.GetCategoryTransactions(workspaceID, int32(id), start, end)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMonthFormat) {
+			return NewValidationError(c, "Invalid month format. Use YYYY-MM", nil)
+		}
+		if errors.Is(err, domain.ErrInvalidDateRange) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "end", Code: ValidationCodeOutOfRange, Message: "End month must not be before start month"},
+			})
+		}
+		if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
+			return NewNotFoundError(c, "Category not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("category_id", id).Msg("Failed to get category transactions")
+		return NewInternalError(c, "Failed to get category transactions")
+	}
+
+	response := make([]TransactionResponse, len(transactions))
+	for i, tx := range transactions {
+		response[i] = toTransactionResponse(tx)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
 // Helper function to convert domain.BudgetCategory to BudgetCategoryResponse
 func toBudgetCategoryResponse(category *domain.BudgetCategory) BudgetCategoryResponse {
 	resp := BudgetCategoryResponse{
-		ID:          category.ID,
-		WorkspaceID: category.WorkspaceID,
-		Name:        category.Name,
-		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   category.UpdatedAt.Format(time.RFC3339),
+		ID:              category.ID,
+		WorkspaceID:     category.WorkspaceID,
+		Name:            category.Name,
+		Description:     category.Description,
+		Color:           category.Color,
+		ParentID:        category.ParentID,
+		IsTaxDeductible: category.IsTaxDeductible,
+		CreatedAt:       category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       category.UpdatedAt.Format(time.RFC3339),
 	}
 	if category.DeletedAt != nil {
 		deletedAt := category.DeletedAt.Format(time.RFC3339)
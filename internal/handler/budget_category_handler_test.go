@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/labstack/echo/v4"
+)
+
+// newBudgetCategoryUpdateContext builds a PUT /budget-categories/2 request context with body
+func newBudgetCategoryUpdateContext(e *echo.Echo, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/budget-categories/2", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+	return c, rec
+}
+
+// addSubcategoryWithDetails stores category 2 under parent 1 with a description and color
+func addSubcategoryWithDetails(categoryRepo *testutil.MockBudgetCategoryRepository) {
+	parentID := int32(1)
+	description := "Weekly shop"
+	color := "#00FF00"
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 1, WorkspaceID: 1, Name: "Food"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          2,
+		WorkspaceID: 1,
+		Name:        "Groceries",
+		Description: &description,
+		Color:       &color,
+		ParentID:    &parentID,
+	})
+}
+
+func TestUpdateCategory_RenameOnlyKeepsOtherFields(t *testing.T) {
+	e := echo.New()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	handler := NewBudgetCategoryHandler(service.NewBudgetCategoryService(categoryRepo))
+	addSubcategoryWithDetails(categoryRepo)
+
+	c, rec := newBudgetCategoryUpdateContext(e, `{"name": "Supermarket"}`)
+	if err := handler.UpdateCategory(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response BudgetCategoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Name != "Supermarket" {
+		t.Errorf("Expected name 'Supermarket', got %s", response.Name)
+	}
+	if response.Description == nil || *response.Description != "Weekly shop" {
+		t.Errorf("Expected description to be kept, got %v", response.Description)
+	}
+	if response.Color == nil || *response.Color != "#00FF00" {
+		t.Errorf("Expected color to be kept, got %v", response.Color)
+	}
+	if response.ParentID == nil || *response.ParentID != 1 {
+		t.Errorf("Expected parent to be kept, got %v", response.ParentID)
+	}
+}
+
+func TestUpdateCategory_NullAndEmptyClearFields(t *testing.T) {
+	e := echo.New()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	handler := NewBudgetCategoryHandler(service.NewBudgetCategoryService(categoryRepo))
+	addSubcategoryWithDetails(categoryRepo)
+
+	c, rec := newBudgetCategoryUpdateContext(e, `{"name": "Groceries", "description": null, "color": "", "parentId": null}`)
+	if err := handler.UpdateCategory(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response BudgetCategoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Description != nil {
+		t.Errorf("Expected null description to clear it, got %q", *response.Description)
+	}
+	if response.Color != nil {
+		t.Errorf("Expected empty color to clear it, got %q", *response.Color)
+	}
+	if response.ParentID != nil {
+		t.Errorf("Expected null parentId to clear it, got %d", *response.ParentID)
+	}
+}
@@ -88,6 +88,7 @@ type MonthlyBudgetSummaryResponse struct {
 // @Security BearerAuth
 // @Param year path int true "Year"
 // @Param month path int true "Month (1-12)"
+// @Param rollup query bool false "Add subcategory spending to parent categories"
 // @Success 200 {object} MonthlyBudgetSummaryResponse
 // @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
@@ -109,7 +110,12 @@ func (h *BudgetHandler) GetAllocations(c echo.Context) error {
 		return NewValidationError(c, "Invalid month", nil)
 	}
 
-	result, err := h.allocationService.GetMonthlyProgress(workspaceID, year, month)
+	var result *domain.MonthlyBudgetSummary
+	if c.QueryParam("rollup") == "true" {
+		result, err = h.allocationService.GetMonthlyProgressRolledUp(workspaceID, year, month)
+	} else {
+		result, err = h.allocationService.GetMonthlyProgress(workspaceID, year, month)
+	}
 	if err != nil {
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to get budget progress")
 		return NewInternalError(c, "Failed to get budget progress")
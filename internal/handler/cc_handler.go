@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -135,6 +136,68 @@ func (h *CCHandler) CreateCCPayment(c echo.Context) error {
 	return c.JSON(http.StatusCreated, response)
 }
 
+// CCStateTotalsEntry is the count and total for one CC state
+type CCStateTotalsEntry struct {
+	Count int32  `json:"count"`
+	Total string `json:"total"`
+}
+
+// CCStateBreakdownResponse is the JSON response for a CC account's per-state totals
+type CCStateBreakdownResponse struct {
+	AccountID int32              `json:"accountId"`
+	Pending   CCStateTotalsEntry `json:"pending"`
+	Billed    CCStateTotalsEntry `json:"billed"`
+	Settled   CCStateTotalsEntry `json:"settled"`
+}
+
+// GetCCStateBreakdown returns transaction counts and totals per CC state for an account
+// @Summary Get CC state breakdown
+// @Description Returns counts and summed amounts of pending, billed and settled purchases on a credit card account
+// @Tags cc
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Account ID"
+// @Success 200 {object} CCStateBreakdownResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /accounts/{id}/cc-state-breakdown [get]
+func (h *CCHandler) GetCCStateBreakdown(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	breakdown, err := h.ccService.GetCCStateBreakdown(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		if errors.Is(err, domain.ErrNotCreditCard) {
+			return NewValidationError(c, "Account must be a credit card", nil)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to get CC state breakdown")
+		return NewInternalError(c, "Failed to get CC state breakdown")
+	}
+
+	return c.JSON(http.StatusOK, CCStateBreakdownResponse{
+		AccountID: breakdown.AccountID,
+		Pending:   toCCStateTotalsEntry(breakdown.Pending),
+		Billed:    toCCStateTotalsEntry(breakdown.Billed),
+		Settled:   toCCStateTotalsEntry(breakdown.Settled),
+	})
+}
+
+func toCCStateTotalsEntry(totals domain.CCStateTotals) CCStateTotalsEntry {
+	return CCStateTotalsEntry{Count: totals.Count, Total: FormatAmount(totals.Total, domain.DefaultCurrency)}
+}
+
 // convertDomainTransaction converts a domain Transaction to a response entry
 func convertDomainTransaction(t *domain.Transaction) *TransactionResponseEntry {
 	if t == nil {
@@ -152,3 +215,140 @@ func convertDomainTransaction(t *domain.Transaction) *TransactionResponseEntry {
 		Notes:           t.Notes,
 	}
 }
+
+// CloseBillingCycleRequest is the JSON request for closing a CC billing cycle
+type CloseBillingCycleRequest struct {
+	CutoffDate string `json:"cutoffDate"` // YYYY-MM-DD, defaults to today
+}
+
+// BillingCycleResponse is the JSON response for a closed CC billing cycle
+type BillingCycleResponse struct {
+	AccountID    int32                 `json:"accountId"`
+	CutoffDate   string                `json:"cutoffDate"`
+	BilledCount  int                   `json:"billedCount"`
+	TotalBilled  string                `json:"totalBilled"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+// CloseBillingCycle bills all pending purchases on a CC account up to a cutoff date
+// @Summary Close CC billing cycle
+// @Description Transitions pending purchases dated on/before the cutoff to billed, as when a statement closes
+// @Tags cc
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Account ID"
+// @Param request body CloseBillingCycleRequest false "Cutoff date"
+// @Success 200 {object} BillingCycleResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /accounts/{id}/close-cycle [post]
+func (h *CCHandler) CloseBillingCycle(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid account ID", nil)
+	}
+
+	var req CloseBillingCycleRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	cutoffDate := time.Now()
+	if req.CutoffDate != "" {
+		cutoffDate, err = time.Parse("2006-01-02", req.CutoffDate)
+		if err != nil {
+			return NewValidationError(c, "Invalid cutoff date", []ValidationError{
+				{Field: "cutoffDate", Message: "Must be in YYYY-MM-DD format"},
+			})
+		}
+	}
+
+	result, err := h.ccService.CloseBillingCycle(workspaceID, int32(id), cutoffDate)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewNotFoundError(c, "Account not found")
+		}
+		if errors.Is(err, domain.ErrNotCreditCard) {
+			return NewValidationError(c, "Account must be a credit card", nil)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("account_id", id).Msg("Failed to close CC billing cycle")
+		return NewInternalError(c, "Failed to close billing cycle")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("account_id", id).Int("billed_count", result.BilledCount).Msg("CC billing cycle closed")
+
+	transactions := make([]TransactionResponse, len(result.Transactions))
+	for i, tx := range result.Transactions {
+		transactions[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, BillingCycleResponse{
+		AccountID:    result.AccountID,
+		CutoffDate:   result.CutoffDate.Format("2006-01-02"),
+		BilledCount:  result.BilledCount,
+		TotalBilled:  FormatAmount(result.TotalBilled, domain.DefaultCurrency),
+		Transactions: transactions,
+	})
+}
+
+// SettleDeferredResponse is the JSON response for a bulk deferred settlement
+type SettleDeferredResponse struct {
+	Month        string                `json:"month"`
+	SettledCount int                   `json:"settledCount"`
+	TotalSettled string                `json:"totalSettled"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+// SettleDeferredForMonth settles all billed deferred CC transactions due by a month
+// @Summary Settle deferred CC transactions for a month
+// @Description Settles every billed deferred CC transaction due by the month, including loan installments
+// @Tags cc
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param month query string true "Month (YYYY-MM)"
+// @Success 200 {object} SettleDeferredResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /cc/settle-deferred [post]
+func (h *CCHandler) SettleDeferredForMonth(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	month := c.QueryParam("month")
+	result, err := h.ccService.SettleDeferredForMonth(workspaceID, month)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSettlementMonth) {
+			return NewValidationError(c, "Invalid month format", []ValidationError{
+				{Field: "month", Message: "Must be in YYYY-MM format"},
+			})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Str("month", month).Msg("Failed to settle deferred CC transactions")
+		return NewInternalError(c, "Failed to settle deferred transactions")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Str("month", result.Month).Int("settled_count", result.SettledCount).Msg("Deferred CC transactions settled")
+
+	transactions := make([]TransactionResponse, len(result.Transactions))
+	for i, tx := range result.Transactions {
+		transactions[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, SettleDeferredResponse{
+		Month:        result.Month,
+		SettledCount: result.SettledCount,
+		TotalSettled: FormatAmount(result.TotalSettled, domain.DefaultCurrency),
+		Transactions: transactions,
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
 	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
 )
 
 func TestCreateCCPayment_Success(t *testing.T) {
@@ -202,3 +204,104 @@ func TestCreateCCPayment_SourceCannotBeCC(t *testing.T) {
 		t.Errorf("Expected status 400, got %d", rec.Code)
 	}
 }
+
+func TestGetCCStateBreakdown_CountsAndTotalsPerState(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	ccService := service.NewCCService(transactionRepo, accountRepo)
+	handler := NewCCHandler(ccService)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Test CC",
+		Template:    domain.TemplateCreditCard,
+		AccountType: domain.AccountTypeLiability,
+	})
+
+	billedAt := time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC)
+	expense := domain.TransactionTypeExpense
+	fixtures := []*domain.Transaction{
+		{ID: 1, Amount: decimal.NewFromInt(50), Type: expense},
+		{ID: 2, Amount: decimal.NewFromInt(25), Type: expense},
+		{ID: 3, Amount: decimal.NewFromInt(120), Type: expense, BilledAt: &billedAt},
+		{ID: 4, Amount: decimal.NewFromInt(80), Type: expense, BilledAt: &billedAt, IsPaid: true},
+		{ID: 5, Amount: decimal.NewFromInt(40), Type: expense, IsPaid: true},
+		{ID: 6, Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeIncome, IsPaid: true, IsCCPayment: true},
+	}
+	for _, tx := range fixtures {
+		tx.WorkspaceID = 1
+		tx.AccountID = 1
+		transactionRepo.AddTransaction(tx)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/1/cc-state-breakdown", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetCCStateBreakdown(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response CCStateBreakdownResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	expected := map[string]struct {
+		got   CCStateTotalsEntry
+		count int32
+		total string
+	}{
+		"pending": {response.Pending, 2, "75.00"},
+		"billed":  {response.Billed, 1, "120.00"},
+		"settled": {response.Settled, 2, "120.00"},
+	}
+	for state, want := range expected {
+		if want.got.Count != want.count {
+			t.Errorf("Expected %s count %d, got %d", state, want.count, want.got.Count)
+		}
+		if want.got.Total != want.total {
+			t.Errorf("Expected %s total %s, got %s", state, want.total, want.got.Total)
+		}
+	}
+}
+
+func TestGetCCStateBreakdown_NotCreditCard(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	ccService := service.NewCCService(transactionRepo, accountRepo)
+	handler := NewCCHandler(ccService)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          2,
+		WorkspaceID: 1,
+		Name:        "Checking",
+		Template:    domain.TemplateBank,
+		AccountType: domain.AccountTypeAsset,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/2/cc-state-breakdown", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetCCStateBreakdown(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -55,6 +59,7 @@ type DashboardSummaryResponse struct {
 // @Security BearerAuth
 // @Param year query int false "Year for historical data"
 // @Param month query int false "Month for historical data (1-12)"
+// @Param accountIds query string false "Comma-separated account IDs to scope balances and month totals to"
 // @Success 200 {object} DashboardSummaryResponse
 // @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
@@ -66,34 +71,21 @@ func (h *DashboardHandler) GetSummary(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
-	// Parse optional year/month params (default to current)
-	now := time.Now()
-	year := now.Year()
-	month := int(now.Month())
-
-	if yearStr := c.QueryParam("year"); yearStr != "" {
-		parsedYear, err := strconv.Atoi(yearStr)
-		if err != nil {
-			return NewValidationError(c, "Invalid year format", []ValidationError{{Field: "year", Message: "Must be a valid integer"}})
-		}
-		if parsedYear < 2000 || parsedYear > 2100 {
-			return NewValidationError(c, "Year must be between 2000 and 2100", []ValidationError{{Field: "year", Message: "Must be between 2000 and 2100"}})
-		}
-		year = parsedYear
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
 	}
-	if monthStr := c.QueryParam("month"); monthStr != "" {
-		parsedMonth, err := strconv.Atoi(monthStr)
-		if err != nil {
-			return NewValidationError(c, "Invalid month format", []ValidationError{{Field: "month", Message: "Must be a valid integer"}})
-		}
-		if parsedMonth < 1 || parsedMonth > 12 {
-			return NewValidationError(c, "Month must be between 1 and 12", []ValidationError{{Field: "month", Message: "Must be between 1 and 12"}})
-		}
-		month = parsedMonth
+
+	accountIDs, err := parseAccountIDsQuery(c)
+	if err != nil {
+		return NewRequestValidationError(c, err)
 	}
 
-	summary, err := h.dashboardService.GetSummaryForMonth(workspaceID, year, month)
+	summary, err := h.dashboardService.GetSummaryForMonth(workspaceID, year, month, accountIDs)
 	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return accountScopeNotFound(c)
+		}
 		// Handle projection limit exceeded error
 		if errors.Is(err, service.ErrProjectionLimitExceeded) {
 			return NewValidationError(c, "Cannot project more than 12 months ahead", []ValidationError{
@@ -107,9 +99,9 @@ func (h *DashboardHandler) GetSummary(c echo.Context) error {
 	var projection *ProjectionResponse
 	if summary.Projection != nil {
 		projection = &ProjectionResponse{
-			RecurringIncome:   summary.Projection.RecurringIncome.StringFixed(2),
-			RecurringExpenses: summary.Projection.RecurringExpenses.StringFixed(2),
-			LoanPayments:      summary.Projection.LoanPayments.StringFixed(2),
+			RecurringIncome:   FormatAmount(summary.Projection.RecurringIncome, domain.DefaultCurrency),
+			RecurringExpenses: FormatAmount(summary.Projection.RecurringExpenses, domain.DefaultCurrency),
+			LoanPayments:      FormatAmount(summary.Projection.LoanPayments, domain.DefaultCurrency),
 			Note:              summary.Projection.Note,
 		}
 	}
@@ -123,16 +115,74 @@ func (h *DashboardHandler) GetSummary(c echo.Context) error {
 	return c.JSON(http.StatusOK, DashboardSummaryResponse{
 		IsProjection:          summary.IsProjection,
 		ProjectionLimitMonths: projectionLimit,
-		TotalBalance:          summary.TotalBalance.StringFixed(2),
-		InHandBalance:         summary.InHandBalance.StringFixed(2),
-		DisposableIncome:      summary.DisposableIncome.StringFixed(2),
+		TotalBalance:          FormatAmount(summary.TotalBalance, domain.DefaultCurrency),
+		InHandBalance:         FormatAmount(summary.InHandBalance, domain.DefaultCurrency),
+		DisposableIncome:      FormatAmount(summary.DisposableIncome, domain.DefaultCurrency),
 		DaysRemaining:         summary.DaysRemaining,
-		DailyBudget:           summary.DailyBudget.StringFixed(2),
+		DailyBudget:           FormatAmount(summary.DailyBudget, domain.DefaultCurrency),
 		Month:                 toMonthResponse(summary.Month),
 		Projection:            projection,
 	})
 }
 
+// parseYearMonthQuery reads the optional year/month query params, defaulting to the current month
+// Returns a non-nil response when a param is invalid
+func parseYearMonthQuery(c echo.Context) (int, int, error) {
+	now := time.Now()
+	year := now.Year()
+	month := int(now.Month())
+
+	if yearStr := c.QueryParam("year"); yearStr != "" {
+		parsedYear, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return 0, 0, NewValidationError(c, "Invalid year format", []ValidationError{{Field: "year", Message: "Must be a valid integer"}})
+		}
+		if parsedYear < 2000 || parsedYear > 2100 {
+			return 0, 0, NewValidationError(c, "Year must be between 2000 and 2100", []ValidationError{{Field: "year", Message: "Must be between 2000 and 2100"}})
+		}
+		year = parsedYear
+	}
+	if monthStr := c.QueryParam("month"); monthStr != "" {
+		parsedMonth, err := strconv.Atoi(monthStr)
+		if err != nil {
+			return 0, 0, NewValidationError(c, "Invalid month format", []ValidationError{{Field: "month", Message: "Must be a valid integer"}})
+		}
+		if parsedMonth < 1 || parsedMonth > 12 {
+			return 0, 0, NewValidationError(c, "Month must be between 1 and 12", []ValidationError{{Field: "month", Message: "Must be between 1 and 12"}})
+		}
+		month = parsedMonth
+	}
+
+	return year, month, nil
+}
+
+// parseAccountIDsQuery parses the optional comma-separated accountIds query parameter
+func parseAccountIDsQuery(c echo.Context) ([]int32, error) {
+	raw := c.QueryParam("accountIds")
+	if raw == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	ids := make([]int32, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+		if err != nil || id <= 0 {
+			return nil, &requestValidationError{Detail: "Invalid accountIds", Errors: []ValidationError{
+				{Field: "accountIds", Code: ValidationCodeInvalidFormat, Message: "Must be a comma-separated list of account IDs"},
+			}}
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+// accountScopeNotFound reports an accountIds entry that isn't one of the workspace's accounts
+func accountScopeNotFound(c echo.Context) error {
+	return NewValidationError(c, "Validation failed", []ValidationError{
+		{Field: "accountIds", Code: ValidationCodeNotFound, Message: "One or more accounts not found"},
+	})
+}
+
 // GetFutureSpending godoc
 // @Summary Get future spending data
 // @Description Get aggregated spending data for future months including projections
@@ -141,6 +191,7 @@ func (h *DashboardHandler) GetSummary(c echo.Context) error {
 // @Produce json
 // @Security BearerAuth
 // @Param months query int false "Number of months to include (1-24, default 12)"
+// @Param accountIds query string false "Comma-separated account IDs to scope the aggregation to"
 // @Success 200 {object} domain.FutureSpendingData
 // @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
@@ -165,11 +216,548 @@ func (h *DashboardHandler) GetFutureSpending(c echo.Context) error {
 		months = parsedMonths
 	}
 
-	data, err := h.dashboardService.GetFutureSpending(workspaceID, months)
+	accountIDs, err := parseAccountIDsQuery(c)
 	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	data, err := h.dashboardService.GetFutureSpending(workspaceID, months, accountIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return accountScopeNotFound(c)
+		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("months", months).Msg("Failed to get future spending data")
 		return NewInternalError(c, "Failed to get future spending data")
 	}
 
 	return c.JSON(http.StatusOK, data)
 }
+
+// MerchantSpendingResponse represents a merchant's spending total in API responses
+type MerchantSpendingResponse struct {
+	Merchant         string `json:"merchant"`
+	Total            string `json:"total"`
+	TransactionCount int    `json:"transactionCount"`
+}
+
+// MerchantSummaryResponse represents the merchant summary API response
+type MerchantSummaryResponse struct {
+	Month     string                     `json:"month"`
+	Merchants []MerchantSpendingResponse `json:"merchants"`
+}
+
+// GetMerchantSummary godoc
+// @Summary Get spending by merchant
+// @Description Get a month's expenses grouped by merchant, falling back to transaction name
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month in YYYY-MM format (default current month)"
+// @Success 200 {object} MerchantSummaryResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/merchants [get]
+func (h *DashboardHandler) GetMerchantSummary(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	monthStart := time.Now()
+	if monthStr := c.QueryParam("month"); monthStr != "" {
+		parsed, err := time.Parse("2006-01", monthStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid month format", []ValidationError{{Field: "month", Message: "Must be in YYYY-MM format"}})
+		}
+		monthStart = parsed
+	}
+
+	summary, err := h.dashboardService.GetMerchantSummary(workspaceID, monthStart.Year(), int(monthStart.Month()))
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get merchant summary")
+		return NewInternalError(c, "Failed to get merchant summary")
+	}
+
+	merchants := make([]MerchantSpendingResponse, len(summary.Merchants))
+	for i, m := range summary.Merchants {
+		merchants[i] = MerchantSpendingResponse{
+			Merchant:         m.Merchant,
+			Total:            FormatAmount(m.Total, domain.DefaultCurrency),
+			TransactionCount: m.TransactionCount,
+		}
+	}
+
+	return c.JSON(http.StatusOK, MerchantSummaryResponse{
+		Month:     summary.Month,
+		Merchants: merchants,
+	})
+}
+
+// PayeeSpendingResponse represents a payee's spending total in API responses
+type PayeeSpendingResponse struct {
+	Payee            string `json:"payee"`
+	Total            string `json:"total"`
+	TransactionCount int    `json:"transactionCount"`
+}
+
+// PayeeSummaryResponse represents the payee summary API response
+type PayeeSummaryResponse struct {
+	Month  string                  `json:"month"`
+	Payees []PayeeSpendingResponse `json:"payees"`
+}
+
+// GetPayeeSummary godoc
+// @Summary Get spending by payee
+// @Description Get a month's expenses grouped by payee; transactions without a payee are omitted
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month in YYYY-MM format (default current month)"
+// @Success 200 {object} PayeeSummaryResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/payees [get]
+func (h *DashboardHandler) GetPayeeSummary(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	monthStart := time.Now()
+	if monthStr := c.QueryParam("month"); monthStr != "" {
+		parsed, err := time.Parse("2006-01", monthStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid month format", []ValidationError{{Field: "month", Message: "Must be in YYYY-MM format"}})
+		}
+		monthStart = parsed
+	}
+
+	summary, err := h.dashboardService.GetPayeeSummary(workspaceID, monthStart.Year(), int(monthStart.Month()))
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get payee summary")
+		return NewInternalError(c, "Failed to get payee summary")
+	}
+
+	payees := make([]PayeeSpendingResponse, len(summary.Payees))
+	for i, p := range summary.Payees {
+		payees[i] = PayeeSpendingResponse{
+			Payee:            p.Payee,
+			Total:            FormatAmount(p.Total, domain.DefaultCurrency),
+			TransactionCount: p.TransactionCount,
+		}
+	}
+
+	return c.JSON(http.StatusOK, PayeeSummaryResponse{
+		Month:  summary.Month,
+		Payees: payees,
+	})
+}
+
+// SpendableCashResponse represents the spendable cash API response
+type SpendableCashResponse struct {
+	SpendableCash string `json:"spendableCash"`
+}
+
+// GetSpendableCash godoc
+// @Summary Get spendable cash
+// @Description Get the combined balance of accounts whose template counts as spendable (bank, cash and e-wallet by default)
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param accountIds query string false "Comma-separated account IDs to scope the sum to"
+// @Success 200 {object} SpendableCashResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/spendable [get]
+func (h *DashboardHandler) GetSpendableCash(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	accountIDs, err := parseAccountIDsQuery(c)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	spendable, err := h.dashboardService.GetSpendableCash(workspaceID, accountIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return accountScopeNotFound(c)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get spendable cash")
+		return NewInternalError(c, "Failed to get spendable cash")
+	}
+
+	return c.JSON(http.StatusOK, SpendableCashResponse{
+		SpendableCash: FormatAmount(spendable, domain.DefaultCurrency),
+	})
+}
+
+// DefaultLargestTransactionsLimit is the number of entries returned when no limit is given
+const DefaultLargestTransactionsLimit = 5
+
+// GetLargestTransactions godoc
+// @Summary Get largest transactions
+// @Description Get a month's largest expenses by amount, excluding income and transfers
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (default current year)"
+// @Param month query int false "Month 1-12 (default current month)"
+// @Param limit query int false "Number of entries, clamped to 1-20" default(5)
+// @Success 200 {array} TransactionResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/largest [get]
+func (h *DashboardHandler) GetLargestTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
+	}
+
+	limit := DefaultLargestTransactionsLimit
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid limit format", []ValidationError{{Field: "limit", Message: "Must be a valid integer"}})
+		}
+		limit = parsed
+	}
+
+	transactions, err := h.dashboardService.GetLargestTransactions(workspaceID, year, month, limit)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to get largest transactions")
+		return NewInternalError(c, "Failed to get largest transactions")
+	}
+
+	response := make([]TransactionResponse, len(transactions))
+	for i, txn := range transactions {
+		response[i] = toTransactionResponse(txn)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ObligationsBreakdownResponse represents the per-source split of monthly obligations
+type ObligationsBreakdownResponse struct {
+	LoanCommitments   string `json:"loanCommitments"`
+	RecurringExpenses string `json:"recurringExpenses"`
+	CCStatements      string `json:"ccStatements"`
+}
+
+// ObligationsResponse represents the monthly obligations API response
+type ObligationsResponse struct {
+	Year      int                          `json:"year"`
+	Month     int                          `json:"month"`
+	Total     string                       `json:"total"`
+	Breakdown ObligationsBreakdownResponse `json:"breakdown"`
+}
+
+// GetObligations godoc
+// @Summary Get monthly obligations
+// @Description Get everything still owed for a month (loans, recurring expenses, CC statements) as one total
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (default current year)"
+// @Param month query int false "Month 1-12 (default current month)"
+// @Success 200 {object} ObligationsResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/obligations [get]
+func (h *DashboardHandler) GetObligations(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
+	}
+
+	obligations, err := h.dashboardService.GetMonthlyObligationsTotal(workspaceID, year, month)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to get monthly obligations")
+		return NewInternalError(c, "Failed to get monthly obligations")
+	}
+
+	return c.JSON(http.StatusOK, ObligationsResponse{
+		Year:  obligations.Year,
+		Month: obligations.Month,
+		Total: FormatAmount(obligations.Total, domain.DefaultCurrency),
+		Breakdown: ObligationsBreakdownResponse{
+			LoanCommitments:   FormatAmount(obligations.Breakdown.LoanCommitments, domain.DefaultCurrency),
+			RecurringExpenses: FormatAmount(obligations.Breakdown.RecurringExpenses, domain.DefaultCurrency),
+			CCStatements:      FormatAmount(obligations.Breakdown.CCStatements, domain.DefaultCurrency),
+		},
+	})
+}
+
+// SpendingVelocityResponse represents the spending velocity API response
+type SpendingVelocityResponse struct {
+	Year              int    `json:"year"`
+	Month             int    `json:"month"`
+	DaysElapsed       int    `json:"daysElapsed"`
+	DaysInMonth       int    `json:"daysInMonth"`
+	SpentSoFar        string `json:"spentSoFar"`
+	AverageDailySpend string `json:"averageDailySpend"`
+	ProjectedTotal    string `json:"projectedTotal"`
+}
+
+// GetSpendingVelocity godoc
+// @Summary Get spending velocity
+// @Description Get the month's average daily spend so far and the projected month-end total at that run rate
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (default current year)"
+// @Param month query int false "Month 1-12 (default current month)"
+// @Param tz query string false "IANA timezone used to count elapsed days (default UTC)"
+// @Success 200 {object} SpendingVelocityResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/velocity [get]
+func (h *DashboardHandler) GetSpendingVelocity(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
+	}
+
+	// Count elapsed days in the workspace timezone
+	tz, err := parseTimezoneQuery(c)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	velocity, err := h.dashboardService.GetSpendingVelocityAt(workspaceID, year, month, time.Now().In(tz))
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to get spending velocity")
+		return NewInternalError(c, "Failed to get spending velocity")
+	}
+
+	return c.JSON(http.StatusOK, SpendingVelocityResponse{
+		Year:              velocity.Year,
+		Month:             velocity.Month,
+		DaysElapsed:       velocity.DaysElapsed,
+		DaysInMonth:       velocity.DaysInMonth,
+		SpentSoFar:        FormatAmount(velocity.SpentSoFar, domain.DefaultCurrency),
+		AverageDailySpend: FormatAmount(velocity.AverageDailySpend, domain.DefaultCurrency),
+		ProjectedTotal:    FormatAmount(velocity.ProjectedTotal, domain.DefaultCurrency),
+	})
+}
+
+// DebtToIncomeResponse represents the debt-to-income API response
+// Ratio is null and NoIncome is set when the month has no income to divide by
+type DebtToIncomeResponse struct {
+	Year     int     `json:"year"`
+	Month    int     `json:"month"`
+	Ratio    *string `json:"ratio"` // Obligations as a percentage of income, e.g. "35.50"
+	NoIncome bool    `json:"noIncome"`
+}
+
+// GetDebtToIncome godoc
+// @Summary Get debt-to-income ratio
+// @Description Get the month's loan and recurring obligations as a percentage of its income
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (default current year)"
+// @Param month query int false "Month 1-12 (default current month)"
+// @Success 200 {object} DebtToIncomeResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/dti [get]
+func (h *DashboardHandler) GetDebtToIncome(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
+	}
+
+	response := DebtToIncomeResponse{Year: year, Month: month}
+	ratio, err := h.dashboardService.ComputeDebtToIncome(workspaceID, year, month)
+	if err != nil {
+		if !errors.Is(err, service.ErrNoIncome) {
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to compute debt-to-income ratio")
+			return NewInternalError(c, "Failed to compute debt-to-income ratio")
+		}
+		response.NoIncome = true
+		return c.JSON(http.StatusOK, response)
+	}
+
+	formatted := ratio.StringFixed(2)
+	response.Ratio = &formatted
+	return c.JSON(http.StatusOK, response)
+}
+
+// SavingsRateResponse represents the savings rate API response
+// Rate is null and NoIncome is set when the month has no income to divide by
+type SavingsRateResponse struct {
+	Year     int     `json:"year"`
+	Month    int     `json:"month"`
+	Rate     *string `json:"rate"` // Income left after expenses as a percentage, e.g. "20.00"; negative when overspent
+	NoIncome bool    `json:"noIncome"`
+}
+
+// GetSavingsRate godoc
+// @Summary Get savings rate
+// @Description Get the share of the month's income left after expenses, excluding transfers
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (default current year)"
+// @Param month query int false "Month 1-12 (default current month)"
+// @Success 200 {object} SavingsRateResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /dashboard/savings-rate [get]
+func (h *DashboardHandler) GetSavingsRate(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, resp := parseYearMonthQuery(c)
+	if resp != nil {
+		return resp
+	}
+
+	response := SavingsRateResponse{Year: year, Month: month}
+	rate, err := h.dashboardService.ComputeSavingsRate(workspaceID, year, month)
+	if err != nil {
+		if !errors.Is(err, service.ErrNoIncome) {
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to compute savings rate")
+			return NewInternalError(c, "Failed to compute savings rate")
+		}
+		response.NoIncome = true
+		return c.JSON(http.StatusOK, response)
+	}
+
+	formatted := rate.StringFixed(2)
+	response.Rate = &formatted
+	return c.JSON(http.StatusOK, response)
+}
+
+// TaxCategoryResponse represents one category's deductible total in the tax report
+type TaxCategoryResponse struct {
+	CategoryID       *int32 `json:"categoryId"`
+	CategoryName     string `json:"categoryName"`
+	Total            string `json:"total"`
+	TransactionCount int    `json:"transactionCount"`
+}
+
+// TaxReportResponse represents the annual tax report API response
+type TaxReportResponse struct {
+	Year             int                   `json:"year"`
+	Total            string                `json:"total"`
+	TransactionCount int                   `json:"transactionCount"`
+	Categories       []TaxCategoryResponse `json:"categories"`
+}
+
+// GetTaxReport godoc
+// @Summary Get annual tax report
+// @Description Get the year's tax-deductible transactions aggregated by category, as JSON or CSV
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param year query int false "Calendar year (default current year)"
+// @Param format query string false "Response format: json (default) or csv"
+// @Success 200 {object} TaxReportResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /reports/tax [get]
+func (h *DashboardHandler) GetTaxReport(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, _, err := parseYearMonthQuery(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return NewValidationError(c, "Invalid format", []ValidationError{{Field: "format", Code: ValidationCodeInvalidFormat, Message: "Must be json or csv"}})
+	}
+
+	report, err := h.dashboardService.GetTaxReport(workspaceID, year)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Msg("Failed to get tax report")
+		return NewInternalError(c, "Failed to get tax report")
+	}
+
+	if format == "csv" {
+		return writeTaxReportCSV(c, report)
+	}
+
+	categories := make([]TaxCategoryResponse, len(report.Categories))
+	for i, cat := range report.Categories {
+		categories[i] = TaxCategoryResponse{
+			CategoryID:       cat.CategoryID,
+			CategoryName:     cat.CategoryName,
+			Total:            FormatAmount(cat.Total, domain.DefaultCurrency),
+			TransactionCount: cat.TransactionCount,
+		}
+	}
+
+	return c.JSON(http.StatusOK, TaxReportResponse{
+		Year:             report.Year,
+		Total:            FormatAmount(report.Total, domain.DefaultCurrency),
+		TransactionCount: report.TransactionCount,
+		Categories:       categories,
+	})
+}
+
+// writeTaxReportCSV streams the tax report as a downloadable CSV with a trailing total row
+func writeTaxReportCSV(c echo.Context, report *domain.TaxReport) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"Category", "Transactions", "Total"}}
+	for _, cat := range report.Categories {
+		rows = append(rows, []string{cat.CategoryName, strconv.Itoa(cat.TransactionCount), FormatAmount(cat.Total, domain.DefaultCurrency)})
+	}
+	rows = append(rows, []string{"Total", strconv.Itoa(report.TransactionCount), FormatAmount(report.Total, domain.DefaultCurrency)})
+	if err := w.WriteAll(rows); err != nil {
+		return NewInternalError(c, "Failed to write tax report")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"tax-report-%d.csv\"", report.Year))
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -558,6 +558,39 @@ func TestGetFutureSpending_InvalidMonthsParameter(t *testing.T) {
 	}
 }
 
+func TestGetFutureSpending_InvalidAccountIDs(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := service.NewCalculationService(accountRepo, transactionRepo)
+	monthService := service.NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := service.NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+	handler := NewDashboardHandler(dashboardService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/future-spending?accountIds=1,abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetFutureSpending(c); err != nil {
+		t.Fatalf("Expected JSON response, got error: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "accountIds" {
+		t.Errorf("Expected an accountIds validation error, got %+v", problem.Errors)
+	}
+}
+
 func TestGetFutureSpending_MissingWorkspaceID(t *testing.T) {
 	e := echo.New()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -661,3 +694,66 @@ func TestGetFutureSpending_WithDeferredCC(t *testing.T) {
 		t.Errorf("Expected current month total '300.00' (deferred CC), got %s", response.Months[0].Total)
 	}
 }
+
+func TestGetMerchantSummary_InvalidMonth(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := service.NewCalculationService(accountRepo, transactionRepo)
+	monthService := service.NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := service.NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+	handler := NewDashboardHandler(dashboardService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/merchants?month=2026-13", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetMerchantSummary(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestGetDebtToIncome_NoIncomeReturnsNullRatio(t *testing.T) {
+	e := echo.New()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := service.NewCalculationService(accountRepo, transactionRepo)
+	monthService := service.NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := service.NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+	handler := NewDashboardHandler(dashboardService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/dti?year=2026&month=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetDebtToIncome(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if ratio, ok := response["ratio"]; !ok || ratio != nil {
+		t.Errorf("Expected null ratio, got %v", response["ratio"])
+	}
+	if response["noIncome"] != true {
+		t.Errorf("Expected noIncome flag, got %v", response["noIncome"])
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/version"
+	"github.com/labstack/echo/v4"
+)
+
+// HealthResponse represents the health check response with build information
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildTime string `json:"buildTime"`
+}
+
+// Health handles GET /health
+// Reports build info so operators can confirm which release is deployed
+func Health(c echo.Context) error {
+	info := version.Get()
+	return c.JSON(http.StatusOK, HealthResponse{
+		Status:    "ok",
+		Version:   info.Version,
+		GitCommit: info.GitCommit,
+		BuildTime: info.BuildTime,
+	})
+}
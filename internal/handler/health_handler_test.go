@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/version"
+	"github.com/labstack/echo/v4"
+)
+
+func callHealth(t *testing.T) HealthResponse {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := Health(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return response
+}
+
+func setBuildInfo(t *testing.T, v, commit, buildTime string) {
+	t.Helper()
+	origVersion, origCommit, origBuildTime := version.Version, version.GitCommit, version.BuildTime
+	version.Version, version.GitCommit, version.BuildTime = v, commit, buildTime
+	t.Cleanup(func() {
+		version.Version, version.GitCommit, version.BuildTime = origVersion, origCommit, origBuildTime
+	})
+}
+
+func TestHealth_IncludesBuildInfo(t *testing.T) {
+	setBuildInfo(t, "v1.4.0", "abc1234", "2026-03-01T10:00:00Z")
+
+	response := callHealth(t)
+
+	if response.Status != "ok" {
+		t.Errorf("Expected status 'ok', got '%s'", response.Status)
+	}
+	if response.Version != "v1.4.0" {
+		t.Errorf("Expected version 'v1.4.0', got '%s'", response.Version)
+	}
+	if response.GitCommit != "abc1234" {
+		t.Errorf("Expected git commit 'abc1234', got '%s'", response.GitCommit)
+	}
+	if response.BuildTime != "2026-03-01T10:00:00Z" {
+		t.Errorf("Expected build time '2026-03-01T10:00:00Z', got '%s'", response.BuildTime)
+	}
+}
+
+func TestHealth_EmptyBuildInfoFallsBack(t *testing.T) {
+	setBuildInfo(t, "", "", "")
+
+	response := callHealth(t)
+
+	if response.Version != "dev" {
+		t.Errorf("Expected version 'dev', got '%s'", response.Version)
+	}
+	if response.GitCommit != "unknown" {
+		t.Errorf("Expected git commit 'unknown', got '%s'", response.GitCommit)
+	}
+	if response.BuildTime != "unknown" {
+		t.Errorf("Expected build time 'unknown', got '%s'", response.BuildTime)
+	}
+}
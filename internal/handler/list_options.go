@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// parseListOptions reads the shared list query params (?includeDeleted=true).
+// Listing soft-deleted rows is restricted to workspace owners; ok is false when
+// a non-owner asks for them.
+func parseListOptions(c echo.Context) (opts domain.ListOptions, ok bool) {
+	opts.IncludeDeleted = c.QueryParam("includeDeleted") == "true"
+	if opts.IncludeDeleted && middleware.GetWorkspaceRole(c) != domain.WorkspaceRoleOwner {
+		return domain.ListOptions{}, false
+	}
+	return opts, true
+}
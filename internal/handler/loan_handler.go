@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -34,25 +37,40 @@ type UpdateLoanRequest struct {
 
 // CreateLoanRequest represents the create loan request body
 type CreateLoanRequest struct {
-	ProviderID       int32    `json:"providerId"`
-	ItemName         string   `json:"itemName"`
-	TotalAmount      string   `json:"totalAmount"`
-	NumMonths        int32    `json:"numMonths"`
-	PurchaseDate     string   `json:"purchaseDate"`
-	InterestRate     *string  `json:"interestRate,omitempty"`
-	Notes            *string  `json:"notes,omitempty"`
-	PaymentAmounts   []string `json:"paymentAmounts,omitempty"` // Optional custom amounts for each payment
-	AccountID        int32    `json:"accountId"`                // Required: the account to use for loan payments
-	SettlementIntent *string  `json:"settlementIntent,omitempty"` // Optional: "immediate" or "deferred" for CC accounts
+	ProviderID        int32    `json:"providerId"`
+	ItemName          string   `json:"itemName"`
+	TotalAmount       string   `json:"totalAmount"`
+	NumMonths         int32    `json:"numMonths"`
+	PurchaseDate      string   `json:"purchaseDate"`
+	InterestRate      *string  `json:"interestRate,omitempty"`
+	InterestMethod    string   `json:"interestMethod,omitempty"` // Optional "flat" or "reducing_balance", defaults to the provider's
+	Notes             *string  `json:"notes,omitempty"`
+	PaymentAmounts    []string `json:"paymentAmounts,omitempty"`    // Optional custom amounts for each payment
+	AccountID         int32    `json:"accountId"`                   // Required: the account to use for loan payments
+	SettlementIntent  *string  `json:"settlementIntent,omitempty"`  // Optional: "immediate" or "deferred" for CC accounts
+	FirstPaymentMonth *string  `json:"firstPaymentMonth,omitempty"` // Optional "YYYY-MM" override of the cutoff-derived first payment
+	// Optional down payment paid up front from downPaymentAccountId (defaults to accountId); the rest is financed
+	DownPaymentAmount    *string `json:"downPaymentAmount,omitempty"`
+	DownPaymentAccountID int32   `json:"downPaymentAccountId,omitempty"`
+	// Set to skip the similarLoans warning once the user has confirmed this is not a re-entry
+	ConfirmDuplicate bool `json:"confirmDuplicate,omitempty"`
+}
+
+// CreateLoanResponse is the created loan plus any near-identical loans that already existed,
+// as a warning about a likely re-entry; omitted when none were found or confirmDuplicate was set
+type CreateLoanResponse struct {
+	LoanResponse
+	SimilarLoans []LoanResponse `json:"similarLoans,omitempty"`
 }
 
 // PreviewLoanRequest represents the preview loan request body
 type PreviewLoanRequest struct {
-	ProviderID   int32   `json:"providerId"`
-	TotalAmount  string  `json:"totalAmount"`
-	NumMonths    int32   `json:"numMonths"`
-	PurchaseDate string  `json:"purchaseDate"`
-	InterestRate *string `json:"interestRate,omitempty"`
+	ProviderID     int32   `json:"providerId"`
+	TotalAmount    string  `json:"totalAmount"`
+	NumMonths      int32   `json:"numMonths"`
+	PurchaseDate   string  `json:"purchaseDate"`
+	InterestRate   *string `json:"interestRate,omitempty"`
+	InterestMethod string  `json:"interestMethod,omitempty"`
 }
 
 // LoanResponse represents a loan in API responses
@@ -62,9 +80,11 @@ type LoanResponse struct {
 	ProviderID        int32   `json:"providerId"`
 	ItemName          string  `json:"itemName"`
 	TotalAmount       string  `json:"totalAmount"`
+	DownPaymentAmount string  `json:"downPaymentAmount"`
 	NumMonths         int32   `json:"numMonths"`
 	PurchaseDate      string  `json:"purchaseDate"`
 	InterestRate      string  `json:"interestRate"`
+	InterestMethod    string  `json:"interestMethod"`
 	MonthlyPayment    string  `json:"monthlyPayment"`
 	FirstPaymentYear  int32   `json:"firstPaymentYear"`
 	FirstPaymentMonth int32   `json:"firstPaymentMonth"`
@@ -76,14 +96,33 @@ type LoanResponse struct {
 	CreatedAt         string  `json:"createdAt"`
 	UpdatedAt         string  `json:"updatedAt"`
 	DeletedAt         *string `json:"deletedAt,omitempty"`
+	CompletedAt       *string `json:"completedAt,omitempty"`
 }
 
 // PreviewLoanResponse represents the preview loan calculation result
 type PreviewLoanResponse struct {
-	MonthlyPayment    string `json:"monthlyPayment"`
-	FirstPaymentYear  int    `json:"firstPaymentYear"`
-	FirstPaymentMonth int    `json:"firstPaymentMonth"`
-	InterestRate      string `json:"interestRate"`
+	MonthlyPayment          string                 `json:"monthlyPayment"`
+	MonthlyFee              string                 `json:"monthlyFee"`
+	FeeMode                 string                 `json:"feeMode"`
+	EffectiveMonthlyPayment string                 `json:"effectiveMonthlyPayment"` // Payment plus provider fee
+	PromoInterestRate       string                 `json:"promoInterestRate"`
+	PromoMonths             int                    `json:"promoMonths"`
+	PromoMonthlyPayment     string                 `json:"promoMonthlyPayment"` // Payment during the promo period
+	FirstPaymentYear        int                    `json:"firstPaymentYear"`
+	FirstPaymentMonth       int                    `json:"firstPaymentMonth"`
+	InterestRate            string                 `json:"interestRate"`
+	InterestMethod          string                 `json:"interestMethod"`
+	Schedule                []PreviewScheduleEntry `json:"schedule"`
+}
+
+// PreviewScheduleEntry represents one month of a previewed payment schedule
+type PreviewScheduleEntry struct {
+	PaymentNumber int    `json:"paymentNumber"`
+	Year          int    `json:"year"`
+	Month         int    `json:"month"`
+	Payment       string `json:"payment"`
+	Fee           string `json:"fee"`
+	Total         string `json:"total"`
 }
 
 // LoanWithStatsResponse represents a loan with payment statistics in API responses
@@ -93,9 +132,11 @@ type LoanWithStatsResponse struct {
 	ProviderID        int32   `json:"providerId"`
 	ItemName          string  `json:"itemName"`
 	TotalAmount       string  `json:"totalAmount"`
+	DownPaymentAmount string  `json:"downPaymentAmount"`
 	NumMonths         int32   `json:"numMonths"`
 	PurchaseDate      string  `json:"purchaseDate"`
 	InterestRate      string  `json:"interestRate"`
+	InterestMethod    string  `json:"interestMethod"`
 	MonthlyPayment    string  `json:"monthlyPayment"`
 	FirstPaymentYear  int32   `json:"firstPaymentYear"`
 	FirstPaymentMonth int32   `json:"firstPaymentMonth"`
@@ -107,6 +148,7 @@ type LoanWithStatsResponse struct {
 	CreatedAt         string  `json:"createdAt"`
 	UpdatedAt         string  `json:"updatedAt"`
 	DeletedAt         *string `json:"deletedAt,omitempty"`
+	CompletedAt       *string `json:"completedAt,omitempty"`
 	// Stats fields
 	TotalCount       int32   `json:"totalCount"`
 	PaidCount        int32   `json:"paidCount"`
@@ -126,20 +168,48 @@ func (h *LoanHandler) CreateLoan(c echo.Context) error {
 		return NewValidationError(c, "Invalid request body", nil)
 	}
 
+	input, err := parseCreateLoanRequest(req)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	loan, similar, err := h.loanService.CreateLoanWithDuplicateCheck(workspaceID, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, fmt.Sprintf("Amounts and rates must have at most %d decimal places", precisionLimit(err)), nil)
+		}
+		if issue, ok := loanPlanIssue(err); ok {
+			return NewValidationError(c, "Validation failed", []ValidationError{issue})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create loan")
+		return NewInternalError(c, "Failed to create loan")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("loan_id", loan.ID).Str("item", loan.ItemName).Msg("Loan created")
+
+	response := CreateLoanResponse{LoanResponse: toLoanResponse(loan)}
+	for _, existing := range similar {
+		response.SimilarLoans = append(response.SimilarLoans, toLoanResponse(existing))
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// parseCreateLoanRequest converts a create/validate request into service input
+func parseCreateLoanRequest(req CreateLoanRequest) (service.CreateLoanInput, error) {
 	// Parse total amount
 	totalAmount, err := decimal.NewFromString(req.TotalAmount)
 	if err != nil {
-		return NewValidationError(c, "Invalid total amount", []ValidationError{
-			{Field: "totalAmount", Message: "Must be a valid decimal number"},
-		})
+		return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid total amount", Errors: []ValidationError{
+			{Field: "totalAmount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+		}}
 	}
 
 	// Parse purchase date
 	purchaseDate, err := time.Parse("2006-01-02", req.PurchaseDate)
 	if err != nil {
-		return NewValidationError(c, "Invalid purchase date", []ValidationError{
-			{Field: "purchaseDate", Message: "Must be in YYYY-MM-DD format"},
-		})
+		return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid purchase date", Errors: []ValidationError{
+			{Field: "purchaseDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
+		}}
 	}
 
 	// Parse optional interest rate override
@@ -147,90 +217,256 @@ func (h *LoanHandler) CreateLoan(c echo.Context) error {
 	if req.InterestRate != nil && *req.InterestRate != "" {
 		rate, err := decimal.NewFromString(*req.InterestRate)
 		if err != nil {
-			return NewValidationError(c, "Invalid interest rate", []ValidationError{
-				{Field: "interestRate", Message: "Must be a valid decimal number"},
-			})
+			return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid interest rate", Errors: []ValidationError{
+				{Field: "interestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			}}
 		}
 		interestRate = &rate
 	}
 
-	// Parse optional custom payment amounts
+	// Parse optional custom payment amounts; count and sign are checked by the service
 	var paymentAmounts []decimal.Decimal
 	if len(req.PaymentAmounts) > 0 {
-		if len(req.PaymentAmounts) != int(req.NumMonths) {
-			return NewValidationError(c, "Invalid payment amounts", []ValidationError{
-				{Field: "paymentAmounts", Message: "Must have exactly numMonths amounts"},
-			})
-		}
 		paymentAmounts = make([]decimal.Decimal, len(req.PaymentAmounts))
 		for i, amtStr := range req.PaymentAmounts {
 			amt, err := decimal.NewFromString(amtStr)
 			if err != nil {
-				return NewValidationError(c, "Invalid payment amount", []ValidationError{
-					{Field: "paymentAmounts", Message: "All amounts must be valid decimal numbers"},
-				})
-			}
-			if amt.LessThanOrEqual(decimal.Zero) {
-				return NewValidationError(c, "Invalid payment amount", []ValidationError{
-					{Field: "paymentAmounts", Message: "All amounts must be positive"},
-				})
+				return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid payment amount", Errors: []ValidationError{
+					{Field: "paymentAmounts", Code: ValidationCodeInvalidFormat, Message: "All amounts must be valid decimal numbers"},
+				}}
 			}
 			paymentAmounts[i] = amt
 		}
 	}
 
-	input := service.CreateLoanInput{
-		ProviderID:       req.ProviderID,
-		ItemName:         req.ItemName,
-		TotalAmount:      totalAmount,
-		NumMonths:        req.NumMonths,
-		PurchaseDate:     purchaseDate,
-		InterestRate:     interestRate,
-		Notes:            req.Notes,
-		PaymentAmounts:   paymentAmounts,
-		AccountID:        req.AccountID,
-		SettlementIntent: req.SettlementIntent,
+	// Parse optional first payment month override
+	var firstPaymentYear, firstPaymentMonth int
+	if req.FirstPaymentMonth != nil && *req.FirstPaymentMonth != "" {
+		parsed, err := time.Parse("2006-01", *req.FirstPaymentMonth)
+		if err != nil {
+			return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid first payment month", Errors: []ValidationError{
+				{Field: "firstPaymentMonth", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM format"},
+			}}
+		}
+		firstPaymentYear, firstPaymentMonth = parsed.Year(), int(parsed.Month())
 	}
 
-	loan, err := h.loanService.CreateLoan(workspaceID, input)
-	if err != nil {
-		if errors.Is(err, domain.ErrLoanItemNameEmpty) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "itemName", Message: "Item name is required"},
-			})
-		}
-		if errors.Is(err, domain.ErrLoanItemNameTooLong) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "itemName", Message: "Item name must be 200 characters or less"},
-			})
-		}
-		if errors.Is(err, domain.ErrLoanAmountInvalid) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "totalAmount", Message: "Amount must be positive"},
-			})
+	// Parse optional down payment; range and account are checked by the service
+	var downPaymentAmount decimal.Decimal
+	if req.DownPaymentAmount != nil && *req.DownPaymentAmount != "" {
+		downPaymentAmount, err = decimal.NewFromString(*req.DownPaymentAmount)
+		if err != nil {
+			return service.CreateLoanInput{}, &requestValidationError{Detail: "Invalid down payment amount", Errors: []ValidationError{
+				{Field: "downPaymentAmount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			}}
 		}
-		if errors.Is(err, domain.ErrLoanMonthsInvalid) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "numMonths", Message: "Number of months must be at least 1"},
-			})
+	}
+
+	return service.CreateLoanInput{
+		ProviderID:           req.ProviderID,
+		ItemName:             req.ItemName,
+		TotalAmount:          totalAmount,
+		NumMonths:            req.NumMonths,
+		PurchaseDate:         purchaseDate,
+		InterestRate:         interestRate,
+		InterestMethod:       req.InterestMethod,
+		Notes:                req.Notes,
+		PaymentAmounts:       paymentAmounts,
+		AccountID:            req.AccountID,
+		SettlementIntent:     req.SettlementIntent,
+		FirstPaymentYear:     firstPaymentYear,
+		FirstPaymentMonth:    firstPaymentMonth,
+		DownPaymentAmount:    downPaymentAmount,
+		DownPaymentAccountID: req.DownPaymentAccountID,
+		ConfirmDuplicate:     req.ConfirmDuplicate,
+	}, nil
+}
+
+// loanPlanIssue maps a loan plan validation error to the field it concerns
+func loanPlanIssue(err error) (ValidationError, bool) {
+	switch {
+	case errors.Is(err, domain.ErrLoanItemNameEmpty):
+		return ValidationError{Field: "itemName", Code: ValidationCodeRequired, Message: "Item name is required"}, true
+	case errors.Is(err, domain.ErrLoanItemNameTooLong):
+		return ValidationError{Field: "itemName", Code: ValidationCodeTooLong, Message: "Item name must be 200 characters or less"}, true
+	case errors.Is(err, domain.ErrLoanAmountInvalid):
+		return ValidationError{Field: "totalAmount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"}, true
+	case errors.Is(err, domain.ErrLoanMonthsInvalid):
+		return ValidationError{Field: "numMonths", Code: ValidationCodeOutOfRange, Message: "Number of months must be at least 1"}, true
+	case errors.Is(err, domain.ErrLoanMonthsExceedsMax):
+		return ValidationError{Field: "numMonths", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("Number of months must be at most %d", loanMonthsLimit(err))}, true
+	case errors.Is(err, domain.ErrLoanProviderInvalid):
+		return ValidationError{Field: "providerId", Code: ValidationCodeNotFound, Message: "Invalid loan provider"}, true
+	case errors.Is(err, domain.ErrLoanAccountInvalid):
+		return ValidationError{Field: "accountId", Code: ValidationCodeRequired, Message: "Account is required"}, true
+	case errors.Is(err, domain.ErrLoanDownPaymentInvalid):
+		return ValidationError{Field: "downPaymentAmount", Code: ValidationCodeOutOfRange, Message: "Down payment must be zero or more and less than the total amount"}, true
+	case errors.Is(err, domain.ErrLoanDownPaymentAccountInvalid):
+		return ValidationError{Field: "downPaymentAccountId", Code: ValidationCodeNotFound, Message: "Down payment account not found"}, true
+	case errors.Is(err, domain.ErrLoanPaymentAmountsCount):
+		return ValidationError{Field: "paymentAmounts", Code: ValidationCodeInvalid, Message: "Must have exactly numMonths amounts"}, true
+	case errors.Is(err, domain.ErrLoanPaymentAmountsInvalid):
+		return ValidationError{Field: "paymentAmounts", Code: ValidationCodeOutOfRange, Message: "All amounts must be positive"}, true
+	case errors.Is(err, domain.ErrLoanPaymentAmountsSum):
+		return ValidationError{Field: "paymentAmounts", Code: ValidationCodeInvalid, Message: "Amounts must sum to the total amount plus interest"}, true
+	case errors.Is(err, domain.ErrLoanFirstPaymentInvalid):
+		return ValidationError{Field: "firstPaymentMonth", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("First payment month cannot be more than %d month(s) in the past", domain.FirstPaymentOverrideToleranceMonths)}, true
+	case errors.Is(err, domain.ErrInvalidInterestMethod):
+		return ValidationError{Field: "interestMethod", Code: ValidationCodeInvalidChoice, Message: "Interest method must be 'flat' or 'reducing_balance'"}, true
+	case errors.Is(err, domain.ErrExcessivePrecision):
+		return ValidationError{Field: "amounts", Code: ValidationCodeInvalidFormat, Message: fmt.Sprintf("Amounts and rates must have at most %d decimal places", precisionLimit(err))}, true
+	}
+	return ValidationError{}, false
+}
+
+// LoanPlanValidationResponse represents the result of validating a loan plan
+type LoanPlanValidationResponse struct {
+	Valid             bool                   `json:"valid"`
+	InterestRate      string                 `json:"interestRate"`
+	InterestMethod    string                 `json:"interestMethod"`
+	MonthlyPayment    string                 `json:"monthlyPayment"`
+	ExpectedTotal     string                 `json:"expectedTotal"`
+	FirstPaymentYear  int                    `json:"firstPaymentYear"`
+	FirstPaymentMonth int                    `json:"firstPaymentMonth"`
+	Schedule          []PlanScheduleResponse `json:"schedule"`
+	Issues            []ValidationError      `json:"issues"`
+}
+
+// PlanScheduleResponse is a single month of a validated plan's schedule
+type PlanScheduleResponse struct {
+	PaymentNumber int    `json:"paymentNumber"`
+	Year          int    `json:"year"`
+	Month         int    `json:"month"`
+	Amount        string `json:"amount"`
+}
+
+// ValidateLoanPlan handles POST /api/v1/loans/validate
+// Accepts the same body as CreateLoan and reports every issue without creating the loan
+func (h *LoanHandler) ValidateLoanPlan(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req CreateLoanRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	input, err := parseCreateLoanRequest(req)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	plan, err := h.loanService.ValidateLoanPlan(workspaceID, input)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to validate loan plan")
+		return NewInternalError(c, "Failed to validate loan plan")
+	}
+
+	issues := make([]ValidationError, 0, len(plan.Issues))
+	for _, planErr := range plan.Issues {
+		issue, ok := loanPlanIssue(planErr)
+		if !ok {
+			issue = ValidationError{Code: ValidationCodeInvalid, Message: planErr.Error()}
 		}
-		if errors.Is(err, domain.ErrLoanProviderInvalid) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "providerId", Message: "Invalid loan provider"},
-			})
+		issues = append(issues, issue)
+	}
+
+	schedule := make([]PlanScheduleResponse, len(plan.Schedule))
+	for i, entry := range plan.Schedule {
+		schedule[i] = PlanScheduleResponse{
+			PaymentNumber: entry.PaymentNumber,
+			Year:          entry.Year,
+			Month:         entry.Month,
+			Amount:        FormatAmount(entry.Amount, domain.DefaultCurrency),
 		}
-		if errors.Is(err, domain.ErrLoanAccountInvalid) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "accountId", Message: "Account is required"},
-			})
+	}
+
+	return c.JSON(http.StatusOK, LoanPlanValidationResponse{
+		Valid:             plan.Valid(),
+		InterestRate:      plan.InterestRate.StringFixed(2),
+		InterestMethod:    plan.InterestMethod,
+		MonthlyPayment:    FormatAmount(plan.MonthlyPayment, domain.DefaultCurrency),
+		ExpectedTotal:     FormatAmount(plan.ExpectedTotal, domain.DefaultCurrency),
+		FirstPaymentYear:  plan.FirstPaymentYear,
+		FirstPaymentMonth: plan.FirstPaymentMonth,
+		Schedule:          schedule,
+		Issues:            issues,
+	})
+}
+
+// InterestComparisonResponse represents a loan plan priced under both interest methods
+type InterestComparisonResponse struct {
+	InterestRate    string                      `json:"interestRate"`
+	FinancedAmount  string                      `json:"financedAmount"`
+	NumMonths       int32                       `json:"numMonths"`
+	Flat            InterestMethodQuoteResponse `json:"flat"`
+	ReducingBalance InterestMethodQuoteResponse `json:"reducingBalance"`
+}
+
+// InterestMethodQuoteResponse is the cost of a loan plan under one interest method
+type InterestMethodQuoteResponse struct {
+	InterestMethod string                 `json:"interestMethod"`
+	MonthlyPayment string                 `json:"monthlyPayment"`
+	TotalRepaid    string                 `json:"totalRepaid"`
+	TotalInterest  string                 `json:"totalInterest"`
+	Schedule       []PlanScheduleResponse `json:"schedule"`
+}
+
+// CompareInterestMethods handles POST /api/v1/loans/compare-methods
+// Accepts the same body as CreateLoan and prices it under flat and reducing-balance interest
+func (h *LoanHandler) CompareInterestMethods(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req CreateLoanRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	input, err := parseCreateLoanRequest(req)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	comparison, err := h.loanService.CompareInterestMethods(workspaceID, input)
+	if err != nil {
+		if issue, ok := loanPlanIssue(err); ok {
+			return NewValidationError(c, "Validation failed", []ValidationError{issue})
 		}
-		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create loan")
-		return NewInternalError(c, "Failed to create loan")
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to compare interest methods")
+		return NewInternalError(c, "Failed to compare interest methods")
 	}
 
-	log.Info().Int32("workspace_id", workspaceID).Int32("loan_id", loan.ID).Str("item", loan.ItemName).Msg("Loan created")
+	return c.JSON(http.StatusOK, InterestComparisonResponse{
+		InterestRate:    comparison.InterestRate.StringFixed(2),
+		FinancedAmount:  FormatAmount(comparison.FinancedAmount, domain.DefaultCurrency),
+		NumMonths:       comparison.NumMonths,
+		Flat:            toInterestMethodQuoteResponse(comparison.Flat),
+		ReducingBalance: toInterestMethodQuoteResponse(comparison.ReducingBalance),
+	})
+}
 
-	return c.JSON(http.StatusCreated, toLoanResponse(loan))
+func toInterestMethodQuoteResponse(quote domain.InterestMethodQuote) InterestMethodQuoteResponse {
+	schedule := make([]PlanScheduleResponse, len(quote.Schedule))
+	for i, entry := range quote.Schedule {
+		schedule[i] = PlanScheduleResponse{
+			PaymentNumber: entry.PaymentNumber,
+			Year:          entry.Year,
+			Month:         entry.Month,
+			Amount:        FormatAmount(entry.Amount, domain.DefaultCurrency),
+		}
+	}
+	return InterestMethodQuoteResponse{
+		InterestMethod: quote.InterestMethod,
+		MonthlyPayment: FormatAmount(quote.MonthlyPayment, domain.DefaultCurrency),
+		TotalRepaid:    FormatAmount(quote.TotalRepaid, domain.DefaultCurrency),
+		TotalInterest:  FormatAmount(quote.TotalInterest, domain.DefaultCurrency),
+		Schedule:       schedule,
+	}
 }
 
 // GetLoans godoc
@@ -241,9 +477,15 @@ func (h *LoanHandler) CreateLoan(c echo.Context) error {
 // @Produce json
 // @Security BearerAuth
 // @Param status query string false "Filter by status: active, completed, all" default(all)
+// @Param includeDeleted query bool false "Include soft-deleted loans in status=all (owners only)"
+// @Param groupBy query string false "Group loans by: provider (returns ProviderLoanGroupResponse items)"
 // @Success 200 {array} LoanWithStatsResponse
+// @Success 200 {array} ProviderLoanGroupResponse "When groupBy=provider"
+// @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
 // @Failure 500 {object} ProblemDetails
+// @Failure 504 {object} ProblemDetails
 // @Router /loans [get]
 func (h *LoanHandler) GetLoans(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -263,12 +505,36 @@ func (h *LoanHandler) GetLoans(c echo.Context) error {
 		filter = domain.LoanFilterAll
 	default:
 		return NewValidationError(c, "Invalid status parameter", []ValidationError{
-			{Field: "status", Message: "Must be 'all', 'active', or 'completed'"},
+			{Field: "status", Code: ValidationCodeInvalidChoice, Message: "Must be 'all', 'active', or 'completed'"},
 		})
 	}
 
-	loans, err := h.loanService.GetLoansWithStats(workspaceID, filter)
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return NewForbiddenError(c, "Only workspace owners can list deleted loans")
+	}
+	// Only the plain status=all list can include deleted loans
+	if opts.IncludeDeleted && (filter != domain.LoanFilterAll || c.QueryParam("groupBy") != "") {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "includeDeleted", Code: ValidationCodeInvalid, Message: "Only supported with status=all and without groupBy"},
+		})
+	}
+
+	switch c.QueryParam("groupBy") {
+	case "":
+	case "provider":
+		return h.getLoansGroupedByProvider(c, workspaceID, filter)
+	default:
+		return NewValidationError(c, "Invalid groupBy parameter", []ValidationError{
+			{Field: "groupBy", Code: ValidationCodeInvalidChoice, Message: "Must be 'provider'"},
+		})
+	}
+
+	loans, err := h.loanService.GetLoansWithStats(c.Request().Context(), workspaceID, filter, opts)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewGatewayTimeoutError(c, "Timed out loading loans")
+		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get loans")
 		return NewInternalError(c, "Failed to get loans")
 	}
@@ -281,6 +547,44 @@ func (h *LoanHandler) GetLoans(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// ProviderLoanGroupResponse represents a provider's loans in the grouped loan listing
+type ProviderLoanGroupResponse struct {
+	ProviderID       int32                   `json:"providerId"`
+	ProviderName     string                  `json:"providerName"`
+	ProviderDeleted  bool                    `json:"providerDeleted"`
+	TotalOutstanding string                  `json:"totalOutstanding"`
+	Loans            []LoanWithStatsResponse `json:"loans"`
+}
+
+// getLoansGroupedByProvider serves GET /api/v1/loans?groupBy=provider
+func (h *LoanHandler) getLoansGroupedByProvider(c echo.Context, workspaceID int32, filter domain.LoanFilter) error {
+	groups, err := h.loanService.GetLoansGroupedByProvider(c.Request().Context(), workspaceID, filter)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewGatewayTimeoutError(c, "Timed out loading loans")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get loans grouped by provider")
+		return NewInternalError(c, "Failed to get loans")
+	}
+
+	response := make([]ProviderLoanGroupResponse, len(groups))
+	for i, group := range groups {
+		loans := make([]LoanWithStatsResponse, len(group.Loans))
+		for j, loan := range group.Loans {
+			loans[j] = toLoanWithStatsResponse(loan)
+		}
+		response[i] = ProviderLoanGroupResponse{
+			ProviderID:       group.ProviderID,
+			ProviderName:     group.ProviderName,
+			ProviderDeleted:  group.ProviderDeleted,
+			TotalOutstanding: FormatAmount(group.TotalOutstanding, domain.DefaultCurrency),
+			Loans:            loans,
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // GetLoan handles GET /api/v1/loans/:id
 func (h *LoanHandler) GetLoan(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -305,6 +609,80 @@ func (h *LoanHandler) GetLoan(c echo.Context) error {
 	return c.JSON(http.StatusOK, toLoanResponse(loan))
 }
 
+// GetLoanScheduleICS handles GET /api/v1/loans/:id/schedule.ics
+// Returns an iCalendar feed with one event per unpaid payment
+func (h *LoanHandler) GetLoanScheduleICS(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan ID", nil)
+	}
+
+	loan, schedule, err := h.loanService.GetUnpaidSchedule(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanNotFound) {
+			return NewNotFoundError(c, "Loan not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to get loan schedule")
+		return NewInternalError(c, "Failed to get loan schedule")
+	}
+
+	var buf bytes.Buffer
+	if err := service.RenderLoanScheduleICS(loan, schedule, domain.DefaultCurrency, &buf); err != nil {
+		return NewInternalError(c, "Failed to render loan schedule")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"loan-%d.ics\"", loan.ID))
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+// LoanDetailResponse bundles a loan with its stats, provider and payment schedule
+type LoanDetailResponse struct {
+	Loan     LoanWithStatsResponse `json:"loan"`
+	Provider *LoanProviderResponse `json:"provider"`
+	Schedule []TransactionResponse `json:"schedule"`
+}
+
+// GetLoanDetail handles GET /api/v1/loans/:id/detail
+func (h *LoanHandler) GetLoanDetail(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan ID", nil)
+	}
+
+	detail, err := h.loanService.GetLoanDetail(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanNotFound) {
+			return NewNotFoundError(c, "Loan not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to get loan detail")
+		return NewInternalError(c, "Failed to get loan detail")
+	}
+
+	response := LoanDetailResponse{
+		Loan:     toLoanWithStatsResponse(&detail.Loan),
+		Schedule: make([]TransactionResponse, len(detail.Schedule)),
+	}
+	if detail.Provider != nil {
+		provider := toLoanProviderResponse(detail.Provider)
+		response.Provider = &provider
+	}
+	for i, txn := range detail.Schedule {
+		response.Schedule[i] = toTransactionResponse(txn)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // UpdateLoan handles PUT /api/v1/loans/:id
 // Only updates editable fields (itemName, notes); amount/months/dates are locked
 func (h *LoanHandler) UpdateLoan(c echo.Context) error {
@@ -336,22 +714,22 @@ func (h *LoanHandler) UpdateLoan(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrLoanItemNameEmpty) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "itemName", Message: "Item name is required"},
+				{Field: "itemName", Code: ValidationCodeRequired, Message: "Item name is required"},
 			})
 		}
 		if errors.Is(err, domain.ErrLoanItemNameTooLong) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "itemName", Message: "Item name must be 200 characters or less"},
+				{Field: "itemName", Code: ValidationCodeTooLong, Message: "Item name must be 200 characters or less"},
 			})
 		}
 		if errors.Is(err, domain.ErrCannotChangeProviderAfterPayments) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "providerId", Message: "Cannot change provider after payments are made"},
+				{Field: "providerId", Code: ValidationCodeInvalid, Message: "Cannot change provider after payments are made"},
 			})
 		}
 		if errors.Is(err, domain.ErrLoanProviderInvalid) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "providerId", Message: "Invalid loan provider"},
+				{Field: "providerId", Code: ValidationCodeNotFound, Message: "Invalid loan provider"},
 			})
 		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to update loan")
@@ -433,7 +811,7 @@ func (h *LoanHandler) GetDeleteCheck(c echo.Context) error {
 		ItemName:    loan.ItemName,
 		PaidCount:   stats.PaidCount,
 		UnpaidCount: stats.UnpaidCount,
-		TotalAmount: stats.TotalAmount.StringFixed(2),
+		TotalAmount: FormatAmount(stats.TotalAmount, domain.DefaultCurrency),
 	})
 }
 
@@ -461,13 +839,181 @@ func (h *LoanHandler) DeleteLoan(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ListDeletedLoans handles GET /api/v1/loans/trash
+func (h *LoanHandler) ListDeletedLoans(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	loans, err := h.loanService.ListDeletedLoans(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get deleted loans")
+		return NewInternalError(c, "Failed to get deleted loans")
+	}
+
+	response := make([]LoanResponse, len(loans))
+	for i, loan := range loans {
+		response[i] = toLoanResponse(loan)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RestoreLoan handles POST /api/v1/loans/:id/restore
+func (h *LoanHandler) RestoreLoan(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan ID", nil)
+	}
+
+	loan, err := h.loanService.RestoreLoan(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanNotFound) {
+			return NewNotFoundError(c, "Deleted loan not found")
+		}
+		if errors.Is(err, domain.ErrLoanRestoreClosedMonth) {
+			return NewConflictError(c, "Cannot restore a loan whose payments start in a closed month")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to restore loan")
+		return NewInternalError(c, "Failed to restore loan")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Loan restored")
+	return c.JSON(http.StatusOK, toLoanResponse(loan))
+}
+
+// RefundLoanPaymentRequest represents the request body for refunding part of a loan payment
+type RefundLoanPaymentRequest struct {
+	PaymentNumber int32  `json:"paymentNumber"` // 1-based position in the payment schedule
+	Amount        string `json:"amount"`
+}
+
+// RefundLoanPayment handles POST /api/v1/loans/:id/refund
+// Reduces the targeted payment by the refunded amount, voiding it when fully refunded
+func (h *LoanHandler) RefundLoanPayment(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan ID", nil)
+	}
+
+	var req RefundLoanPaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return NewValidationError(c, "Invalid amount", []ValidationError{
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+		})
+	}
+
+	loan, err := h.loanService.RefundLoanPayment(workspaceID, int32(id), req.PaymentNumber, amount)
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanNotFound) {
+			return NewNotFoundError(c, "Loan not found")
+		}
+		if errors.Is(err, domain.ErrLoanPaymentNotFound) {
+			return NewNotFoundError(c, "Payment not found")
+		}
+		if errors.Is(err, domain.ErrLoanPaymentNumberInvalid) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "paymentNumber", Code: ValidationCodeOutOfRange, Message: "Payment number must be within the loan's schedule"},
+			})
+		}
+		if errors.Is(err, domain.ErrRefundAmountInvalid) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Refund amount must be positive"},
+			})
+		}
+		if errors.Is(err, domain.ErrRefundExceedsPayment) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Refund amount cannot exceed the payment amount"},
+			})
+		}
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, fmt.Sprintf("Amounts must have at most %d decimal places", precisionLimit(err)), nil)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to refund loan payment")
+		return NewInternalError(c, "Failed to refund loan payment")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("loan_id", id).Int32("payment_number", req.PaymentNumber).Str("amount", amount.String()).Msg("Loan payment refunded")
+	return c.JSON(http.StatusOK, toLoanResponse(loan))
+}
+
+// SettleLoanExternallyRequest represents the request body for settling a loan outside the app
+type SettleLoanExternallyRequest struct {
+	Note string `json:"note,omitempty"` // Recorded on the settled payments
+}
+
+// SettleLoanExternally handles POST /api/v1/loans/:id/settle-external
+// Marks every remaining payment paid without creating transactions, completing the loan
+func (h *LoanHandler) SettleLoanExternally(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan ID", nil)
+	}
+
+	var req SettleLoanExternallyRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	loan, err := h.loanService.SettleLoanExternally(workspaceID, int32(id), req.Note)
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanNotFound) {
+			return NewNotFoundError(c, "Loan not found")
+		}
+		if errors.Is(err, domain.ErrLoanAlreadySettled) {
+			return NewConflictError(c, "Loan has no unpaid payments to settle")
+		}
+		if errors.Is(err, domain.ErrNotesTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "note", Code: ValidationCodeTooLong, Message: fmt.Sprintf("Note must be %d characters or less", domain.MaxTransactionNotesLength)},
+			})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Failed to settle loan externally")
+		return NewInternalError(c, "Failed to settle loan")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("loan_id", id).Msg("Loan settled externally")
+	return c.JSON(http.StatusOK, toLoanResponse(loan))
+}
+
 // CommitmentsResponse represents the monthly loan commitments aggregation
 type CommitmentsResponse struct {
-	Year        int                 `json:"year"`
-	Month       int                 `json:"month"`
-	TotalUnpaid string              `json:"totalUnpaid"`
-	TotalPaid   string              `json:"totalPaid"`
-	Payments    []CommitmentPayment `json:"payments"`
+	Year        int                  `json:"year"`
+	Month       int                  `json:"month"`
+	TotalUnpaid string               `json:"totalUnpaid"`
+	TotalPaid   string               `json:"totalPaid"`
+	Status      string               `json:"status"` // none (nothing due) | unpaid | partial | paid
+	Payments    []CommitmentPayment  `json:"payments"`
+	Providers   []CommitmentProvider `json:"providers"`
+}
+
+// CommitmentProvider represents a provider's subtotals in the monthly commitments
+type CommitmentProvider struct {
+	ProviderID   int32  `json:"providerId"`
+	ProviderName string `json:"providerName"`
+	TotalUnpaid  string `json:"totalUnpaid"`
+	TotalPaid    string `json:"totalPaid"`
 }
 
 // CommitmentPayment represents a single payment in the monthly commitments
@@ -478,6 +1024,7 @@ type CommitmentPayment struct {
 	TotalPayments int32  `json:"totalPayments"`
 	Amount        string `json:"amount"`
 	Paid          bool   `json:"paid"`
+	Status        string `json:"status"` // unpaid | partial | paid
 }
 
 // GetMonthlyCommitments handles GET /api/v1/loans/commitments/:year/:month
@@ -510,17 +1057,30 @@ func (h *LoanHandler) GetMonthlyCommitments(c echo.Context) error {
 			ItemName:      p.ItemName,
 			PaymentNumber: p.PaymentNumber,
 			TotalPayments: p.TotalPayments,
-			Amount:        p.Amount.StringFixed(2),
+			Amount:        FormatAmount(p.Amount, domain.DefaultCurrency),
 			Paid:          p.Paid,
+			Status:        string(p.Status),
+		}
+	}
+
+	providers := make([]CommitmentProvider, len(result.Providers))
+	for i, p := range result.Providers {
+		providers[i] = CommitmentProvider{
+			ProviderID:   p.ProviderID,
+			ProviderName: p.ProviderName,
+			TotalUnpaid:  FormatAmount(p.TotalUnpaid, domain.DefaultCurrency),
+			TotalPaid:    FormatAmount(p.TotalPaid, domain.DefaultCurrency),
 		}
 	}
 
 	return c.JSON(http.StatusOK, CommitmentsResponse{
 		Year:        result.Year,
 		Month:       result.Month,
-		TotalUnpaid: result.TotalUnpaid.StringFixed(2),
-		TotalPaid:   result.TotalPaid.StringFixed(2),
+		TotalUnpaid: FormatAmount(result.TotalUnpaid, domain.DefaultCurrency),
+		TotalPaid:   FormatAmount(result.TotalPaid, domain.DefaultCurrency),
+		Status:      string(result.Status),
 		Payments:    payments,
+		Providers:   providers,
 	})
 }
 
@@ -540,7 +1100,7 @@ func (h *LoanHandler) PreviewLoan(c echo.Context) error {
 	totalAmount, err := decimal.NewFromString(req.TotalAmount)
 	if err != nil {
 		return NewValidationError(c, "Invalid total amount", []ValidationError{
-			{Field: "totalAmount", Message: "Must be a valid decimal number"},
+			{Field: "totalAmount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -548,7 +1108,7 @@ func (h *LoanHandler) PreviewLoan(c echo.Context) error {
 	purchaseDate, err := time.Parse("2006-01-02", req.PurchaseDate)
 	if err != nil {
 		return NewValidationError(c, "Invalid purchase date", []ValidationError{
-			{Field: "purchaseDate", Message: "Must be in YYYY-MM-DD format"},
+			{Field: "purchaseDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 		})
 	}
 
@@ -558,46 +1118,80 @@ func (h *LoanHandler) PreviewLoan(c echo.Context) error {
 		rate, err := decimal.NewFromString(*req.InterestRate)
 		if err != nil {
 			return NewValidationError(c, "Invalid interest rate", []ValidationError{
-				{Field: "interestRate", Message: "Must be a valid decimal number"},
+				{Field: "interestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 			})
 		}
 		interestRate = &rate
 	}
 
 	input := service.PreviewLoanInput{
-		ProviderID:   req.ProviderID,
-		TotalAmount:  totalAmount,
-		NumMonths:    req.NumMonths,
-		PurchaseDate: purchaseDate,
-		InterestRate: interestRate,
+		ProviderID:     req.ProviderID,
+		TotalAmount:    totalAmount,
+		NumMonths:      req.NumMonths,
+		PurchaseDate:   purchaseDate,
+		InterestRate:   interestRate,
+		InterestMethod: req.InterestMethod,
 	}
 
 	result, err := h.loanService.PreviewLoan(workspaceID, input)
 	if err != nil {
 		if errors.Is(err, domain.ErrLoanProviderInvalid) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "providerId", Message: "Invalid loan provider"},
+				{Field: "providerId", Code: ValidationCodeNotFound, Message: "Invalid loan provider"},
 			})
 		}
 		if errors.Is(err, domain.ErrLoanAmountInvalid) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "totalAmount", Message: "Amount must be positive"},
+				{Field: "totalAmount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
 			})
 		}
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, fmt.Sprintf("Amounts and rates must have at most %d decimal places", precisionLimit(err)), nil)
+		}
 		if errors.Is(err, domain.ErrLoanMonthsInvalid) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "numMonths", Message: "Number of months must be at least 1"},
+				{Field: "numMonths", Code: ValidationCodeOutOfRange, Message: "Number of months must be at least 1"},
+			})
+		}
+		if errors.Is(err, domain.ErrLoanMonthsExceedsMax) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "numMonths", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("Number of months must be at most %d", loanMonthsLimit(err))},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidInterestMethod) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "interestMethod", Code: ValidationCodeInvalidChoice, Message: "Interest method must be 'flat' or 'reducing_balance'"},
 			})
 		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to preview loan")
 		return NewInternalError(c, "Failed to preview loan")
 	}
 
+	schedule := make([]PreviewScheduleEntry, len(result.Schedule))
+	for i, entry := range result.Schedule {
+		schedule[i] = PreviewScheduleEntry{
+			PaymentNumber: entry.PaymentNumber,
+			Year:          entry.Year,
+			Month:         entry.Month,
+			Payment:       FormatAmount(entry.Payment, domain.DefaultCurrency),
+			Fee:           FormatAmount(entry.Fee, domain.DefaultCurrency),
+			Total:         FormatAmount(entry.Total, domain.DefaultCurrency),
+		}
+	}
+
 	return c.JSON(http.StatusOK, PreviewLoanResponse{
-		MonthlyPayment:    result.MonthlyPayment.StringFixed(2),
-		FirstPaymentYear:  result.FirstPaymentYear,
-		FirstPaymentMonth: result.FirstPaymentMonth,
-		InterestRate:      result.InterestRate.StringFixed(2),
+		MonthlyPayment:          FormatAmount(result.MonthlyPayment, domain.DefaultCurrency),
+		MonthlyFee:              FormatAmount(result.MonthlyFee, domain.DefaultCurrency),
+		FeeMode:                 result.FeeMode,
+		EffectiveMonthlyPayment: FormatAmount(result.EffectiveMonthlyPayment, domain.DefaultCurrency),
+		PromoInterestRate:       result.PromoInterestRate.StringFixed(2),
+		PromoMonths:             result.PromoMonths,
+		PromoMonthlyPayment:     FormatAmount(result.PromoMonthlyPayment, domain.DefaultCurrency),
+		FirstPaymentYear:        result.FirstPaymentYear,
+		FirstPaymentMonth:       result.FirstPaymentMonth,
+		InterestRate:            result.InterestRate.StringFixed(2),
+		InterestMethod:          result.InterestMethod,
+		Schedule:                schedule,
 	})
 }
 
@@ -629,17 +1223,11 @@ func (h *LoanHandler) GetTrend(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
-	// Parse months query parameter (default 12, max 24)
-	months := 12
-	monthsParam := c.QueryParam("months")
-	if monthsParam != "" {
-		parsed, err := strconv.Atoi(monthsParam)
-		if err != nil || parsed < 1 || parsed > 24 {
-			return NewValidationError(c, "Invalid months parameter", []ValidationError{
-				{Field: "months", Message: "Must be a number between 1 and 24"},
-			})
-		}
-		months = parsed
+	months, ok := parseTrendMonths(c.QueryParam("months"))
+	if !ok {
+		return NewValidationError(c, "Invalid months parameter", []ValidationError{
+			{Field: "months", Code: ValidationCodeOutOfRange, Message: "Must be a number between 1 and 24"},
+		})
 	}
 
 	result, err := h.loanService.GetTrend(workspaceID, months)
@@ -648,7 +1236,151 @@ func (h *LoanHandler) GetTrend(c echo.Context) error {
 		return NewInternalError(c, "Failed to get loan trend")
 	}
 
-	// Convert to API response format (decimal to string)
+	return c.JSON(http.StatusOK, toTrendAPIResponse(result))
+}
+
+// LoanPaymentBucketResponse represents one due-date bucket of unpaid loan payments
+type LoanPaymentBucketResponse struct {
+	Total    string                `json:"total"`
+	Count    int                   `json:"count"`
+	Payments []TransactionResponse `json:"payments"`
+}
+
+// LoanStatusBucketsResponse represents unpaid loan payments grouped by due status
+type LoanStatusBucketsResponse struct {
+	Overdue      LoanPaymentBucketResponse `json:"overdue"`
+	DueThisMonth LoanPaymentBucketResponse `json:"dueThisMonth"`
+	Upcoming     LoanPaymentBucketResponse `json:"upcoming"`
+}
+
+// GetStatusBuckets handles GET /api/v1/loans/status-buckets
+// Classifies unpaid loan payments as overdue, due this month, or upcoming
+func (h *LoanHandler) GetStatusBuckets(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	// Resolve the current month in the workspace timezone
+	tz, err := parseTimezoneQuery(c)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	buckets, err := h.loanService.GetLoanPaymentStatusBuckets(workspaceID, time.Now().In(tz))
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get loan status buckets")
+		return NewInternalError(c, "Failed to get loan status buckets")
+	}
+
+	return c.JSON(http.StatusOK, LoanStatusBucketsResponse{
+		Overdue:      toLoanPaymentBucketResponse(buckets.Overdue),
+		DueThisMonth: toLoanPaymentBucketResponse(buckets.DueThisMonth),
+		Upcoming:     toLoanPaymentBucketResponse(buckets.Upcoming),
+	})
+}
+
+func toLoanPaymentBucketResponse(bucket domain.LoanPaymentBucket) LoanPaymentBucketResponse {
+	payments := make([]TransactionResponse, len(bucket.Payments))
+	for i, tx := range bucket.Payments {
+		payments[i] = toTransactionResponse(tx)
+	}
+	return LoanPaymentBucketResponse{
+		Total:    FormatAmount(bucket.Total, domain.DefaultCurrency),
+		Count:    bucket.Count,
+		Payments: payments,
+	}
+}
+
+// GetProviderTrend handles GET /api/v1/loan-providers/:id/trend
+// Returns monthly loan payment aggregates for a single provider
+func (h *LoanHandler) GetProviderTrend(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	providerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid provider ID", nil)
+	}
+
+	months, ok := parseTrendMonths(c.QueryParam("months"))
+	if !ok {
+		return NewValidationError(c, "Invalid months parameter", []ValidationError{
+			{Field: "months", Code: ValidationCodeOutOfRange, Message: "Must be a number between 1 and 24"},
+		})
+	}
+
+	result, err := h.loanService.GetProviderTrend(workspaceID, int32(providerID), months)
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanProviderNotFound) {
+			return NewNotFoundError(c, "Loan provider not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Int("months", months).Msg("Failed to get loan provider trend")
+		return NewInternalError(c, "Failed to get loan provider trend")
+	}
+
+	return c.JSON(http.StatusOK, toTrendAPIResponse(result))
+}
+
+// ProviderPayoffResponse represents the amount needed to clear a provider's active loans
+type ProviderPayoffResponse struct {
+	ProviderID   int32                   `json:"providerId"`
+	ProviderName string                  `json:"providerName"`
+	TotalPayoff  string                  `json:"totalPayoff"`
+	Loans        []LoanWithStatsResponse `json:"loans"`
+}
+
+// GetProviderPayoff handles GET /api/v1/loan-providers/:id/payoff
+// Returns the remaining balance across the provider's active loans with per-loan detail
+func (h *LoanHandler) GetProviderPayoff(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	providerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid provider ID", nil)
+	}
+
+	payoff, err := h.loanService.GetProviderPayoff(workspaceID, int32(providerID))
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanProviderNotFound) {
+			return NewNotFoundError(c, "Loan provider not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Msg("Failed to get loan provider payoff")
+		return NewInternalError(c, "Failed to get loan provider payoff")
+	}
+
+	loans := make([]LoanWithStatsResponse, len(payoff.Loans))
+	for i, loan := range payoff.Loans {
+		loans[i] = toLoanWithStatsResponse(loan)
+	}
+
+	return c.JSON(http.StatusOK, ProviderPayoffResponse{
+		ProviderID:   payoff.ProviderID,
+		ProviderName: payoff.ProviderName,
+		TotalPayoff:  FormatAmount(payoff.TotalPayoff, domain.DefaultCurrency),
+		Loans:        loans,
+	})
+}
+
+// parseTrendMonths parses the months query parameter (default 12, max 24)
+func parseTrendMonths(monthsParam string) (int, bool) {
+	if monthsParam == "" {
+		return 12, true
+	}
+	parsed, err := strconv.Atoi(monthsParam)
+	if err != nil || parsed < 1 || parsed > 24 {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// toTrendAPIResponse converts trend data to API response format (decimal to string)
+func toTrendAPIResponse(result *domain.TrendResponse) TrendAPIResponse {
 	response := TrendAPIResponse{
 		Months: make([]TrendMonthResponse, len(result.Months)),
 	}
@@ -658,24 +1390,24 @@ func (h *LoanHandler) GetTrend(c echo.Context) error {
 			providers[j] = TrendProviderResponse{
 				ID:     p.ID,
 				Name:   p.Name,
-				Amount: p.Amount.StringFixed(2),
+				Amount: FormatAmount(p.Amount, domain.DefaultCurrency),
 			}
 		}
 		response.Months[i] = TrendMonthResponse{
 			Month:     m.Month,
-			Total:     m.Total.StringFixed(2),
+			Total:     FormatAmount(m.Total, domain.DefaultCurrency),
 			IsPaid:    m.IsPaid,
 			Providers: providers,
 		}
 	}
-
-	return c.JSON(http.StatusOK, response)
+	return response
 }
 
 // PayLoanMonthRequest represents the request body for paying a loan month
 type PayLoanMonthRequest struct {
-	Year  int `json:"year"`
-	Month int `json:"month"`
+	Year     int     `json:"year"`
+	Month    int     `json:"month"`
+	PaidDate *string `json:"paidDate,omitempty"` // YYYY-MM-DD, defaults to today
 }
 
 // PayLoanMonthResponse represents the response for paying a loan month
@@ -687,11 +1419,12 @@ type PayLoanMonthResponse struct {
 
 // TransactionBriefResponse represents a minimal transaction in the payment response
 type TransactionBriefResponse struct {
-	ID              int32  `json:"id"`
-	Name            string `json:"name"`
-	Amount          string `json:"amount"`
-	IsPaid          bool   `json:"isPaid"`
-	TransactionDate string `json:"transactionDate"`
+	ID              int32   `json:"id"`
+	Name            string  `json:"name"`
+	Amount          string  `json:"amount"`
+	IsPaid          bool    `json:"isPaid"`
+	TransactionDate string  `json:"transactionDate"`
+	PaidAt          *string `json:"paidAt,omitempty"`
 }
 
 // PayLoanMonth handles POST /api/v1/loans/:id/pay-month
@@ -715,12 +1448,12 @@ func (h *LoanHandler) PayLoanMonth(c echo.Context) error {
 	// Validate year and month
 	if req.Year < 2000 || req.Year > 2100 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "year", Message: "Year must be between 2000 and 2100"},
+			{Field: "year", Code: ValidationCodeOutOfRange, Message: "Year must be between 2000 and 2100"},
 		})
 	}
 	if req.Month < 1 || req.Month > 12 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "month", Message: "Month must be between 1 and 12"},
+			{Field: "month", Code: ValidationCodeOutOfRange, Message: "Month must be between 1 and 12"},
 		})
 	}
 
@@ -729,15 +1462,29 @@ func (h *LoanHandler) PayLoanMonth(c echo.Context) error {
 		Year:   req.Year,
 		Month:  req.Month,
 	}
+	if req.PaidDate != nil && *req.PaidDate != "" {
+		paidDate, err := time.Parse("2006-01-02", *req.PaidDate)
+		if err != nil {
+			return NewValidationError(c, "Invalid paid date", []ValidationError{
+				{Field: "paidDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
+			})
+		}
+		input.PaidDate = &paidDate
+	}
 
 	result, err := h.loanService.PayLoanMonth(workspaceID, input)
 	if err != nil {
 		if errors.Is(err, domain.ErrLoanNotFound) {
 			return NewNotFoundError(c, "Loan not found")
 		}
+		if errors.Is(err, domain.ErrLoanPaidDateInFuture) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "paidDate", Code: ValidationCodeOutOfRange, Message: "Paid date cannot be in the future"},
+			})
+		}
 		if errors.Is(err, domain.ErrNoTransactionsToSettle) {
 			return NewValidationError(c, "No unpaid transactions found", []ValidationError{
-				{Field: "month", Message: "No unpaid transactions found for this month"},
+				{Field: "month", Code: ValidationCodeInvalid, Message: "No unpaid transactions found for this month"},
 			})
 		}
 		if errors.Is(err, domain.ErrLoanPaymentAtomicityFailed) {
@@ -754,10 +1501,14 @@ func (h *LoanHandler) PayLoanMonth(c echo.Context) error {
 		settled[i] = TransactionBriefResponse{
 			ID:              tx.ID,
 			Name:            tx.Name,
-			Amount:          tx.Amount.StringFixed(2),
+			Amount:          FormatAmount(tx.Amount, domain.DefaultCurrency),
 			IsPaid:          tx.IsPaid,
 			TransactionDate: tx.TransactionDate.Format(time.RFC3339),
 		}
+		if tx.PaidAt != nil {
+			paidAt := tx.PaidAt.Format(time.RFC3339)
+			settled[i].PaidAt = &paidAt
+		}
 	}
 
 	log.Info().
@@ -770,11 +1521,107 @@ func (h *LoanHandler) PayLoanMonth(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, PayLoanMonthResponse{
 		Settled:     settled,
-		TotalAmount: result.TotalAmount.StringFixed(2),
+		TotalAmount: FormatAmount(result.TotalAmount, domain.DefaultCurrency),
 		Message:     result.Message,
 	})
 }
 
+// PayAllLoanResponse represents one loan's settlement in the pay-all response
+type PayAllLoanResponse struct {
+	LoanID      int32                      `json:"loanId"`
+	ItemName    string                     `json:"itemName"`
+	Settled     []TransactionBriefResponse `json:"settled"`
+	TotalAmount string                     `json:"totalAmount"`
+}
+
+// PayAllResponse represents the response for paying all loans due in a month
+type PayAllResponse struct {
+	Loans       []PayAllLoanResponse `json:"loans"`
+	TotalAmount string               `json:"totalAmount"`
+}
+
+// PayAllDue handles POST /api/v1/loans/pay-all?year=2026&month=1
+// Marks every unpaid loan transaction in the month as paid, across all loans
+func (h *LoanHandler) PayAllDue(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, err := strconv.Atoi(c.QueryParam("year"))
+	if err != nil || year < 2000 || year > 2100 {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "year", Code: ValidationCodeOutOfRange, Message: "Year must be between 2000 and 2100"},
+		})
+	}
+	month, err := strconv.Atoi(c.QueryParam("month"))
+	if err != nil || month < 1 || month > 12 {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "month", Code: ValidationCodeOutOfRange, Message: "Month must be between 1 and 12"},
+		})
+	}
+
+	result, err := h.loanService.PayAllDue(workspaceID, year, month)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoTransactionsToSettle) {
+			return NewValidationError(c, "No unpaid transactions found", []ValidationError{
+				{Field: "month", Code: ValidationCodeInvalid, Message: "No unpaid loan transactions found for this month"},
+			})
+		}
+		var mustPayErr domain.ErrMustPayEarlierMonth
+		if errors.As(err, &mustPayErr) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "month", Code: ValidationCodeInvalid, Message: mustPayErr.Error()},
+			})
+		}
+		if errors.Is(err, domain.ErrLoanPaymentAtomicityFailed) {
+			log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Pay-all atomicity failed")
+			return NewInternalError(c, "Failed to settle all transactions")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to pay all due loans")
+		return NewInternalError(c, "Failed to pay all due loans")
+	}
+
+	loans := make([]PayAllLoanResponse, len(result.Loans))
+	settledCount := 0
+	for i, loanResult := range result.Loans {
+		settled := make([]TransactionBriefResponse, len(loanResult.SettledTransactions))
+		for j, tx := range loanResult.SettledTransactions {
+			settled[j] = TransactionBriefResponse{
+				ID:              tx.ID,
+				Name:            tx.Name,
+				Amount:          FormatAmount(tx.Amount, domain.DefaultCurrency),
+				IsPaid:          tx.IsPaid,
+				TransactionDate: tx.TransactionDate.Format(time.RFC3339),
+			}
+			if tx.PaidAt != nil {
+				paidAt := tx.PaidAt.Format(time.RFC3339)
+				settled[j].PaidAt = &paidAt
+			}
+		}
+		settledCount += len(settled)
+		loans[i] = PayAllLoanResponse{
+			LoanID:      loanResult.LoanID,
+			ItemName:    loanResult.ItemName,
+			Settled:     settled,
+			TotalAmount: FormatAmount(loanResult.TotalAmount, domain.DefaultCurrency),
+		}
+	}
+
+	log.Info().
+		Int32("workspace_id", workspaceID).
+		Int("year", year).
+		Int("month", month).
+		Int("loan_count", len(loans)).
+		Int("settled_count", settledCount).
+		Msg("All due loans paid")
+
+	return c.JSON(http.StatusOK, PayAllResponse{
+		Loans:       loans,
+		TotalAmount: FormatAmount(result.TotalAmount, domain.DefaultCurrency),
+	})
+}
+
 // GetLoansByProvider handles GET /api/v1/loan-providers/:id/loans
 // Returns all loans for a provider with payment statistics for item-based modal
 func (h *LoanHandler) GetLoansByProvider(c echo.Context) error {
@@ -788,8 +1635,11 @@ func (h *LoanHandler) GetLoansByProvider(c echo.Context) error {
 		return NewValidationError(c, "Invalid provider ID", nil)
 	}
 
-	loans, err := h.loanService.GetLoansByProvider(workspaceID, int32(providerID))
+	loans, err := h.loanService.GetLoansByProvider(c.Request().Context(), workspaceID, int32(providerID))
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewGatewayTimeoutError(c, "Timed out loading loans")
+		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Msg("Failed to get loans by provider")
 		return NewInternalError(c, "Failed to get loans")
 	}
@@ -840,7 +1690,7 @@ func (h *LoanHandler) GetLoanTransactions(c echo.Context) error {
 		response[i] = LoanTransactionResponse{
 			ID:              tx.ID,
 			Name:            tx.Name,
-			Amount:          tx.Amount.StringFixed(2),
+			Amount:          FormatAmount(tx.Amount, domain.DefaultCurrency),
 			TransactionDate: tx.TransactionDate.Format("2006-01-02"),
 			IsPaid:          tx.IsPaid,
 			Year:            tx.TransactionDate.Year(),
@@ -851,6 +1701,120 @@ func (h *LoanHandler) GetLoanTransactions(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// OrphanedLoanTransactionResponse is a transaction still linked to a missing or deleted loan
+type OrphanedLoanTransactionResponse struct {
+	TransactionResponse
+	LoanID int32 `json:"loanId"`
+}
+
+// OrphanRepairResponse reports the transactions whose loan link was cleared
+type OrphanRepairResponse struct {
+	RepairedCount int                   `json:"repairedCount"`
+	Transactions  []TransactionResponse `json:"transactions"`
+}
+
+// GetOrphanedLoanTransactions godoc
+// @Summary List orphaned loan transactions
+// @Description Diagnostic: transactions linked to a loan that no longer exists or was deleted
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} OrphanedLoanTransactionResponse
+// @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /admin/orphaned-loan-transactions [get]
+func (h *LoanHandler) GetOrphanedLoanTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	orphans, err := h.loanService.FindOrphanedLoanTransactions(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to find orphaned loan transactions")
+		return NewInternalError(c, "Failed to find orphaned loan transactions")
+	}
+
+	response := make([]OrphanedLoanTransactionResponse, 0, len(orphans))
+	for _, tx := range orphans {
+		if tx.LoanID == nil {
+			continue
+		}
+		response = append(response, OrphanedLoanTransactionResponse{
+			TransactionResponse: toTransactionResponse(tx),
+			LoanID:              *tx.LoanID,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RepairOrphanedLoanTransactions godoc
+// @Summary Repair orphaned loan transactions
+// @Description Clears the loan link on transactions whose loan no longer exists or was deleted
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} OrphanRepairResponse
+// @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /admin/orphaned-loan-transactions/repair [post]
+func (h *LoanHandler) RepairOrphanedLoanTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	repaired, err := h.loanService.ReattachOrClearOrphans(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to repair orphaned loan transactions")
+		return NewInternalError(c, "Failed to repair orphaned loan transactions")
+	}
+
+	transactions := make([]TransactionResponse, len(repaired))
+	for i, tx := range repaired {
+		transactions[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, OrphanRepairResponse{
+		RepairedCount: len(repaired),
+		Transactions:  transactions,
+	})
+}
+
+// GenerateMissingSchedulesResponse represents the result of generating missing loan schedules
+type GenerateMissingSchedulesResponse struct {
+	GeneratedCount int `json:"generatedCount"`
+}
+
+// GenerateMissingLoanSchedules godoc
+// @Summary Generate missing loan schedules
+// @Description Generates payment transactions for loans that have none (e.g. after a bulk import). Loans with existing transactions are left untouched.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} GenerateMissingSchedulesResponse
+// @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /admin/generate-missing-loan-schedules [post]
+func (h *LoanHandler) GenerateMissingLoanSchedules(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	generated, err := h.loanService.GenerateMissingSchedules(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("generated", generated).Msg("Failed to generate missing loan schedules")
+		return NewInternalError(c, "Failed to generate missing loan schedules")
+	}
+
+	return c.JSON(http.StatusOK, GenerateMissingSchedulesResponse{GeneratedCount: generated})
+}
+
 // Helper function to convert domain.Loan to LoanResponse
 func toLoanResponse(loan *domain.Loan) LoanResponse {
 	lastYear, lastMonth := loan.GetLastPaymentYearMonth()
@@ -859,11 +1823,13 @@ func toLoanResponse(loan *domain.Loan) LoanResponse {
 		WorkspaceID:       loan.WorkspaceID,
 		ProviderID:        loan.ProviderID,
 		ItemName:          loan.ItemName,
-		TotalAmount:       loan.TotalAmount.StringFixed(2),
+		TotalAmount:       FormatAmount(loan.TotalAmount, domain.DefaultCurrency),
+		DownPaymentAmount: FormatAmount(loan.DownPaymentAmount, domain.DefaultCurrency),
 		NumMonths:         loan.NumMonths,
 		PurchaseDate:      loan.PurchaseDate.Format("2006-01-02"),
 		InterestRate:      loan.InterestRate.StringFixed(2),
-		MonthlyPayment:    loan.MonthlyPayment.StringFixed(2),
+		InterestMethod:    loan.InterestMethod,
+		MonthlyPayment:    FormatAmount(loan.MonthlyPayment, domain.DefaultCurrency),
 		FirstPaymentYear:  loan.FirstPaymentYear,
 		FirstPaymentMonth: loan.FirstPaymentMonth,
 		LastPaymentYear:   lastYear,
@@ -878,6 +1844,10 @@ func toLoanResponse(loan *domain.Loan) LoanResponse {
 		deletedAt := loan.DeletedAt.Format(time.RFC3339)
 		resp.DeletedAt = &deletedAt
 	}
+	if loan.CompletedAt != nil {
+		completedAt := loan.CompletedAt.Format(time.RFC3339)
+		resp.CompletedAt = &completedAt
+	}
 	return resp
 }
 
@@ -888,11 +1858,13 @@ func toLoanWithStatsResponse(loanWithStats *domain.LoanWithStats) LoanWithStatsR
 		WorkspaceID:       loanWithStats.WorkspaceID,
 		ProviderID:        loanWithStats.ProviderID,
 		ItemName:          loanWithStats.ItemName,
-		TotalAmount:       loanWithStats.TotalAmount.StringFixed(2),
+		TotalAmount:       FormatAmount(loanWithStats.TotalAmount, domain.DefaultCurrency),
+		DownPaymentAmount: FormatAmount(loanWithStats.DownPaymentAmount, domain.DefaultCurrency),
 		NumMonths:         loanWithStats.NumMonths,
 		PurchaseDate:      loanWithStats.PurchaseDate.Format("2006-01-02"),
 		InterestRate:      loanWithStats.InterestRate.StringFixed(2),
-		MonthlyPayment:    loanWithStats.MonthlyPayment.StringFixed(2),
+		InterestMethod:    loanWithStats.InterestMethod,
+		MonthlyPayment:    FormatAmount(loanWithStats.MonthlyPayment, domain.DefaultCurrency),
 		FirstPaymentYear:  loanWithStats.FirstPaymentYear,
 		FirstPaymentMonth: loanWithStats.FirstPaymentMonth,
 		LastPaymentYear:   loanWithStats.LastPaymentYear,
@@ -905,12 +1877,25 @@ func toLoanWithStatsResponse(loanWithStats *domain.LoanWithStats) LoanWithStatsR
 		// Stats fields
 		TotalCount:       loanWithStats.TotalCount,
 		PaidCount:        loanWithStats.PaidCount,
-		RemainingBalance: loanWithStats.RemainingBalance.StringFixed(2),
+		RemainingBalance: FormatAmount(loanWithStats.RemainingBalance, domain.DefaultCurrency),
 		Progress:         loanWithStats.Progress,
 	}
 	if loanWithStats.DeletedAt != nil {
 		deletedAt := loanWithStats.DeletedAt.Format(time.RFC3339)
 		resp.DeletedAt = &deletedAt
 	}
+	if loanWithStats.CompletedAt != nil {
+		completedAt := loanWithStats.CompletedAt.Format(time.RFC3339)
+		resp.CompletedAt = &completedAt
+	}
 	return resp
 }
+
+// loanMonthsLimit returns the month cap reported by a loan term validation error
+func loanMonthsLimit(err error) int32 {
+	var limitErr domain.ErrLoanMonthsLimit
+	if errors.As(err, &limitErr) {
+		return limitErr.MaxMonths
+	}
+	return domain.DefaultMaxLoanMonths
+}
@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
 	"github.com/labstack/echo/v4"
@@ -104,6 +107,61 @@ func TestCreateLoan_Success(t *testing.T) {
 	}
 }
 
+func TestCreateLoan_ReturnsSimilarLoansWithCreatedLoan(t *testing.T) {
+	e := echo.New()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	loanService := createTestLoanService(loanRepo, providerRepo)
+	handler := NewLoanHandler(loanService)
+
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         1,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	reqBody := `{
+		"providerId": 1,
+		"itemName": "iPhone Case",
+		"totalAmount": "300.00",
+		"numMonths": 3,
+		"purchaseDate": "2024-03-20",
+		"accountId": 1
+	}`
+	var responses []CreateLoanResponse
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/loans", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+		if err := handler.CreateLoan(c); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", rec.Code)
+		}
+		var response CreateLoanResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+
+	if len(responses[0].SimilarLoans) != 0 {
+		t.Errorf("Expected no similar loans for the first entry, got %d", len(responses[0].SimilarLoans))
+	}
+	if len(responses[1].SimilarLoans) != 1 || responses[1].SimilarLoans[0].ID != responses[0].ID {
+		t.Errorf("Expected the first loan to be reported as similar, got %+v", responses[1].SimilarLoans)
+	}
+	if responses[1].ID == responses[0].ID {
+		t.Errorf("Expected the re-entry to be created as a new loan")
+	}
+}
+
 func TestCreateLoan_WithInterestRateOverride(t *testing.T) {
 	e := echo.New()
 	loanRepo := testutil.NewMockLoanRepository()
@@ -196,6 +254,99 @@ func TestCreateLoan_EmptyItemName(t *testing.T) {
 	if rec.Code != http.StatusBadRequest {
 		t.Errorf("Expected status 400, got %d", rec.Code)
 	}
+
+	assertValidationCode(t, rec, "itemName", ValidationCodeRequired)
+}
+
+func TestCreateLoan_ItemNameTooLong(t *testing.T) {
+	e := echo.New()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	loanService := createTestLoanService(loanRepo, providerRepo)
+	handler := NewLoanHandler(loanService)
+
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Provider",
+		CutoffDay:   25,
+	})
+
+	reqBody := `{
+		"providerId": 1,
+		"itemName": "` + strings.Repeat("a", 201) + `",
+		"totalAmount": "100.00",
+		"numMonths": 3,
+		"purchaseDate": "2024-03-20",
+		"accountId": 1
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/loans", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.CreateLoan(c); err != nil {
+		t.Fatalf("Expected no error (error should be in response), got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+
+	assertValidationCode(t, rec, "itemName", ValidationCodeTooLong)
+}
+
+// assertValidationCode checks that the problem response reports code for field
+func assertValidationCode(t *testing.T, rec *httptest.ResponseRecorder, field, code string) {
+	t.Helper()
+	var problem ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	for _, fieldErr := range problem.Errors {
+		if fieldErr.Field == field {
+			if fieldErr.Code != code {
+				t.Errorf("Expected %s error code %q, got %q", field, code, fieldErr.Code)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected a validation error for %s, got %+v", field, problem.Errors)
+}
+
+func TestValidateLoanPlan_MalformedPurchaseDate(t *testing.T) {
+	e := echo.New()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	loanService := createTestLoanService(loanRepo, providerRepo)
+	handler := NewLoanHandler(loanService)
+
+	reqBody := `{
+		"providerId": 1,
+		"itemName": "Test",
+		"totalAmount": "100.00",
+		"numMonths": 3,
+		"purchaseDate": "20-03-2024",
+		"accountId": 1
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/loans/validate", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.ValidateLoanPlan(c); err != nil {
+		t.Fatalf("Expected no error (error should be in response), got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+
+	assertValidationCode(t, rec, "purchaseDate", ValidationCodeInvalidFormat)
 }
 
 func TestCreateLoan_InvalidProvider(t *testing.T) {
@@ -297,6 +448,43 @@ func TestGetLoans_Success(t *testing.T) {
 	}
 }
 
+func TestGetLoans_DBTimeoutReturns504(t *testing.T) {
+	e := echo.New()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	loanService := createTestLoanService(loanRepo, providerRepo)
+	handler := NewLoanHandler(loanService)
+
+	// Stub query that blocks well past the request deadline unless cancelled
+	loanRepo.GetAllWithStatsFn = func(ctx context.Context, workspaceID int32, opts domain.ListOptions) ([]*domain.LoanWithStats, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return []*domain.LoanWithStats{}, nil
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/loans", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	start := time.Now()
+	err := middleware.DBTimeout(20 * time.Millisecond)(handler.GetLoans)(c)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected status 504, got %d", rec.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected request to be cut off at the deadline, took %v", elapsed)
+	}
+}
+
 func TestGetLoans_WithStatusFilter(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -605,6 +793,28 @@ func TestPreviewLoan_InvalidProvider(t *testing.T) {
 	}
 }
 
+func TestGetLoans_IncludeDeletedOnlyWithAllStatus(t *testing.T) {
+	for _, query := range []string{"status=active", "status=completed", "groupBy=provider"} {
+		e := echo.New()
+		loanRepo := testutil.NewMockLoanRepository()
+		providerRepo := testutil.NewMockLoanProviderRepository()
+		handler := NewLoanHandler(createTestLoanService(loanRepo, providerRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/loans?includeDeleted=true&"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), middleware.WorkspaceRoleKey, domain.WorkspaceRoleOwner)))
+
+		if err := handler.GetLoans(c); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
+
 func TestGetLoans_WorkspaceIsolation(t *testing.T) {
 	e := echo.New()
 	loanRepo := testutil.NewMockLoanRepository()
@@ -999,3 +1209,63 @@ func TestGetDeleteCheck_WorkspaceIsolation(t *testing.T) {
 		t.Errorf("Workspace 1 should not see workspace 2's loan, expected 404 but got %d", rec.Code)
 	}
 }
+
+func TestOrphanedLoanTransactions_DetectAndRepair(t *testing.T) {
+	e := echo.New()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	loanService, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	transactionRepo.LoanRepo = loanRepo
+	handler := NewLoanHandler(loanService)
+
+	missingLoanID := int32(42)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:          1,
+		WorkspaceID: 1,
+		AccountID:   1,
+		Name:        "Stale installment",
+		Amount:      decimal.NewFromInt(50),
+		Type:        domain.TransactionTypeExpense,
+		LoanID:      &missingLoanID,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/orphaned-loan-transactions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetOrphanedLoanTransactions(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	var orphans []OrphanedLoanTransactionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &orphans); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].ID != 1 || orphans[0].LoanID != missingLoanID {
+		t.Fatalf("Expected orphaned transaction 1 linked to loan %d, got %+v", missingLoanID, orphans)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/orphaned-loan-transactions/repair", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.RepairOrphanedLoanTransactions(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var repair OrphanRepairResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &repair); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if repair.RepairedCount != 1 {
+		t.Errorf("Expected 1 repaired transaction, got %d", repair.RepairedCount)
+	}
+
+	tx, _ := transactionRepo.GetByID(1, 1)
+	if tx.LoanID != nil {
+		t.Error("Expected LoanID to be cleared after repair")
+	}
+}
@@ -65,6 +65,26 @@ type PayRangeResponse struct {
 	NextPayableMonth *string  `json:"nextPayableMonth,omitempty"`
 }
 
+// PayRangePreviewRequest represents the pay-range preview request body
+type PayRangePreviewRequest struct {
+	StartMonth string `json:"startMonth"` // Format: YYYY-MM
+	EndMonth   string `json:"endMonth"`   // Format: YYYY-MM
+}
+
+// PayRangePreviewMonthResponse represents one month in the pay-range preview
+type PayRangePreviewMonthResponse struct {
+	Month       string  `json:"month"`
+	PaymentIDs  []int32 `json:"paymentIds"`
+	TotalAmount string  `json:"totalAmount"`
+}
+
+// PayRangePreviewResponse represents the pay-range preview response
+type PayRangePreviewResponse struct {
+	Months       []PayRangePreviewMonthResponse `json:"months"`
+	PaymentCount int                            `json:"paymentCount"`
+	TotalAmount  string                         `json:"totalAmount"`
+}
+
 // PayMonthRequest represents the pay-month request body for single month payment
 type PayMonthRequest struct {
 	Month      string  `json:"month"`      // Format: YYYY-MM
@@ -93,6 +113,24 @@ type UnpayMonthResponse struct {
 	PreviousPayable *string `json:"previousPayable,omitempty"`
 }
 
+// ConsolidatedPreviewItemResponse represents one loan's contribution in a consolidated preview
+type ConsolidatedPreviewItemResponse struct {
+	PaymentID     int32  `json:"paymentId"`
+	LoanID        int32  `json:"loanId"`
+	ItemName      string `json:"itemName"`
+	PaymentNumber int32  `json:"paymentNumber"`
+	TotalPayments int32  `json:"totalPayments"`
+	Amount        string `json:"amount"`
+}
+
+// ConsolidatedPreviewResponse represents the consolidated-preview response
+type ConsolidatedPreviewResponse struct {
+	ProviderID  int32                             `json:"providerId"`
+	Month       string                            `json:"month"`
+	Items       []ConsolidatedPreviewItemResponse `json:"items"`
+	TotalAmount string                            `json:"totalAmount"`
+}
+
 // EarliestUnpaidMonthResponse represents the earliest unpaid month response
 type EarliestUnpaidMonthResponse struct {
 	Year  int32 `json:"year"`
@@ -153,7 +191,7 @@ func (h *LoanPaymentHandler) UpdatePaymentAmount(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -167,7 +205,7 @@ func (h *LoanPaymentHandler) UpdatePaymentAmount(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrLoanPaymentAmountInvalid) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "amount", Message: "Amount must be positive"},
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
 			})
 		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("loan_id", loanID).Int("payment_id", paymentID).Msg("Failed to update payment amount")
@@ -207,7 +245,7 @@ func (h *LoanPaymentHandler) TogglePaymentPaid(c echo.Context) error {
 		parsed, err := time.Parse("2006-01-02", *req.PaidDate)
 		if err != nil {
 			return NewValidationError(c, "Invalid paid date", []ValidationError{
-				{Field: "paidDate", Message: "Must be in YYYY-MM-DD format"},
+				{Field: "paidDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
 		paidDate = &parsed
@@ -230,6 +268,42 @@ func (h *LoanPaymentHandler) TogglePaymentPaid(c echo.Context) error {
 	return c.JSON(http.StatusOK, toLoanPaymentResponse(payment))
 }
 
+// SequentialPaymentProblem is the 409 body for a payment that breaks month order,
+// naming the month the client has to pay first or the one it left out
+type SequentialPaymentProblem struct {
+	ProblemDetails
+	Expected  string `json:"expected,omitempty"`  // Earliest unpaid month that must be paid first
+	Requested string `json:"requested,omitempty"` // Month the client tried to pay
+	Skipped   string `json:"skipped,omitempty"`   // Month missing from a multi-month payment
+}
+
+// writeSequentialPaymentError writes a 409 for ErrMustPayEarlierMonth and ErrCannotSkipMonth;
+// handled is false when err is neither
+func writeSequentialPaymentError(c echo.Context, err error) (handled bool, resp error) {
+	problem := SequentialPaymentProblem{
+		ProblemDetails: ProblemDetails{
+			Type:     ErrorTypeSequentialPayment,
+			Title:    "Sequential Payment Violation",
+			Status:   http.StatusConflict,
+			Detail:   err.Error(),
+			Instance: c.Request().URL.Path,
+		},
+	}
+
+	var mustPayErr domain.ErrMustPayEarlierMonth
+	var skipErr domain.ErrCannotSkipMonth
+	switch {
+	case errors.As(err, &mustPayErr):
+		problem.Expected = mustPayErr.Expected
+		problem.Requested = mustPayErr.Requested
+	case errors.As(err, &skipErr):
+		problem.Skipped = skipErr.Skipped
+	default:
+		return false, nil
+	}
+	return true, c.JSON(http.StatusConflict, problem)
+}
+
 // PayRange handles POST /api/v1/loan-providers/:id/pay-range
 func (h *LoanPaymentHandler) PayRange(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -250,17 +324,17 @@ func (h *LoanPaymentHandler) PayRange(c echo.Context) error {
 	// Validate required fields
 	if req.StartMonth == "" {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "startMonth", Message: "Start month is required"},
+			{Field: "startMonth", Code: ValidationCodeRequired, Message: "Start month is required"},
 		})
 	}
 	if req.EndMonth == "" {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "endMonth", Message: "End month is required"},
+			{Field: "endMonth", Code: ValidationCodeRequired, Message: "End month is required"},
 		})
 	}
 	if len(req.PaymentIDs) == 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "paymentIds", Message: "At least one payment ID is required"},
+			{Field: "paymentIds", Code: ValidationCodeRequired, Message: "At least one payment ID is required"},
 		})
 	}
 
@@ -277,27 +351,15 @@ func (h *LoanPaymentHandler) PayRange(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrEndMonthBeforeStart) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "endMonth", Message: "End month must be after start month"},
+				{Field: "endMonth", Code: ValidationCodeInvalid, Message: "End month must be after start month"},
 			})
 		}
 		if errors.Is(err, domain.ErrPaymentIDsInvalid) {
 			return NewValidationError(c, "One or more payment IDs are invalid or do not belong to the specified month range", nil)
 		}
 
-		// Check for ErrMustPayEarlierMonth
-		var mustPayErr domain.ErrMustPayEarlierMonth
-		if errors.As(err, &mustPayErr) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "startMonth", Message: mustPayErr.Error()},
-			})
-		}
-
-		// Check for ErrCannotSkipMonth
-		var skipErr domain.ErrCannotSkipMonth
-		if errors.As(err, &skipErr) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "paymentIds", Message: skipErr.Error()},
-			})
+		if handled, resp := writeSequentialPaymentError(c, err); handled {
+			return resp
 		}
 
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Msg("Failed to pay range")
@@ -322,6 +384,77 @@ func (h *LoanPaymentHandler) PayRange(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// PreviewPayRange handles POST /api/v1/loan-providers/:id/pay-range/preview
+// Returns the months, per-month totals and grand total a pay-range would settle, without paying
+func (h *LoanPaymentHandler) PreviewPayRange(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	providerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan provider ID", nil)
+	}
+
+	var req PayRangePreviewRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate required fields
+	if req.StartMonth == "" {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "startMonth", Code: ValidationCodeRequired, Message: "Start month is required"},
+		})
+	}
+	if req.EndMonth == "" {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "endMonth", Code: ValidationCodeRequired, Message: "End month is required"},
+		})
+	}
+
+	preview, err := h.paymentService.PreviewPayRange(c.Request().Context(), workspaceID, int32(providerID), req.StartMonth, req.EndMonth)
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanProviderNotFound) {
+			return NewNotFoundError(c, "Loan provider not found")
+		}
+		if errors.Is(err, domain.ErrProviderNotConsolidated) {
+			return NewValidationError(c, "Provider does not use consolidated monthly payment mode", nil)
+		}
+		if errors.Is(err, domain.ErrNoUnpaidMonths) {
+			return NewValidationError(c, "No unpaid months found for this provider", nil)
+		}
+		if errors.Is(err, domain.ErrEndMonthBeforeStart) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "endMonth", Code: ValidationCodeInvalid, Message: "End month must be after start month"},
+			})
+		}
+
+		if handled, resp := writeSequentialPaymentError(c, err); handled {
+			return resp
+		}
+
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Msg("Failed to preview pay range")
+		return NewInternalError(c, "Failed to preview pay range")
+	}
+
+	months := make([]PayRangePreviewMonthResponse, len(preview.Months))
+	for i, m := range preview.Months {
+		months[i] = PayRangePreviewMonthResponse{
+			Month:       m.Month,
+			PaymentIDs:  m.PaymentIDs,
+			TotalAmount: FormatAmount(m.TotalAmount, domain.DefaultCurrency),
+		}
+	}
+
+	return c.JSON(http.StatusOK, PayRangePreviewResponse{
+		Months:       months,
+		PaymentCount: preview.PaymentCount,
+		TotalAmount:  FormatAmount(preview.TotalAmount, domain.DefaultCurrency),
+	})
+}
+
 // PayMonth handles POST /api/v1/loan-providers/:id/pay-month
 func (h *LoanPaymentHandler) PayMonth(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -342,12 +475,12 @@ func (h *LoanPaymentHandler) PayMonth(c echo.Context) error {
 	// Validate required fields
 	if req.Month == "" {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "month", Message: "Month is required"},
+			{Field: "month", Code: ValidationCodeRequired, Message: "Month is required"},
 		})
 	}
 	if len(req.PaymentIDs) == 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "paymentIds", Message: "At least one payment ID is required"},
+			{Field: "paymentIds", Code: ValidationCodeRequired, Message: "At least one payment ID is required"},
 		})
 	}
 
@@ -366,12 +499,8 @@ func (h *LoanPaymentHandler) PayMonth(c echo.Context) error {
 			return NewValidationError(c, "One or more payment IDs are invalid or do not belong to the specified month", nil)
 		}
 
-		// Check for ErrMustPayEarlierMonth
-		var mustPayErr domain.ErrMustPayEarlierMonth
-		if errors.As(err, &mustPayErr) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "month", Message: mustPayErr.Error()},
-			})
+		if handled, resp := writeSequentialPaymentError(c, err); handled {
+			return resp
 		}
 
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Str("month", req.Month).Msg("Failed to pay month")
@@ -416,7 +545,7 @@ func (h *LoanPaymentHandler) UnpayMonth(c echo.Context) error {
 	// Validate required fields
 	if req.Month == "" {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "month", Message: "Month is required"},
+			{Field: "month", Code: ValidationCodeRequired, Message: "Month is required"},
 		})
 	}
 
@@ -436,7 +565,7 @@ func (h *LoanPaymentHandler) UnpayMonth(c echo.Context) error {
 		var cannotUnpayErr domain.ErrCannotUnpayEarlierMonth
 		if errors.As(err, &cannotUnpayErr) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "month", Message: cannotUnpayErr.Error()},
+				{Field: "month", Code: ValidationCodeInvalid, Message: cannotUnpayErr.Error()},
 			})
 		}
 
@@ -461,6 +590,62 @@ func (h *LoanPaymentHandler) UnpayMonth(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// GetConsolidatedPreview handles GET /api/v1/loan-providers/:id/consolidated-preview?month=YYYY-MM
+func (h *LoanPaymentHandler) GetConsolidatedPreview(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	providerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid loan provider ID", nil)
+	}
+
+	month := c.QueryParam("month")
+	if month == "" {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "month", Code: ValidationCodeRequired, Message: "Month is required"},
+		})
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "month", Code: ValidationCodeInvalid, Message: "Month must be in YYYY-MM format"},
+		})
+	}
+
+	preview, err := h.paymentService.PreviewConsolidatedMonth(workspaceID, int32(providerID), month)
+	if err != nil {
+		if errors.Is(err, domain.ErrLoanProviderNotFound) {
+			return NewNotFoundError(c, "Loan provider not found")
+		}
+		if errors.Is(err, domain.ErrProviderNotConsolidated) {
+			return NewValidationError(c, "Provider does not use consolidated monthly payment mode", nil)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("provider_id", providerID).Str("month", month).Msg("Failed to preview consolidated month")
+		return NewInternalError(c, "Failed to preview consolidated month")
+	}
+
+	items := make([]ConsolidatedPreviewItemResponse, len(preview.Items))
+	for i, item := range preview.Items {
+		items[i] = ConsolidatedPreviewItemResponse{
+			PaymentID:     item.PaymentID,
+			LoanID:        item.LoanID,
+			ItemName:      item.ItemName,
+			PaymentNumber: item.PaymentNumber,
+			TotalPayments: item.TotalPayments,
+			Amount:        FormatAmount(item.Amount, domain.DefaultCurrency),
+		}
+	}
+
+	return c.JSON(http.StatusOK, ConsolidatedPreviewResponse{
+		ProviderID:  preview.ProviderID,
+		Month:       preview.Month,
+		Items:       items,
+		TotalAmount: FormatAmount(preview.TotalAmount, domain.DefaultCurrency),
+	})
+}
+
 // GetEarliestUnpaidMonth handles GET /api/v1/loan-providers/:id/earliest-unpaid
 func (h *LoanPaymentHandler) GetEarliestUnpaidMonth(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -692,3 +692,93 @@ func TestUpdatePaymentAmount_WorkspaceIsolation(t *testing.T) {
 		t.Errorf("Workspace 1 should not update workspace 2's payment, expected 404 but got %d", rec.Code)
 	}
 }
+
+// newSequentialPaymentTestHandler returns a handler for a consolidated provider whose earliest unpaid month is 2024-01
+func newSequentialPaymentTestHandler(paymentRepo *testutil.MockLoanPaymentRepository) *LoanPaymentHandler {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Atome",
+		CutoffDay:   1,
+		PaymentMode: domain.PaymentModeConsolidatedMonthly,
+	})
+	paymentRepo.GetEarliestUnpaidMonthFn = func(workspaceID int32, providerID int32) (*domain.EarliestUnpaidMonth, error) {
+		return &domain.EarliestUnpaidMonth{Year: 2024, Month: 1}, nil
+	}
+	paymentService := service.NewLoanPaymentService(nil, paymentRepo, testutil.NewMockLoanRepository(), providerRepo)
+	return NewLoanPaymentHandler(paymentService)
+}
+
+func TestPayMonth_SequentialViolationReturnsExpectedMonth(t *testing.T) {
+	e := echo.New()
+	handler := newSequentialPaymentTestHandler(testutil.NewMockLoanPaymentRepository())
+
+	body := `{"month":"2024-03","paymentIds":[5]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/loan-providers/1/pay-month", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.PayMonth(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response SequentialPaymentProblem
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Type != ErrorTypeSequentialPayment {
+		t.Errorf("Expected type %s, got %s", ErrorTypeSequentialPayment, response.Type)
+	}
+	if response.Expected != "2024-01" || response.Requested != "2024-03" {
+		t.Errorf("Expected expected=2024-01 requested=2024-03, got expected=%q requested=%q", response.Expected, response.Requested)
+	}
+}
+
+func TestPayRange_GapReturnsSkippedMonth(t *testing.T) {
+	e := echo.New()
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	// January and March have payments due, February has none
+	paymentRepo.GetUnpaidPaymentsByProviderMonthFn = func(workspaceID int32, providerID int32, year int32, month int32) ([]*domain.LoanPayment, error) {
+		if month == 2 {
+			return []*domain.LoanPayment{}, nil
+		}
+		return []*domain.LoanPayment{{ID: month, DueYear: year, DueMonth: month}}, nil
+	}
+	handler := newSequentialPaymentTestHandler(paymentRepo)
+
+	body := `{"startMonth":"2024-01","endMonth":"2024-03","paymentIds":[1,3]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/loan-providers/1/pay-range", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.PayRange(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response SequentialPaymentProblem
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Skipped != "2024-02" {
+		t.Errorf("Expected skipped=2024-02, got %q", response.Skipped)
+	}
+}
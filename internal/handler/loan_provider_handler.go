@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -29,27 +30,43 @@ type CreateLoanProviderRequest struct {
 	Name                string `json:"name"`
 	CutoffDay           int32  `json:"cutoffDay"`
 	DefaultInterestRate string `json:"defaultInterestRate"`
+	MonthlyFee          string `json:"monthlyFee,omitempty"`
+	FeeMode             string `json:"feeMode,omitempty"` // Optional: "separate" (default) or "folded"
+	PromoInterestRate   string `json:"promoInterestRate,omitempty"`
+	PromoMonths         int32  `json:"promoMonths,omitempty"`
+	// Optional: "flat" (default) or "reducing_balance"
+	DefaultInterestMethod string `json:"defaultInterestMethod,omitempty"`
 }
 
 // UpdateLoanProviderRequest represents the update loan provider request body
 type UpdateLoanProviderRequest struct {
-	Name                string  `json:"name"`
-	CutoffDay           int32   `json:"cutoffDay"`
-	DefaultInterestRate string  `json:"defaultInterestRate"`
-	PaymentMode         *string `json:"paymentMode,omitempty"`
+	Name                  string  `json:"name"`
+	CutoffDay             int32   `json:"cutoffDay"`
+	DefaultInterestRate   string  `json:"defaultInterestRate"`
+	PaymentMode           *string `json:"paymentMode,omitempty"`
+	MonthlyFee            *string `json:"monthlyFee,omitempty"`
+	FeeMode               *string `json:"feeMode,omitempty"`
+	PromoInterestRate     *string `json:"promoInterestRate,omitempty"`
+	PromoMonths           *int32  `json:"promoMonths,omitempty"`
+	DefaultInterestMethod *string `json:"defaultInterestMethod,omitempty"`
 }
 
 // LoanProviderResponse represents a loan provider in API responses
 type LoanProviderResponse struct {
-	ID                  int32   `json:"id"`
-	WorkspaceID         int32   `json:"workspaceId"`
-	Name                string  `json:"name"`
-	CutoffDay           int32   `json:"cutoffDay"`
-	DefaultInterestRate string  `json:"defaultInterestRate"`
-	PaymentMode         string  `json:"paymentMode"`
-	CreatedAt           string  `json:"createdAt"`
-	UpdatedAt           string  `json:"updatedAt"`
-	DeletedAt           *string `json:"deletedAt,omitempty"`
+	ID                    int32   `json:"id"`
+	WorkspaceID           int32   `json:"workspaceId"`
+	Name                  string  `json:"name"`
+	CutoffDay             int32   `json:"cutoffDay"`
+	DefaultInterestRate   string  `json:"defaultInterestRate"`
+	PaymentMode           string  `json:"paymentMode"`
+	MonthlyFee            string  `json:"monthlyFee"`
+	FeeMode               string  `json:"feeMode"`
+	PromoInterestRate     string  `json:"promoInterestRate"`
+	PromoMonths           int32   `json:"promoMonths"`
+	DefaultInterestMethod string  `json:"defaultInterestMethod"`
+	CreatedAt             string  `json:"createdAt"`
+	UpdatedAt             string  `json:"updatedAt"`
+	DeletedAt             *string `json:"deletedAt,omitempty"`
 }
 
 // CreateLoanProvider handles POST /api/v1/loan-providers
@@ -64,61 +81,138 @@ func (h *LoanProviderHandler) CreateLoanProvider(c echo.Context) error {
 		return NewValidationError(c, "Invalid request body", nil)
 	}
 
+	input, err := parseCreateLoanProviderRequest(req)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	provider, err := h.providerService.CreateProvider(workspaceID, input)
+	if err != nil {
+		return handleCreateProviderError(c, workspaceID, err)
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("provider_id", provider.ID).Str("name", provider.Name).Msg("Loan provider created")
+
+	return c.JSON(http.StatusCreated, toLoanProviderResponse(provider))
+}
+
+// parseCreateLoanProviderRequest converts a create request into service input
+func parseCreateLoanProviderRequest(req CreateLoanProviderRequest) (service.CreateProviderInput, error) {
 	// Parse interest rate (default to 0)
 	interestRate := decimal.Zero
 	if req.DefaultInterestRate != "" {
 		var err error
 		interestRate, err = decimal.NewFromString(req.DefaultInterestRate)
 		if err != nil {
-			return NewValidationError(c, "Invalid interest rate", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Must be a valid decimal number"},
-			})
+			return service.CreateProviderInput{}, &requestValidationError{Detail: "Invalid interest rate", Errors: []ValidationError{
+				{Field: "defaultInterestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			}}
 		}
 	}
 
-	input := service.CreateProviderInput{
-		Name:                req.Name,
-		CutoffDay:           req.CutoffDay,
-		DefaultInterestRate: interestRate,
+	// Parse monthly fee (default to 0)
+	monthlyFee := decimal.Zero
+	if req.MonthlyFee != "" {
+		var err error
+		monthlyFee, err = decimal.NewFromString(req.MonthlyFee)
+		if err != nil {
+			return service.CreateProviderInput{}, &requestValidationError{Detail: "Invalid monthly fee", Errors: []ValidationError{
+				{Field: "monthlyFee", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			}}
+		}
 	}
 
-	provider, err := h.providerService.CreateProvider(workspaceID, input)
-	if err != nil {
-		if errors.Is(err, domain.ErrLoanProviderNameEmpty) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name is required"},
-			})
-		}
-		if errors.Is(err, domain.ErrLoanProviderNameTooLong) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name must be 100 characters or less"},
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidCutoffDay) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "cutoffDay", Message: "Cutoff day must be between 1 and 31"},
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidInterestRate) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Interest rate must be non-negative"},
-			})
-		}
-		if errors.Is(err, domain.ErrInterestRateTooHigh) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Interest rate must be 100% or less"},
-			})
-		}
-		if errors.Is(err, domain.ErrLoanProviderNameExists) {
-			return NewConflictError(c, "A loan provider with this name already exists")
+	// Parse promotional interest rate (default to 0)
+	promoRate := decimal.Zero
+	if req.PromoInterestRate != "" {
+		var err error
+		promoRate, err = decimal.NewFromString(req.PromoInterestRate)
+		if err != nil {
+			return service.CreateProviderInput{}, &requestValidationError{Detail: "Invalid promo interest rate", Errors: []ValidationError{
+				{Field: "promoInterestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			}}
 		}
-		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create loan provider")
-		return NewInternalError(c, "Failed to create loan provider")
 	}
 
-	log.Info().Int32("workspace_id", workspaceID).Int32("provider_id", provider.ID).Str("name", provider.Name).Msg("Loan provider created")
+	return service.CreateProviderInput{
+		Name:                  req.Name,
+		CutoffDay:             req.CutoffDay,
+		DefaultInterestRate:   interestRate,
+		MonthlyFee:            monthlyFee,
+		FeeMode:               req.FeeMode,
+		PromoInterestRate:     promoRate,
+		PromoMonths:           req.PromoMonths,
+		DefaultInterestMethod: req.DefaultInterestMethod,
+	}, nil
+}
 
-	return c.JSON(http.StatusCreated, toLoanProviderResponse(provider))
+// handleCreateProviderError maps provider creation errors to HTTP responses
+func handleCreateProviderError(c echo.Context, workspaceID int32, err error) error {
+	if errors.Is(err, domain.ErrLoanProviderNameEmpty) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Code: ValidationCodeRequired, Message: "Name is required"},
+		})
+	}
+	if errors.Is(err, domain.ErrLoanProviderNameTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Code: ValidationCodeTooLong, Message: "Name must be 100 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidCutoffDay) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "cutoffDay", Code: ValidationCodeOutOfRange, Message: "Cutoff day must be between 1 and 31"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidInterestRate) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "defaultInterestRate", Code: ValidationCodeOutOfRange, Message: "Interest rate must be non-negative"},
+		})
+	}
+	if errors.Is(err, domain.ErrInterestRateTooHigh) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "defaultInterestRate", Code: ValidationCodeOutOfRange, Message: "Interest rate must be 100% or less"},
+		})
+	}
+	if resp := handlePricingError(c, err); resp != nil {
+		return resp
+	}
+	if errors.Is(err, domain.ErrLoanProviderNameExists) {
+		return NewConflictError(c, "A loan provider with this name already exists")
+	}
+	log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create loan provider")
+	return NewInternalError(c, "Failed to create loan provider")
+}
+
+// UpsertLoanProviderByName handles PUT /api/v1/loan-providers/by-name
+// Creates the provider (201) or updates the one with the same normalized name (200)
+func (h *LoanProviderHandler) UpsertLoanProviderByName(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req CreateLoanProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	input, err := parseCreateLoanProviderRequest(req)
+	if err != nil {
+		return NewRequestValidationError(c, err)
+	}
+
+	provider, created, err := h.providerService.UpsertProviderByName(workspaceID, input)
+	if err != nil {
+		return handleCreateProviderError(c, workspaceID, err)
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	log.Info().Int32("workspace_id", workspaceID).Int32("provider_id", provider.ID).Str("name", provider.Name).Bool("created", created).Msg("Loan provider upserted")
+
+	return c.JSON(status, toLoanProviderResponse(provider))
 }
 
 // GetLoanProviders handles GET /api/v1/loan-providers
@@ -189,16 +283,45 @@ func (h *LoanProviderHandler) UpdateLoanProvider(c echo.Context) error {
 		interestRate, err = decimal.NewFromString(req.DefaultInterestRate)
 		if err != nil {
 			return NewValidationError(c, "Invalid interest rate", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Must be a valid decimal number"},
+				{Field: "defaultInterestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			})
+		}
+	}
+
+	// Parse optional monthly fee (nil preserves the existing fee)
+	var monthlyFee *decimal.Decimal
+	if req.MonthlyFee != nil && *req.MonthlyFee != "" {
+		fee, err := decimal.NewFromString(*req.MonthlyFee)
+		if err != nil {
+			return NewValidationError(c, "Invalid monthly fee", []ValidationError{
+				{Field: "monthlyFee", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 			})
 		}
+		monthlyFee = &fee
+	}
+
+	// Parse optional promotional interest rate (nil preserves the existing rate)
+	var promoRate *decimal.Decimal
+	if req.PromoInterestRate != nil && *req.PromoInterestRate != "" {
+		rate, err := decimal.NewFromString(*req.PromoInterestRate)
+		if err != nil {
+			return NewValidationError(c, "Invalid promo interest rate", []ValidationError{
+				{Field: "promoInterestRate", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
+			})
+		}
+		promoRate = &rate
 	}
 
 	input := service.UpdateProviderInput{
-		Name:                req.Name,
-		CutoffDay:           req.CutoffDay,
-		DefaultInterestRate: interestRate,
-		PaymentMode:         req.PaymentMode,
+		Name:                  req.Name,
+		CutoffDay:             req.CutoffDay,
+		DefaultInterestRate:   interestRate,
+		PaymentMode:           req.PaymentMode,
+		MonthlyFee:            monthlyFee,
+		FeeMode:               req.FeeMode,
+		PromoInterestRate:     promoRate,
+		PromoMonths:           req.PromoMonths,
+		DefaultInterestMethod: req.DefaultInterestMethod,
 	}
 
 	provider, err := h.providerService.UpdateProvider(workspaceID, int32(id), input)
@@ -208,34 +331,37 @@ func (h *LoanProviderHandler) UpdateLoanProvider(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrLoanProviderNameEmpty) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name is required"},
+				{Field: "name", Code: ValidationCodeRequired, Message: "Name is required"},
 			})
 		}
 		if errors.Is(err, domain.ErrLoanProviderNameTooLong) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name must be 100 characters or less"},
+				{Field: "name", Code: ValidationCodeTooLong, Message: "Name must be 100 characters or less"},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidCutoffDay) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "cutoffDay", Message: "Cutoff day must be between 1 and 31"},
+				{Field: "cutoffDay", Code: ValidationCodeOutOfRange, Message: "Cutoff day must be between 1 and 31"},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidInterestRate) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Interest rate must be non-negative"},
+				{Field: "defaultInterestRate", Code: ValidationCodeOutOfRange, Message: "Interest rate must be non-negative"},
 			})
 		}
 		if errors.Is(err, domain.ErrInterestRateTooHigh) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "defaultInterestRate", Message: "Interest rate must be 100% or less"},
+				{Field: "defaultInterestRate", Code: ValidationCodeOutOfRange, Message: "Interest rate must be 100% or less"},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidPaymentMode) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "paymentMode", Message: "Payment mode must be 'per_item' or 'consolidated_monthly'"},
+				{Field: "paymentMode", Code: ValidationCodeInvalidChoice, Message: "Payment mode must be 'per_item' or 'consolidated_monthly'"},
 			})
 		}
+		if resp := handlePricingError(c, err); resp != nil {
+			return resp
+		}
 		if errors.Is(err, domain.ErrLoanProviderNameExists) {
 			return NewConflictError(c, "A loan provider with this name already exists")
 		}
@@ -274,17 +400,55 @@ func (h *LoanProviderHandler) DeleteLoanProvider(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// handlePricingError maps fee, promo and interest method validation errors; returns nil if err is not one of them
+func handlePricingError(c echo.Context, err error) error {
+	if errors.Is(err, domain.ErrInvalidMonthlyFee) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "monthlyFee", Code: ValidationCodeOutOfRange, Message: "Monthly fee must be non-negative"},
+		})
+	}
+	if errors.Is(err, domain.ErrExcessivePrecision) {
+		return NewValidationError(c, fmt.Sprintf("Amounts and rates must have at most %d decimal places", precisionLimit(err)), nil)
+	}
+	if errors.Is(err, domain.ErrInvalidFeeMode) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "feeMode", Code: ValidationCodeInvalidChoice, Message: "Fee mode must be 'separate' or 'folded'"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidPromoRate) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "promoInterestRate", Code: ValidationCodeOutOfRange, Message: "Promo interest rate must be between 0 and 100"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidPromoMonths) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "promoMonths", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("Promo months must be between 0 and %d", loanMonthsLimit(err))},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidInterestMethod) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "defaultInterestMethod", Code: ValidationCodeInvalidChoice, Message: "Interest method must be 'flat' or 'reducing_balance'"},
+		})
+	}
+	return nil
+}
+
 // Helper function to convert domain.LoanProvider to LoanProviderResponse
 func toLoanProviderResponse(provider *domain.LoanProvider) LoanProviderResponse {
 	resp := LoanProviderResponse{
-		ID:                  provider.ID,
-		WorkspaceID:         provider.WorkspaceID,
-		Name:                provider.Name,
-		CutoffDay:           provider.CutoffDay,
-		DefaultInterestRate: provider.DefaultInterestRate.StringFixed(2),
-		PaymentMode:         provider.PaymentMode,
-		CreatedAt:           provider.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:           provider.UpdatedAt.Format(time.RFC3339),
+		ID:                    provider.ID,
+		WorkspaceID:           provider.WorkspaceID,
+		Name:                  provider.Name,
+		CutoffDay:             provider.CutoffDay,
+		DefaultInterestRate:   provider.DefaultInterestRate.StringFixed(2),
+		PaymentMode:           provider.PaymentMode,
+		MonthlyFee:            FormatAmount(provider.MonthlyFee, domain.DefaultCurrency),
+		FeeMode:               provider.FeeMode,
+		PromoInterestRate:     provider.PromoInterestRate.StringFixed(2),
+		PromoMonths:           provider.PromoMonths,
+		DefaultInterestMethod: provider.DefaultInterestMethod,
+		CreatedAt:             provider.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             provider.UpdatedAt.Format(time.RFC3339),
 	}
 	if provider.DeletedAt != nil {
 		deletedAt := provider.DeletedAt.Format(time.RFC3339)
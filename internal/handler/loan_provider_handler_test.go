@@ -136,6 +136,42 @@ func TestCreateLoanProvider_InvalidInterestRate(t *testing.T) {
 	}
 }
 
+func TestCreateLoanProvider_MalformedMonthlyFee(t *testing.T) {
+	e := echo.New()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := service.NewLoanProviderService(providerRepo)
+	handler := NewLoanProviderHandler(providerService)
+
+	reqBody := `{"name": "Bank Test", "cutoffDay": 15, "monthlyFee": "abc"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/loan-providers", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	err := handler.CreateLoanProvider(c)
+	if err != nil {
+		t.Fatalf("Expected no error (error should be in response), got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "monthlyFee" {
+		t.Errorf("Expected a monthlyFee validation error, got %+v", problem.Errors)
+	}
+	if len(providerRepo.Providers) != 0 {
+		t.Errorf("Expected no provider to be created, got %d", len(providerRepo.Providers))
+	}
+}
+
 func TestCreateLoanProvider_NoWorkspace(t *testing.T) {
 	e := echo.New()
 	providerRepo := testutil.NewMockLoanProviderRepository()
@@ -39,6 +39,16 @@ type MonthResponse struct {
 	CreatedAt       string `json:"createdAt"`
 }
 
+// MonthSummaryResponse represents a month in the month navigation list
+type MonthSummaryResponse struct {
+	Year          int    `json:"year"`
+	Month         int    `json:"month"`
+	TotalIncome   string `json:"totalIncome"`
+	TotalExpenses string `json:"totalExpenses"`
+	Net           string `json:"net"`
+	IsClosed      bool   `json:"isClosed"`
+}
+
 // GetCurrent handles GET /api/v1/months/current
 func (h *MonthHandler) GetCurrent(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -92,13 +102,106 @@ func (h *MonthHandler) GetByYearMonth(c echo.Context) error {
 	return c.JSON(http.StatusOK, toMonthResponse(month))
 }
 
+// DeleteMonthTransactionsResponse reports how many transactions a month reset removed
+type DeleteMonthTransactionsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// DeleteMonthTransactions handles DELETE /api/v1/months/:year/:month/transactions
+// Query params: onlyUnpaid=true keeps paid transactions; includeGenerated=true allows
+// removing loan and recurring transactions
+func (h *MonthHandler) DeleteMonthTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil || year < 2000 || year > 2100 {
+		return NewValidationError(c, "Invalid year", []ValidationError{
+			{Field: "year", Message: "Year must be between 2000 and 2100"},
+		})
+	}
+
+	monthNum, err := strconv.Atoi(c.Param("month"))
+	if err != nil || monthNum < 1 || monthNum > 12 {
+		return NewValidationError(c, "Invalid month", []ValidationError{
+			{Field: "month", Message: "Month must be between 1 and 12"},
+		})
+	}
+
+	opts := service.DeleteMonthOptions{
+		OnlyUnpaid:       c.QueryParam("onlyUnpaid") == "true",
+		IncludeGenerated: c.QueryParam("includeGenerated") == "true",
+	}
+
+	deleted, err := h.monthService.DeleteMonthTransactions(workspaceID, year, monthNum, opts)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return NewValidationError(c, "Invalid month or year", nil)
+		}
+		if errors.Is(err, domain.ErrMonthClosed) {
+			return NewConflictError(c, "Cannot clear transactions in a closed month")
+		}
+		if errors.Is(err, domain.ErrGeneratedTransactionsInMonth) {
+			return NewConflictError(c, "Month contains loan or recurring transactions; set includeGenerated=true to remove them")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", monthNum).Msg("Failed to clear month transactions")
+		return NewInternalError(c, "Failed to clear month transactions")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("year", year).Int("month", monthNum).Int("deleted", deleted).Bool("only_unpaid", opts.OnlyUnpaid).Msg("Month transactions cleared")
+	return c.JSON(http.StatusOK, DeleteMonthTransactionsResponse{Deleted: deleted})
+}
+
+// AutoCloseMonthsResponse reports how many months an auto-close closed
+type AutoCloseMonthsResponse struct {
+	Closed int `json:"closed"`
+}
+
+// AutoCloseMonths handles POST /api/v1/months/auto-close?olderThanMonths=3
+// Closes open months more than olderThanMonths months before the current one
+func (h *MonthHandler) AutoCloseMonths(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	olderThanMonths, err := strconv.Atoi(c.QueryParam("olderThanMonths"))
+	if err != nil || olderThanMonths < 1 || olderThanMonths > 120 {
+		return NewValidationError(c, "Invalid olderThanMonths parameter", []ValidationError{
+			{Field: "olderThanMonths", Code: ValidationCodeOutOfRange, Message: "Must be a number between 1 and 120"},
+		})
+	}
+
+	closed, err := h.monthService.AutoCloseStaleMonths(workspaceID, olderThanMonths)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("older_than_months", olderThanMonths).Msg("Failed to auto-close months")
+		return NewInternalError(c, "Failed to auto-close months")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("older_than_months", olderThanMonths).Int("closed", closed).Msg("Stale months auto-closed")
+	return c.JSON(http.StatusOK, AutoCloseMonthsResponse{Closed: closed})
+}
+
 // GetAllMonths handles GET /api/v1/months
+// With ?limit=N, returns the N most recent months as MonthSummaryResponse items instead
 func (h *MonthHandler) GetAllMonths(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
 	if workspaceID == 0 {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > 120 {
+			return NewValidationError(c, "Invalid limit parameter", []ValidationError{
+				{Field: "limit", Code: ValidationCodeOutOfRange, Message: "Must be a number between 1 and 120"},
+			})
+		}
+		return h.listMonths(c, workspaceID, limit)
+	}
+
 	months, err := h.monthService.GetAllMonths(workspaceID)
 	if err != nil {
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get months")
@@ -113,6 +216,29 @@ func (h *MonthHandler) GetAllMonths(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// listMonths serves GET /api/v1/months?limit=N
+func (h *MonthHandler) listMonths(c echo.Context, workspaceID int32, limit int) error {
+	months, err := h.monthService.ListMonths(workspaceID, limit)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("limit", limit).Msg("Failed to list months")
+		return NewInternalError(c, "Failed to get months")
+	}
+
+	response := make([]MonthSummaryResponse, len(months))
+	for i, m := range months {
+		response[i] = MonthSummaryResponse{
+			Year:          m.Year,
+			Month:         m.Month,
+			TotalIncome:   FormatAmount(m.TotalIncome, domain.DefaultCurrency),
+			TotalExpenses: FormatAmount(m.TotalExpenses, domain.DefaultCurrency),
+			Net:           FormatAmount(m.Net, domain.DefaultCurrency),
+			IsClosed:      m.IsClosed,
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // Helper function to convert domain.CalculatedMonth to MonthResponse
 func toMonthResponse(m *domain.CalculatedMonth) MonthResponse {
 	return MonthResponse{
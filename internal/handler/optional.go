@@ -0,0 +1,25 @@
+package handler
+
+import "encoding/json"
+
+// Optional is a request field that records whether it was sent, so an update can tell
+// an omitted field (keep the stored value) from an explicit null (clear it)
+type Optional[T any] struct {
+	Set   bool // The field was present in the request body
+	Value *T   // nil when the field was sent as null
+}
+
+// UnmarshalJSON is only called for fields present in the body
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
@@ -52,6 +53,12 @@ type UpdateTemplateRequest struct {
 	SettlementIntent *string `json:"settlementIntent,omitempty"` // For CC accounts: "immediate" or "deferred"
 }
 
+// SkipOccurrenceRequest identifies the month of a template occurrence to skip or restore
+type SkipOccurrenceRequest struct {
+	Year  int `json:"year" query:"year"`
+	Month int `json:"month" query:"month"`
+}
+
 // TemplateResponse represents a recurring template in API responses
 type TemplateResponse struct {
 	ID               int32   `json:"id"`
@@ -69,9 +76,64 @@ type TemplateResponse struct {
 	UpdatedAt        string  `json:"updatedAt"`
 }
 
+// TemplateListItem represents a recurring template in list responses
+type TemplateListItem struct {
+	TemplateResponse
+	NextDueDate *string `json:"nextDueDate"` // Next occurrence (YYYY-MM-DD); null once the template has ended
+}
+
 // TemplateListResponse represents the list response
 type TemplateListResponse struct {
-	Data []TemplateResponse `json:"data"`
+	Data []TemplateListItem `json:"data"`
+}
+
+// GeneratePreviewItemResponse represents a projection generation would create
+type GeneratePreviewItemResponse struct {
+	TemplateID int32  `json:"templateId"`
+	Name       string `json:"name"`
+	Amount     string `json:"amount"`
+	Date       string `json:"date"`
+}
+
+// GeneratePreviewSkipResponse represents a template generation would skip for the month
+type GeneratePreviewSkipResponse struct {
+	TemplateID int32  `json:"templateId"`
+	Name       string `json:"name"`
+	Date       string `json:"date"`
+	Reason     string `json:"reason"` // already_generated | excluded | not_started | ended | outside_window
+}
+
+// GeneratePreviewResponse represents the generation preview for a month
+type GeneratePreviewResponse struct {
+	Year        int                           `json:"year"`
+	Month       int                           `json:"month"`
+	WouldCreate []GeneratePreviewItemResponse `json:"wouldCreate"`
+	WouldSkip   []GeneratePreviewSkipResponse `json:"wouldSkip"`
+}
+
+// GenerateAheadResponse represents what generating ahead created and skipped
+type GenerateAheadResponse struct {
+	FromMonth string                        `json:"fromMonth"`
+	ToMonth   string                        `json:"toMonth"`
+	Created   []GeneratePreviewItemResponse `json:"created"`
+	Skipped   []GeneratePreviewSkipResponse `json:"skipped"`
+}
+
+// SubscriptionResponse is one active recurring expense with its normalized costs
+type SubscriptionResponse struct {
+	TemplateID  int32  `json:"templateId"`
+	Description string `json:"description"`
+	Frequency   string `json:"frequency"`
+	Amount      string `json:"amount"`
+	MonthlyCost string `json:"monthlyCost"`
+	AnnualCost  string `json:"annualCost"`
+}
+
+// SubscriptionSummaryResponse aggregates the workspace's active recurring expenses
+type SubscriptionSummaryResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+	MonthlyTotal  string                 `json:"monthlyTotal"`
+	AnnualTotal   string                 `json:"annualTotal"`
 }
 
 // CreateTemplate handles POST /api/v1/recurring-templates
@@ -101,7 +163,7 @@ func (h *RecurringTemplateHandler) CreateTemplate(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -109,7 +171,7 @@ func (h *RecurringTemplateHandler) CreateTemplate(c echo.Context) error {
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
 		return NewValidationError(c, "Invalid start date", []ValidationError{
-			{Field: "startDate", Message: "Must be in YYYY-MM-DD format"},
+			{Field: "startDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 		})
 	}
 
@@ -130,7 +192,7 @@ func (h *RecurringTemplateHandler) CreateTemplate(c echo.Context) error {
 		endDate, err := time.Parse("2006-01-02", *req.EndDate)
 		if err != nil {
 			return NewValidationError(c, "Invalid end date", []ValidationError{
-				{Field: "endDate", Message: "Must be in YYYY-MM-DD format"},
+				{Field: "endDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
 		input.EndDate = &endDate
@@ -141,7 +203,7 @@ func (h *RecurringTemplateHandler) CreateTemplate(c echo.Context) error {
 		intent := domain.SettlementIntent(*req.SettlementIntent)
 		if intent != domain.SettlementIntentImmediate && intent != domain.SettlementIntentDeferred {
 			return NewValidationError(c, "Invalid settlement intent", []ValidationError{
-				{Field: "settlementIntent", Message: "Must be one of: immediate, deferred"},
+				{Field: "settlementIntent", Code: ValidationCodeInvalidChoice, Message: "Must be one of: immediate, deferred"},
 			})
 		}
 		input.SettlementIntent = &intent
@@ -159,10 +221,15 @@ func (h *RecurringTemplateHandler) CreateTemplate(c echo.Context) error {
 
 // ListTemplates handles GET /api/v1/recurring-templates
 // @Summary List all recurring templates
-// @Description Retrieves all recurring templates for the workspace
+// @Description Retrieves recurring templates for the workspace, optionally filtered by account, category and active status
 // @Tags Recurring Templates
 // @Produce json
+// @Param accountId query int false "Filter by account ID"
+// @Param categoryId query int false "Filter by category ID"
+// @Param active query bool false "Filter by active status"
+// @Param tz query string false "IANA timezone used to resolve today for nextDueDate (default UTC)"
 // @Success 200 {object} TemplateListResponse
+// @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
 // @Security BearerAuth
 // @Router /recurring-templates [get]
@@ -172,20 +239,164 @@ func (h *RecurringTemplateHandler) ListTemplates(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
-	templates, err := h.service.ListTemplates(workspaceID)
+	filter := &domain.RecurringFilter{}
+	if accountIDStr := c.QueryParam("accountId"); accountIDStr != "" {
+		accountID, err := strconv.Atoi(accountIDStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid account ID", []ValidationError{
+				{Field: "accountId", Code: ValidationCodeInvalidFormat, Message: "Must be a valid integer"},
+			})
+		}
+		id := int32(accountID)
+		filter.AccountID = &id
+	}
+	if categoryIDStr := c.QueryParam("categoryId"); categoryIDStr != "" {
+		categoryID, err := strconv.Atoi(categoryIDStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid category ID", []ValidationError{
+				{Field: "categoryId", Code: ValidationCodeInvalidFormat, Message: "Must be a valid integer"},
+			})
+		}
+		id := int32(categoryID)
+		filter.CategoryID = &id
+	}
+	if activeStr := c.QueryParam("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid active filter", []ValidationError{
+				{Field: "active", Code: ValidationCodeInvalidFormat, Message: "Must be true or false"},
+			})
+		}
+		filter.Active = &active
+	}
+
+	// Resolve "today" in the workspace timezone for next due dates
+	tz, err := parseTimezoneQuery(c)
 	if err != nil {
-		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to list recurring templates")
-		return NewInternalError(c, "Failed to list recurring templates")
+		return NewRequestValidationError(c, err)
 	}
+	now := time.Now().In(tz)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
-	response := make([]TemplateResponse, len(templates))
+	templates, err := h.service.ListTemplates(workspaceID, filter)
+	if err != nil {
+		return h.handleServiceError(c, err, workspaceID, "list recurring templates")
+	}
+
+	response := make([]TemplateListItem, len(templates))
 	for i, t := range templates {
-		response[i] = toTemplateResponse(t)
+		response[i] = TemplateListItem{TemplateResponse: toTemplateResponse(t)}
+		if next := service.NextDueDate(t, today); next != nil {
+			nextDueDate := next.Format("2006-01-02")
+			response[i].NextDueDate = &nextDueDate
+		}
 	}
 
 	return c.JSON(http.StatusOK, TemplateListResponse{Data: response})
 }
 
+// PreviewGeneration handles GET /api/v1/recurring-templates/preview
+// @Summary Preview recurring generation for a month
+// @Description Lists the projections generation would create for the month and the templates it would skip, without persisting anything
+// @Tags Recurring Templates
+// @Produce json
+// @Param year query int false "Year (defaults to current)"
+// @Param month query int false "Month 1-12 (defaults to current)"
+// @Success 200 {object} GeneratePreviewResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/preview [get]
+func (h *RecurringTemplateHandler) PreviewGeneration(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	year, month, err := parseYearMonthQuery(c)
+	if err != nil {
+		return err
+	}
+
+	preview, err := h.service.PreviewGeneration(workspaceID, year, time.Month(month))
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("year", year).Int("month", month).Msg("Failed to preview recurring generation")
+		return NewInternalError(c, "Failed to preview recurring generation")
+	}
+
+	response := GeneratePreviewResponse{
+		Year:        preview.Year,
+		Month:       int(preview.Month),
+		WouldCreate: make([]GeneratePreviewItemResponse, len(preview.WouldCreate)),
+		WouldSkip:   make([]GeneratePreviewSkipResponse, len(preview.WouldSkip)),
+	}
+	for i, item := range preview.WouldCreate {
+		response.WouldCreate[i] = GeneratePreviewItemResponse{
+			TemplateID: item.TemplateID,
+			Name:       item.Name,
+			Amount:     FormatAmount(item.Amount, domain.DefaultCurrency),
+			Date:       item.Date.Format("2006-01-02"),
+		}
+	}
+	for i, skip := range preview.WouldSkip {
+		response.WouldSkip[i] = GeneratePreviewSkipResponse{
+			TemplateID: skip.TemplateID,
+			Name:       skip.Name,
+			Date:       skip.Date.Format("2006-01-02"),
+			Reason:     string(skip.Reason),
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GenerateAhead handles POST /api/v1/recurring-templates/generate-ahead
+// @Summary Generate projections ahead
+// @Description Creates missing projections from the current month through the workspace's generation horizon; months already generated or skipped are left alone
+// @Tags Recurring Templates
+// @Produce json
+// @Success 200 {object} GenerateAheadResponse
+// @Failure 401 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/generate-ahead [post]
+func (h *RecurringTemplateHandler) GenerateAhead(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	result, err := h.service.GenerateAhead(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to generate recurring projections ahead")
+		return NewInternalError(c, "Failed to generate recurring projections")
+	}
+
+	response := GenerateAheadResponse{
+		FromMonth: result.FromMonth,
+		ToMonth:   result.ToMonth,
+		Created:   make([]GeneratePreviewItemResponse, len(result.Created)),
+		Skipped:   make([]GeneratePreviewSkipResponse, len(result.Skipped)),
+	}
+	for i, item := range result.Created {
+		response.Created[i] = GeneratePreviewItemResponse{
+			TemplateID: item.TemplateID,
+			Name:       item.Name,
+			Amount:     FormatAmount(item.Amount, domain.DefaultCurrency),
+			Date:       item.Date.Format("2006-01-02"),
+		}
+	}
+	for i, skip := range result.Skipped {
+		response.Skipped[i] = GeneratePreviewSkipResponse{
+			TemplateID: skip.TemplateID,
+			Name:       skip.Name,
+			Date:       skip.Date.Format("2006-01-02"),
+			Reason:     string(skip.Reason),
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // GetTemplate handles GET /api/v1/recurring-templates/:id
 // @Summary Get a recurring template
 // @Description Retrieves a single recurring template by ID
@@ -220,6 +431,46 @@ func (h *RecurringTemplateHandler) GetTemplate(c echo.Context) error {
 	return c.JSON(http.StatusOK, toTemplateResponse(template))
 }
 
+// GetTemplateTransactions handles GET /api/v1/recurring-templates/:id/transactions
+// @Summary List transactions generated by a recurring template
+// @Description Retrieves all projected and actual transactions linked to a template, newest first
+// @Tags Recurring Templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {array} TransactionResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/{id}/transactions [get]
+func (h *RecurringTemplateHandler) GetTemplateTransactions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid template ID", nil)
+	}
+
+	transactions, err := h.service.GetTransactionsByRecurring(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrRecurringTemplateNotFound) {
+			return NewNotFoundError(c, "Recurring template not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("template_id", id).Msg("Failed to get recurring template transactions")
+		return NewInternalError(c, "Failed to get recurring template transactions")
+	}
+
+	response := make([]TransactionResponse, len(transactions))
+	for i, tx := range transactions {
+		response[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // UpdateTemplate handles PUT /api/v1/recurring-templates/:id
 // @Summary Update a recurring template
 // @Description Updates a recurring template and recalculates projections
@@ -254,7 +505,7 @@ func (h *RecurringTemplateHandler) UpdateTemplate(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -262,7 +513,7 @@ func (h *RecurringTemplateHandler) UpdateTemplate(c echo.Context) error {
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
 		return NewValidationError(c, "Invalid start date", []ValidationError{
-			{Field: "startDate", Message: "Must be in YYYY-MM-DD format"},
+			{Field: "startDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 		})
 	}
 
@@ -281,7 +532,7 @@ func (h *RecurringTemplateHandler) UpdateTemplate(c echo.Context) error {
 		endDate, err := time.Parse("2006-01-02", *req.EndDate)
 		if err != nil {
 			return NewValidationError(c, "Invalid end date", []ValidationError{
-				{Field: "endDate", Message: "Must be in YYYY-MM-DD format"},
+				{Field: "endDate", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
 		input.EndDate = &endDate
@@ -292,7 +543,7 @@ func (h *RecurringTemplateHandler) UpdateTemplate(c echo.Context) error {
 		intent := domain.SettlementIntent(*req.SettlementIntent)
 		if intent != domain.SettlementIntentImmediate && intent != domain.SettlementIntentDeferred {
 			return NewValidationError(c, "Invalid settlement intent", []ValidationError{
-				{Field: "settlementIntent", Message: "Must be one of: immediate, deferred"},
+				{Field: "settlementIntent", Code: ValidationCodeInvalidChoice, Message: "Must be one of: immediate, deferred"},
 			})
 		}
 		input.SettlementIntent = &intent
@@ -313,6 +564,7 @@ func (h *RecurringTemplateHandler) UpdateTemplate(c echo.Context) error {
 // @Description Deletes a recurring template and all its projections
 // @Tags Recurring Templates
 // @Param id path int true "Template ID"
+// @Param keepGenerated query bool false "Keep generated transactions (detached from template); false also deletes future unpaid ones" default(true)
 // @Success 204 "No Content"
 // @Failure 401 {object} ProblemDetails
 // @Failure 404 {object} ProblemDetails
@@ -329,7 +581,18 @@ func (h *RecurringTemplateHandler) DeleteTemplate(c echo.Context) error {
 		return NewValidationError(c, "Invalid template ID", nil)
 	}
 
-	if err := h.service.DeleteTemplate(workspaceID, int32(id)); err != nil {
+	// Generated transactions are kept (detached) unless explicitly disabled
+	keepGenerated := true
+	if keepParam := c.QueryParam("keepGenerated"); keepParam != "" {
+		keepGenerated, err = strconv.ParseBool(keepParam)
+		if err != nil {
+			return NewValidationError(c, "Invalid keepGenerated parameter", []ValidationError{
+				{Field: "keepGenerated", Code: ValidationCodeInvalidFormat, Message: "Must be true or false"},
+			})
+		}
+	}
+
+	if err := h.service.DeleteTemplate(workspaceID, int32(id), keepGenerated); err != nil {
 		if errors.Is(err, domain.ErrRecurringTemplateNotFound) {
 			return NewNotFoundError(c, "Recurring template not found")
 		}
@@ -337,7 +600,86 @@ func (h *RecurringTemplateHandler) DeleteTemplate(c echo.Context) error {
 		return NewInternalError(c, "Failed to delete recurring template")
 	}
 
-	log.Info().Int32("workspace_id", workspaceID).Int("template_id", id).Msg("Recurring template deleted")
+	log.Info().Int32("workspace_id", workspaceID).Int("template_id", id).Bool("keep_generated", keepGenerated).Msg("Recurring template deleted")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SkipOccurrence handles POST /api/v1/recurring-templates/:id/skip
+// @Summary Skip one occurrence of a recurring template
+// @Description Skips a single month without deactivating the template; its unpaid projection is removed and never regenerated
+// @Tags Recurring Templates
+// @Accept json
+// @Param id path int true "Template ID"
+// @Param occurrence body SkipOccurrenceRequest true "Month to skip"
+// @Success 204 "No Content"
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/{id}/skip [post]
+func (h *RecurringTemplateHandler) SkipOccurrence(c echo.Context) error {
+	return h.setOccurrenceSkipped(c, true)
+}
+
+// UnskipOccurrence handles DELETE /api/v1/recurring-templates/:id/skip
+// @Summary Restore a skipped occurrence of a recurring template
+// @Description Removes a month's skip marker so the occurrence is generated again
+// @Tags Recurring Templates
+// @Param id path int true "Template ID"
+// @Param year query int true "Year of the skipped occurrence"
+// @Param month query int true "Month of the skipped occurrence (1-12)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/{id}/skip [delete]
+func (h *RecurringTemplateHandler) UnskipOccurrence(c echo.Context) error {
+	return h.setOccurrenceSkipped(c, false)
+}
+
+// setOccurrenceSkipped skips or restores the occurrence month named by the request
+func (h *RecurringTemplateHandler) setOccurrenceSkipped(c echo.Context, skip bool) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid template ID", nil)
+	}
+
+	var req SkipOccurrenceRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	var errs []ValidationError
+	if req.Year < 1 {
+		errs = append(errs, ValidationError{Field: "year", Code: ValidationCodeRequired, Message: "Year is required"})
+	}
+	if req.Month < 1 || req.Month > 12 {
+		errs = append(errs, ValidationError{Field: "month", Code: ValidationCodeOutOfRange, Message: "Month must be between 1 and 12"})
+	}
+	if len(errs) > 0 {
+		return NewValidationError(c, "Validation failed", errs)
+	}
+
+	if skip {
+		err = h.service.SkipOccurrence(workspaceID, int32(id), req.Year, time.Month(req.Month))
+	} else {
+		err = h.service.UnskipOccurrence(workspaceID, int32(id), req.Year, time.Month(req.Month))
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrRecurringTemplateNotFound) {
+			return NewNotFoundError(c, "Recurring template not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("template_id", id).Bool("skip", skip).Msg("Failed to update skipped occurrence")
+		return NewInternalError(c, "Failed to update skipped occurrence")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int("template_id", id).Int("year", req.Year).Int("month", req.Month).Bool("skip", skip).Msg("Recurring occurrence skip updated")
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -348,32 +690,32 @@ func (h *RecurringTemplateHandler) handleServiceError(c echo.Context, err error,
 	}
 	if errors.Is(err, domain.ErrNameRequired) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "description", Message: "Description is required"},
+			{Field: "description", Code: ValidationCodeRequired, Message: "Description is required"},
 		})
 	}
 	if errors.Is(err, domain.ErrNameTooLong) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "description", Message: "Description must be 255 characters or less"},
+			{Field: "description", Code: ValidationCodeTooLong, Message: "Description must be 255 characters or less"},
 		})
 	}
 	if errors.Is(err, domain.ErrInvalidAmount) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "amount", Message: "Amount must be positive"},
+			{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
 		})
 	}
 	if errors.Is(err, domain.ErrInvalidFrequency) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "frequency", Message: "Frequency must be 'monthly'"},
+			{Field: "frequency", Code: ValidationCodeInvalidChoice, Message: "Frequency must be 'monthly'"},
 		})
 	}
 	if errors.Is(err, domain.ErrAccountNotFound) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "accountId", Message: "Account not found"},
+			{Field: "accountId", Code: ValidationCodeNotFound, Message: "Account not found"},
 		})
 	}
 	if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "categoryId", Message: "Category not found"},
+			{Field: "categoryId", Code: ValidationCodeNotFound, Message: "Category not found"},
 		})
 	}
 	log.Error().Err(err).Int32("workspace_id", workspaceID).Str("operation", operation).Msg("Failed to " + operation)
@@ -405,3 +747,43 @@ func toTemplateResponse(t *domain.RecurringTemplate) TemplateResponse {
 	}
 	return resp
 }
+
+// GetSubscriptions handles GET /api/v1/recurring-templates/subscriptions
+// @Summary Get subscription costs
+// @Description Lists active recurring expenses with monthly and annualized costs
+// @Tags Recurring Templates
+// @Produce json
+// @Success 200 {object} SubscriptionSummaryResponse
+// @Failure 401 {object} ProblemDetails
+// @Security BearerAuth
+// @Router /recurring-templates/subscriptions [get]
+func (h *RecurringTemplateHandler) GetSubscriptions(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	summary, err := h.service.GetSubscriptions(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get subscriptions")
+		return NewInternalError(c, "Failed to get subscriptions")
+	}
+
+	response := SubscriptionSummaryResponse{
+		Subscriptions: make([]SubscriptionResponse, len(summary.Subscriptions)),
+		MonthlyTotal:  FormatAmount(summary.MonthlyTotal, domain.DefaultCurrency),
+		AnnualTotal:   FormatAmount(summary.AnnualTotal, domain.DefaultCurrency),
+	}
+	for i, sub := range summary.Subscriptions {
+		response.Subscriptions[i] = SubscriptionResponse{
+			TemplateID:  sub.TemplateID,
+			Description: sub.Description,
+			Frequency:   sub.Frequency,
+			Amount:      FormatAmount(sub.Amount, domain.DefaultCurrency),
+			MonthlyCost: FormatAmount(sub.MonthlyCost, domain.DefaultCurrency),
+			AnnualCost:  FormatAmount(sub.AnnualCost, domain.DefaultCurrency),
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
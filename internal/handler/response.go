@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -17,22 +18,37 @@ type ProblemDetails struct {
 }
 
 // ValidationError represents a single validation error
+// Code is a stable, machine-readable reason (one of the ValidationCode constants) clients can localize
 type ValidationError struct {
 	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
 }
 
+// Validation error codes
+const (
+	ValidationCodeRequired      = "required"       // value missing or empty
+	ValidationCodeTooLong       = "too_long"       // text exceeds its maximum length
+	ValidationCodeOutOfRange    = "out_of_range"   // number or date outside the allowed bounds
+	ValidationCodeInvalidFormat = "invalid_format" // value could not be parsed (dates, decimals, booleans)
+	ValidationCodeInvalidChoice = "invalid_choice" // value is not one of the allowed options
+	ValidationCodeNotFound      = "not_found"      // referenced record does not exist
+	ValidationCodeInvalid       = "invalid"        // value conflicts with other fields or existing data
+)
+
 // Error types
 const (
-	ErrorTypeValidation   = "https://fortuna.app/errors/validation"
-	ErrorTypeNotFound     = "https://fortuna.app/errors/not-found"
-	ErrorTypeUnauthorized = "https://fortuna.app/errors/unauthorized"
-	ErrorTypeForbidden    = "https://fortuna.app/errors/forbidden"
-	ErrorTypeConflict     = "https://fortuna.app/errors/conflict"
-	ErrorTypeUnavailable      = "https://fortuna.app/errors/unavailable"
-	ErrorTypeInternal         = "https://fortuna.app/errors/internal"
-	ErrorTypeMonthBoundary    = "https://fortuna.app/errors/month-boundary-violation"
-	ErrorTypeAlreadyGrouped   = "https://fortuna.app/errors/already-grouped"
+	ErrorTypeValidation        = "https://fortuna.app/errors/validation"
+	ErrorTypeNotFound          = "https://fortuna.app/errors/not-found"
+	ErrorTypeUnauthorized      = "https://fortuna.app/errors/unauthorized"
+	ErrorTypeForbidden         = "https://fortuna.app/errors/forbidden"
+	ErrorTypeConflict          = "https://fortuna.app/errors/conflict"
+	ErrorTypeUnavailable       = "https://fortuna.app/errors/unavailable"
+	ErrorTypeInternal          = "https://fortuna.app/errors/internal"
+	ErrorTypeTimeout           = "https://fortuna.app/errors/timeout"
+	ErrorTypeMonthBoundary     = "https://fortuna.app/errors/month-boundary-violation"
+	ErrorTypeAlreadyGrouped    = "https://fortuna.app/errors/already-grouped"
+	ErrorTypeSequentialPayment = "https://fortuna.app/errors/sequential-payment"
 )
 
 // NewValidationError creates a validation error response
@@ -47,6 +63,25 @@ func NewValidationError(c echo.Context, detail string, errors []ValidationError)
 	})
 }
 
+// requestValidationError is a request parsing failure that handlers report as a validation response
+type requestValidationError struct {
+	Detail string
+	Errors []ValidationError
+}
+
+func (e *requestValidationError) Error() string {
+	return e.Detail
+}
+
+// NewRequestValidationError writes the validation response for an error returned by a request parser
+func NewRequestValidationError(c echo.Context, err error) error {
+	var reqErr *requestValidationError
+	if errors.As(err, &reqErr) {
+		return NewValidationError(c, reqErr.Detail, reqErr.Errors)
+	}
+	return NewValidationError(c, err.Error(), nil)
+}
+
 // NewNotFoundError creates a not found error response
 func NewNotFoundError(c echo.Context, detail string) error {
 	return c.JSON(http.StatusNotFound, ProblemDetails{
@@ -112,3 +147,15 @@ func NewServiceUnavailableError(c echo.Context, detail string) error {
 		Instance: c.Request().URL.Path,
 	})
 }
+
+// NewGatewayTimeoutError creates a gateway timeout error response
+// Used when a request's database deadline (see middleware.DBTimeout) expires
+func NewGatewayTimeoutError(c echo.Context, detail string) error {
+	return c.JSON(http.StatusGatewayTimeout, ProblemDetails{
+		Type:     ErrorTypeTimeout,
+		Title:    "Gateway Timeout",
+		Status:   http.StatusGatewayTimeout,
+		Detail:   detail,
+		Instance: c.Request().URL.Path,
+	})
+}
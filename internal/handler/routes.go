@@ -1,18 +1,22 @@
 package handler
 
 import (
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/labstack/echo/v4"
 )
 
 // RegisterRoutes sets up all API routes
-func RegisterRoutes(e *echo.Echo, dualAuth *middleware.DualAuthMiddleware, rateLimiter *middleware.RateLimiter, authHandler *AuthHandler, profileHandler *ProfileHandler, accountHandler *AccountHandler, transactionHandler *TransactionHandler, monthHandler *MonthHandler, dashboardHandler *DashboardHandler, budgetCategoryHandler *BudgetCategoryHandler, budgetHandler *BudgetHandler, ccHandler *CCHandler, recurringTemplateHandler *RecurringTemplateHandler, loanProviderHandler *LoanProviderHandler, loanHandler *LoanHandler, loanPaymentHandler *LoanPaymentHandler, wishlistHandler *WishlistHandler, wishlistItemHandler *WishlistItemHandler, wishlistPriceHandler *WishlistPriceHandler, wishlistNoteHandler *WishlistNoteHandler, imageHandler *ImageHandler, wsHandler *WebSocketHandler, apiTokenHandler *APITokenHandler, settlementHandler *SettlementHandler, transactionGroupHandler *TransactionGroupHandler) {
+func RegisterRoutes(e *echo.Echo, dualAuth *middleware.DualAuthMiddleware, rateLimiter *middleware.RateLimiter, authHandler *AuthHandler, profileHandler *ProfileHandler, accountHandler *AccountHandler, accountGroupHandler *AccountGroupHandler, transactionHandler *TransactionHandler, monthHandler *MonthHandler, dashboardHandler *DashboardHandler, budgetCategoryHandler *BudgetCategoryHandler, budgetHandler *BudgetHandler, ccHandler *CCHandler, recurringTemplateHandler *RecurringTemplateHandler, loanProviderHandler *LoanProviderHandler, loanHandler *LoanHandler, loanPaymentHandler *LoanPaymentHandler, wishlistHandler *WishlistHandler, wishlistItemHandler *WishlistItemHandler, wishlistPriceHandler *WishlistPriceHandler, wishlistNoteHandler *WishlistNoteHandler, imageHandler *ImageHandler, wsHandler *WebSocketHandler, apiTokenHandler *APITokenHandler, settlementHandler *SettlementHandler, transactionGroupHandler *TransactionGroupHandler, workspaceHandler *WorkspaceHandler, searchHandler *SearchHandler) {
 	// WebSocket route (auth via query param token)
 	e.GET("/ws", wsHandler.HandleWS)
 
 	// API version 1
 	api := e.Group("/api/v1")
 
+	// Viewers can read workspace data; changing it requires at least editor
+	requireEditor := middleware.RequireRole(domain.WorkspaceRoleEditor)
+
 	// Auth routes (JWT only - session management)
 	auth := api.Group("/auth")
 	auth.Use(dualAuth.JWTOnly())
@@ -20,6 +24,25 @@ func RegisterRoutes(e *echo.Echo, dualAuth *middleware.DualAuthMiddleware, rateL
 	auth.GET("/me", authHandler.Me)
 	auth.POST("/logout", authHandler.Logout)
 
+	// Workspace listing (JWT only - API tokens are scoped to a single workspace)
+	workspaces := api.Group("/workspaces")
+	workspaces.Use(dualAuth.JWTOnly())
+	workspaces.GET("", authHandler.ListWorkspaces)
+
+	// Workspace sharing routes (JWT only - invites are tied to a user's email)
+	workspace := api.Group("/workspace")
+	workspace.Use(dualAuth.JWTOnly())
+	workspace.POST("/invites", workspaceHandler.CreateInvite)
+	workspace.POST("/invites/:token/accept", workspaceHandler.AcceptInvite)
+
+	// Workspace settings (owners change them; any member can read them)
+	workspaceSettings := api.Group("/workspace/settings")
+	workspaceSettings.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
+	workspaceSettings.GET("", workspaceHandler.GetSettings)
+	workspaceSettings.PUT("", workspaceHandler.UpdateSettings, middleware.RequireRole(domain.WorkspaceRoleOwner))
+	workspaceSettings.PUT("/round-up", workspaceHandler.UpdateRoundUpSavings, middleware.RequireRole(domain.WorkspaceRoleOwner))
+	workspaceSettings.PUT("/generation-horizon", workspaceHandler.UpdateGenerationHorizon, middleware.RequireRole(domain.WorkspaceRoleOwner))
+
 	// Profile routes (JWT only - user settings)
 	profile := api.Group("/profile")
 	profile.Use(dualAuth.JWTOnly())
@@ -29,172 +52,247 @@ func RegisterRoutes(e *echo.Echo, dualAuth *middleware.DualAuthMiddleware, rateL
 	// Account routes (dual auth with rate limiting)
 	accounts := api.Group("/accounts")
 	accounts.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	accounts.POST("", accountHandler.CreateAccount)
+	accounts.POST("", accountHandler.CreateAccount, requireEditor)
 	accounts.GET("", accountHandler.GetAccounts)
 	accounts.GET("/cc-summary", accountHandler.GetCCSummary)
-	accounts.PUT("/:id", accountHandler.UpdateAccount)
-	accounts.DELETE("/:id", accountHandler.DeleteAccount)
+	accounts.PUT("/:id", accountHandler.UpdateAccount, requireEditor)
+	accounts.DELETE("/:id", accountHandler.DeleteAccount, requireEditor)
+	accounts.PUT("/:id/group", accountHandler.AssignAccountGroup, requireEditor)
+	accounts.PUT("/:id/template", accountHandler.ChangeAccountTemplate, requireEditor)
+	accounts.GET("/:id/balance", accountHandler.GetBalanceAsOf)
+	accounts.POST("/:id/reconcile", accountHandler.ReconcileAccount, requireEditor)
+	accounts.GET("/:id/cc-state-breakdown", ccHandler.GetCCStateBreakdown)
+	accounts.POST("/:id/close-cycle", ccHandler.CloseBillingCycle, requireEditor)
+
+	// Account group (folder) routes (dual auth with rate limiting)
+	accountGroups := api.Group("/account-groups")
+	accountGroups.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
+	accountGroups.POST("", accountGroupHandler.CreateGroup, requireEditor)
+	accountGroups.GET("", accountGroupHandler.GetGroups)
+	accountGroups.PUT("/:id", accountGroupHandler.UpdateGroup, requireEditor)
+	accountGroups.DELETE("/:id", accountGroupHandler.DeleteGroup, requireEditor)
 
 	// Transaction routes (dual auth with rate limiting)
 	transactions := api.Group("/transactions")
 	transactions.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	transactions.POST("", transactionHandler.CreateTransaction)
+	transactions.POST("", transactionHandler.CreateTransaction, requireEditor)
 	transactions.GET("", transactionHandler.GetTransactions)
 	transactions.GET("/categories/recent", transactionHandler.GetRecentlyUsedCategories)
+	transactions.GET("/sources", transactionHandler.GetTransactionSources)
 	transactions.GET("/cc-metrics", transactionHandler.GetCCMetrics)
-	transactions.PUT("/:id", transactionHandler.UpdateTransaction)
-	transactions.DELETE("/:id", transactionHandler.DeleteTransaction)
-	transactions.PATCH("/:id/toggle-paid", transactionHandler.TogglePaidStatus)
-	transactions.PATCH("/:id/toggle-billed", transactionHandler.ToggleBilled)
-	transactions.POST("/transfers", transactionHandler.CreateTransfer)
-	transactions.POST("/batch-toggle-billed", transactionHandler.BatchToggleBilled)
+	transactions.PUT("/:id", transactionHandler.UpdateTransaction, requireEditor)
+	transactions.DELETE("/:id", transactionHandler.DeleteTransaction, requireEditor)
+	transactions.POST("/:id/clone", transactionHandler.CloneTransaction, requireEditor)
+	transactions.PATCH("/:id/toggle-paid", transactionHandler.TogglePaidStatus, requireEditor)
+	transactions.PATCH("/:id/toggle-billed", transactionHandler.ToggleBilled, requireEditor)
+	transactions.POST("/transfers", transactionHandler.CreateTransfer, requireEditor)
+	transactions.POST("/transfers/:id/reverse", transactionHandler.ReverseTransfer, requireEditor)
+	transactions.POST("/batch-toggle-billed", transactionHandler.BatchToggleBilled, requireEditor)
+	transactions.PATCH("/cc-state", transactionHandler.BatchTransitionCCState, requireEditor)
+	transactions.PATCH("/bulk-account", transactionHandler.BulkMoveAccount, requireEditor)
 	transactions.GET("/deferred-to-settle", transactionHandler.GetDeferredToSettle)
 	transactions.GET("/immediate-to-settle", transactionHandler.GetImmediateToSettle)
 	transactions.GET("/pending-deferred", transactionHandler.GetPendingDeferred)
 	transactions.GET("/overdue", transactionHandler.GetOverdue)
-	transactions.PATCH("/:id/amount", transactionHandler.UpdateAmount)
+	transactions.GET("/uncategorized", transactionHandler.GetUncategorized)
+	transactions.POST("/scheduled/promote", transactionHandler.PromoteScheduled, requireEditor)
+	transactions.PATCH("/:id/amount", transactionHandler.UpdateAmount, requireEditor)
 
 	// Month routes (dual auth with rate limiting)
 	months := api.Group("/months")
 	months.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	months.GET("/current", monthHandler.GetCurrent)
 	months.GET("/:year/:month", monthHandler.GetByYearMonth)
+	months.DELETE("/:year/:month/transactions", monthHandler.DeleteMonthTransactions, requireEditor)
+	months.POST("/auto-close", monthHandler.AutoCloseMonths, requireEditor)
 	months.GET("", monthHandler.GetAllMonths)
 
+	// Global search route (dual auth with rate limiting)
+	search := api.Group("/search")
+	search.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
+	search.GET("", searchHandler.GlobalSearch)
+
 	// Dashboard routes (dual auth with rate limiting)
 	dashboard := api.Group("/dashboard")
 	dashboard.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	dashboard.GET("/summary", dashboardHandler.GetSummary)
 	dashboard.GET("/future-spending", dashboardHandler.GetFutureSpending)
+	dashboard.GET("/merchants", dashboardHandler.GetMerchantSummary)
+	dashboard.GET("/payees", dashboardHandler.GetPayeeSummary)
+	dashboard.GET("/obligations", dashboardHandler.GetObligations)
+	dashboard.GET("/velocity", dashboardHandler.GetSpendingVelocity)
+	dashboard.GET("/largest", dashboardHandler.GetLargestTransactions)
+	dashboard.GET("/spendable", dashboardHandler.GetSpendableCash)
+	dashboard.GET("/dti", dashboardHandler.GetDebtToIncome)
+	dashboard.GET("/savings-rate", dashboardHandler.GetSavingsRate)
+
+	// Report routes (dual auth with rate limiting)
+	reports := api.Group("/reports")
+	reports.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
+	reports.GET("/tax", dashboardHandler.GetTaxReport)
 
 	// Budget Category routes (dual auth with rate limiting)
 	budgetCategories := api.Group("/budget-categories")
 	budgetCategories.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	budgetCategories.POST("", budgetCategoryHandler.CreateCategory)
+	budgetCategories.POST("", budgetCategoryHandler.CreateCategory, requireEditor)
 	budgetCategories.GET("", budgetCategoryHandler.GetCategories)
-	budgetCategories.PUT("/:id", budgetCategoryHandler.UpdateCategory)
-	budgetCategories.DELETE("/:id", budgetCategoryHandler.DeleteCategory)
+	budgetCategories.PUT("/:id", budgetCategoryHandler.UpdateCategory, requireEditor)
+	budgetCategories.DELETE("/:id", budgetCategoryHandler.DeleteCategory, requireEditor)
 	budgetCategories.GET("/:id/can-delete", budgetCategoryHandler.CanDeleteCategory)
+	budgetCategories.GET("/:id/transactions", budgetCategoryHandler.GetCategoryTransactions)
 
 	// Budget Allocation routes (dual auth with rate limiting)
 	budgets := api.Group("/budgets")
 	budgets.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	budgets.GET("/:year/:month", budgetHandler.GetAllocations)
-	budgets.PUT("/:year/:month", budgetHandler.SetAllocations)
-	budgets.PUT("/:year/:month/:categoryId", budgetHandler.SetAllocation)
+	budgets.PUT("/:year/:month", budgetHandler.SetAllocations, requireEditor)
+	budgets.PUT("/:year/:month/:categoryId", budgetHandler.SetAllocation, requireEditor)
 	budgets.GET("/:year/:month/:categoryId/transactions", budgetHandler.GetCategoryTransactions)
 
 	// Credit Card routes (dual auth with rate limiting)
 	cc := api.Group("/cc")
 	cc.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	cc.POST("/payments", ccHandler.CreateCCPayment)
+	cc.POST("/payments", ccHandler.CreateCCPayment, requireEditor)
+	cc.POST("/settle-deferred", ccHandler.SettleDeferredForMonth, requireEditor)
 
 	// Settlement routes (dual auth with rate limiting)
 	settlements := api.Group("/settlements")
 	settlements.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	settlements.POST("", settlementHandler.Create)
+	settlements.POST("", settlementHandler.Create, requireEditor)
 
 	// Transaction Group routes (dual auth with rate limiting)
 	transactionGroups := api.Group("/transaction-groups")
 	transactionGroups.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	transactionGroups.GET("", transactionGroupHandler.GetGroupsByMonth)
-	transactionGroups.POST("", transactionGroupHandler.CreateGroup)
-	transactionGroups.PUT("/:id", transactionGroupHandler.RenameGroup)
-	transactionGroups.POST("/:id/transactions", transactionGroupHandler.AddTransactions)
-	transactionGroups.DELETE("/:id", transactionGroupHandler.DeleteGroup)
-	transactionGroups.DELETE("/:id/transactions", transactionGroupHandler.RemoveTransactions)
+	transactionGroups.GET("/:id", transactionGroupHandler.GetGroup)
+	transactionGroups.GET("/:id/delete-check", transactionGroupHandler.GetDeleteCheck)
+	transactionGroups.POST("", transactionGroupHandler.CreateGroup, requireEditor)
+	transactionGroups.PUT("/:id", transactionGroupHandler.RenameGroup, requireEditor)
+	transactionGroups.POST("/:id/transactions", transactionGroupHandler.AddTransactions, requireEditor)
+	transactionGroups.POST("/:id/add-by-filter", transactionGroupHandler.AddTransactionsByFilter, requireEditor)
+	transactionGroups.POST("/:id/recalculate", transactionGroupHandler.RecalculateGroup, requireEditor)
+	transactionGroups.DELETE("/:id", transactionGroupHandler.DeleteGroup, requireEditor)
+	transactionGroups.DELETE("/:id/transactions", transactionGroupHandler.RemoveTransactions, requireEditor)
 
 	// Recurring Templates routes (dual auth with rate limiting)
 	recurringTemplates := api.Group("/recurring-templates")
 	recurringTemplates.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	recurringTemplates.POST("", recurringTemplateHandler.CreateTemplate)
+	recurringTemplates.POST("", recurringTemplateHandler.CreateTemplate, requireEditor)
 	recurringTemplates.GET("", recurringTemplateHandler.ListTemplates)
+	recurringTemplates.GET("/preview", recurringTemplateHandler.PreviewGeneration)
+	recurringTemplates.POST("/generate-ahead", recurringTemplateHandler.GenerateAhead, requireEditor)
+	recurringTemplates.GET("/subscriptions", recurringTemplateHandler.GetSubscriptions)
 	recurringTemplates.GET("/:id", recurringTemplateHandler.GetTemplate)
-	recurringTemplates.PUT("/:id", recurringTemplateHandler.UpdateTemplate)
-	recurringTemplates.DELETE("/:id", recurringTemplateHandler.DeleteTemplate)
+	recurringTemplates.GET("/:id/transactions", recurringTemplateHandler.GetTemplateTransactions)
+	recurringTemplates.POST("/:id/skip", recurringTemplateHandler.SkipOccurrence, requireEditor)
+	recurringTemplates.DELETE("/:id/skip", recurringTemplateHandler.UnskipOccurrence, requireEditor)
+	recurringTemplates.PUT("/:id", recurringTemplateHandler.UpdateTemplate, requireEditor)
+	recurringTemplates.DELETE("/:id", recurringTemplateHandler.DeleteTemplate, requireEditor)
 
 	// Loan Provider routes (dual auth with rate limiting)
 	loanProviders := api.Group("/loan-providers")
 	loanProviders.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	loanProviders.POST("", loanProviderHandler.CreateLoanProvider)
+	loanProviders.POST("", loanProviderHandler.CreateLoanProvider, requireEditor)
 	loanProviders.GET("", loanProviderHandler.GetLoanProviders)
+	loanProviders.PUT("/by-name", loanProviderHandler.UpsertLoanProviderByName, requireEditor)
 	loanProviders.GET("/:id", loanProviderHandler.GetLoanProvider)
-	loanProviders.PUT("/:id", loanProviderHandler.UpdateLoanProvider)
-	loanProviders.DELETE("/:id", loanProviderHandler.DeleteLoanProvider)
+	loanProviders.PUT("/:id", loanProviderHandler.UpdateLoanProvider, requireEditor)
+	loanProviders.DELETE("/:id", loanProviderHandler.DeleteLoanProvider, requireEditor)
 	loanProviders.GET("/:id/earliest-unpaid", loanPaymentHandler.GetEarliestUnpaidMonth)
-	loanProviders.POST("/:id/pay-range", loanPaymentHandler.PayRange)
-	loanProviders.POST("/:id/pay-month", loanPaymentHandler.PayMonth)
-	loanProviders.POST("/:id/unpay-month", loanPaymentHandler.UnpayMonth)
+	loanProviders.GET("/:id/consolidated-preview", loanPaymentHandler.GetConsolidatedPreview)
+	loanProviders.POST("/:id/pay-range", loanPaymentHandler.PayRange, requireEditor)
+	loanProviders.POST("/:id/pay-range/preview", loanPaymentHandler.PreviewPayRange)
+	loanProviders.POST("/:id/pay-month", loanPaymentHandler.PayMonth, requireEditor)
+	loanProviders.POST("/:id/unpay-month", loanPaymentHandler.UnpayMonth, requireEditor)
+	loanProviders.GET("/:id/trend", loanHandler.GetProviderTrend)
+	loanProviders.GET("/:id/payoff", loanHandler.GetProviderPayoff)
 	loanProviders.GET("/:id/loans", loanHandler.GetLoansByProvider) // CL v2: Get loans for item-based modal
 
 	// Loan routes (dual auth with rate limiting)
 	loans := api.Group("/loans")
 	loans.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	loans.POST("", loanHandler.CreateLoan)
+	loans.POST("", loanHandler.CreateLoan, requireEditor)
 	loans.GET("", loanHandler.GetLoans)
 	loans.POST("/preview", loanHandler.PreviewLoan)
+	loans.POST("/validate", loanHandler.ValidateLoanPlan)
+	loans.POST("/compare-methods", loanHandler.CompareInterestMethods)
+	loans.POST("/pay-all", loanHandler.PayAllDue, requireEditor)
 	loans.GET("/commitments/:year/:month", loanHandler.GetMonthlyCommitments)
 	loans.GET("/trend", loanHandler.GetTrend)
+	loans.GET("/status-buckets", loanHandler.GetStatusBuckets)
+	loans.GET("/trash", loanHandler.ListDeletedLoans)
 	loans.GET("/:id", loanHandler.GetLoan)
+	loans.GET("/:id/detail", loanHandler.GetLoanDetail)
+	loans.GET("/:id/schedule.ics", loanHandler.GetLoanScheduleICS)
 	loans.GET("/:id/edit-check", loanHandler.GetEditCheck)     // Returns if provider can be changed
 	loans.GET("/:id/delete-check", loanHandler.GetDeleteCheck)
-	loans.PUT("/:id", loanHandler.UpdateLoan)
-	loans.DELETE("/:id", loanHandler.DeleteLoan)
-	loans.POST("/:id/pay-month", loanHandler.PayLoanMonth)       // CL v2: settle loan month via transactions
+	loans.PUT("/:id", loanHandler.UpdateLoan, requireEditor)
+	loans.DELETE("/:id", loanHandler.DeleteLoan, requireEditor)
+	loans.POST("/:id/restore", loanHandler.RestoreLoan, requireEditor)
+	loans.POST("/:id/refund", loanHandler.RefundLoanPayment, requireEditor)
+	loans.POST("/:id/settle-external", loanHandler.SettleLoanExternally, requireEditor)
+	loans.POST("/:id/pay-month", loanHandler.PayLoanMonth, requireEditor) // CL v2: settle loan month via transactions
 	loans.GET("/:id/transactions", loanHandler.GetLoanTransactions) // CL v2: Get transactions for item-based modal
 
 	// Loan Payment routes (nested under loans)
 	loans.GET("/:loanId/payments", loanPaymentHandler.GetPaymentsByLoanID)
-	loans.PATCH("/:loanId/payments/:paymentId", loanPaymentHandler.UpdatePaymentAmount)
-	loans.PUT("/:loanId/payments/:paymentId/toggle-paid", loanPaymentHandler.TogglePaymentPaid)
+	loans.PATCH("/:loanId/payments/:paymentId", loanPaymentHandler.UpdatePaymentAmount, requireEditor)
+	loans.PUT("/:loanId/payments/:paymentId/toggle-paid", loanPaymentHandler.TogglePaymentPaid, requireEditor)
+
+	// Admin diagnostics (workspace owners only)
+	admin := api.Group("/admin")
+	admin.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter), middleware.RequireRole(domain.WorkspaceRoleOwner))
+	admin.GET("/orphaned-loan-transactions", loanHandler.GetOrphanedLoanTransactions)
+	admin.POST("/orphaned-loan-transactions/repair", loanHandler.RepairOrphanedLoanTransactions)
+	admin.POST("/generate-missing-loan-schedules", loanHandler.GenerateMissingLoanSchedules)
 
 	// Wishlist routes (dual auth with rate limiting)
 	wishlists := api.Group("/wishlists")
 	wishlists.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	wishlists.POST("", wishlistHandler.CreateWishlist)
+	wishlists.POST("", wishlistHandler.CreateWishlist, requireEditor)
 	wishlists.GET("", wishlistHandler.GetWishlists)
 	wishlists.GET("/:id", wishlistHandler.GetWishlist)
-	wishlists.PUT("/:id", wishlistHandler.UpdateWishlist)
-	wishlists.DELETE("/:id", wishlistHandler.DeleteWishlist)
+	wishlists.PUT("/:id", wishlistHandler.UpdateWishlist, requireEditor)
+	wishlists.DELETE("/:id", wishlistHandler.DeleteWishlist, requireEditor)
 
 	// Wishlist Item routes (nested under wishlists)
-	wishlists.POST("/:id/items", wishlistItemHandler.CreateItem)
+	wishlists.POST("/:id/items", wishlistItemHandler.CreateItem, requireEditor)
 	wishlists.GET("/:id/items", wishlistItemHandler.ListItems)
 
 	// Wishlist Item routes (direct access by item ID, dual auth with rate limiting)
 	wishlistItems := api.Group("/wishlist-items")
 	wishlistItems.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	wishlistItems.GET("/:id", wishlistItemHandler.GetItem)
-	wishlistItems.PUT("/:id", wishlistItemHandler.UpdateItem)
-	wishlistItems.PATCH("/:id/move", wishlistItemHandler.MoveItem)
-	wishlistItems.DELETE("/:id", wishlistItemHandler.DeleteItem)
+	wishlistItems.PUT("/:id", wishlistItemHandler.UpdateItem, requireEditor)
+	wishlistItems.PATCH("/:id/move", wishlistItemHandler.MoveItem, requireEditor)
+	wishlistItems.DELETE("/:id", wishlistItemHandler.DeleteItem, requireEditor)
 
 	// Wishlist Item Price routes (nested under wishlist-items)
-	wishlistItems.POST("/:id/prices", wishlistPriceHandler.CreatePrice)
+	wishlistItems.POST("/:id/prices", wishlistPriceHandler.CreatePrice, requireEditor)
 	wishlistItems.GET("/:id/prices", wishlistPriceHandler.ListPrices)
 	wishlistItems.GET("/:id/prices/:platform", wishlistPriceHandler.GetPlatformHistory)
 
 	// Wishlist Item Price routes (direct access by price ID, dual auth with rate limiting)
 	wishlistItemPrices := api.Group("/wishlist-item-prices")
 	wishlistItemPrices.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
-	wishlistItemPrices.DELETE("/:id", wishlistPriceHandler.DeletePrice)
+	wishlistItemPrices.DELETE("/:id", wishlistPriceHandler.DeletePrice, requireEditor)
 
 	// Wishlist Item Note routes (nested under wishlist-items)
-	wishlistItems.POST("/:id/notes", wishlistNoteHandler.CreateNote)
+	wishlistItems.POST("/:id/notes", wishlistNoteHandler.CreateNote, requireEditor)
 	wishlistItems.GET("/:id/notes", wishlistNoteHandler.ListNotes)
 
 	// Wishlist Item Note routes (direct access by note ID, dual auth with rate limiting)
 	wishlistItemNotes := api.Group("/wishlist-item-notes")
 	wishlistItemNotes.Use(dualAuth.Authenticate(), middleware.RateLimitMiddleware(rateLimiter))
 	wishlistItemNotes.GET("/:id", wishlistNoteHandler.GetNote)
-	wishlistItemNotes.PUT("/:id", wishlistNoteHandler.UpdateNote)
-	wishlistItemNotes.DELETE("/:id", wishlistNoteHandler.DeleteNote)
+	wishlistItemNotes.PUT("/:id", wishlistNoteHandler.UpdateNote, requireEditor)
+	wishlistItemNotes.DELETE("/:id", wishlistNoteHandler.DeleteNote, requireEditor)
 
 	// Image routes (binary uploads JWT only, presigned URLs support dual auth)
 	images := api.Group("/images")
 	images.Use(dualAuth.JWTOnly())
-	images.POST("", imageHandler.UploadImage)
-	images.DELETE("", imageHandler.DeleteImage)
+	images.POST("", imageHandler.UploadImage, requireEditor)
+	images.DELETE("", imageHandler.DeleteImage, requireEditor)
 
 	// Presigned URL routes (dual auth - usable by frontend and API tokens)
 	presignedImages := api.Group("/images")
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// SearchHandler handles workspace-wide search requests
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// SearchResponse represents global search matches grouped by type
+type SearchResponse struct {
+	Query        string                   `json:"query"`
+	Total        int                      `json:"total"`
+	Transactions []TransactionResponse    `json:"transactions"`
+	Loans        []LoanResponse           `json:"loans"`
+	Categories   []BudgetCategoryResponse `json:"categories"`
+}
+
+// GlobalSearch handles GET /api/v1/search?q=phone&limit=20
+func (h *SearchHandler) GlobalSearch(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > domain.MaxSearchLimit {
+			return NewValidationError(c, "Invalid limit parameter", []ValidationError{
+				{Field: "limit", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("Must be a number between 1 and %d", domain.MaxSearchLimit)},
+			})
+		}
+		limit = parsed
+	}
+
+	results, err := h.searchService.GlobalSearch(workspaceID, c.QueryParam("q"), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptySearchQuery) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "q", Code: ValidationCodeRequired, Message: "Search query is required"},
+			})
+		}
+		if errors.Is(err, domain.ErrSearchQueryTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "q", Code: ValidationCodeTooLong, Message: fmt.Sprintf("Search query must be at most %d characters", domain.MaxSearchQueryLength)},
+			})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to search workspace")
+		return NewInternalError(c, "Failed to search")
+	}
+
+	response := SearchResponse{
+		Query:        results.Query,
+		Total:        results.Total(),
+		Transactions: make([]TransactionResponse, len(results.Transactions)),
+		Loans:        make([]LoanResponse, len(results.Loans)),
+		Categories:   make([]BudgetCategoryResponse, len(results.Categories)),
+	}
+	for i, tx := range results.Transactions {
+		response.Transactions[i] = toTransactionResponse(tx)
+	}
+	for i, loan := range results.Loans {
+		response.Loans[i] = toLoanResponse(loan)
+	}
+	for i, category := range results.Categories {
+		response.Categories[i] = toBudgetCategoryResponse(category)
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parseTimezoneQuery reads the optional tz query param as an IANA timezone name, defaulting to UTC
+func parseTimezoneQuery(c echo.Context) (*time.Location, error) {
+	tzStr := c.QueryParam("tz")
+	if tzStr == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(tzStr)
+	if err != nil {
+		return nil, &requestValidationError{Detail: "Invalid tz (use an IANA timezone name)", Errors: []ValidationError{
+			{Field: "tz", Code: ValidationCodeInvalidFormat, Message: "Must be an IANA timezone name"},
+		}}
+	}
+	return loc, nil
+}
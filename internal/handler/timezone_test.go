@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestParseTimezoneQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantErr  bool
+		wantName string
+	}{
+		{"", false, "UTC"},
+		{"?tz=Asia/Kuala_Lumpur", false, "Asia/Kuala_Lumpur"},
+		{"?tz=Not/AZone", true, ""},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		loc, err := parseTimezoneQuery(c)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseTimezoneQuery(%q) err = %v, wantErr %v", tt.query, err, tt.wantErr)
+		}
+		if err != nil {
+			if err := NewRequestValidationError(c, err); err != nil {
+				t.Fatalf("NewRequestValidationError: %v", err)
+			}
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("parseTimezoneQuery(%q) status = %d, want %d", tt.query, rec.Code, http.StatusBadRequest)
+			}
+			assertValidationCode(t, rec, "tz", ValidationCodeInvalidFormat)
+			continue
+		}
+		if loc.String() != tt.wantName {
+			t.Errorf("parseTimezoneQuery(%q) = %s, want %s", tt.query, loc, tt.wantName)
+		}
+	}
+}
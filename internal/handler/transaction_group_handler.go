@@ -40,6 +40,20 @@ type MembershipRequest struct {
 	TransactionIDs []int32 `json:"transactionIds"`
 }
 
+// AddByFilterRequest represents the add-by-filter request body; set criteria are ANDed
+type AddByFilterRequest struct {
+	LoanProviderID *int32  `json:"loanProviderId,omitempty"`
+	NameContains   *string `json:"nameContains,omitempty"`
+	AccountID      *int32  `json:"accountId,omitempty"`
+	CategoryID     *int32  `json:"categoryId,omitempty"`
+}
+
+// AddByFilterResponse reports how many transactions were added to the group
+type AddByFilterResponse struct {
+	GroupID    int32 `json:"groupId"`
+	AddedCount int   `json:"addedCount"`
+}
+
 // GroupDeletedResponse represents the response when a group is auto-deleted
 type GroupDeletedResponse struct {
 	Deleted bool  `json:"deleted"`
@@ -59,6 +73,19 @@ type GroupResponse struct {
 	UpdatedAt      string `json:"updatedAt"`
 }
 
+// GroupDeletePreviewResponse represents what deleting a group with its children would remove
+type GroupDeletePreviewResponse struct {
+	GroupID     int32  `json:"groupId"`
+	ChildCount  int32  `json:"childCount"`
+	TotalAmount string `json:"totalAmount"`
+}
+
+// GroupDetailResponse represents a transaction group with its member transactions
+type GroupDetailResponse struct {
+	GroupResponse
+	Children []TransactionResponse `json:"children"`
+}
+
 // CreateGroup handles POST /api/v1/transaction-groups
 func (h *TransactionGroupHandler) CreateGroup(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -159,6 +186,44 @@ func (h *TransactionGroupHandler) AddTransactions(c echo.Context) error {
 	return c.JSON(http.StatusOK, toGroupResponse(group))
 }
 
+// AddTransactionsByFilter handles POST /api/v1/transaction-groups/:id/add-by-filter
+// Adds every ungrouped transaction in the group's month that matches the filter
+func (h *TransactionGroupHandler) AddTransactionsByFilter(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid group ID", nil)
+	}
+
+	var req AddByFilterRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	added, err := h.groupService.AddMonthTransactionsToGroup(workspaceID, int32(id), domain.TransactionFilter{
+		LoanProviderID: req.LoanProviderID,
+		NameContains:   req.NameContains,
+		AccountID:      req.AccountID,
+		CategoryID:     req.CategoryID,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	log.Info().
+		Int32("workspace_id", workspaceID).
+		Int32("group_id", int32(id)).
+		Int("added_count", added).
+		Str("action", "add_transactions_to_group_by_filter").
+		Msg("Transactions added to group by filter")
+
+	return c.JSON(http.StatusOK, AddByFilterResponse{GroupID: int32(id), AddedCount: added})
+}
+
 // RemoveTransactions handles DELETE /api/v1/transaction-groups/:id/transactions
 func (h *TransactionGroupHandler) RemoveTransactions(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -209,7 +274,33 @@ func (h *TransactionGroupHandler) RemoveTransactions(c echo.Context) error {
 	return c.JSON(http.StatusOK, toGroupResponse(group))
 }
 
+// GetDeleteCheck handles GET /api/v1/transaction-groups/:id/delete-check
+// Returns the child count to confirm before deleting with mode=delete_all
+func (h *TransactionGroupHandler) GetDeleteCheck(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid group ID", nil)
+	}
+
+	preview, err := h.groupService.GetGroupDeletePreview(workspaceID, int32(id))
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, GroupDeletePreviewResponse{
+		GroupID:     preview.GroupID,
+		ChildCount:  preview.ChildCount,
+		TotalAmount: FormatAmount(preview.TotalAmount, domain.DefaultCurrency),
+	})
+}
+
 // DeleteGroup handles DELETE /api/v1/transaction-groups/:id?mode=ungroup|delete_all
+// mode=delete_all also requires confirmCount, the childCount from the delete-check endpoint
 func (h *TransactionGroupHandler) DeleteGroup(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
 	if workspaceID == 0 {
@@ -241,7 +332,13 @@ func (h *TransactionGroupHandler) DeleteGroup(c echo.Context) error {
 			Msg("Transaction group ungrouped")
 		return c.JSON(http.StatusOK, result)
 	case "delete_all":
-		result, err := h.groupService.DeleteGroupWithChildren(workspaceID, int32(id))
+		confirmCount, err := strconv.ParseInt(c.QueryParam("confirmCount"), 10, 32)
+		if err != nil || confirmCount < 0 {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "confirmCount", Message: "Required: child count from delete-check"},
+			})
+		}
+		result, err := h.groupService.DeleteGroupWithChildren(workspaceID, int32(id), int32(confirmCount))
 		if err != nil {
 			return h.handleServiceError(c, err)
 		}
@@ -255,6 +352,67 @@ func (h *TransactionGroupHandler) DeleteGroup(c echo.Context) error {
 	return nil
 }
 
+// RecalculateGroup handles POST /api/v1/transaction-groups/:id/recalculate
+func (h *TransactionGroupHandler) RecalculateGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid group ID", nil)
+	}
+
+	group, err := h.groupService.RecalculateGroup(workspaceID, int32(id))
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	if group == nil {
+		return c.JSON(http.StatusOK, GroupDeletedResponse{
+			Deleted: true,
+			GroupID: int32(id),
+		})
+	}
+
+	log.Info().
+		Int32("workspace_id", workspaceID).
+		Int32("group_id", int32(id)).
+		Str("action", "recalculate_group").
+		Msg("Transaction group recalculated")
+
+	return c.JSON(http.StatusOK, toGroupResponse(group))
+}
+
+// GetGroup handles GET /api/v1/transaction-groups/:id
+func (h *TransactionGroupHandler) GetGroup(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid group ID", nil)
+	}
+
+	detail, err := h.groupService.GetGroupWithChildren(workspaceID, int32(id))
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	children := make([]TransactionResponse, len(detail.Children))
+	for i, tx := range detail.Children {
+		children[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, GroupDetailResponse{
+		GroupResponse: toGroupResponse(detail.Group),
+		Children:      children,
+	})
+}
+
 // GetGroupsByMonth handles GET /api/v1/transaction-groups?month=YYYY-MM
 func (h *TransactionGroupHandler) GetGroupsByMonth(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -301,8 +459,12 @@ func (h *TransactionGroupHandler) handleServiceError(c echo.Context, err error)
 			Detail:   err.Error(),
 			Instance: c.Request().URL.Path,
 		})
+	case errors.Is(err, domain.ErrDeleteConfirmationMismatch):
+		return NewConflictError(c, "Group contents changed since the delete was confirmed; check again before deleting")
 	case errors.Is(err, domain.ErrTransactionNotInGroup):
 		return NewValidationError(c, err.Error(), nil)
+	case errors.Is(err, domain.ErrEmptyGroupFilter):
+		return NewValidationError(c, "At least one of loanProviderId, nameContains, accountId or categoryId is required", nil)
 	case errors.Is(err, domain.ErrGroupNotFound):
 		return NewNotFoundError(c, "Transaction group not found")
 	case errors.Is(err, domain.ErrGroupNameEmpty):
@@ -662,10 +662,11 @@ func TestTransactionGroupHandler_DeleteGroup_DeleteAll_Success(t *testing.T) {
 		return 5, nil
 	}
 
-	c, rec := createGroupContext(http.MethodDelete, "/api/v1/transaction-groups/1?mode=delete_all", nil)
+	c, rec := createGroupContext(http.MethodDelete, "/api/v1/transaction-groups/1?mode=delete_all&confirmCount=5", nil)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 	c.QueryParams().Set("mode", "delete_all")
+	c.QueryParams().Set("confirmCount", "5")
 
 	err := handler.DeleteGroup(c)
 	if err != nil {
@@ -690,6 +691,35 @@ func TestTransactionGroupHandler_DeleteGroup_DeleteAll_Success(t *testing.T) {
 	}
 }
 
+func TestTransactionGroupHandler_DeleteGroup_DeleteAll_ConfirmCountMismatch(t *testing.T) {
+	handler, groupRepo, _ := setupGroupHandler()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Delete Me",
+		Month:       "2026-01",
+		ChildCount:  5,
+	})
+
+	c, rec := createGroupContext(http.MethodDelete, "/api/v1/transaction-groups/1?mode=delete_all&confirmCount=4", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.QueryParams().Set("mode", "delete_all")
+	c.QueryParams().Set("confirmCount", "4")
+
+	err := handler.DeleteGroup(c)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rec.Code)
+	}
+	if _, ok := groupRepo.Groups[1]; !ok {
+		t.Error("expected group to survive a mismatched confirmation")
+	}
+}
+
 func TestTransactionGroupHandler_DeleteGroup_InvalidMode(t *testing.T) {
 	handler, _, _ := setupGroupHandler()
 
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/util"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
@@ -43,6 +46,18 @@ type CreateTransactionRequest struct {
 	Notes            *string `json:"notes,omitempty"`
 	CategoryID       *int32  `json:"categoryId,omitempty"`
 	SettlementIntent *string `json:"settlementIntent,omitempty"` // v2: "immediate" or "deferred"
+	IsScheduled      bool    `json:"isScheduled,omitempty"`      // Future-dated: excluded from balances until its date
+	Merchant         *string `json:"merchant,omitempty"`         // Normalized merchant for reporting
+	Payee            *string `json:"payee,omitempty"`            // Counterparty who was paid
+	IsRefund         bool    `json:"isRefund,omitempty"`         // Income that reduces spending (e.g. a card refund)
+	// Keep out of insights and spending breakdowns (e.g. a reimbursed expense); balances still include it
+	ExcludeFromReports bool `json:"excludeFromReports,omitempty"`
+	// Omit to use the category's default
+	IsTaxDeductible *bool `json:"isTaxDeductible,omitempty"`
+	// Optional geo metadata; latitude and longitude are decimal strings and must be sent together
+	Latitude  *string `json:"latitude,omitempty"`
+	Longitude *string `json:"longitude,omitempty"`
+	Location  *string `json:"location,omitempty"`
 }
 
 // TransactionResponse represents a transaction in API responses
@@ -56,6 +71,11 @@ type TransactionResponse struct {
 	TransactionDate string  `json:"transactionDate"`
 	IsPaid          bool    `json:"isPaid"`
 	Notes           *string `json:"notes,omitempty"`
+	Merchant        *string `json:"merchant,omitempty"`
+	Payee           *string `json:"payee,omitempty"`
+	Latitude        *string `json:"latitude,omitempty"`
+	Longitude       *string `json:"longitude,omitempty"`
+	Location        *string `json:"location,omitempty"`
 	TransferPairID  *string `json:"transferPairId,omitempty"`
 	CategoryID      *int32  `json:"categoryId,omitempty"`
 	CategoryName    *string `json:"categoryName,omitempty"`
@@ -63,10 +83,33 @@ type TransactionResponse struct {
 	UpdatedAt       string  `json:"updatedAt"`
 
 	// Recurring/Projection fields
-	Source      string `json:"source"`               // "manual", "recurring", or "import"
+	Source      string `json:"source"`               // "manual", "recurring", "loan", or a custom source
 	TemplateID  *int32 `json:"templateId,omitempty"` // ID of recurring template that generated this
 	IsProjected bool   `json:"isProjected"`          // true if this is a projected (not yet actual) transaction
 	IsModified  bool   `json:"isModified"`           // true if projected instance differs from template
+	// true once an edit left the amount or category different from the template (badged in the UI)
+	ModifiedFromTemplate bool `json:"modifiedFromTemplate"`
+
+	// Scheduled: future-dated, excluded from balances until its date
+	IsScheduled bool `json:"isScheduled"`
+
+	// Refund: income that reduces spending instead of counting as income
+	IsRefund bool `json:"isRefund"`
+
+	// Loan fee: a provider's separate monthly fee line, not counted as a loan payment
+	IsLoanFee bool `json:"isLoanFee"`
+
+	// Excluded from insights and spending breakdowns; balances still include it
+	ExcludeFromReports bool `json:"excludeFromReports"`
+
+	// Counted in the annual tax report
+	IsTaxDeductible bool `json:"isTaxDeductible"`
+
+	// Settlement: when the payment actually happened, if recorded
+	PaidAt *string `json:"paidAt,omitempty"`
+
+	// Transfer reversal: the transfer pair this counter-transfer reverses
+	ReversesTransferPairID *string `json:"reversesTransferPairId,omitempty"`
 
 	// CC Lifecycle fields (v2 simplified - ccState computed from isPaid and billedAt)
 	CCState          *string `json:"ccState,omitempty"`          // Computed: "pending", "billed", or "settled"
@@ -119,7 +162,7 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 	// Validate accountId early to avoid unnecessary database lookup
 	if req.AccountID <= 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "accountId", Message: "Account ID is required"},
+			{Field: "accountId", Code: ValidationCodeRequired, Message: "Account ID is required"},
 		})
 	}
 
@@ -127,7 +170,7 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -137,7 +180,7 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 		parsed, err := time.Parse("2006-01-02", *req.Date)
 		if err != nil {
 			return NewValidationError(c, "Invalid date", []ValidationError{
-				{Field: "date", Message: "Must be in YYYY-MM-DD format"},
+				{Field: "date", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
 		transactionDate = &parsed
@@ -149,66 +192,186 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 		intent := domain.SettlementIntent(*req.SettlementIntent)
 		if intent != domain.SettlementIntentImmediate && intent != domain.SettlementIntentDeferred {
 			return NewValidationError(c, "Invalid settlementIntent", []ValidationError{
-				{Field: "settlementIntent", Message: "Must be one of: immediate, deferred"},
+				{Field: "settlementIntent", Code: ValidationCodeInvalidChoice, Message: "Must be one of: immediate, deferred"},
 			})
 		}
 		settlementIntent = &intent
 	}
 
+	latitude, longitude, err := parseCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		return NewValidationError(c, "Invalid coordinates", []ValidationError{
+			{Field: "latitude", Code: ValidationCodeInvalidFormat, Message: "Latitude and longitude must be valid decimal numbers"},
+		})
+	}
+
 	input := service.CreateTransactionInput{
-		AccountID:        req.AccountID,
-		Name:             req.Name,
-		Amount:           amount,
-		Type:             domain.TransactionType(req.Type),
-		TransactionDate:  transactionDate,
-		IsPaid:           req.IsPaid,
-		Notes:            req.Notes,
-		CategoryID:       req.CategoryID,
-		SettlementIntent: settlementIntent,
+		AccountID:          req.AccountID,
+		Name:               req.Name,
+		Amount:             amount,
+		Type:               domain.TransactionType(req.Type),
+		TransactionDate:    transactionDate,
+		IsPaid:             req.IsPaid,
+		Notes:              req.Notes,
+		CategoryID:         req.CategoryID,
+		SettlementIntent:   settlementIntent,
+		IsScheduled:        req.IsScheduled,
+		Merchant:           req.Merchant,
+		Payee:              req.Payee,
+		Latitude:           latitude,
+		Longitude:          longitude,
+		Location:           req.Location,
+		IsRefund:           req.IsRefund,
+		ExcludeFromReports: req.ExcludeFromReports,
+		IsTaxDeductible:    req.IsTaxDeductible,
 	}
 
 	transaction, err := h.transactionService.CreateTransaction(workspaceID, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrNameRequired) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name is required"},
-			})
-		}
-		if errors.Is(err, domain.ErrNameTooLong) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name must be 255 characters or less"},
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidAmount) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "amount", Message: "Amount must be positive"},
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidTransactionType) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "type", Message: "Type must be one of: income, expense"},
-			})
-		}
-		if errors.Is(err, domain.ErrAccountNotFound) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "accountId", Message: "Account not found"},
+		return writeCreateTransactionError(c, workspaceID, err)
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("transaction_id", transaction.ID).Str("name", transaction.Name).Msg("Transaction created")
+
+	return c.JSON(http.StatusCreated, toTransactionResponse(transaction))
+}
+
+// writeCreateTransactionError maps CreateTransaction validation errors to API responses
+func writeCreateTransactionError(c echo.Context, workspaceID int32, err error) error {
+	if errors.Is(err, domain.ErrNameRequired) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Code: ValidationCodeRequired, Message: "Name is required"},
+		})
+	}
+	if errors.Is(err, domain.ErrNameTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "name", Code: ValidationCodeTooLong, Message: "Name must be 255 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidAmount) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
+		})
+	}
+	if errors.Is(err, domain.ErrExcessivePrecision) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: fmt.Sprintf("Amount must have at most %d decimal places", precisionLimit(err))},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidTransactionType) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "type", Code: ValidationCodeInvalidChoice, Message: "Type must be one of: income, expense"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidRefund) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "isRefund", Code: ValidationCodeInvalid, Message: "Only income transactions that are not transfers can be refunds"},
+		})
+	}
+	if errors.Is(err, domain.ErrAccountNotFound) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "accountId", Code: ValidationCodeNotFound, Message: "Account not found"},
+		})
+	}
+	if errors.Is(err, domain.ErrNotesTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "notes", Code: ValidationCodeTooLong, Message: "Notes must be 1000 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrMerchantTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "merchant", Code: ValidationCodeTooLong, Message: "Merchant must be 255 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrPayeeTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "payee", Code: ValidationCodeTooLong, Message: "Payee must be 255 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidCoordinates) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "latitude", Code: ValidationCodeOutOfRange, Message: "Latitude (-90 to 90) and longitude (-180 to 180) must be provided together"},
+		})
+	}
+	if errors.Is(err, domain.ErrLocationTooLong) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "location", Code: ValidationCodeTooLong, Message: "Location must be 255 characters or less"},
+		})
+	}
+	if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "categoryId", Code: ValidationCodeNotFound, Message: "Category not found"},
+		})
+	}
+	log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create transaction")
+	return NewInternalError(c, "Failed to create transaction")
+}
+
+// CloneTransactionRequest represents the clone transaction request body
+type CloneTransactionRequest struct {
+	Date   *string `json:"date,omitempty"`   // YYYY-MM-DD, defaults to the source transaction's date
+	Amount *string `json:"amount,omitempty"` // Defaults to the source transaction's amount
+}
+
+// CloneTransaction godoc
+// @Summary Duplicate a transaction
+// @Description Create an unpaid copy of a transaction, optionally with a new date and amount. The copy is not linked to any group or loan.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transaction ID"
+// @Param request body CloneTransactionRequest false "Optional overrides"
+// @Success 201 {object} TransactionResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /transactions/{id}/clone [post]
+func (h *TransactionHandler) CloneTransaction(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid transaction ID", nil)
+	}
+
+	var req CloneTransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	var overrides service.TransactionCloneOverrides
+	if req.Amount != nil {
+		amount, err := decimal.NewFromString(*req.Amount)
+		if err != nil {
+			return NewValidationError(c, "Invalid amount", []ValidationError{
+				{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 			})
 		}
-		if errors.Is(err, domain.ErrNotesTooLong) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "notes", Message: "Notes must be 1000 characters or less"},
+		overrides.Amount = &amount
+	}
+	if req.Date != nil && *req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", *req.Date)
+		if err != nil {
+			return NewValidationError(c, "Invalid date", []ValidationError{
+				{Field: "date", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
-		if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
-			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "categoryId", Message: "Category not found"},
-			})
+		overrides.TransactionDate = &parsed
+	}
+
+	transaction, err := h.transactionService.CloneTransaction(workspaceID, int32(id), overrides)
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			return NewNotFoundError(c, "Transaction not found")
 		}
-		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create transaction")
-		return NewInternalError(c, "Failed to create transaction")
+		return writeCreateTransactionError(c, workspaceID, err)
 	}
 
-	log.Info().Int32("workspace_id", workspaceID).Int32("transaction_id", transaction.ID).Str("name", transaction.Name).Msg("Transaction created")
+	log.Info().Int32("workspace_id", workspaceID).Int32("transaction_id", transaction.ID).Int("source_id", id).Msg("Transaction cloned")
 
 	return c.JSON(http.StatusCreated, toTransactionResponse(transaction))
 }
@@ -222,6 +385,12 @@ type PaginatedTransactionsResponse struct {
 	TotalPages int32                 `json:"totalPages"`
 }
 
+// CursorTransactionsResponse represents a keyset page of transactions
+type CursorTransactionsResponse struct {
+	Data       []TransactionResponse `json:"data"`
+	NextCursor *string               `json:"nextCursor"` // null on the last page
+}
+
 // GetTransactions godoc
 // @Summary List transactions
 // @Description Get paginated transactions with optional filters
@@ -231,15 +400,23 @@ type PaginatedTransactionsResponse struct {
 // @Security BearerAuth
 // @Param accountId query int false "Filter by account ID"
 // @Param month query string false "Filter by month (YYYY-MM format, overrides startDate/endDate)"
+// @Param range query string false "Named range (today, this_week, this_month, last_month), overrides startDate/endDate"
+// @Param tz query string false "IANA timezone used to resolve range (default UTC)"
 // @Param startDate query string false "Start date (YYYY-MM-DD)"
 // @Param endDate query string false "End date (YYYY-MM-DD)"
+// @Param paidStart query string false "Paid on or after (YYYY-MM-DD), regardless of transaction date"
+// @Param paidEnd query string false "Paid on or before (YYYY-MM-DD), regardless of transaction date"
 // @Param type query string false "Transaction type (income or expense)"
 // @Param ccStatus query string false "Filter by CC status (pending, billed, or settled)"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Items per page" default(20)
+// @Param cursor query string false "Opaque cursor for keyset pagination (switches to cursor mode; filters are ignored)"
+// @Param limit query int false "Items per cursor page" default(100)
+// @Param includeDeleted query bool false "Include soft-deleted transactions (owners only)"
 // @Success 200 {object} PaginatedTransactionsResponse
 // @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
 // @Router /transactions [get]
 func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 	workspaceID := middleware.GetWorkspaceID(c)
@@ -247,10 +424,21 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
+	// Cursor mode for large exports: ?cursor=...&limit=...
+	if c.QueryParams().Has("cursor") || c.QueryParam("limit") != "" {
+		return h.getTransactionsByCursor(c, workspaceID)
+	}
+
+	listOptions, ok := parseListOptions(c)
+	if !ok {
+		return NewForbiddenError(c, "Only workspace owners can list deleted transactions")
+	}
+
 	// Parse filters and pagination
 	filters := &domain.TransactionFilters{
-		Page:     1,
-		PageSize: domain.DefaultPageSize,
+		ListOptions: listOptions,
+		Page:        1,
+		PageSize:    domain.DefaultPageSize,
 	}
 
 	accountIDStr := c.QueryParam("accountId")
@@ -285,8 +473,22 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		if h.transactionGroupService != nil {
 			_ = h.transactionGroupService.EnsureAutoGroups(workspaceID, monthStr)
 		}
+	} else if rangeStr := c.QueryParam("range"); rangeStr != "" {
+		// Resolve named range in the workspace timezone, mapped onto the date filter
+		tz, err := parseTimezoneQuery(c)
+		if err != nil {
+			return NewRequestValidationError(c, err)
+		}
+		start, end, err := util.ResolveNamedRange(rangeStr, time.Now(), tz)
+		if err != nil {
+			return NewValidationError(c, "Invalid range (must be 'today', 'this_week', 'this_month', or 'last_month')", nil)
+		}
+		startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+		filters.StartDate = &startDate
+		filters.EndDate = &endDate
 	} else {
-		// Use startDate/endDate if month not provided
+		// Use startDate/endDate if month or range not provided
 		if startDateStr != "" {
 			parsed, err := time.Parse("2006-01-02", startDateStr)
 			if err != nil {
@@ -304,6 +506,27 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		}
 	}
 
+	// Paid range filters on when the transaction was paid, independent of its date
+	if paidStartStr := c.QueryParam("paidStart"); paidStartStr != "" {
+		parsed, err := time.Parse("2006-01-02", paidStartStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid paidStart format (use YYYY-MM-DD)", nil)
+		}
+		filters.PaidStart = &parsed
+	}
+	if paidEndStr := c.QueryParam("paidEnd"); paidEndStr != "" {
+		parsed, err := time.Parse("2006-01-02", paidEndStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid paidEnd format (use YYYY-MM-DD)", nil)
+		}
+		filters.PaidEnd = &parsed
+	}
+	if filters.PaidStart != nil && filters.PaidEnd != nil && filters.PaidEnd.Before(*filters.PaidStart) {
+		return NewValidationError(c, "Validation failed", []ValidationError{
+			{Field: "paidEnd", Code: ValidationCodeOutOfRange, Message: "Must not be before paidStart"},
+		})
+	}
+
 	if typeStr != "" {
 		transactionType := domain.TransactionType(typeStr)
 		if transactionType != domain.TransactionTypeIncome && transactionType != domain.TransactionTypeExpense {
@@ -321,6 +544,18 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		filters.CCStatus = &ccStatus
 	}
 
+	if sourceStr := c.QueryParam("source"); sourceStr != "" {
+		filters.Source = &sourceStr
+	}
+
+	if hasLocationStr := c.QueryParam("hasLocation"); hasLocationStr != "" {
+		hasLocation, err := strconv.ParseBool(hasLocationStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid hasLocation (must be 'true' or 'false')", nil)
+		}
+		filters.HasLocation = &hasLocation
+	}
+
 	if pageStr != "" {
 		var page int32
 		if _, err := parseIntParam(pageStr, &page); err != nil || page < 1 {
@@ -340,8 +575,16 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		filters.PageSize = pageSize
 	}
 
-	result, err := h.transactionService.GetTransactions(workspaceID, filters)
+	result, err := h.transactionService.GetTransactions(c.Request().Context(), workspaceID, filters)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewGatewayTimeoutError(c, "Timed out loading transactions")
+		}
+		if errors.Is(err, domain.ErrInvalidTransactionSource) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "source", Code: ValidationCodeInvalid, Message: "Source must be a built-in source or one already used in this workspace"},
+			})
+		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get transactions")
 		return NewInternalError(c, "Failed to get transactions")
 	}
@@ -363,6 +606,41 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// getTransactionsByCursor serves the keyset-paginated variant of GetTransactions
+func (h *TransactionHandler) getTransactionsByCursor(c echo.Context, workspaceID int32) error {
+	limit := domain.DefaultCursorPageSize
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			return NewValidationError(c, "Invalid limit (must be positive integer)", nil)
+		}
+		limit = parsed
+	}
+
+	transactions, nextCursor, err := h.transactionService.ListByCursor(workspaceID, c.QueryParam("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "cursor", Code: ValidationCodeInvalidFormat, Message: "Cursor is invalid"},
+			})
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get transactions by cursor")
+		return NewInternalError(c, "Failed to get transactions")
+	}
+
+	response := CursorTransactionsResponse{
+		Data: make([]TransactionResponse, len(transactions)),
+	}
+	for i, transaction := range transactions {
+		response.Data[i] = toTransactionResponse(transaction)
+	}
+	if nextCursor != "" {
+		response.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // TogglePaidStatus godoc
 // @Summary Toggle transaction paid status
 // @Description Toggle the paid/unpaid status of a transaction
@@ -454,6 +732,18 @@ type UpdateTransactionRequest struct {
 	Notes            *string `json:"notes,omitempty"`
 	CategoryID       *int32  `json:"categoryId,omitempty"`
 	SettlementIntent *string `json:"settlementIntent,omitempty"` // "immediate" or "deferred"
+	IsScheduled      *bool   `json:"isScheduled,omitempty"`      // Omit to keep current value
+	Merchant         *string `json:"merchant,omitempty"`         // Normalized merchant for reporting
+	Payee            *string `json:"payee,omitempty"`            // Counterparty who was paid
+	IsRefund         *bool   `json:"isRefund,omitempty"`         // Omit to keep current value
+	// Omit to keep current value
+	ExcludeFromReports *bool `json:"excludeFromReports,omitempty"`
+	// Omit to keep current value
+	IsTaxDeductible *bool `json:"isTaxDeductible,omitempty"`
+	// Geo metadata replaces the current values; omit to clear
+	Latitude  *string `json:"latitude,omitempty"`
+	Longitude *string `json:"longitude,omitempty"`
+	Location  *string `json:"location,omitempty"`
 }
 
 // UpdateTransaction godoc
@@ -489,7 +779,7 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 	// Validate accountId early
 	if req.AccountID <= 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "accountId", Message: "Account ID is required"},
+			{Field: "accountId", Code: ValidationCodeRequired, Message: "Account ID is required"},
 		})
 	}
 
@@ -497,7 +787,7 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -505,7 +795,7 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 	transactionDate, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return NewValidationError(c, "Invalid date", []ValidationError{
-			{Field: "date", Message: "Must be in YYYY-MM-DD format"},
+			{Field: "date", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 		})
 	}
 
@@ -515,21 +805,37 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 		intent := domain.SettlementIntent(*req.SettlementIntent)
 		if intent != domain.SettlementIntentImmediate && intent != domain.SettlementIntentDeferred {
 			return NewValidationError(c, "Invalid settlementIntent", []ValidationError{
-				{Field: "settlementIntent", Message: "Must be one of: immediate, deferred"},
+				{Field: "settlementIntent", Code: ValidationCodeInvalidChoice, Message: "Must be one of: immediate, deferred"},
 			})
 		}
 		settlementIntent = &intent
 	}
 
+	latitude, longitude, err := parseCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		return NewValidationError(c, "Invalid coordinates", []ValidationError{
+			{Field: "latitude", Code: ValidationCodeInvalidFormat, Message: "Latitude and longitude must be valid decimal numbers"},
+		})
+	}
+
 	input := service.UpdateTransactionInput{
-		AccountID:        req.AccountID,
-		Name:             req.Name,
-		Amount:           amount,
-		Type:             domain.TransactionType(req.Type),
-		TransactionDate:  transactionDate,
-		Notes:            req.Notes,
-		CategoryID:       req.CategoryID,
-		SettlementIntent: settlementIntent,
+		AccountID:          req.AccountID,
+		Name:               req.Name,
+		Amount:             amount,
+		Type:               domain.TransactionType(req.Type),
+		TransactionDate:    transactionDate,
+		Notes:              req.Notes,
+		CategoryID:         req.CategoryID,
+		SettlementIntent:   settlementIntent,
+		IsScheduled:        req.IsScheduled,
+		Merchant:           req.Merchant,
+		Payee:              req.Payee,
+		Latitude:           latitude,
+		Longitude:          longitude,
+		Location:           req.Location,
+		IsRefund:           req.IsRefund,
+		ExcludeFromReports: req.ExcludeFromReports,
+		IsTaxDeductible:    req.IsTaxDeductible,
 	}
 
 	transaction, err := h.transactionService.UpdateTransaction(workspaceID, int32(id), input)
@@ -539,37 +845,67 @@ func (h *TransactionHandler) UpdateTransaction(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrNameRequired) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name is required"},
+				{Field: "name", Code: ValidationCodeRequired, Message: "Name is required"},
 			})
 		}
 		if errors.Is(err, domain.ErrNameTooLong) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "name", Message: "Name must be 255 characters or less"},
+				{Field: "name", Code: ValidationCodeTooLong, Message: "Name must be 255 characters or less"},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidAmount) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "amount", Message: "Amount must be positive"},
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
+			})
+		}
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "amount", Code: ValidationCodeInvalidFormat, Message: fmt.Sprintf("Amount must have at most %d decimal places", precisionLimit(err))},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidTransactionType) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "type", Message: "Type must be one of: income, expense"},
+				{Field: "type", Code: ValidationCodeInvalidChoice, Message: "Type must be one of: income, expense"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidRefund) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "isRefund", Code: ValidationCodeInvalid, Message: "Only income transactions that are not transfers can be refunds"},
 			})
 		}
 		if errors.Is(err, domain.ErrAccountNotFound) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "accountId", Message: "Account not found"},
+				{Field: "accountId", Code: ValidationCodeNotFound, Message: "Account not found"},
 			})
 		}
 		if errors.Is(err, domain.ErrNotesTooLong) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "notes", Message: "Notes must be 1000 characters or less"},
+				{Field: "notes", Code: ValidationCodeTooLong, Message: "Notes must be 1000 characters or less"},
+			})
+		}
+		if errors.Is(err, domain.ErrMerchantTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "merchant", Code: ValidationCodeTooLong, Message: "Merchant must be 255 characters or less"},
+			})
+		}
+		if errors.Is(err, domain.ErrPayeeTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "payee", Code: ValidationCodeTooLong, Message: "Payee must be 255 characters or less"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidCoordinates) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "latitude", Code: ValidationCodeOutOfRange, Message: "Latitude (-90 to 90) and longitude (-180 to 180) must be provided together"},
+			})
+		}
+		if errors.Is(err, domain.ErrLocationTooLong) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "location", Code: ValidationCodeTooLong, Message: "Location must be 255 characters or less"},
 			})
 		}
 		if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "categoryId", Message: "Category not found"},
+				{Field: "categoryId", Code: ValidationCodeNotFound, Message: "Category not found"},
 			})
 		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("transaction_id", id).Msg("Failed to update transaction")
@@ -631,14 +967,14 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 	// Validate fromAccountId
 	if req.FromAccountID <= 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "fromAccountId", Message: "Source account is required"},
+			{Field: "fromAccountId", Code: ValidationCodeRequired, Message: "Source account is required"},
 		})
 	}
 
 	// Validate toAccountId
 	if req.ToAccountID <= 0 {
 		return NewValidationError(c, "Validation failed", []ValidationError{
-			{Field: "toAccountId", Message: "Destination account is required"},
+			{Field: "toAccountId", Code: ValidationCodeRequired, Message: "Destination account is required"},
 		})
 	}
 
@@ -646,7 +982,7 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -656,7 +992,7 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 		parsed, err := time.Parse("2006-01-02", *req.Date)
 		if err != nil {
 			return NewValidationError(c, "Invalid date", []ValidationError{
-				{Field: "date", Message: "Must be in YYYY-MM-DD format"},
+				{Field: "date", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
 			})
 		}
 		transferDate = parsed
@@ -674,12 +1010,17 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 	if err != nil {
 		if errors.Is(err, domain.ErrSameAccountTransfer) {
 			return NewValidationError(c, "Cannot transfer to the same account", []ValidationError{
-				{Field: "toAccountId", Message: "Must be different from source account"},
+				{Field: "toAccountId", Code: ValidationCodeInvalid, Message: "Must be different from source account"},
 			})
 		}
 		if errors.Is(err, domain.ErrInvalidAmount) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "amount", Message: "Amount must be positive"},
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
+			})
+		}
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "amount", Code: ValidationCodeInvalidFormat, Message: fmt.Sprintf("Amount must have at most %d decimal places", precisionLimit(err))},
 			})
 		}
 		if errors.Is(err, domain.ErrAccountNotFound) {
@@ -687,7 +1028,7 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrNotesTooLong) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "notes", Message: "Notes must be 1000 characters or less"},
+				{Field: "notes", Code: ValidationCodeTooLong, Message: "Notes must be 1000 characters or less"},
 			})
 		}
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create transfer")
@@ -701,6 +1042,57 @@ func (h *TransactionHandler) CreateTransfer(c echo.Context) error {
 	})
 }
 
+// ReverseTransfer handles POST /api/v1/transactions/transfers/:id/reverse
+// The id may be either leg of the transfer; a mirrored counter-transfer is created.
+func (h *TransactionHandler) ReverseTransfer(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return NewValidationError(c, "Invalid transaction ID", nil)
+	}
+
+	result, err := h.transactionService.ReverseTransfer(workspaceID, int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			return NewNotFoundError(c, "Transfer not found")
+		}
+		if errors.Is(err, domain.ErrNotTransfer) {
+			return NewValidationError(c, "Transaction is not part of a transfer", nil)
+		}
+		if errors.Is(err, domain.ErrTransferAlreadyReversed) {
+			return NewConflictError(c, "Transfer has already been reversed")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("transaction_id", id).Msg("Failed to reverse transfer")
+		return NewInternalError(c, "Failed to reverse transfer")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Str("pair_id", result.FromTransaction.TransferPairID.String()).Int("transaction_id", id).Msg("Transfer reversed")
+	return c.JSON(http.StatusCreated, TransferResponse{
+		FromTransaction: toTransactionResponse(result.FromTransaction),
+		ToTransaction:   toTransactionResponse(result.ToTransaction),
+	})
+}
+
+// GetTransactionSources handles GET /api/v1/transactions/sources
+func (h *TransactionHandler) GetTransactionSources(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	sources, err := h.transactionService.DistinctSources(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get transaction sources")
+		return NewInternalError(c, "Failed to get transaction sources")
+	}
+
+	return c.JSON(http.StatusOK, sources)
+}
+
 // RecentCategoryResponse represents a recently used category in API responses
 type RecentCategoryResponse struct {
 	ID       int32  `json:"id"`
@@ -746,6 +1138,27 @@ func parseIntParam(s string, out *int32) (bool, error) {
 	return true, nil
 }
 
+// parseCoordinates parses optional latitude/longitude decimal strings.
+// Range and pairing rules are enforced by the service.
+func parseCoordinates(latStr, lngStr *string) (*decimal.Decimal, *decimal.Decimal, error) {
+	var latitude, longitude *decimal.Decimal
+	if latStr != nil && *latStr != "" {
+		parsed, err := decimal.NewFromString(*latStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		latitude = &parsed
+	}
+	if lngStr != nil && *lngStr != "" {
+		parsed, err := decimal.NewFromString(*lngStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		longitude = &parsed
+	}
+	return latitude, longitude, nil
+}
+
 // Helper function to convert domain.Transaction to TransactionResponse
 func toTransactionResponse(transaction *domain.Transaction) TransactionResponse {
 	// Default source to "manual" if not set
@@ -759,7 +1172,7 @@ func toTransactionResponse(transaction *domain.Transaction) TransactionResponse
 		WorkspaceID:     transaction.WorkspaceID,
 		AccountID:       transaction.AccountID,
 		Name:            transaction.Name,
-		Amount:          transaction.Amount.StringFixed(2),
+		Amount:          FormatAmount(transaction.Amount, domain.DefaultCurrency),
 		Type:            string(transaction.Type),
 		TransactionDate: transaction.TransactionDate.Format("2006-01-02"),
 		IsPaid:          transaction.IsPaid,
@@ -771,14 +1184,46 @@ func toTransactionResponse(transaction *domain.Transaction) TransactionResponse
 		TemplateID:  transaction.TemplateID,
 		IsProjected: transaction.IsProjected,
 		IsModified:  transaction.IsModified,
+
+		ModifiedFromTemplate: transaction.ModifiedFromTemplate,
+
+		// Scheduled
+		IsScheduled: transaction.IsScheduled,
+
+		IsRefund: transaction.IsRefund,
+
+		IsLoanFee: transaction.IsLoanFee,
+
+		ExcludeFromReports: transaction.ExcludeFromReports,
+
+		IsTaxDeductible: transaction.IsTaxDeductible,
 	}
 	if transaction.Notes != nil {
 		resp.Notes = transaction.Notes
 	}
+	if transaction.Merchant != nil {
+		resp.Merchant = transaction.Merchant
+	}
+	if transaction.Payee != nil {
+		resp.Payee = transaction.Payee
+	}
+	if transaction.Latitude != nil && transaction.Longitude != nil {
+		lat := transaction.Latitude.String()
+		lng := transaction.Longitude.String()
+		resp.Latitude = &lat
+		resp.Longitude = &lng
+	}
+	if transaction.Location != nil {
+		resp.Location = transaction.Location
+	}
 	if transaction.TransferPairID != nil {
 		pairID := transaction.TransferPairID.String()
 		resp.TransferPairID = &pairID
 	}
+	if transaction.ReversesTransferPairID != nil {
+		reversesPairID := transaction.ReversesTransferPairID.String()
+		resp.ReversesTransferPairID = &reversesPairID
+	}
 	if transaction.CategoryID != nil {
 		resp.CategoryID = transaction.CategoryID
 	}
@@ -794,6 +1239,10 @@ func toTransactionResponse(transaction *domain.Transaction) TransactionResponse
 		billedAt := transaction.BilledAt.Format(time.RFC3339)
 		resp.BilledAt = &billedAt
 	}
+	if transaction.PaidAt != nil {
+		paidAt := transaction.PaidAt.Format(time.RFC3339)
+		resp.PaidAt = &paidAt
+	}
 	// SettledAt removed in v2 - settlement status is determined by isPaid
 	if transaction.SettlementIntent != nil {
 		settlementIntent := string(*transaction.SettlementIntent)
@@ -853,9 +1302,9 @@ func (h *TransactionHandler) GetCCMetrics(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, CCMetricsResponse{
-		Pending:     metrics.Pending.StringFixed(2),
-		Outstanding: metrics.Outstanding.StringFixed(2),
-		Purchases:   metrics.Purchases.StringFixed(2),
+		Pending:     FormatAmount(metrics.Pending, domain.DefaultCurrency),
+		Outstanding: FormatAmount(metrics.Outstanding, domain.DefaultCurrency),
+		Purchases:   FormatAmount(metrics.Purchases, domain.DefaultCurrency),
 	})
 }
 
@@ -919,6 +1368,155 @@ func (h *TransactionHandler) BatchToggleBilled(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// BatchTransitionCCStateRequest represents the request body for a batch CC state transition
+type BatchTransitionCCStateRequest struct {
+	IDs []int32 `json:"ids"`
+	To  string  `json:"to"` // pending, billed or settled
+}
+
+// CCTransitionFailure reports why a transaction was not transitioned
+type CCTransitionFailure struct {
+	ID    int32  `json:"id"`
+	Error string `json:"error"`
+}
+
+// BatchTransitionCCStateResponse represents the per-ID outcome of a batch CC state transition
+type BatchTransitionCCStateResponse struct {
+	Updated []TransactionResponse `json:"updated"`
+	Failed  []CCTransitionFailure `json:"failed"`
+	Count   int                   `json:"count"`
+}
+
+// BatchTransitionCCState godoc
+// @Summary Batch transition CC transactions between states
+// @Description Move CC transactions to pending, billed or settled; illegal transitions are reported per ID
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BatchTransitionCCStateRequest true "Transaction IDs and target state"
+// @Success 200 {object} BatchTransitionCCStateResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Router /transactions/cc-state [patch]
+func (h *TransactionHandler) BatchTransitionCCState(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req BatchTransitionCCStateRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	if len(req.IDs) == 0 {
+		return NewValidationError(c, "At least one transaction ID is required", nil)
+	}
+
+	if len(req.IDs) > 100 {
+		return NewValidationError(c, "Maximum 100 transactions per batch", nil)
+	}
+
+	to := domain.CCState(req.To)
+	if to != domain.CCStatePending && to != domain.CCStateBilled && to != domain.CCStateSettled {
+		return NewValidationError(c, "Invalid target state", []ValidationError{
+			{Field: "to", Code: ValidationCodeInvalidChoice, Message: "Must be one of: pending, billed, settled"},
+		})
+	}
+
+	transactions, err := h.transactionService.BatchTransitionCCState(workspaceID, req.IDs, to)
+	var transitionErr *domain.CCTransitionError
+	if err != nil && !errors.As(err, &transitionErr) {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int("count", len(req.IDs)).Msg("Failed to batch transition CC state")
+		return NewInternalError(c, "Failed to batch transition CC state")
+	}
+
+	response := BatchTransitionCCStateResponse{
+		Updated: make([]TransactionResponse, len(transactions)),
+		Failed:  []CCTransitionFailure{},
+		Count:   len(transactions),
+	}
+	for i, tx := range transactions {
+		response.Updated[i] = toTransactionResponse(tx)
+	}
+	if transitionErr != nil {
+		// Report failures in request order
+		for _, id := range req.IDs {
+			if failure, ok := transitionErr.Failures[id]; ok {
+				response.Failed = append(response.Failed, CCTransitionFailure{ID: id, Error: failure.Error()})
+			}
+		}
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Str("to", req.To).Int("updated", len(transactions)).Int("failed", len(response.Failed)).Msg("Batch CC state transition completed")
+	return c.JSON(http.StatusOK, response)
+}
+
+// BulkMoveAccountRequest represents the request body for moving transactions to another account
+type BulkMoveAccountRequest struct {
+	IDs       []int32 `json:"ids"`
+	AccountID int32   `json:"accountId"`
+}
+
+// BulkMoveAccountResponse reports how many transactions were moved
+type BulkMoveAccountResponse struct {
+	Count int `json:"count"`
+}
+
+// BulkMoveAccount godoc
+// @Summary Move transactions to another account
+// @Description Reassign multiple transactions to an account atomically; transfer legs are skipped, and loan transactions or moves to or from a credit card are refused
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BulkMoveAccountRequest true "Transaction IDs and target account"
+// @Success 200 {object} BulkMoveAccountResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 409 {object} ProblemDetails
+// @Router /transactions/bulk-account [patch]
+func (h *TransactionHandler) BulkMoveAccount(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req BulkMoveAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	if len(req.IDs) == 0 {
+		return NewValidationError(c, "At least one transaction ID is required", nil)
+	}
+
+	if len(req.IDs) > 100 {
+		return NewValidationError(c, "Maximum 100 transactions per batch", nil)
+	}
+
+	count, err := h.transactionService.BulkMoveAccount(workspaceID, req.IDs, req.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "accountId", Code: ValidationCodeNotFound, Message: "Account not found"},
+			})
+		}
+		if errors.Is(err, domain.ErrCannotMoveAcrossCreditCard) {
+			return NewConflictError(c, "Cannot move transactions between a credit card and another account")
+		}
+		if errors.Is(err, domain.ErrCannotMoveLoanTransaction) {
+			return NewConflictError(c, "Loan transactions follow their loan's account and cannot be moved")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Int32("account_id", req.AccountID).Int("count", len(req.IDs)).Msg("Failed to move transactions")
+		return NewInternalError(c, "Failed to move transactions")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("account_id", req.AccountID).Int("moved", count).Msg("Transactions moved to account")
+	return c.JSON(http.StatusOK, BulkMoveAccountResponse{Count: count})
+}
+
 // DeferredGroup represents a group of deferred transactions by month
 type DeferredGroup struct {
 	Month        string                `json:"month"`        // "2026-01"
@@ -1013,7 +1611,7 @@ func (h *TransactionHandler) GetImmediateToSettle(c echo.Context) error {
 	group := ImmediateGroup{
 		Month:        month.Format("2006-01"),
 		MonthLabel:   month.Format("January"),
-		TotalAmount:  total.StringFixed(2),
+		TotalAmount:  FormatAmount(total, domain.DefaultCurrency),
 		ItemCount:    len(transactions),
 		Transactions: txResponses,
 	}
@@ -1030,6 +1628,45 @@ type PendingDeferredGroup struct {
 	Transactions []TransactionResponse `json:"transactions"`
 }
 
+// GetUncategorized returns the month's expenses without a category
+// @Summary Get uncategorized expenses
+// @Description Returns expenses with no category for a month so they can be categorized
+// @Tags transactions
+// @Produce json
+// @Param month query string false "Month in YYYY-MM format (defaults to current month)"
+// @Security BearerAuth
+// @Success 200 {array} TransactionResponse
+// @Failure 400 {object} ProblemDetails
+// @Failure 401 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /transactions/uncategorized [get]
+func (h *TransactionHandler) GetUncategorized(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	month := c.QueryParam("month")
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+
+	transactions, err := h.transactionService.ListUncategorized(workspaceID, month)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMonthFormat) {
+			return NewValidationError(c, "Invalid month format. Use YYYY-MM", nil)
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get uncategorized transactions")
+		return NewInternalError(c, "Failed to get uncategorized transactions")
+	}
+
+	response := make([]TransactionResponse, len(transactions))
+	for i, tx := range transactions {
+		response[i] = toTransactionResponse(tx)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
 // GetPendingDeferred returns pending (not yet billed) deferred CC transactions
 // @Summary Get pending deferred CC transactions
 // @Description Returns pending CC transactions with deferred intent (Pay Next Month) for visibility
@@ -1078,7 +1715,7 @@ func (h *TransactionHandler) GetPendingDeferred(c echo.Context) error {
 	group := PendingDeferredGroup{
 		Month:        month.Format("2006-01"),
 		MonthLabel:   month.Format("January"),
-		TotalAmount:  total.StringFixed(2),
+		TotalAmount:  FormatAmount(total, domain.DefaultCurrency),
 		ItemCount:    len(transactions),
 		Transactions: txResponses,
 	}
@@ -1096,13 +1733,48 @@ type OverdueGroupResponse struct {
 	Transactions  []TransactionResponse `json:"transactions"`
 }
 
+// PromoteScheduled activates scheduled transactions whose date has arrived
+// @Summary Promote due scheduled transactions
+// @Description Clears the scheduled flag on transactions dated today or earlier so they count toward balances
+// @Tags transactions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} TransactionResponse
+// @Failure 401 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 500 {object} ProblemDetails
+// @Router /transactions/scheduled/promote [post]
+func (h *TransactionHandler) PromoteScheduled(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	promoted, err := h.transactionService.PromoteScheduled(workspaceID)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to promote scheduled transactions")
+		return NewInternalError(c, "Failed to promote scheduled transactions")
+	}
+
+	response := make([]TransactionResponse, len(promoted))
+	for i, tx := range promoted {
+		response[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // GetOverdue returns overdue CC transactions grouped by month
-// @Summary Get overdue CC transactions
-// @Description Returns CC transactions that are billed but overdue (2+ months), grouped by month
+// @Summary Get overdue transactions
+// @Description Returns CC transactions that are billed but overdue (2+ months), grouped by month.
+// @Description With kind=unpaid, returns a flat list of unpaid expenses dated before asOf (default today), oldest first.
 // @Tags transactions
 // @Produce json
 // @Security BearerAuth
+// @Param kind query string false "unpaid for past-due unpaid expenses"
+// @Param asOf query string false "Cutoff date (YYYY-MM-DD) for kind=unpaid"
 // @Success 200 {array} OverdueGroupResponse
+// @Failure 400 {object} ProblemDetails
 // @Failure 401 {object} ProblemDetails
 // @Failure 500 {object} ProblemDetails
 // @Router /transactions/overdue [get]
@@ -1112,6 +1784,10 @@ func (h *TransactionHandler) GetOverdue(c echo.Context) error {
 		return NewUnauthorizedError(c, "Workspace required")
 	}
 
+	if c.QueryParam("kind") == "unpaid" {
+		return h.getOverdueUnpaid(c, workspaceID)
+	}
+
 	groups, err := h.transactionService.GetOverdue(workspaceID)
 	if err != nil {
 		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get overdue transactions")
@@ -1129,7 +1805,7 @@ func (h *TransactionHandler) GetOverdue(c echo.Context) error {
 			Month:         group.Month,
 			MonthLabel:    group.MonthLabel,
 			MonthsOverdue: group.MonthsOverdue,
-			TotalAmount:   group.TotalAmount.StringFixed(2),
+			TotalAmount:   FormatAmount(group.TotalAmount, domain.DefaultCurrency),
 			ItemCount:     group.ItemCount,
 			Transactions:  transactions,
 		}
@@ -1138,6 +1814,33 @@ func (h *TransactionHandler) GetOverdue(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// getOverdueUnpaid returns unpaid expenses dated before the asOf query date (default today)
+func (h *TransactionHandler) getOverdueUnpaid(c echo.Context, workspaceID int32) error {
+	asOf := time.Now()
+	if asOfStr := c.QueryParam("asOf"); asOfStr != "" {
+		parsed, err := time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			return NewValidationError(c, "Invalid asOf date", []ValidationError{
+				{Field: "asOf", Code: ValidationCodeInvalidFormat, Message: "Must be in YYYY-MM-DD format"},
+			})
+		}
+		asOf = parsed
+	}
+
+	transactions, err := h.transactionService.GetOverdueTransactions(workspaceID, asOf)
+	if err != nil {
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get overdue unpaid transactions")
+		return NewInternalError(c, "Failed to get overdue transactions")
+	}
+
+	response := make([]TransactionResponse, len(transactions))
+	for i, tx := range transactions {
+		response[i] = toTransactionResponse(tx)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // UpdateAmountRequest represents the update amount request body
 type UpdateAmountRequest struct {
 	Amount string `json:"amount"`
@@ -1178,7 +1881,7 @@ func (h *TransactionHandler) UpdateAmount(c echo.Context) error {
 	amount, err := decimal.NewFromString(req.Amount)
 	if err != nil {
 		return NewValidationError(c, "Invalid amount", []ValidationError{
-			{Field: "amount", Message: "Must be a valid decimal number"},
+			{Field: "amount", Code: ValidationCodeInvalidFormat, Message: "Must be a valid decimal number"},
 		})
 	}
 
@@ -1189,7 +1892,12 @@ func (h *TransactionHandler) UpdateAmount(c echo.Context) error {
 		}
 		if errors.Is(err, domain.ErrInvalidAmount) {
 			return NewValidationError(c, "Validation failed", []ValidationError{
-				{Field: "amount", Message: "Amount must be positive"},
+				{Field: "amount", Code: ValidationCodeOutOfRange, Message: "Amount must be positive"},
+			})
+		}
+		if errors.Is(err, domain.ErrExcessivePrecision) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "amount", Code: ValidationCodeInvalidFormat, Message: fmt.Sprintf("Amount must have at most %d decimal places", precisionLimit(err))},
 			})
 		}
 		log.Error().Err(err).Int32("id", int32(id)).Msg("Failed to update transaction amount")
@@ -1226,7 +1934,7 @@ func groupTransactionsByMonth(transactions []*domain.Transaction) []DeferredGrou
 
 		// Update total
 		currentTotal, _ := decimal.NewFromString(group.TotalAmount)
-		group.TotalAmount = currentTotal.Add(tx.Amount).StringFixed(2)
+		group.TotalAmount = FormatAmount(currentTotal.Add(tx.Amount), domain.DefaultCurrency)
 	}
 
 	// Convert map to slice in order
@@ -1237,3 +1945,12 @@ func groupTransactionsByMonth(transactions []*domain.Transaction) []DeferredGrou
 
 	return result
 }
+
+// precisionLimit returns the decimal-place limit reported by an ErrExcessivePrecision error
+func precisionLimit(err error) int32 {
+	var precisionErr domain.ErrPrecisionExceeded
+	if errors.As(err, &precisionErr) {
+		return precisionErr.MaxPlaces
+	}
+	return domain.DefaultMaxDecimalPlaces
+}
@@ -71,6 +71,48 @@ func TestCreateTransaction_Success(t *testing.T) {
 	}
 }
 
+func TestCreateTransaction_WithPayee(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	handler := NewTransactionHandler(transactionService)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+	})
+
+	reqBody := `{"accountId": 1, "name": "March rent", "amount": "1200.00", "type": "expense", "payee": "  Mr.   Tan  "}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", workspaceID)
+
+	if err := handler.CreateTransaction(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", rec.Code)
+	}
+
+	var response TransactionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Payee == nil || *response.Payee != "Mr. Tan" {
+		t.Errorf("Expected normalized payee 'Mr. Tan', got %v", response.Payee)
+	}
+	if response.Name != "March rent" {
+		t.Errorf("Expected name to stay 'March rent', got %s", response.Name)
+	}
+}
+
 func TestCreateTransaction_WithDate(t *testing.T) {
 	e := echo.New()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -473,6 +515,113 @@ func TestGetTransactions_EmptyList(t *testing.T) {
 	}
 }
 
+func TestGetTransactions_UnknownRange(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	handler := NewTransactionHandler(transactionService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions?range=next_year", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	err := handler.GetTransactions(c)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestGetTransactions_CursorMode(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	handler := NewTransactionHandler(transactionService)
+
+	for i := int32(1); i <= 3; i++ {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:          i,
+			WorkspaceID: 1,
+			AccountID:   1,
+			Name:        "Transaction",
+			Amount:      decimal.NewFromInt(10),
+			Type:        domain.TransactionTypeExpense,
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions?limit=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetTransactions(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response CursorTransactionsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 2 {
+		t.Errorf("Expected 2 transactions, got %d", len(response.Data))
+	}
+	if response.NextCursor == nil {
+		t.Fatal("Expected a next cursor")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/transactions?limit=2&cursor="+*response.NextCursor, nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetTransactions(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	response = CursorTransactionsResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].ID != 3 {
+		t.Errorf("Expected only transaction 3 on the last page, got %+v", response.Data)
+	}
+	if response.NextCursor != nil {
+		t.Errorf("Expected no next cursor on the last page, got %s", *response.NextCursor)
+	}
+}
+
+func TestGetTransactions_InvalidCursor(t *testing.T) {
+	e := echo.New()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	handler := NewTransactionHandler(transactionService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions?cursor=tampered&limit=100", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, "auth0|test", "test@example.com", "Test User", "", 1)
+
+	if err := handler.GetTransactions(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
+
 func TestGetTransactions_WorkspaceIsolation(t *testing.T) {
 	e := echo.New()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/middleware"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// WorkspaceHandler handles workspace sharing HTTP requests
+type WorkspaceHandler struct {
+	workspaceService *service.WorkspaceService
+}
+
+// NewWorkspaceHandler creates a new WorkspaceHandler
+func NewWorkspaceHandler(workspaceService *service.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{workspaceService: workspaceService}
+}
+
+// CreateInviteRequest represents the create invite request body
+type CreateInviteRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // "editor" or "viewer"
+}
+
+// InviteResponse represents a workspace invite in API responses
+type InviteResponse struct {
+	ID          int32  `json:"id"`
+	WorkspaceID int32  `json:"workspaceId"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	Token       string `json:"token"`
+	ExpiresAt   string `json:"expiresAt"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// MemberResponse represents a workspace membership in API responses
+type MemberResponse struct {
+	WorkspaceID int32  `json:"workspaceId"`
+	UserID      string `json:"userId"`
+	Role        string `json:"role"`
+}
+
+// CreateInvite handles POST /api/v1/workspace/invites
+func (h *WorkspaceHandler) CreateInvite(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+	auth0ID := middleware.GetAuth0ID(c)
+	if auth0ID == "" {
+		return NewUnauthorizedError(c, "Authentication required")
+	}
+
+	var req CreateInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	invite, err := h.workspaceService.CreateInvite(workspaceID, auth0ID, service.CreateInviteInput{
+		Email: req.Email,
+		Role:  domain.WorkspaceRole(req.Role),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInviteEmailRequired) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "email", Message: "Email is required"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidInviteRole) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "role", Message: "Role must be 'editor' or 'viewer'"},
+			})
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return NewForbiddenError(c, "Only workspace owners can invite collaborators")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to create workspace invite")
+		return NewInternalError(c, "Failed to create invite")
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("invite_id", invite.ID).Str("role", string(invite.Role)).Msg("Workspace invite created")
+
+	return c.JSON(http.StatusCreated, InviteResponse{
+		ID:          invite.ID,
+		WorkspaceID: invite.WorkspaceID,
+		Email:       invite.Email,
+		Role:        string(invite.Role),
+		Token:       invite.Token,
+		ExpiresAt:   invite.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:   invite.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// AcceptInvite handles POST /api/v1/workspace/invites/:token/accept
+func (h *WorkspaceHandler) AcceptInvite(c echo.Context) error {
+	auth0ID := middleware.GetAuth0ID(c)
+	if auth0ID == "" {
+		return NewUnauthorizedError(c, "Authentication required")
+	}
+
+	token := c.Param("token")
+	if token == "" {
+		return NewValidationError(c, "Invite token is required", nil)
+	}
+
+	member, err := h.workspaceService.AcceptInvite(token, auth0ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInviteNotFound) {
+			return NewNotFoundError(c, "Invite not found")
+		}
+		if errors.Is(err, domain.ErrInviteExpired) {
+			return NewConflictError(c, "Invite has expired")
+		}
+		if errors.Is(err, domain.ErrInviteAlreadyAccepted) {
+			return NewConflictError(c, "Invite has already been accepted")
+		}
+		if errors.Is(err, domain.ErrInviteEmailMismatch) {
+			return NewForbiddenError(c, "Invite was sent to a different email address")
+		}
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return NewNotFoundError(c, "User not found")
+		}
+		log.Error().Err(err).Str("auth0_id", auth0ID).Msg("Failed to accept workspace invite")
+		return NewInternalError(c, "Failed to accept invite")
+	}
+
+	return c.JSON(http.StatusOK, MemberResponse{
+		WorkspaceID: member.WorkspaceID,
+		UserID:      member.UserID.String(),
+		Role:        string(member.Role),
+	})
+}
+
+// UpdateWorkspaceSettingsRequest represents the update workspace settings request body
+type UpdateWorkspaceSettingsRequest struct {
+	Currency string `json:"currency"` // ISO 4217 code, e.g. "MYR"
+}
+
+// UpdateRoundUpSavingsRequest represents the round-up savings settings request body
+type UpdateRoundUpSavingsRequest struct {
+	AccountID *int32 `json:"accountId"` // Savings account; null disables round-up savings
+}
+
+// UpdateGenerationHorizonRequest represents the recurring generation horizon request body
+type UpdateGenerationHorizonRequest struct {
+	Months int32 `json:"months"` // Months past the current one to generate, 0-12
+}
+
+// WorkspaceSettingsResponse represents workspace settings in API responses
+type WorkspaceSettingsResponse struct {
+	Currency                string `json:"currency"`
+	CurrencySymbol          string `json:"currencySymbol"`
+	RoundUpSavingsAccountID *int32 `json:"roundUpSavingsAccountId"`
+	GenerationHorizonMonths int32  `json:"generationHorizonMonths"`
+}
+
+// GetSettings handles GET /api/v1/workspace/settings
+func (h *WorkspaceHandler) GetSettings(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	settings, err := h.workspaceService.GetSettings(workspaceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWorkspaceNotFound) {
+			return NewNotFoundError(c, "Workspace not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to get workspace settings")
+		return NewInternalError(c, "Failed to get workspace settings")
+	}
+
+	return c.JSON(http.StatusOK, toWorkspaceSettingsResponse(settings))
+}
+
+// UpdateSettings handles PUT /api/v1/workspace/settings
+func (h *WorkspaceHandler) UpdateSettings(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req UpdateWorkspaceSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	settings, err := h.workspaceService.UpdateCurrency(workspaceID, req.Currency)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCurrency) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "currency", Code: ValidationCodeInvalidFormat, Message: "Currency must be an ISO 4217 code"},
+			})
+		}
+		if errors.Is(err, domain.ErrWorkspaceNotFound) {
+			return NewNotFoundError(c, "Workspace not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to update workspace settings")
+		return NewInternalError(c, "Failed to update workspace settings")
+	}
+
+	return c.JSON(http.StatusOK, toWorkspaceSettingsResponse(settings))
+}
+
+// UpdateRoundUpSavings handles PUT /api/v1/workspace/settings/round-up
+// Sets the savings account each expense's round-up is transferred to, or disables round-ups
+func (h *WorkspaceHandler) UpdateRoundUpSavings(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req UpdateRoundUpSavingsRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	settings, err := h.workspaceService.UpdateRoundUpSavingsAccount(workspaceID, req.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "accountId", Code: ValidationCodeNotFound, Message: "Account not found"},
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidRoundUpAccount) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "accountId", Code: ValidationCodeInvalidChoice, Message: "Round-up savings account cannot be a credit card"},
+			})
+		}
+		if errors.Is(err, domain.ErrWorkspaceNotFound) {
+			return NewNotFoundError(c, "Workspace not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to update round-up savings")
+		return NewInternalError(c, "Failed to update round-up savings")
+	}
+
+	return c.JSON(http.StatusOK, toWorkspaceSettingsResponse(settings))
+}
+
+// UpdateGenerationHorizon handles PUT /api/v1/workspace/settings/generation-horizon
+// Sets how many months past the current one recurring generate-ahead covers
+func (h *WorkspaceHandler) UpdateGenerationHorizon(c echo.Context) error {
+	workspaceID := middleware.GetWorkspaceID(c)
+	if workspaceID == 0 {
+		return NewUnauthorizedError(c, "Workspace required")
+	}
+
+	var req UpdateGenerationHorizonRequest
+	if err := c.Bind(&req); err != nil {
+		return NewValidationError(c, "Invalid request body", nil)
+	}
+
+	settings, err := h.workspaceService.UpdateGenerationHorizon(workspaceID, req.Months)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGenerationHorizon) {
+			return NewValidationError(c, "Validation failed", []ValidationError{
+				{Field: "months", Code: ValidationCodeOutOfRange, Message: fmt.Sprintf("Months must be between 0 and %d", domain.MaxGenerationHorizonMonths)},
+			})
+		}
+		if errors.Is(err, domain.ErrWorkspaceNotFound) {
+			return NewNotFoundError(c, "Workspace not found")
+		}
+		log.Error().Err(err).Int32("workspace_id", workspaceID).Msg("Failed to update generation horizon")
+		return NewInternalError(c, "Failed to update generation horizon")
+	}
+
+	return c.JSON(http.StatusOK, toWorkspaceSettingsResponse(settings))
+}
+
+func toWorkspaceSettingsResponse(settings *domain.WorkspaceSettings) WorkspaceSettingsResponse {
+	return WorkspaceSettingsResponse{
+		Currency:                settings.Currency,
+		CurrencySymbol:          settings.CurrencySymbol,
+		RoundUpSavingsAccountID: settings.RoundUpSavingsAccountID,
+		GenerationHorizonMonths: settings.GenerationHorizonMonths,
+	}
+}
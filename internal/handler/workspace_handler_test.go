@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/service"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func setupWorkspaceHandler() (*WorkspaceHandler, *testutil.MockWorkspaceRepository, *testutil.MockUserRepository) {
+	userRepo := testutil.NewMockUserRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	inviteRepo := testutil.NewMockWorkspaceInviteRepository(workspaceRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, inviteRepo, userRepo)
+	return NewWorkspaceHandler(workspaceService), workspaceRepo, userRepo
+}
+
+func TestCreateInvite_OwnerCreated(t *testing.T) {
+	e := echo.New()
+	handler, workspaceRepo, userRepo := setupWorkspaceHandler()
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|owner", Email: "owner@example.com"}
+	userRepo.AddUser(owner)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Household"}, owner.Auth0ID)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspace/invites", strings.NewReader(`{"email":"friend@example.com","role":"editor"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, owner.Auth0ID, owner.Email, "", "", 1)
+
+	if err := handler.CreateInvite(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", rec.Code)
+	}
+}
+
+func TestCreateInvite_NonOwnerForbidden(t *testing.T) {
+	e := echo.New()
+	handler, workspaceRepo, userRepo := setupWorkspaceHandler()
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|owner", Email: "owner@example.com"}
+	viewer := &domain.User{ID: uuid.New(), Auth0ID: "auth0|viewer", Email: "viewer@example.com"}
+	userRepo.AddUser(owner)
+	userRepo.AddUser(viewer)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Household"}, owner.Auth0ID)
+	workspaceRepo.AddMember(&domain.WorkspaceMember{WorkspaceID: 1, UserID: viewer.ID, Role: domain.WorkspaceRoleViewer})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspace/invites", strings.NewReader(`{"email":"friend@example.com","role":"editor"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, viewer.Auth0ID, viewer.Email, "", "", 1)
+
+	_ = handler.CreateInvite(c)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", rec.Code)
+	}
+}
+
+func TestAcceptInvite_NotFound(t *testing.T) {
+	e := echo.New()
+	handler, _, _ := setupWorkspaceHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspace/invites/missing/accept", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("token")
+	c.SetParamValues("missing")
+	setupAuthContext(c, "auth0|someone", "someone@example.com", "", "")
+
+	_ = handler.AcceptInvite(c)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestGetSettings_ReturnsConfiguredSymbol(t *testing.T) {
+	e := echo.New()
+	handler, workspaceRepo, userRepo := setupWorkspaceHandler()
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|owner", Email: "owner@example.com"}
+	userRepo.AddUser(owner)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Household", Currency: "GBP"}, owner.Auth0ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workspace/settings", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, owner.Auth0ID, owner.Email, "", "", 1)
+
+	if err := handler.GetSettings(c); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"currency":"GBP"`) || !strings.Contains(rec.Body.String(), `"currencySymbol":"£"`) {
+		t.Errorf("Expected GBP settings with £ symbol, got %s", rec.Body.String())
+	}
+}
+
+func TestUpdateSettings_InvalidCurrency(t *testing.T) {
+	e := echo.New()
+	handler, workspaceRepo, userRepo := setupWorkspaceHandler()
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|owner", Email: "owner@example.com"}
+	userRepo.AddUser(owner)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Household"}, owner.Auth0ID)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/workspace/settings", strings.NewReader(`{"currency":"RM"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	setupAuthContextWithWorkspace(c, owner.Auth0ID, owner.Email, "", "", 1)
+
+	_ = handler.UpdateSettings(c)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
@@ -26,7 +26,8 @@ type APITokenValidator interface {
 
 // APITokenAuthMiddleware provides API token authentication middleware
 type APITokenAuthMiddleware struct {
-	validator APITokenValidator
+	validator    APITokenValidator
+	roleProvider MemberRoleProvider
 }
 
 // NewAPITokenAuthMiddleware creates a new APITokenAuthMiddleware
@@ -34,6 +35,11 @@ func NewAPITokenAuthMiddleware(validator APITokenValidator) *APITokenAuthMiddlew
 	return &APITokenAuthMiddleware{validator: validator}
 }
 
+// SetRoleProvider sets the lookup used to resolve the token owner's workspace role
+func (m *APITokenAuthMiddleware) SetRoleProvider(provider MemberRoleProvider) {
+	m.roleProvider = provider
+}
+
 // Authenticate returns an Echo middleware that validates API tokens
 func (m *APITokenAuthMiddleware) Authenticate() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -95,6 +101,16 @@ func (m *APITokenAuthMiddleware) validateAndSetContext(c echo.Context, token str
 	ctx = context.WithValue(ctx, APITokenIDKey, apiToken.ID)
 	ctx = context.WithValue(ctx, IsAPITokenAuthKey, true)
 
+	// Tokens act with their owner's current role in the workspace
+	if m.roleProvider != nil {
+		role, err := m.roleProvider.GetMemberRole(apiToken.WorkspaceID, apiToken.UserID)
+		if err != nil {
+			log.Debug().Err(err).Str("token_id", apiToken.ID.String()).Msg("API token owner is no longer a workspace member")
+			return unauthorizedError(c, "Invalid or expired API token")
+		}
+		ctx = context.WithValue(ctx, WorkspaceRoleKey, role)
+	}
+
 	c.SetRequest(c.Request().WithContext(ctx))
 
 	log.Debug().
@@ -4,11 +4,12 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/auth0/go-jwt-middleware/v2/jwks"
 	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 )
@@ -37,30 +38,49 @@ const (
 	WorkspaceIDKey contextKey = "workspace_id"
 )
 
+// WorkspaceHeader lets clients switch to one of their other workspaces
+const WorkspaceHeader = "X-Workspace-ID"
+
 // WorkspaceProvider provides workspace lookup by Auth0 ID
 type WorkspaceProvider interface {
 	GetWorkspaceByAuth0ID(auth0ID string) (workspaceID int32, err error)
+	// GetSelectedWorkspace verifies the user can access workspaceID and returns it
+	GetSelectedWorkspace(auth0ID string, workspaceID int32) (int32, error)
+	// GetWorkspaceRole returns the user's role in the workspace
+	GetWorkspaceRole(auth0ID string, workspaceID int32) (domain.WorkspaceRole, error)
 }
 
 // AuthMiddleware provides JWT validation middleware
 type AuthMiddleware struct {
 	validator         *validator.Validator
+	keys              *jwksProvider
 	workspaceProvider WorkspaceProvider
 }
 
 // NewAuthMiddleware creates a new AuthMiddleware with Auth0 configuration
-func NewAuthMiddleware(domain, audience string, workspaceProvider WorkspaceProvider) (*AuthMiddleware, error) {
+// Signing keys are cached and refreshed per jwksOptions; call Stop to end the background refresh
+func NewAuthMiddleware(domain, audience string, workspaceProvider WorkspaceProvider, jwksOptions JWKSOptions) (*AuthMiddleware, error) {
 	issuerURL, err := url.Parse("https://" + domain + "/")
 	if err != nil {
 		return nil, err
 	}
 
-	provider := jwks.NewCachingProvider(issuerURL, 5*time.Minute)
+	keys := newJWKSProvider(issuerURL.JoinPath(".well-known", "jwks.json").String(), jwksOptions)
+
+	m, err := newAuthMiddleware(keys, issuerURL.String(), audience, workspaceProvider)
+	if err != nil {
+		keys.Stop()
+		return nil, err
+	}
+	return m, nil
+}
 
+// newAuthMiddleware builds the middleware around an existing key provider
+func newAuthMiddleware(keys *jwksProvider, issuer, audience string, workspaceProvider WorkspaceProvider) (*AuthMiddleware, error) {
 	jwtValidator, err := validator.New(
-		provider.KeyFunc,
+		keys.KeyFunc,
 		validator.RS256,
-		issuerURL.String(),
+		issuer,
 		[]string{audience},
 		validator.WithCustomClaims(func() validator.CustomClaims {
 			return &CustomClaims{}
@@ -73,10 +93,18 @@ func NewAuthMiddleware(domain, audience string, workspaceProvider WorkspaceProvi
 
 	return &AuthMiddleware{
 		validator:         jwtValidator,
+		keys:              keys,
 		workspaceProvider: workspaceProvider,
 	}, nil
 }
 
+// Stop ends the background JWKS refresh
+func (m *AuthMiddleware) Stop() {
+	if m.keys != nil {
+		m.keys.Stop()
+	}
+}
+
 // Authenticate returns an Echo middleware that validates JWT tokens
 func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -94,8 +122,9 @@ func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 
 			token := parts[1]
 
-			// Validate the token
-			claims, err := m.validator.ValidateToken(c.Request().Context(), token)
+			// Validate the token; the key ID lets the key provider refetch on rotation
+			keyCtx := context.WithValue(c.Request().Context(), jwksKeyIDKey, tokenKeyID(token))
+			claims, err := m.validator.ValidateToken(keyCtx, token)
 			if err != nil {
 				log.Debug().Err(err).Msg("Token validation failed")
 				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
@@ -114,12 +143,17 @@ func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 
 			// Fetch workspace by auth0_id and inject into context
 			if m.workspaceProvider != nil {
-				workspaceID, err := m.workspaceProvider.GetWorkspaceByAuth0ID(auth0ID)
+				workspaceID, err := resolveWorkspaceID(m.workspaceProvider, auth0ID, c.Request().Header.Get(WorkspaceHeader))
 				if err != nil {
-					log.Debug().Err(err).Str("auth0_id", auth0ID).Msg("Workspace lookup failed")
-					return echo.NewHTTPError(http.StatusUnauthorized, "workspace not found")
+					return err
+				}
+				role, err := m.workspaceProvider.GetWorkspaceRole(auth0ID, workspaceID)
+				if err != nil {
+					log.Debug().Err(err).Str("auth0_id", auth0ID).Int32("workspace_id", workspaceID).Msg("Workspace role lookup failed")
+					return echo.NewHTTPError(http.StatusForbidden, "workspace access denied")
 				}
 				ctx = context.WithValue(ctx, WorkspaceIDKey, workspaceID)
+				ctx = context.WithValue(ctx, WorkspaceRoleKey, role)
 			}
 
 			c.SetRequest(c.Request().WithContext(ctx))
@@ -129,6 +163,30 @@ func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 	}
 }
 
+// resolveWorkspaceID picks the workspace for the request: the one named in the
+// selected-workspace header if present, otherwise the user's default workspace
+func resolveWorkspaceID(provider WorkspaceProvider, auth0ID, selected string) (int32, error) {
+	if selected == "" {
+		workspaceID, err := provider.GetWorkspaceByAuth0ID(auth0ID)
+		if err != nil {
+			log.Debug().Err(err).Str("auth0_id", auth0ID).Msg("Workspace lookup failed")
+			return 0, echo.NewHTTPError(http.StatusUnauthorized, "workspace not found")
+		}
+		return workspaceID, nil
+	}
+
+	requestedID, err := strconv.ParseInt(selected, 10, 32)
+	if err != nil || requestedID <= 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid workspace header")
+	}
+	workspaceID, err := provider.GetSelectedWorkspace(auth0ID, int32(requestedID))
+	if err != nil {
+		log.Debug().Err(err).Str("auth0_id", auth0ID).Int64("workspace_id", requestedID).Msg("Selected workspace lookup failed")
+		return 0, echo.NewHTTPError(http.StatusForbidden, "workspace access denied")
+	}
+	return workspaceID, nil
+}
+
 // GetAuth0ID extracts the Auth0 user ID from the context
 func GetAuth0ID(c echo.Context) string {
 	if id, ok := c.Request().Context().Value(Auth0IDKey).(string); ok {
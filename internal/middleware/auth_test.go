@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/labstack/echo/v4"
 )
 
@@ -279,6 +280,7 @@ func TestGetWorkspaceID(t *testing.T) {
 // MockWorkspaceProvider implements WorkspaceProvider for testing
 type MockWorkspaceProvider struct {
 	workspaceID int32
+	accessible  []int32 // additional workspaces the user may select via header
 	err         error
 }
 
@@ -289,6 +291,28 @@ func (m *MockWorkspaceProvider) GetWorkspaceByAuth0ID(auth0ID string) (int32, er
 	return m.workspaceID, nil
 }
 
+func (m *MockWorkspaceProvider) GetSelectedWorkspace(auth0ID string, workspaceID int32) (int32, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	if workspaceID == m.workspaceID {
+		return workspaceID, nil
+	}
+	for _, id := range m.accessible {
+		if id == workspaceID {
+			return id, nil
+		}
+	}
+	return 0, echo.NewHTTPError(http.StatusForbidden, "workspace access denied")
+}
+
+func (m *MockWorkspaceProvider) GetWorkspaceRole(auth0ID string, workspaceID int32) (domain.WorkspaceRole, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return domain.WorkspaceRoleOwner, nil
+}
+
 func TestAuthMiddleware_WorkspaceInjection(t *testing.T) {
 	e := echo.New()
 
@@ -354,3 +378,42 @@ func TestAuthMiddleware_WorkspaceInjection(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveWorkspaceID(t *testing.T) {
+	// User with two workspaces: 1 (default) and 2
+	provider := &MockWorkspaceProvider{workspaceID: 1, accessible: []int32{2}}
+
+	tests := []struct {
+		name       string
+		header     string
+		expectedID int32
+		statusCode int
+	}{
+		{name: "no header resolves default workspace", header: "", expectedID: 1},
+		{name: "header selects second workspace", header: "2", expectedID: 2},
+		{name: "header for inaccessible workspace is forbidden", header: "3", statusCode: http.StatusForbidden},
+		{name: "non-numeric header is rejected", header: "abc", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := resolveWorkspaceID(provider, "auth0|test", tt.header)
+			if tt.statusCode != 0 {
+				httpErr, ok := err.(*echo.HTTPError)
+				if !ok {
+					t.Fatalf("Expected HTTP error, got %v", err)
+				}
+				if httpErr.Code != tt.statusCode {
+					t.Errorf("Expected status %d, got %d", tt.statusCode, httpErr.Code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if id != tt.expectedID {
+				t.Errorf("Expected workspace ID %d, got %d", tt.expectedID, id)
+			}
+		})
+	}
+}
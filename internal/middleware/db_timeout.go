@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultDBQueryTimeout bounds the database work done on behalf of a single request
+const DefaultDBQueryTimeout = 30 * time.Second
+
+// DBTimeout derives a per-request context that expires after timeout, so repository
+// calls made with c.Request().Context() cannot hang a request indefinitely.
+// Handlers map context.DeadlineExceeded to 504. A non-positive timeout disables the
+// deadline, and WebSocket upgrades are skipped since those connections are long-lived.
+func DBTimeout(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if timeout <= 0 || strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket") {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -18,6 +18,7 @@ type problemDetails struct {
 // Error types
 const (
 	errorTypeUnauthorized = "https://fortuna.app/errors/unauthorized"
+	errorTypeForbidden    = "https://fortuna.app/errors/forbidden"
 )
 
 // unauthorizedError creates an unauthorized error response
@@ -30,3 +31,14 @@ func unauthorizedError(c echo.Context, detail string) error {
 		Instance: c.Request().URL.Path,
 	})
 }
+
+// forbiddenError creates a forbidden error response
+func forbiddenError(c echo.Context, detail string) error {
+	return c.JSON(http.StatusForbidden, problemDetails{
+		Type:     errorTypeForbidden,
+		Title:    "Forbidden",
+		Status:   http.StatusForbidden,
+		Detail:   detail,
+		Instance: c.Request().URL.Path,
+	})
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	// DefaultJWKSCacheTTL is how long fetched signing keys are served before a request refetches them
+	DefaultJWKSCacheTTL = 5 * time.Minute
+	// DefaultJWKSRefreshInterval is how often signing keys are refreshed in the background
+	DefaultJWKSRefreshInterval = time.Minute
+	// DefaultJWKSMaxStale is how long past the cache TTL keys are still served while refetches fail
+	DefaultJWKSMaxStale = time.Hour
+	// JWKSMinRefetchInterval throttles refetches triggered by tokens with an unknown key ID
+	JWKSMinRefetchInterval = 10 * time.Second
+	// jwksFetchTimeout bounds a single JWKS request
+	jwksFetchTimeout = 15 * time.Second
+)
+
+// jwksKeyIDKey is the context key for the key ID of the token being validated
+const jwksKeyIDKey contextKey = "jwks_kid"
+
+// JWKSOptions configures how the auth middleware caches the issuer's signing keys
+type JWKSOptions struct {
+	// CacheTTL is how long fetched keys are trusted; zero uses DefaultJWKSCacheTTL
+	CacheTTL time.Duration
+	// RefreshInterval is the background refresh period; zero disables background refresh
+	RefreshInterval time.Duration
+	// MaxStale is how long past CacheTTL cached keys may be served while the endpoint is
+	// unreachable; after that tokens are rejected. Zero uses DefaultJWKSMaxStale
+	MaxStale time.Duration
+}
+
+// jwksProvider fetches and caches a JSON Web Key Set so key rotation is picked
+// up without a restart: keys are refreshed in the background, on expiry, and
+// when a token names a key ID the cached set does not contain
+type jwksProvider struct {
+	jwksURL            string
+	client             *http.Client
+	cacheTTL           time.Duration
+	maxStale           time.Duration
+	minRefetchInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+
+	fetchMu  sync.Mutex // serializes fetches so concurrent misses share one request
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newJWKSProvider creates a provider for jwksURL and starts the background refresh if enabled
+func newJWKSProvider(jwksURL string, opts JWKSOptions) *jwksProvider {
+	cacheTTL := opts.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultJWKSCacheTTL
+	}
+	maxStale := opts.MaxStale
+	if maxStale <= 0 {
+		maxStale = DefaultJWKSMaxStale
+	}
+
+	p := &jwksProvider{
+		jwksURL:            jwksURL,
+		client:             &http.Client{Timeout: jwksFetchTimeout},
+		cacheTTL:           cacheTTL,
+		maxStale:           maxStale,
+		minRefetchInterval: JWKSMinRefetchInterval,
+		stopCh:             make(chan struct{}),
+	}
+
+	if opts.RefreshInterval > 0 {
+		go p.refreshLoop(opts.RefreshInterval)
+	}
+
+	return p
+}
+
+// KeyFunc adheres to the validator's keyFunc signature and returns a *jose.JSONWebKeySet
+// If a refetch fails, the previously cached keys are served rather than rejecting every token,
+// but only until they are maxStale past the cache TTL; after that it fails closed
+func (p *jwksProvider) KeyFunc(ctx context.Context) (interface{}, error) {
+	keys, fetchedAt := p.cached()
+
+	needsFetch := keys == nil || time.Since(fetchedAt) > p.cacheTTL
+	if !needsFetch {
+		// Unknown key ID: the issuer may have rotated keys since the last fetch
+		kid, _ := ctx.Value(jwksKeyIDKey).(string)
+		needsFetch = kid != "" && len(keys.Key(kid)) == 0 && time.Since(fetchedAt) >= p.minRefetchInterval
+	}
+	if !needsFetch {
+		return keys, nil
+	}
+
+	fresh, err := p.refetch(ctx, fetchedAt)
+	if err != nil {
+		if keys != nil && time.Since(fetchedAt) <= p.cacheTTL+p.maxStale {
+			log.Warn().Err(err).Msg("JWKS refetch failed, serving cached keys")
+			return keys, nil
+		}
+		if keys != nil {
+			log.Error().Err(err).Time("fetched_at", fetchedAt).Msg("JWKS refetch failed and cached keys are past the max-stale window")
+		}
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// Stop ends the background refresh
+func (p *jwksProvider) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+}
+
+// cached returns the current key set and when it was fetched
+func (p *jwksProvider) cached() (*jose.JSONWebKeySet, time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.keys, p.fetchedAt
+}
+
+// refetch fetches the key set unless another caller already replaced the one fetched at seen
+func (p *jwksProvider) refetch(ctx context.Context, seen time.Time) (*jose.JSONWebKeySet, error) {
+	p.fetchMu.Lock()
+	defer p.fetchMu.Unlock()
+
+	if keys, fetchedAt := p.cached(); keys != nil && fetchedAt.After(seen) {
+		return keys, nil
+	}
+
+	keys, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return keys, nil
+}
+
+// fetch requests the key set from the JWKS endpoint
+func (p *jwksProvider) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build JWKS request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("could not decode JWKS: %w", err)
+	}
+
+	return &keys, nil
+}
+
+// refreshLoop refetches the key set every interval until Stop is called
+func (p *jwksProvider) refreshLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_, fetchedAt := p.cached()
+			ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+			if _, err := p.refetch(ctx, fetchedAt); err != nil {
+				log.Warn().Err(err).Msg("Background JWKS refresh failed")
+			}
+			cancel()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// tokenKeyID returns the key ID from a compact JWS token's header, or "" if it cannot be read
+func tokenKeyID(token string) string {
+	sig, err := jose.ParseSigned(token)
+	if err != nil || len(sig.Signatures) == 0 {
+		return ""
+	}
+	return sig.Signatures[0].Header.KeyID
+}
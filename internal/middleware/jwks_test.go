@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const (
+	testIssuer   = "https://fortuna-test.auth0.com/"
+	testAudience = "https://api.fortuna.test"
+)
+
+// stubJWKS serves a swappable key set and counts fetches; failing makes it return 503
+type stubJWKS struct {
+	mu      sync.Mutex
+	keys    []*testSigningKey
+	fetches atomic.Int32
+	failing atomic.Bool
+}
+
+type testSigningKey struct {
+	kid     string
+	private *rsa.PrivateKey
+}
+
+func newTestSigningKey(t *testing.T, kid string) *testSigningKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	return &testSigningKey{kid: kid, private: private}
+}
+
+func (s *stubJWKS) setKeys(keys ...*testSigningKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *stubJWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.fetches.Add(1)
+	if s.failing.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	s.mu.Lock()
+	set := jose.JSONWebKeySet{}
+	for _, key := range s.keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: &key.private.PublicKey, KeyID: key.kid, Algorithm: "RS256", Use: "sig"})
+	}
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+// signTestToken issues a valid token for the test issuer/audience signed by key
+func signTestToken(t *testing.T, key *testSigningKey) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key.private, KeyID: key.kid}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   testIssuer,
+		Subject:  "auth0|jwks-test",
+		Audience: jwt.Audience{testAudience},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+// setupJWKSAuth builds an AuthMiddleware backed by the stub endpoint
+func setupJWKSAuth(t *testing.T, stub *stubJWKS, opts JWKSOptions, minRefetchInterval time.Duration) *AuthMiddleware {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	keys := newJWKSProvider(server.URL, opts)
+	keys.minRefetchInterval = minRefetchInterval
+
+	m, err := newAuthMiddleware(keys, testIssuer, testAudience, nil)
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	t.Cleanup(m.Stop)
+	return m
+}
+
+// authenticateStatus runs a request with token through the middleware and returns the status code
+func authenticateStatus(m *AuthMiddleware, token string) int {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := m.Authenticate()(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	if err := handler(c); err != nil {
+		if httpErr, ok := err.(*echo.HTTPError); ok {
+			return httpErr.Code
+		}
+		return http.StatusInternalServerError
+	}
+	return rec.Code
+}
+
+func TestJWKS_RotatedKeyAcceptedAfterBackgroundRefresh(t *testing.T) {
+	oldKey := newTestSigningKey(t, "old")
+	newKey := newTestSigningKey(t, "new")
+	stub := &stubJWKS{}
+	stub.setKeys(oldKey)
+
+	// Long TTL and refetch throttle: only the background refresh can pick up the new key
+	m := setupJWKSAuth(t, stub, JWKSOptions{CacheTTL: time.Hour, RefreshInterval: 20 * time.Millisecond}, time.Hour)
+
+	if status := authenticateStatus(m, signTestToken(t, oldKey)); status != http.StatusOK {
+		t.Fatalf("Expected old key to be accepted, got %d", status)
+	}
+
+	// Rotate mid-run
+	stub.setKeys(oldKey, newKey)
+	newToken := signTestToken(t, newKey)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for authenticateStatus(m, newToken) != http.StatusOK {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected new key to be accepted after background refresh")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stub.fetches.Load() < 2 {
+		t.Errorf("Expected the key set to be refetched, got %d fetches", stub.fetches.Load())
+	}
+}
+
+func TestJWKS_UnknownKidTriggersRefetch(t *testing.T) {
+	oldKey := newTestSigningKey(t, "old")
+	newKey := newTestSigningKey(t, "new")
+	stub := &stubJWKS{}
+	stub.setKeys(oldKey)
+
+	// No background refresh and a long TTL: the unknown kid alone must trigger the refetch
+	m := setupJWKSAuth(t, stub, JWKSOptions{CacheTTL: time.Hour}, 0)
+
+	if status := authenticateStatus(m, signTestToken(t, oldKey)); status != http.StatusOK {
+		t.Fatalf("Expected old key to be accepted, got %d", status)
+	}
+	if got := stub.fetches.Load(); got != 1 {
+		t.Fatalf("Expected 1 fetch, got %d", got)
+	}
+
+	// Known kid is served from cache
+	if status := authenticateStatus(m, signTestToken(t, oldKey)); status != http.StatusOK {
+		t.Fatalf("Expected cached old key to be accepted, got %d", status)
+	}
+	if got := stub.fetches.Load(); got != 1 {
+		t.Errorf("Expected cached keys to be reused, got %d fetches", got)
+	}
+
+	stub.setKeys(newKey)
+	if status := authenticateStatus(m, signTestToken(t, newKey)); status != http.StatusOK {
+		t.Fatalf("Expected new key to be accepted after refetch, got %d", status)
+	}
+	if got := stub.fetches.Load(); got != 2 {
+		t.Errorf("Expected unknown kid to trigger 1 refetch, got %d fetches", got)
+	}
+}
+
+func TestJWKS_UnknownKidRefetchIsThrottled(t *testing.T) {
+	knownKey := newTestSigningKey(t, "known")
+	rogueKey := newTestSigningKey(t, "rogue")
+	stub := &stubJWKS{}
+	stub.setKeys(knownKey)
+
+	m := setupJWKSAuth(t, stub, JWKSOptions{CacheTTL: time.Hour}, time.Hour)
+
+	if status := authenticateStatus(m, signTestToken(t, knownKey)); status != http.StatusOK {
+		t.Fatalf("Expected known key to be accepted, got %d", status)
+	}
+
+	for i := 0; i < 3; i++ {
+		if status := authenticateStatus(m, signTestToken(t, rogueKey)); status != http.StatusUnauthorized {
+			t.Errorf("Expected unknown key to be rejected, got %d", status)
+		}
+	}
+	if got := stub.fetches.Load(); got != 1 {
+		t.Errorf("Expected throttled refetch to skip the endpoint, got %d fetches", got)
+	}
+}
+
+func TestJWKS_StaleKeysServedUntilMaxStale(t *testing.T) {
+	key := newTestSigningKey(t, "key-1")
+	stub := &stubJWKS{}
+	stub.setKeys(key)
+
+	m := setupJWKSAuth(t, stub, JWKSOptions{CacheTTL: 10 * time.Millisecond, MaxStale: 300 * time.Millisecond}, 0)
+	token := signTestToken(t, key)
+
+	if status := authenticateStatus(m, token); status != http.StatusOK {
+		t.Fatalf("Expected token to be accepted, got %d", status)
+	}
+
+	// Past the TTL but inside the max-stale window: cached keys cover the outage
+	stub.failing.Store(true)
+	time.Sleep(50 * time.Millisecond)
+	if status := authenticateStatus(m, token); status != http.StatusOK {
+		t.Errorf("Expected cached keys to be served during the outage, got %d", status)
+	}
+
+	// Past the max-stale window: fail closed
+	time.Sleep(350 * time.Millisecond)
+	if status := authenticateStatus(m, token); status != http.StatusUnauthorized {
+		t.Errorf("Expected token to be rejected once keys are past max-stale, got %d", status)
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// WorkspaceRoleKey is the context key for the caller's role in the current workspace
+const WorkspaceRoleKey contextKey = "workspace_role"
+
+// MemberRoleProvider looks up a user's role in a workspace (used by API token auth)
+type MemberRoleProvider interface {
+	GetMemberRole(workspaceID int32, userID uuid.UUID) (domain.WorkspaceRole, error)
+}
+
+// roleRank orders roles so that higher roles satisfy lower requirements
+var roleRank = map[domain.WorkspaceRole]int{
+	domain.WorkspaceRoleViewer: 1,
+	domain.WorkspaceRoleEditor: 2,
+	domain.WorkspaceRoleOwner:  3,
+}
+
+// RequireRole returns a middleware that rejects callers whose workspace role is below role.
+// Must run after an authentication middleware that resolves the role.
+func RequireRole(role domain.WorkspaceRole) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if roleRank[GetWorkspaceRole(c)] < roleRank[role] {
+				return forbiddenError(c, "Your role in this workspace does not allow this action")
+			}
+			return next(c)
+		}
+	}
+}
+
+// GetWorkspaceRole extracts the caller's workspace role from the context
+func GetWorkspaceRole(c echo.Context) domain.WorkspaceRole {
+	if role, ok := c.Request().Context().Value(WorkspaceRoleKey).(domain.WorkspaceRole); ok {
+		return role
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// withRole simulates the auth middleware resolving the caller's workspace role
+func withRole(role domain.WorkspaceRole) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := context.WithValue(c.Request().Context(), WorkspaceIDKey, int32(1))
+			if role != "" {
+				ctx = context.WithValue(ctx, WorkspaceRoleKey, role)
+			}
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func newRoleTestServer(role domain.WorkspaceRole) *echo.Echo {
+	e := echo.New()
+	transactions := e.Group("/api/v1/transactions")
+	transactions.Use(withRole(role))
+	transactions.GET("", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	transactions.POST("", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, RequireRole(domain.WorkspaceRoleEditor))
+	return e
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       domain.WorkspaceRole
+		method     string
+		expectCode int
+	}{
+		{name: "viewer blocked from creating transaction", role: domain.WorkspaceRoleViewer, method: http.MethodPost, expectCode: http.StatusForbidden},
+		{name: "editor can create transaction", role: domain.WorkspaceRoleEditor, method: http.MethodPost, expectCode: http.StatusCreated},
+		{name: "owner can create transaction", role: domain.WorkspaceRoleOwner, method: http.MethodPost, expectCode: http.StatusCreated},
+		{name: "viewer can list transactions", role: domain.WorkspaceRoleViewer, method: http.MethodGet, expectCode: http.StatusOK},
+		{name: "missing role is blocked", role: "", method: http.MethodPost, expectCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newRoleTestServer(tt.role)
+			req := httptest.NewRequest(tt.method, "/api/v1/transactions", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectCode {
+				t.Errorf("Expected status %d, got %d", tt.expectCode, rec.Code)
+			}
+		})
+	}
+}
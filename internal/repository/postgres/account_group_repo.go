@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dafibh/fortuna/fortuna-backend/db/sqlc"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccountGroupRepository implements domain.AccountGroupRepository using PostgreSQL
+type AccountGroupRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewAccountGroupRepository creates a new AccountGroupRepository
+func NewAccountGroupRepository(pool *pgxpool.Pool) *AccountGroupRepository {
+	return &AccountGroupRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// Create creates a new account group
+func (r *AccountGroupRepository) Create(group *domain.AccountGroup) (*domain.AccountGroup, error) {
+	ctx := context.Background()
+	created, err := r.queries.CreateAccountGroup(ctx, sqlc.CreateAccountGroupParams{
+		WorkspaceID: group.WorkspaceID,
+		Name:        group.Name,
+	})
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return nil, domain.ErrAccountGroupAlreadyExists
+		}
+		return nil, err
+	}
+	return sqlcAccountGroupToDomain(created), nil
+}
+
+// GetByID retrieves an account group by its ID within a workspace
+func (r *AccountGroupRepository) GetByID(workspaceID int32, id int32) (*domain.AccountGroup, error) {
+	ctx := context.Background()
+	group, err := r.queries.GetAccountGroupByID(ctx, sqlc.GetAccountGroupByIDParams{
+		WorkspaceID: workspaceID,
+		ID:          id,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAccountGroupNotFound
+		}
+		return nil, err
+	}
+	return sqlcAccountGroupToDomain(group), nil
+}
+
+// GetAllByWorkspace retrieves all account groups for a workspace, ordered by name
+func (r *AccountGroupRepository) GetAllByWorkspace(workspaceID int32) ([]*domain.AccountGroup, error) {
+	ctx := context.Background()
+	groups, err := r.queries.GetAccountGroupsByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.AccountGroup, len(groups))
+	for i, g := range groups {
+		result[i] = sqlcAccountGroupToDomain(g)
+	}
+	return result, nil
+}
+
+// Update renames an account group
+func (r *AccountGroupRepository) Update(group *domain.AccountGroup) (*domain.AccountGroup, error) {
+	ctx := context.Background()
+	updated, err := r.queries.UpdateAccountGroup(ctx, sqlc.UpdateAccountGroupParams{
+		WorkspaceID: group.WorkspaceID,
+		ID:          group.ID,
+		Name:        group.Name,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAccountGroupNotFound
+		}
+		if isPgUniqueViolation(err) {
+			return nil, domain.ErrAccountGroupAlreadyExists
+		}
+		return nil, err
+	}
+	return sqlcAccountGroupToDomain(updated), nil
+}
+
+// SoftDelete marks an account group as deleted
+func (r *AccountGroupRepository) SoftDelete(workspaceID int32, id int32) error {
+	ctx := context.Background()
+	return r.queries.SoftDeleteAccountGroup(ctx, sqlc.SoftDeleteAccountGroupParams{
+		WorkspaceID: workspaceID,
+		ID:          id,
+	})
+}
+
+// Helper functions
+
+func sqlcAccountGroupToDomain(g sqlc.AccountGroup) *domain.AccountGroup {
+	group := &domain.AccountGroup{
+		ID:          g.ID,
+		WorkspaceID: g.WorkspaceID,
+		Name:        g.Name,
+		CreatedAt:   g.CreatedAt.Time,
+		UpdatedAt:   g.UpdatedAt.Time,
+	}
+	if g.DeletedAt.Valid {
+		group.DeletedAt = &g.DeletedAt.Time
+	}
+	return group
+}
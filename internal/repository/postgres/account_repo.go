@@ -35,11 +35,12 @@ func (r *AccountRepository) Create(account *domain.Account) (*domain.Account, er
 	}
 
 	created, err := r.queries.CreateAccount(ctx, sqlc.CreateAccountParams{
-		WorkspaceID:    account.WorkspaceID,
-		Name:           account.Name,
-		AccountType:    string(account.AccountType),
-		Template:       string(account.Template),
-		InitialBalance: initialBalance,
+		WorkspaceID:         account.WorkspaceID,
+		Name:                account.Name,
+		AccountType:         string(account.AccountType),
+		Template:            string(account.Template),
+		InitialBalance:      initialBalance,
+		ExcludeFromNetWorth: account.ExcludeFromNetWorth,
 	})
 	if err != nil {
 		return nil, err
@@ -64,10 +65,10 @@ func (r *AccountRepository) GetByID(workspaceID int32, id int32) (*domain.Accoun
 }
 
 // GetAllByWorkspace retrieves all accounts for a workspace
-func (r *AccountRepository) GetAllByWorkspace(workspaceID int32, includeArchived bool) ([]*domain.Account, error) {
+func (r *AccountRepository) GetAllByWorkspace(workspaceID int32, opts domain.ListOptions) ([]*domain.Account, error) {
 	ctx := context.Background()
 
-	if includeArchived {
+	if opts.IncludeDeleted {
 		accounts, err := r.queries.GetAccountsByWorkspaceAll(ctx, workspaceID)
 		if err != nil {
 			return nil, err
@@ -107,6 +108,33 @@ func (r *AccountRepository) Update(workspaceID int32, id int32, name string) (*d
 	return sqlcAccountToDomain(account), nil
 }
 
+// UpdateTemplate changes an account's template and account type
+func (r *AccountRepository) UpdateTemplate(workspaceID int32, id int32, template domain.AccountTemplate, accountType domain.AccountType) (*domain.Account, error) {
+	ctx := context.Background()
+	account, err := r.queries.UpdateAccountTemplate(ctx, sqlc.UpdateAccountTemplateParams{
+		WorkspaceID: workspaceID,
+		ID:          id,
+		Template:    string(template),
+		AccountType: string(accountType),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return sqlcAccountToDomain(account), nil
+}
+
+// CountTransactions counts an account's transactions, including soft-deleted ones
+func (r *AccountRepository) CountTransactions(workspaceID int32, id int32) (int64, error) {
+	ctx := context.Background()
+	return r.queries.CountAccountTransactions(ctx, sqlc.CountAccountTransactionsParams{
+		WorkspaceID: workspaceID,
+		AccountID:   id,
+	})
+}
+
 // SoftDelete marks an account as deleted (sets deleted_at timestamp)
 func (r *AccountRepository) SoftDelete(workspaceID int32, id int32) error {
 	ctx := context.Background()
@@ -132,22 +160,72 @@ func (r *AccountRepository) HardDelete(workspaceID int32, id int32) error {
 	})
 }
 
+// SetGroup assigns an account to a group; a nil groupID moves it to the ungrouped bucket
+func (r *AccountRepository) SetGroup(workspaceID int32, id int32, groupID *int32) (*domain.Account, error) {
+	ctx := context.Background()
+	params := sqlc.SetAccountGroupParams{
+		WorkspaceID: workspaceID,
+		ID:          id,
+	}
+	if groupID != nil {
+		params.AccountGroupID = pgtype.Int4{Int32: *groupID, Valid: true}
+	}
+	account, err := r.queries.SetAccountGroup(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return sqlcAccountToDomain(account), nil
+}
+
+// UngroupByGroup moves all accounts of a group to the ungrouped bucket
+func (r *AccountRepository) UngroupByGroup(workspaceID int32, groupID int32) error {
+	ctx := context.Background()
+	return r.queries.UngroupAccountsByGroup(ctx, sqlc.UngroupAccountsByGroupParams{
+		WorkspaceID:    workspaceID,
+		AccountGroupID: pgtype.Int4{Int32: groupID, Valid: true},
+	})
+}
+
+// SetExcludeFromNetWorth includes or excludes an account's balance from net worth
+func (r *AccountRepository) SetExcludeFromNetWorth(workspaceID int32, id int32, exclude bool) (*domain.Account, error) {
+	ctx := context.Background()
+	account, err := r.queries.SetAccountExcludeFromNetWorth(ctx, sqlc.SetAccountExcludeFromNetWorthParams{
+		WorkspaceID:         workspaceID,
+		ID:                  id,
+		ExcludeFromNetWorth: exclude,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return sqlcAccountToDomain(account), nil
+}
+
 // Helper functions
 
 func sqlcAccountToDomain(a sqlc.Account) *domain.Account {
 	account := &domain.Account{
-		ID:             a.ID,
-		WorkspaceID:    a.WorkspaceID,
-		Name:           a.Name,
-		AccountType:    domain.AccountType(a.AccountType),
-		Template:       domain.AccountTemplate(a.Template),
-		InitialBalance: pgNumericToDecimal(a.InitialBalance),
-		CreatedAt:      a.CreatedAt.Time,
-		UpdatedAt:      a.UpdatedAt.Time,
+		ID:                  a.ID,
+		WorkspaceID:         a.WorkspaceID,
+		Name:                a.Name,
+		AccountType:         domain.AccountType(a.AccountType),
+		Template:            domain.AccountTemplate(a.Template),
+		InitialBalance:      pgNumericToDecimal(a.InitialBalance),
+		CreatedAt:           a.CreatedAt.Time,
+		UpdatedAt:           a.UpdatedAt.Time,
+		ExcludeFromNetWorth: a.ExcludeFromNetWorth,
 	}
 	if a.DeletedAt.Valid {
 		account.DeletedAt = &a.DeletedAt.Time
 	}
+	if a.AccountGroupID.Valid {
+		account.AccountGroupID = &a.AccountGroupID.Int32
+	}
 	return account
 }
 
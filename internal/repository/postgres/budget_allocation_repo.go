@@ -235,6 +235,7 @@ func (r *BudgetAllocationRepository) GetCategoryTransactions(workspaceID int32,
 			Amount:          pgNumericToDecimal(row.Amount),
 			TransactionDate: row.TransactionDate.Time.Format("2006-01-02"),
 			AccountName:     row.AccountName,
+			IsRefund:        row.IsRefund,
 		}
 	}
 	return result, nil
@@ -30,8 +30,12 @@ func NewBudgetCategoryRepository(pool *pgxpool.Pool) *BudgetCategoryRepository {
 func (r *BudgetCategoryRepository) Create(category *domain.BudgetCategory) (*domain.BudgetCategory, error) {
 	ctx := context.Background()
 	created, err := r.queries.CreateBudgetCategory(ctx, sqlc.CreateBudgetCategoryParams{
-		WorkspaceID: category.WorkspaceID,
-		Name:        category.Name,
+		WorkspaceID:     category.WorkspaceID,
+		Name:            category.Name,
+		Description:     stringPtrToPgText(category.Description),
+		Color:           stringPtrToPgText(category.Color),
+		ParentID:        int32PtrToPgInt4(category.ParentID),
+		IsTaxDeductible: category.IsTaxDeductible,
 	})
 	if err != nil {
 		// Check for unique constraint violation
@@ -44,8 +48,7 @@ func (r *BudgetCategoryRepository) Create(category *domain.BudgetCategory) (*dom
 }
 
 // GetByID retrieves a budget category by its ID within a workspace
-func (r *BudgetCategoryRepository) GetByID(workspaceID int32, id int32) (*domain.BudgetCategory, error) {
-	ctx := context.Background()
+func (r *BudgetCategoryRepository) GetByID(ctx context.Context, workspaceID int32, id int32) (*domain.BudgetCategory, error) {
 	category, err := r.queries.GetBudgetCategoryByID(ctx, sqlc.GetBudgetCategoryByIDParams{
 		WorkspaceID: workspaceID,
 		ID:          id,
@@ -89,13 +92,46 @@ func (r *BudgetCategoryRepository) GetAllByWorkspace(workspaceID int32) ([]*doma
 	return result, nil
 }
 
-// Update updates a budget category's name
-func (r *BudgetCategoryRepository) Update(workspaceID int32, id int32, name string) (*domain.BudgetCategory, error) {
+// GetAllWithUsage retrieves all categories with their transaction count and net spend
+func (r *BudgetCategoryRepository) GetAllWithUsage(workspaceID int32) ([]domain.CategoryUsage, error) {
 	ctx := context.Background()
-	category, err := r.queries.UpdateBudgetCategory(ctx, sqlc.UpdateBudgetCategoryParams{
-		WorkspaceID: workspaceID,
-		ID:          id,
-		Name:        name,
+	rows, err := r.queries.GetBudgetCategoriesWithUsage(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.CategoryUsage, len(rows))
+	for i, row := range rows {
+		result[i] = domain.CategoryUsage{
+			Category: sqlcBudgetCategoryToDomain(sqlc.BudgetCategory{
+				ID:              row.ID,
+				WorkspaceID:     row.WorkspaceID,
+				Name:            row.Name,
+				CreatedAt:       row.CreatedAt,
+				UpdatedAt:       row.UpdatedAt,
+				DeletedAt:       row.DeletedAt,
+				Description:     row.Description,
+				Color:           row.Color,
+				ParentID:        row.ParentID,
+				IsTaxDeductible: row.IsTaxDeductible,
+			}),
+			TransactionCount: row.TransactionCount,
+			TotalSpent:       pgNumericToDecimal(row.TotalSpent),
+		}
+	}
+	return result, nil
+}
+
+// Update updates a budget category's name, description, color, parent and tax default
+func (r *BudgetCategoryRepository) Update(category *domain.BudgetCategory) (*domain.BudgetCategory, error) {
+	ctx := context.Background()
+	updated, err := r.queries.UpdateBudgetCategory(ctx, sqlc.UpdateBudgetCategoryParams{
+		WorkspaceID:     category.WorkspaceID,
+		ID:              category.ID,
+		Name:            category.Name,
+		Description:     stringPtrToPgText(category.Description),
+		Color:           stringPtrToPgText(category.Color),
+		ParentID:        int32PtrToPgInt4(category.ParentID),
+		IsTaxDeductible: category.IsTaxDeductible,
 	})
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -107,15 +143,21 @@ func (r *BudgetCategoryRepository) Update(workspaceID int32, id int32, name stri
 		}
 		return nil, err
 	}
-	return sqlcBudgetCategoryToDomain(category), nil
+	return sqlcBudgetCategoryToDomain(updated), nil
 }
 
-// SoftDelete marks a budget category as deleted
+// SoftDelete marks a budget category as deleted and promotes its subcategories to top level
 func (r *BudgetCategoryRepository) SoftDelete(workspaceID int32, id int32) error {
 	ctx := context.Background()
-	return r.queries.SoftDeleteBudgetCategory(ctx, sqlc.SoftDeleteBudgetCategoryParams{
+	if err := r.queries.SoftDeleteBudgetCategory(ctx, sqlc.SoftDeleteBudgetCategoryParams{
 		WorkspaceID: workspaceID,
 		ID:          id,
+	}); err != nil {
+		return err
+	}
+	return r.queries.DetachBudgetCategoryChildren(ctx, sqlc.DetachBudgetCategoryChildrenParams{
+		WorkspaceID: workspaceID,
+		ParentID:    pgtype.Int4{Int32: id, Valid: true},
 	})
 }
 
@@ -136,11 +178,17 @@ func (r *BudgetCategoryRepository) HasTransactions(workspaceID int32, id int32)
 
 func sqlcBudgetCategoryToDomain(c sqlc.BudgetCategory) *domain.BudgetCategory {
 	category := &domain.BudgetCategory{
-		ID:          c.ID,
-		WorkspaceID: c.WorkspaceID,
-		Name:        c.Name,
-		CreatedAt:   c.CreatedAt.Time,
-		UpdatedAt:   c.UpdatedAt.Time,
+		ID:              c.ID,
+		WorkspaceID:     c.WorkspaceID,
+		Name:            c.Name,
+		Description:     pgTextToStringPtr(c.Description),
+		Color:           pgTextToStringPtr(c.Color),
+		IsTaxDeductible: c.IsTaxDeductible,
+		CreatedAt:       c.CreatedAt.Time,
+		UpdatedAt:       c.UpdatedAt.Time,
+	}
+	if c.ParentID.Valid {
+		category.ParentID = &c.ParentID.Int32
 	}
 	if c.DeletedAt.Valid {
 		category.DeletedAt = &c.DeletedAt.Time
@@ -148,6 +196,14 @@ func sqlcBudgetCategoryToDomain(c sqlc.BudgetCategory) *domain.BudgetCategory {
 	return category
 }
 
+// int32PtrToPgInt4 converts an optional int32 to pgtype.Int4 (nil becomes NULL)
+func int32PtrToPgInt4(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}
+
 // isPgUniqueViolation checks if an error is a PostgreSQL unique constraint violation
 func isPgUniqueViolation(err error) bool {
 	if err == nil {
@@ -34,8 +34,17 @@ func (r *ExclusionRepository) Create(workspaceID int32, templateID int32, exclud
 }
 
 // IsExcluded checks if a specific month is excluded for a template
-func (r *ExclusionRepository) IsExcluded(workspaceID int32, templateID int32, excludedMonth time.Time) (bool, error) {
-	return r.queries.IsMonthExcluded(context.Background(), sqlc.IsMonthExcludedParams{
+func (r *ExclusionRepository) IsExcluded(ctx context.Context, workspaceID int32, templateID int32, excludedMonth time.Time) (bool, error) {
+	return r.queries.IsMonthExcluded(ctx, sqlc.IsMonthExcludedParams{
+		WorkspaceID:   workspaceID,
+		TemplateID:    templateID,
+		ExcludedMonth: pgtype.Date{Time: excludedMonth, Valid: true},
+	})
+}
+
+// Delete removes a single month's exclusion for a template
+func (r *ExclusionRepository) Delete(workspaceID int32, templateID int32, excludedMonth time.Time) error {
+	return r.queries.DeleteProjectionExclusion(context.Background(), sqlc.DeleteProjectionExclusionParams{
 		WorkspaceID:   workspaceID,
 		TemplateID:    templateID,
 		ExcludedMonth: pgtype.Date{Time: excludedMonth, Valid: true},
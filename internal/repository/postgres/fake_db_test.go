@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeColumn is one column of a fakeDB result set. A nil value is SQL NULL.
+type fakeColumn struct {
+	name  string
+	oid   uint32
+	value *string
+}
+
+func textValue(s string) *string {
+	return &s
+}
+
+// fakeDB is a sqlc.DBTX that answers every query with the same single-row
+// result set, or with no rows when it has no columns. Rows are decoded through
+// pgx's type map and, like pgx, reject a Scan whose destination count differs
+// from the number of columns. The arguments of the last query are kept in args.
+type fakeDB struct {
+	columns []fakeColumn
+	args    []interface{}
+}
+
+func (db *fakeDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("fakeDB: Exec not supported")
+}
+
+func (db *fakeDB) Query(_ context.Context, _ string, args ...interface{}) (pgx.Rows, error) {
+	db.args = args
+	return &fakeRows{columns: db.columns, typeMap: pgtype.NewMap()}, nil
+}
+
+func (db *fakeDB) QueryRow(_ context.Context, _ string, args ...interface{}) pgx.Row {
+	db.args = args
+	return &fakeRows{columns: db.columns, typeMap: pgtype.NewMap()}
+}
+
+type fakeRows struct {
+	columns []fakeColumn
+	typeMap *pgtype.Map
+	read    bool
+	err     error
+}
+
+func (r *fakeRows) Close()                        {}
+func (r *fakeRows) Err() error                    { return r.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag { return pgconn.CommandTag{} }
+func (r *fakeRows) Conn() *pgx.Conn               { return nil }
+
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, len(r.columns))
+	for i, c := range r.columns {
+		fields[i] = pgconn.FieldDescription{Name: c.name, DataTypeOID: c.oid, Format: pgtype.TextFormatCode}
+	}
+	return fields
+}
+
+func (r *fakeRows) Next() bool {
+	if r.read || len(r.columns) == 0 {
+		return false
+	}
+	r.read = true
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if len(dest) != len(r.columns) {
+		r.err = fmt.Errorf("number of field descriptions must equal number of destinations, got %d and %d", len(r.columns), len(dest))
+		return r.err
+	}
+	for i, c := range r.columns {
+		var src []byte
+		if c.value != nil {
+			src = []byte(*c.value)
+		}
+		if err := r.typeMap.Scan(c.oid, pgtype.TextFormatCode, src, dest[i]); err != nil {
+			r.err = fmt.Errorf("can't scan into dest[%d] (%s): %w", i, c.name, err)
+			return r.err
+		}
+	}
+	return nil
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return nil, errors.New("fakeDB: Values not supported")
+}
+
+func (r *fakeRows) RawValues() [][]byte {
+	values := make([][]byte, len(r.columns))
+	for i, c := range r.columns {
+		if c.value != nil {
+			values[i] = []byte(*c.value)
+		}
+	}
+	return values
+}
@@ -30,11 +30,24 @@ func (r *LoanProviderRepository) Create(provider *domain.LoanProvider) (*domain.
 	if err != nil {
 		return nil, err
 	}
+	monthlyFee, err := decimalToPgNumeric(provider.MonthlyFee)
+	if err != nil {
+		return nil, err
+	}
+	promoRate, err := decimalToPgNumeric(provider.PromoInterestRate)
+	if err != nil {
+		return nil, err
+	}
 	created, err := r.queries.CreateLoanProvider(ctx, sqlc.CreateLoanProviderParams{
-		WorkspaceID:         provider.WorkspaceID,
-		Name:                provider.Name,
-		CutoffDay:           provider.CutoffDay,
-		DefaultInterestRate: interestRate,
+		WorkspaceID:           provider.WorkspaceID,
+		Name:                  provider.Name,
+		CutoffDay:             provider.CutoffDay,
+		DefaultInterestRate:   interestRate,
+		MonthlyFee:            monthlyFee,
+		FeeMode:               provider.FeeMode,
+		PromoInterestRate:     promoRate,
+		PromoMonths:           provider.PromoMonths,
+		DefaultInterestMethod: provider.DefaultInterestMethod,
 	})
 	if err != nil {
 		if isPgUniqueViolation(err) {
@@ -82,13 +95,26 @@ func (r *LoanProviderRepository) Update(provider *domain.LoanProvider) (*domain.
 	if err != nil {
 		return nil, err
 	}
+	monthlyFee, err := decimalToPgNumeric(provider.MonthlyFee)
+	if err != nil {
+		return nil, err
+	}
+	promoRate, err := decimalToPgNumeric(provider.PromoInterestRate)
+	if err != nil {
+		return nil, err
+	}
 	updated, err := r.queries.UpdateLoanProvider(ctx, sqlc.UpdateLoanProviderParams{
-		ID:                  provider.ID,
-		WorkspaceID:         provider.WorkspaceID,
-		Name:                provider.Name,
-		CutoffDay:           provider.CutoffDay,
-		DefaultInterestRate: interestRate,
-		PaymentMode:         provider.PaymentMode,
+		ID:                    provider.ID,
+		WorkspaceID:           provider.WorkspaceID,
+		Name:                  provider.Name,
+		CutoffDay:             provider.CutoffDay,
+		DefaultInterestRate:   interestRate,
+		PaymentMode:           provider.PaymentMode,
+		MonthlyFee:            monthlyFee,
+		FeeMode:               provider.FeeMode,
+		PromoInterestRate:     promoRate,
+		PromoMonths:           provider.PromoMonths,
+		DefaultInterestMethod: provider.DefaultInterestMethod,
 	})
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -115,14 +141,19 @@ func (r *LoanProviderRepository) SoftDelete(workspaceID int32, id int32) error {
 
 func sqlcLoanProviderToDomain(p sqlc.LoanProvider) *domain.LoanProvider {
 	provider := &domain.LoanProvider{
-		ID:                  p.ID,
-		WorkspaceID:         p.WorkspaceID,
-		Name:                p.Name,
-		CutoffDay:           p.CutoffDay,
-		DefaultInterestRate: pgNumericToDecimal(p.DefaultInterestRate),
-		PaymentMode:         p.PaymentMode,
-		CreatedAt:           p.CreatedAt.Time,
-		UpdatedAt:           p.UpdatedAt.Time,
+		ID:                    p.ID,
+		WorkspaceID:           p.WorkspaceID,
+		Name:                  p.Name,
+		CutoffDay:             p.CutoffDay,
+		DefaultInterestRate:   pgNumericToDecimal(p.DefaultInterestRate),
+		PaymentMode:           p.PaymentMode,
+		MonthlyFee:            pgNumericToDecimal(p.MonthlyFee),
+		FeeMode:               p.FeeMode,
+		PromoInterestRate:     pgNumericToDecimal(p.PromoInterestRate),
+		PromoMonths:           p.PromoMonths,
+		DefaultInterestMethod: p.DefaultInterestMethod,
+		CreatedAt:             p.CreatedAt.Time,
+		UpdatedAt:             p.UpdatedAt.Time,
 	}
 	if p.DeletedAt.Valid {
 		provider.DeletedAt = &p.DeletedAt.Time
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// loanProviderColumns is a loan_providers row in table column order, as
+// returned by SELECT * / RETURNING *.
+func loanProviderColumns() []fakeColumn {
+	return []fakeColumn{
+		{"id", pgtype.Int4OID, textValue("7")},
+		{"workspace_id", pgtype.Int4OID, textValue("1")},
+		{"name", pgtype.VarcharOID, textValue("Bank ABC")},
+		{"cutoff_day", pgtype.Int4OID, textValue("15")},
+		{"default_interest_rate", pgtype.NumericOID, textValue("1.50")},
+		{"created_at", pgtype.TimestamptzOID, textValue("2026-01-02 03:04:05+00")},
+		{"updated_at", pgtype.TimestamptzOID, textValue("2026-01-02 03:04:05+00")},
+		{"deleted_at", pgtype.TimestamptzOID, nil},
+		{"payment_mode", pgtype.TextOID, textValue("per_item")},
+		{"monthly_fee", pgtype.NumericOID, textValue("2500.00")},
+		{"fee_mode", pgtype.TextOID, textValue("separate")},
+		{"promo_interest_rate", pgtype.NumericOID, textValue("0.00")},
+		{"promo_months", pgtype.Int4OID, textValue("3")},
+		{"default_interest_method", pgtype.VarcharOID, textValue("reducing_balance")},
+	}
+}
+
+func TestLoanProviderRepository_GetAllByWorkspace(t *testing.T) {
+	repo := &LoanProviderRepository{queries: sqlc.New(&fakeDB{columns: loanProviderColumns()})}
+
+	providers, err := repo.GetAllByWorkspace(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(providers) != 1 {
+		t.Fatalf("Expected 1 provider, got %d", len(providers))
+	}
+
+	p := providers[0]
+	if p.ID != 7 || p.Name != "Bank ABC" || p.CutoffDay != 15 {
+		t.Errorf("Unexpected provider identity: %+v", p)
+	}
+	if !p.MonthlyFee.Equal(decimal.NewFromInt(2500)) {
+		t.Errorf("Expected monthly fee 2500, got %s", p.MonthlyFee)
+	}
+	if p.PromoMonths != 3 {
+		t.Errorf("Expected promo months 3, got %d", p.PromoMonths)
+	}
+	if p.DefaultInterestMethod != "reducing_balance" {
+		t.Errorf("Expected default interest method reducing_balance, got %s", p.DefaultInterestMethod)
+	}
+	if p.DeletedAt != nil {
+		t.Errorf("Expected no deleted_at, got %v", p.DeletedAt)
+	}
+}
+
+func TestLoanProviderRepository_GetByID(t *testing.T) {
+	repo := &LoanProviderRepository{queries: sqlc.New(&fakeDB{columns: loanProviderColumns()})}
+
+	p, err := repo.GetByID(1, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !p.DefaultInterestRate.Equal(decimal.NewFromFloat(1.5)) {
+		t.Errorf("Expected interest rate 1.5, got %s", p.DefaultInterestRate)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 // LoanRepository implements domain.LoanRepository using PostgreSQL
@@ -60,6 +61,11 @@ func (r *LoanRepository) createLoan(ctx context.Context, q *sqlc.Queries, loan *
 		return nil, err
 	}
 
+	downPaymentAmount, err := decimalToPgNumeric(loan.DownPaymentAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	purchaseDate := pgtype.Date{
 		Time:  loan.PurchaseDate,
 		Valid: true,
@@ -97,6 +103,8 @@ func (r *LoanRepository) createLoan(ctx context.Context, q *sqlc.Queries, loan *
 		AccountID:         accountID,
 		SettlementIntent:  settlementIntent,
 		Notes:             notes,
+		DownPaymentAmount: downPaymentAmount,
+		InterestMethod:    loan.InterestMethod,
 	})
 	if err != nil {
 		return nil, err
@@ -129,7 +137,7 @@ func (r *LoanRepository) GetAllByWorkspace(workspaceID int32) ([]*domain.Loan, e
 	}
 	result := make([]*domain.Loan, len(loans))
 	for i, l := range loans {
-		result[i] = sqlcLoanToDomain(l)
+		result[i] = sqlcListLoansRowToDomain(l)
 	}
 	return result, nil
 }
@@ -170,6 +178,20 @@ func (r *LoanRepository) GetCompletedByWorkspace(workspaceID int32, currentYear,
 	return result, nil
 }
 
+// GetWithoutSchedule retrieves the loans of a workspace that never had a scheduled transaction
+func (r *LoanRepository) GetWithoutSchedule(workspaceID int32) ([]*domain.Loan, error) {
+	ctx := context.Background()
+	loans, err := r.queries.ListLoansWithoutSchedule(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Loan, len(loans))
+	for i, l := range loans {
+		result[i] = sqlcLoanToDomain(l)
+	}
+	return result, nil
+}
+
 // Update updates a loan
 func (r *LoanRepository) Update(loan *domain.Loan) (*domain.Loan, error) {
 	ctx := context.Background()
@@ -283,6 +305,79 @@ func (r *LoanRepository) SoftDelete(workspaceID int32, id int32) error {
 	})
 }
 
+// GetDeletedByID retrieves a soft-deleted loan by its ID within a workspace
+func (r *LoanRepository) GetDeletedByID(workspaceID int32, id int32) (*domain.Loan, error) {
+	ctx := context.Background()
+	loan, err := r.queries.GetDeletedLoanByID(ctx, sqlc.GetDeletedLoanByIDParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrLoanNotFound
+		}
+		return nil, err
+	}
+	return sqlcLoanToDomain(loan), nil
+}
+
+// GetDeletedByWorkspace retrieves soft-deleted loans for a workspace, most recently deleted first
+func (r *LoanRepository) GetDeletedByWorkspace(workspaceID int32) ([]*domain.Loan, error) {
+	ctx := context.Background()
+	loans, err := r.queries.ListDeletedLoans(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Loan, len(loans))
+	for i, l := range loans {
+		result[i] = sqlcLoanToDomain(l)
+	}
+	return result, nil
+}
+
+// Restore clears DeletedAt on a soft-deleted loan
+func (r *LoanRepository) Restore(workspaceID int32, id int32) (*domain.Loan, error) {
+	return r.restoreLoan(context.Background(), r.queries, workspaceID, id)
+}
+
+// RestoreTx clears DeletedAt on a soft-deleted loan within a database transaction
+func (r *LoanRepository) RestoreTx(tx interface{}, workspaceID int32, id int32) (*domain.Loan, error) {
+	pgxTx, ok := tx.(pgx.Tx)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+	return r.restoreLoan(context.Background(), r.queries.WithTx(pgxTx), workspaceID, id)
+}
+
+// restoreLoan is the internal implementation for restoring a loan
+func (r *LoanRepository) restoreLoan(ctx context.Context, q *sqlc.Queries, workspaceID int32, id int32) (*domain.Loan, error) {
+	loan, err := q.RestoreLoan(ctx, sqlc.RestoreLoanParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrLoanNotFound
+		}
+		return nil, err
+	}
+	return sqlcLoanToDomain(loan), nil
+}
+
+// SetCompletedAt records when a loan was fully paid (nil clears it)
+func (r *LoanRepository) SetCompletedAt(workspaceID int32, id int32, completedAt *time.Time) error {
+	ctx := context.Background()
+	var pgCompletedAt pgtype.Timestamptz
+	if completedAt != nil {
+		pgCompletedAt = pgtype.Timestamptz{Time: *completedAt, Valid: true}
+	}
+	return r.queries.SetLoanCompletedAt(ctx, sqlc.SetLoanCompletedAtParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		CompletedAt: pgCompletedAt,
+	})
+}
+
 // CountActiveLoansByProvider counts active loans for a provider
 func (r *LoanRepository) CountActiveLoansByProvider(workspaceID int32, providerID int32, currentYear, currentMonth int) (int64, error) {
 	ctx := context.Background()
@@ -296,9 +391,11 @@ func (r *LoanRepository) CountActiveLoansByProvider(workspaceID int32, providerI
 
 // GetAllWithStats retrieves all loans with payment statistics
 // CL v2: Uses transactions with loan_id instead of loan_payments table
-func (r *LoanRepository) GetAllWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
-	ctx := context.Background()
-	rows, err := r.queries.GetLoansWithStats(ctx, workspaceID)
+func (r *LoanRepository) GetAllWithStats(ctx context.Context, workspaceID int32, opts domain.ListOptions) ([]*domain.LoanWithStats, error) {
+	rows, err := r.queries.GetLoansWithStats(ctx, sqlc.GetLoansWithStatsParams{
+		WorkspaceID:    workspaceID,
+		IncludeDeleted: opts.IncludeDeleted,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -311,8 +408,7 @@ func (r *LoanRepository) GetAllWithStats(workspaceID int32) ([]*domain.LoanWithS
 
 // GetActiveWithStats retrieves active loans (remaining_balance > 0) with payment statistics
 // CL v2: Uses transactions with loan_id instead of loan_payments table
-func (r *LoanRepository) GetActiveWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
-	ctx := context.Background()
+func (r *LoanRepository) GetActiveWithStats(ctx context.Context, workspaceID int32) ([]*domain.LoanWithStats, error) {
 	rows, err := r.queries.GetActiveLoansWithStats(ctx, workspaceID)
 	if err != nil {
 		return nil, err
@@ -326,8 +422,7 @@ func (r *LoanRepository) GetActiveWithStats(workspaceID int32) ([]*domain.LoanWi
 
 // GetCompletedWithStats retrieves completed loans (remaining_balance = 0) with payment statistics
 // CL v2: Uses transactions with loan_id instead of loan_payments table
-func (r *LoanRepository) GetCompletedWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
-	ctx := context.Background()
+func (r *LoanRepository) GetCompletedWithStats(ctx context.Context, workspaceID int32) ([]*domain.LoanWithStats, error) {
 	rows, err := r.queries.GetCompletedLoansWithStats(ctx, workspaceID)
 	if err != nil {
 		return nil, err
@@ -341,8 +436,7 @@ func (r *LoanRepository) GetCompletedWithStats(workspaceID int32) ([]*domain.Loa
 
 // GetByProviderWithStats retrieves all loans for a provider with payment statistics
 // CL v2: Uses transactions with loan_id for stats, ordered by unpaid first then item name
-func (r *LoanRepository) GetByProviderWithStats(workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
-	ctx := context.Background()
+func (r *LoanRepository) GetByProviderWithStats(ctx context.Context, workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
 	rows, err := r.queries.GetLoansWithStatsByProvider(ctx, sqlc.GetLoansWithStatsByProviderParams{
 		WorkspaceID: workspaceID,
 		ProviderID:  providerID,
@@ -366,8 +460,10 @@ func sqlcLoanToDomain(l sqlc.Loan) *domain.Loan {
 		ProviderID:        l.ProviderID,
 		ItemName:          l.ItemName,
 		TotalAmount:       pgNumericToDecimal(l.TotalAmount),
+		DownPaymentAmount: pgNumericToDecimal(l.DownPaymentAmount),
 		NumMonths:         l.NumMonths,
 		InterestRate:      pgNumericToDecimal(l.InterestRate),
+		InterestMethod:    l.InterestMethod,
 		MonthlyPayment:    pgNumericToDecimal(l.MonthlyPayment),
 		FirstPaymentYear:  l.FirstPaymentYear,
 		FirstPaymentMonth: l.FirstPaymentMonth,
@@ -401,12 +497,42 @@ func sqlcLoanToDomain(l sqlc.Loan) *domain.Loan {
 	if l.DeletedAt.Valid {
 		loan.DeletedAt = &l.DeletedAt.Time
 	}
+	if l.CompletedAt.Valid {
+		loan.CompletedAt = &l.CompletedAt.Time
+	}
 
 	return loan
 }
 
 // CL v2: Helper functions for converting stats rows from transaction-based queries
 
+func sqlcListLoansRowToDomain(row sqlc.ListLoansRow) *domain.Loan {
+	loan := sqlcLoanToDomain(sqlc.Loan{
+		ID:                row.ID,
+		WorkspaceID:       row.WorkspaceID,
+		ProviderID:        row.ProviderID,
+		ItemName:          row.ItemName,
+		TotalAmount:       row.TotalAmount,
+		NumMonths:         row.NumMonths,
+		PurchaseDate:      row.PurchaseDate,
+		InterestRate:      row.InterestRate,
+		MonthlyPayment:    row.MonthlyPayment,
+		FirstPaymentYear:  row.FirstPaymentYear,
+		FirstPaymentMonth: row.FirstPaymentMonth,
+		Notes:             row.Notes,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+		DeletedAt:         row.DeletedAt,
+		AccountID:         row.AccountID,
+		SettlementIntent:  row.SettlementIntent,
+		CompletedAt:       row.CompletedAt,
+		DownPaymentAmount: row.DownPaymentAmount,
+		InterestMethod:    row.InterestMethod,
+	})
+	loan.ProviderName = row.ProviderName
+	return loan
+}
+
 func sqlcLoansWithStatsRowToDomain(row sqlc.GetLoansWithStatsRow) *domain.LoanWithStats {
 	loan := &domain.LoanWithStats{
 		Loan: domain.Loan{
@@ -415,8 +541,10 @@ func sqlcLoansWithStatsRowToDomain(row sqlc.GetLoansWithStatsRow) *domain.LoanWi
 			ProviderID:        row.ProviderID,
 			ItemName:          row.ItemName,
 			TotalAmount:       pgNumericToDecimal(row.TotalAmount),
+			DownPaymentAmount: pgNumericToDecimal(row.DownPaymentAmount),
 			NumMonths:         row.NumMonths,
 			InterestRate:      pgNumericToDecimal(row.InterestRate),
+			InterestMethod:    row.InterestMethod,
 			MonthlyPayment:    pgNumericToDecimal(row.MonthlyPayment),
 			FirstPaymentYear:  row.FirstPaymentYear,
 			FirstPaymentMonth: row.FirstPaymentMonth,
@@ -446,11 +574,12 @@ func sqlcLoansWithStatsRowToDomain(row sqlc.GetLoansWithStatsRow) *domain.LoanWi
 	if row.DeletedAt.Valid {
 		loan.DeletedAt = &row.DeletedAt.Time
 	}
+	if row.CompletedAt.Valid {
+		loan.CompletedAt = &row.CompletedAt.Time
+	}
 
 	// Calculate progress percentage
-	if loan.TotalCount > 0 {
-		loan.Progress = float64(loan.PaidCount) / float64(loan.TotalCount) * 100
-	}
+	loan.Progress = domain.ComputeProgress(decimal.NewFromInt32(loan.PaidCount), decimal.NewFromInt32(loan.TotalCount))
 
 	return loan
 }
@@ -463,8 +592,10 @@ func sqlcActiveLoansWithStatsRowToDomain(row sqlc.GetActiveLoansWithStatsRow) *d
 			ProviderID:        row.ProviderID,
 			ItemName:          row.ItemName,
 			TotalAmount:       pgNumericToDecimal(row.TotalAmount),
+			DownPaymentAmount: pgNumericToDecimal(row.DownPaymentAmount),
 			NumMonths:         row.NumMonths,
 			InterestRate:      pgNumericToDecimal(row.InterestRate),
+			InterestMethod:    row.InterestMethod,
 			MonthlyPayment:    pgNumericToDecimal(row.MonthlyPayment),
 			FirstPaymentYear:  row.FirstPaymentYear,
 			FirstPaymentMonth: row.FirstPaymentMonth,
@@ -493,9 +624,10 @@ func sqlcActiveLoansWithStatsRowToDomain(row sqlc.GetActiveLoansWithStatsRow) *d
 	if row.DeletedAt.Valid {
 		loan.DeletedAt = &row.DeletedAt.Time
 	}
-	if loan.TotalCount > 0 {
-		loan.Progress = float64(loan.PaidCount) / float64(loan.TotalCount) * 100
+	if row.CompletedAt.Valid {
+		loan.CompletedAt = &row.CompletedAt.Time
 	}
+	loan.Progress = domain.ComputeProgress(decimal.NewFromInt32(loan.PaidCount), decimal.NewFromInt32(loan.TotalCount))
 
 	return loan
 }
@@ -508,8 +640,10 @@ func sqlcCompletedLoansWithStatsRowToDomain(row sqlc.GetCompletedLoansWithStatsR
 			ProviderID:        row.ProviderID,
 			ItemName:          row.ItemName,
 			TotalAmount:       pgNumericToDecimal(row.TotalAmount),
+			DownPaymentAmount: pgNumericToDecimal(row.DownPaymentAmount),
 			NumMonths:         row.NumMonths,
 			InterestRate:      pgNumericToDecimal(row.InterestRate),
+			InterestMethod:    row.InterestMethod,
 			MonthlyPayment:    pgNumericToDecimal(row.MonthlyPayment),
 			FirstPaymentYear:  row.FirstPaymentYear,
 			FirstPaymentMonth: row.FirstPaymentMonth,
@@ -538,9 +672,10 @@ func sqlcCompletedLoansWithStatsRowToDomain(row sqlc.GetCompletedLoansWithStatsR
 	if row.DeletedAt.Valid {
 		loan.DeletedAt = &row.DeletedAt.Time
 	}
-	if loan.TotalCount > 0 {
-		loan.Progress = float64(loan.PaidCount) / float64(loan.TotalCount) * 100
+	if row.CompletedAt.Valid {
+		loan.CompletedAt = &row.CompletedAt.Time
 	}
+	loan.Progress = domain.ComputeProgress(decimal.NewFromInt32(loan.PaidCount), decimal.NewFromInt32(loan.TotalCount))
 
 	return loan
 }
@@ -553,8 +688,10 @@ func sqlcLoansWithStatsByProviderRowToDomain(row sqlc.GetLoansWithStatsByProvide
 			ProviderID:        row.ProviderID,
 			ItemName:          row.ItemName,
 			TotalAmount:       pgNumericToDecimal(row.TotalAmount),
+			DownPaymentAmount: pgNumericToDecimal(row.DownPaymentAmount),
 			NumMonths:         row.NumMonths,
 			InterestRate:      pgNumericToDecimal(row.InterestRate),
+			InterestMethod:    row.InterestMethod,
 			MonthlyPayment:    pgNumericToDecimal(row.MonthlyPayment),
 			FirstPaymentYear:  row.FirstPaymentYear,
 			FirstPaymentMonth: row.FirstPaymentMonth,
@@ -583,9 +720,10 @@ func sqlcLoansWithStatsByProviderRowToDomain(row sqlc.GetLoansWithStatsByProvide
 	if row.DeletedAt.Valid {
 		loan.DeletedAt = &row.DeletedAt.Time
 	}
-	if loan.TotalCount > 0 {
-		loan.Progress = float64(loan.PaidCount) / float64(loan.TotalCount) * 100
+	if row.CompletedAt.Valid {
+		loan.CompletedAt = &row.CompletedAt.Time
 	}
+	loan.Progress = domain.ComputeProgress(decimal.NewFromInt32(loan.PaidCount), decimal.NewFromInt32(loan.TotalCount))
 
 	return loan
 }
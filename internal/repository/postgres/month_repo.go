@@ -113,10 +113,30 @@ func (r *MonthRepository) UpdateStartingBalance(workspaceID, id int32, balance d
 	})
 }
 
+// Close marks a month closed; returns false if it was already closed
+func (r *MonthRepository) Close(workspaceID, id int32) (bool, error) {
+	ctx := context.Background()
+
+	rows, err := r.queries.CloseMonth(ctx, sqlc.CloseMonthParams{
+		WorkspaceID: workspaceID,
+		ID:          id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// CloseAllBefore closes every open month before year/month across all workspaces
+func (r *MonthRepository) CloseAllBefore(year, month int) (int64, error) {
+	ctx := context.Background()
+	return r.queries.CloseMonthsBefore(ctx, int32(year*12+month))
+}
+
 // Helper functions
 
 func sqlcMonthToDomain(m sqlc.Month) *domain.Month {
-	return &domain.Month{
+	month := &domain.Month{
 		ID:              m.ID,
 		WorkspaceID:     m.WorkspaceID,
 		Year:            int(m.Year),
@@ -127,6 +147,10 @@ func sqlcMonthToDomain(m sqlc.Month) *domain.Month {
 		CreatedAt:       m.CreatedAt.Time,
 		UpdatedAt:       m.UpdatedAt.Time,
 	}
+	if m.ClosedAt.Valid {
+		month.ClosedAt = &m.ClosedAt.Time
+	}
+	return month
 }
 
 func timeToPgDate(t time.Time) pgtype.Date {
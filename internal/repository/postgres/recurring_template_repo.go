@@ -167,11 +167,24 @@ func (r *RecurringTemplateRepository) GetByID(workspaceID int32, id int32) (*dom
 	return sqlcRecurringTemplateToDomain(template), nil
 }
 
-// ListByWorkspace retrieves all recurring templates for a workspace
-func (r *RecurringTemplateRepository) ListByWorkspace(workspaceID int32) ([]*domain.RecurringTemplate, error) {
+// ListByWorkspace retrieves recurring templates for a workspace, narrowed by the optional filter
+func (r *RecurringTemplateRepository) ListByWorkspace(workspaceID int32, filter *domain.RecurringFilter) ([]*domain.RecurringTemplate, error) {
 	ctx := context.Background()
 
-	templates, err := r.queries.ListRecurringTemplatesByWorkspace(ctx, workspaceID)
+	params := sqlc.ListRecurringTemplatesByWorkspaceParams{WorkspaceID: workspaceID}
+	if filter != nil {
+		if filter.AccountID != nil {
+			params.AccountID = pgtype.Int4{Int32: *filter.AccountID, Valid: true}
+		}
+		if filter.CategoryID != nil {
+			params.CategoryID = pgtype.Int4{Int32: *filter.CategoryID, Valid: true}
+		}
+		if filter.Active != nil {
+			params.Active = pgtype.Bool{Bool: *filter.Active, Valid: true}
+		}
+	}
+
+	templates, err := r.queries.ListRecurringTemplatesByWorkspace(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -185,9 +198,7 @@ func (r *RecurringTemplateRepository) ListByWorkspace(workspaceID int32) ([]*dom
 }
 
 // GetActive retrieves all active recurring templates (no end_date or end_date >= today)
-func (r *RecurringTemplateRepository) GetActive(workspaceID int32) ([]*domain.RecurringTemplate, error) {
-	ctx := context.Background()
-
+func (r *RecurringTemplateRepository) GetActive(ctx context.Context, workspaceID int32) ([]*domain.RecurringTemplate, error) {
 	templates, err := r.queries.GetActiveRecurringTemplates(ctx, workspaceID)
 	if err != nil {
 		return nil, err
@@ -236,6 +236,23 @@ func (r *TransactionGroupRepository) CountGroupChildren(workspaceID int32, group
 	return count, nil
 }
 
+// GetGroupChildren retrieves the active member transactions of a group
+func (r *TransactionGroupRepository) GetGroupChildren(workspaceID int32, groupID int32) ([]*domain.Transaction, error) {
+	ctx := context.Background()
+	rows, err := r.queries.GetGroupChildren(ctx, sqlc.GetGroupChildrenParams{
+		GroupID:     pgtype.Int4{Int32: groupID, Valid: true},
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		result[i] = sqlcTransactionToDomain(row)
+	}
+	return result, nil
+}
+
 // GetUngroupedTransactionsByMonth retrieves ungrouped transactions for a date range
 func (r *TransactionGroupRepository) GetUngroupedTransactionsByMonth(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error) {
 	ctx := context.Background()
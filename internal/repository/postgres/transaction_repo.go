@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/db/sqlc"
@@ -29,9 +30,7 @@ func NewTransactionRepository(pool *pgxpool.Pool) *TransactionRepository {
 }
 
 // Create creates a new transaction
-func (r *TransactionRepository) Create(transaction *domain.Transaction) (*domain.Transaction, error) {
-	ctx := context.Background()
-
+func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
 	amount, err := decimalToPgNumeric(transaction.Amount)
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount: %w", err)
@@ -47,12 +46,35 @@ func (r *TransactionRepository) Create(transaction *domain.Transaction) (*domain
 		notes.Valid = true
 	}
 
+	var merchant pgtype.Text
+	if transaction.Merchant != nil {
+		merchant.String = *transaction.Merchant
+		merchant.Valid = true
+	}
+
+	var payee pgtype.Text
+	if transaction.Payee != nil {
+		payee.String = *transaction.Payee
+		payee.Valid = true
+	}
+
+	latitude, longitude, location, err := locationToPg(transaction.Latitude, transaction.Longitude, transaction.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
 	var transferPairID pgtype.UUID
 	if transaction.TransferPairID != nil {
 		transferPairID.Bytes = *transaction.TransferPairID
 		transferPairID.Valid = true
 	}
 
+	var reversesPairID pgtype.UUID
+	if transaction.ReversesTransferPairID != nil {
+		reversesPairID.Bytes = *transaction.ReversesTransferPairID
+		reversesPairID.Valid = true
+	}
+
 	var categoryID pgtype.Int4
 	if transaction.CategoryID != nil {
 		categoryID.Int32 = *transaction.CategoryID
@@ -100,23 +122,35 @@ func (r *TransactionRepository) Create(transaction *domain.Transaction) (*domain
 	}
 
 	created, err := r.queries.CreateTransaction(ctx, sqlc.CreateTransactionParams{
-		WorkspaceID:      transaction.WorkspaceID,
-		AccountID:        transaction.AccountID,
-		Name:             transaction.Name,
-		Amount:           amount,
-		Type:             string(transaction.Type),
-		TransactionDate:  transactionDate,
-		IsPaid:           transaction.IsPaid,
-		Notes:            notes,
-		TransferPairID:   transferPairID,
-		CategoryID:       categoryID,
-		IsCcPayment:      transaction.IsCCPayment,
-		BilledAt:         billedAt,
-		SettlementIntent: settlementIntent,
-		Source:           source,
-		TemplateID:       templateID,
-		IsProjected:      isProjected,
-		LoanID:           loanID,
+		WorkspaceID:            transaction.WorkspaceID,
+		AccountID:              transaction.AccountID,
+		Name:                   transaction.Name,
+		Amount:                 amount,
+		Type:                   string(transaction.Type),
+		TransactionDate:        transactionDate,
+		IsPaid:                 transaction.IsPaid,
+		Notes:                  notes,
+		TransferPairID:         transferPairID,
+		CategoryID:             categoryID,
+		IsCcPayment:            transaction.IsCCPayment,
+		BilledAt:               billedAt,
+		SettlementIntent:       settlementIntent,
+		Source:                 source,
+		TemplateID:             templateID,
+		IsProjected:            isProjected,
+		LoanID:                 loanID,
+		IsScheduled:            transaction.IsScheduled,
+		Merchant:               merchant,
+		Payee:                  payee,
+		Latitude:               latitude,
+		Longitude:              longitude,
+		Location:               location,
+		ReversesTransferPairID: reversesPairID,
+		RoundUpSourceID:        int32PtrToPgInt4(transaction.RoundUpSourceID),
+		IsLoanFee:              transaction.IsLoanFee,
+		IsRefund:               transaction.IsRefund,
+		ExcludeFromReports:     transaction.ExcludeFromReports,
+		IsTaxDeductible:        transaction.IsTaxDeductible,
 	})
 	if err != nil {
 		return nil, err
@@ -141,9 +175,7 @@ func (r *TransactionRepository) GetByID(workspaceID int32, id int32) (*domain.Tr
 }
 
 // GetByWorkspace retrieves transactions for a workspace with optional filters and pagination
-func (r *TransactionRepository) GetByWorkspace(workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
-	ctx := context.Background()
-
+func (r *TransactionRepository) GetByWorkspace(ctx context.Context, workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
 	// Set default pagination values
 	page := int32(1)
 	pageSize := int32(domain.DefaultPageSize)
@@ -190,6 +222,24 @@ func (r *TransactionRepository) GetByWorkspace(workspaceID int32, filters *domai
 			params.Type = pgtype.Text{String: string(*filters.Type), Valid: true}
 			countParams.Type = pgtype.Text{String: string(*filters.Type), Valid: true}
 		}
+		if filters.Source != nil {
+			params.Source = pgtype.Text{String: *filters.Source, Valid: true}
+			countParams.Source = pgtype.Text{String: *filters.Source, Valid: true}
+		}
+		if filters.PaidStart != nil {
+			params.PaidStart = pgtype.Date{Time: *filters.PaidStart, Valid: true}
+			countParams.PaidStart = pgtype.Date{Time: *filters.PaidStart, Valid: true}
+		}
+		if filters.PaidEnd != nil {
+			params.PaidEnd = pgtype.Date{Time: *filters.PaidEnd, Valid: true}
+			countParams.PaidEnd = pgtype.Date{Time: *filters.PaidEnd, Valid: true}
+		}
+		if filters.HasLocation != nil {
+			params.HasLocation = pgtype.Bool{Bool: *filters.HasLocation, Valid: true}
+			countParams.HasLocation = pgtype.Bool{Bool: *filters.HasLocation, Valid: true}
+		}
+		params.IncludeDeleted = filters.IncludeDeleted
+		countParams.IncludeDeleted = filters.IncludeDeleted
 		// Note: CCStatus filtering now happens via computed ccState from isPaid/billedAt
 		// The SQL query no longer has cc_status filter - filtering is done client-side if needed
 	}
@@ -226,6 +276,31 @@ func (r *TransactionRepository) GetByWorkspace(workspaceID int32, filters *domai
 	}, nil
 }
 
+// ListAfterCursor retrieves up to limit transactions ordered by (transaction_date, id), starting after the cursor
+// A nil cursor starts from the first transaction
+func (r *TransactionRepository) ListAfterCursor(workspaceID int32, after *domain.TransactionCursor, limit int32) ([]*domain.Transaction, error) {
+	params := sqlc.ListTransactionsByCursorParams{
+		WorkspaceID: workspaceID,
+		RowLimit:    limit,
+	}
+	if after != nil {
+		params.AfterDate = pgtype.Date{Time: after.TransactionDate, Valid: true}
+		params.AfterID = after.ID
+	}
+
+	rows, err := r.queries.ListTransactionsByCursor(context.Background(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
 // TogglePaid toggles the paid status of a transaction
 func (r *TransactionRepository) TogglePaid(workspaceID int32, id int32) (*domain.Transaction, error) {
 	ctx := context.Background()
@@ -261,6 +336,23 @@ func (r *TransactionRepository) Update(workspaceID int32, id int32, data *domain
 		notes.Valid = true
 	}
 
+	var merchant pgtype.Text
+	if data.Merchant != nil {
+		merchant.String = *data.Merchant
+		merchant.Valid = true
+	}
+
+	var payee pgtype.Text
+	if data.Payee != nil {
+		payee.String = *data.Payee
+		payee.Valid = true
+	}
+
+	latitude, longitude, location, err := locationToPg(data.Latitude, data.Longitude, data.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
 	var categoryID pgtype.Int4
 	if data.CategoryID != nil {
 		categoryID.Int32 = *data.CategoryID
@@ -301,21 +393,31 @@ func (r *TransactionRepository) Update(workspaceID int32, id int32, data *domain
 	isProjected.Valid = true
 
 	transaction, err := r.queries.UpdateTransaction(ctx, sqlc.UpdateTransactionParams{
-		WorkspaceID:      workspaceID,
-		ID:               id,
-		Name:             data.Name,
-		Amount:           amount,
-		Type:             string(data.Type),
-		TransactionDate:  transactionDate,
-		AccountID:        data.AccountID,
-		Notes:            notes,
-		CategoryID:       categoryID,
-		IsPaid:           data.IsPaid,
-		BilledAt:         billedAt,
-		SettlementIntent: settlementIntent,
-		Source:           source,
-		TemplateID:       templateID,
-		IsProjected:      isProjected,
+		WorkspaceID:          workspaceID,
+		ID:                   id,
+		Name:                 data.Name,
+		Amount:               amount,
+		Type:                 string(data.Type),
+		TransactionDate:      transactionDate,
+		AccountID:            data.AccountID,
+		Notes:                notes,
+		CategoryID:           categoryID,
+		IsPaid:               data.IsPaid,
+		BilledAt:             billedAt,
+		SettlementIntent:     settlementIntent,
+		Source:               source,
+		TemplateID:           templateID,
+		IsProjected:          isProjected,
+		IsScheduled:          data.IsScheduled,
+		Merchant:             merchant,
+		Payee:                payee,
+		Latitude:             latitude,
+		Longitude:            longitude,
+		Location:             location,
+		IsRefund:             data.IsRefund,
+		ExcludeFromReports:   data.ExcludeFromReports,
+		IsTaxDeductible:      data.IsTaxDeductible,
+		ModifiedFromTemplate: data.ModifiedFromTemplate,
 	})
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -358,25 +460,113 @@ func (r *TransactionRepository) CreateTransferPair(fromTx, toTx *domain.Transact
 	// Create from transaction
 	fromResult, err := r.createTransactionWithTx(ctx, qtx, fromTx)
 	if err != nil {
-		return nil, err
+		return nil, mapTransferReversalError(fromTx, err)
 	}
 
 	// Create to transaction
 	toResult, err := r.createTransactionWithTx(ctx, qtx, toTx)
 	if err != nil {
+		return nil, mapTransferReversalError(toTx, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
+	return &domain.TransferResult{
+		FromTransaction: fromResult,
+		ToTransaction:   toResult,
+	}, nil
+}
+
+// CreateWithRoundUp creates an expense together with the transfer moving its round-up to savings,
+// in one database transaction. Both round-up legs are linked to the created expense.
+func (r *TransactionRepository) CreateWithRoundUp(ctx context.Context, expense, roundUpFrom, roundUpTo *domain.Transaction) (*domain.Transaction, *domain.TransferResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	created, err := r.createTransactionWithTx(ctx, qtx, expense)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roundUpFrom.RoundUpSourceID = &created.ID
+	roundUpTo.RoundUpSourceID = &created.ID
+	result, err := r.createRoundUpWithTx(ctx, qtx, roundUpFrom, roundUpTo)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return created, result, nil
+}
+
+// ReplaceRoundUp soft deletes the round-up transfer created for an expense and, when both legs
+// are given, creates them linked to the expense instead, in one database transaction.
+// Returns a nil result when no replacement legs are given.
+func (r *TransactionRepository) ReplaceRoundUp(ctx context.Context, workspaceID int32, sourceID int32, roundUpFrom, roundUpTo *domain.Transaction) (*domain.TransferResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	if _, err := qtx.SoftDeleteRoundUpsBySource(ctx, sqlc.SoftDeleteRoundUpsBySourceParams{
+		WorkspaceID:     workspaceID,
+		RoundUpSourceID: pgtype.Int4{Int32: sourceID, Valid: true},
+	}); err != nil {
 		return nil, err
 	}
 
+	var result *domain.TransferResult
+	if roundUpFrom != nil && roundUpTo != nil {
+		roundUpFrom.RoundUpSourceID = &sourceID
+		roundUpTo.RoundUpSourceID = &sourceID
+		result, err = r.createRoundUpWithTx(ctx, qtx, roundUpFrom, roundUpTo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// createRoundUpWithTx creates both legs of a round-up transfer within a database transaction
+func (r *TransactionRepository) createRoundUpWithTx(ctx context.Context, qtx *sqlc.Queries, fromTx, toTx *domain.Transaction) (*domain.TransferResult, error) {
+	fromResult, err := r.createTransactionWithTx(ctx, qtx, fromTx)
+	if err != nil {
+		return nil, err
+	}
+	toResult, err := r.createTransactionWithTx(ctx, qtx, toTx)
+	if err != nil {
+		return nil, err
+	}
 	return &domain.TransferResult{
 		FromTransaction: fromResult,
 		ToTransaction:   toResult,
 	}, nil
 }
 
+// mapTransferReversalError reports a concurrent duplicate reversal as ErrTransferAlreadyReversed
+func mapTransferReversalError(transaction *domain.Transaction, err error) error {
+	if transaction.ReversesTransferPairID != nil && isPgUniqueViolation(err) {
+		return domain.ErrTransferAlreadyReversed
+	}
+	return err
+}
+
 // createTransactionWithTx is a helper to create a transaction within a database transaction
 func (r *TransactionRepository) createTransactionWithTx(ctx context.Context, qtx *sqlc.Queries, transaction *domain.Transaction) (*domain.Transaction, error) {
 	amount, err := decimalToPgNumeric(transaction.Amount)
@@ -394,12 +584,35 @@ func (r *TransactionRepository) createTransactionWithTx(ctx context.Context, qtx
 		notes.Valid = true
 	}
 
+	var merchant pgtype.Text
+	if transaction.Merchant != nil {
+		merchant.String = *transaction.Merchant
+		merchant.Valid = true
+	}
+
+	var payee pgtype.Text
+	if transaction.Payee != nil {
+		payee.String = *transaction.Payee
+		payee.Valid = true
+	}
+
+	latitude, longitude, location, err := locationToPg(transaction.Latitude, transaction.Longitude, transaction.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
 	var transferPairID pgtype.UUID
 	if transaction.TransferPairID != nil {
 		transferPairID.Bytes = *transaction.TransferPairID
 		transferPairID.Valid = true
 	}
 
+	var reversesPairID pgtype.UUID
+	if transaction.ReversesTransferPairID != nil {
+		reversesPairID.Bytes = *transaction.ReversesTransferPairID
+		reversesPairID.Valid = true
+	}
+
 	var categoryID pgtype.Int4
 	if transaction.CategoryID != nil {
 		categoryID.Int32 = *transaction.CategoryID
@@ -447,23 +660,35 @@ func (r *TransactionRepository) createTransactionWithTx(ctx context.Context, qtx
 	}
 
 	created, err := qtx.CreateTransaction(ctx, sqlc.CreateTransactionParams{
-		WorkspaceID:      transaction.WorkspaceID,
-		AccountID:        transaction.AccountID,
-		Name:             transaction.Name,
-		Amount:           amount,
-		Type:             string(transaction.Type),
-		TransactionDate:  transactionDate,
-		IsPaid:           transaction.IsPaid,
-		Notes:            notes,
-		TransferPairID:   transferPairID,
-		CategoryID:       categoryID,
-		IsCcPayment:      transaction.IsCCPayment,
-		BilledAt:         billedAt,
-		SettlementIntent: settlementIntent,
-		Source:           source,
-		TemplateID:       templateID,
-		IsProjected:      isProjected,
-		LoanID:           loanID,
+		WorkspaceID:            transaction.WorkspaceID,
+		AccountID:              transaction.AccountID,
+		Name:                   transaction.Name,
+		Amount:                 amount,
+		Type:                   string(transaction.Type),
+		TransactionDate:        transactionDate,
+		IsPaid:                 transaction.IsPaid,
+		Notes:                  notes,
+		TransferPairID:         transferPairID,
+		CategoryID:             categoryID,
+		IsCcPayment:            transaction.IsCCPayment,
+		BilledAt:               billedAt,
+		SettlementIntent:       settlementIntent,
+		Source:                 source,
+		TemplateID:             templateID,
+		IsProjected:            isProjected,
+		LoanID:                 loanID,
+		IsScheduled:            transaction.IsScheduled,
+		Merchant:               merchant,
+		Payee:                  payee,
+		Latitude:               latitude,
+		Longitude:              longitude,
+		Location:               location,
+		ReversesTransferPairID: reversesPairID,
+		RoundUpSourceID:        int32PtrToPgInt4(transaction.RoundUpSourceID),
+		IsLoanFee:              transaction.IsLoanFee,
+		IsRefund:               transaction.IsRefund,
+		ExcludeFromReports:     transaction.ExcludeFromReports,
+		IsTaxDeductible:        transaction.IsTaxDeductible,
 	})
 	if err != nil {
 		return nil, err
@@ -489,6 +714,31 @@ func (r *TransactionRepository) CreateBatchTx(tx interface{}, transactions []*do
 	return created, nil
 }
 
+// GetTransferPair retrieves both transactions of a transfer pair
+func (r *TransactionRepository) GetTransferPair(workspaceID int32, pairID uuid.UUID) ([]*domain.Transaction, error) {
+	rows, err := r.queries.GetTransferPairTransactions(context.Background(), sqlc.GetTransferPairTransactionsParams{
+		WorkspaceID:    workspaceID,
+		TransferPairID: pgtype.UUID{Bytes: pairID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+	return transactions, nil
+}
+
+// IsTransferReversed checks whether a reversal already exists for a transfer pair
+func (r *TransactionRepository) IsTransferReversed(workspaceID int32, pairID uuid.UUID) (bool, error) {
+	return r.queries.IsTransferPairReversed(context.Background(), sqlc.IsTransferPairReversedParams{
+		WorkspaceID:            workspaceID,
+		ReversesTransferPairID: pgtype.UUID{Bytes: pairID, Valid: true},
+	})
+}
+
 // SoftDeleteTransferPair soft deletes both transactions in a transfer pair
 func (r *TransactionRepository) SoftDeleteTransferPair(workspaceID int32, pairID uuid.UUID) error {
 	ctx := context.Background()
@@ -509,6 +759,44 @@ func (r *TransactionRepository) SoftDeleteTransferPair(workspaceID int32, pairID
 	return nil
 }
 
+// SoftDeleteByIDs soft deletes multiple transactions atomically
+func (r *TransactionRepository) SoftDeleteByIDs(workspaceID int32, ids []int32) (int, error) {
+	ctx := context.Background()
+
+	rowsAffected, err := r.queries.SoftDeleteTransactionsByIDs(ctx, sqlc.SoftDeleteTransactionsByIDsParams{
+		WorkspaceID: workspaceID,
+		Column2:     ids,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
+
+// BulkUpdateAccount reassigns multiple transactions to another account atomically
+// Transfer legs and transactions already on the target account are left untouched
+func (r *TransactionRepository) BulkUpdateAccount(workspaceID int32, ids []int32, accountID int32) ([]*domain.Transaction, error) {
+	if len(ids) == 0 {
+		return []*domain.Transaction{}, nil
+	}
+
+	rows, err := r.queries.BulkUpdateTransactionAccount(context.Background(), sqlc.BulkUpdateTransactionAccountParams{
+		WorkspaceID: workspaceID,
+		Column2:     ids,
+		AccountID:   accountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
 // GetAccountTransactionSummaries retrieves aggregated transaction data for all accounts in a workspace
 func (r *TransactionRepository) GetAccountTransactionSummaries(workspaceID int32) ([]*domain.TransactionSummary, error) {
 	ctx := context.Background()
@@ -531,6 +819,66 @@ func (r *TransactionRepository) GetAccountTransactionSummaries(workspaceID int32
 	return summaries, nil
 }
 
+// GetAccountTransactionSummaryAsOf retrieves aggregated transaction data for one account up to and including asOf
+func (r *TransactionRepository) GetAccountTransactionSummaryAsOf(workspaceID, accountID int32, asOf time.Time) (*domain.TransactionSummary, error) {
+	ctx := context.Background()
+	row, err := r.queries.GetAccountTransactionSummaryAsOf(ctx, sqlc.GetAccountTransactionSummaryAsOfParams{
+		WorkspaceID:     workspaceID,
+		AccountID:       accountID,
+		TransactionDate: pgtype.Date{Time: asOf, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TransactionSummary{
+		AccountID:         accountID,
+		SumIncome:         interfaceToDecimal(row.SumIncome),
+		SumExpenses:       interfaceToDecimal(row.SumExpenses),
+		SumUnpaidExpenses: interfaceToDecimal(row.SumUnpaidExpenses),
+		SumAllExpenses:    interfaceToDecimal(row.SumAllExpenses),
+	}, nil
+}
+
+// locationToPg converts optional geo metadata to query params; nil values are stored as NULL
+func locationToPg(latitude, longitude *decimal.Decimal, location *string) (pgtype.Numeric, pgtype.Numeric, pgtype.Text, error) {
+	var lat, lng pgtype.Numeric
+	var err error
+	if latitude != nil {
+		if lat, err = decimalToPgNumeric(*latitude); err != nil {
+			return lat, lng, pgtype.Text{}, err
+		}
+	}
+	if longitude != nil {
+		if lng, err = decimalToPgNumeric(*longitude); err != nil {
+			return lat, lng, pgtype.Text{}, err
+		}
+	}
+	var loc pgtype.Text
+	if location != nil {
+		loc = pgtype.Text{String: *location, Valid: true}
+	}
+	return lat, lng, loc, nil
+}
+
+// pgLocationToDomain converts nullable geo columns to optional domain values
+func pgLocationToDomain(latitude, longitude pgtype.Numeric, location pgtype.Text) (*decimal.Decimal, *decimal.Decimal, *string) {
+	var lat, lng *decimal.Decimal
+	if latitude.Valid {
+		v := pgNumericToDecimal(latitude)
+		lat = &v
+	}
+	if longitude.Valid {
+		v := pgNumericToDecimal(longitude)
+		lng = &v
+	}
+	var loc *string
+	if location.Valid {
+		loc = &location.String
+	}
+	return lat, lng, loc
+}
+
 // interfaceToDecimal converts an interface{} value (from aggregated queries) to decimal.Decimal
 func interfaceToDecimal(v interface{}) decimal.Decimal {
 	if v == nil {
@@ -553,6 +901,11 @@ func interfaceToDecimal(v interface{}) decimal.Decimal {
 
 // SumByTypeAndDateRange sums transactions by type within a date range
 func (r *TransactionRepository) SumByTypeAndDateRange(workspaceID int32, startDate, endDate time.Time, txType domain.TransactionType) (decimal.Decimal, error) {
+	return r.SumByTypeAndDateRangeInAccounts(workspaceID, startDate, endDate, txType, nil)
+}
+
+// SumByTypeAndDateRangeInAccounts is SumByTypeAndDateRange limited to accountIDs; empty means every account
+func (r *TransactionRepository) SumByTypeAndDateRangeInAccounts(workspaceID int32, startDate, endDate time.Time, txType domain.TransactionType, accountIDs []int32) (decimal.Decimal, error) {
 	ctx := context.Background()
 
 	total, err := r.queries.SumTransactionsByTypeAndDateRange(ctx, sqlc.SumTransactionsByTypeAndDateRangeParams{
@@ -560,6 +913,7 @@ func (r *TransactionRepository) SumByTypeAndDateRange(workspaceID int32, startDa
 		TransactionDate:   pgtype.Date{Time: startDate, Valid: true},
 		TransactionDate_2: pgtype.Date{Time: endDate, Valid: true},
 		Type:              string(txType),
+		Column5:           accountIDsFilter(accountIDs),
 	})
 	if err != nil {
 		return decimal.Zero, err
@@ -588,14 +942,15 @@ func (r *TransactionRepository) GetMonthlyTransactionSummaries(workspaceID int32
 	return summaries, nil
 }
 
-// SumPaidExpensesByDateRange sums paid expenses within a date range
-func (r *TransactionRepository) SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+// SumPaidExpensesByDateRange sums paid expenses within a date range, limited to accountIDs when given
+func (r *TransactionRepository) SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	ctx := context.Background()
 
 	total, err := r.queries.SumPaidExpensesByDateRange(ctx, sqlc.SumPaidExpensesByDateRangeParams{
 		WorkspaceID:       workspaceID,
 		TransactionDate:   pgtype.Date{Time: startDate, Valid: true},
 		TransactionDate_2: pgtype.Date{Time: endDate, Valid: true},
+		Column4:           accountIDsFilter(accountIDs),
 	})
 	if err != nil {
 		return decimal.Zero, err
@@ -620,13 +975,15 @@ func (r *TransactionRepository) SumUnpaidExpensesByDateRange(workspaceID int32,
 
 // SumUnpaidExpensesForDisposable sums unpaid expenses for disposable income calculation
 // This EXCLUDES deferred CC transactions (they're for next month)
-func (r *TransactionRepository) SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+// A non-empty accountIDs limits the sum to those accounts
+func (r *TransactionRepository) SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	ctx := context.Background()
 
 	total, err := r.queries.SumUnpaidExpensesForDisposable(ctx, sqlc.SumUnpaidExpensesForDisposableParams{
 		WorkspaceID:       workspaceID,
 		TransactionDate:   pgtype.Date{Time: startDate, Valid: true},
 		TransactionDate_2: pgtype.Date{Time: endDate, Valid: true},
+		Column4:           accountIDsFilter(accountIDs),
 	})
 	if err != nil {
 		return decimal.Zero, err
@@ -636,13 +993,15 @@ func (r *TransactionRepository) SumUnpaidExpensesForDisposable(workspaceID int32
 
 // SumDeferredCCByDateRange sums deferred CC expenses within a date range
 // Used for next month projections
-func (r *TransactionRepository) SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+// A non-empty accountIDs limits the sum to those accounts
+func (r *TransactionRepository) SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	ctx := context.Background()
 
 	total, err := r.queries.SumDeferredCCByDateRange(ctx, sqlc.SumDeferredCCByDateRangeParams{
 		WorkspaceID:       workspaceID,
 		TransactionDate:   pgtype.Date{Time: startDate, Valid: true},
 		TransactionDate_2: pgtype.Date{Time: endDate, Valid: true},
+		Column4:           accountIDsFilter(accountIDs),
 	})
 	if err != nil {
 		return decimal.Zero, err
@@ -680,6 +1039,11 @@ func (r *TransactionRepository) GetRecentlyUsedCategories(workspaceID int32) ([]
 	return result, nil
 }
 
+// GetDistinctSources returns the source values in use across the workspace's transactions
+func (r *TransactionRepository) GetDistinctSources(ctx context.Context, workspaceID int32) ([]string, error) {
+	return r.queries.GetDistinctTransactionSources(ctx, workspaceID)
+}
+
 // Helper functions
 
 func sqlcTransactionToDomain(t sqlc.Transaction) *domain.Transaction {
@@ -731,6 +1095,32 @@ func sqlcTransactionToDomain(t sqlc.Transaction) *domain.Transaction {
 		transaction.TemplateID = &t.TemplateID.Int32
 	}
 	transaction.IsProjected = t.IsProjected.Bool
+	transaction.IsScheduled = t.IsScheduled
+	if t.PaidAt.Valid {
+		transaction.PaidAt = &t.PaidAt.Time
+	}
+	if t.Merchant.Valid {
+		transaction.Merchant = &t.Merchant.String
+	}
+	if t.Payee.Valid {
+		transaction.Payee = &t.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(t.Latitude, t.Longitude, t.Location)
+	if t.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(t.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	if t.RoundUpSourceID.Valid {
+		transaction.RoundUpSourceID = &t.RoundUpSourceID.Int32
+	}
+	transaction.IsRefund = t.IsRefund
+	transaction.IsLoanFee = t.IsLoanFee
+	transaction.ExcludeFromReports = t.ExcludeFromReports
+	transaction.IsTaxDeductible = t.IsTaxDeductible
+	transaction.ModifiedFromTemplate = t.ModifiedFromTemplate
+	if t.DeletedLoanID.Valid {
+		transaction.DeletedLoanID = &t.DeletedLoanID.Int32
+	}
 	// Loan Integration (v2)
 	if t.LoanID.Valid {
 		transaction.LoanID = &t.LoanID.Int32
@@ -794,6 +1184,25 @@ func sqlcTransactionWithCategoryToDomain(t sqlc.GetTransactionsWithCategoryRow)
 		transaction.TemplateID = &t.TemplateID.Int32
 	}
 	transaction.IsProjected = t.IsProjected.Bool
+	transaction.IsScheduled = t.IsScheduled
+	if t.PaidAt.Valid {
+		transaction.PaidAt = &t.PaidAt.Time
+	}
+	if t.Merchant.Valid {
+		transaction.Merchant = &t.Merchant.String
+	}
+	if t.Payee.Valid {
+		transaction.Payee = &t.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(t.Latitude, t.Longitude, t.Location)
+	if t.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(t.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	transaction.IsRefund = t.IsRefund
+	transaction.ExcludeFromReports = t.ExcludeFromReports
+	transaction.IsTaxDeductible = t.IsTaxDeductible
+	transaction.ModifiedFromTemplate = t.ModifiedFromTemplate
 	// Loan Integration (v2)
 	if t.LoanID.Valid {
 		transaction.LoanID = &t.LoanID.Int32
@@ -809,10 +1218,27 @@ func sqlcTransactionWithCategoryToDomain(t sqlc.GetTransactionsWithCategoryRow)
 }
 
 // GetProjectionsByTemplate retrieves all projected transactions for a specific template
-func (r *TransactionRepository) GetProjectionsByTemplate(workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
+func (r *TransactionRepository) GetProjectionsByTemplate(ctx context.Context, workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
+	rows, err := r.queries.GetProjectionsByTemplate(ctx, sqlc.GetProjectionsByTemplateParams{
+		WorkspaceID: workspaceID,
+		TemplateID:  pgtype.Int4{Int32: templateID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		result[i] = sqlcTransactionToDomain(row)
+	}
+	return result, nil
+}
+
+// GetByTemplate retrieves all projected and actual transactions generated from a template, newest first
+func (r *TransactionRepository) GetByTemplate(workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
 	ctx := context.Background()
 
-	rows, err := r.queries.GetProjectionsByTemplate(ctx, sqlc.GetProjectionsByTemplateParams{
+	rows, err := r.queries.GetTransactionsByTemplate(ctx, sqlc.GetTransactionsByTemplateParams{
 		WorkspaceID: workspaceID,
 		TemplateID:  pgtype.Int4{Int32: templateID, Valid: true},
 	})
@@ -837,6 +1263,17 @@ func (r *TransactionRepository) DeleteProjectionsByTemplate(workspaceID int32, t
 	})
 }
 
+// DeleteFutureUnpaidActualsByTemplate soft deletes unpaid actual transactions of a template dated after the given day
+func (r *TransactionRepository) DeleteFutureUnpaidActualsByTemplate(workspaceID int32, templateID int32, after time.Time) error {
+	ctx := context.Background()
+
+	return r.queries.DeleteFutureUnpaidActualsByTemplate(ctx, sqlc.DeleteFutureUnpaidActualsByTemplateParams{
+		WorkspaceID:     workspaceID,
+		TemplateID:      pgtype.Int4{Int32: templateID, Valid: true},
+		TransactionDate: pgtype.Date{Time: after, Valid: true},
+	})
+}
+
 // OrphanActualsByTemplate unlinks actual transactions from a template (keeps them, clears template_id)
 func (r *TransactionRepository) OrphanActualsByTemplate(workspaceID int32, templateID int32) error {
 	ctx := context.Background()
@@ -878,6 +1315,26 @@ func (r *TransactionRepository) GetCCMetrics(workspaceID int32, startDate, endDa
 	}, nil
 }
 
+// GetCCStateBreakdown returns counts and totals of a CC account's purchases per CC state
+func (r *TransactionRepository) GetCCStateBreakdown(workspaceID, accountID int32) (*domain.CCStateBreakdown, error) {
+	ctx := context.Background()
+
+	row, err := r.queries.GetCCStateBreakdown(ctx, sqlc.GetCCStateBreakdownParams{
+		WorkspaceID: workspaceID,
+		AccountID:   accountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CCStateBreakdown{
+		AccountID: accountID,
+		Pending:   domain.CCStateTotals{Count: row.PendingCount, Total: pgNumericToDecimal(row.PendingTotal)},
+		Billed:    domain.CCStateTotals{Count: row.BilledCount, Total: pgNumericToDecimal(row.BilledTotal)},
+		Settled:   domain.CCStateTotals{Count: row.SettledCount, Total: pgNumericToDecimal(row.SettledTotal)},
+	}, nil
+}
+
 // BatchToggleToBilled toggles multiple pending transactions to billed state
 func (r *TransactionRepository) BatchToggleToBilled(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
 	ctx := context.Background()
@@ -897,6 +1354,26 @@ func (r *TransactionRepository) BatchToggleToBilled(workspaceID int32, ids []int
 	return transactions, nil
 }
 
+// BillPendingUpTo transitions pending CC purchases dated on/before cutoff to billed
+func (r *TransactionRepository) BillPendingUpTo(workspaceID, accountID int32, cutoff time.Time) ([]*domain.Transaction, error) {
+	ctx := context.Background()
+
+	rows, err := r.queries.BillPendingCCTransactions(ctx, sqlc.BillPendingCCTransactionsParams{
+		WorkspaceID: workspaceID,
+		AccountID:   accountID,
+		Column3:     pgtype.Date{Time: cutoff, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+	return transactions, nil
+}
+
 // GetByIDs retrieves multiple transactions by their IDs
 func (r *TransactionRepository) GetByIDs(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
 	ctx := context.Background()
@@ -937,9 +1414,17 @@ func (r *TransactionRepository) BulkSettle(workspaceID int32, ids []int32) ([]*d
 
 // GetDeferredForSettlement retrieves all billed+deferred transactions that need settlement
 func (r *TransactionRepository) GetDeferredForSettlement(workspaceID int32) ([]*domain.Transaction, error) {
+	return r.GetDeferredForSettlementInAccounts(workspaceID, nil)
+}
+
+// GetDeferredForSettlementInAccounts is GetDeferredForSettlement limited to accountIDs; empty means every account
+func (r *TransactionRepository) GetDeferredForSettlementInAccounts(workspaceID int32, accountIDs []int32) ([]*domain.Transaction, error) {
 	ctx := context.Background()
 
-	rows, err := r.queries.GetDeferredForSettlement(ctx, workspaceID)
+	rows, err := r.queries.GetDeferredForSettlement(ctx, sqlc.GetDeferredForSettlementParams{
+		WorkspaceID: workspaceID,
+		Column2:     accountIDsFilter(accountIDs),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1007,6 +1492,25 @@ func (r *TransactionRepository) GetOverdueCC(workspaceID int32) ([]*domain.Trans
 	return transactions, nil
 }
 
+// GetOverdueTransactions retrieves unpaid expenses dated before asOf, oldest first
+func (r *TransactionRepository) GetOverdueTransactions(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error) {
+	ctx := context.Background()
+
+	rows, err := r.queries.GetPastDueUnpaidTransactions(ctx, sqlc.GetPastDueUnpaidTransactionsParams{
+		WorkspaceID:     workspaceID,
+		TransactionDate: pgtype.Date{Time: asOf, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+	return transactions, nil
+}
+
 // AtomicSettle creates a transfer pair (expense and income) and settles CC transactions atomically
 // within a single database transaction. If any operation fails, all changes are rolled back.
 func (r *TransactionRepository) AtomicSettle(fromTx, toTx *domain.Transaction, settleIDs []int32) (*domain.Transaction, int, error) {
@@ -1058,12 +1562,18 @@ func (r *TransactionRepository) AtomicSettle(fromTx, toTx *domain.Transaction, s
 // GetByDateRangeForAggregation retrieves all transactions in a date range for aggregation
 // This method returns all transactions without pagination, intended for dashboard calculations
 func (r *TransactionRepository) GetByDateRangeForAggregation(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error) {
+	return r.GetByDateRangeForAggregationInAccounts(workspaceID, startDate, endDate, nil)
+}
+
+// GetByDateRangeForAggregationInAccounts is GetByDateRangeForAggregation limited to accountIDs; empty means every account
+func (r *TransactionRepository) GetByDateRangeForAggregationInAccounts(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) ([]*domain.Transaction, error) {
 	ctx := context.Background()
 
 	rows, err := r.queries.GetTransactionsForAggregation(ctx, sqlc.GetTransactionsForAggregationParams{
 		WorkspaceID: workspaceID,
 		StartDate:   pgtype.Date{Time: startDate, Valid: true},
 		EndDate:     pgtype.Date{Time: endDate, Valid: true},
+		AccountIds:  accountIDsFilter(accountIDs),
 	})
 	if err != nil {
 		return nil, err
@@ -1076,6 +1586,14 @@ func (r *TransactionRepository) GetByDateRangeForAggregation(workspaceID int32,
 	return transactions, nil
 }
 
+// accountIDsFilter maps an empty account list to NULL, which the scoped queries read as every account
+func accountIDsFilter(accountIDs []int32) []int32 {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	return accountIDs
+}
+
 // sqlcAggregationRowToDomain converts a GetTransactionsForAggregationRow to domain.Transaction
 func sqlcAggregationRowToDomain(row sqlc.GetTransactionsForAggregationRow) *domain.Transaction {
 	transaction := &domain.Transaction{
@@ -1130,6 +1648,25 @@ func sqlcAggregationRowToDomain(row sqlc.GetTransactionsForAggregationRow) *doma
 	if row.IsProjected.Valid {
 		transaction.IsProjected = row.IsProjected.Bool
 	}
+	transaction.IsScheduled = row.IsScheduled
+	if row.PaidAt.Valid {
+		transaction.PaidAt = &row.PaidAt.Time
+	}
+	if row.Merchant.Valid {
+		transaction.Merchant = &row.Merchant.String
+	}
+	if row.Payee.Valid {
+		transaction.Payee = &row.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(row.Latitude, row.Longitude, row.Location)
+	if row.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(row.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	transaction.IsRefund = row.IsRefund
+	transaction.ExcludeFromReports = row.ExcludeFromReports
+	transaction.IsTaxDeductible = row.IsTaxDeductible
+	transaction.ModifiedFromTemplate = row.ModifiedFromTemplate
 	// Loan Integration (v2)
 	if row.LoanID.Valid {
 		transaction.LoanID = &row.LoanID.Int32
@@ -1196,6 +1733,32 @@ func sqlcDeferredRowToDomain(row sqlc.GetDeferredForSettlementRow) *domain.Trans
 	if row.IsProjected.Valid {
 		transaction.IsProjected = row.IsProjected.Bool
 	}
+	transaction.IsScheduled = row.IsScheduled
+	if row.PaidAt.Valid {
+		transaction.PaidAt = &row.PaidAt.Time
+	}
+	if row.Merchant.Valid {
+		transaction.Merchant = &row.Merchant.String
+	}
+	if row.Payee.Valid {
+		transaction.Payee = &row.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(row.Latitude, row.Longitude, row.Location)
+	if row.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(row.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	if row.RoundUpSourceID.Valid {
+		transaction.RoundUpSourceID = &row.RoundUpSourceID.Int32
+	}
+	transaction.IsRefund = row.IsRefund
+	transaction.IsLoanFee = row.IsLoanFee
+	transaction.ExcludeFromReports = row.ExcludeFromReports
+	transaction.IsTaxDeductible = row.IsTaxDeductible
+	transaction.ModifiedFromTemplate = row.ModifiedFromTemplate
+	if row.DeletedLoanID.Valid {
+		transaction.DeletedLoanID = &row.DeletedLoanID.Int32
+	}
 
 	return transaction
 }
@@ -1251,6 +1814,32 @@ func sqlcImmediateRowToDomain(row sqlc.GetImmediateForSettlementRow) *domain.Tra
 	if row.IsProjected.Valid {
 		transaction.IsProjected = row.IsProjected.Bool
 	}
+	transaction.IsScheduled = row.IsScheduled
+	if row.PaidAt.Valid {
+		transaction.PaidAt = &row.PaidAt.Time
+	}
+	if row.Merchant.Valid {
+		transaction.Merchant = &row.Merchant.String
+	}
+	if row.Payee.Valid {
+		transaction.Payee = &row.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(row.Latitude, row.Longitude, row.Location)
+	if row.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(row.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	if row.RoundUpSourceID.Valid {
+		transaction.RoundUpSourceID = &row.RoundUpSourceID.Int32
+	}
+	transaction.IsRefund = row.IsRefund
+	transaction.IsLoanFee = row.IsLoanFee
+	transaction.ExcludeFromReports = row.ExcludeFromReports
+	transaction.IsTaxDeductible = row.IsTaxDeductible
+	transaction.ModifiedFromTemplate = row.ModifiedFromTemplate
+	if row.DeletedLoanID.Valid {
+		transaction.DeletedLoanID = &row.DeletedLoanID.Int32
+	}
 
 	return transaction
 }
@@ -1306,6 +1895,32 @@ func sqlcPendingDeferredRowToDomain(row sqlc.GetPendingDeferredCCRow) *domain.Tr
 	if row.IsProjected.Valid {
 		transaction.IsProjected = row.IsProjected.Bool
 	}
+	transaction.IsScheduled = row.IsScheduled
+	if row.PaidAt.Valid {
+		transaction.PaidAt = &row.PaidAt.Time
+	}
+	if row.Merchant.Valid {
+		transaction.Merchant = &row.Merchant.String
+	}
+	if row.Payee.Valid {
+		transaction.Payee = &row.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(row.Latitude, row.Longitude, row.Location)
+	if row.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(row.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	if row.RoundUpSourceID.Valid {
+		transaction.RoundUpSourceID = &row.RoundUpSourceID.Int32
+	}
+	transaction.IsRefund = row.IsRefund
+	transaction.IsLoanFee = row.IsLoanFee
+	transaction.ExcludeFromReports = row.ExcludeFromReports
+	transaction.IsTaxDeductible = row.IsTaxDeductible
+	transaction.ModifiedFromTemplate = row.ModifiedFromTemplate
+	if row.DeletedLoanID.Valid {
+		transaction.DeletedLoanID = &row.DeletedLoanID.Int32
+	}
 
 	return transaction
 }
@@ -1330,9 +1945,28 @@ func (r *TransactionRepository) GetLoanTransactionsByMonth(workspaceID int32, lo
 	return transactions, nil
 }
 
+// GetAllLoanTransactionsByMonth retrieves all loan transactions (paid and unpaid) for a month
+func (r *TransactionRepository) GetAllLoanTransactionsByMonth(workspaceID int32, year, month int) ([]*domain.Transaction, error) {
+	rows, err := r.queries.GetAllLoanTransactionsByMonth(context.Background(), sqlc.GetAllLoanTransactionsByMonthParams{
+		WorkspaceID: workspaceID,
+		Year:        int32(year),
+		Month:       int32(month),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
 // BulkMarkPaid marks multiple transactions as paid by IDs
 // Works for both bank and CC transactions - CC state transitions to 'settled' automatically
-func (r *TransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
+func (r *TransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32, paidAt time.Time) ([]*domain.Transaction, error) {
 	if len(ids) == 0 {
 		return []*domain.Transaction{}, nil
 	}
@@ -1340,6 +1974,31 @@ func (r *TransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32) ([]
 	rows, err := r.queries.BulkMarkTransactionsPaid(context.Background(), sqlc.BulkMarkTransactionsPaidParams{
 		WorkspaceID: workspaceID,
 		Column2:     ids,
+		PaidAt:      pgtype.Timestamptz{Time: paidAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+// BulkMarkPaidTx marks multiple transactions as paid by IDs within a database transaction
+func (r *TransactionRepository) BulkMarkPaidTx(tx interface{}, workspaceID int32, ids []int32, paidAt time.Time) ([]*domain.Transaction, error) {
+	if len(ids) == 0 {
+		return []*domain.Transaction{}, nil
+	}
+
+	qtx := r.queries.WithTx(tx.(pgx.Tx))
+	rows, err := qtx.BulkMarkTransactionsPaid(context.Background(), sqlc.BulkMarkTransactionsPaidParams{
+		WorkspaceID: workspaceID,
+		Column2:     ids,
+		PaidAt:      pgtype.Timestamptz{Time: paidAt, Valid: true},
 	})
 	if err != nil {
 		return nil, err
@@ -1371,6 +2030,25 @@ func (r *TransactionRepository) GetByLoanID(workspaceID int32, loanID int32) ([]
 	return transactions, nil
 }
 
+// GetByLoanIDTx retrieves all transactions for a loan within a DB transaction
+func (r *TransactionRepository) GetByLoanIDTx(tx interface{}, workspaceID int32, loanID int32) ([]*domain.Transaction, error) {
+	qtx := r.queries.WithTx(tx.(pgx.Tx))
+	rows, err := qtx.GetTransactionsByLoanID(context.Background(), sqlc.GetTransactionsByLoanIDParams{
+		WorkspaceID: workspaceID,
+		LoanID:      pgtype.Int4{Int32: loanID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
 // OrphanPaidTransactionsByLoan unlinks paid transactions from a loan (keeps them, clears loan_id)
 // Used when deleting a loan to preserve payment history
 func (r *TransactionRepository) OrphanPaidTransactionsByLoan(workspaceID int32, loanID int32) error {
@@ -1381,7 +2059,7 @@ func (r *TransactionRepository) OrphanPaidTransactionsByLoan(workspaceID int32,
 	})
 }
 
-// DeleteUnpaidTransactionsByLoan hard deletes unpaid transactions for a loan
+// DeleteUnpaidTransactionsByLoan soft deletes unpaid transactions for a loan
 // Used when deleting a loan (unpaid future payments are removed)
 func (r *TransactionRepository) DeleteUnpaidTransactionsByLoan(workspaceID int32, loanID int32) error {
 	ctx := context.Background()
@@ -1391,6 +2069,37 @@ func (r *TransactionRepository) DeleteUnpaidTransactionsByLoan(workspaceID int32
 	})
 }
 
+// ClearUnpaidTransactionsByLoanTx soft-deletes a loan's unpaid transactions within a DB transaction
+// so its schedule can be regenerated
+func (r *TransactionRepository) ClearUnpaidTransactionsByLoanTx(tx interface{}, workspaceID int32, loanID int32) error {
+	ctx := context.Background()
+	qtx := r.queries.WithTx(tx.(pgx.Tx))
+	return qtx.ClearUnpaidTransactionsByLoan(ctx, sqlc.ClearUnpaidTransactionsByLoanParams{
+		WorkspaceID: workspaceID,
+		LoanID:      pgtype.Int4{Int32: loanID, Valid: true},
+	})
+}
+
+// RestoreTransactionsByDeletedLoan re-links transactions unlinked when the loan was deleted
+// Unpaid transactions removed with the loan are undeleted; paid ones keep their own deleted state
+func (r *TransactionRepository) RestoreTransactionsByDeletedLoan(workspaceID int32, loanID int32) error {
+	ctx := context.Background()
+	return r.queries.RestoreTransactionsByDeletedLoan(ctx, sqlc.RestoreTransactionsByDeletedLoanParams{
+		WorkspaceID:   workspaceID,
+		DeletedLoanID: pgtype.Int4{Int32: loanID, Valid: true},
+	})
+}
+
+// RestoreTransactionsByDeletedLoanTx re-links a restored loan's transactions within a DB transaction
+func (r *TransactionRepository) RestoreTransactionsByDeletedLoanTx(tx interface{}, workspaceID int32, loanID int32) error {
+	ctx := context.Background()
+	qtx := r.queries.WithTx(tx.(pgx.Tx))
+	return qtx.RestoreTransactionsByDeletedLoan(ctx, sqlc.RestoreTransactionsByDeletedLoanParams{
+		WorkspaceID:   workspaceID,
+		DeletedLoanID: pgtype.Int4{Int32: loanID, Valid: true},
+	})
+}
+
 // GetLoanTransactionStats returns paid/unpaid transaction counts for loan deletion confirmation
 func (r *TransactionRepository) GetLoanTransactionStats(workspaceID int32, loanID int32) (*domain.LoanTransactionStats, error) {
 	ctx := context.Background()
@@ -1421,6 +2130,25 @@ func (r *TransactionRepository) UpdatePayeesByLoan(workspaceID int32, loanID int
 	})
 }
 
+// SettleUnpaidByLoanExternally marks every unpaid payment of a loan paid with the external source
+func (r *TransactionRepository) SettleUnpaidByLoanExternally(workspaceID int32, loanID int32, note string, paidAt time.Time) ([]*domain.Transaction, error) {
+	rows, err := r.queries.SettleTransactionsByLoanExternally(context.Background(), sqlc.SettleTransactionsByLoanExternallyParams{
+		WorkspaceID: workspaceID,
+		LoanID:      pgtype.Int4{Int32: loanID, Valid: true},
+		PaidAt:      pgtype.Timestamptz{Time: paidAt, Valid: true},
+		Notes:       note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+	return transactions, nil
+}
+
 // HasPaidTransactionsByLoan checks if any transactions for this loan are paid
 // Used to validate if provider change is allowed (only when no payments made)
 func (r *TransactionRepository) HasPaidTransactionsByLoan(workspaceID int32, loanID int32) (bool, error) {
@@ -1482,6 +2210,32 @@ func sqlcOverdueRowToDomain(row sqlc.GetOverdueCCRow) *domain.Transaction {
 	if row.IsProjected.Valid {
 		transaction.IsProjected = row.IsProjected.Bool
 	}
+	transaction.IsScheduled = row.IsScheduled
+	if row.PaidAt.Valid {
+		transaction.PaidAt = &row.PaidAt.Time
+	}
+	if row.Merchant.Valid {
+		transaction.Merchant = &row.Merchant.String
+	}
+	if row.Payee.Valid {
+		transaction.Payee = &row.Payee.String
+	}
+	transaction.Latitude, transaction.Longitude, transaction.Location = pgLocationToDomain(row.Latitude, row.Longitude, row.Location)
+	if row.ReversesTransferPairID.Valid {
+		reversesPairID := uuid.UUID(row.ReversesTransferPairID.Bytes)
+		transaction.ReversesTransferPairID = &reversesPairID
+	}
+	if row.RoundUpSourceID.Valid {
+		transaction.RoundUpSourceID = &row.RoundUpSourceID.Int32
+	}
+	transaction.IsRefund = row.IsRefund
+	transaction.IsLoanFee = row.IsLoanFee
+	transaction.ExcludeFromReports = row.ExcludeFromReports
+	transaction.IsTaxDeductible = row.IsTaxDeductible
+	transaction.ModifiedFromTemplate = row.ModifiedFromTemplate
+	if row.DeletedLoanID.Valid {
+		transaction.DeletedLoanID = &row.DeletedLoanID.Int32
+	}
 
 	return transaction
 }
@@ -1515,3 +2269,104 @@ func (r *TransactionRepository) GetLoanTrendData(workspaceID int32, startYear, s
 
 	return result, nil
 }
+
+// PromoteDueScheduled clears the scheduled flag on a workspace's transactions dated on or before asOf
+func (r *TransactionRepository) PromoteDueScheduled(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error) {
+	ctx := context.Background()
+
+	rows, err := r.queries.PromoteDueScheduledTransactions(ctx, sqlc.PromoteDueScheduledTransactionsParams{
+		WorkspaceID:     workspaceID,
+		TransactionDate: pgtype.Date{Time: asOf, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+// PromoteAllDueScheduled clears the scheduled flag on due transactions across all workspaces
+func (r *TransactionRepository) PromoteAllDueScheduled(asOf time.Time) ([]*domain.Transaction, error) {
+	rows, err := r.queries.PromoteAllDueScheduledTransactions(context.Background(), pgtype.Date{Time: asOf, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+// GetOrphanedLoanTransactions retrieves transactions whose loan no longer exists or was deleted
+func (r *TransactionRepository) GetOrphanedLoanTransactions(workspaceID int32) ([]*domain.Transaction, error) {
+	rows, err := r.queries.GetOrphanedLoanTransactions(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+// GetUnpaidLoanTransactions returns unpaid payments of loans that have not been deleted
+func (r *TransactionRepository) GetUnpaidLoanTransactions(workspaceID int32) ([]*domain.Transaction, error) {
+	rows, err := r.queries.GetUnpaidLoanTransactions(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+// likeEscaper escapes the LIKE wildcards (and the escape character itself) so a search term matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Search returns up to limit transactions whose name, merchant, or payee contains query
+func (r *TransactionRepository) Search(workspaceID int32, query string, limit int32) ([]*domain.Transaction, error) {
+	rows, err := r.queries.SearchTransactions(context.Background(), sqlc.SearchTransactionsParams{
+		WorkspaceID: workspaceID,
+		Query:       likeEscaper.Replace(query),
+		MaxResults:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
+
+func (r *TransactionRepository) ClearOrphanedLoanLinks(workspaceID int32) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ClearOrphanedLoanTransactions(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = sqlcTransactionToDomain(row)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/db/sqlc"
+)
+
+func TestTransactionRepository_Search_EscapesLikeWildcards(t *testing.T) {
+	db := &fakeDB{}
+	repo := &TransactionRepository{queries: sqlc.New(db)}
+
+	// A literal "%" must not turn "50% off" into a match-anything pattern
+	if _, err := repo.Search(1, `50% off_now\`, 20); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(db.args) != 3 {
+		t.Fatalf("Expected 3 query arguments, got %d", len(db.args))
+	}
+	if got, want := db.args[1], `50\% off\_now\\`; got != want {
+		t.Errorf("Expected escaped query %q, got %q", want, got)
+	}
+}
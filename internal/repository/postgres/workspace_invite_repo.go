@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dafibh/fortuna/fortuna-backend/db/sqlc"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WorkspaceInviteRepository implements domain.WorkspaceInviteRepository using PostgreSQL
+type WorkspaceInviteRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewWorkspaceInviteRepository creates a new WorkspaceInviteRepository
+func NewWorkspaceInviteRepository(pool *pgxpool.Pool) *WorkspaceInviteRepository {
+	return &WorkspaceInviteRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// Create stores a new invite
+func (r *WorkspaceInviteRepository) Create(invite *domain.WorkspaceInvite) (*domain.WorkspaceInvite, error) {
+	created, err := r.queries.CreateWorkspaceInvite(context.Background(), sqlc.CreateWorkspaceInviteParams{
+		WorkspaceID: invite.WorkspaceID,
+		Email:       invite.Email,
+		Role:        string(invite.Role),
+		Token:       invite.Token,
+		InvitedBy:   pgtype.UUID{Bytes: invite.InvitedBy, Valid: true},
+		ExpiresAt:   pgtype.Timestamptz{Time: invite.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sqlcWorkspaceInviteToDomain(created), nil
+}
+
+// GetByToken retrieves an invite by its token
+func (r *WorkspaceInviteRepository) GetByToken(token string) (*domain.WorkspaceInvite, error) {
+	invite, err := r.queries.GetWorkspaceInviteByToken(context.Background(), token)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrInviteNotFound
+		}
+		return nil, err
+	}
+	return sqlcWorkspaceInviteToDomain(invite), nil
+}
+
+// Accept marks the invite accepted and adds the member within a single transaction
+func (r *WorkspaceInviteRepository) Accept(inviteID int32, member *domain.WorkspaceMember) (*domain.WorkspaceMember, error) {
+	ctx := context.Background()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	if _, err := qtx.MarkWorkspaceInviteAccepted(ctx, inviteID); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrInviteAlreadyAccepted
+		}
+		return nil, err
+	}
+
+	created, err := qtx.AddWorkspaceMember(ctx, sqlc.AddWorkspaceMemberParams{
+		WorkspaceID: member.WorkspaceID,
+		UserID:      pgtype.UUID{Bytes: member.UserID, Valid: true},
+		Role:        string(member.Role),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return sqlcWorkspaceMemberToDomain(created), nil
+}
+
+// Helper functions
+
+func sqlcWorkspaceInviteToDomain(i sqlc.WorkspaceInvite) *domain.WorkspaceInvite {
+	invitedBy, _ := uuid.FromBytes(i.InvitedBy.Bytes[:])
+	invite := &domain.WorkspaceInvite{
+		ID:          i.ID,
+		WorkspaceID: i.WorkspaceID,
+		Email:       i.Email,
+		Role:        domain.WorkspaceRole(i.Role),
+		Token:       i.Token,
+		InvitedBy:   invitedBy,
+		ExpiresAt:   i.ExpiresAt.Time,
+		CreatedAt:   i.CreatedAt.Time,
+	}
+	if i.AcceptedAt.Valid {
+		invite.AcceptedAt = &i.AcceptedAt.Time
+	}
+	return invite
+}
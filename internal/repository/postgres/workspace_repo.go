@@ -62,16 +62,62 @@ func (r *WorkspaceRepository) GetByUserAuth0ID(auth0ID string) (*domain.Workspac
 	return sqlcWorkspaceToDomain(workspace), nil
 }
 
-// Create creates a new workspace
+// ListByUserAuth0ID retrieves all workspaces the user is a member of with the given Auth0 ID
+func (r *WorkspaceRepository) ListByUserAuth0ID(auth0ID string) ([]*domain.Workspace, error) {
+	workspaces, err := r.queries.ListWorkspacesByUserAuth0ID(context.Background(), auth0ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Workspace, len(workspaces))
+	for i, w := range workspaces {
+		result[i] = sqlcWorkspaceToDomain(sqlc.Workspace{
+			ID:                      w.ID,
+			UserID:                  w.UserID,
+			Name:                    w.Name,
+			CreatedAt:               w.CreatedAt,
+			UpdatedAt:               w.UpdatedAt,
+			Currency:                w.Currency,
+			RoundUpSavingsAccountID: w.RoundUpSavingsAccountID,
+			GenerationHorizonMonths: w.GenerationHorizonMonths,
+		})
+		result[i].Role = domain.WorkspaceRole(w.Role)
+	}
+	return result, nil
+}
+
+// Create creates a new workspace and records its creator as owner
 func (r *WorkspaceRepository) Create(workspace *domain.Workspace) (*domain.Workspace, error) {
+	ctx := context.Background()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
 	pgUserID := pgtype.UUID{Bytes: workspace.UserID, Valid: true}
-	created, err := r.queries.CreateWorkspace(context.Background(), sqlc.CreateWorkspaceParams{
+	created, err := qtx.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{
 		UserID: pgUserID,
 		Name:   workspace.Name,
 	})
 	if err != nil {
 		return nil, err
 	}
+
+	_, err = qtx.AddWorkspaceMember(ctx, sqlc.AddWorkspaceMemberParams{
+		WorkspaceID: created.ID,
+		UserID:      pgUserID,
+		Role:        string(domain.WorkspaceRoleOwner),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return sqlcWorkspaceToDomain(created), nil
 }
 
@@ -90,20 +136,110 @@ func (r *WorkspaceRepository) Update(workspace *domain.Workspace) (*domain.Works
 	return sqlcWorkspaceToDomain(updated), nil
 }
 
+// UpdateCurrency sets the currency a workspace displays amounts in
+func (r *WorkspaceRepository) UpdateCurrency(id int32, currency string) (*domain.Workspace, error) {
+	updated, err := r.queries.UpdateWorkspaceCurrency(context.Background(), sqlc.UpdateWorkspaceCurrencyParams{
+		ID:       id,
+		Currency: currency,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return sqlcWorkspaceToDomain(updated), nil
+}
+
+// UpdateRoundUpSavingsAccount sets the account receiving expense round-ups; nil disables them
+func (r *WorkspaceRepository) UpdateRoundUpSavingsAccount(id int32, accountID *int32) (*domain.Workspace, error) {
+	updated, err := r.queries.UpdateWorkspaceRoundUpSavingsAccount(context.Background(), sqlc.UpdateWorkspaceRoundUpSavingsAccountParams{
+		ID:                      id,
+		RoundUpSavingsAccountID: int32PtrToPgInt4(accountID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return sqlcWorkspaceToDomain(updated), nil
+}
+
+// UpdateGenerationHorizon sets how many months past the current one recurring generation covers
+func (r *WorkspaceRepository) UpdateGenerationHorizon(id int32, months int32) (*domain.Workspace, error) {
+	updated, err := r.queries.UpdateWorkspaceGenerationHorizon(context.Background(), sqlc.UpdateWorkspaceGenerationHorizonParams{
+		ID:                      id,
+		GenerationHorizonMonths: months,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return sqlcWorkspaceToDomain(updated), nil
+}
+
 // Delete deletes a workspace by its ID
 func (r *WorkspaceRepository) Delete(id int32) error {
 	return r.queries.DeleteWorkspace(context.Background(), id)
 }
 
+// AddMember adds a user to a workspace; an existing member keeps the higher of their current and new role
+func (r *WorkspaceRepository) AddMember(member *domain.WorkspaceMember) (*domain.WorkspaceMember, error) {
+	created, err := r.queries.AddWorkspaceMember(context.Background(), sqlc.AddWorkspaceMemberParams{
+		WorkspaceID: member.WorkspaceID,
+		UserID:      pgtype.UUID{Bytes: member.UserID, Valid: true},
+		Role:        string(member.Role),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sqlcWorkspaceMemberToDomain(created), nil
+}
+
+// GetMemberRole retrieves a user's role in a workspace
+func (r *WorkspaceRepository) GetMemberRole(workspaceID int32, userID uuid.UUID) (domain.WorkspaceRole, error) {
+	role, err := r.queries.GetWorkspaceMemberRole(context.Background(), sqlc.GetWorkspaceMemberRoleParams{
+		WorkspaceID: workspaceID,
+		UserID:      pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", domain.ErrWorkspaceMemberNotFound
+		}
+		return "", err
+	}
+	return domain.WorkspaceRole(role), nil
+}
+
 // Helper functions
 
 func sqlcWorkspaceToDomain(w sqlc.Workspace) *domain.Workspace {
 	userID, _ := uuid.FromBytes(w.UserID.Bytes[:])
-	return &domain.Workspace{
-		ID:        w.ID,
-		UserID:    userID,
-		Name:      w.Name,
-		CreatedAt: w.CreatedAt.Time,
-		UpdatedAt: w.UpdatedAt.Time,
+	workspace := &domain.Workspace{
+		ID:                      w.ID,
+		UserID:                  userID,
+		Name:                    w.Name,
+		CreatedAt:               w.CreatedAt.Time,
+		UpdatedAt:               w.UpdatedAt.Time,
+		Currency:                w.Currency,
+		GenerationHorizonMonths: w.GenerationHorizonMonths,
+	}
+	if w.RoundUpSavingsAccountID.Valid {
+		workspace.RoundUpSavingsAccountID = &w.RoundUpSavingsAccountID.Int32
+	}
+	return workspace
+}
+
+func sqlcWorkspaceMemberToDomain(m sqlc.WorkspaceMember) *domain.WorkspaceMember {
+	userID, _ := uuid.FromBytes(m.UserID.Bytes[:])
+	return &domain.WorkspaceMember{
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		UserID:      userID,
+		Role:        domain.WorkspaceRole(m.Role),
+		CreatedAt:   m.CreatedAt.Time,
 	}
 }
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+)
+
+// AccountGroupService handles account group (folder) business logic
+type AccountGroupService struct {
+	groupRepo   domain.AccountGroupRepository
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountGroupService creates a new AccountGroupService
+func NewAccountGroupService(groupRepo domain.AccountGroupRepository, accountRepo domain.AccountRepository) *AccountGroupService {
+	return &AccountGroupService{
+		groupRepo:   groupRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// CreateGroup creates a new account group
+func (s *AccountGroupService) CreateGroup(workspaceID int32, name string) (*domain.AccountGroup, error) {
+	name, err := validateAccountGroupName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.Create(&domain.AccountGroup{
+		WorkspaceID: workspaceID,
+		Name:        name,
+	})
+}
+
+// GetGroups retrieves all account groups for a workspace
+func (s *AccountGroupService) GetGroups(workspaceID int32) ([]*domain.AccountGroup, error) {
+	return s.groupRepo.GetAllByWorkspace(workspaceID)
+}
+
+// UpdateGroup renames an account group
+func (s *AccountGroupService) UpdateGroup(workspaceID int32, id int32, name string) (*domain.AccountGroup, error) {
+	name, err := validateAccountGroupName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.Update(&domain.AccountGroup{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		Name:        name,
+	})
+}
+
+// DeleteGroup soft-deletes an account group and moves its accounts to the ungrouped bucket
+func (s *AccountGroupService) DeleteGroup(workspaceID int32, id int32) error {
+	// Verify group exists before deleting
+	if _, err := s.groupRepo.GetByID(workspaceID, id); err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.UngroupByGroup(workspaceID, id); err != nil {
+		return err
+	}
+
+	return s.groupRepo.SoftDelete(workspaceID, id)
+}
+
+// validateAccountGroupName trims and validates a group name
+func validateAccountGroupName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.ErrNameRequired
+	}
+	if len(name) > domain.MaxAccountGroupNameLength {
+		return "", domain.ErrNameTooLong
+	}
+	return name, nil
+}
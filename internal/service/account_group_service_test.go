@@ -0,0 +1,156 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+)
+
+func TestAssignGroup_Success(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	groupRepo := testutil.NewMockAccountGroupRepository()
+	accountService := NewAccountService(accountRepo)
+	accountService.SetGroupRepository(groupRepo)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Checking"})
+	groupRepo.AddGroup(&domain.AccountGroup{ID: 5, WorkspaceID: 1, Name: "Personal"})
+
+	groupID := int32(5)
+	account, err := accountService.AssignGroup(1, 1, &groupID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if account.AccountGroupID == nil || *account.AccountGroupID != 5 {
+		t.Errorf("Expected account group 5, got %v", account.AccountGroupID)
+	}
+
+	// nil moves the account back to ungrouped
+	account, err = accountService.AssignGroup(1, 1, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if account.AccountGroupID != nil {
+		t.Errorf("Expected account to be ungrouped, got %d", *account.AccountGroupID)
+	}
+}
+
+func TestAssignGroup_GroupNotFound(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	groupRepo := testutil.NewMockAccountGroupRepository()
+	accountService := NewAccountService(accountRepo)
+	accountService.SetGroupRepository(groupRepo)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Checking"})
+	// Group belongs to another workspace
+	groupRepo.AddGroup(&domain.AccountGroup{ID: 5, WorkspaceID: 2, Name: "Personal"})
+
+	groupID := int32(5)
+	_, err := accountService.AssignGroup(1, 1, &groupID)
+	if err != domain.ErrAccountGroupNotFound {
+		t.Errorf("Expected ErrAccountGroupNotFound, got %v", err)
+	}
+}
+
+func TestGetAccountsByFolder_GroupsAccounts(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	groupRepo := testutil.NewMockAccountGroupRepository()
+	accountService := NewAccountService(accountRepo)
+	accountService.SetGroupRepository(groupRepo)
+
+	personal := int32(1)
+	business := int32(2)
+	groupRepo.AddGroup(&domain.AccountGroup{ID: personal, WorkspaceID: 1, Name: "Personal"})
+	groupRepo.AddGroup(&domain.AccountGroup{ID: business, WorkspaceID: 1, Name: "Business"})
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Checking", AccountGroupID: &personal})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Company Card", AccountGroupID: &business})
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 1, Name: "Wallet"})
+	accountRepo.AddAccount(&domain.Account{ID: 4, WorkspaceID: 1, Name: "Savings", AccountGroupID: &personal})
+
+	folders, err := accountService.GetAccountsByFolder(1, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(folders) != 3 {
+		t.Fatalf("Expected 3 folders (2 groups + ungrouped), got %d", len(folders))
+	}
+
+	// Groups are ordered by name, ungrouped bucket last
+	if folders[0].Group == nil || folders[0].Group.Name != "Business" {
+		t.Errorf("Expected first folder Business, got %+v", folders[0].Group)
+	}
+	if len(folders[0].Accounts) != 1 || folders[0].Accounts[0].ID != 2 {
+		t.Errorf("Expected Business to contain account 2, got %v", folders[0].Accounts)
+	}
+	if folders[1].Group == nil || folders[1].Group.Name != "Personal" {
+		t.Errorf("Expected second folder Personal, got %+v", folders[1].Group)
+	}
+	if len(folders[1].Accounts) != 2 {
+		t.Errorf("Expected Personal to contain 2 accounts, got %d", len(folders[1].Accounts))
+	}
+	if folders[2].Group != nil {
+		t.Errorf("Expected last folder to be ungrouped, got %+v", folders[2].Group)
+	}
+	if len(folders[2].Accounts) != 1 || folders[2].Accounts[0].ID != 3 {
+		t.Errorf("Expected ungrouped to contain account 3, got %v", folders[2].Accounts)
+	}
+}
+
+func TestCreateGroup_ValidatesName(t *testing.T) {
+	groupService := NewAccountGroupService(testutil.NewMockAccountGroupRepository(), testutil.NewMockAccountRepository())
+
+	if _, err := groupService.CreateGroup(1, "   "); err != domain.ErrNameRequired {
+		t.Errorf("Expected ErrNameRequired, got %v", err)
+	}
+
+	group, err := groupService.CreateGroup(1, "  Personal  ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if group.Name != "Personal" {
+		t.Errorf("Expected trimmed name 'Personal', got %q", group.Name)
+	}
+
+	if _, err := groupService.CreateGroup(1, "Personal"); err != domain.ErrAccountGroupAlreadyExists {
+		t.Errorf("Expected ErrAccountGroupAlreadyExists, got %v", err)
+	}
+}
+
+func TestDeleteGroup_UngroupsAccounts(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	groupRepo := testutil.NewMockAccountGroupRepository()
+	groupService := NewAccountGroupService(groupRepo, accountRepo)
+
+	personal := int32(1)
+	business := int32(2)
+	groupRepo.AddGroup(&domain.AccountGroup{ID: personal, WorkspaceID: 1, Name: "Personal"})
+	groupRepo.AddGroup(&domain.AccountGroup{ID: business, WorkspaceID: 1, Name: "Business"})
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Checking", AccountGroupID: &personal})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Company Card", AccountGroupID: &business})
+
+	if err := groupService.DeleteGroup(1, personal); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := groupRepo.GetByID(1, personal); err != domain.ErrAccountGroupNotFound {
+		t.Errorf("Expected deleted group to be gone, got %v", err)
+	}
+
+	checking, _ := accountRepo.GetByID(1, 1)
+	if checking.AccountGroupID != nil {
+		t.Errorf("Expected account 1 to be ungrouped, got %d", *checking.AccountGroupID)
+	}
+	card, _ := accountRepo.GetByID(1, 2)
+	if card.AccountGroupID == nil || *card.AccountGroupID != business {
+		t.Errorf("Expected account 2 to stay in Business, got %v", card.AccountGroupID)
+	}
+}
+
+func TestDeleteGroup_NotFound(t *testing.T) {
+	groupService := NewAccountGroupService(testutil.NewMockAccountGroupRepository(), testutil.NewMockAccountRepository())
+
+	if err := groupService.DeleteGroup(1, 999); err != domain.ErrAccountGroupNotFound {
+		t.Errorf("Expected ErrAccountGroupNotFound, got %v", err)
+	}
+}
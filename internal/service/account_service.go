@@ -10,6 +10,7 @@ import (
 // AccountService handles account-related business logic
 type AccountService struct {
 	accountRepo domain.AccountRepository
+	groupRepo   domain.AccountGroupRepository
 }
 
 // NewAccountService creates a new AccountService
@@ -17,11 +18,18 @@ func NewAccountService(accountRepo domain.AccountRepository) *AccountService {
 	return &AccountService{accountRepo: accountRepo}
 }
 
+// SetGroupRepository sets the account group repository used for folder assignment and listing
+func (s *AccountService) SetGroupRepository(groupRepo domain.AccountGroupRepository) {
+	s.groupRepo = groupRepo
+}
+
 // CreateAccountInput holds the input for creating an account
 type CreateAccountInput struct {
 	Name           string
 	Template       domain.AccountTemplate
 	InitialBalance decimal.Decimal
+	// ExcludeFromNetWorth keeps the account's balance out of net worth
+	ExcludeFromNetWorth bool
 }
 
 // CreateAccount creates a new account with template-to-type mapping
@@ -42,19 +50,21 @@ func (s *AccountService) CreateAccount(workspaceID int32, input CreateAccountInp
 	}
 
 	account := &domain.Account{
-		WorkspaceID:    workspaceID,
-		Name:           name,
-		AccountType:    accountType,
-		Template:       input.Template,
-		InitialBalance: input.InitialBalance,
+		WorkspaceID:         workspaceID,
+		Name:                name,
+		AccountType:         accountType,
+		Template:            input.Template,
+		InitialBalance:      input.InitialBalance,
+		ExcludeFromNetWorth: input.ExcludeFromNetWorth,
 	}
 
 	return s.accountRepo.Create(account)
 }
 
 // GetAccounts retrieves all accounts for a workspace
-func (s *AccountService) GetAccounts(workspaceID int32, includeArchived bool) ([]*domain.Account, error) {
-	return s.accountRepo.GetAllByWorkspace(workspaceID, includeArchived)
+// Archived (soft-deleted) accounts are included only when opts.IncludeDeleted is set
+func (s *AccountService) GetAccounts(workspaceID int32, opts domain.ListOptions) ([]*domain.Account, error) {
+	return s.accountRepo.GetAllByWorkspace(workspaceID, opts)
 }
 
 // GetAccountByID retrieves an account by ID within a workspace
@@ -76,12 +86,107 @@ func (s *AccountService) UpdateAccount(workspaceID int32, id int32, name string)
 	return s.accountRepo.Update(workspaceID, id, name)
 }
 
+// ChangeAccountTemplate switches an account to another template and the matching account type
+// (e.g. bank/asset to credit_card/liability). Balances and net worth derive from the template
+// when read, so the account's contribution to aggregates follows the change. Moving to or from
+// credit_card is refused once the account has transactions, since their CC lifecycle fields
+// (settlement intent, billing state) would no longer match the account.
+func (s *AccountService) ChangeAccountTemplate(workspaceID int32, accountID int32, newTemplate domain.AccountTemplate) (*domain.Account, error) {
+	accountType, ok := domain.TemplateToType[newTemplate]
+	if !ok {
+		return nil, domain.ErrInvalidTemplate
+	}
+
+	account, err := s.accountRepo.GetByID(workspaceID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Template == newTemplate {
+		return account, nil
+	}
+
+	if account.Template == domain.TemplateCreditCard || newTemplate == domain.TemplateCreditCard {
+		count, err := s.accountRepo.CountTransactions(workspaceID, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, domain.ErrCannotChangeTemplateWithTransactions
+		}
+	}
+
+	return s.accountRepo.UpdateTemplate(workspaceID, accountID, newTemplate, accountType)
+}
+
 // DeleteAccount soft-deletes an account (sets deleted_at timestamp)
 func (s *AccountService) DeleteAccount(workspaceID int32, id int32) error {
 	// SoftDelete atomically checks existence and deletes, returning ErrAccountNotFound if not found
 	return s.accountRepo.SoftDelete(workspaceID, id)
 }
 
+// AssignGroup moves an account into a group; a nil groupID moves it to the ungrouped bucket
+func (s *AccountService) AssignGroup(workspaceID int32, id int32, groupID *int32) (*domain.Account, error) {
+	if groupID != nil {
+		if s.groupRepo == nil {
+			return nil, domain.ErrAccountGroupNotFound
+		}
+		if _, err := s.groupRepo.GetByID(workspaceID, *groupID); err != nil {
+			return nil, err
+		}
+	}
+	return s.accountRepo.SetGroup(workspaceID, id, groupID)
+}
+
+// SetIncludeInNetWorth includes or excludes an account's balance from net worth
+func (s *AccountService) SetIncludeInNetWorth(workspaceID int32, id int32, include bool) (*domain.Account, error) {
+	return s.accountRepo.SetExcludeFromNetWorth(workspaceID, id, !include)
+}
+
+// AccountFolder holds the accounts of one group; Group is nil for the ungrouped bucket
+type AccountFolder struct {
+	Group    *domain.AccountGroup
+	Accounts []*domain.Account
+}
+
+// GetAccountsByFolder lists accounts grouped by folder
+// Folders follow group name order (empty groups included); the ungrouped bucket comes last
+func (s *AccountService) GetAccountsByFolder(workspaceID int32, opts domain.ListOptions) ([]*AccountFolder, error) {
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*domain.AccountGroup{}
+	if s.groupRepo != nil {
+		groups, err = s.groupRepo.GetAllByWorkspace(workspaceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	folders := make([]*AccountFolder, 0, len(groups)+1)
+	byGroupID := make(map[int32]*AccountFolder, len(groups))
+	for _, g := range groups {
+		folder := &AccountFolder{Group: g, Accounts: []*domain.Account{}}
+		folders = append(folders, folder)
+		byGroupID[g.ID] = folder
+	}
+	ungrouped := &AccountFolder{Accounts: []*domain.Account{}}
+
+	for _, account := range accounts {
+		folder := ungrouped
+		if account.AccountGroupID != nil {
+			// Accounts pointing at a missing group fall back to the ungrouped bucket
+			if f, ok := byGroupID[*account.AccountGroupID]; ok {
+				folder = f
+			}
+		}
+		folder.Accounts = append(folder.Accounts, account)
+	}
+
+	return append(folders, ungrouped), nil
+}
+
 // CCOutstandingResult holds the aggregated CC outstanding data
 // including total outstanding balance and per-account breakdown
 type CCOutstandingResult struct {
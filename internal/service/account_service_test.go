@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
@@ -231,7 +232,7 @@ func TestGetAccounts_Success(t *testing.T) {
 		Name:        "Account 2",
 	})
 
-	accounts, err := accountService.GetAccounts(workspaceID, false)
+	accounts, err := accountService.GetAccounts(workspaceID, domain.ListOptions{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -247,7 +248,7 @@ func TestGetAccounts_EmptyList(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	accounts, err := accountService.GetAccounts(workspaceID, false)
+	accounts, err := accountService.GetAccounts(workspaceID, domain.ListOptions{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -257,6 +258,43 @@ func TestGetAccounts_EmptyList(t *testing.T) {
 	}
 }
 
+func TestGetAccounts_IncludeDeleted(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountService := NewAccountService(accountRepo)
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+	deletedAt := time.Now()
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Live", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: workspaceID, Name: "Closed", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, DeletedAt: &deletedAt})
+
+	accounts, err := accountService.GetAccounts(workspaceID, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].ID != 1 {
+		t.Errorf("Expected only the live account by default, got %d accounts", len(accounts))
+	}
+
+	accounts, err = accountService.GetAccounts(workspaceID, domain.ListOptions{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Errorf("Expected deleted account to be listed with IncludeDeleted, got %d accounts", len(accounts))
+	}
+
+	// Balances never include deleted accounts
+	balances, err := calculationService.CalculateAccountBalances(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := balances[2]; ok {
+		t.Error("Expected deleted account to be excluded from balances")
+	}
+}
+
 func TestGetAccountByID_Success(t *testing.T) {
 	accountRepo := testutil.NewMockAccountRepository()
 	accountService := NewAccountService(accountRepo)
@@ -443,6 +481,68 @@ func TestUpdateAccount_WrongWorkspace(t *testing.T) {
 
 // DeleteAccount tests
 
+func TestChangeAccountTemplate_EmptyAccount(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	accountService := NewAccountService(accountRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Card",
+		AccountType: domain.AccountTypeAsset,
+		Template:    domain.TemplateBank,
+	})
+
+	account, err := accountService.ChangeAccountTemplate(workspaceID, 1, domain.TemplateCreditCard)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if account.Template != domain.TemplateCreditCard {
+		t.Errorf("Expected template credit_card, got %s", account.Template)
+	}
+	if account.AccountType != domain.AccountTypeLiability {
+		t.Errorf("Expected account type liability, got %s", account.AccountType)
+	}
+
+	_, err = accountService.ChangeAccountTemplate(workspaceID, 1, domain.AccountTemplate("brokerage"))
+	if err != domain.ErrInvalidTemplate {
+		t.Errorf("Expected ErrInvalidTemplate, got %v", err)
+	}
+}
+
+func TestChangeAccountTemplate_RejectsCreditCardChangeWithTransactions(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	accountService := NewAccountService(accountRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Savings",
+		AccountType: domain.AccountTypeAsset,
+		Template:    domain.TemplateBank,
+	})
+	accountRepo.TransactionCounts = map[int32]int64{1: 3}
+
+	_, err := accountService.ChangeAccountTemplate(workspaceID, 1, domain.TemplateCreditCard)
+	if err != domain.ErrCannotChangeTemplateWithTransactions {
+		t.Errorf("Expected ErrCannotChangeTemplateWithTransactions, got %v", err)
+	}
+	if account, _ := accountRepo.GetByID(workspaceID, 1); account.Template != domain.TemplateBank || account.AccountType != domain.AccountTypeAsset {
+		t.Errorf("Expected account to stay bank/asset, got %s/%s", account.Template, account.AccountType)
+	}
+
+	// Switching between asset templates does not touch CC fields, so it is allowed
+	account, err := accountService.ChangeAccountTemplate(workspaceID, 1, domain.TemplateCash)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if account.Template != domain.TemplateCash {
+		t.Errorf("Expected template cash, got %s", account.Template)
+	}
+}
+
 func TestDeleteAccount_Success(t *testing.T) {
 	accountRepo := testutil.NewMockAccountRepository()
 	accountService := NewAccountService(accountRepo)
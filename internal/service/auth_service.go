@@ -88,6 +88,35 @@ func (s *AuthService) GetWorkspaceByAuth0ID(auth0ID string) (*domain.Workspace,
 	return s.workspaceRepo.GetByUserAuth0ID(auth0ID)
 }
 
+// ListWorkspacesForUser retrieves every workspace the user can access, with their role in each
+func (s *AuthService) ListWorkspacesForUser(auth0ID string) ([]*domain.Workspace, error) {
+	return s.workspaceRepo.ListByUserAuth0ID(auth0ID)
+}
+
+// GetSelectedWorkspace returns the requested workspace if the user has access to it.
+// Returns ErrWorkspaceNotFound when the workspace doesn't exist or isn't the user's.
+func (s *AuthService) GetSelectedWorkspace(auth0ID string, workspaceID int32) (*domain.Workspace, error) {
+	workspaces, err := s.workspaceRepo.ListByUserAuth0ID(auth0ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		if ws.ID == workspaceID {
+			return ws, nil
+		}
+	}
+	return nil, domain.ErrWorkspaceNotFound
+}
+
+// GetWorkspaceRole returns the user's role in the given workspace
+func (s *AuthService) GetWorkspaceRole(auth0ID string, workspaceID int32) (domain.WorkspaceRole, error) {
+	user, err := s.userRepo.GetByAuth0ID(auth0ID)
+	if err != nil {
+		return "", err
+	}
+	return s.workspaceRepo.GetMemberRole(workspaceID, user.ID)
+}
+
 // GetWorkspaceByID retrieves a workspace by its ID
 func (s *AuthService) GetWorkspaceByID(id int32) (*domain.Workspace, error) {
 	return s.workspaceRepo.GetByID(id)
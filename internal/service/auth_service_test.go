@@ -191,3 +191,76 @@ func TestGetWorkspaceByAuth0ID(t *testing.T) {
 		}
 	})
 }
+
+func TestListWorkspacesForUser(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	service := NewAuthService(userRepo, workspaceRepo)
+
+	auth0ID := "auth0|multi-workspace"
+	userID := uuid.New()
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: userID, Name: "Personal", Role: domain.WorkspaceRoleOwner}, auth0ID)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 2, UserID: userID, Name: "Household", Role: domain.WorkspaceRoleOwner}, auth0ID)
+
+	t.Run("lists both workspaces", func(t *testing.T) {
+		workspaces, err := service.ListWorkspacesForUser(auth0ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(workspaces) != 2 {
+			t.Fatalf("Expected 2 workspaces, got %d", len(workspaces))
+		}
+		if workspaces[0].Name != "Personal" || workspaces[1].Name != "Household" {
+			t.Errorf("Unexpected workspace names: %s, %s", workspaces[0].Name, workspaces[1].Name)
+		}
+		if workspaces[1].Role != domain.WorkspaceRoleOwner {
+			t.Errorf("Expected role owner, got %s", workspaces[1].Role)
+		}
+	})
+
+	t.Run("selects a workspace the user has access to", func(t *testing.T) {
+		found, err := service.GetSelectedWorkspace(auth0ID, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if found.ID != 2 {
+			t.Errorf("Expected workspace ID 2, got %d", found.ID)
+		}
+	})
+
+	t.Run("rejects a workspace the user cannot access", func(t *testing.T) {
+		_, err := service.GetSelectedWorkspace(auth0ID, 99)
+		if err != domain.ErrWorkspaceNotFound {
+			t.Errorf("Expected ErrWorkspaceNotFound, got %v", err)
+		}
+	})
+}
+
+func TestGetWorkspaceRole(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	service := NewAuthService(userRepo, workspaceRepo)
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|role-owner", Email: "owner@example.com"}
+	viewer := &domain.User{ID: uuid.New(), Auth0ID: "auth0|role-viewer", Email: "viewer@example.com"}
+	userRepo.AddUser(owner)
+	userRepo.AddUser(viewer)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Shared"}, owner.Auth0ID)
+	workspaceRepo.AddMember(&domain.WorkspaceMember{WorkspaceID: 1, UserID: viewer.ID, Role: domain.WorkspaceRoleViewer})
+
+	role, err := service.GetWorkspaceRole(viewer.Auth0ID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role != domain.WorkspaceRoleViewer {
+		t.Errorf("Expected role viewer, got %s", role)
+	}
+
+	role, err = service.GetWorkspaceRole(owner.Auth0ID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role != domain.WorkspaceRoleOwner {
+		t.Errorf("Expected role owner, got %s", role)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/util"
 	"github.com/shopspring/decimal"
@@ -66,7 +67,7 @@ func (s *BudgetAllocationService) SetAllocation(workspaceID int32, categoryID in
 	}
 
 	// Validate category exists and belongs to workspace
-	category, err := s.categoryRepo.GetByID(workspaceID, categoryID)
+	category, err := s.categoryRepo.GetByID(context.Background(), workspaceID, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +104,7 @@ func (s *BudgetAllocationService) SetAllocations(workspaceID int32, year, month
 		}
 
 		// Validate category exists and belongs to workspace
-		_, err := s.categoryRepo.GetByID(workspaceID, input.CategoryID)
+		_, err := s.categoryRepo.GetByID(context.Background(), workspaceID, input.CategoryID)
 		if err != nil {
 			return nil, err
 		}
@@ -129,7 +130,7 @@ func (s *BudgetAllocationService) SetAllocations(workspaceID int32, year, month
 // DeleteAllocation removes a budget allocation
 func (s *BudgetAllocationService) DeleteAllocation(workspaceID int32, categoryID int32, year, month int) error {
 	// Validate category exists and belongs to workspace
-	_, err := s.categoryRepo.GetByID(workspaceID, categoryID)
+	_, err := s.categoryRepo.GetByID(context.Background(), workspaceID, categoryID)
 	if err != nil {
 		return err
 	}
@@ -140,7 +141,7 @@ func (s *BudgetAllocationService) DeleteAllocation(workspaceID int32, categoryID
 // GetCategoryTransactions retrieves transactions for a specific category in a month
 func (s *BudgetAllocationService) GetCategoryTransactions(workspaceID int32, categoryID int32, year, month int) (*domain.CategoryTransactionsResponse, error) {
 	// Validate category exists and belongs to workspace
-	category, err := s.categoryRepo.GetByID(workspaceID, categoryID)
+	category, err := s.categoryRepo.GetByID(context.Background(), workspaceID, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +160,16 @@ func (s *BudgetAllocationService) GetCategoryTransactions(workspaceID int32, cat
 
 // GetMonthlyProgress retrieves budget progress for all categories in a month
 func (s *BudgetAllocationService) GetMonthlyProgress(workspaceID int32, year, month int) (*domain.MonthlyBudgetSummary, error) {
+	return s.getMonthlyProgress(workspaceID, year, month, false)
+}
+
+// GetMonthlyProgressRolledUp is GetMonthlyProgress with each subcategory's spending added
+// to its parent's progress. Totals still count every transaction once.
+func (s *BudgetAllocationService) GetMonthlyProgressRolledUp(workspaceID int32, year, month int) (*domain.MonthlyBudgetSummary, error) {
+	return s.getMonthlyProgress(workspaceID, year, month, true)
+}
+
+func (s *BudgetAllocationService) getMonthlyProgress(workspaceID int32, year, month int, rollUp bool) (*domain.MonthlyBudgetSummary, error) {
 	// Check if this month has any allocations (lazy initialization check)
 	existingCount, err := s.allocationRepo.CountAllocationsForMonth(workspaceID, year, month)
 	if err != nil {
@@ -204,45 +215,24 @@ func (s *BudgetAllocationService) GetMonthlyProgress(workspaceID int32, year, mo
 	totalAllocated := decimal.Zero
 	totalSpent := decimal.Zero
 
-	hundred := decimal.NewFromInt(100)
-	eightyPercent := decimal.NewFromInt(80)
-
 	for _, alloc := range allocations {
 		spent := spentMap[alloc.CategoryID]
 		if spent.IsZero() {
 			spent = decimal.Zero
 		}
 
-		remaining := alloc.Allocated.Sub(spent)
-
-		var percentage decimal.Decimal
-		if alloc.Allocated.IsPositive() {
-			percentage = spent.Div(alloc.Allocated).Mul(hundred)
-		} else {
-			percentage = decimal.Zero
-		}
-
-		status := domain.BudgetStatusHealthy
-		if percentage.GreaterThanOrEqual(hundred) {
-			status = domain.BudgetStatusOver
-		} else if percentage.GreaterThanOrEqual(eightyPercent) {
-			status = domain.BudgetStatusWarning
-		}
-
-		categories = append(categories, &domain.BudgetProgress{
-			CategoryID:   alloc.CategoryID,
-			CategoryName: alloc.CategoryName,
-			Allocated:    alloc.Allocated,
-			Spent:        spent,
-			Remaining:    remaining,
-			Percentage:   percentage.Round(2),
-			Status:       status,
-		})
+		categories = append(categories, newBudgetProgress(alloc.CategoryID, alloc.CategoryName, alloc.Allocated, spent))
 
 		totalAllocated = totalAllocated.Add(alloc.Allocated)
 		totalSpent = totalSpent.Add(spent)
 	}
 
+	if rollUp {
+		if err := s.rollUpSpending(workspaceID, categories, spentMap); err != nil {
+			return nil, err
+		}
+	}
+
 	return &domain.MonthlyBudgetSummary{
 		Year:                    year,
 		Month:                   month,
@@ -255,3 +245,57 @@ func (s *BudgetAllocationService) GetMonthlyProgress(workspaceID int32, year, mo
 		IsHistorical:            util.IsHistoricalMonth(year, month),
 	}, nil
 }
+
+// rollUpSpending adds each subcategory's spending to its parent's progress entry
+func (s *BudgetAllocationService) rollUpSpending(workspaceID int32, categories []*domain.BudgetProgress, spentMap map[int32]decimal.Decimal) error {
+	all, err := s.categoryRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	childSpent := make(map[int32]decimal.Decimal)
+	for _, category := range all {
+		if category.ParentID != nil {
+			childSpent[*category.ParentID] = childSpent[*category.ParentID].Add(spentMap[category.ID])
+		}
+	}
+
+	for i, progress := range categories {
+		extra, ok := childSpent[progress.CategoryID]
+		if !ok || extra.IsZero() {
+			continue
+		}
+		categories[i] = newBudgetProgress(progress.CategoryID, progress.CategoryName, progress.Allocated, progress.Spent.Add(extra))
+	}
+	return nil
+}
+
+// newBudgetProgress computes remaining, percentage and status for a category's spending
+func newBudgetProgress(categoryID int32, categoryName string, allocated, spent decimal.Decimal) *domain.BudgetProgress {
+	hundred := decimal.NewFromInt(100)
+	eightyPercent := decimal.NewFromInt(80)
+
+	var percentage decimal.Decimal
+	if allocated.IsPositive() {
+		percentage = spent.Div(allocated).Mul(hundred)
+	} else {
+		percentage = decimal.Zero
+	}
+
+	status := domain.BudgetStatusHealthy
+	if percentage.GreaterThanOrEqual(hundred) {
+		status = domain.BudgetStatusOver
+	} else if percentage.GreaterThanOrEqual(eightyPercent) {
+		status = domain.BudgetStatusWarning
+	}
+
+	return &domain.BudgetProgress{
+		CategoryID:   categoryID,
+		CategoryName: categoryName,
+		Allocated:    allocated,
+		Spent:        spent,
+		Remaining:    allocated.Sub(spent),
+		Percentage:   percentage.Round(2),
+		Status:       status,
+	}
+}
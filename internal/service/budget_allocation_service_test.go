@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
@@ -801,3 +802,99 @@ func TestGetMonthlyProgress_YearBoundaryCopy(t *testing.T) {
 		t.Error("expected CopiedFromPreviousMonth to be true")
 	}
 }
+
+func TestGetMonthlyProgress_RefundReducesCategorySpend(t *testing.T) {
+	allocationRepo := testutil.NewMockBudgetAllocationRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	service := NewBudgetAllocationService(allocationRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	year := 2026
+	month := 3
+	foodID := int32(1)
+	date := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	allocationRepo.SetCategoriesWithAllocations(workspaceID, year, month, []*domain.BudgetCategoryWithAllocation{
+		{CategoryID: foodID, CategoryName: "Food & Dining", Allocated: decimal.NewFromInt(500)},
+	})
+	allocationRepo.SetSpendingFromTransactions(workspaceID, year, month, []*domain.Transaction{
+		{ID: 1, Name: "Restaurant", Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeExpense, TransactionDate: date, CategoryID: &foodID},
+		{ID: 2, Name: "Restaurant refund", Amount: decimal.NewFromInt(80), Type: domain.TransactionTypeIncome, TransactionDate: date, CategoryID: &foodID, IsRefund: true},
+		// Plain income in the same category is not spending
+		{ID: 3, Name: "Cashback", Amount: decimal.NewFromInt(10), Type: domain.TransactionTypeIncome, TransactionDate: date, CategoryID: &foodID},
+	})
+
+	result, err := service.GetMonthlyProgress(workspaceID, year, month)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(result.Categories) != 1 {
+		t.Fatalf("expected 1 category, got %d", len(result.Categories))
+	}
+	expectedSpent := decimal.NewFromInt(120)
+	if !result.Categories[0].Spent.Equal(expectedSpent) {
+		t.Errorf("expected net spent %s, got %s", expectedSpent.String(), result.Categories[0].Spent.String())
+	}
+	expectedRemaining := decimal.NewFromInt(380)
+	if !result.TotalRemaining.Equal(expectedRemaining) {
+		t.Errorf("expected total remaining %s, got %s", expectedRemaining.String(), result.TotalRemaining.String())
+	}
+}
+
+func TestGetMonthlyProgressRolledUp_AddsChildSpendingToParent(t *testing.T) {
+	allocationRepo := testutil.NewMockBudgetAllocationRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	service := NewBudgetAllocationService(allocationRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	year := 2026
+	month := 1
+
+	parentID := int32(1)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 1, WorkspaceID: workspaceID, Name: "Food"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 2, WorkspaceID: workspaceID, Name: "Groceries", ParentID: &parentID})
+
+	allocationRepo.SetCategoriesWithAllocations(workspaceID, year, month, []*domain.BudgetCategoryWithAllocation{
+		{CategoryID: 1, CategoryName: "Food", Allocated: decimal.NewFromInt(1000)},
+		{CategoryID: 2, CategoryName: "Groceries", Allocated: decimal.NewFromInt(500)},
+	})
+	allocationRepo.SetSpendingByCategory(workspaceID, year, month, []*domain.CategorySpending{
+		{CategoryID: 1, Spent: decimal.NewFromInt(300)},
+		{CategoryID: 2, Spent: decimal.NewFromInt(600)},
+	})
+
+	result, err := service.GetMonthlyProgressRolledUp(workspaceID, year, month)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, progress := range result.Categories {
+		switch progress.CategoryID {
+		case 1:
+			if !progress.Spent.Equal(decimal.NewFromInt(900)) {
+				t.Errorf("expected parent spent 900, got %s", progress.Spent.String())
+			}
+			if progress.Status != domain.BudgetStatusWarning {
+				t.Errorf("expected parent status warning, got %s", progress.Status)
+			}
+		case 2:
+			if !progress.Spent.Equal(decimal.NewFromInt(600)) {
+				t.Errorf("expected child spent 600, got %s", progress.Spent.String())
+			}
+		}
+	}
+
+	// Totals count each transaction once
+	if !result.TotalSpent.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("expected total spent 900, got %s", result.TotalSpent.String())
+	}
+
+	plain, err := service.GetMonthlyProgress(workspaceID, year, month)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !plain.Categories[0].Spent.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("expected unrolled parent spent 300, got %s", plain.Categories[0].Spent.String())
+	}
+}
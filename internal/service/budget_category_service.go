@@ -1,14 +1,19 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 )
 
 // BudgetCategoryService handles budget category business logic
 type BudgetCategoryService struct {
-	categoryRepo domain.BudgetCategoryRepository
+	categoryRepo    domain.BudgetCategoryRepository
+	transactionRepo domain.TransactionRepository
 }
 
 // NewBudgetCategoryService creates a new BudgetCategoryService
@@ -16,20 +21,40 @@ func NewBudgetCategoryService(categoryRepo domain.BudgetCategoryRepository) *Bud
 	return &BudgetCategoryService{categoryRepo: categoryRepo}
 }
 
+// SetTransactionRepository sets the transaction repository used for category drill-down
+func (s *BudgetCategoryService) SetTransactionRepository(transactionRepo domain.TransactionRepository) {
+	s.transactionRepo = transactionRepo
+}
+
+// BudgetCategoryInput contains input for creating or updating a budget category
+type BudgetCategoryInput struct {
+	Name            string
+	Description     *string
+	Color           *string
+	ParentID        *int32 // Optional parent; must be a top-level category
+	IsTaxDeductible *bool  // Default for new transactions in the category; nil is off on create and unchanged on update
+	// Update only: keep the stored description, color or parent instead of applying the field above
+	KeepDescription bool
+	KeepColor       bool
+	KeepParent      bool
+}
+
 // CreateCategory creates a new budget category
-func (s *BudgetCategoryService) CreateCategory(workspaceID int32, name string) (*domain.BudgetCategory, error) {
-	// Validate name
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return nil, domain.ErrNameRequired
+func (s *BudgetCategoryService) CreateCategory(workspaceID int32, input BudgetCategoryInput) (*domain.BudgetCategory, error) {
+	if err := validateBudgetCategoryInput(&input); err != nil {
+		return nil, err
 	}
-	if len(name) > domain.MaxBudgetCategoryNameLength {
-		return nil, domain.ErrNameTooLong
+	if err := s.validateParent(workspaceID, 0, input.ParentID); err != nil {
+		return nil, err
 	}
 
 	category := &domain.BudgetCategory{
-		WorkspaceID: workspaceID,
-		Name:        name,
+		WorkspaceID:     workspaceID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Color:           input.Color,
+		ParentID:        input.ParentID,
+		IsTaxDeductible: input.IsTaxDeductible != nil && *input.IsTaxDeductible,
 	}
 
 	return s.categoryRepo.Create(category)
@@ -40,35 +65,174 @@ func (s *BudgetCategoryService) GetCategories(workspaceID int32) ([]*domain.Budg
 	return s.categoryRepo.GetAllByWorkspace(workspaceID)
 }
 
+// ListCategoriesWithUsage retrieves all categories with their transaction count and net spend,
+// including unused ones, so they can be pruned
+func (s *BudgetCategoryService) ListCategoriesWithUsage(workspaceID int32) ([]domain.CategoryUsage, error) {
+	return s.categoryRepo.GetAllWithUsage(workspaceID)
+}
+
 // GetCategoryByID retrieves a budget category by ID within a workspace
 func (s *BudgetCategoryService) GetCategoryByID(workspaceID int32, id int32) (*domain.BudgetCategory, error) {
-	return s.categoryRepo.GetByID(workspaceID, id)
+	return s.categoryRepo.GetByID(context.Background(), workspaceID, id)
+}
+
+// UpdateCategory updates a budget category's name, description, color and parent.
+// A nil Description, Color or ParentID clears the stored value unless its Keep flag is set;
+// a nil IsTaxDeductible keeps it.
+func (s *BudgetCategoryService) UpdateCategory(workspaceID int32, id int32, input BudgetCategoryInput) (*domain.BudgetCategory, error) {
+	if err := validateBudgetCategoryInput(&input); err != nil {
+		return nil, err
+	}
+
+	if input.IsTaxDeductible == nil || input.KeepDescription || input.KeepColor || input.KeepParent {
+		existing, err := s.categoryRepo.GetByID(context.Background(), workspaceID, id)
+		if err != nil {
+			return nil, err
+		}
+		if input.IsTaxDeductible == nil {
+			input.IsTaxDeductible = &existing.IsTaxDeductible
+		}
+		if input.KeepDescription {
+			input.Description = existing.Description
+		}
+		if input.KeepColor {
+			input.Color = existing.Color
+		}
+		if input.KeepParent {
+			input.ParentID = existing.ParentID
+		}
+	}
+
+	if err := s.validateParent(workspaceID, id, input.ParentID); err != nil {
+		return nil, err
+	}
+
+	return s.categoryRepo.Update(&domain.BudgetCategory{
+		ID:              id,
+		WorkspaceID:     workspaceID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Color:           input.Color,
+		ParentID:        input.ParentID,
+		IsTaxDeductible: *input.IsTaxDeductible,
+	})
 }
 
-// UpdateCategory updates a budget category's name
-func (s *BudgetCategoryService) UpdateCategory(workspaceID int32, id int32, name string) (*domain.BudgetCategory, error) {
-	// Validate name
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return nil, domain.ErrNameRequired
+// GetCategoryTree returns top-level categories with their subcategories, both sorted by name.
+// A category whose parent is missing is listed at the top level.
+func (s *BudgetCategoryService) GetCategoryTree(workspaceID int32) ([]*domain.BudgetCategoryNode, error) {
+	categories, err := s.categoryRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int32]*domain.BudgetCategoryNode)
+	for _, category := range categories {
+		if category.ParentID == nil {
+			nodes[category.ID] = &domain.BudgetCategoryNode{Category: category, Children: []*domain.BudgetCategory{}}
+		}
 	}
-	if len(name) > domain.MaxBudgetCategoryNameLength {
-		return nil, domain.ErrNameTooLong
+
+	tree := make([]*domain.BudgetCategoryNode, 0, len(nodes))
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+			tree = append(tree, &domain.BudgetCategoryNode{Category: category, Children: []*domain.BudgetCategory{}})
+			continue
+		}
+		tree = append(tree, nodes[category.ID])
 	}
 
-	return s.categoryRepo.Update(workspaceID, id, name)
+	sort.SliceStable(tree, func(i, j int) bool {
+		return tree[i].Category.Name < tree[j].Category.Name
+	})
+	return tree, nil
+}
+
+// validateParent enforces a single level of nesting: the parent must exist, differ from
+// the category, and be top-level, and a category with children cannot become a child.
+// id is 0 when creating a new category.
+func (s *BudgetCategoryService) validateParent(workspaceID, id int32, parentID *int32) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		return domain.ErrInvalidCategoryHierarchy
+	}
+
+	parent, err := s.categoryRepo.GetByID(context.Background(), workspaceID, *parentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBudgetCategoryNotFound) {
+			return domain.ErrInvalidCategoryHierarchy
+		}
+		return err
+	}
+	if parent.ParentID != nil {
+		return domain.ErrInvalidCategoryHierarchy
+	}
+
+	if id == 0 {
+		return nil
+	}
+	categories, err := s.categoryRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if category.ParentID != nil && *category.ParentID == id {
+			return domain.ErrInvalidCategoryHierarchy
+		}
+	}
+	return nil
 }
 
 // DeleteCategory soft-deletes a budget category
 func (s *BudgetCategoryService) DeleteCategory(workspaceID int32, id int32) error {
 	// Verify category exists before deleting
-	_, err := s.categoryRepo.GetByID(workspaceID, id)
+	_, err := s.categoryRepo.GetByID(context.Background(), workspaceID, id)
 	if err != nil {
 		return err
 	}
 	return s.categoryRepo.SoftDelete(workspaceID, id)
 }
 
+// GetCategoryTransactions returns every transaction in a category from the start of startMonth
+// through the end of endMonth (both "YYYY-MM"), for drilling into a category's spending
+func (s *BudgetCategoryService) GetCategoryTransactions(workspaceID, categoryID int32, startMonth, endMonth string) ([]*domain.Transaction, error) {
+	start, err := time.Parse("2006-01", startMonth)
+	if err != nil {
+		return nil, domain.ErrInvalidMonthFormat
+	}
+	endStart, err := time.Parse("2006-01", endMonth)
+	if err != nil {
+		return nil, domain.ErrInvalidMonthFormat
+	}
+	if endStart.Before(start) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	// Verify category exists and belongs to the workspace
+	if _, err := s.categoryRepo.GetByID(context.Background(), workspaceID, categoryID); err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, start, endStart.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*domain.Transaction{}
+	for _, txn := range transactions {
+		if txn.CategoryID != nil && *txn.CategoryID == categoryID {
+			result = append(result, txn)
+		}
+	}
+	return result, nil
+}
+
 // CanDeleteResponse contains information about whether a category can be safely deleted
 type CanDeleteResponse struct {
 	HasTransactions  bool  `json:"hasTransactions"`
@@ -78,7 +242,7 @@ type CanDeleteResponse struct {
 // CanDelete checks if a budget category can be safely deleted (no transactions assigned)
 func (s *BudgetCategoryService) CanDelete(workspaceID int32, id int32) (*CanDeleteResponse, error) {
 	// Verify category exists
-	_, err := s.categoryRepo.GetByID(workspaceID, id)
+	_, err := s.categoryRepo.GetByID(context.Background(), workspaceID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -94,3 +258,39 @@ func (s *BudgetCategoryService) CanDelete(workspaceID int32, id int32) (*CanDele
 		TransactionCount: 0, // Will be populated after Story 4.2
 	}, nil
 }
+
+// validateBudgetCategoryInput trims and validates the input in place.
+// Empty description/color strings are normalized to nil.
+func validateBudgetCategoryInput(input *BudgetCategoryInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return domain.ErrNameRequired
+	}
+	if len(input.Name) > domain.MaxBudgetCategoryNameLength {
+		return domain.ErrNameTooLong
+	}
+
+	if input.Description != nil {
+		desc := strings.TrimSpace(*input.Description)
+		if desc == "" {
+			input.Description = nil
+		} else if len(desc) > domain.MaxBudgetCategoryDescLength {
+			return domain.ErrDescriptionTooLong
+		} else {
+			input.Description = &desc
+		}
+	}
+
+	if input.Color != nil {
+		color := strings.TrimSpace(*input.Color)
+		if color == "" {
+			input.Color = nil
+		} else if !domain.IsValidColor(color) {
+			return domain.ErrInvalidColor
+		} else {
+			input.Color = &color
+		}
+	}
+
+	return nil
+}
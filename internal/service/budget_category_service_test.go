@@ -3,9 +3,11 @@ package service
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/shopspring/decimal"
 )
 
 func TestCreateCategory_Success(t *testing.T) {
@@ -15,7 +17,7 @@ func TestCreateCategory_Success(t *testing.T) {
 	workspaceID := int32(1)
 	name := "Groceries"
 
-	category, err := categoryService.CreateCategory(workspaceID, name)
+	category, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: name})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -35,7 +37,7 @@ func TestCreateCategory_EmptyName(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	_, err := categoryService.CreateCategory(workspaceID, "")
+	_, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: ""})
 	if err == nil {
 		t.Fatal("Expected error for empty name, got nil")
 	}
@@ -51,7 +53,7 @@ func TestCreateCategory_WhitespaceOnlyName(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	_, err := categoryService.CreateCategory(workspaceID, "   ")
+	_, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "   "})
 	if err == nil {
 		t.Fatal("Expected error for whitespace-only name, got nil")
 	}
@@ -67,7 +69,7 @@ func TestCreateCategory_TrimsName(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	category, err := categoryService.CreateCategory(workspaceID, "  Groceries  ")
+	category, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "  Groceries  "})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -86,7 +88,7 @@ func TestCreateCategory_NameTooLong(t *testing.T) {
 	// Create a name longer than MaxBudgetCategoryNameLength (100)
 	longName := strings.Repeat("a", 101)
 
-	_, err := categoryService.CreateCategory(workspaceID, longName)
+	_, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: longName})
 	if err != domain.ErrNameTooLong {
 		t.Errorf("Expected ErrNameTooLong, got %v", err)
 	}
@@ -99,13 +101,13 @@ func TestCreateCategory_DuplicateName(t *testing.T) {
 	workspaceID := int32(1)
 
 	// Create first category
-	_, err := categoryService.CreateCategory(workspaceID, "Groceries")
+	_, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Groceries"})
 	if err != nil {
 		t.Fatalf("Expected no error for first create, got %v", err)
 	}
 
 	// Try to create duplicate
-	_, err = categoryService.CreateCategory(workspaceID, "Groceries")
+	_, err = categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Groceries"})
 	if err != domain.ErrBudgetCategoryAlreadyExists {
 		t.Errorf("Expected ErrBudgetCategoryAlreadyExists, got %v", err)
 	}
@@ -170,7 +172,7 @@ func TestGetCategories_ExcludesSoftDeleted(t *testing.T) {
 	_ = categoryService.DeleteCategory(workspaceID, 1)
 
 	// Create an active category
-	_, _ = categoryService.CreateCategory(workspaceID, "Transport")
+	_, _ = categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Transport"})
 
 	categories, err := categoryService.GetCategories(workspaceID)
 	if err != nil {
@@ -250,7 +252,7 @@ func TestUpdateCategory_Success(t *testing.T) {
 		Name:        "Old Name",
 	})
 
-	category, err := categoryService.UpdateCategory(workspaceID, 1, "New Name")
+	category, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{Name: "New Name"})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -271,7 +273,7 @@ func TestUpdateCategory_TrimsName(t *testing.T) {
 		Name:        "Old Name",
 	})
 
-	category, err := categoryService.UpdateCategory(workspaceID, 1, "  New Name  ")
+	category, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{Name: "  New Name  "})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -292,7 +294,7 @@ func TestUpdateCategory_EmptyName(t *testing.T) {
 		Name:        "Old Name",
 	})
 
-	_, err := categoryService.UpdateCategory(workspaceID, 1, "")
+	_, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{Name: ""})
 	if err != domain.ErrNameRequired {
 		t.Errorf("Expected ErrNameRequired, got %v", err)
 	}
@@ -304,12 +306,99 @@ func TestUpdateCategory_NotFound(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	_, err := categoryService.UpdateCategory(workspaceID, 999, "New Name")
+	_, err := categoryService.UpdateCategory(workspaceID, 999, BudgetCategoryInput{Name: "New Name"})
 	if err != domain.ErrBudgetCategoryNotFound {
 		t.Errorf("Expected ErrBudgetCategoryNotFound, got %v", err)
 	}
 }
 
+func TestCreateCategory_ValidColor(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	color := "#1A2b3C"
+	description := "  Weekly shopping  "
+
+	category, err := categoryService.CreateCategory(1, BudgetCategoryInput{
+		Name:        "Groceries",
+		Description: &description,
+		Color:       &color,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if category.Color == nil || *category.Color != "#1A2b3C" {
+		t.Errorf("Expected color '#1A2b3C', got %v", category.Color)
+	}
+	if category.Description == nil || *category.Description != "Weekly shopping" {
+		t.Errorf("Expected trimmed description 'Weekly shopping', got %v", category.Description)
+	}
+}
+
+func TestCreateCategory_InvalidColor(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	for _, color := range []string{"red", "#FFF", "#GGGGGG", "123456", "#1234567"} {
+		c := color
+		_, err := categoryService.CreateCategory(1, BudgetCategoryInput{Name: "Groceries", Color: &c})
+		if err != domain.ErrInvalidColor {
+			t.Errorf("Expected ErrInvalidColor for %q, got %v", color, err)
+		}
+	}
+}
+
+func TestUpdateCategory_InvalidColor(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Groceries",
+	})
+
+	color := "blue"
+	_, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{Name: "Groceries", Color: &color})
+	if err != domain.ErrInvalidColor {
+		t.Errorf("Expected ErrInvalidColor, got %v", err)
+	}
+}
+
+func TestUpdateCategory_ClearsDescriptionWithNull(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	description := "Old description"
+	color := "#00FF00"
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Groceries",
+		Description: &description,
+		Color:       &color,
+	})
+
+	category, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{
+		Name:        "Groceries",
+		Description: nil,
+		Color:       &color,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if category.Description != nil {
+		t.Errorf("Expected description to be cleared, got %q", *category.Description)
+	}
+	if category.Color == nil || *category.Color != "#00FF00" {
+		t.Errorf("Expected color to be kept, got %v", category.Color)
+	}
+}
+
 func TestDeleteCategory_Success(t *testing.T) {
 	categoryRepo := testutil.NewMockBudgetCategoryRepository()
 	categoryService := NewBudgetCategoryService(categoryRepo)
@@ -381,3 +470,248 @@ func TestCanDelete_NotFound(t *testing.T) {
 		t.Errorf("Expected ErrBudgetCategoryNotFound, got %v", err)
 	}
 }
+
+func TestCreateCategory_WithParent(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 10, WorkspaceID: workspaceID, Name: "Food"})
+
+	parentID := int32(10)
+	category, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Groceries", ParentID: &parentID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if category.ParentID == nil || *category.ParentID != 10 {
+		t.Errorf("Expected parent ID 10, got %v", category.ParentID)
+	}
+
+	tree, err := categoryService.GetCategoryTree(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tree) != 1 {
+		t.Fatalf("Expected 1 top-level category, got %d", len(tree))
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Name != "Groceries" {
+		t.Errorf("Expected Groceries nested under Food, got %+v", tree[0].Children)
+	}
+}
+
+func TestUpdateCategory_RejectsCycle(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	parentID := int32(10)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 10, WorkspaceID: workspaceID, Name: "Food"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 11, WorkspaceID: workspaceID, Name: "Groceries", ParentID: &parentID})
+
+	// Own parent
+	selfID := int32(10)
+	_, err := categoryService.UpdateCategory(workspaceID, 10, BudgetCategoryInput{Name: "Food", ParentID: &selfID})
+	if err != domain.ErrInvalidCategoryHierarchy {
+		t.Errorf("Expected ErrInvalidCategoryHierarchy for self-parent, got %v", err)
+	}
+
+	// Food -> Groceries -> Food
+	childID := int32(11)
+	_, err = categoryService.UpdateCategory(workspaceID, 10, BudgetCategoryInput{Name: "Food", ParentID: &childID})
+	if err != domain.ErrInvalidCategoryHierarchy {
+		t.Errorf("Expected ErrInvalidCategoryHierarchy for cycle, got %v", err)
+	}
+}
+
+func TestCreateCategory_RejectsSecondLevel(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	parentID := int32(10)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 10, WorkspaceID: workspaceID, Name: "Food"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 11, WorkspaceID: workspaceID, Name: "Groceries", ParentID: &parentID})
+
+	childID := int32(11)
+	_, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Produce", ParentID: &childID})
+	if err != domain.ErrInvalidCategoryHierarchy {
+		t.Errorf("Expected ErrInvalidCategoryHierarchy, got %v", err)
+	}
+}
+
+func TestListCategoriesWithUsage(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 1, WorkspaceID: workspaceID, Name: "Groceries"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: 2, WorkspaceID: workspaceID, Name: "Hobbies"})
+	categoryRepo.Usage[1] = domain.CategoryUsage{TransactionCount: 3, TotalSpent: decimal.NewFromFloat(245.50)}
+
+	usages, err := categoryService.ListCategoriesWithUsage(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(usages) != 2 {
+		t.Fatalf("Expected 2 categories including the unused one, got %d", len(usages))
+	}
+
+	byName := make(map[string]domain.CategoryUsage)
+	for _, u := range usages {
+		byName[u.Category.Name] = u
+	}
+
+	used := byName["Groceries"]
+	if used.TransactionCount != 3 || !used.TotalSpent.Equal(decimal.NewFromFloat(245.50)) {
+		t.Errorf("Expected Groceries x3 totalling 245.50, got x%d totalling %s", used.TransactionCount, used.TotalSpent)
+	}
+
+	unused := byName["Hobbies"]
+	if unused.TransactionCount != 0 || !unused.TotalSpent.IsZero() {
+		t.Errorf("Expected unused Hobbies to report zero, got x%d totalling %s", unused.TransactionCount, unused.TotalSpent)
+	}
+}
+
+func TestGetCategoryTransactionsInRange_AcrossMonths(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+	categoryService.SetTransactionRepository(transactionRepo)
+
+	workspaceID := int32(1)
+	groceries, _ := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Groceries"})
+	dining, _ := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Dining"})
+
+	dates := []time.Time{
+		time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	for i, date := range dates {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID: int32(i + 1), WorkspaceID: workspaceID, Name: "Market",
+			Amount: decimal.NewFromInt(50), TransactionDate: date, CategoryID: &groceries.ID,
+		})
+	}
+	// Other category, uncategorized, and outside the range
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 10, WorkspaceID: workspaceID, Name: "Restaurant",
+		Amount: decimal.NewFromInt(30), TransactionDate: dates[1], CategoryID: &dining.ID,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 11, WorkspaceID: workspaceID, Name: "Misc",
+		Amount: decimal.NewFromInt(10), TransactionDate: dates[1],
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 12, WorkspaceID: workspaceID, Name: "Market",
+		Amount: decimal.NewFromInt(50), TransactionDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), CategoryID: &groceries.ID,
+	})
+
+	transactions, err := categoryService.GetCategoryTransactions(workspaceID, groceries.ID, "2026-01", "2026-03")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+	for _, tx := range transactions {
+		if tx.CategoryID == nil || *tx.CategoryID != groceries.ID {
+			t.Errorf("Expected only Groceries transactions, got transaction %d", tx.ID)
+		}
+	}
+}
+
+func TestGetCategoryTransactionsInRange_CategoryNotFound(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+	categoryService.SetTransactionRepository(testutil.NewMockTransactionRepository())
+
+	_, err := categoryService.GetCategoryTransactions(1, 999, "2026-01", "2026-03")
+	if err != domain.ErrBudgetCategoryNotFound {
+		t.Errorf("Expected ErrBudgetCategoryNotFound, got %v", err)
+	}
+}
+
+func TestGetCategoryTransactionsInRange_InvalidRange(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+	categoryService.SetTransactionRepository(testutil.NewMockTransactionRepository())
+
+	workspaceID := int32(1)
+	category, _ := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Groceries"})
+
+	_, err := categoryService.GetCategoryTransactions(workspaceID, category.ID, "2026-03", "2026-01")
+	if err != domain.ErrInvalidDateRange {
+		t.Errorf("Expected ErrInvalidDateRange, got %v", err)
+	}
+
+	_, err = categoryService.GetCategoryTransactions(workspaceID, category.ID, "2026-1", "2026-03")
+	if err != domain.ErrInvalidMonthFormat {
+		t.Errorf("Expected ErrInvalidMonthFormat, got %v", err)
+	}
+}
+
+func TestUpdateCategory_KeepFlagsKeepStoredValues(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	description := "Weekly shop"
+	color := "#00FF00"
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Groceries",
+		Description: &description,
+		Color:       &color,
+	})
+
+	category, err := categoryService.UpdateCategory(workspaceID, 1, BudgetCategoryInput{
+		Name:            "Supermarket",
+		KeepDescription: true,
+		KeepColor:       true,
+		KeepParent:      true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if category.Name != "Supermarket" {
+		t.Errorf("Expected name 'Supermarket', got %s", category.Name)
+	}
+	if category.Description == nil || *category.Description != description {
+		t.Errorf("Expected description to be kept, got %v", category.Description)
+	}
+	if category.Color == nil || *category.Color != color {
+		t.Errorf("Expected color to be kept, got %v", category.Color)
+	}
+}
+
+func TestUpdateCategory_OmittedTaxDeductibleKeepsFlag(t *testing.T) {
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	categoryService := NewBudgetCategoryService(categoryRepo)
+
+	workspaceID := int32(1)
+	deductible := true
+	category, err := categoryService.CreateCategory(workspaceID, BudgetCategoryInput{Name: "Medical", IsTaxDeductible: &deductible})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, err := categoryService.UpdateCategory(workspaceID, category.ID, BudgetCategoryInput{Name: "Health"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !updated.IsTaxDeductible {
+		t.Error("Expected omitted IsTaxDeductible to keep the stored flag")
+	}
+
+	notDeductible := false
+	updated, err = categoryService.UpdateCategory(workspaceID, category.ID, BudgetCategoryInput{Name: "Health", IsTaxDeductible: &notDeductible})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.IsTaxDeductible {
+		t.Error("Expected explicit false to clear the flag")
+	}
+}
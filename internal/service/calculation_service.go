@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/shopspring/decimal"
 )
@@ -25,12 +28,13 @@ type AccountBalanceResult struct {
 	InitialBalance    decimal.Decimal
 	CalculatedBalance decimal.Decimal
 	CCOutstanding     decimal.Decimal
+	IncludeInNetWorth bool
 }
 
 // CalculateAccountBalances calculates balances for all accounts in a workspace
 func (s *CalculationService) CalculateAccountBalances(workspaceID int32) (map[int32]*AccountBalanceResult, error) {
 	// Get all accounts
-	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, false)
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, domain.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +57,9 @@ func (s *CalculationService) CalculateAccountBalances(workspaceID int32) (map[in
 		summary := summaryMap[account.ID]
 
 		result := &AccountBalanceResult{
-			AccountID:      account.ID,
-			InitialBalance: account.InitialBalance,
+			AccountID:         account.ID,
+			InitialBalance:    account.InitialBalance,
+			IncludeInNetWorth: account.IncludeInNetWorth(),
 		}
 
 		if summary != nil {
@@ -82,6 +87,24 @@ func (s *CalculationService) CalculateAccountBalances(workspaceID int32) (map[in
 	return results, nil
 }
 
+// ComputeNetWorth sums the calculated balances of all accounts that count towards net worth
+// Liability balances are negative when debt exists, so they reduce the total
+func (s *CalculationService) ComputeNetWorth(workspaceID int32) (decimal.Decimal, error) {
+	balances, err := s.CalculateAccountBalances(workspaceID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	total := decimal.Zero
+	for _, balance := range balances {
+		if !balance.IncludeInNetWorth {
+			continue
+		}
+		total = total.Add(balance.CalculatedBalance)
+	}
+	return total, nil
+}
+
 // CalculateAccountBalance calculates the balance for a single account
 func (s *CalculationService) CalculateAccountBalance(workspaceID, accountID int32) (*AccountBalanceResult, error) {
 	// Get the account
@@ -128,3 +151,82 @@ func (s *CalculationService) CalculateAccountBalance(workspaceID, accountID int3
 
 	return result, nil
 }
+
+// GetBalanceAsOf calculates an account's balance from transactions dated on or before asOf,
+// using the same rules as CalculateAccountBalance (for bank statement reconciliation)
+func (s *CalculationService) GetBalanceAsOf(workspaceID, accountID int32, asOf time.Time) (decimal.Decimal, error) {
+	return s.balanceAsOf(workspaceID, accountID, asOf, false)
+}
+
+// GetSettledBalanceAsOf is GetBalanceAsOf without unsettled CC purchases, matching what the
+// card issuer has posted; for non-CC accounts it is the same as GetBalanceAsOf
+func (s *CalculationService) GetSettledBalanceAsOf(workspaceID, accountID int32, asOf time.Time) (decimal.Decimal, error) {
+	return s.balanceAsOf(workspaceID, accountID, asOf, true)
+}
+
+// Reconcile compares the account's balance as of asOf with an externally reported balance
+// (e.g. a bank statement). When adjust is set and the two differ, a paid correction
+// transaction dated asOf is created so the computed balance matches the external one.
+func (s *CalculationService) Reconcile(workspaceID, accountID int32, externalBalance decimal.Decimal, asOf time.Time, adjust bool) (*domain.ReconcileResult, error) {
+	computed, err := s.GetBalanceAsOf(workspaceID, accountID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ReconcileResult{
+		AccountID:       accountID,
+		AsOf:            asOf,
+		ComputedBalance: computed,
+		ExternalBalance: externalBalance,
+		Difference:      externalBalance.Sub(computed),
+	}
+	if !adjust || result.Difference.IsZero() {
+		return result, nil
+	}
+
+	// A shortfall is booked as an expense, a surplus as income; either way it is a
+	// bookkeeping correction, so it is kept out of reports
+	txType := domain.TransactionTypeIncome
+	if result.Difference.IsNegative() {
+		txType = domain.TransactionTypeExpense
+	}
+	now := time.Now()
+	adjustment, err := s.transactionRepo.Create(context.Background(), &domain.Transaction{
+		WorkspaceID:        workspaceID,
+		AccountID:          accountID,
+		Name:               "Balance adjustment",
+		Amount:             result.Difference.Abs(),
+		Type:               txType,
+		TransactionDate:    asOf,
+		IsPaid:             true,
+		ExcludeFromReports: true,
+		Source:             "manual",
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Adjustment = adjustment
+
+	return result, nil
+}
+
+func (s *CalculationService) balanceAsOf(workspaceID, accountID int32, asOf time.Time, settledOnly bool) (decimal.Decimal, error) {
+	account, err := s.accountRepo.GetByID(workspaceID, accountID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	summary, err := s.transactionRepo.GetAccountTransactionSummaryAsOf(workspaceID, accountID, asOf)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	expenses := summary.SumExpenses
+	if account.Template == domain.TemplateCreditCard && !settledOnly {
+		expenses = summary.SumAllExpenses
+	}
+
+	return account.InitialBalance.Add(summary.SumIncome).Sub(expenses), nil
+}
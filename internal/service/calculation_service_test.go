@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
@@ -442,3 +443,329 @@ func TestCalculateAccountBalances_NonCCDoesNotHaveOutstanding(t *testing.T) {
 		t.Errorf("Expected CCOutstanding to be zero for non-CC account, got %s", result.CCOutstanding.String())
 	}
 }
+
+func TestCalculateAccountBalances_ExcludesFutureScheduled(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromFloat(1000.00),
+	})
+
+	// Scheduled rent payment due next week
+	scheduled := &domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Rent",
+		Amount:          decimal.NewFromFloat(400.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now().AddDate(0, 0, 7),
+		IsPaid:          true,
+		IsScheduled:     true,
+	}
+	transactionRepo.AddTransaction(scheduled)
+
+	results, err := calculationService.CalculateAccountBalances(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !results[1].CalculatedBalance.Equal(decimal.NewFromFloat(1000.00)) {
+		t.Errorf("Expected future scheduled transaction to be excluded (1000.00), got %s", results[1].CalculatedBalance.String())
+	}
+
+	// Once the date passes the transaction counts, even before promotion
+	scheduled.TransactionDate = time.Now().AddDate(0, 0, -1)
+
+	result, err := calculationService.CalculateAccountBalance(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.CalculatedBalance.Equal(decimal.NewFromFloat(600.00)) {
+		t.Errorf("Expected due scheduled transaction to be included (600.00), got %s", result.CalculatedBalance.String())
+	}
+}
+
+func TestCalculateAccountBalances_IncludesUnscheduledFutureTransactions(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromFloat(1000.00),
+	})
+
+	// Future-dated but not scheduled keeps existing behavior
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Prepaid",
+		Amount:          decimal.NewFromFloat(100.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now().AddDate(0, 1, 0),
+		IsPaid:          true,
+	})
+
+	result, err := calculationService.CalculateAccountBalance(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.CalculatedBalance.Equal(decimal.NewFromFloat(900.00)) {
+		t.Errorf("Expected calculated balance 900.00, got %s", result.CalculatedBalance.String())
+	}
+}
+
+func TestGetBalanceAsOf_MatchesHandComputedFold(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromInt(1000),
+	})
+
+	jan := func(day int) time.Time { return time.Date(2026, time.January, day, 0, 0, 0, 0, time.UTC) }
+	deletedAt := jan(20)
+	transactions := []*domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeIncome, Amount: decimal.NewFromInt(500), TransactionDate: jan(5), IsPaid: true},
+		{ID: 2, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(120), TransactionDate: jan(10), IsPaid: true},
+		{ID: 3, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(80), TransactionDate: jan(31), IsPaid: true},
+		{ID: 4, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(50), TransactionDate: jan(15), IsPaid: false},
+		{ID: 5, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(999), TransactionDate: jan(12), IsPaid: true, DeletedAt: &deletedAt},
+		{ID: 6, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(300), TransactionDate: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), IsPaid: true},
+	}
+
+	// Fold by hand: paid income adds, paid expenses subtract, nothing after asOf or deleted counts
+	asOf := jan(31)
+	expected := decimal.NewFromInt(1000)
+	for _, tx := range transactions {
+		tx.WorkspaceID = workspaceID
+		tx.AccountID = 1
+		transactionRepo.AddTransaction(tx)
+		if tx.DeletedAt != nil || !tx.IsPaid || tx.TransactionDate.After(asOf) {
+			continue
+		}
+		if tx.Type == domain.TransactionTypeIncome {
+			expected = expected.Add(tx.Amount)
+		} else {
+			expected = expected.Sub(tx.Amount)
+		}
+	}
+
+	balance, err := calculationService.GetBalanceAsOf(workspaceID, 1, asOf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !balance.Equal(expected) || !balance.Equal(decimal.NewFromInt(1300)) {
+		t.Errorf("Expected as-of balance %s, got %s", expected, balance)
+	}
+
+	// The 10th excludes the 80 spent on the 31st and the income stays
+	balance, _ = calculationService.GetBalanceAsOf(workspaceID, 1, jan(10))
+	if !balance.Equal(decimal.NewFromInt(1380)) {
+		t.Errorf("Expected balance 1380 as of Jan 10, got %s", balance)
+	}
+}
+
+func TestGetBalanceAsOf_TodayEqualsCurrentBalance(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Credit Card",
+		Template:       domain.TemplateCreditCard,
+		InitialBalance: decimal.Zero,
+	})
+
+	now := time.Now()
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 1, WorkspaceID: workspaceID, AccountID: 1, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(200), TransactionDate: now.AddDate(0, 0, -3), IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 2, WorkspaceID: workspaceID, AccountID: 1, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(75), TransactionDate: now, IsPaid: false})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 3, WorkspaceID: workspaceID, AccountID: 1, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(40), TransactionDate: now.AddDate(0, 0, 5), IsPaid: false, IsScheduled: true})
+
+	current, err := calculationService.CalculateAccountBalance(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	balance, err := calculationService.GetBalanceAsOf(workspaceID, 1, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !balance.Equal(current.CalculatedBalance) {
+		t.Errorf("Expected as-of-today balance %s to equal current balance %s", balance, current.CalculatedBalance)
+	}
+
+	// Leaving out the unsettled purchase only counts the settled 200
+	settled, err := calculationService.GetSettledBalanceAsOf(workspaceID, 1, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !settled.Equal(decimal.NewFromInt(-200)) {
+		t.Errorf("Expected settled balance -200, got %s", settled)
+	}
+}
+
+func newReconcileTestService(t *testing.T) (*CalculationService, *testutil.MockTransactionRepository, time.Time) {
+	t.Helper()
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    1,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromInt(1000),
+	})
+	asOf := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 1, WorkspaceID: 1, AccountID: 1, Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(250), TransactionDate: asOf.AddDate(0, 0, -10), IsPaid: true})
+
+	return NewCalculationService(accountRepo, transactionRepo), transactionRepo, asOf
+}
+
+func TestReconcile_MatchingBalance(t *testing.T) {
+	calculationService, transactionRepo, asOf := newReconcileTestService(t)
+
+	result, err := calculationService.Reconcile(1, 1, decimal.NewFromInt(750), asOf, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Difference.IsZero() {
+		t.Errorf("Expected zero difference, got %s", result.Difference)
+	}
+	if result.Adjustment != nil {
+		t.Errorf("Expected no adjustment for a matching balance, got %+v", result.Adjustment)
+	}
+	if len(transactionRepo.Transactions) != 1 {
+		t.Errorf("Expected no new transactions, got %d", len(transactionRepo.Transactions))
+	}
+}
+
+func TestReconcile_MismatchReportsDelta(t *testing.T) {
+	calculationService, transactionRepo, asOf := newReconcileTestService(t)
+
+	result, err := calculationService.Reconcile(1, 1, decimal.NewFromInt(700), asOf, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.ComputedBalance.Equal(decimal.NewFromInt(750)) {
+		t.Errorf("Expected computed balance 750, got %s", result.ComputedBalance)
+	}
+	if !result.Difference.Equal(decimal.NewFromInt(-50)) {
+		t.Errorf("Expected difference -50, got %s", result.Difference)
+	}
+	if result.Adjustment != nil || len(transactionRepo.Transactions) != 1 {
+		t.Error("Expected no adjustment without adjust")
+	}
+}
+
+func TestReconcile_MismatchWithAdjustCreatesCorrection(t *testing.T) {
+	calculationService, _, asOf := newReconcileTestService(t)
+
+	result, err := calculationService.Reconcile(1, 1, decimal.NewFromInt(700), asOf, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Adjustment == nil {
+		t.Fatal("Expected an adjustment transaction")
+	}
+	if result.Adjustment.Type != domain.TransactionTypeExpense || !result.Adjustment.Amount.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("Expected a 50 expense adjustment, got %s %s", result.Adjustment.Type, result.Adjustment.Amount)
+	}
+	if !result.Adjustment.IsPaid || !result.Adjustment.TransactionDate.Equal(asOf) {
+		t.Errorf("Expected a paid adjustment dated %v, got paid=%v date=%v", asOf, result.Adjustment.IsPaid, result.Adjustment.TransactionDate)
+	}
+	if !result.Adjustment.ExcludeFromReports {
+		t.Error("Expected the adjustment to be excluded from reports")
+	}
+
+	// The correction brings the computed balance in line with the external one
+	balance, _ := calculationService.GetBalanceAsOf(1, 1, asOf)
+	if !balance.Equal(decimal.NewFromInt(700)) {
+		t.Errorf("Expected balance 700 after adjustment, got %s", balance)
+	}
+}
+
+func TestComputeNetWorth_OmitsExcludedAccount(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+	accountService := NewAccountService(accountRepo)
+
+	workspaceID := int32(1)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromFloat(1000.00),
+	})
+	// Shared account held on behalf of someone else
+	accountRepo.AddAccount(&domain.Account{
+		ID:                  2,
+		WorkspaceID:         workspaceID,
+		Name:                "Shared Savings",
+		Template:            domain.TemplateBank,
+		InitialBalance:      decimal.NewFromFloat(5000.00),
+		ExcludeFromNetWorth: true,
+	})
+	accountRepo.AddAccount(&domain.Account{
+		ID:             3,
+		WorkspaceID:    workspaceID,
+		Name:           "Credit Card",
+		Template:       domain.TemplateCreditCard,
+		InitialBalance: decimal.NewFromFloat(-200.00),
+	})
+
+	netWorth, err := calculationService.ComputeNetWorth(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !netWorth.Equal(decimal.NewFromFloat(800.00)) {
+		t.Errorf("Expected net worth 800.00, got %s", netWorth.String())
+	}
+
+	// The excluded account is still listed
+	accounts, err := accountService.GetAccounts(workspaceID, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("Expected 3 accounts, got %d", len(accounts))
+	}
+
+	// Including it again restores its balance
+	if _, err := accountService.SetIncludeInNetWorth(workspaceID, 2, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	netWorth, err = calculationService.ComputeNetWorth(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !netWorth.Equal(decimal.NewFromFloat(5800.00)) {
+		t.Errorf("Expected net worth 5800.00, got %s", netWorth.String())
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -12,6 +13,7 @@ import (
 type CCService struct {
 	transactionRepo domain.TransactionRepository
 	accountRepo     domain.AccountRepository
+	loanRepo        domain.LoanRepository
 }
 
 // NewCCService creates a new CCService
@@ -22,6 +24,11 @@ func NewCCService(transactionRepo domain.TransactionRepository, accountRepo doma
 	}
 }
 
+// SetLoanRepository sets the loan repository used to keep loan completion in sync when settling
+func (s *CCService) SetLoanRepository(loanRepo domain.LoanRepository) {
+	s.loanRepo = loanRepo
+}
+
 // CreateCCPayment creates a CC payment transaction (income on CC account)
 // Optionally creates a linked expense on the source bank account
 func (s *CCService) CreateCCPayment(workspaceID int32, req *domain.CreateCCPaymentRequest) (*domain.CCPaymentResponse, error) {
@@ -119,7 +126,7 @@ func (s *CCService) CreateCCPayment(workspaceID int32, req *domain.CreateCCPayme
 			UpdatedAt:       time.Now(),
 		}
 
-		created, err := s.transactionRepo.Create(ccTx)
+		created, err := s.transactionRepo.Create(context.Background(), ccTx)
 		if err != nil {
 			return nil, err
 		}
@@ -129,3 +136,93 @@ func (s *CCService) CreateCCPayment(workspaceID int32, req *domain.CreateCCPayme
 
 	return response, nil
 }
+
+// GetCCStateBreakdown returns counts and totals of pending, billed and settled purchases on a CC account
+func (s *CCService) GetCCStateBreakdown(workspaceID, accountID int32) (*domain.CCStateBreakdown, error) {
+	account, err := s.accountRepo.GetByID(workspaceID, accountID)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	if account.Template != domain.TemplateCreditCard {
+		return nil, domain.ErrNotCreditCard
+	}
+
+	return s.transactionRepo.GetCCStateBreakdown(workspaceID, accountID)
+}
+
+// CloseBillingCycle bills every pending purchase on a CC account dated on/before cutoffDate,
+// as happens when a statement closes. Purchases after the cutoff stay pending.
+func (s *CCService) CloseBillingCycle(workspaceID, accountID int32, cutoffDate time.Time) (*domain.BillingCycleResult, error) {
+	account, err := s.accountRepo.GetByID(workspaceID, accountID)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	if account.Template != domain.TemplateCreditCard {
+		return nil, domain.ErrNotCreditCard
+	}
+
+	cutoff := time.Date(cutoffDate.Year(), cutoffDate.Month(), cutoffDate.Day(), 0, 0, 0, 0, time.UTC)
+	billed, err := s.transactionRepo.BillPendingUpTo(workspaceID, accountID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	total := decimal.Zero
+	for _, tx := range billed {
+		total = total.Add(tx.Amount)
+	}
+
+	return &domain.BillingCycleResult{
+		AccountID:    accountID,
+		CutoffDate:   cutoff,
+		BilledCount:  len(billed),
+		TotalBilled:  total,
+		Transactions: billed,
+	}, nil
+}
+
+// SettleDeferredForMonth settles every billed deferred CC transaction due by the given month (YYYY-MM).
+// Deferred purchases fall due the month after they were made, so anything dated before the month
+// starts is settled, including older ones that were carried forward. Loan installments are included.
+func (s *CCService) SettleDeferredForMonth(workspaceID int32, month string) (*domain.SettleResult, error) {
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, domain.ErrInvalidSettlementMonth
+	}
+
+	deferred, err := s.transactionRepo.GetDeferredForSettlement(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(deferred))
+	for _, tx := range deferred {
+		if tx.TransactionDate.Before(monthStart) {
+			ids = append(ids, tx.ID)
+		}
+	}
+
+	result := &domain.SettleResult{
+		Month:        monthStart.Format("2006-01"),
+		TotalSettled: decimal.Zero,
+		Transactions: []*domain.Transaction{},
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	settled, err := s.transactionRepo.BulkSettle(workspaceID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, transactionLoanIDs(settled)); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range settled {
+		result.TotalSettled = result.TotalSettled.Add(tx.Amount)
+	}
+	result.SettledCount = len(settled)
+	result.Transactions = settled
+	return result, nil
+}
@@ -292,3 +292,125 @@ func TestCreateCCPayment_NotesTooLong(t *testing.T) {
 		t.Errorf("Expected ErrNotesTooLong, got %v", err)
 	}
 }
+
+// ============================================================================
+// CloseBillingCycle Tests
+// ============================================================================
+
+func TestCloseBillingCycle_BillsPendingUpToCutoff(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	ccService := NewCCService(transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "My CC",
+		Template:    domain.TemplateCreditCard,
+	})
+
+	cutoff := time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, AccountID: 1, Name: "Groceries", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(120), TransactionDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, AccountID: 1, Name: "Fuel", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(80), TransactionDate: cutoff})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: workspaceID, AccountID: 1, Name: "Dinner", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(60), TransactionDate: time.Date(2026, 3, 26, 0, 0, 0, 0, time.UTC)})
+
+	result, err := ccService.CloseBillingCycle(workspaceID, 1, cutoff)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.BilledCount != 2 {
+		t.Errorf("Expected 2 billed transactions, got %d", result.BilledCount)
+	}
+	if !result.TotalBilled.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected total billed 200, got %s", result.TotalBilled)
+	}
+	for _, id := range []int32{100, 101} {
+		billedAt := transactionRepo.Transactions[id].BilledAt
+		if billedAt == nil || !billedAt.Equal(cutoff) {
+			t.Errorf("Expected transaction %d billed at cutoff, got %v", id, billedAt)
+		}
+	}
+	if transactionRepo.Transactions[102].BilledAt != nil {
+		t.Error("Expected transaction after cutoff to stay pending")
+	}
+}
+
+func TestCloseBillingCycle_NotCreditCard(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	ccService := NewCCService(transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Bank",
+		Template:    domain.TemplateBank,
+	})
+
+	_, err := ccService.CloseBillingCycle(workspaceID, 1, time.Now())
+	if err != domain.ErrNotCreditCard {
+		t.Errorf("Expected ErrNotCreditCard, got %v", err)
+	}
+}
+
+// ============================================================================
+// SettleDeferredForMonth Tests
+// ============================================================================
+
+func TestSettleDeferredForMonth_SettlesDueIncludingLoan(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	ccService := NewCCService(transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(7)
+	billedAt := time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC)
+	deferred := domain.SettlementIntentDeferred
+	billed := domain.CCStateBilled
+	newDeferred := func(id int32, name string, amount int64, date time.Time) *domain.Transaction {
+		return &domain.Transaction{
+			ID: id, WorkspaceID: workspaceID, AccountID: 1, Name: name,
+			Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(amount), TransactionDate: date,
+			BilledAt: &billedAt, SettlementIntent: &deferred, CCState: &billed,
+		}
+	}
+
+	transactionRepo.AddTransaction(newDeferred(100, "Groceries", 150, time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)))
+	installment := newDeferred(101, "Laptop installment", 250, time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC))
+	installment.LoanID = &loanID
+	transactionRepo.AddTransaction(installment)
+	// Made in January, so not due until February
+	transactionRepo.AddTransaction(newDeferred(102, "Dinner", 60, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)))
+
+	result, err := ccService.SettleDeferredForMonth(workspaceID, "2026-01")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.SettledCount != 2 {
+		t.Errorf("Expected 2 settled transactions, got %d", result.SettledCount)
+	}
+	if !result.TotalSettled.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("Expected total settled 400, got %s", result.TotalSettled)
+	}
+	for _, id := range []int32{100, 101} {
+		if !transactionRepo.Transactions[id].IsPaid {
+			t.Errorf("Expected transaction %d to be settled", id)
+		}
+	}
+	if transactionRepo.Transactions[102].IsPaid {
+		t.Error("Expected transaction not yet due to stay unsettled")
+	}
+}
+
+func TestSettleDeferredForMonth_InvalidMonth(t *testing.T) {
+	ccService := NewCCService(testutil.NewMockTransactionRepository(), testutil.NewMockAccountRepository())
+
+	_, err := ccService.SettleDeferredForMonth(1, "January")
+	if err != domain.ErrInvalidSettlementMonth {
+		t.Errorf("Expected ErrInvalidSettlementMonth, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -11,6 +13,15 @@ import (
 // ErrProjectionLimitExceeded is returned when requesting projections beyond the maximum allowed
 var ErrProjectionLimitExceeded = errors.New("projection limit exceeded (max 12 months)")
 
+// ErrNoIncome is returned by ComputeDebtToIncome when the month has no income to divide by
+var ErrNoIncome = errors.New("no income recorded for month")
+
+// Bounds for the number of entries returned by GetLargestTransactions
+const (
+	MinLargestTransactionsLimit = 1
+	MaxLargestTransactionsLimit = 20
+)
+
 // DashboardService handles dashboard-related business logic
 type DashboardService struct {
 	accountRepo     domain.AccountRepository
@@ -18,6 +29,10 @@ type DashboardService struct {
 	loanPaymentRepo domain.LoanPaymentRepository
 	monthService    *MonthService
 	calcService     *CalculationService
+	// Optional services reused for the monthly obligations total
+	loanService        *LoanService
+	transactionService *TransactionService
+	spendableTemplates domain.SpendableTemplates
 }
 
 // NewDashboardService creates a new DashboardService
@@ -29,25 +44,48 @@ func NewDashboardService(
 	calcService *CalculationService,
 ) *DashboardService {
 	return &DashboardService{
-		accountRepo:     accountRepo,
-		transactionRepo: transactionRepo,
-		loanPaymentRepo: loanPaymentRepo,
-		monthService:    monthService,
-		calcService:     calcService,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		loanPaymentRepo:    loanPaymentRepo,
+		monthService:       monthService,
+		calcService:        calcService,
+		spendableTemplates: domain.DefaultSpendableTemplates(),
 	}
 }
 
+// SetSpendableTemplates sets which account templates count towards spendable cash
+func (s *DashboardService) SetSpendableTemplates(templates domain.SpendableTemplates) {
+	s.spendableTemplates = templates
+}
+
+// SetLoanService sets the loan service used for unpaid loan commitments
+func (s *DashboardService) SetLoanService(loanService *LoanService) {
+	s.loanService = loanService
+}
+
+// SetTransactionService sets the transaction service used for CC statement balances
+func (s *DashboardService) SetTransactionService(transactionService *TransactionService) {
+	s.transactionService = transactionService
+}
+
 // GetSummary returns the dashboard summary for a workspace for the current month
 func (s *DashboardService) GetSummary(workspaceID int32) (*domain.DashboardSummary, error) {
 	now := time.Now()
-	return s.GetSummaryForMonth(workspaceID, now.Year(), int(now.Month()))
+	return s.GetSummaryForMonth(workspaceID, now.Year(), int(now.Month()), nil)
 }
 
 // GetSummaryForMonth returns the dashboard summary for a workspace for a specific month
 // For future months, it returns projected data based on current balances
+// A non-empty accountIDs limits balances and month totals to those accounts; unpaid loan
+// payments are not tied to an account here and stay workspace-wide.
 // NOTE: Uses server's local timezone for month boundary detection. Consider accepting
 // timezone from request if users report unexpected behavior near month boundaries.
-func (s *DashboardService) GetSummaryForMonth(workspaceID int32, year, month int) (*domain.DashboardSummary, error) {
+func (s *DashboardService) GetSummaryForMonth(workspaceID int32, year, month int, accountIDs []int32) (*domain.DashboardSummary, error) {
+	scope, err := s.loadAccountScope(workspaceID, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	requestedDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
 	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
@@ -60,39 +98,42 @@ func (s *DashboardService) GetSummaryForMonth(workspaceID int32, year, month int
 		if monthsAhead > domain.MaxProjectionMonths {
 			return nil, ErrProjectionLimitExceeded
 		}
-		return s.getProjection(workspaceID, year, month)
+		return s.getProjection(workspaceID, year, month, scope)
 	}
 
-	return s.getActualSummary(workspaceID, year, month)
+	return s.getActualSummary(workspaceID, year, month, scope)
 }
 
 // getActualSummary returns the dashboard summary for current or past months
-func (s *DashboardService) getActualSummary(workspaceID int32, year, month int) (*domain.DashboardSummary, error) {
+func (s *DashboardService) getActualSummary(workspaceID int32, year, month int, scope accountFilter) (*domain.DashboardSummary, error) {
 	// 1. Get month data
-	monthData, err := s.monthService.GetOrCreateMonth(workspaceID, year, month)
+	monthData, err := s.getMonth(workspaceID, year, month, scope)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. Calculate total balance from all accounts (assets - liabilities)
-	totalBalance, err := s.calculateTotalBalance(workspaceID)
+	totalBalance, counted, err := s.calculateTotalBalance(workspaceID, scope)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2b. Adjust total balance to exclude deferred CC transactions for this month
 	// Deferred CC transactions are obligations for NEXT month, not this month
-	// The account balance calculation includes all CC transactions, so we add back deferred ones
-	deferredCCForMonth, err := s.transactionRepo.SumDeferredCCByDateRange(
-		workspaceID, monthData.StartDate, monthData.EndDate)
-	if err != nil {
-		return nil, err
+	// The account balance calculation includes all CC transactions, so we add back deferred ones,
+	// but only for the accounts whose balance was counted above
+	if len(counted) > 0 {
+		deferredCCForMonth, err := s.transactionRepo.SumDeferredCCByDateRange(
+			workspaceID, monthData.StartDate, monthData.EndDate, counted.ids())
+		if err != nil {
+			return nil, err
+		}
+		totalBalance = totalBalance.Add(deferredCCForMonth)
 	}
-	totalBalance = totalBalance.Add(deferredCCForMonth)
 
 	// 3. Calculate in-hand balance (starting + income - paid expenses only)
 	paidExpenses, err := s.transactionRepo.SumPaidExpensesByDateRange(
-		workspaceID, monthData.StartDate, monthData.EndDate)
+		workspaceID, monthData.StartDate, monthData.EndDate, scope.ids())
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +144,7 @@ func (s *DashboardService) getActualSummary(workspaceID int32, year, month int)
 	// Use SumUnpaidExpensesForDisposable which EXCLUDES deferred CC transactions
 	// (deferred CC transactions are obligations for next month, not this month)
 	unpaidExpenses, err := s.transactionRepo.SumUnpaidExpensesForDisposable(
-		workspaceID, monthData.StartDate, monthData.EndDate)
+		workspaceID, monthData.StartDate, monthData.EndDate, scope.ids())
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +181,9 @@ func (s *DashboardService) getActualSummary(workspaceID int32, year, month int)
 }
 
 // getProjection returns projected dashboard data for a future month
-func (s *DashboardService) getProjection(workspaceID int32, year, month int) (*domain.DashboardSummary, error) {
+func (s *DashboardService) getProjection(workspaceID int32, year, month int, scope accountFilter) (*domain.DashboardSummary, error) {
 	// Get the starting balance by chaining from current month
-	startingBalance, err := s.chainBalanceToMonth(workspaceID)
+	startingBalance, err := s.chainBalanceToMonth(workspaceID, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +201,7 @@ func (s *DashboardService) getProjection(workspaceID int32, year, month int) (*d
 	prevMonthEnd := time.Date(prevMonthDate.Year(), prevMonthDate.Month()+1, 0, 0, 0, 0, 0, time.Local)
 
 	deferredFromPrevMonth, err := s.transactionRepo.SumDeferredCCByDateRange(
-		workspaceID, prevMonthStart, prevMonthEnd)
+		workspaceID, prevMonthStart, prevMonthEnd, scope.ids())
 	if err != nil {
 		return nil, err
 	}
@@ -223,9 +264,9 @@ func (s *DashboardService) getProjection(workspaceID int32, year, month int) (*d
 
 // chainBalanceToMonth calculates the projected starting balance for a future month
 // For MVP, uses the current month's closing balance as the projection base
-func (s *DashboardService) chainBalanceToMonth(workspaceID int32) (decimal.Decimal, error) {
+func (s *DashboardService) chainBalanceToMonth(workspaceID int32, scope accountFilter) (decimal.Decimal, error) {
 	now := time.Now()
-	currentMonth, err := s.monthService.GetOrCreateMonth(workspaceID, now.Year(), int(now.Month()))
+	currentMonth, err := s.getMonth(workspaceID, now.Year(), int(now.Month()), scope)
 	if err != nil {
 		return decimal.Zero, err
 	}
@@ -233,6 +274,42 @@ func (s *DashboardService) chainBalanceToMonth(workspaceID int32) (decimal.Decim
 	return currentMonth.ClosingBalance, nil
 }
 
+// getMonth returns the calculated month, recomputed over the scoped accounts when there is a scope:
+// the starting balance is their balance at the end of the previous month and the totals only
+// count their transactions
+func (s *DashboardService) getMonth(workspaceID int32, year, month int, scope accountFilter) (*domain.CalculatedMonth, error) {
+	monthData, err := s.monthService.GetOrCreateMonth(workspaceID, year, month)
+	if err != nil || scope == nil {
+		return monthData, err
+	}
+
+	startDate, endDate := getMonthBoundaries(year, month)
+	startingBalance := decimal.Zero
+	for accountID := range scope {
+		balance, err := s.calcService.GetBalanceAsOf(workspaceID, accountID, startDate.AddDate(0, 0, -1))
+		if err != nil {
+			return nil, err
+		}
+		startingBalance = startingBalance.Add(balance)
+	}
+
+	income, err := s.transactionRepo.SumByTypeAndDateRangeInAccounts(workspaceID, startDate, endDate, domain.TransactionTypeIncome, scope.ids())
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.transactionRepo.SumByTypeAndDateRangeInAccounts(workspaceID, startDate, endDate, domain.TransactionTypeExpense, scope.ids())
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := *monthData
+	scoped.StartingBalance = startingBalance
+	scoped.TotalIncome = income
+	scoped.TotalExpenses = expenses
+	scoped.ClosingBalance = startingBalance.Add(income).Sub(expenses)
+	return &scoped, nil
+}
+
 // monthsBetween calculates the number of months between two dates
 func monthsBetween(start, end time.Time) int {
 	years := end.Year() - start.Year()
@@ -271,40 +348,130 @@ func (s *DashboardService) calculateDaysRemaining(year, month int) int {
 	return daysRemaining
 }
 
-// calculateTotalBalance calculates total balance from all accounts
-// Total = sum of all calculated balances
-// Note: For liabilities (CC), the calculated balance is negative when debt exists,
-// so we simply sum all balances to get net worth
-func (s *DashboardService) calculateTotalBalance(workspaceID int32) (decimal.Decimal, error) {
+// GetSpendableCash sums the calculated balances of accounts whose template counts
+// towards spendable cash (see SetSpendableTemplates).
+// A non-empty accountIDs limits the sum to those accounts.
+func (s *DashboardService) GetSpendableCash(workspaceID int32, accountIDs []int32) (decimal.Decimal, error) {
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, domain.ListOptions{})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	scope, err := accountScope(accounts, accountIDs)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
 	balances, err := s.calcService.CalculateAccountBalances(workspaceID)
 	if err != nil {
 		return decimal.Zero, err
 	}
 
 	total := decimal.Zero
-	for _, balance := range balances {
-		total = total.Add(balance.CalculatedBalance)
+	for _, account := range accounts {
+		if !s.spendableTemplates.Includes(account) || !scope.includes(account.ID) {
+			continue
+		}
+		if balance, ok := balances[account.ID]; ok {
+			total = total.Add(balance.CalculatedBalance)
+		}
 	}
 
 	return total, nil
 }
 
+// calculateTotalBalance calculates total balance (net worth) across accounts in scope
+// Accounts excluded from net worth are left out; the accounts that were counted are returned with the total
+func (s *DashboardService) calculateTotalBalance(workspaceID int32, scope accountFilter) (decimal.Decimal, accountFilter, error) {
+	balances, err := s.calcService.CalculateAccountBalances(workspaceID)
+	if err != nil {
+		return decimal.Zero, nil, err
+	}
+	total := decimal.Zero
+	counted := accountFilter{}
+	for accountID, balance := range balances {
+		if balance.IncludeInNetWorth && scope.includes(accountID) {
+			total = total.Add(balance.CalculatedBalance)
+			counted[accountID] = true
+		}
+	}
+	return total, counted, nil
+}
+
+// accountFilter restricts dashboard aggregates to a subset of accounts; nil means all accounts
+type accountFilter map[int32]bool
+
+// includes reports whether the account is in scope
+func (f accountFilter) includes(accountID int32) bool {
+	return f == nil || f[accountID]
+}
+
+// ids returns the scoped account IDs for the repository's account-scoped queries; nil means all accounts
+func (f accountFilter) ids() []int32 {
+	if f == nil {
+		return nil
+	}
+	ids := make([]int32, 0, len(f))
+	for id := range f {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// loadAccountScope is accountScope for callers that don't already have the workspace's accounts;
+// the lookup is skipped when no accounts were requested
+func (s *DashboardService) loadAccountScope(workspaceID int32, accountIDs []int32) (accountFilter, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, domain.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return accountScope(accounts, accountIDs)
+}
+
+// accountScope builds the filter for the requested account IDs, which must all belong to the
+// workspace's accounts. An empty list returns a nil filter that includes every account.
+func accountScope(accounts []*domain.Account, accountIDs []int32) (accountFilter, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+	known := make(map[int32]bool, len(accounts))
+	for _, acc := range accounts {
+		known[acc.ID] = true
+	}
+	scope := make(accountFilter, len(accountIDs))
+	for _, id := range accountIDs {
+		if !known[id] {
+			return nil, domain.ErrAccountNotFound
+		}
+		scope[id] = true
+	}
+	return scope, nil
+}
+
 // GetFutureSpending returns aggregated spending data for future months
-// including both actual and projected transactions
-func (s *DashboardService) GetFutureSpending(workspaceID int32, months int) (*domain.FutureSpendingData, error) {
+// including both actual and projected transactions.
+// A non-empty accountIDs limits the aggregation to transactions on those accounts.
+func (s *DashboardService) GetFutureSpending(workspaceID int32, months int, accountIDs []int32) (*domain.FutureSpendingData, error) {
 	now := time.Now()
 	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, months, 0)
 
-	// Get all transactions in the date range (includes both actual and projected)
-	// Uses dedicated aggregation method that doesn't have pagination limits
-	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	// Get accounts for name lookup
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, domain.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get accounts for name lookup
-	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, false)
+	scope, err := accountScope(accounts, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get all transactions in the date range (includes both actual and projected)
+	// Uses dedicated aggregation method that doesn't have pagination limits
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregationInAccounts(workspaceID, startDate, endDate, scope.ids())
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +482,7 @@ func (s *DashboardService) GetFutureSpending(workspaceID int32, months int) (*do
 	}
 
 	// Get deferred CC transactions that should be carried forward
-	deferredCC, err := s.transactionRepo.GetDeferredForSettlement(workspaceID)
+	deferredCC, err := s.transactionRepo.GetDeferredForSettlementInAccounts(workspaceID, scope.ids())
 	if err != nil {
 		return nil, err
 	}
@@ -348,6 +515,11 @@ func (s *DashboardService) GetFutureSpending(workspaceID int32, months int) (*do
 			continue
 		}
 
+		// Skip transactions the user excluded from reports
+		if txn.ExcludeFromReports {
+			continue
+		}
+
 		// Skip deferred CC transactions - they belong to next month's spending
 		if ccAccountIDs[txn.AccountID] && txn.SettlementIntent != nil && *txn.SettlementIntent == domain.SettlementIntentDeferred {
 			// Calculate the target month (next month from transaction date)
@@ -389,6 +561,9 @@ func (s *DashboardService) GetFutureSpending(workspaceID int32, months int) (*do
 		}
 	}
 	for _, txn := range deferredCC {
+		if txn.ExcludeFromReports {
+			continue
+		}
 		m := monthlyData[currentMonthKey]
 		amount := txn.Amount.Abs()
 		m.Total = m.Total.Add(amount)
@@ -475,3 +650,386 @@ func (s *DashboardService) GetFutureSpending(workspaceID int32, months int) (*do
 
 	return result, nil
 }
+
+// GetMerchantSummary aggregates a month's expenses by merchant, falling back to the
+// transaction name when no merchant is set. Transfers, CC payments, projections and transactions
+// excluded from reports are left out.
+func (s *DashboardService) GetMerchantSummary(workspaceID int32, year, month int) (*domain.MerchantSummary, error) {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byMerchant := make(map[string]*domain.MerchantSpending)
+	for _, txn := range transactions {
+		if txn.Type != domain.TransactionTypeExpense || txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected || txn.ExcludeFromReports {
+			continue
+		}
+
+		merchant := txn.Name
+		if txn.Merchant != nil {
+			merchant = *txn.Merchant
+		}
+
+		entry, exists := byMerchant[merchant]
+		if !exists {
+			entry = &domain.MerchantSpending{Merchant: merchant, Total: decimal.Zero}
+			byMerchant[merchant] = entry
+		}
+		entry.Total = entry.Total.Add(txn.Amount.Abs())
+		entry.TransactionCount++
+	}
+
+	result := &domain.MerchantSummary{
+		Month:     startDate.Format("2006-01"),
+		Merchants: make([]domain.MerchantSpending, 0, len(byMerchant)),
+	}
+	for _, entry := range byMerchant {
+		result.Merchants = append(result.Merchants, *entry)
+	}
+	sort.Slice(result.Merchants, func(i, j int) bool {
+		a, b := result.Merchants[i], result.Merchants[j]
+		if !a.Total.Equal(b.Total) {
+			return a.Total.GreaterThan(b.Total)
+		}
+		return a.Merchant < b.Merchant
+	})
+
+	return result, nil
+}
+
+// GetPayeeSummary aggregates a month's expenses by payee. Payees are matched
+// case-insensitively; the first spelling seen is the one reported.
+func (s *DashboardService) GetPayeeSummary(workspaceID int32, year, month int) (*domain.PayeeSummary, error) {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byPayee := make(map[string]*domain.PayeeSpending)
+	for _, txn := range transactions {
+		if txn.Payee == nil || txn.Type != domain.TransactionTypeExpense || txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected || txn.ExcludeFromReports {
+			continue
+		}
+
+		key := strings.ToLower(*txn.Payee)
+		entry, exists := byPayee[key]
+		if !exists {
+			entry = &domain.PayeeSpending{Payee: *txn.Payee, Total: decimal.Zero}
+			byPayee[key] = entry
+		}
+		entry.Total = entry.Total.Add(txn.Amount.Abs())
+		entry.TransactionCount++
+	}
+
+	result := &domain.PayeeSummary{
+		Month:  startDate.Format("2006-01"),
+		Payees: make([]domain.PayeeSpending, 0, len(byPayee)),
+	}
+	for _, entry := range byPayee {
+		result.Payees = append(result.Payees, *entry)
+	}
+	sort.Slice(result.Payees, func(i, j int) bool {
+		a, b := result.Payees[i], result.Payees[j]
+		if !a.Total.Equal(b.Total) {
+			return a.Total.GreaterThan(b.Total)
+		}
+		return a.Payee < b.Payee
+	})
+
+	return result, nil
+}
+
+// uncategorizedTaxLabel names the tax report group for deductible transactions without a category
+const uncategorizedTaxLabel = "Uncategorized"
+
+// GetTaxReport aggregates a calendar year's tax-deductible transactions by category, largest first.
+// Refunds flagged deductible reduce their category's total. Transfers, CC payments and projections
+// are left out; the explicit tax flag takes precedence over exclude-from-reports.
+func (s *DashboardService) GetTaxReport(workspaceID int32, year int) (*domain.TaxReport, error) {
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TaxReport{Year: year, Total: decimal.Zero, Categories: []domain.TaxCategoryTotal{}}
+	byCategory := make(map[int32]*domain.TaxCategoryTotal)
+	var uncategorized *domain.TaxCategoryTotal
+	for _, txn := range transactions {
+		if !txn.IsTaxDeductible || txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected {
+			continue
+		}
+		if txn.Type != domain.TransactionTypeExpense && !txn.IsRefund {
+			continue
+		}
+
+		amount := txn.Amount.Abs()
+		if txn.IsRefund {
+			amount = amount.Neg()
+		}
+
+		var entry *domain.TaxCategoryTotal
+		if txn.CategoryID == nil {
+			if uncategorized == nil {
+				uncategorized = &domain.TaxCategoryTotal{CategoryName: uncategorizedTaxLabel, Total: decimal.Zero}
+			}
+			entry = uncategorized
+		} else {
+			entry = byCategory[*txn.CategoryID]
+			if entry == nil {
+				name := ""
+				if txn.CategoryName != nil {
+					name = *txn.CategoryName
+				}
+				categoryID := *txn.CategoryID
+				entry = &domain.TaxCategoryTotal{CategoryID: &categoryID, CategoryName: name, Total: decimal.Zero}
+				byCategory[categoryID] = entry
+			}
+		}
+		entry.Total = entry.Total.Add(amount)
+		entry.TransactionCount++
+
+		report.Total = report.Total.Add(amount)
+		report.TransactionCount++
+	}
+
+	for _, entry := range byCategory {
+		report.Categories = append(report.Categories, *entry)
+	}
+	if uncategorized != nil {
+		report.Categories = append(report.Categories, *uncategorized)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		a, b := report.Categories[i], report.Categories[j]
+		if !a.Total.Equal(b.Total) {
+			return a.Total.GreaterThan(b.Total)
+		}
+		return a.CategoryName < b.CategoryName
+	})
+
+	return report, nil
+}
+
+// GetLargestTransactions returns a month's largest expenses by absolute amount, largest first.
+// Income, transfers, CC payments, projections and transactions excluded from reports are left out;
+// limit is clamped to 1-20.
+func (s *DashboardService) GetLargestTransactions(workspaceID int32, year, month int, limit int) ([]*domain.Transaction, error) {
+	limit = max(MinLargestTransactionsLimit, min(limit, MaxLargestTransactionsLimit))
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := make([]*domain.Transaction, 0, len(transactions))
+	for _, txn := range transactions {
+		if txn.Type != domain.TransactionTypeExpense || txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected || txn.ExcludeFromReports {
+			continue
+		}
+		expenses = append(expenses, txn)
+	}
+	sort.Slice(expenses, func(i, j int) bool {
+		a, b := expenses[i].Amount.Abs(), expenses[j].Amount.Abs()
+		if !a.Equal(b) {
+			return a.GreaterThan(b)
+		}
+		return expenses[i].ID < expenses[j].ID
+	})
+
+	if len(expenses) > limit {
+		expenses = expenses[:limit]
+	}
+	return expenses, nil
+}
+
+// GetMonthlyObligationsTotal sums what is still owed for a month: unpaid loan commitments,
+// unpaid recurring expenses and the CC statement balance to settle.
+// Recurring expenses on credit cards are left to the statement balance so they are not counted twice.
+func (s *DashboardService) GetMonthlyObligationsTotal(workspaceID int32, year, month int) (*domain.ObligationsTotal, error) {
+	result := &domain.ObligationsTotal{
+		Year:  year,
+		Month: month,
+		Breakdown: domain.ObligationsBreakdown{
+			LoanCommitments:   decimal.Zero,
+			RecurringExpenses: decimal.Zero,
+			CCStatements:      decimal.Zero,
+		},
+	}
+
+	if s.loanService != nil {
+		commitments, err := s.loanService.GetMonthlyCommitments(workspaceID, year, month)
+		if err != nil {
+			return nil, err
+		}
+		result.Breakdown.LoanCommitments = commitments.TotalUnpaid
+	}
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range transactions {
+		if txn.TemplateID == nil || txn.IsPaid || txn.Type != domain.TransactionTypeExpense {
+			continue
+		}
+		if txn.LoanID != nil || txn.TransferPairID != nil || txn.SettlementIntent != nil {
+			continue
+		}
+		result.Breakdown.RecurringExpenses = result.Breakdown.RecurringExpenses.Add(txn.Amount.Abs())
+	}
+
+	if s.transactionService != nil {
+		metrics, err := s.transactionService.GetCCMetrics(workspaceID, startDate)
+		if err != nil {
+			return nil, err
+		}
+		result.Breakdown.CCStatements = metrics.Outstanding
+	}
+
+	result.Total = result.Breakdown.LoanCommitments.
+		Add(result.Breakdown.RecurringExpenses).
+		Add(result.Breakdown.CCStatements)
+
+	return result, nil
+}
+
+// ComputeDebtToIncome returns the month's debt obligations as a percentage of its income, rounded to 2 places.
+// Obligations are every loan payment and recurring expense dated in the month, paid or not;
+// income is the month's income transactions, not counting refunds. Transfers and transactions
+// excluded from reports are left out of both sides.
+// Returns ErrNoIncome when the month has no income.
+func (s *DashboardService) ComputeDebtToIncome(workspaceID int32, year, month int) (decimal.Decimal, error) {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	debt, income := decimal.Zero, decimal.Zero
+	for _, txn := range transactions {
+		if txn.TransferPairID != nil || txn.IsCCPayment || txn.ExcludeFromReports {
+			continue
+		}
+		switch {
+		case txn.IsRefund:
+			continue
+		case txn.Type == domain.TransactionTypeIncome:
+			income = income.Add(txn.Amount.Abs())
+		case txn.LoanID != nil || txn.TemplateID != nil:
+			debt = debt.Add(txn.Amount.Abs())
+		}
+	}
+
+	if !income.IsPositive() {
+		return decimal.Zero, ErrNoIncome
+	}
+	return debt.Div(income).Mul(decimal.NewFromInt(100)).Round(2), nil
+}
+
+// ComputeSavingsRate returns the share of the month's income left after expenses, as a percentage
+// rounded to 2 places: (income - expense) / income * 100. It is negative when the month was overspent.
+// Refunds reduce expense rather than count as income. Transfers, CC payments, projections and
+// transactions excluded from reports are left out.
+// Returns ErrNoIncome when the month has no income.
+func (s *DashboardService) ComputeSavingsRate(workspaceID int32, year, month int) (decimal.Decimal, error) {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	income, expense := decimal.Zero, decimal.Zero
+	for _, txn := range transactions {
+		if txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected || txn.ExcludeFromReports {
+			continue
+		}
+		switch {
+		case txn.IsRefund:
+			expense = expense.Sub(txn.Amount.Abs())
+		case txn.Type == domain.TransactionTypeIncome:
+			income = income.Add(txn.Amount.Abs())
+		default:
+			expense = expense.Add(txn.Amount.Abs())
+		}
+	}
+
+	if !income.IsPositive() {
+		return decimal.Zero, ErrNoIncome
+	}
+	return income.Sub(expense).Div(income).Mul(decimal.NewFromInt(100)).Round(2), nil
+}
+
+// GetSpendingVelocity returns the month's average daily spend so far and the month-end total at that rate
+func (s *DashboardService) GetSpendingVelocity(workspaceID int32, year, month int) (*domain.SpendingVelocity, error) {
+	return s.GetSpendingVelocityAt(workspaceID, year, month, time.Now())
+}
+
+// GetSpendingVelocityAt computes spending velocity as of now, which should be in the workspace timezone.
+// Today counts as an elapsed day, so the first of the month divides by one. Past months use every day
+// and project their actual total; future months have nothing elapsed and project zero.
+// Transfers, CC payments and projections are excluded, matching GetMerchantSummary.
+func (s *DashboardService) GetSpendingVelocityAt(workspaceID int32, year, month int, now time.Time) (*domain.SpendingVelocity, error) {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+	daysInMonth := endDate.Day()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	daysElapsed := 0
+	switch {
+	case today.After(endDate):
+		daysElapsed = daysInMonth
+	case !today.Before(startDate):
+		daysElapsed = today.Day()
+	}
+
+	velocity := &domain.SpendingVelocity{
+		Year:              year,
+		Month:             month,
+		DaysElapsed:       daysElapsed,
+		DaysInMonth:       daysInMonth,
+		SpentSoFar:        decimal.Zero,
+		AverageDailySpend: decimal.Zero,
+		ProjectedTotal:    decimal.Zero,
+	}
+	if daysElapsed == 0 {
+		return velocity, nil
+	}
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range transactions {
+		if txn.Type != domain.TransactionTypeExpense || txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected || txn.ExcludeFromReports {
+			continue
+		}
+		if txn.TransactionDate.After(today) {
+			continue
+		}
+		velocity.SpentSoFar = velocity.SpentSoFar.Add(txn.Amount.Abs())
+	}
+
+	average := velocity.SpentSoFar.Div(decimal.NewFromInt(int64(daysElapsed)))
+	velocity.AverageDailySpend = average.Round(2)
+	velocity.ProjectedTotal = average.Mul(decimal.NewFromInt(int64(daysInMonth))).Round(2)
+
+	return velocity, nil
+}
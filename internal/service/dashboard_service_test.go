@@ -1,11 +1,13 @@
 package service
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -311,7 +313,7 @@ func TestDashboardService_GetSummary(t *testing.T) {
 			dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
 			// Execute
-			summary, err := dashboardService.GetSummaryForMonth(tt.workspaceID, testYear, testMonth)
+			summary, err := dashboardService.GetSummaryForMonth(tt.workspaceID, testYear, testMonth, nil)
 
 			// Assert
 			if (err != nil) != tt.wantErr {
@@ -409,12 +411,12 @@ func TestDashboardService_WorkspaceIsolation(t *testing.T) {
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
 	// Get summaries for both workspaces using fixed dates
-	summary1, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth)
+	summary1, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth(1) error = %v", err)
 	}
 
-	summary2, err := dashboardService.GetSummaryForMonth(2, testYear, testMonth)
+	summary2, err := dashboardService.GetSummaryForMonth(2, testYear, testMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth(2) error = %v", err)
 	}
@@ -430,6 +432,115 @@ func TestDashboardService_WorkspaceIsolation(t *testing.T) {
 	}
 }
 
+func TestDashboardService_GetSummaryForMonth_AccountScope(t *testing.T) {
+	testYear := 2025
+	testMonth := 1
+	startDate := time.Date(testYear, time.Month(testMonth), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+	txDate := time.Date(testYear, time.Month(testMonth), 15, 0, 0, 0, 0, time.UTC)
+
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Bank", AccountType: domain.AccountTypeAsset, Template: domain.TemplateBank, InitialBalance: decimal.NewFromInt(1000)})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Wallet", AccountType: domain.AccountTypeAsset, Template: domain.TemplateEwallet, InitialBalance: decimal.NewFromInt(200)})
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 2, Name: "Foreign", AccountType: domain.AccountTypeAsset, Template: domain.TemplateBank})
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 1, WorkspaceID: 1, AccountID: 2, Name: "Top-up", Amount: decimal.NewFromInt(100), Type: domain.TransactionTypeIncome, TransactionDate: startDate.AddDate(0, 0, -5), IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 2, WorkspaceID: 1, AccountID: 1, Name: "Salary", Amount: decimal.NewFromInt(3000), Type: domain.TransactionTypeIncome, TransactionDate: txDate, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 3, WorkspaceID: 1, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(400), Type: domain.TransactionTypeExpense, TransactionDate: txDate, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 4, WorkspaceID: 1, AccountID: 2, Name: "Snacks", Amount: decimal.NewFromInt(50), Type: domain.TransactionTypeExpense, TransactionDate: txDate, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 5, WorkspaceID: 1, AccountID: 1, Name: "Utility Bill", Amount: decimal.NewFromInt(120), Type: domain.TransactionTypeExpense, TransactionDate: txDate})
+
+	monthRepo.AddMonth(&domain.Month{
+		ID:              1,
+		WorkspaceID:     1,
+		Year:            testYear,
+		Month:           testMonth,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		StartingBalance: decimal.NewFromInt(1300),
+		CreatedAt:       startDate,
+		UpdatedAt:       startDate,
+	})
+
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, testutil.NewMockLoanPaymentRepository(), monthService, calcService)
+
+	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, []int32{2})
+	if err != nil {
+		t.Fatalf("GetSummaryForMonth() error = %v", err)
+	}
+
+	// Only the wallet counts: 200 initial + 100 top-up before the month, then 50 spent
+	if summary.Month.StartingBalance.StringFixed(2) != "300.00" {
+		t.Errorf("StartingBalance = %v, want 300.00", summary.Month.StartingBalance.StringFixed(2))
+	}
+	if !summary.Month.TotalIncome.IsZero() || summary.Month.TotalExpenses.StringFixed(2) != "50.00" {
+		t.Errorf("Month totals = %v income / %v expenses, want 0.00 / 50.00", summary.Month.TotalIncome.StringFixed(2), summary.Month.TotalExpenses.StringFixed(2))
+	}
+	if summary.TotalBalance.StringFixed(2) != "250.00" {
+		t.Errorf("TotalBalance = %v, want 250.00", summary.TotalBalance.StringFixed(2))
+	}
+	if summary.InHandBalance.StringFixed(2) != "250.00" {
+		t.Errorf("InHandBalance = %v, want 250.00", summary.InHandBalance.StringFixed(2))
+	}
+	// The bank's unpaid bill is out of scope
+	if !summary.UnpaidExpenses.IsZero() {
+		t.Errorf("UnpaidExpenses = %v, want 0", summary.UnpaidExpenses.StringFixed(2))
+	}
+
+	if _, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, []int32{2, 3}); err != domain.ErrAccountNotFound {
+		t.Errorf("Expected ErrAccountNotFound for a foreign account, got %v", err)
+	}
+}
+
+func TestDashboardService_GetSummary_ExcludedCCDeferredNotAddedBack(t *testing.T) {
+	testYear := 2025
+	testMonth := 1
+	startDate := time.Date(testYear, time.Month(testMonth), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+	txDate := time.Date(testYear, time.Month(testMonth), 15, 0, 0, 0, 0, time.UTC)
+	deferred := domain.SettlementIntentDeferred
+
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Bank", AccountType: domain.AccountTypeAsset, Template: domain.TemplateBank, InitialBalance: decimal.NewFromInt(1000)})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Business Card", AccountType: domain.AccountTypeLiability, Template: domain.TemplateCreditCard, ExcludeFromNetWorth: true})
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 1, WorkspaceID: 1, AccountID: 2, Name: "Flight", Amount: decimal.NewFromInt(300), Type: domain.TransactionTypeExpense, TransactionDate: txDate, SettlementIntent: &deferred})
+
+	monthRepo.AddMonth(&domain.Month{
+		ID:              1,
+		WorkspaceID:     1,
+		Year:            testYear,
+		Month:           testMonth,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		StartingBalance: decimal.NewFromInt(1000),
+		CreatedAt:       startDate,
+		UpdatedAt:       startDate,
+	})
+
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, testutil.NewMockLoanPaymentRepository(), monthService, calcService)
+
+	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, nil)
+	if err != nil {
+		t.Fatalf("GetSummaryForMonth() error = %v", err)
+	}
+
+	// The card's balance is left out of net worth, so its deferred spend must not be added back either
+	if summary.TotalBalance.StringFixed(2) != "1000.00" {
+		t.Errorf("TotalBalance = %v, want 1000.00", summary.TotalBalance.StringFixed(2))
+	}
+}
+
 func TestDashboardService_DaysRemainingAndDailyBudget(t *testing.T) {
 	// Test that DaysRemaining and DailyBudget are calculated correctly
 	// Note: DaysRemaining depends on current date, so we test relative behavior
@@ -496,7 +607,7 @@ func TestDashboardService_DaysRemainingAndDailyBudget(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -580,7 +691,7 @@ func TestDashboardService_NegativeDisposableIncome(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, testYear, testMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -661,7 +772,7 @@ func TestDashboardService_Projection_FutureMonth(t *testing.T) {
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
 	// Get projection for future month
-	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -731,7 +842,7 @@ func TestDashboardService_Projection_CurrentMonthNotProjection(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	summary, err := dashboardService.GetSummaryForMonth(1, currentYear, currentMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, currentYear, currentMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -775,7 +886,7 @@ func TestDashboardService_Projection_PastMonthNotProjection(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	summary, err := dashboardService.GetSummaryForMonth(1, pastYear, pastMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, pastYear, pastMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -809,7 +920,7 @@ func TestDashboardService_Projection_LimitExceeded(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	_, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth)
+	_, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth, nil)
 	if err == nil {
 		t.Error("Expected error for projection beyond 12 months")
 	}
@@ -854,7 +965,7 @@ func TestDashboardService_Projection_ExactlyAtLimit(t *testing.T) {
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
 	// Should succeed - exactly at limit
-	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth, nil)
 	if err != nil {
 		t.Fatalf("Expected success for exactly 12 months ahead, got error: %v", err)
 	}
@@ -934,7 +1045,7 @@ func TestDashboardService_Projection_BalanceChaining(t *testing.T) {
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
 	// Get projection for next month
-	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth)
+	summary, err := dashboardService.GetSummaryForMonth(1, futureYear, futureMonth, nil)
 	if err != nil {
 		t.Fatalf("GetSummaryForMonth() error = %v", err)
 	}
@@ -1014,7 +1125,7 @@ func TestDashboardService_GetFutureSpending_BasicAggregation(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	result, err := dashboardService.GetFutureSpending(workspaceID, 3)
+	result, err := dashboardService.GetFutureSpending(workspaceID, 3, nil)
 	if err != nil {
 		t.Fatalf("GetFutureSpending() error = %v", err)
 	}
@@ -1040,6 +1151,127 @@ func TestDashboardService_GetFutureSpending_BasicAggregation(t *testing.T) {
 	}
 }
 
+func TestDashboardService_GetFutureSpending_AccountScope(t *testing.T) {
+	now := time.Now()
+	workspaceID := int32(1)
+
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, testutil.NewMockLoanPaymentRepository(), nil, nil)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Bank", Template: domain.TemplateBank})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: workspaceID, Name: "Wallet", Template: domain.TemplateEwallet})
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 2, Name: "Foreign", Template: domain.TemplateBank})
+
+	foodID, travelID := int32(1), int32(2)
+	food, travel := "Food", "Travel"
+	txDate := time.Date(now.Year(), now.Month(), 15, 12, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 1, WorkspaceID: workspaceID, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(500),
+		Type: domain.TransactionTypeExpense, TransactionDate: txDate, IsPaid: true, CategoryID: &foodID, CategoryName: &food,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 2, WorkspaceID: workspaceID, AccountID: 2, Name: "Snacks", Amount: decimal.NewFromInt(50),
+		Type: domain.TransactionTypeExpense, TransactionDate: txDate, IsPaid: true, CategoryID: &foodID, CategoryName: &food,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 3, WorkspaceID: workspaceID, AccountID: 2, Name: "Train", Amount: decimal.NewFromInt(80),
+		Type: domain.TransactionTypeExpense, TransactionDate: txDate, IsPaid: true, CategoryID: &travelID, CategoryName: &travel,
+	})
+
+	result, err := dashboardService.GetFutureSpending(workspaceID, 1, []int32{1})
+	if err != nil {
+		t.Fatalf("GetFutureSpending() error = %v", err)
+	}
+
+	month := result.Months[0]
+	if month.Total != "500.00" {
+		t.Errorf("Scoped total = %s, want 500.00", month.Total)
+	}
+	if len(month.ByCategory) != 1 || month.ByCategory[0].ID != foodID || month.ByCategory[0].Amount != "500.00" {
+		t.Errorf("Expected only Food at 500.00 from the scoped account, got %+v", month.ByCategory)
+	}
+	if len(month.ByAccount) != 1 || month.ByAccount[0].ID != 1 {
+		t.Errorf("Expected only account 1 in the breakdown, got %+v", month.ByAccount)
+	}
+
+	// Accounts from another workspace are rejected
+	if _, err := dashboardService.GetFutureSpending(workspaceID, 1, []int32{1, 3}); err != domain.ErrAccountNotFound {
+		t.Errorf("Expected ErrAccountNotFound for a foreign account, got %v", err)
+	}
+}
+
+func TestDashboardService_GetFutureSpending_ExcludeFromReports(t *testing.T) {
+	now := time.Now()
+	workspaceID := int32(1)
+	categoryID := int32(1)
+
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Bank Account",
+		AccountType:    domain.AccountTypeAsset,
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromInt(1000),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+
+	txDate := time.Date(now.Year(), now.Month(), 15, 12, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Groceries",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: txDate,
+		IsPaid:          true,
+		CategoryID:      &categoryID,
+	})
+	// Reimbursed work lunch: excluded from reports but still paid from the account
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:                 2,
+		WorkspaceID:        workspaceID,
+		AccountID:          1,
+		Name:               "Client lunch",
+		Amount:             decimal.NewFromInt(40),
+		Type:               domain.TransactionTypeExpense,
+		TransactionDate:    txDate,
+		IsPaid:             true,
+		CategoryID:         &categoryID,
+		ExcludeFromReports: true,
+	})
+
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+
+	result, err := dashboardService.GetFutureSpending(workspaceID, 1, nil)
+	if err != nil {
+		t.Fatalf("GetFutureSpending() error = %v", err)
+	}
+	if len(result.Months[0].ByCategory) != 1 || result.Months[0].ByCategory[0].Amount != "100.00" {
+		t.Errorf("ByCategory = %+v, want only the 100.00 not excluded from reports", result.Months[0].ByCategory)
+	}
+	if result.Months[0].Total != "100.00" {
+		t.Errorf("Total = %s, want 100.00", result.Months[0].Total)
+	}
+
+	balance, err := calcService.CalculateAccountBalance(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("CalculateAccountBalance() error = %v", err)
+	}
+	if !balance.CalculatedBalance.Equal(decimal.NewFromInt(860)) {
+		t.Errorf("CalculatedBalance = %s, want 860 (excluded transaction still affects balance)", balance.CalculatedBalance)
+	}
+}
+
 func TestDashboardService_GetFutureSpending_IncludesDeferredCC(t *testing.T) {
 	now := time.Now()
 	workspaceID := int32(1)
@@ -1085,7 +1317,7 @@ func TestDashboardService_GetFutureSpending_IncludesDeferredCC(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	result, err := dashboardService.GetFutureSpending(workspaceID, 1)
+	result, err := dashboardService.GetFutureSpending(workspaceID, 1, nil)
 	if err != nil {
 		t.Fatalf("GetFutureSpending() error = %v", err)
 	}
@@ -1156,7 +1388,7 @@ func TestDashboardService_GetFutureSpending_ExcludesIncome(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	result, err := dashboardService.GetFutureSpending(workspaceID, 1)
+	result, err := dashboardService.GetFutureSpending(workspaceID, 1, nil)
 	if err != nil {
 		t.Fatalf("GetFutureSpending() error = %v", err)
 	}
@@ -1179,7 +1411,7 @@ func TestDashboardService_GetFutureSpending_EmptyMonths(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	result, err := dashboardService.GetFutureSpending(workspaceID, 6)
+	result, err := dashboardService.GetFutureSpending(workspaceID, 6, nil)
 	if err != nil {
 		t.Fatalf("GetFutureSpending() error = %v", err)
 	}
@@ -1262,7 +1494,7 @@ func TestDashboardService_GetFutureSpending_MultipleAccounts(t *testing.T) {
 	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
 	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
 
-	result, err := dashboardService.GetFutureSpending(workspaceID, 1)
+	result, err := dashboardService.GetFutureSpending(workspaceID, 1, nil)
 	if err != nil {
 		t.Fatalf("GetFutureSpending() error = %v", err)
 	}
@@ -1335,7 +1567,7 @@ func BenchmarkDashboardService_GetFutureSpending(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_, err := dashboardService.GetFutureSpending(workspaceID, 12)
+		_, err := dashboardService.GetFutureSpending(workspaceID, 12, nil)
 		if err != nil {
 			b.Fatalf("GetFutureSpending() error = %v", err)
 		}
@@ -1397,7 +1629,7 @@ func TestDashboardService_GetFutureSpending_Performance(t *testing.T) {
 
 	// Measure execution time
 	start := time.Now()
-	_, err := dashboardService.GetFutureSpending(workspaceID, 12)
+	_, err := dashboardService.GetFutureSpending(workspaceID, 12, nil)
 	elapsed := time.Since(start)
 
 	if err != nil {
@@ -1412,3 +1644,598 @@ func TestDashboardService_GetFutureSpending_Performance(t *testing.T) {
 
 	t.Logf("GetFutureSpending() completed in %v (limit: %v)", elapsed, maxDuration)
 }
+
+func TestDashboardService_GetMerchantSummary(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+
+	grab := "Grab"
+	txns := []struct {
+		name     string
+		merchant *string
+		amount   int64
+		txType   domain.TransactionType
+		date     time.Time
+	}{
+		{"Ride to office", &grab, 15, domain.TransactionTypeExpense, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"GrabFood lunch", &grab, 25, domain.TransactionTypeExpense, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{"Airport ride", &grab, 60, domain.TransactionTypeExpense, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"Coffee", nil, 8, domain.TransactionTypeExpense, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"Coffee", nil, 7, domain.TransactionTypeExpense, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"Salary", nil, 5000, domain.TransactionTypeIncome, time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC)},
+		{"Next month ride", &grab, 99, domain.TransactionTypeExpense, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for i, tx := range txns {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            tx.name,
+			Merchant:        tx.merchant,
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            tx.txType,
+			TransactionDate: tx.date,
+			IsPaid:          true,
+		})
+	}
+
+	summary, err := dashboardService.GetMerchantSummary(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("GetMerchantSummary() error = %v", err)
+	}
+
+	if summary.Month != "2026-01" {
+		t.Errorf("Expected month 2026-01, got %s", summary.Month)
+	}
+	if len(summary.Merchants) != 2 {
+		t.Fatalf("Expected 2 merchant rows, got %d: %+v", len(summary.Merchants), summary.Merchants)
+	}
+
+	first := summary.Merchants[0]
+	if first.Merchant != "Grab" || first.TransactionCount != 3 || !first.Total.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected Grab x3 totalling 100, got %s x%d totalling %s", first.Merchant, first.TransactionCount, first.Total)
+	}
+
+	second := summary.Merchants[1]
+	if second.Merchant != "Coffee" || second.TransactionCount != 2 || !second.Total.Equal(decimal.NewFromInt(15)) {
+		t.Errorf("Expected name fallback Coffee x2 totalling 15, got %s x%d totalling %s", second.Merchant, second.TransactionCount, second.Total)
+	}
+}
+
+func TestDashboardService_GetPayeeSummary(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	landlord, landlordLower, plumber := "Mr. Tan", "mr. tan", "Ah Seng Plumbing"
+	txns := []struct {
+		name   string
+		payee  *string
+		amount int64
+		txType domain.TransactionType
+	}{
+		{"January rent", &landlord, 1200, domain.TransactionTypeExpense},
+		{"Parking bay", &landlordLower, 80, domain.TransactionTypeExpense},
+		{"Sink repair", &plumber, 150, domain.TransactionTypeExpense},
+		{"Groceries", nil, 90, domain.TransactionTypeExpense},
+		{"Deposit refund", &landlord, 500, domain.TransactionTypeIncome},
+	}
+	for i, tx := range txns {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            tx.name,
+			Payee:           tx.payee,
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            tx.txType,
+			TransactionDate: time.Date(2026, 1, 5+i, 0, 0, 0, 0, time.UTC),
+			IsPaid:          true,
+		})
+	}
+
+	summary, err := dashboardService.GetPayeeSummary(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("GetPayeeSummary() error = %v", err)
+	}
+
+	if summary.Month != "2026-01" {
+		t.Errorf("Expected month 2026-01, got %s", summary.Month)
+	}
+	if len(summary.Payees) != 2 {
+		t.Fatalf("Expected 2 payee rows, got %d: %+v", len(summary.Payees), summary.Payees)
+	}
+
+	first := summary.Payees[0]
+	if first.Payee != "Mr. Tan" || first.TransactionCount != 2 || !first.Total.Equal(decimal.NewFromInt(1280)) {
+		t.Errorf("Expected Mr. Tan x2 totalling 1280, got %s x%d totalling %s", first.Payee, first.TransactionCount, first.Total)
+	}
+
+	second := summary.Payees[1]
+	if second.Payee != "Ah Seng Plumbing" || second.TransactionCount != 1 || !second.Total.Equal(decimal.NewFromInt(150)) {
+		t.Errorf("Expected Ah Seng Plumbing x1 totalling 150, got %s x%d totalling %s", second.Payee, second.TransactionCount, second.Total)
+	}
+}
+
+func TestDashboardService_GetTaxReport(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	medicalID, charityID := int32(10), int32(11)
+	medical, charity := "Medical", "Charity"
+	txns := []struct {
+		name       string
+		categoryID *int32
+		category   *string
+		amount     int64
+		deductible bool
+		date       time.Time
+	}{
+		{"Clinic visit", &medicalID, &medical, 120, true, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"Prescription", &medicalID, &medical, 80, true, time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC)},
+		{"Donation", &charityID, &charity, 50, true, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"Vitamins", &medicalID, &medical, 40, false, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC)},
+		{"Last year's clinic", &medicalID, &medical, 300, true, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	for i, tx := range txns {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            tx.name,
+			CategoryID:      tx.categoryID,
+			CategoryName:    tx.category,
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: tx.date,
+			IsPaid:          true,
+			IsTaxDeductible: tx.deductible,
+		})
+	}
+
+	report, err := dashboardService.GetTaxReport(1, 2026)
+	if err != nil {
+		t.Fatalf("GetTaxReport() error = %v", err)
+	}
+
+	if !report.Total.Equal(decimal.NewFromInt(250)) || report.TransactionCount != 3 {
+		t.Errorf("Expected 3 deductible transactions totalling 250, got %d totalling %s", report.TransactionCount, report.Total)
+	}
+	if len(report.Categories) != 2 {
+		t.Fatalf("Expected 2 category rows, got %d: %+v", len(report.Categories), report.Categories)
+	}
+
+	first := report.Categories[0]
+	if first.CategoryName != "Medical" || first.TransactionCount != 2 || !first.Total.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected Medical x2 totalling 200 across months, got %s x%d totalling %s", first.CategoryName, first.TransactionCount, first.Total)
+	}
+	second := report.Categories[1]
+	if second.CategoryName != "Charity" || second.TransactionCount != 1 || !second.Total.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("Expected Charity x1 totalling 50, got %s x%d totalling %s", second.CategoryName, second.TransactionCount, second.Total)
+	}
+}
+
+func TestDashboardService_GetMonthlyObligationsTotal(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+	dashboardService.SetLoanService(NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo))
+	dashboardService.SetTransactionService(NewTransactionService(transactionRepo, accountRepo, nil))
+
+	loanID := int32(1)
+	templateID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: 1, ProviderID: 1, ItemName: "Phone", NumMonths: 6, FirstPaymentYear: 2026, FirstPaymentMonth: 1})
+
+	// Unpaid loan payment
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 1, WorkspaceID: 1, AccountID: 1, Name: "Phone", Amount: decimal.NewFromInt(250),
+		Type: domain.TransactionTypeExpense, TransactionDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), LoanID: &loanID,
+	})
+	// Unpaid recurring expense
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 2, WorkspaceID: 1, AccountID: 1, Name: "Rent", Amount: decimal.NewFromInt(1200),
+		Type: domain.TransactionTypeExpense, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), TemplateID: &templateID,
+	})
+	// Paid recurring expense is no longer owed
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 3, WorkspaceID: 1, AccountID: 1, Name: "Internet", Amount: decimal.NewFromInt(100),
+		Type: domain.TransactionTypeExpense, TransactionDate: time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), TemplateID: &templateID, IsPaid: true,
+	})
+	// CC statement balance to settle this month
+	transactionRepo.GetCCMetricsFn = func(workspaceID int32, startDate, endDate time.Time) (*domain.CCMetrics, error) {
+		return &domain.CCMetrics{Pending: decimal.NewFromInt(40), Outstanding: decimal.NewFromInt(320), Purchases: decimal.Zero}, nil
+	}
+
+	obligations, err := dashboardService.GetMonthlyObligationsTotal(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("GetMonthlyObligationsTotal() error = %v", err)
+	}
+
+	if !obligations.Breakdown.LoanCommitments.Equal(decimal.NewFromInt(250)) {
+		t.Errorf("Expected loan commitments 250, got %s", obligations.Breakdown.LoanCommitments)
+	}
+	if !obligations.Breakdown.RecurringExpenses.Equal(decimal.NewFromInt(1200)) {
+		t.Errorf("Expected recurring expenses 1200, got %s", obligations.Breakdown.RecurringExpenses)
+	}
+	if !obligations.Breakdown.CCStatements.Equal(decimal.NewFromInt(320)) {
+		t.Errorf("Expected CC statements 320, got %s", obligations.Breakdown.CCStatements)
+	}
+	if !obligations.Total.Equal(decimal.NewFromInt(1770)) {
+		t.Errorf("Expected total 1770, got %s", obligations.Total)
+	}
+}
+
+func newVelocityTestService(transactionRepo *testutil.MockTransactionRepository) *DashboardService {
+	accountRepo := testutil.NewMockAccountRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	return NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+}
+
+func TestDashboardService_GetSpendingVelocity_MidMonth(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	pairID := uuid.New()
+	txns := []struct {
+		amount   int64
+		txType   domain.TransactionType
+		day      int
+		transfer *uuid.UUID
+	}{
+		{120, domain.TransactionTypeExpense, 2, nil},
+		{80, domain.TransactionTypeExpense, 5, nil},
+		{100, domain.TransactionTypeExpense, 10, nil},
+		{5000, domain.TransactionTypeIncome, 1, nil},     // income is not spending
+		{400, domain.TransactionTypeExpense, 8, &pairID}, // transfer out is not spending
+		{250, domain.TransactionTypeExpense, 20, nil},    // after today
+	}
+	for i, tx := range txns {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            "Spend",
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            tx.txType,
+			TransactionDate: time.Date(2026, 4, tx.day, 0, 0, 0, 0, time.UTC),
+			TransferPairID:  tx.transfer,
+		})
+	}
+
+	// Evening of April 10 in the workspace timezone
+	now := time.Date(2026, 4, 10, 21, 0, 0, 0, time.UTC)
+	velocity, err := dashboardService.GetSpendingVelocityAt(1, 2026, 4, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if velocity.DaysElapsed != 10 || velocity.DaysInMonth != 30 {
+		t.Errorf("Expected 10 of 30 days elapsed, got %d of %d", velocity.DaysElapsed, velocity.DaysInMonth)
+	}
+	if !velocity.SpentSoFar.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("Expected spent so far 300, got %s", velocity.SpentSoFar)
+	}
+	if !velocity.AverageDailySpend.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("Expected average daily spend 30, got %s", velocity.AverageDailySpend)
+	}
+	if !velocity.ProjectedTotal.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("Expected projected total 900, got %s", velocity.ProjectedTotal)
+	}
+}
+
+func TestDashboardService_GetSpendingVelocity_DayOne(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Breakfast",
+		Amount:          decimal.NewFromInt(12),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	now := time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)
+	velocity, err := dashboardService.GetSpendingVelocityAt(1, 2026, 1, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if velocity.DaysElapsed != 1 {
+		t.Errorf("Expected 1 day elapsed, got %d", velocity.DaysElapsed)
+	}
+	if !velocity.AverageDailySpend.Equal(decimal.NewFromInt(12)) {
+		t.Errorf("Expected average daily spend 12, got %s", velocity.AverageDailySpend)
+	}
+	if !velocity.ProjectedTotal.Equal(decimal.NewFromInt(372)) {
+		t.Errorf("Expected projected total 372, got %s", velocity.ProjectedTotal)
+	}
+
+	// Nothing has elapsed in a future month
+	future, err := dashboardService.GetSpendingVelocityAt(1, 2026, 2, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if future.DaysElapsed != 0 || !future.ProjectedTotal.IsZero() {
+		t.Errorf("Expected no elapsed days and zero projection for a future month, got %d and %s", future.DaysElapsed, future.ProjectedTotal)
+	}
+}
+
+func TestDashboardService_GetLargestTransactions(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	pairID := uuid.New()
+	txns := []struct {
+		name     string
+		amount   int64
+		txType   domain.TransactionType
+		day      int
+		transfer *uuid.UUID
+	}{
+		{"Groceries", 120, domain.TransactionTypeExpense, 2, nil},
+		{"Rent", 1500, domain.TransactionTypeExpense, 1, nil},
+		{"Coffee", 5, domain.TransactionTypeExpense, 3, nil},
+		{"Phone", 800, domain.TransactionTypeExpense, 9, nil},
+		{"Salary", 5000, domain.TransactionTypeIncome, 25, nil},         // income is not spending
+		{"To savings", 2000, domain.TransactionTypeExpense, 8, &pairID}, // transfer out is not spending
+		{"Dinner", 90, domain.TransactionTypeExpense, 14, nil},
+	}
+	for i, tx := range txns {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            tx.name,
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            tx.txType,
+			TransactionDate: time.Date(2026, 1, tx.day, 0, 0, 0, 0, time.UTC),
+			TransferPairID:  tx.transfer,
+		})
+	}
+
+	largest, err := dashboardService.GetLargestTransactions(1, 2026, 1, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"Rent", "Phone", "Groceries"}
+	if len(largest) != len(expected) {
+		t.Fatalf("Expected %d transactions, got %d", len(expected), len(largest))
+	}
+	for i, name := range expected {
+		if largest[i].Name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, largest[i].Name)
+		}
+	}
+
+	// Limit is clamped, so an oversized request returns every expense
+	all, err := dashboardService.GetLargestTransactions(1, 2026, 1, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("Expected 5 expenses, got %d", len(all))
+	}
+	for _, txn := range all {
+		if txn.Type == domain.TransactionTypeIncome || txn.TransferPairID != nil {
+			t.Errorf("Expected income and transfers to be excluded, got %s", txn.Name)
+		}
+	}
+}
+
+func TestDashboardService_GetSpendableCash(t *testing.T) {
+	accountRepo := testutil.NewMockAccountRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	monthRepo := testutil.NewMockMonthRepository()
+	loanPaymentRepo := testutil.NewMockLoanPaymentRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	monthService := NewMonthService(monthRepo, transactionRepo, calcService)
+	dashboardService := NewDashboardService(accountRepo, transactionRepo, loanPaymentRepo, monthService, calcService)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Bank", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, InitialBalance: decimal.NewFromInt(1000)})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Wallet", Template: domain.TemplateCash, AccountType: domain.AccountTypeAsset, InitialBalance: decimal.NewFromInt(50)})
+	// Templates without the spendable flag, such as investment accounts, are left out
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 1, Name: "Brokerage", Template: domain.AccountTemplate("investment"), AccountType: domain.AccountTypeAsset, InitialBalance: decimal.NewFromInt(5000)})
+	accountRepo.AddAccount(&domain.Account{ID: 4, WorkspaceID: 1, Name: "Visa", Template: domain.TemplateCreditCard, AccountType: domain.AccountTypeLiability})
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeExpense, TransactionDate: time.Now(), IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: 1, AccountID: 4, Name: "Flight", Amount: decimal.NewFromInt(700), Type: domain.TransactionTypeExpense, TransactionDate: time.Now()})
+
+	spendable, err := dashboardService.GetSpendableCash(1, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Bank 1000 - 200 plus cash 50; the investment balance and the CC debt do not count
+	if !spendable.Equal(decimal.NewFromInt(850)) {
+		t.Errorf("Expected spendable cash 850, got %s", spendable)
+	}
+
+	// Configured templates replace the defaults for this service only
+	bankOnly, err := domain.ParseSpendableTemplates([]string{string(domain.TemplateBank)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	dashboardService.SetSpendableTemplates(bankOnly)
+	spendable, err = dashboardService.GetSpendableCash(1, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !spendable.Equal(decimal.NewFromInt(800)) {
+		t.Errorf("Expected bank-only spendable cash 800, got %s", spendable)
+	}
+
+	if _, err := domain.ParseSpendableTemplates([]string{"investment"}); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+}
+
+func TestDashboardService_ComputeDebtToIncome(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	loanID := int32(1)
+	templateID := int32(1)
+	pairID := uuid.New()
+	jan := func(day int) time.Time { return time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Salary", Amount: decimal.NewFromInt(4000),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(1), IsPaid: true,
+	})
+	// Paid and unpaid obligations both count
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Phone", Amount: decimal.NewFromInt(300),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(5), LoanID: &loanID, IsPaid: true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 102, WorkspaceID: 1, AccountID: 1, Name: "Rent", Amount: decimal.NewFromInt(1100),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(10), TemplateID: &templateID,
+	})
+	// Everyday spending and transfers are not obligations
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 103, WorkspaceID: 1, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(250),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(12),
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 104, WorkspaceID: 1, AccountID: 2, Name: "Transfer in", Amount: decimal.NewFromInt(500),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(15), TransferPairID: &pairID,
+	})
+
+	// A refund is not income
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 105, WorkspaceID: 1, AccountID: 1, Name: "Returned shoes", Amount: decimal.NewFromInt(400),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(18), IsPaid: true, IsRefund: true,
+	})
+
+	ratio, err := dashboardService.ComputeDebtToIncome(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("ComputeDebtToIncome() error = %v", err)
+	}
+	// (300 + 1100) / 4000 = 35%
+	if !ratio.Equal(decimal.NewFromInt(35)) {
+		t.Errorf("Expected ratio 35, got %s", ratio)
+	}
+}
+
+func TestDashboardService_ComputeDebtToIncome_NoIncome(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	loanID := int32(1)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Phone", Amount: decimal.NewFromInt(300),
+		Type: domain.TransactionTypeExpense, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), LoanID: &loanID,
+	})
+
+	if _, err := dashboardService.ComputeDebtToIncome(1, 2026, 1); !errors.Is(err, ErrNoIncome) {
+		t.Errorf("Expected ErrNoIncome, got %v", err)
+	}
+}
+
+func TestDashboardService_ComputeSavingsRate(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	pairID := uuid.New()
+	jan := func(day int) time.Time { return time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Salary", Amount: decimal.NewFromInt(4000),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(1), IsPaid: true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Rent", Amount: decimal.NewFromInt(2000),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(5), IsPaid: true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 102, WorkspaceID: 1, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(1000),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(12),
+	})
+	// Transfers move money without earning or spending it
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 103, WorkspaceID: 1, AccountID: 1, Name: "To savings", Amount: decimal.NewFromInt(800),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(15), TransferPairID: &pairID,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 104, WorkspaceID: 1, AccountID: 2, Name: "From checking", Amount: decimal.NewFromInt(800),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(15), TransferPairID: &pairID,
+	})
+
+	rate, err := dashboardService.ComputeSavingsRate(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("ComputeSavingsRate() error = %v", err)
+	}
+	// (4000 - 3000) / 4000 = 25%
+	if !rate.Equal(decimal.NewFromInt(25)) {
+		t.Errorf("Expected savings rate 25, got %s", rate)
+	}
+
+	// A refund nets against expense instead of counting as income
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 105, WorkspaceID: 1, AccountID: 1, Name: "Returned shoes", Amount: decimal.NewFromInt(400),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(18), IsPaid: true, IsRefund: true,
+	})
+	rate, err = dashboardService.ComputeSavingsRate(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("ComputeSavingsRate() error = %v", err)
+	}
+	// (4000 - (3000 - 400)) / 4000 = 35%
+	if !rate.Equal(decimal.NewFromInt(35)) {
+		t.Errorf("Expected savings rate 35 with the refund, got %s", rate)
+	}
+}
+
+func TestDashboardService_ComputeSavingsRate_Overspent(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	jan := func(day int) time.Time { return time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC) }
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Salary", Amount: decimal.NewFromInt(2000),
+		Type: domain.TransactionTypeIncome, TransactionDate: jan(1), IsPaid: true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Holiday", Amount: decimal.NewFromInt(2500),
+		Type: domain.TransactionTypeExpense, TransactionDate: jan(20), IsPaid: true,
+	})
+
+	rate, err := dashboardService.ComputeSavingsRate(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("ComputeSavingsRate() error = %v", err)
+	}
+	// (2000 - 2500) / 2000 = -25%
+	if !rate.Equal(decimal.NewFromInt(-25)) {
+		t.Errorf("Expected savings rate -25, got %s", rate)
+	}
+}
+
+func TestDashboardService_ComputeSavingsRate_NoIncome(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	dashboardService := newVelocityTestService(transactionRepo)
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Groceries", Amount: decimal.NewFromInt(300),
+		Type: domain.TransactionTypeExpense, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+	})
+
+	if _, err := dashboardService.ComputeSavingsRate(1, 2026, 1); !errors.Is(err, ErrNoIncome) {
+		t.Errorf("Expected ErrNoIncome, got %v", err)
+	}
+}
@@ -13,11 +13,14 @@ import (
 
 // LoanPaymentService handles loan payment business logic
 type LoanPaymentService struct {
-	pool           *pgxpool.Pool
-	paymentRepo    domain.LoanPaymentRepository
-	loanRepo       domain.LoanRepository
-	providerRepo   domain.LoanProviderRepository
-	eventPublisher websocket.EventPublisher
+	pool            *pgxpool.Pool
+	paymentRepo     domain.LoanPaymentRepository
+	loanRepo        domain.LoanRepository
+	providerRepo    domain.LoanProviderRepository
+	transactionRepo domain.TransactionRepository
+	eventPublisher  websocket.EventPublisher
+
+	transactionService *TransactionService
 }
 
 // NewLoanPaymentService creates a new LoanPaymentService
@@ -35,6 +38,41 @@ func (s *LoanPaymentService) SetEventPublisher(publisher websocket.EventPublishe
 	s.eventPublisher = publisher
 }
 
+// SetTransactionRepository sets the transaction repository used to keep loan completion in sync
+func (s *LoanPaymentService) SetTransactionRepository(transactionRepo domain.TransactionRepository) {
+	s.transactionRepo = transactionRepo
+}
+
+// SetTransactionService sets the transaction service used to round up paid installments
+func (s *LoanPaymentService) SetTransactionService(transactionService *TransactionService) {
+	s.transactionService = transactionService
+}
+
+// syncRoundUps keeps round-up savings in step with payments whose paid state changed
+func (s *LoanPaymentService) syncRoundUps(workspaceID int32, paymentIDs []int32) error {
+	if s.transactionService == nil || s.transactionRepo == nil {
+		return nil
+	}
+	transactions := make([]*domain.Transaction, 0, len(paymentIDs))
+	for _, id := range paymentIDs {
+		tx, err := s.transactionRepo.GetByID(workspaceID, id)
+		if err != nil {
+			return err
+		}
+		transactions = append(transactions, tx)
+	}
+	return s.transactionService.SyncRoundUps(workspaceID, transactions)
+}
+
+// syncCompletion re-syncs completion for the loans owning the given payments
+func (s *LoanPaymentService) syncCompletion(workspaceID int32, payments []*domain.LoanPayment) error {
+	loanIDs := make([]int32, len(payments))
+	for i, p := range payments {
+		loanIDs[i] = p.LoanID
+	}
+	return syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, loanIDs)
+}
+
 // publishEvent publishes a WebSocket event if a publisher is configured
 func (s *LoanPaymentService) publishEvent(workspaceID int32, event websocket.Event) {
 	if s.eventPublisher != nil {
@@ -106,7 +144,17 @@ func (s *LoanPaymentService) TogglePaymentPaid(workspaceID int32, loanID int32,
 		}
 	}
 
-	return s.paymentRepo.TogglePaid(paymentID, paid, paidDate)
+	updated, err := s.paymentRepo.TogglePaid(paymentID, paid, paidDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncRoundUps(workspaceID, []int32{updated.ID}); err != nil {
+		return nil, err
+	}
+	if err := s.syncCompletion(workspaceID, []*domain.LoanPayment{updated}); err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
 // GetPaymentsByMonth retrieves all loan payments due in a specific month for a workspace
@@ -207,6 +255,12 @@ func (s *LoanPaymentService) PayMonth(ctx context.Context, workspaceID int32, pr
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
+	if err := s.syncRoundUps(workspaceID, paymentIDs); err != nil {
+		return nil, err
+	}
+	if err := s.syncCompletion(workspaceID, expectedPayments); err != nil {
+		return nil, err
+	}
 
 	// 8. Get next payable month
 	var nextPayableMonth *string
@@ -226,6 +280,58 @@ func (s *LoanPaymentService) PayMonth(ctx context.Context, workspaceID int32, pr
 	}, nil
 }
 
+// PreviewConsolidatedMonth shows which loans contribute to a provider's consolidated payment
+// for the given month, without marking anything paid. Only unpaid payments are included,
+// matching what PayMonth would settle.
+func (s *LoanPaymentService) PreviewConsolidatedMonth(workspaceID int32, providerID int32, month string) (*domain.ConsolidatedPreview, error) {
+	provider, err := s.providerRepo.GetByID(workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.PaymentMode != domain.PaymentModeConsolidatedMonthly {
+		return nil, domain.ErrProviderNotConsolidated
+	}
+
+	year, monthNum, err := parseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepo.GetUnpaidPaymentsByProviderMonth(workspaceID, providerID, int32(year), int32(monthNum))
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.ConsolidatedPreview{
+		ProviderID:  providerID,
+		Month:       formatMonth(year, monthNum),
+		Items:       make([]*domain.ConsolidatedPreviewItem, 0, len(payments)),
+		TotalAmount: decimal.Zero,
+	}
+	loans := make(map[int32]*domain.Loan)
+	for _, p := range payments {
+		loan, ok := loans[p.LoanID]
+		if !ok {
+			loan, err = s.loanRepo.GetByID(workspaceID, p.LoanID)
+			if err != nil {
+				return nil, err
+			}
+			loans[p.LoanID] = loan
+		}
+		preview.Items = append(preview.Items, &domain.ConsolidatedPreviewItem{
+			PaymentID:     p.ID,
+			LoanID:        p.LoanID,
+			ItemName:      loan.ItemName,
+			PaymentNumber: p.PaymentNumber,
+			TotalPayments: loan.NumMonths,
+			Amount:        p.Amount,
+		})
+		preview.TotalAmount = preview.TotalAmount.Add(p.Amount)
+	}
+
+	return preview, nil
+}
+
 // ValidatePayMonth validates whether a month can be paid for a provider
 // without actually performing the payment. Used for pre-validation.
 func (s *LoanPaymentService) ValidatePayMonth(ctx context.Context, workspaceID int32, providerID int32, month string, paymentIDs []int32) error {
@@ -353,76 +459,29 @@ func generateMonthRange(startYear, startMonth, endYear, endMonth int) []string {
 // Validates consecutive months: all months from start to end must be present.
 // Only works for providers with payment_mode = 'consolidated_monthly'.
 func (s *LoanPaymentService) PayRange(ctx context.Context, workspaceID int32, providerID int32, startMonth string, endMonth string, paymentIDs []int32) (*domain.PayRangeResult, error) {
-	// 1. Validate provider exists and belongs to workspace
-	provider, err := s.providerRepo.GetByID(workspaceID, providerID)
-	if err != nil {
-		return nil, err
-	}
-
-	// 2. Validate provider uses consolidated_monthly mode
-	if provider.PaymentMode != domain.PaymentModeConsolidatedMonthly {
-		return nil, domain.ErrProviderNotConsolidated
-	}
-
-	// 3. Parse start and end months
-	startYear, startMonthNum, err := parseMonth(startMonth)
+	// 1-6. Validate provider, month range and sequential enforcement
+	expectedMonths, err := s.validatePayRange(workspaceID, providerID, startMonth, endMonth)
 	if err != nil {
 		return nil, err
 	}
 
-	endYear, endMonthNum, err := parseMonth(endMonth)
-	if err != nil {
-		return nil, err
-	}
-
-	// 4. Validate end month is after start month
-	if compareMonths(endYear, endMonthNum, startYear, startMonthNum) <= 0 {
-		return nil, domain.ErrEndMonthBeforeStart
-	}
-
-	// 5. Validate sequential enforcement (start month = earliest unpaid)
-	earliestUnpaid, err := s.paymentRepo.GetEarliestUnpaidMonth(workspaceID, providerID)
-	if err != nil {
-		return nil, err
-	}
-	if earliestUnpaid == nil {
-		return nil, domain.ErrNoUnpaidMonths
-	}
-
-	if startYear != int(earliestUnpaid.Year) || startMonthNum != int(earliestUnpaid.Month) {
-		return nil, domain.ErrMustPayEarlierMonth{
-			Expected:  formatMonth(int(earliestUnpaid.Year), int(earliestUnpaid.Month)),
-			Requested: startMonth,
-		}
-	}
-
-	// 6. Generate expected month range
-	expectedMonths := generateMonthRange(startYear, startMonthNum, endYear, endMonthNum)
-
 	// 7. Validate payment IDs and check they cover all months in range
 	if len(paymentIDs) == 0 {
 		return nil, domain.ErrPaymentIDsInvalid
 	}
 
 	// Collect all unpaid payments for the entire range and validate
-	allExpectedPayments := make([]*domain.LoanPayment, 0)
-	for _, month := range expectedMonths {
-		year, monthNum, _ := parseMonth(month)
-		payments, err := s.paymentRepo.GetUnpaidPaymentsByProviderMonth(workspaceID, providerID, int32(year), int32(monthNum))
-		if err != nil {
-			return nil, err
-		}
-		if len(payments) == 0 {
-			// No payments for this month - gap detected
-			return nil, domain.ErrCannotSkipMonth{Skipped: month}
-		}
-		allExpectedPayments = append(allExpectedPayments, payments...)
+	paymentsByMonth, err := s.unpaidPaymentsForRange(workspaceID, providerID, expectedMonths)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build map of expected payment IDs
 	expectedIDMap := make(map[int32]bool)
-	for _, p := range allExpectedPayments {
-		expectedIDMap[p.ID] = true
+	for _, payments := range paymentsByMonth {
+		for _, p := range payments {
+			expectedIDMap[p.ID] = true
+		}
 	}
 
 	// Verify all provided payment IDs are in the expected list
@@ -448,6 +507,16 @@ func (s *LoanPaymentService) PayRange(ctx context.Context, workspaceID int32, pr
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
+	if err := s.syncRoundUps(workspaceID, paymentIDs); err != nil {
+		return nil, err
+	}
+	var rangePayments []*domain.LoanPayment
+	for _, payments := range paymentsByMonth {
+		rangePayments = append(rangePayments, payments...)
+	}
+	if err := s.syncCompletion(workspaceID, rangePayments); err != nil {
+		return nil, err
+	}
 
 	// 10. Get next payable month
 	var nextPayableMonth *string
@@ -480,6 +549,109 @@ func (s *LoanPaymentService) PayRange(ctx context.Context, workspaceID int32, pr
 	return result, nil
 }
 
+// PreviewPayRange reports what PayRange would settle for a span of months without marking
+// anything paid. It applies the same validation, so sequencing and gap errors surface here first.
+func (s *LoanPaymentService) PreviewPayRange(ctx context.Context, workspaceID int32, providerID int32, startMonth string, endMonth string) (*domain.PayRangePreview, error) {
+	months, err := s.validatePayRange(workspaceID, providerID, startMonth, endMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentsByMonth, err := s.unpaidPaymentsForRange(workspaceID, providerID, months)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.PayRangePreview{
+		Months:      make([]domain.PayRangePreviewMonth, len(months)),
+		TotalAmount: decimal.Zero,
+	}
+	for i, month := range months {
+		entry := domain.PayRangePreviewMonth{
+			Month:       month,
+			PaymentIDs:  make([]int32, len(paymentsByMonth[i])),
+			TotalAmount: decimal.Zero,
+		}
+		for j, p := range paymentsByMonth[i] {
+			entry.PaymentIDs[j] = p.ID
+			entry.TotalAmount = entry.TotalAmount.Add(p.Amount)
+		}
+		preview.Months[i] = entry
+		preview.PaymentCount += len(entry.PaymentIDs)
+		preview.TotalAmount = preview.TotalAmount.Add(entry.TotalAmount)
+	}
+
+	return preview, nil
+}
+
+// validatePayRange checks the provider is consolidated, the range is well-formed and
+// starts at the earliest unpaid month. Returns the months covered, in order.
+func (s *LoanPaymentService) validatePayRange(workspaceID int32, providerID int32, startMonth string, endMonth string) ([]string, error) {
+	// Validate provider exists and belongs to workspace
+	provider, err := s.providerRepo.GetByID(workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate provider uses consolidated_monthly mode
+	if provider.PaymentMode != domain.PaymentModeConsolidatedMonthly {
+		return nil, domain.ErrProviderNotConsolidated
+	}
+
+	// Parse start and end months
+	startYear, startMonthNum, err := parseMonth(startMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	endYear, endMonthNum, err := parseMonth(endMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate end month is after start month
+	if compareMonths(endYear, endMonthNum, startYear, startMonthNum) <= 0 {
+		return nil, domain.ErrEndMonthBeforeStart
+	}
+
+	// Validate sequential enforcement (start month = earliest unpaid)
+	earliestUnpaid, err := s.paymentRepo.GetEarliestUnpaidMonth(workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if earliestUnpaid == nil {
+		return nil, domain.ErrNoUnpaidMonths
+	}
+
+	if startYear != int(earliestUnpaid.Year) || startMonthNum != int(earliestUnpaid.Month) {
+		return nil, domain.ErrMustPayEarlierMonth{
+			Expected:  formatMonth(int(earliestUnpaid.Year), int(earliestUnpaid.Month)),
+			Requested: startMonth,
+		}
+	}
+
+	return generateMonthRange(startYear, startMonthNum, endYear, endMonthNum), nil
+}
+
+// unpaidPaymentsForRange loads each month's unpaid payments, in the order of months.
+// A month with nothing unpaid is a gap and returns ErrCannotSkipMonth.
+func (s *LoanPaymentService) unpaidPaymentsForRange(workspaceID int32, providerID int32, months []string) ([][]*domain.LoanPayment, error) {
+	paymentsByMonth := make([][]*domain.LoanPayment, len(months))
+	for i, month := range months {
+		year, monthNum, _ := parseMonth(month)
+		payments, err := s.paymentRepo.GetUnpaidPaymentsByProviderMonth(workspaceID, providerID, int32(year), int32(monthNum))
+		if err != nil {
+			return nil, err
+		}
+		if len(payments) == 0 {
+			// No payments for this month - gap detected
+			return nil, domain.ErrCannotSkipMonth{Skipped: month}
+		}
+		paymentsByMonth[i] = payments
+	}
+	return paymentsByMonth, nil
+}
+
 // UnpayMonth atomically marks all loan payments for a specific month as unpaid.
 // Validates reverse sequential enforcement: can only unpay the latest paid month.
 // Only works for providers with payment_mode = 'consolidated_monthly'.
@@ -552,6 +724,12 @@ func (s *LoanPaymentService) UnpayMonth(ctx context.Context, workspaceID int32,
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
+	if err := s.syncRoundUps(workspaceID, paymentIDs); err != nil {
+		return nil, err
+	}
+	if err := s.syncCompletion(workspaceID, paidPayments); err != nil {
+		return nil, err
+	}
 
 	// 9. Build result - the unpaid month is now the previous payable month
 	result := &domain.UnpayMonthResult{
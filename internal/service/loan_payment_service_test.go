@@ -1170,3 +1170,135 @@ func TestGetEarliestUnpaidMonth_AllPaid(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 }
+
+func TestPreviewConsolidatedMonth_SumsLoanContributions(t *testing.T) {
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc := NewLoanPaymentService(nil, paymentRepo, loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerID := int32(1)
+
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          providerID,
+		WorkspaceID: workspaceID,
+		Name:        "Atome",
+		PaymentMode: domain.PaymentModeConsolidatedMonthly,
+	})
+	loanRepo.AddLoan(&domain.Loan{ID: 10, WorkspaceID: workspaceID, ProviderID: providerID, ItemName: "Phone", NumMonths: 6})
+	loanRepo.AddLoan(&domain.Loan{ID: 11, WorkspaceID: workspaceID, ProviderID: providerID, ItemName: "Headphones", NumMonths: 3})
+
+	paymentRepo.GetUnpaidPaymentsByProviderMonthFn = func(wsID, provID, year, month int32) ([]*domain.LoanPayment, error) {
+		assert.Equal(t, int32(2026), year)
+		assert.Equal(t, int32(1), month)
+		return []*domain.LoanPayment{
+			{ID: 100, LoanID: 10, PaymentNumber: 2, Amount: decimal.NewFromFloat(250.50), DueYear: 2026, DueMonth: 1},
+			{ID: 200, LoanID: 11, PaymentNumber: 1, Amount: decimal.NewFromFloat(99.50), DueYear: 2026, DueMonth: 1},
+		}, nil
+	}
+
+	preview, err := svc.PreviewConsolidatedMonth(workspaceID, providerID, "2026-01")
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01", preview.Month)
+	assert.Len(t, preview.Items, 2)
+	assert.True(t, preview.TotalAmount.Equal(decimal.NewFromInt(350)), "expected total 350, got %s", preview.TotalAmount)
+	assert.Equal(t, "Phone", preview.Items[0].ItemName)
+	assert.Equal(t, int32(6), preview.Items[0].TotalPayments)
+	assert.Equal(t, "Headphones", preview.Items[1].ItemName)
+	assert.Equal(t, int32(200), preview.Items[1].PaymentID)
+}
+
+func TestPreviewConsolidatedMonth_ProviderNotConsolidated(t *testing.T) {
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc := NewLoanPaymentService(nil, paymentRepo, loanRepo, providerRepo)
+
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Shopee",
+		PaymentMode: domain.PaymentModePerItem,
+	})
+
+	preview, err := svc.PreviewConsolidatedMonth(1, 1, "2026-01")
+	assert.ErrorIs(t, err, domain.ErrProviderNotConsolidated)
+	assert.Nil(t, preview)
+}
+
+func TestPreviewPayRange_FourMonthTotal(t *testing.T) {
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc := NewLoanPaymentService(nil, paymentRepo, loanRepo, providerRepo)
+
+	ctx := context.Background()
+	workspaceID := int32(1)
+	providerID := int32(1)
+
+	providerRepo.Providers[providerID] = &domain.LoanProvider{
+		ID:          providerID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Provider",
+		PaymentMode: domain.PaymentModeConsolidatedMonthly,
+	}
+	paymentRepo.GetEarliestUnpaidMonthFn = func(wID int32, pID int32) (*domain.EarliestUnpaidMonth, error) {
+		return &domain.EarliestUnpaidMonth{Year: 2026, Month: 2}, nil
+	}
+	// Two loans through May, one ending in March
+	paymentRepo.GetUnpaidPaymentsByProviderMonthFn = func(wID int32, pID int32, year int32, month int32) ([]*domain.LoanPayment, error) {
+		payments := []*domain.LoanPayment{
+			{ID: month*10 + 1, LoanID: 10, Amount: decimal.NewFromInt(100)},
+		}
+		if month <= 3 {
+			payments = append(payments, &domain.LoanPayment{ID: month*10 + 2, LoanID: 11, Amount: decimal.NewFromInt(50)})
+		}
+		return payments, nil
+	}
+
+	preview, err := svc.PreviewPayRange(ctx, workspaceID, providerID, "2026-02", "2026-05")
+	assert.NoError(t, err)
+	assert.Len(t, preview.Months, 4)
+	assert.Equal(t, "2026-02", preview.Months[0].Month)
+	assert.Equal(t, "2026-05", preview.Months[3].Month)
+	assert.True(t, preview.Months[0].TotalAmount.Equal(decimal.NewFromInt(150)))
+	assert.True(t, preview.Months[3].TotalAmount.Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, []int32{21, 22}, preview.Months[0].PaymentIDs)
+	assert.Equal(t, 6, preview.PaymentCount)
+	assert.True(t, preview.TotalAmount.Equal(decimal.NewFromInt(500)), "expected total 500, got %s", preview.TotalAmount)
+}
+
+func TestPreviewPayRange_GapInMonths(t *testing.T) {
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc := NewLoanPaymentService(nil, paymentRepo, loanRepo, providerRepo)
+
+	ctx := context.Background()
+	workspaceID := int32(1)
+	providerID := int32(1)
+
+	providerRepo.Providers[providerID] = &domain.LoanProvider{
+		ID:          providerID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Provider",
+		PaymentMode: domain.PaymentModeConsolidatedMonthly,
+	}
+	paymentRepo.GetEarliestUnpaidMonthFn = func(wID int32, pID int32) (*domain.EarliestUnpaidMonth, error) {
+		return &domain.EarliestUnpaidMonth{Year: 2026, Month: 2}, nil
+	}
+	// Feb has payments, March has none (gap)
+	paymentRepo.GetUnpaidPaymentsByProviderMonthFn = func(wID int32, pID int32, year int32, month int32) ([]*domain.LoanPayment, error) {
+		if year == 2026 && month == 2 {
+			return []*domain.LoanPayment{{ID: 1, LoanID: 10, Amount: decimal.NewFromInt(100)}}, nil
+		}
+		return []*domain.LoanPayment{}, nil
+	}
+
+	preview, err := svc.PreviewPayRange(ctx, workspaceID, providerID, "2026-02", "2026-05")
+	skipErr, ok := err.(domain.ErrCannotSkipMonth)
+	assert.True(t, ok, "Expected ErrCannotSkipMonth error type, got %v", err)
+	assert.Equal(t, "2026-03", skipErr.Skipped)
+	assert.Nil(t, preview)
+}
@@ -10,13 +10,29 @@ import (
 
 // LoanProviderService handles loan provider business logic
 type LoanProviderService struct {
-	providerRepo   domain.LoanProviderRepository
-	eventPublisher websocket.EventPublisher
+	providerRepo     domain.LoanProviderRepository
+	eventPublisher   websocket.EventPublisher
+	maxDecimalPlaces int32
+	maxLoanMonths    int32
 }
 
 // NewLoanProviderService creates a new LoanProviderService
 func NewLoanProviderService(providerRepo domain.LoanProviderRepository) *LoanProviderService {
-	return &LoanProviderService{providerRepo: providerRepo}
+	return &LoanProviderService{
+		providerRepo:     providerRepo,
+		maxDecimalPlaces: domain.DefaultMaxDecimalPlaces,
+		maxLoanMonths:    domain.DefaultMaxLoanMonths,
+	}
+}
+
+// SetMaxDecimalPlaces sets the decimal places accepted for fees and rates
+func (s *LoanProviderService) SetMaxDecimalPlaces(places int32) {
+	s.maxDecimalPlaces = places
+}
+
+// SetMaxLoanMonths sets the loan term cap that promo periods must fit within
+func (s *LoanProviderService) SetMaxLoanMonths(months int32) {
+	s.maxLoanMonths = months
 }
 
 // SetEventPublisher sets the event publisher for real-time updates
@@ -29,6 +45,12 @@ type CreateProviderInput struct {
 	Name                string
 	CutoffDay           int32
 	DefaultInterestRate decimal.Decimal
+	MonthlyFee          decimal.Decimal
+	FeeMode             string // Optional: "separate" (default) or "folded"
+	PromoInterestRate   decimal.Decimal
+	PromoMonths         int32 // 0 means no promotional period
+	// Optional: "flat" (default) or "reducing_balance"
+	DefaultInterestMethod string
 }
 
 // CreateProvider creates a new loan provider
@@ -55,11 +77,42 @@ func (s *LoanProviderService) CreateProvider(workspaceID int32, input CreateProv
 		return nil, domain.ErrInterestRateTooHigh
 	}
 
+	// Validate monthly fee and how it is applied
+	if err := s.validateMonthlyFee(input.MonthlyFee); err != nil {
+		return nil, err
+	}
+	feeMode := input.FeeMode
+	if feeMode == "" {
+		feeMode = domain.FeeModeSeparate
+	}
+	if !domain.IsValidFeeMode(feeMode) {
+		return nil, domain.ErrInvalidFeeMode
+	}
+
+	// Validate promotional interest period
+	if err := s.validatePromoInterest(input.PromoInterestRate, input.PromoMonths); err != nil {
+		return nil, err
+	}
+
+	// Validate the interest method loans inherit
+	interestMethod := input.DefaultInterestMethod
+	if interestMethod == "" {
+		interestMethod = domain.InterestMethodFlat
+	}
+	if !domain.IsValidInterestMethod(interestMethod) {
+		return nil, domain.ErrInvalidInterestMethod
+	}
+
 	provider := &domain.LoanProvider{
-		WorkspaceID:         workspaceID,
-		Name:                name,
-		CutoffDay:           input.CutoffDay,
-		DefaultInterestRate: input.DefaultInterestRate,
+		WorkspaceID:           workspaceID,
+		Name:                  name,
+		CutoffDay:             input.CutoffDay,
+		DefaultInterestRate:   input.DefaultInterestRate,
+		MonthlyFee:            input.MonthlyFee,
+		FeeMode:               feeMode,
+		PromoInterestRate:     input.PromoInterestRate,
+		PromoMonths:           input.PromoMonths,
+		DefaultInterestMethod: interestMethod,
 	}
 
 	return s.providerRepo.Create(provider)
@@ -77,10 +130,15 @@ func (s *LoanProviderService) GetProviderByID(workspaceID int32, id int32) (*dom
 
 // UpdateProviderInput contains input for updating a loan provider
 type UpdateProviderInput struct {
-	Name                string
-	CutoffDay           int32
-	DefaultInterestRate decimal.Decimal
-	PaymentMode         *string // Optional pointer - nil means preserve existing
+	Name                  string
+	CutoffDay             int32
+	DefaultInterestRate   decimal.Decimal
+	PaymentMode           *string          // Optional pointer - nil means preserve existing
+	MonthlyFee            *decimal.Decimal // Optional pointer - nil means preserve existing
+	FeeMode               *string          // Optional pointer - nil means preserve existing
+	PromoInterestRate     *decimal.Decimal // Optional pointer - nil means preserve existing
+	PromoMonths           *int32           // Optional pointer - nil means preserve existing
+	DefaultInterestMethod *string          // Optional pointer - nil means preserve existing
 }
 
 // UpdateProvider updates a loan provider
@@ -125,6 +183,39 @@ func (s *LoanProviderService) UpdateProvider(workspaceID int32, id int32, input
 		existing.PaymentMode = *input.PaymentMode
 	}
 
+	// Handle optional monthly fee update
+	if input.MonthlyFee != nil {
+		if err := s.validateMonthlyFee(*input.MonthlyFee); err != nil {
+			return nil, err
+		}
+		existing.MonthlyFee = *input.MonthlyFee
+	}
+	if input.FeeMode != nil {
+		if !domain.IsValidFeeMode(*input.FeeMode) {
+			return nil, domain.ErrInvalidFeeMode
+		}
+		existing.FeeMode = *input.FeeMode
+	}
+
+	// Handle optional promotional interest update
+	if input.PromoInterestRate != nil {
+		existing.PromoInterestRate = *input.PromoInterestRate
+	}
+	if input.PromoMonths != nil {
+		existing.PromoMonths = *input.PromoMonths
+	}
+	if err := s.validatePromoInterest(existing.PromoInterestRate, existing.PromoMonths); err != nil {
+		return nil, err
+	}
+
+	// Handle optional default interest method update
+	if input.DefaultInterestMethod != nil {
+		if !domain.IsValidInterestMethod(*input.DefaultInterestMethod) {
+			return nil, domain.ErrInvalidInterestMethod
+		}
+		existing.DefaultInterestMethod = *input.DefaultInterestMethod
+	}
+
 	updated, err := s.providerRepo.Update(existing)
 	if err != nil {
 		return nil, err
@@ -138,6 +229,51 @@ func (s *LoanProviderService) UpdateProvider(workspaceID int32, id int32, input
 	return updated, nil
 }
 
+// UpsertProviderByName creates a provider or updates the existing one whose name matches after
+// trimming and case folding, so repeated imports don't create duplicates. The bool reports creation.
+func (s *LoanProviderService) UpsertProviderByName(workspaceID int32, input CreateProviderInput) (*domain.LoanProvider, bool, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, false, domain.ErrLoanProviderNameEmpty
+	}
+
+	providers, err := s.providerRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, existing := range providers {
+		if !strings.EqualFold(strings.TrimSpace(existing.Name), name) {
+			continue
+		}
+		update := UpdateProviderInput{
+			Name:                name,
+			CutoffDay:           input.CutoffDay,
+			DefaultInterestRate: input.DefaultInterestRate,
+			MonthlyFee:          &input.MonthlyFee,
+			PromoInterestRate:   &input.PromoInterestRate,
+			PromoMonths:         &input.PromoMonths,
+		}
+		if input.FeeMode != "" {
+			update.FeeMode = &input.FeeMode
+		}
+		if input.DefaultInterestMethod != "" {
+			update.DefaultInterestMethod = &input.DefaultInterestMethod
+		}
+		updated, err := s.UpdateProvider(workspaceID, existing.ID, update)
+		if err != nil {
+			return nil, false, err
+		}
+		return updated, false, nil
+	}
+
+	created, err := s.CreateProvider(workspaceID, input)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
 // DeleteProvider soft-deletes a loan provider
 func (s *LoanProviderService) DeleteProvider(workspaceID int32, id int32) error {
 	// Verify provider exists before deleting
@@ -151,3 +287,22 @@ func (s *LoanProviderService) DeleteProvider(workspaceID int32, id int32) error
 
 	return s.providerRepo.SoftDelete(workspaceID, id)
 }
+
+// validateMonthlyFee checks that a provider fee is non-negative and within the allowed precision
+func (s *LoanProviderService) validateMonthlyFee(fee decimal.Decimal) error {
+	if fee.LessThan(decimal.Zero) {
+		return domain.ErrInvalidMonthlyFee
+	}
+	return domain.ValidatePrecision(s.maxDecimalPlaces, fee)
+}
+
+// validatePromoInterest checks the promotional rate is 0-100% and the period fits a loan term
+func (s *LoanProviderService) validatePromoInterest(rate decimal.Decimal, months int32) error {
+	if rate.LessThan(decimal.Zero) || rate.GreaterThan(decimal.NewFromInt(100)) {
+		return domain.ErrInvalidPromoRate
+	}
+	if months < 0 || months > s.maxLoanMonths {
+		return domain.ErrLoanMonthsLimit{Err: domain.ErrInvalidPromoMonths, MaxMonths: s.maxLoanMonths}
+	}
+	return domain.ValidatePrecision(s.maxDecimalPlaces, rate)
+}
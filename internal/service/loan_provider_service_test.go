@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -204,6 +205,56 @@ func TestCreateProvider_InvalidInterestRate_Negative(t *testing.T) {
 	}
 }
 
+func TestCreateProvider_MonthlyFee(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	provider, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:       "Atome",
+		CutoffDay:  15,
+		MonthlyFee: decimal.NewFromInt(5),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !provider.MonthlyFee.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Expected monthly fee 5, got %s", provider.MonthlyFee.String())
+	}
+	if provider.FeeMode != domain.FeeModeSeparate {
+		t.Errorf("Expected default fee mode %q, got %q", domain.FeeModeSeparate, provider.FeeMode)
+	}
+}
+
+func TestCreateProvider_NegativeMonthlyFee(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	_, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:       "Atome",
+		CutoffDay:  15,
+		MonthlyFee: decimal.NewFromInt(-5),
+	})
+	if err != domain.ErrInvalidMonthlyFee {
+		t.Errorf("Expected ErrInvalidMonthlyFee, got %v", err)
+	}
+}
+
+func TestCreateProvider_InvalidFeeMode(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	_, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:       "Atome",
+		CutoffDay:  15,
+		MonthlyFee: decimal.NewFromInt(5),
+		FeeMode:    "weekly",
+	})
+	if err != domain.ErrInvalidFeeMode {
+		t.Errorf("Expected ErrInvalidFeeMode, got %v", err)
+	}
+}
+
 func TestCreateProvider_ZeroInterestRate(t *testing.T) {
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	providerService := NewLoanProviderService(providerRepo)
@@ -725,6 +776,75 @@ func TestUpdateProvider_WrongWorkspace(t *testing.T) {
 	}
 }
 
+// UpsertProviderByName tests
+
+func TestUpsertProviderByName_CreatesOnFirstCall(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	workspaceID := int32(1)
+	input := CreateProviderInput{
+		Name:                "Bank ABC",
+		CutoffDay:           15,
+		DefaultInterestRate: decimal.NewFromFloat(1.5),
+	}
+
+	provider, created, err := providerService.UpsertProviderByName(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !created {
+		t.Error("Expected created to be true on first call")
+	}
+	if provider.Name != "Bank ABC" || provider.CutoffDay != 15 {
+		t.Errorf("Expected Bank ABC with cutoff 15, got %s with cutoff %d", provider.Name, provider.CutoffDay)
+	}
+}
+
+func TestUpsertProviderByName_UpdatesExistingWithoutDuplicate(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	workspaceID := int32(1)
+	first, _, err := providerService.UpsertProviderByName(workspaceID, CreateProviderInput{
+		Name:                "Bank ABC",
+		CutoffDay:           15,
+		DefaultInterestRate: decimal.NewFromFloat(1.5),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error on first call, got %v", err)
+	}
+
+	// Same name after trimming and case folding
+	second, created, err := providerService.UpsertProviderByName(workspaceID, CreateProviderInput{
+		Name:                "  bank abc ",
+		CutoffDay:           20,
+		DefaultInterestRate: decimal.NewFromFloat(2),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error on second call, got %v", err)
+	}
+
+	if created {
+		t.Error("Expected created to be false on second call")
+	}
+	if second.ID != first.ID {
+		t.Errorf("Expected existing provider %d to be updated, got %d", first.ID, second.ID)
+	}
+	if second.CutoffDay != 20 || !second.DefaultInterestRate.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("Expected cutoff 20 and rate 2, got cutoff %d and rate %s", second.CutoffDay, second.DefaultInterestRate)
+	}
+
+	providers, err := providerService.GetProviders(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(providers) != 1 {
+		t.Errorf("Expected 1 provider after upserting the same name twice, got %d", len(providers))
+	}
+}
+
 // DeleteProvider tests
 
 func TestDeleteProvider_Success(t *testing.T) {
@@ -809,3 +929,52 @@ func TestDeleteProvider_AlreadyDeleted(t *testing.T) {
 		t.Errorf("Expected ErrLoanProviderNotFound for already deleted provider, got %v", err)
 	}
 }
+
+func TestCreateProvider_InvalidPromoInterest(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	_, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:              "Kredivo",
+		CutoffDay:         25,
+		PromoInterestRate: decimal.NewFromInt(101),
+		PromoMonths:       3,
+	})
+	if err != domain.ErrInvalidPromoRate {
+		t.Errorf("Expected ErrInvalidPromoRate, got %v", err)
+	}
+
+	_, err = providerService.CreateProvider(1, CreateProviderInput{
+		Name:        "Kredivo",
+		CutoffDay:   25,
+		PromoMonths: -1,
+	})
+	if !errors.Is(err, domain.ErrInvalidPromoMonths) {
+		t.Errorf("Expected ErrInvalidPromoMonths, got %v", err)
+	}
+}
+
+func TestCreateProvider_InvalidInterestMethod(t *testing.T) {
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerService := NewLoanProviderService(providerRepo)
+
+	_, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:                  "Kredivo",
+		CutoffDay:             25,
+		DefaultInterestMethod: "compound",
+	})
+	if err != domain.ErrInvalidInterestMethod {
+		t.Errorf("Expected ErrInvalidInterestMethod, got %v", err)
+	}
+
+	provider, err := providerService.CreateProvider(1, CreateProviderInput{
+		Name:      "Kredivo",
+		CutoffDay: 25,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if provider.DefaultInterestMethod != domain.InterestMethodFlat {
+		t.Errorf("Expected default interest method flat, got %s", provider.DefaultInterestMethod)
+	}
+}
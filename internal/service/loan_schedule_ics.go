@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+)
+
+// icsTextEscaper escapes iCalendar TEXT values (RFC 5545 section 3.3.11)
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+// RenderLoanScheduleICS writes an iCalendar feed with one all-day VEVENT per scheduled payment
+// UIDs are derived from the loan and payment transaction so calendar clients update
+// existing events on re-import instead of duplicating them. Amounts are shown with the
+// currency's minor units.
+func RenderLoanScheduleICS(loan *domain.Loan, schedule []domain.ScheduledPayment, currency string, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(bw, format+"\r\n", args...)
+	}
+
+	places := domain.CurrencyMinorUnits(currency)
+	stamp := loan.CreatedAt.UTC().Format("20060102T150405Z")
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Fortuna//Loan Schedule//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:%s", icsTextEscaper.Replace(loan.ItemName))
+	for _, payment := range schedule {
+		line("BEGIN:VEVENT")
+		line("UID:loan-%d-payment-%d@fortuna", loan.ID, payment.TransactionID)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", payment.DueDate.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", payment.DueDate.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:%s", icsTextEscaper.Replace(fmt.Sprintf("%s payment: %s", loan.ItemName, payment.Amount.StringFixed(places))))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	return bw.Flush()
+}
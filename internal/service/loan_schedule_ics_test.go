@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/shopspring/decimal"
+)
+
+func TestRenderLoanScheduleICS_OneEventPerUnpaidPayment(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(7)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Phone, 128GB",
+		NumMonths:   3,
+		CreatedAt:   time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC),
+	})
+	dates := []time.Time{
+		time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC),
+	}
+	for i, date := range dates {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Phone",
+			Amount:          decimal.NewFromInt(100),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: date,
+			IsPaid:          i == 0, // March is already paid
+			LoanID:          &loanID,
+		})
+	}
+
+	loan, schedule, err := service.GetUnpaidSchedule(workspaceID, loanID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderLoanScheduleICS(loan, schedule, domain.DefaultCurrency, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ics := buf.String()
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("Expected a CRLF-delimited VCALENDAR, got %q", ics)
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 2 {
+		t.Fatalf("Expected 2 VEVENTs, got %d", n)
+	}
+	april := strings.Index(ics, "DTSTART;VALUE=DATE:20240425\r\n")
+	may := strings.Index(ics, "DTSTART;VALUE=DATE:20240525\r\n")
+	if april < 0 || may < 0 || april > may {
+		t.Errorf("Expected April then May DTSTARTs, got %q", ics)
+	}
+	if strings.Contains(ics, "20240325") {
+		t.Error("Expected paid March payment to be excluded")
+	}
+	if !strings.Contains(ics, `SUMMARY:Phone\, 128GB payment: 100.00`) {
+		t.Errorf("Expected escaped summary with amount, got %q", ics)
+	}
+}
+
+func TestRenderLoanScheduleICS_UsesCurrencyMinorUnits(t *testing.T) {
+	loan := &domain.Loan{ID: 7, ItemName: "Phone", CreatedAt: time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC)}
+	schedule := []domain.ScheduledPayment{
+		{TransactionID: 1, DueDate: time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(10000)},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderLoanScheduleICS(loan, schedule, "JPY", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "SUMMARY:Phone payment: 10000\r\n") {
+		t.Errorf("Expected a whole-yen amount, got %q", buf.String())
+	}
+}
+
+func TestGetUnpaidSchedule_FoldsFeeIntoPayment(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(7)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: workspaceID, ItemName: "Phone", NumMonths: 3})
+
+	txns := GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 3, 2024, 4, false, nil, nil, decimal.NewFromInt(5), domain.FeeModeSeparate)
+	for i, txn := range txns {
+		txn.ID = int32(i + 1)
+		transactionRepo.AddTransaction(txn)
+	}
+
+	_, schedule, err := service.GetUnpaidSchedule(workspaceID, loanID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(schedule) != 3 {
+		t.Fatalf("Expected 3 scheduled payments, got %d", len(schedule))
+	}
+	for _, entry := range schedule {
+		if !entry.Amount.Equal(decimal.NewFromInt(105)) {
+			t.Errorf("Expected payment of 105 including the fee, got %s", entry.Amount.String())
+		}
+	}
+}
+
+func TestGetUnpaidSchedule_LoanNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	_, _, err := service.GetUnpaidSchedule(1, 999)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
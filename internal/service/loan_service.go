@@ -2,32 +2,86 @@ package service
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 )
 
+// txBeginner starts database transactions; satisfied by *pgxpool.Pool
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // LoanService handles loan business logic
 type LoanService struct {
-	pool            *pgxpool.Pool
+	pool            txBeginner
 	loanRepo        domain.LoanRepository
 	providerRepo    domain.LoanProviderRepository
 	transactionRepo domain.TransactionRepository // v2: transactions replace loan_payments
 	accountRepo     domain.AccountRepository     // v2: to look up account type for CC handling
+	monthRepo       domain.MonthRepository       // to refuse restores into closed months
+	paymentRepo     domain.LoanPaymentRepository // earliest unpaid month for the consolidated sequential rule
+
+	transactionService *TransactionService
+
+	maxDecimalPlaces int32
+	maxLoanMonths    int32
 }
 
 // NewLoanService creates a new LoanService
 func NewLoanService(pool *pgxpool.Pool, loanRepo domain.LoanRepository, providerRepo domain.LoanProviderRepository, transactionRepo domain.TransactionRepository, accountRepo domain.AccountRepository) *LoanService {
-	return &LoanService{
-		pool:            pool,
-		loanRepo:        loanRepo,
-		providerRepo:    providerRepo,
-		transactionRepo: transactionRepo,
-		accountRepo:     accountRepo,
+	s := &LoanService{
+		loanRepo:         loanRepo,
+		providerRepo:     providerRepo,
+		transactionRepo:  transactionRepo,
+		accountRepo:      accountRepo,
+		maxDecimalPlaces: domain.DefaultMaxDecimalPlaces,
+		maxLoanMonths:    domain.DefaultMaxLoanMonths,
+	}
+	// Keep a nil pool as a nil interface so the pool != nil checks still hold
+	if pool != nil {
+		s.pool = pool
+	}
+	return s
+}
+
+// SetMaxDecimalPlaces sets the decimal places accepted for loan amounts and rates
+func (s *LoanService) SetMaxDecimalPlaces(places int32) {
+	s.maxDecimalPlaces = places
+}
+
+// SetMaxLoanMonths sets the largest NumMonths accepted when creating or previewing a loan
+func (s *LoanService) SetMaxLoanMonths(months int32) {
+	s.maxLoanMonths = months
+}
+
+// SetMonthRepository sets the month repository used to check for closed months
+func (s *LoanService) SetMonthRepository(monthRepo domain.MonthRepository) {
+	s.monthRepo = monthRepo
+}
+
+// SetPaymentRepository sets the loan payment repository used to enforce sequential payment
+func (s *LoanService) SetPaymentRepository(paymentRepo domain.LoanPaymentRepository) {
+	s.paymentRepo = paymentRepo
+}
+
+// SetTransactionService sets the transaction service used to round up paid installments
+func (s *LoanService) SetTransactionService(transactionService *TransactionService) {
+	s.transactionService = transactionService
+}
+
+// syncRoundUps keeps round-up savings in step with installments whose paid state changed
+func (s *LoanService) syncRoundUps(workspaceID int32, transactions []*domain.Transaction) error {
+	if s.transactionService == nil {
+		return nil
 	}
+	return s.transactionService.SyncRoundUps(workspaceID, transactions)
 }
 
 // CreateLoanInput contains input for creating a loan
@@ -37,49 +91,32 @@ type CreateLoanInput struct {
 	TotalAmount      decimal.Decimal
 	NumMonths        int32
 	PurchaseDate     time.Time
-	InterestRate     *decimal.Decimal  // Optional override, uses provider default if nil
+	InterestRate     *decimal.Decimal // Optional override, uses provider default if nil
+	InterestMethod   string           // Optional override, uses provider default if empty
 	Notes            *string
 	PaymentAmounts   []decimal.Decimal // Optional custom amounts for each payment
 	AccountID        int32             // Required: the account to use for loan payments
 	SettlementIntent *string           // Optional: "immediate" or "deferred" for CC accounts
+	// Optional override of the cutoff-derived first payment month; both must be set (0 means derive)
+	FirstPaymentYear  int
+	FirstPaymentMonth int
+	// Optional down payment, recorded as a separate paid expense; only the rest of TotalAmount is financed
+	DownPaymentAmount    decimal.Decimal
+	DownPaymentAccountID int32 // Account the down payment came from; 0 uses AccountID
+	// Skip the FindSimilarLoans warning in CreateLoanWithDuplicateCheck
+	ConfirmDuplicate bool
 }
 
 // CreateLoan creates a new loan with calculated values and generates payment schedule
 func (s *LoanService) CreateLoan(workspaceID int32, input CreateLoanInput) (*domain.Loan, error) {
-	// Validate item name
-	itemName := strings.TrimSpace(input.ItemName)
-	if itemName == "" {
-		return nil, domain.ErrLoanItemNameEmpty
-	}
-	if len(itemName) > 200 {
-		return nil, domain.ErrLoanItemNameTooLong
-	}
-
-	// Validate amount
-	if input.TotalAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, domain.ErrLoanAmountInvalid
-	}
-
-	// Validate months
-	if input.NumMonths < 1 {
-		return nil, domain.ErrLoanMonthsInvalid
-	}
-
-	// Validate provider exists
-	if input.ProviderID <= 0 {
-		return nil, domain.ErrLoanProviderInvalid
-	}
-
-	// Validate account ID and get account type
-	if input.AccountID <= 0 {
-		return nil, domain.ErrLoanAccountInvalid
-	}
-
-	// v2: Look up account to determine if it's a CC account
-	account, err := s.accountRepo.GetByID(workspaceID, input.AccountID)
+	plan, provider, account, err := s.validateLoanPlan(workspaceID, input)
 	if err != nil {
-		return nil, domain.ErrLoanAccountInvalid
+		return nil, err
 	}
+	if !plan.Valid() {
+		return nil, plan.Issues[0]
+	}
+	itemName := strings.TrimSpace(input.ItemName)
 
 	// Determine settlement intent based on account type
 	// For CC accounts: use provided intent or default to "deferred"
@@ -95,40 +132,49 @@ func (s *LoanService) CreateLoan(workspaceID int32, input CreateLoanInput) (*dom
 		}
 	}
 
-	provider, err := s.providerRepo.GetByID(workspaceID, input.ProviderID)
-	if err != nil {
-		if err == domain.ErrLoanProviderNotFound {
-			return nil, domain.ErrLoanProviderInvalid
-		}
-		return nil, err
-	}
-
 	// Use provided interest rate or default from provider
 	interestRate := provider.DefaultInterestRate
 	if input.InterestRate != nil {
 		interestRate = *input.InterestRate
 	}
 
-	// Calculate monthly payment
-	monthlyPayment := CalculateMonthlyPayment(input.TotalAmount, interestRate, int(input.NumMonths))
+	// Calculate monthly payment on the financed amount under the resolved interest method
+	financed := plan.FinancedAmount
+	monthlyPayment := CalculateMonthlyPaymentForMethod(plan.InterestMethod, financed, interestRate, int(input.NumMonths))
 
-	// Calculate first payment month based on cutoff day
-	firstPaymentYear, firstPaymentMonth := CalculateFirstPaymentMonth(input.PurchaseDate, int(provider.CutoffDay))
+	paymentAmounts := loanPaymentAmounts(provider, plan.InterestMethod, financed, interestRate, int(input.NumMonths), input.PaymentAmounts)
+
+	// Down payment is made at purchase on its own account, separate from the installments
+	var downPaymentAccount *domain.Account
+	if input.DownPaymentAmount.GreaterThan(decimal.Zero) {
+		downPaymentAccount = account
+		if input.DownPaymentAccountID > 0 && input.DownPaymentAccountID != input.AccountID {
+			downPaymentAccount, err = s.accountRepo.GetByID(workspaceID, input.DownPaymentAccountID)
+			if err != nil {
+				return nil, domain.ErrLoanDownPaymentAccountInvalid
+			}
+		}
+	}
+
+	// First payment month: cutoff-derived unless overridden (resolved during validation)
+	firstPaymentYear, firstPaymentMonth := plan.FirstPaymentYear, plan.FirstPaymentMonth
 
 	loan := &domain.Loan{
 		WorkspaceID:       workspaceID,
 		ProviderID:        input.ProviderID,
 		ItemName:          itemName,
-		TotalAmount:       input.TotalAmount,
+		TotalAmount:       financed,
 		NumMonths:         input.NumMonths,
 		PurchaseDate:      input.PurchaseDate,
 		InterestRate:      interestRate,
+		InterestMethod:    plan.InterestMethod,
 		MonthlyPayment:    monthlyPayment,
 		FirstPaymentYear:  int32(firstPaymentYear),
 		FirstPaymentMonth: int32(firstPaymentMonth),
 		AccountID:         input.AccountID,
 		SettlementIntent:  settlementIntent, // Use computed intent based on account type
 		Notes:             input.Notes,
+		DownPaymentAmount: input.DownPaymentAmount,
 	}
 
 	// Use transaction if pool is available (for transaction generation)
@@ -158,8 +204,13 @@ func (s *LoanService) CreateLoan(workspaceID int32, input CreateLoanInput) (*dom
 			int(createdLoan.FirstPaymentMonth),
 			isCC,
 			settlementIntent,
-			input.PaymentAmounts,
+			paymentAmounts,
+			provider.MonthlyFee,
+			provider.FeeMode,
 		)
+		if downPaymentAccount != nil {
+			transactions = append(transactions, newDownPaymentTransaction(createdLoan, downPaymentAccount))
+		}
 
 		// Create transactions in DB transaction
 		if _, err := s.transactionRepo.CreateBatchTx(tx, transactions); err != nil {
@@ -175,24 +226,354 @@ func (s *LoanService) CreateLoan(workspaceID int32, input CreateLoanInput) (*dom
 	}
 
 	// Fallback without transaction (for backwards compatibility in tests)
-	return s.loanRepo.Create(loan)
+	createdLoan, err := s.loanRepo.Create(loan)
+	if err != nil {
+		return nil, err
+	}
+	if downPaymentAccount != nil {
+		if _, err := s.transactionRepo.Create(context.Background(), newDownPaymentTransaction(createdLoan, downPaymentAccount)); err != nil {
+			return nil, err
+		}
+	}
+	return createdLoan, nil
+}
+
+// newDownPaymentTransaction builds the down payment expense of a new loan. It is linked to the loan
+// under its own source so the schedule and progress queries leave it out. On a CC account it is a
+// pending charge with a settlement intent, like any other CC purchase.
+func newDownPaymentTransaction(loan *domain.Loan, account *domain.Account) *domain.Transaction {
+	loanID := loan.ID
+	txn := &domain.Transaction{
+		WorkspaceID:     loan.WorkspaceID,
+		AccountID:       account.ID,
+		Name:            loan.ItemName + " (down payment)",
+		Amount:          loan.DownPaymentAmount,
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: loan.PurchaseDate,
+		IsPaid:          true,
+		Source:          domain.TransactionSourceLoanDownPayment,
+		LoanID:          &loanID,
+	}
+	if account.Template == domain.TemplateCreditCard {
+		// Same card as the loan follows the loan's intent; another card defaults to deferred
+		intent := domain.SettlementIntentDeferred
+		if account.ID == loan.AccountID && loan.SettlementIntent != nil {
+			intent = domain.SettlementIntent(*loan.SettlementIntent)
+		}
+		txn.SettlementIntent = &intent
+		txn.IsPaid = false // CC purchases start pending (billedAt = nil, isPaid = false)
+	}
+	return txn
+}
+
+// CreateLoanWithDuplicateCheck creates a loan like CreateLoan and also returns the existing loans
+// that look like the same purchase, so the caller can warn about a likely re-entry.
+// The check is skipped when input.ConfirmDuplicate is set.
+func (s *LoanService) CreateLoanWithDuplicateCheck(workspaceID int32, input CreateLoanInput) (*domain.Loan, []*domain.Loan, error) {
+	similar := []*domain.Loan{}
+	if !input.ConfirmDuplicate {
+		var err error
+		similar, err = s.FindSimilarLoans(workspaceID, input)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	loan, err := s.CreateLoan(workspaceID, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loan, similar, nil
+}
+
+// ValidateLoanPlan runs CreateLoan's validation without persisting anything and returns
+// the schedule the plan would generate along with every issue found, not just the first
+func (s *LoanService) ValidateLoanPlan(workspaceID int32, input CreateLoanInput) (*domain.PlanValidation, error) {
+	plan, _, _, err := s.validateLoanPlan(workspaceID, input)
+	return plan, err
+}
+
+// CompareInterestMethods prices a loan plan under both flat and reducing-balance interest
+// without persisting anything. Custom payment amounts and the interest method override are
+// ignored, and issues that don't affect pricing (item name, accounts) are not reported.
+func (s *LoanService) CompareInterestMethods(workspaceID int32, input CreateLoanInput) (*domain.InterestComparison, error) {
+	input.PaymentAmounts = nil
+	quote := func(method string) (*domain.PlanValidation, domain.InterestMethodQuote, error) {
+		input.InterestMethod = method
+		plan, _, _, err := s.validateLoanPlan(workspaceID, input)
+		if err != nil {
+			return nil, domain.InterestMethodQuote{}, err
+		}
+		for _, issue := range plan.Issues {
+			if affectsPricing(issue) {
+				return nil, domain.InterestMethodQuote{}, issue
+			}
+		}
+		totalRepaid := decimal.Zero
+		for _, entry := range plan.Schedule {
+			totalRepaid = totalRepaid.Add(entry.Amount)
+		}
+		return plan, domain.InterestMethodQuote{
+			InterestMethod: method,
+			MonthlyPayment: plan.MonthlyPayment,
+			TotalRepaid:    totalRepaid,
+			TotalInterest:  totalRepaid.Sub(plan.FinancedAmount),
+			Schedule:       plan.Schedule,
+		}, nil
+	}
+
+	plan, flat, err := quote(domain.InterestMethodFlat)
+	if err != nil {
+		return nil, err
+	}
+	_, reducing, err := quote(domain.InterestMethodReducingBalance)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.InterestComparison{
+		InterestRate:    plan.InterestRate,
+		FinancedAmount:  plan.FinancedAmount,
+		NumMonths:       input.NumMonths,
+		Flat:            flat,
+		ReducingBalance: reducing,
+	}, nil
+}
+
+// affectsPricing reports whether a loan plan issue prevents the plan from being priced
+func affectsPricing(issue error) bool {
+	switch issue {
+	case domain.ErrLoanItemNameEmpty, domain.ErrLoanItemNameTooLong,
+		domain.ErrLoanAccountInvalid, domain.ErrLoanDownPaymentAccountInvalid:
+		return false
+	}
+	return true
+}
+
+// FindSimilarLoans returns existing loans that look like a re-entry of input: same provider,
+// item name (ignoring case and surrounding spaces), financed amount and purchase date
+func (s *LoanService) FindSimilarLoans(workspaceID int32, input CreateLoanInput) ([]*domain.Loan, error) {
+	loans, err := s.loanRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored loans hold the financed amount, i.e. the total less any down payment
+	financed := input.TotalAmount.Sub(input.DownPaymentAmount)
+	itemName := strings.TrimSpace(input.ItemName)
+	purchaseDate := input.PurchaseDate.Format("2006-01-02")
+	similar := []*domain.Loan{}
+	for _, loan := range loans {
+		if loan.ProviderID == input.ProviderID &&
+			strings.EqualFold(strings.TrimSpace(loan.ItemName), itemName) &&
+			loan.TotalAmount.Equal(financed) &&
+			loan.PurchaseDate.Format("2006-01-02") == purchaseDate {
+			similar = append(similar, loan)
+		}
+	}
+	return similar, nil
+}
+
+// validateLoanPlan collects the validation issues of a loan plan in the order CreateLoan reports them.
+// The provider and account are returned when they resolved; err is only set for lookup failures
+// other than not-found.
+func (s *LoanService) validateLoanPlan(workspaceID int32, input CreateLoanInput) (*domain.PlanValidation, *domain.LoanProvider, *domain.Account, error) {
+	plan := &domain.PlanValidation{Schedule: []domain.PlanScheduleEntry{}}
+
+	// Validate item name
+	itemName := strings.TrimSpace(input.ItemName)
+	if itemName == "" {
+		plan.Issues = append(plan.Issues, domain.ErrLoanItemNameEmpty)
+	} else if len(itemName) > 200 {
+		plan.Issues = append(plan.Issues, domain.ErrLoanItemNameTooLong)
+	}
+
+	// Validate amount
+	amountValid := input.TotalAmount.GreaterThan(decimal.Zero)
+	if !amountValid {
+		plan.Issues = append(plan.Issues, domain.ErrLoanAmountInvalid)
+	}
+	// Validate down payment: it must leave something to finance
+	downPaymentValid := !input.DownPaymentAmount.IsNegative() &&
+		(!amountValid || input.DownPaymentAmount.LessThan(input.TotalAmount))
+	if !downPaymentValid {
+		plan.Issues = append(plan.Issues, domain.ErrLoanDownPaymentInvalid)
+	}
+	plan.FinancedAmount = input.TotalAmount.Sub(input.DownPaymentAmount)
+
+	precision := []decimal.Decimal{input.TotalAmount, input.DownPaymentAmount}
+	if input.InterestRate != nil {
+		precision = append(precision, *input.InterestRate)
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, append(precision, input.PaymentAmounts...)...); err != nil {
+		plan.Issues = append(plan.Issues, err)
+	}
+
+	// Validate months
+	monthsValid := input.NumMonths >= 1 && input.NumMonths <= s.maxLoanMonths
+	if input.NumMonths < 1 {
+		plan.Issues = append(plan.Issues, domain.ErrLoanMonthsInvalid)
+	} else if input.NumMonths > s.maxLoanMonths {
+		plan.Issues = append(plan.Issues, domain.ErrLoanMonthsLimit{Err: domain.ErrLoanMonthsExceedsMax, MaxMonths: s.maxLoanMonths})
+	}
+
+	// Validate custom amounts: one positive amount per month
+	customValid := len(input.PaymentAmounts) == 0
+	if len(input.PaymentAmounts) > 0 {
+		customValid = len(input.PaymentAmounts) == int(input.NumMonths)
+		if !customValid {
+			plan.Issues = append(plan.Issues, domain.ErrLoanPaymentAmountsCount)
+		}
+		for _, amount := range input.PaymentAmounts {
+			if amount.LessThanOrEqual(decimal.Zero) {
+				plan.Issues = append(plan.Issues, domain.ErrLoanPaymentAmountsInvalid)
+				customValid = false
+				break
+			}
+		}
+	}
+
+	// Validate interest method override
+	if input.InterestMethod != "" && !domain.IsValidInterestMethod(input.InterestMethod) {
+		plan.Issues = append(plan.Issues, domain.ErrInvalidInterestMethod)
+	}
+
+	// Validate provider and account references
+	if input.ProviderID <= 0 {
+		plan.Issues = append(plan.Issues, domain.ErrLoanProviderInvalid)
+	}
+	var account *domain.Account
+	if input.AccountID <= 0 {
+		plan.Issues = append(plan.Issues, domain.ErrLoanAccountInvalid)
+	} else {
+		found, err := s.accountRepo.GetByID(workspaceID, input.AccountID)
+		if err != nil {
+			plan.Issues = append(plan.Issues, domain.ErrLoanAccountInvalid)
+		} else {
+			account = found
+		}
+	}
+	if input.DownPaymentAmount.GreaterThan(decimal.Zero) && input.DownPaymentAccountID > 0 && input.DownPaymentAccountID != input.AccountID {
+		if _, err := s.accountRepo.GetByID(workspaceID, input.DownPaymentAccountID); err != nil {
+			plan.Issues = append(plan.Issues, domain.ErrLoanDownPaymentAccountInvalid)
+		}
+	}
+	if input.ProviderID <= 0 {
+		return plan, nil, account, nil
+	}
+	provider, err := s.providerRepo.GetByID(workspaceID, input.ProviderID)
+	if err != nil {
+		if err == domain.ErrLoanProviderNotFound {
+			plan.Issues = append(plan.Issues, domain.ErrLoanProviderInvalid)
+			return plan, nil, account, nil
+		}
+		return nil, nil, nil, err
+	}
+
+	// Use provided interest rate or default from provider
+	plan.InterestRate = provider.DefaultInterestRate
+	if input.InterestRate != nil {
+		plan.InterestRate = *input.InterestRate
+	}
+	plan.InterestMethod = resolveInterestMethod(provider, input.InterestMethod)
+	firstYear, firstMonth := CalculateFirstPaymentMonth(input.PurchaseDate, int(provider.CutoffDay))
+	if input.FirstPaymentYear != 0 || input.FirstPaymentMonth != 0 {
+		if !validFirstPaymentOverride(input.FirstPaymentYear, input.FirstPaymentMonth, time.Now()) {
+			plan.Issues = append(plan.Issues, domain.ErrLoanFirstPaymentInvalid)
+		} else {
+			firstYear, firstMonth = input.FirstPaymentYear, input.FirstPaymentMonth
+		}
+	}
+	plan.FirstPaymentYear, plan.FirstPaymentMonth = firstYear, firstMonth
+	if !amountValid || !monthsValid || !downPaymentValid {
+		return plan, provider, account, nil
+	}
+
+	plan.ExpectedTotal = CalculateTotalWithInterest(plan.InterestMethod, plan.FinancedAmount, plan.InterestRate, int(input.NumMonths))
+	if provider.PromoMonths > 0 {
+		// The promotional rate lowers what is repaid, so the total follows the promo schedule
+		promoSchedule := loanPaymentAmounts(provider, plan.InterestMethod, plan.FinancedAmount, plan.InterestRate, int(input.NumMonths), nil)
+		plan.ExpectedTotal = decimal.Sum(decimal.Zero, promoSchedule...)
+	}
+	plan.MonthlyPayment = CalculateMonthlyPaymentForMethod(plan.InterestMethod, plan.FinancedAmount, plan.InterestRate, int(input.NumMonths))
+
+	// Custom amounts must cover exactly the total with interest
+	amounts := input.PaymentAmounts
+	if customValid && len(amounts) > 0 {
+		if !decimal.Sum(decimal.Zero, amounts...).Equal(plan.ExpectedTotal) {
+			plan.Issues = append(plan.Issues, domain.ErrLoanPaymentAmountsSum)
+		}
+	} else if !customValid {
+		amounts = nil
+	}
+	amounts = loanPaymentAmounts(provider, plan.InterestMethod, plan.FinancedAmount, plan.InterestRate, int(input.NumMonths), amounts)
+
+	for _, payment := range GeneratePaymentSchedule(0, plan.MonthlyPayment, int(input.NumMonths), firstYear, firstMonth, amounts) {
+		plan.Schedule = append(plan.Schedule, domain.PlanScheduleEntry{
+			PaymentNumber: int(payment.PaymentNumber),
+			Year:          int(payment.DueYear),
+			Month:         int(payment.DueMonth),
+			Amount:        payment.Amount,
+		})
+	}
+
+	return plan, provider, account, nil
+}
+
+// resolveInterestMethod returns a valid override, falling back to the provider's default and then flat
+func resolveInterestMethod(provider *domain.LoanProvider, override string) string {
+	if domain.IsValidInterestMethod(override) {
+		return override
+	}
+	if domain.IsValidInterestMethod(provider.DefaultInterestMethod) {
+		return provider.DefaultInterestMethod
+	}
+	return domain.InterestMethodFlat
+}
+
+// validFirstPaymentOverride checks an explicit first payment month is a real month no earlier
+// than FirstPaymentOverrideToleranceMonths before now's month
+func validFirstPaymentOverride(year, month int, now time.Time) bool {
+	if year < 1 || month < 1 || month > 12 {
+		return false
+	}
+	earliest := now.Year()*12 + int(now.Month()) - 1 - domain.FirstPaymentOverrideToleranceMonths
+	return year*12+month-1 >= earliest
 }
 
 // PreviewLoanInput contains input for previewing loan calculations
 type PreviewLoanInput struct {
-	ProviderID   int32
-	TotalAmount  decimal.Decimal
-	NumMonths    int32
-	PurchaseDate time.Time
-	InterestRate *decimal.Decimal // Optional override, uses provider default if nil
+	ProviderID     int32
+	TotalAmount    decimal.Decimal
+	NumMonths      int32
+	PurchaseDate   time.Time
+	InterestRate   *decimal.Decimal // Optional override, uses provider default if nil
+	InterestMethod string           // Optional override, uses provider default if empty
 }
 
 // PreviewLoanResult contains the calculated values for a loan
 type PreviewLoanResult struct {
-	MonthlyPayment    decimal.Decimal
-	FirstPaymentYear  int
-	FirstPaymentMonth int
-	InterestRate      decimal.Decimal
+	MonthlyPayment          decimal.Decimal
+	MonthlyFee              decimal.Decimal // Provider's flat monthly fee
+	FeeMode                 string
+	EffectiveMonthlyPayment decimal.Decimal // MonthlyPayment + MonthlyFee
+	PromoInterestRate       decimal.Decimal
+	PromoMonths             int
+	PromoMonthlyPayment     decimal.Decimal // Payment during the promo period (equals MonthlyPayment when there is none)
+	FirstPaymentYear        int
+	FirstPaymentMonth       int
+	InterestRate            decimal.Decimal
+	InterestMethod          string
+	Schedule                []PreviewScheduleEntry
+}
+
+// PreviewScheduleEntry is a single month of a previewed payment schedule
+type PreviewScheduleEntry struct {
+	PaymentNumber int
+	Year          int
+	Month         int
+	Payment       decimal.Decimal
+	Fee           decimal.Decimal
+	Total         decimal.Decimal
 }
 
 // PreviewLoan calculates loan values without creating the loan
@@ -214,29 +595,75 @@ func (s *LoanService) PreviewLoan(workspaceID int32, input PreviewLoanInput) (*P
 	if input.TotalAmount.LessThanOrEqual(decimal.Zero) {
 		return nil, domain.ErrLoanAmountInvalid
 	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, input.TotalAmount); err != nil {
+		return nil, err
+	}
+	if input.InterestRate != nil {
+		if err := domain.ValidatePrecision(s.maxDecimalPlaces, *input.InterestRate); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate months
 	if input.NumMonths < 1 {
 		return nil, domain.ErrLoanMonthsInvalid
 	}
+	if input.NumMonths > s.maxLoanMonths {
+		return nil, domain.ErrLoanMonthsLimit{Err: domain.ErrLoanMonthsExceedsMax, MaxMonths: s.maxLoanMonths}
+	}
 
-	// Use provided interest rate or default from provider
+	// Validate interest method override
+	if input.InterestMethod != "" && !domain.IsValidInterestMethod(input.InterestMethod) {
+		return nil, domain.ErrInvalidInterestMethod
+	}
+
+	// Use provided interest rate and method or defaults from provider
 	interestRate := provider.DefaultInterestRate
 	if input.InterestRate != nil {
 		interestRate = *input.InterestRate
 	}
+	interestMethod := resolveInterestMethod(provider, input.InterestMethod)
 
 	// Calculate monthly payment
-	monthlyPayment := CalculateMonthlyPayment(input.TotalAmount, interestRate, int(input.NumMonths))
+	monthlyPayment := CalculateMonthlyPaymentForMethod(interestMethod, input.TotalAmount, interestRate, int(input.NumMonths))
 
 	// Calculate first payment month based on cutoff day
 	firstPaymentYear, firstPaymentMonth := CalculateFirstPaymentMonth(input.PurchaseDate, int(provider.CutoffDay))
 
+	// Promotional period: the first PromoMonths payments use the promo rate
+	promoMonths := min(int(provider.PromoMonths), int(input.NumMonths))
+	promoMonthlyPayment := monthlyPayment
+	var paymentAmounts []decimal.Decimal
+	if promoMonths > 0 {
+		promoMonthlyPayment = CalculateMonthlyPaymentForMethod(interestMethod, input.TotalAmount, provider.PromoInterestRate, int(input.NumMonths))
+		paymentAmounts = CalculatePaymentAmountsForMethod(interestMethod, input.TotalAmount, interestRate, provider.PromoInterestRate, promoMonths, int(input.NumMonths))
+	}
+
+	schedule := make([]PreviewScheduleEntry, input.NumMonths)
+	for i, payment := range GeneratePaymentSchedule(0, monthlyPayment, int(input.NumMonths), firstPaymentYear, firstPaymentMonth, paymentAmounts) {
+		schedule[i] = PreviewScheduleEntry{
+			PaymentNumber: int(payment.PaymentNumber),
+			Year:          int(payment.DueYear),
+			Month:         int(payment.DueMonth),
+			Payment:       payment.Amount,
+			Fee:           provider.MonthlyFee,
+			Total:         payment.Amount.Add(provider.MonthlyFee),
+		}
+	}
+
 	return &PreviewLoanResult{
-		MonthlyPayment:    monthlyPayment,
-		FirstPaymentYear:  firstPaymentYear,
-		FirstPaymentMonth: firstPaymentMonth,
-		InterestRate:      interestRate,
+		MonthlyPayment:          monthlyPayment,
+		MonthlyFee:              provider.MonthlyFee,
+		FeeMode:                 provider.FeeMode,
+		EffectiveMonthlyPayment: monthlyPayment.Add(provider.MonthlyFee),
+		PromoInterestRate:       provider.PromoInterestRate,
+		PromoMonths:             promoMonths,
+		PromoMonthlyPayment:     promoMonthlyPayment,
+		FirstPaymentYear:        firstPaymentYear,
+		FirstPaymentMonth:       firstPaymentMonth,
+		InterestRate:            interestRate,
+		InterestMethod:          interestMethod,
+		Schedule:                schedule,
 	}, nil
 }
 
@@ -256,21 +683,98 @@ func (s *LoanService) GetCompletedLoans(workspaceID int32, currentYear, currentM
 }
 
 // GetLoansWithStats retrieves loans with payment statistics based on filter
-func (s *LoanService) GetLoansWithStats(workspaceID int32, filter domain.LoanFilter) ([]*domain.LoanWithStats, error) {
+// Soft-deleted loans appear only in the "all" listing, and only when opts.IncludeDeleted is set
+func (s *LoanService) GetLoansWithStats(ctx context.Context, workspaceID int32, filter domain.LoanFilter, opts domain.ListOptions) ([]*domain.LoanWithStats, error) {
 	switch filter {
 	case domain.LoanFilterActive:
-		return s.loanRepo.GetActiveWithStats(workspaceID)
+		return s.loanRepo.GetActiveWithStats(ctx, workspaceID)
 	case domain.LoanFilterCompleted:
-		return s.loanRepo.GetCompletedWithStats(workspaceID)
+		return s.loanRepo.GetCompletedWithStats(ctx, workspaceID)
 	default:
-		return s.loanRepo.GetAllWithStats(workspaceID)
+		return s.loanRepo.GetAllWithStats(ctx, workspaceID, opts)
+	}
+}
+
+// GetLoansGroupedByProvider retrieves loans matching filter grouped by provider
+// Groups follow the provider list order; providers without matching loans are omitted
+// and loans whose provider was deleted are collected in a trailing group
+func (s *LoanService) GetLoansGroupedByProvider(ctx context.Context, workspaceID int32, filter domain.LoanFilter) ([]*domain.ProviderLoanGroup, error) {
+	loans, err := s.GetLoansWithStats(ctx, workspaceID, filter, domain.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	providers, err := s.providerRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupByProvider := make(map[int32]*domain.ProviderLoanGroup, len(providers))
+	for _, p := range providers {
+		groupByProvider[p.ID] = &domain.ProviderLoanGroup{
+			ProviderID:       p.ID,
+			ProviderName:     p.Name,
+			TotalOutstanding: decimal.Zero,
+		}
 	}
+	orphaned := &domain.ProviderLoanGroup{ProviderDeleted: true, TotalOutstanding: decimal.Zero}
+
+	for _, loan := range loans {
+		group, ok := groupByProvider[loan.ProviderID]
+		if !ok {
+			group = orphaned
+		}
+		group.Loans = append(group.Loans, loan)
+		group.TotalOutstanding = group.TotalOutstanding.Add(loan.RemainingBalance)
+	}
+
+	groups := make([]*domain.ProviderLoanGroup, 0, len(providers)+1)
+	for _, p := range providers {
+		if group := groupByProvider[p.ID]; len(group.Loans) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	if len(orphaned.Loans) > 0 {
+		groups = append(groups, orphaned)
+	}
+
+	return groups, nil
 }
 
 // GetLoansByProvider retrieves all loans for a specific provider with payment statistics
 // Used by item-based provider modal to display loan items with progress
-func (s *LoanService) GetLoansByProvider(workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
-	return s.loanRepo.GetByProviderWithStats(workspaceID, providerID)
+func (s *LoanService) GetLoansByProvider(ctx context.Context, workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
+	return s.loanRepo.GetByProviderWithStats(ctx, workspaceID, providerID)
+}
+
+// GetProviderPayoff sums the remaining balance of a provider's active loans,
+// i.e. what it would cost to clear the provider today. Completed loans are skipped.
+func (s *LoanService) GetProviderPayoff(workspaceID int32, providerID int32) (*domain.ProviderPayoff, error) {
+	provider, err := s.providerRepo.GetByID(workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := s.loanRepo.GetByProviderWithStats(context.Background(), workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	payoff := &domain.ProviderPayoff{
+		ProviderID:   provider.ID,
+		ProviderName: provider.Name,
+		TotalPayoff:  decimal.Zero,
+		Loans:        []*domain.LoanWithStats{},
+	}
+	for _, loan := range loans {
+		if loan.IsCompleted() {
+			continue
+		}
+		payoff.Loans = append(payoff.Loans, loan)
+		payoff.TotalPayoff = payoff.TotalPayoff.Add(loan.RemainingBalance)
+	}
+
+	return payoff, nil
 }
 
 // GetTransactionsByLoan retrieves all transactions for a specific loan
@@ -289,6 +793,98 @@ func (s *LoanService) GetLoanByID(workspaceID int32, id int32) (*domain.Loan, er
 	return s.loanRepo.GetByID(workspaceID, id)
 }
 
+// GetLoanDetail returns a loan together with its payment schedule, provider and stats.
+// Stats are derived from the schedule the same way the *WithStats queries compute them.
+func (s *LoanService) GetLoanDetail(workspaceID int32, loanID int32) (*domain.LoanDetail, error) {
+	loan, err := s.GetLoanByID(workspaceID, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.transactionRepo.GetByLoanID(workspaceID, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		schedule = []*domain.Transaction{}
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].TransactionDate.Before(schedule[j].TransactionDate)
+	})
+
+	provider, err := s.providerRepo.GetByID(workspaceID, loan.ProviderID)
+	if err != nil {
+		if err != domain.ErrLoanProviderNotFound {
+			return nil, err
+		}
+		provider = nil
+	}
+
+	lastMonthIndex := int(loan.FirstPaymentMonth) - 1 + int(loan.NumMonths) - 1
+	stats := domain.LoanWithStats{
+		Loan:             *loan,
+		LastPaymentYear:  loan.FirstPaymentYear + int32(lastMonthIndex/12),
+		LastPaymentMonth: int32(lastMonthIndex%12) + 1,
+		RemainingBalance: decimal.Zero,
+	}
+	for _, txn := range schedule {
+		// The down payment is listed with the schedule but is not part of the loan's balance
+		if txn.IsLoanDownPayment() {
+			continue
+		}
+		if !txn.IsPaid {
+			stats.RemainingBalance = stats.RemainingBalance.Add(txn.Amount)
+		}
+		if txn.IsLoanFee {
+			continue
+		}
+		stats.TotalCount++
+		if txn.IsPaid {
+			stats.PaidCount++
+		}
+	}
+	stats.Progress = domain.ComputeProgress(decimal.NewFromInt32(stats.PaidCount), decimal.NewFromInt32(stats.TotalCount))
+
+	return &domain.LoanDetail{Loan: stats, Provider: provider, Schedule: schedule}, nil
+}
+
+// GetUnpaidSchedule returns a loan with its unpaid payments ordered by due date
+func (s *LoanService) GetUnpaidSchedule(workspaceID int32, loanID int32) (*domain.Loan, []domain.ScheduledPayment, error) {
+	loan, err := s.GetLoanByID(workspaceID, loanID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txns, err := s.transactionRepo.GetByLoanID(workspaceID, loanID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A separate fee line is folded into the payment due the same month rather than listed on its own
+	schedule := []domain.ScheduledPayment{}
+	fees := make(map[int]decimal.Decimal)
+	for _, txn := range txns {
+		if txn.IsPaid || txn.IsLoanDownPayment() {
+			continue
+		}
+		if txn.IsLoanFee {
+			key := txn.TransactionDate.Year()*12 + int(txn.TransactionDate.Month())
+			fees[key] = fees[key].Add(txn.Amount)
+			continue
+		}
+		schedule = append(schedule, domain.ScheduledPayment{TransactionID: txn.ID, DueDate: txn.TransactionDate, Amount: txn.Amount})
+	}
+	for i := range schedule {
+		key := schedule[i].DueDate.Year()*12 + int(schedule[i].DueDate.Month())
+		schedule[i].Amount = schedule[i].Amount.Add(fees[key])
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].DueDate.Before(schedule[j].DueDate)
+	})
+
+	return loan, schedule, nil
+}
+
 // UpdateLoanInput contains input for updating editable loan fields
 type UpdateLoanInput struct {
 	ItemName   string
@@ -298,7 +894,7 @@ type UpdateLoanInput struct {
 
 // UpdateLoan updates the editable fields (itemName, notes, optionally provider) of a loan
 // Note: Amount, months, and dates are locked after creation
-// Provider can only change if no payments have been made
+// Provider can only change if no payments have been made, and changing it regenerates the schedule
 func (s *LoanService) UpdateLoan(workspaceID int32, id int32, input UpdateLoanInput) (*domain.Loan, error) {
 	// Validate item name
 	itemName := strings.TrimSpace(input.ItemName)
@@ -350,7 +946,15 @@ func (s *LoanService) UpdateLoan(workspaceID int32, id int32, input UpdateLoanIn
 		return nil, err
 	}
 
-	// 7. Cascade payee update to all transactions
+	// 7. A new provider brings its own fee and promotional schedule, so the unpaid payments are
+	// regenerated (a provider can only change before anything is paid)
+	if providerChanging {
+		if err := s.SyncLoanTransactions(updatedLoan, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// 8. Cascade payee update to all transactions
 	// Only cascade if item name or provider actually changed
 	needsCascade := itemName != currentLoan.ItemName || providerChanging
 	if needsCascade {
@@ -362,59 +966,285 @@ func (s *LoanService) UpdateLoan(workspaceID int32, id int32, input UpdateLoanIn
 		}
 	}
 
-	return updatedLoan, nil
-}
+	return updatedLoan, nil
+}
+
+// LoanEditCheck contains edit eligibility information for a loan
+type LoanEditCheck struct {
+	CanChangeProvider    bool `json:"canChangeProvider"`
+	HasPaidTransactions  bool `json:"hasPaidTransactions"`
+}
+
+// GetEditCheck returns edit eligibility for a loan (whether provider can be changed)
+func (s *LoanService) GetEditCheck(workspaceID int32, id int32) (*LoanEditCheck, error) {
+	// Verify loan exists
+	_, err := s.loanRepo.GetByID(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if any transactions are paid
+	hasPaid, err := s.transactionRepo.HasPaidTransactionsByLoan(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoanEditCheck{
+		CanChangeProvider:   !hasPaid,
+		HasPaidTransactions: hasPaid,
+	}, nil
+}
+
+// SyncLoanTransactions regenerates a loan's unpaid transactions from its current schedule
+// Used when a provider change or a missing schedule calls for the payments to be rebuilt.
+// Without custom amounts the payments are derived as CreateLoan does, including the provider's
+// promotional period. Paid transactions are kept as-is and their months are not regenerated;
+// every unpaid transaction is replaced. Reading the paid months and rewriting the schedule share one DB transaction.
+func (s *LoanService) SyncLoanTransactions(loan *domain.Loan, customAmounts []decimal.Decimal) error {
+	workspaceID := loan.WorkspaceID
+
+	provider, err := s.providerRepo.GetByID(workspaceID, loan.ProviderID)
+	if err != nil {
+		return err
+	}
+
+	// A loan without a recorded method (e.g. one built outside CreateLoan) uses the provider default
+	interestMethod := resolveInterestMethod(provider, loan.InterestMethod)
+	paymentAmounts := loanPaymentAmounts(provider, interestMethod, loan.TotalAmount, loan.InterestRate, int(loan.NumMonths), customAmounts)
+
+	// Settlement intent is only set for loans paid from a CC account
+	schedule := GenerateLoanTransactions(
+		workspaceID,
+		loan.ID,
+		loan.AccountID,
+		loan.ItemName,
+		loan.MonthlyPayment,
+		int(loan.NumMonths),
+		int(loan.FirstPaymentYear),
+		int(loan.FirstPaymentMonth),
+		loan.SettlementIntent != nil,
+		loan.SettlementIntent,
+		paymentAmounts,
+		provider.MonthlyFee,
+		provider.FeeMode,
+	)
+	unpaidSchedule := func(existing []*domain.Transaction) []*domain.Transaction {
+		paidMonths := make(map[string]bool)
+		for _, txn := range existing {
+			if txn.IsPaid && !txn.IsLoanDownPayment() {
+				paidMonths[txn.TransactionDate.Format("2006-01")] = true
+			}
+		}
+		unpaid := make([]*domain.Transaction, 0, len(schedule))
+		for _, txn := range schedule {
+			if !paidMonths[txn.TransactionDate.Format("2006-01")] {
+				unpaid = append(unpaid, txn)
+			}
+		}
+		return unpaid
+	}
+
+	if s.pool != nil {
+		ctx := context.Background()
+		tx, err := s.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		existing, err := s.transactionRepo.GetByLoanIDTx(tx, workspaceID, loan.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.transactionRepo.ClearUnpaidTransactionsByLoanTx(tx, workspaceID, loan.ID); err != nil {
+			return err
+		}
+		if _, err := s.transactionRepo.CreateBatchTx(tx, unpaidSchedule(existing)); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+
+	// Fallback without transaction (for backwards compatibility in tests)
+	existing, err := s.transactionRepo.GetByLoanID(workspaceID, loan.ID)
+	if err != nil {
+		return err
+	}
+	for _, txn := range existing {
+		if txn.IsPaid || txn.IsLoanDownPayment() {
+			continue
+		}
+		if err := s.transactionRepo.SoftDelete(workspaceID, txn.ID); err != nil {
+			return err
+		}
+	}
+	for _, txn := range unpaidSchedule(existing) {
+		if _, err := s.transactionRepo.Create(context.Background(), txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loanPaymentAmounts returns the per-payment amounts for a loan's schedule: the custom amounts
+// when given, otherwise the provider's promotional schedule. Nil means every payment is the monthly payment.
+func loanPaymentAmounts(provider *domain.LoanProvider, interestMethod string, financed, interestRate decimal.Decimal, numMonths int, customAmounts []decimal.Decimal) []decimal.Decimal {
+	if len(customAmounts) > 0 {
+		return customAmounts
+	}
+	if provider.PromoMonths > 0 {
+		return CalculatePaymentAmountsForMethod(interestMethod, financed, interestRate, provider.PromoInterestRate, int(provider.PromoMonths), numMonths)
+	}
+	return nil
+}
+
+// DeleteLoan soft-deletes a loan with cascade transaction handling
+// Follows the same pattern as RecurringTemplateServiceImpl.DeleteTemplate:
+// 1. Orphan paid transactions (set loan_id = NULL to keep them in history)
+// 2. Soft delete unpaid transactions (brought back by RestoreLoan)
+// 3. Soft delete the loan record
+func (s *LoanService) DeleteLoan(workspaceID int32, id int32) error {
+	// Verify loan exists before deleting
+	_, err := s.loanRepo.GetByID(workspaceID, id)
+	if err != nil {
+		return err
+	}
+
+	// 1. Orphan paid transactions (keep them in history, clear loan_id; restorable via deleted_loan_id)
+	if err := s.transactionRepo.OrphanPaidTransactionsByLoan(workspaceID, id); err != nil {
+		return err
+	}
+
+	// 2. Soft delete unpaid transactions (future payments no longer needed until restored)
+	if err := s.transactionRepo.DeleteUnpaidTransactionsByLoan(workspaceID, id); err != nil {
+		return err
+	}
+
+	// 3. Soft delete the loan record
+	return s.loanRepo.SoftDelete(workspaceID, id)
+}
+
+// ListDeletedLoans retrieves soft-deleted loans (the trash) for a workspace
+func (s *LoanService) ListDeletedLoans(workspaceID int32) ([]*domain.Loan, error) {
+	return s.loanRepo.GetDeletedByWorkspace(workspaceID)
+}
+
+// RestoreLoan brings a soft-deleted loan back and re-links the transactions unlinked by DeleteLoan
+// Refused when the first payment falls in a closed month
+func (s *LoanService) RestoreLoan(workspaceID int32, id int32) (*domain.Loan, error) {
+	loan, err := s.loanRepo.GetDeletedByID(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := isMonthClosed(s.monthRepo, workspaceID, int(loan.FirstPaymentYear), int(loan.FirstPaymentMonth))
+	if err != nil {
+		return nil, err
+	}
+	if closed {
+		return nil, domain.ErrLoanRestoreClosedMonth
+	}
+
+	// Restore the loan and re-link its transactions in one DB transaction
+	if s.pool != nil {
+		ctx := context.Background()
+		tx, err := s.pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
+
+		restored, err := s.loanRepo.RestoreTx(tx, workspaceID, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.transactionRepo.RestoreTransactionsByDeletedLoanTx(tx, workspaceID, id); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return restored, nil
+	}
+
+	// Fallback without transaction (for backwards compatibility in tests)
+	restored, err := s.loanRepo.Restore(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
 
-// LoanEditCheck contains edit eligibility information for a loan
-type LoanEditCheck struct {
-	CanChangeProvider    bool `json:"canChangeProvider"`
-	HasPaidTransactions  bool `json:"hasPaidTransactions"`
+	if err := s.transactionRepo.RestoreTransactionsByDeletedLoan(workspaceID, id); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
 }
 
-// GetEditCheck returns edit eligibility for a loan (whether provider can be changed)
-func (s *LoanService) GetEditCheck(workspaceID int32, id int32) (*LoanEditCheck, error) {
-	// Verify loan exists
-	_, err := s.loanRepo.GetByID(workspaceID, id)
-	if err != nil {
+// RefundLoanPayment applies a partial refund to one scheduled payment of a loan
+// The payment's transaction is reduced by amount, or voided when fully refunded;
+// remaining balance and stats are derived from the loan's transactions, so the
+// returned loan reflects the refund once completion is re-synced
+func (s *LoanService) RefundLoanPayment(workspaceID, loanID int32, paymentNumber int32, amount decimal.Decimal) (*domain.Loan, error) {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, domain.ErrRefundAmountInvalid
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, amount); err != nil {
 		return nil, err
 	}
 
-	// Check if any transactions are paid
-	hasPaid, err := s.transactionRepo.HasPaidTransactionsByLoan(workspaceID, id)
+	loan, err := s.loanRepo.GetByID(workspaceID, loanID)
 	if err != nil {
 		return nil, err
 	}
+	if paymentNumber < 1 || paymentNumber > loan.NumMonths {
+		return nil, domain.ErrLoanPaymentNumberInvalid
+	}
 
-	return &LoanEditCheck{
-		CanChangeProvider:   !hasPaid,
-		HasPaidTransactions: hasPaid,
-	}, nil
-}
+	// Payment N falls N-1 months after the first payment
+	dueDate := time.Date(int(loan.FirstPaymentYear), time.Month(loan.FirstPaymentMonth)+time.Month(paymentNumber-1), 1, 0, 0, 0, 0, time.UTC)
 
-// DeleteLoan soft-deletes a loan with cascade transaction handling
-// Follows the same pattern as RecurringTemplateServiceImpl.DeleteTemplate:
-// 1. Orphan paid transactions (set loan_id = NULL to keep them in history)
-// 2. Hard delete unpaid transactions (future payments no longer needed)
-// 3. Soft delete the loan record
-func (s *LoanService) DeleteLoan(workspaceID int32, id int32) error {
-	// Verify loan exists before deleting
-	_, err := s.loanRepo.GetByID(workspaceID, id)
+	transactions, err := s.transactionRepo.GetByLoanID(workspaceID, loanID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 1. Orphan paid transactions (keep them in history, clear loan_id)
-	if err := s.transactionRepo.OrphanPaidTransactionsByLoan(workspaceID, id); err != nil {
-		return err
+	// A separate fee transaction shares the payment's month and is never the one refunded
+	var payment *domain.Transaction
+	for _, txn := range transactions {
+		if txn.IsLoanFee || txn.IsLoanDownPayment() || txn.TransactionDate.Year() != dueDate.Year() || txn.TransactionDate.Month() != dueDate.Month() {
+			continue
+		}
+		if payment == nil || txn.ID < payment.ID {
+			payment = txn
+		}
+	}
+	if payment == nil {
+		return nil, domain.ErrLoanPaymentNotFound
 	}
 
-	// 2. Hard delete unpaid transactions (future payments no longer needed)
-	if err := s.transactionRepo.DeleteUnpaidTransactionsByLoan(workspaceID, id); err != nil {
-		return err
+	if amount.GreaterThan(payment.Amount) {
+		return nil, domain.ErrRefundExceedsPayment
 	}
 
-	// 3. Soft delete the loan record
-	return s.loanRepo.SoftDelete(workspaceID, id)
+	if amount.Equal(payment.Amount) {
+		if err := s.transactionRepo.SoftDelete(workspaceID, payment.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		updateData := payment.ToUpdateData()
+		updateData.Amount = payment.Amount.Sub(amount)
+		_, err := s.transactionRepo.Update(workspaceID, payment.ID, updateData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Voiding the last unpaid payment can complete the loan
+	if _, err := s.SyncLoanCompletion(workspaceID, loanID); err != nil {
+		return nil, err
+	}
+
+	return s.loanRepo.GetByID(workspaceID, loanID)
 }
 
 // GetDeleteStats retrieves loan and payment statistics for delete confirmation dialog
@@ -448,20 +1278,97 @@ type MonthlyCommitmentsResult struct {
 	Month       int
 	TotalUnpaid decimal.Decimal
 	TotalPaid   decimal.Decimal
+	Status      domain.PaymentStatus
 	Payments    []*domain.MonthlyPaymentDetail
+	Providers   []*domain.ProviderCommitment
 }
 
 // GetMonthlyCommitments retrieves loan commitments for a specific month
-// TODO(v2): Implement using transactions instead of loan_payments
+// v2: Aggregates loan transactions per loan; each loan's transactions form one payment
 func (s *LoanService) GetMonthlyCommitments(workspaceID int32, year, month int) (*MonthlyCommitmentsResult, error) {
-	// v2 stub: Return empty result until transaction-based query is implemented
-	return &MonthlyCommitmentsResult{
+	transactions, err := s.transactionRepo.GetAllLoanTransactionsByMonth(workspaceID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := s.loanRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	loanMap := make(map[int32]*domain.Loan, len(loans))
+	for _, l := range loans {
+		loanMap[l.ID] = l
+	}
+
+	result := &MonthlyCommitmentsResult{
 		Year:        year,
 		Month:       month,
 		TotalUnpaid: decimal.Zero,
 		TotalPaid:   decimal.Zero,
 		Payments:    []*domain.MonthlyPaymentDetail{},
-	}, nil
+		Providers:   []*domain.ProviderCommitment{},
+	}
+
+	// Group transactions by loan and by provider, preserving transaction date order
+	paymentByLoan := make(map[int32]*domain.MonthlyPaymentDetail)
+	commitmentByProvider := make(map[int32]*domain.ProviderCommitment)
+	paidCounts := make(map[int32]int)
+	totalCounts := make(map[int32]int)
+	monthPaid, monthTotal := 0, 0
+	for _, tx := range transactions {
+		loan, ok := loanMap[*tx.LoanID]
+		if !ok {
+			continue
+		}
+
+		payment, exists := paymentByLoan[loan.ID]
+		if !exists {
+			payment = &domain.MonthlyPaymentDetail{
+				ID:            tx.ID,
+				LoanID:        loan.ID,
+				ItemName:      loan.ItemName,
+				PaymentNumber: int32((year*12+month)-(int(loan.FirstPaymentYear)*12+int(loan.FirstPaymentMonth))) + 1,
+				TotalPayments: loan.NumMonths,
+				Amount:        decimal.Zero,
+			}
+			paymentByLoan[loan.ID] = payment
+			result.Payments = append(result.Payments, payment)
+		}
+
+		commitment, exists := commitmentByProvider[loan.ProviderID]
+		if !exists {
+			commitment = &domain.ProviderCommitment{
+				ProviderID:   loan.ProviderID,
+				ProviderName: loan.ProviderName,
+				TotalUnpaid:  decimal.Zero,
+				TotalPaid:    decimal.Zero,
+			}
+			commitmentByProvider[loan.ProviderID] = commitment
+			result.Providers = append(result.Providers, commitment)
+		}
+
+		amount := tx.Amount.Abs()
+		payment.Amount = payment.Amount.Add(amount)
+		totalCounts[loan.ID]++
+		monthTotal++
+		if tx.IsPaid {
+			paidCounts[loan.ID]++
+			monthPaid++
+			result.TotalPaid = result.TotalPaid.Add(amount)
+			commitment.TotalPaid = commitment.TotalPaid.Add(amount)
+		} else {
+			result.TotalUnpaid = result.TotalUnpaid.Add(amount)
+			commitment.TotalUnpaid = commitment.TotalUnpaid.Add(amount)
+		}
+	}
+
+	for _, payment := range result.Payments {
+		payment.Status = domain.ComputePaymentStatus(paidCounts[payment.LoanID], totalCounts[payment.LoanID])
+		payment.Paid = payment.Status == domain.PaymentStatusPaid
+	}
+	result.Status = domain.ComputePaymentStatus(monthPaid, monthTotal)
+
+	return result, nil
 }
 
 // CalculateMonthlyPayment calculates the monthly payment for a loan
@@ -475,6 +1382,49 @@ func CalculateMonthlyPayment(totalAmount, interestRate decimal.Decimal, numMonth
 	return totalWithInterest.Div(decimal.NewFromInt(int64(numMonths))).Round(2)
 }
 
+// CalculateMonthlyPaymentForMethod calculates the monthly payment under an interest method.
+// Reducing balance charges the flat rate's monthly share (rate / numMonths) on the outstanding
+// balance only, so the payment is the standard amortized installment for that monthly rate.
+func CalculateMonthlyPaymentForMethod(method string, totalAmount, interestRate decimal.Decimal, numMonths int) decimal.Decimal {
+	if method != domain.InterestMethodReducingBalance || numMonths <= 0 || interestRate.IsZero() {
+		return CalculateMonthlyPayment(totalAmount, interestRate, numMonths)
+	}
+	one := decimal.NewFromInt(1)
+	monthlyRate := interestRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(int64(numMonths)))
+	growth := one.Add(monthlyRate).Pow(decimal.NewFromInt(int64(numMonths)))
+	return totalAmount.Mul(monthlyRate).Mul(growth).Div(growth.Sub(one)).Round(2)
+}
+
+// CalculateTotalWithInterest returns the total repaid over the loan under an interest method
+func CalculateTotalWithInterest(method string, totalAmount, interestRate decimal.Decimal, numMonths int) decimal.Decimal {
+	if method == domain.InterestMethodReducingBalance {
+		return CalculateMonthlyPaymentForMethod(method, totalAmount, interestRate, numMonths).Mul(decimal.NewFromInt(int64(numMonths)))
+	}
+	return totalAmount.Mul(decimal.NewFromInt(1).Add(interestRate.Div(decimal.NewFromInt(100)))).Round(2)
+}
+
+// CalculatePaymentAmounts returns the amount of each payment when a promotional rate applies
+// The first promoMonths payments are charged promoRate and the remaining ones the regular rate
+func CalculatePaymentAmounts(totalAmount, rate, promoRate decimal.Decimal, promoMonths, numMonths int) []decimal.Decimal {
+	return CalculatePaymentAmountsForMethod(domain.InterestMethodFlat, totalAmount, rate, promoRate, promoMonths, numMonths)
+}
+
+// CalculatePaymentAmountsForMethod is CalculatePaymentAmounts under the given interest method
+func CalculatePaymentAmountsForMethod(method string, totalAmount, rate, promoRate decimal.Decimal, promoMonths, numMonths int) []decimal.Decimal {
+	regular := CalculateMonthlyPaymentForMethod(method, totalAmount, rate, numMonths)
+	promo := CalculateMonthlyPaymentForMethod(method, totalAmount, promoRate, numMonths)
+
+	amounts := make([]decimal.Decimal, numMonths)
+	for i := range amounts {
+		if i < promoMonths {
+			amounts[i] = promo
+		} else {
+			amounts[i] = regular
+		}
+	}
+	return amounts
+}
+
 // CalculateFirstPaymentMonth calculates the first payment year and month based on purchase date and cutoff day
 // If purchase day < cutoff day → first payment in current month
 // If purchase day >= cutoff day → first payment in next month
@@ -525,6 +1475,8 @@ func GeneratePaymentSchedule(loanID int32, monthlyPayment decimal.Decimal, numMo
 
 // GenerateLoanTransactions creates transaction entries for each loan payment
 // v2: Replaces loan_payments with transactions linked via loan_id
+// A positive monthlyFee is added to each payment when feeMode is folded,
+// otherwise it is generated as a separate fee transaction in the same month
 func GenerateLoanTransactions(
 	workspaceID int32,
 	loanID int32,
@@ -536,8 +1488,12 @@ func GenerateLoanTransactions(
 	isCC bool,
 	settlementIntent *string,
 	customAmounts []decimal.Decimal,
+	monthlyFee decimal.Decimal,
+	feeMode string,
 ) []*domain.Transaction {
-	transactions := make([]*domain.Transaction, numMonths)
+	transactions := make([]*domain.Transaction, 0, numMonths)
+	hasFee := monthlyFee.GreaterThan(decimal.Zero)
+	foldFee := hasFee && feeMode == domain.FeeModeFolded
 	year := firstPaymentYear
 	month := firstPaymentMonth
 
@@ -556,11 +1512,14 @@ func GenerateLoanTransactions(
 		if useCustom {
 			amount = customAmounts[i]
 		}
+		if foldFee {
+			amount = amount.Add(monthlyFee)
+		}
 
 		// Transaction date is 1st of the payment month
 		transactionDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 
-		transactions[i] = &domain.Transaction{
+		transactions = append(transactions, &domain.Transaction{
 			WorkspaceID:      workspaceID,
 			AccountID:        accountID,
 			Name:             itemName,
@@ -571,6 +1530,22 @@ func GenerateLoanTransactions(
 			Source:           "loan",
 			LoanID:           &loanID,
 			SettlementIntent: domainIntent,
+		})
+
+		if hasFee && !foldFee {
+			transactions = append(transactions, &domain.Transaction{
+				WorkspaceID:      workspaceID,
+				AccountID:        accountID,
+				Name:             itemName + " (fee)",
+				Amount:           monthlyFee,
+				Type:             domain.TransactionTypeExpense,
+				TransactionDate:  transactionDate,
+				IsPaid:           false,
+				Source:           "loan",
+				LoanID:           &loanID,
+				IsLoanFee:        true,
+				SettlementIntent: domainIntent,
+			})
 		}
 
 		// Advance to next month
@@ -588,6 +1563,20 @@ func GenerateLoanTransactions(
 // Returns monthly totals with provider breakdown for the specified number of months
 // Starting from current month, includes gap months with RM 0.00
 func (s *LoanService) GetTrend(workspaceID int32, months int) (*domain.TrendResponse, error) {
+	return s.buildTrend(workspaceID, months, nil)
+}
+
+// GetProviderTrend retrieves the loan payment trend restricted to a single provider
+// Uses the same month bounds as GetTrend; other providers' payments are excluded
+func (s *LoanService) GetProviderTrend(workspaceID int32, providerID int32, months int) (*domain.TrendResponse, error) {
+	if _, err := s.providerRepo.GetByID(workspaceID, providerID); err != nil {
+		return nil, err
+	}
+	return s.buildTrend(workspaceID, months, &providerID)
+}
+
+// buildTrend aggregates loan payments by month, optionally limited to one provider
+func (s *LoanService) buildTrend(workspaceID int32, months int, providerID *int32) (*domain.TrendResponse, error) {
 	// Validate and apply defaults
 	if months <= 0 {
 		months = 12
@@ -624,6 +1613,9 @@ func (s *LoanService) GetTrend(workspaceID int32, months int) (*domain.TrendResp
 	// Key: "YYYY-MM", Value: map of providerID -> breakdown
 	monthProviderMap := make(map[string]map[int32]*domain.ProviderBreakdown)
 	for _, row := range trendData {
+		if providerID != nil && row.ProviderID != *providerID {
+			continue
+		}
 		monthKey := formatMonth(int(row.Year), int(row.Month))
 		if monthProviderMap[monthKey] == nil {
 			monthProviderMap[monthKey] = make(map[int32]*domain.ProviderBreakdown)
@@ -666,9 +1658,10 @@ func (s *LoanService) GetTrend(workspaceID int32, months int) (*domain.TrendResp
 
 // PayLoanMonthInput contains input for paying a loan month
 type PayLoanMonthInput struct {
-	LoanID int32
-	Year   int
-	Month  int
+	LoanID   int32
+	Year     int
+	Month    int
+	PaidDate *time.Time // When the payment was made; defaults to now
 }
 
 // PayLoanMonthResult contains the result of paying a loan month
@@ -681,6 +1674,15 @@ type PayLoanMonthResult struct {
 // PayLoanMonth marks all unpaid transactions for a loan month as paid
 // Works for both bank and CC transactions - CC state transitions automatically
 func (s *LoanService) PayLoanMonth(workspaceID int32, input PayLoanMonthInput) (*PayLoanMonthResult, error) {
+	now := time.Now()
+	paidAt := now
+	if input.PaidDate != nil {
+		if domain.IsAfterDay(*input.PaidDate, now) {
+			return nil, domain.ErrLoanPaidDateInFuture
+		}
+		paidAt = *input.PaidDate
+	}
+
 	// 1. Verify loan exists and belongs to workspace
 	loan, err := s.loanRepo.GetByID(workspaceID, input.LoanID)
 	if err != nil {
@@ -707,7 +1709,7 @@ func (s *LoanService) PayLoanMonth(workspaceID int32, input PayLoanMonthInput) (
 
 	// 4. Bulk mark transactions as paid (works for both bank and CC)
 	// For CC transactions, this also transitions cc_state to 'settled'
-	settled, err := s.transactionRepo.BulkMarkPaid(workspaceID, ids)
+	settled, err := s.transactionRepo.BulkMarkPaid(workspaceID, ids, paidAt)
 	if err != nil {
 		return nil, err
 	}
@@ -716,6 +1718,9 @@ func (s *LoanService) PayLoanMonth(workspaceID int32, input PayLoanMonthInput) (
 	if len(settled) != len(ids) {
 		return nil, domain.ErrLoanPaymentAtomicityFailed
 	}
+	if err := s.syncRoundUps(workspaceID, settled); err != nil {
+		return nil, err
+	}
 
 	// 5. Calculate total amount
 	total := decimal.Zero
@@ -723,7 +1728,12 @@ func (s *LoanService) PayLoanMonth(workspaceID int32, input PayLoanMonthInput) (
 		total = total.Add(tx.Amount.Abs())
 	}
 
-	// 6. Format month name for message
+	// 6. Mark the loan completed if this was its last unpaid month
+	if _, err := s.SyncLoanCompletion(workspaceID, input.LoanID); err != nil {
+		return nil, err
+	}
+
+	// 7. Format month name for message
 	monthName := time.Month(input.Month).String()
 
 	return &PayLoanMonthResult{
@@ -732,3 +1742,332 @@ func (s *LoanService) PayLoanMonth(workspaceID int32, input PayLoanMonthInput) (
 		Message:             monthName + " settled for " + loan.ItemName,
 	}, nil
 }
+
+// PayAllLoanResult contains the settled transactions for one loan in a pay-all run
+type PayAllLoanResult struct {
+	LoanID              int32
+	ItemName            string
+	SettledTransactions []*domain.Transaction
+	TotalAmount         decimal.Decimal
+}
+
+// PayAllResult contains the result of paying every loan due in a month
+type PayAllResult struct {
+	Loans       []PayAllLoanResult
+	TotalAmount decimal.Decimal
+}
+
+// PayAllDue marks every unpaid loan transaction dated in the month as paid, across all loans.
+// Consolidated providers keep their sequential rule: if any of the provider's loans has an
+// unpaid earlier month, nothing is settled and ErrMustPayEarlierMonth is returned.
+// All transactions are settled in a single database transaction.
+func (s *LoanService) PayAllDue(workspaceID int32, year, month int) (*PayAllResult, error) {
+	transactions, err := s.transactionRepo.GetAllLoanTransactionsByMonth(workspaceID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group unpaid transactions by loan, keeping first-seen loan order
+	var loanIDs []int32
+	idsByLoan := make(map[int32][]int32)
+	for _, tx := range transactions {
+		if tx.IsPaid || tx.LoanID == nil {
+			continue
+		}
+		if _, ok := idsByLoan[*tx.LoanID]; !ok {
+			loanIDs = append(loanIDs, *tx.LoanID)
+		}
+		idsByLoan[*tx.LoanID] = append(idsByLoan[*tx.LoanID], tx.ID)
+	}
+	if len(loanIDs) == 0 {
+		return nil, domain.ErrNoTransactionsToSettle
+	}
+
+	loans := make(map[int32]*domain.Loan, len(loanIDs))
+	checkedProviders := make(map[int32]bool)
+	for _, loanID := range loanIDs {
+		loan, err := s.loanRepo.GetByID(workspaceID, loanID)
+		if err != nil {
+			return nil, err
+		}
+		loans[loanID] = loan
+
+		if checkedProviders[loan.ProviderID] {
+			continue
+		}
+		checkedProviders[loan.ProviderID] = true
+		if err := s.checkProviderSequence(workspaceID, loan.ProviderID, year, month); err != nil {
+			return nil, err
+		}
+	}
+
+	paidAt := time.Now()
+	settledByLoan := make(map[int32][]*domain.Transaction, len(loanIDs))
+	if s.pool != nil {
+		ctx := context.Background()
+		tx, err := s.pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
+
+		for _, loanID := range loanIDs {
+			settled, err := s.transactionRepo.BulkMarkPaidTx(tx, workspaceID, idsByLoan[loanID], paidAt)
+			if err != nil {
+				return nil, err
+			}
+			if len(settled) != len(idsByLoan[loanID]) {
+				return nil, domain.ErrLoanPaymentAtomicityFailed
+			}
+			settledByLoan[loanID] = settled
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		// Fallback without transaction (for backwards compatibility in tests)
+		for _, loanID := range loanIDs {
+			settled, err := s.transactionRepo.BulkMarkPaid(workspaceID, idsByLoan[loanID], paidAt)
+			if err != nil {
+				return nil, err
+			}
+			if len(settled) != len(idsByLoan[loanID]) {
+				return nil, domain.ErrLoanPaymentAtomicityFailed
+			}
+			settledByLoan[loanID] = settled
+		}
+	}
+
+	result := &PayAllResult{
+		Loans:       make([]PayAllLoanResult, 0, len(loanIDs)),
+		TotalAmount: decimal.Zero,
+	}
+	for _, loanID := range loanIDs {
+		if err := s.syncRoundUps(workspaceID, settledByLoan[loanID]); err != nil {
+			return nil, err
+		}
+
+		loanTotal := decimal.Zero
+		for _, tx := range settledByLoan[loanID] {
+			loanTotal = loanTotal.Add(tx.Amount.Abs())
+		}
+		result.Loans = append(result.Loans, PayAllLoanResult{
+			LoanID:              loanID,
+			ItemName:            loans[loanID].ItemName,
+			SettledTransactions: settledByLoan[loanID],
+			TotalAmount:         loanTotal,
+		})
+		result.TotalAmount = result.TotalAmount.Add(loanTotal)
+
+		if _, err := s.SyncLoanCompletion(workspaceID, loanID); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// checkProviderSequence enforces sequential payment for consolidated providers:
+// no loan from the provider may have an unpaid transaction before the target month
+func (s *LoanService) checkProviderSequence(workspaceID, providerID int32, year, month int) error {
+	provider, err := s.providerRepo.GetByID(workspaceID, providerID)
+	if err != nil {
+		return err
+	}
+	if provider.PaymentMode != domain.PaymentModeConsolidatedMonthly {
+		return nil
+	}
+
+	earliest, err := s.paymentRepo.GetEarliestUnpaidMonth(workspaceID, providerID)
+	if err != nil {
+		return err
+	}
+	if earliest != nil && int(earliest.Year)*12+int(earliest.Month) < year*12+month {
+		return domain.ErrMustPayEarlierMonth{
+			Expected:  formatMonth(int(earliest.Year), int(earliest.Month)),
+			Requested: formatMonth(year, month),
+		}
+	}
+	return nil
+}
+
+// SettleLoanExternally marks every remaining payment of a loan paid when it was cleared outside
+// the app (e.g. directly with the provider). The payments are tagged with the external source and
+// the note, and the loan is completed. Returns ErrLoanAlreadySettled if nothing is left unpaid.
+func (s *LoanService) SettleLoanExternally(workspaceID, loanID int32, note string) (*domain.Loan, error) {
+	note = strings.TrimSpace(note)
+	if len(note) > domain.MaxTransactionNotesLength {
+		return nil, domain.ErrNotesTooLong
+	}
+	if _, err := s.loanRepo.GetByID(workspaceID, loanID); err != nil {
+		return nil, err
+	}
+
+	settled, err := s.transactionRepo.SettleUnpaidByLoanExternally(workspaceID, loanID, note, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(settled) == 0 {
+		return nil, domain.ErrLoanAlreadySettled
+	}
+	if err := s.syncRoundUps(workspaceID, settled); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.SyncLoanCompletion(workspaceID, loanID); err != nil {
+		return nil, err
+	}
+	return s.loanRepo.GetByID(workspaceID, loanID)
+}
+
+// SyncLoanCompletion sets or clears the loan's CompletedAt based on its transactions.
+// A loan with at least one paid and no unpaid transactions is completed, even if
+// scheduled months remain. Returns the loan's completion time (nil if not completed).
+func (s *LoanService) SyncLoanCompletion(workspaceID int32, loanID int32) (*time.Time, error) {
+	return syncLoanCompletion(s.loanRepo, s.transactionRepo, workspaceID, loanID)
+}
+
+func syncLoanCompletion(loanRepo domain.LoanRepository, transactionRepo domain.TransactionRepository, workspaceID int32, loanID int32) (*time.Time, error) {
+	loan, err := loanRepo.GetByID(workspaceID, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := transactionRepo.GetLoanTransactionStats(workspaceID, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := stats.PaidCount > 0 && stats.UnpaidCount == 0
+	switch {
+	case completed && loan.CompletedAt == nil:
+		now := time.Now()
+		if err := loanRepo.SetCompletedAt(workspaceID, loanID, &now); err != nil {
+			return nil, err
+		}
+		return &now, nil
+	case !completed && loan.CompletedAt != nil:
+		// A payment was reverted; the loan is active again
+		if err := loanRepo.SetCompletedAt(workspaceID, loanID, nil); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return loan.CompletedAt, nil
+}
+
+// syncLoansCompletion re-syncs completion for each distinct loan in loanIDs.
+// Services outside LoanService call it after marking loan payments paid or unpaid;
+// it is a no-op until both repositories are wired.
+func syncLoansCompletion(loanRepo domain.LoanRepository, transactionRepo domain.TransactionRepository, workspaceID int32, loanIDs []int32) error {
+	if loanRepo == nil || transactionRepo == nil {
+		return nil
+	}
+	seen := make(map[int32]bool, len(loanIDs))
+	for _, loanID := range loanIDs {
+		if seen[loanID] {
+			continue
+		}
+		seen[loanID] = true
+		if _, err := syncLoanCompletion(loanRepo, transactionRepo, workspaceID, loanID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transactionLoanIDs returns the loan IDs of the loan-linked transactions in txns
+func transactionLoanIDs(txns []*domain.Transaction) []int32 {
+	var loanIDs []int32
+	for _, txn := range txns {
+		if txn.LoanID != nil {
+			loanIDs = append(loanIDs, *txn.LoanID)
+		}
+	}
+	return loanIDs
+}
+
+// GetLoanPaymentStatusBuckets classifies unpaid loan payments by due month relative to asOf:
+// Overdue (an earlier month), DueThisMonth, or Upcoming (a later month).
+// asOf should already be in the workspace timezone so "this month" matches what the user sees.
+// Separate fee lines add to a bucket's total but are not counted or listed as payments.
+func (s *LoanService) GetLoanPaymentStatusBuckets(workspaceID int32, asOf time.Time) (*domain.LoanPaymentBuckets, error) {
+	unpaid, err := s.transactionRepo.GetUnpaidLoanTransactions(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := &domain.LoanPaymentBuckets{
+		Overdue:      domain.LoanPaymentBucket{Total: decimal.Zero, Payments: []*domain.Transaction{}},
+		DueThisMonth: domain.LoanPaymentBucket{Total: decimal.Zero, Payments: []*domain.Transaction{}},
+		Upcoming:     domain.LoanPaymentBucket{Total: decimal.Zero, Payments: []*domain.Transaction{}},
+	}
+
+	currentMonth := asOf.Year()*12 + int(asOf.Month())
+	for _, tx := range unpaid {
+		dueMonth := tx.TransactionDate.Year()*12 + int(tx.TransactionDate.Month())
+
+		bucket := &buckets.DueThisMonth
+		switch {
+		case dueMonth < currentMonth:
+			bucket = &buckets.Overdue
+		case dueMonth > currentMonth:
+			bucket = &buckets.Upcoming
+		}
+		bucket.Total = bucket.Total.Add(tx.Amount)
+		if tx.IsLoanFee {
+			continue
+		}
+		bucket.Count++
+		bucket.Payments = append(bucket.Payments, tx)
+	}
+
+	return buckets, nil
+}
+
+// FindOrphanedLoanTransactions returns transactions still linked to a loan that no longer exists or was deleted
+func (s *LoanService) FindOrphanedLoanTransactions(workspaceID int32) ([]*domain.Transaction, error) {
+	return s.transactionRepo.GetOrphanedLoanTransactions(workspaceID)
+}
+
+// ReattachOrClearOrphans repairs orphaned loan transactions by clearing their loan link.
+// The transactions themselves are kept so payment history and balances are unaffected.
+// Returns the repaired transactions.
+func (s *LoanService) ReattachOrClearOrphans(workspaceID int32) ([]*domain.Transaction, error) {
+	repaired, err := s.transactionRepo.ClearOrphanedLoanLinks(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repaired) > 0 {
+		log.Info().Int32("workspace_id", workspaceID).Int("count", len(repaired)).Msg("Cleared orphaned loan links")
+	}
+
+	return repaired, nil
+}
+
+// GenerateMissingSchedules generates payment transactions for loans that never had any
+// (e.g. loans bulk-imported without schedules), using each loan's stored parameters.
+// A loan whose payments were all refunded or deleted still had a schedule and is left untouched.
+// Returns how many loans were fixed.
+func (s *LoanService) GenerateMissingSchedules(workspaceID int32) (int, error) {
+	loans, err := s.loanRepo.GetWithoutSchedule(workspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, loan := range loans {
+		if err := s.SyncLoanTransactions(loan, nil); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+
+	if fixed > 0 {
+		log.Info().Int32("workspace_id", workspaceID).Int("count", fixed).Msg("Generated missing loan schedules")
+	}
+
+	return fixed, nil
+}
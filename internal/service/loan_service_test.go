@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -40,6 +42,8 @@ func createTestLoanServiceWithTransactionRepo(loanRepo *testutil.MockLoanReposit
 		AccountType: domain.AccountTypeLiability,
 	})
 
+	// Lets the loan mock see which loans have a schedule
+	loanRepo.TransactionRepo = transactionRepo
 	return NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo), transactionRepo
 }
 
@@ -311,6 +315,90 @@ func TestCreateLoan_UsesProviderDefaultRate(t *testing.T) {
 	}
 }
 
+func TestCreateLoan_InheritsProviderReducingBalance(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                    1,
+		WorkspaceID:           workspaceID,
+		Name:                  "Bank",
+		CutoffDay:             25,
+		DefaultInterestRate:   decimal.NewFromInt(12),
+		DefaultInterestMethod: domain.InterestMethodReducingBalance,
+	})
+
+	loan, err := service.CreateLoan(workspaceID, CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Laptop",
+		TotalAmount:  decimal.NewFromInt(1200),
+		NumMonths:    12,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 1% a month on the outstanding balance: 1200 * 0.01 * 1.01^12 / (1.01^12 - 1)
+	expectedMonthly := decimal.RequireFromString("106.62")
+	if !loan.MonthlyPayment.Equal(expectedMonthly) {
+		t.Errorf("Expected monthly payment %s, got %s", expectedMonthly.String(), loan.MonthlyPayment.String())
+	}
+}
+
+func TestCreateLoan_InterestMethodOverrideToFlat(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                    1,
+		WorkspaceID:           workspaceID,
+		Name:                  "Bank",
+		CutoffDay:             25,
+		DefaultInterestRate:   decimal.NewFromInt(12),
+		DefaultInterestMethod: domain.InterestMethodReducingBalance,
+	})
+
+	loan, err := service.CreateLoan(workspaceID, CreateLoanInput{
+		ProviderID:     1,
+		ItemName:       "Laptop",
+		TotalAmount:    decimal.NewFromInt(1200),
+		NumMonths:      12,
+		PurchaseDate:   time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		InterestMethod: domain.InterestMethodFlat,
+		AccountID:      1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedMonthly := decimal.NewFromInt(112) // 1200 * 1.12 / 12
+	if !loan.MonthlyPayment.Equal(expectedMonthly) {
+		t.Errorf("Expected monthly payment %s, got %s", expectedMonthly.String(), loan.MonthlyPayment.String())
+	}
+	if loan.InterestMethod != domain.InterestMethodFlat {
+		t.Errorf("Expected the override to be stored on the loan, got %q", loan.InterestMethod)
+	}
+
+	_, err = service.CreateLoan(workspaceID, CreateLoanInput{
+		ProviderID:     1,
+		ItemName:       "Laptop",
+		TotalAmount:    decimal.NewFromInt(1200),
+		NumMonths:      12,
+		PurchaseDate:   time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		InterestMethod: "compound",
+		AccountID:      1,
+	})
+	if err != domain.ErrInvalidInterestMethod {
+		t.Errorf("Expected ErrInvalidInterestMethod, got %v", err)
+	}
+}
+
 func TestCreateLoan_EmptyItemName(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
@@ -393,6 +481,95 @@ func TestCreateLoan_NegativeAmount(t *testing.T) {
 	}
 }
 
+func TestCreateLoan_AmountPrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		wantErr error
+	}{
+		{"two decimal places", "300.25", nil},
+		{"trailing zeros", "300.2500", nil},
+		{"four decimal places", "300.2575", domain.ErrExcessivePrecision},
+		{"eight decimal places", "300.25750001", domain.ErrExcessivePrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := testutil.NewMockLoanRepository()
+			providerRepo := testutil.NewMockLoanProviderRepository()
+			service := createTestLoanService(loanRepo, providerRepo)
+
+			providerRepo.AddLoanProvider(&domain.LoanProvider{
+				ID:                  1,
+				WorkspaceID:         1,
+				Name:                "SPayLater",
+				CutoffDay:           25,
+				DefaultInterestRate: decimal.Zero,
+			})
+
+			input := CreateLoanInput{
+				ProviderID:   1,
+				ItemName:     "Test",
+				TotalAmount:  decimal.RequireFromString(tt.amount),
+				NumMonths:    3,
+				PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+				AccountID:    1,
+			}
+
+			_, err := service.CreateLoan(1, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateLoan_InterestRatePrecision(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	rate := decimal.RequireFromString("1.12345678")
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Now(),
+		InterestRate: &rate,
+		AccountID:    1,
+	}
+
+	_, err := service.CreateLoan(1, input)
+	if !errors.Is(err, domain.ErrExcessivePrecision) {
+		t.Errorf("Expected ErrExcessivePrecision, got %v", err)
+	}
+}
+
+func TestCreateLoan_PaymentAmountsPrecision(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    2,
+		PurchaseDate: time.Now(),
+		PaymentAmounts: []decimal.Decimal{
+			decimal.RequireFromString("150.00"),
+			decimal.RequireFromString("149.99999999"),
+		},
+		AccountID: 1,
+	}
+
+	_, err := service.CreateLoan(1, input)
+	if !errors.Is(err, domain.ErrExcessivePrecision) {
+		t.Errorf("Expected ErrExcessivePrecision, got %v", err)
+	}
+}
+
 func TestCreateLoan_ZeroMonths(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
@@ -412,6 +589,53 @@ func TestCreateLoan_ZeroMonths(t *testing.T) {
 	}
 }
 
+func TestCreateLoan_MaxMonths(t *testing.T) {
+	tests := []struct {
+		name      string
+		numMonths int32
+		wantErr   error
+	}{
+		{"at configured max", 24, nil},
+		{"one over max", 25, domain.ErrLoanMonthsExceedsMax},
+		{"zero months", 0, domain.ErrLoanMonthsInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := testutil.NewMockLoanRepository()
+			providerRepo := testutil.NewMockLoanProviderRepository()
+			service := createTestLoanService(loanRepo, providerRepo)
+			service.SetMaxLoanMonths(24)
+
+			providerRepo.AddLoanProvider(&domain.LoanProvider{
+				ID:                  1,
+				WorkspaceID:         1,
+				Name:                "SPayLater",
+				CutoffDay:           25,
+				DefaultInterestRate: decimal.Zero,
+			})
+
+			input := CreateLoanInput{
+				ProviderID:   1,
+				ItemName:     "Test",
+				TotalAmount:  decimal.NewFromInt(2400),
+				NumMonths:    tt.numMonths,
+				PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+				AccountID:    1,
+			}
+
+			_, err := service.CreateLoan(1, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			var limitErr domain.ErrLoanMonthsLimit
+			if errors.As(err, &limitErr) && limitErr.MaxMonths != 24 {
+				t.Errorf("Expected error to report the configured max of 24, got %d", limitErr.MaxMonths)
+			}
+		})
+	}
+}
+
 func TestCreateLoan_InvalidProvider(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
@@ -547,10 +771,10 @@ func TestCreateLoan_WithAccountAndSettlementIntent(t *testing.T) {
 	}
 }
 
-func TestCreateLoan_WithAccountNoSettlementIntent(t *testing.T) {
+func TestCreateLoan_DownPaymentOnSeparateAccount(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
 	providerRepo.AddLoanProvider(&domain.LoanProvider{
@@ -561,66 +785,159 @@ func TestCreateLoan_WithAccountNoSettlementIntent(t *testing.T) {
 		DefaultInterestRate: decimal.Zero,
 	})
 
+	// RM 300 purchase: RM 100 down from the bank account, RM 200 financed on the CC over 2 months
 	input := CreateLoanInput{
-		ProviderID:   1,
-		ItemName:     "Test Item",
-		TotalAmount:  decimal.NewFromInt(300),
-		NumMonths:    3,
-		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
-		AccountID:    1, // Bank account from helper, no settlement intent needed
+		ProviderID:           1,
+		ItemName:             "Headphones",
+		TotalAmount:          decimal.NewFromInt(300),
+		NumMonths:            2,
+		PurchaseDate:         time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:            2,
+		DownPaymentAmount:    decimal.NewFromInt(100),
+		DownPaymentAccountID: 1,
 	}
 
-	loan, err := service.CreateLoan(workspaceID, input)
+	plan, err := service.ValidateLoanPlan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
+	if !plan.Valid() {
+		t.Fatalf("Expected valid plan, got issues %v", plan.Issues)
+	}
+	if !plan.FinancedAmount.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected financed amount 200, got %s", plan.FinancedAmount.String())
+	}
+	if len(plan.Schedule) != 2 {
+		t.Fatalf("Expected 2 scheduled payments, got %d", len(plan.Schedule))
+	}
+	for i, p := range plan.Schedule {
+		if !p.Amount.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("Expected payment %d to be 100, got %s", i+1, p.Amount.String())
+		}
+	}
 
-	if loan.AccountID != 1 {
-		t.Errorf("Expected AccountID 1, got %d", loan.AccountID)
+	loan, err := service.CreateLoan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !loan.TotalAmount.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected loan total 200, got %s", loan.TotalAmount.String())
+	}
+	if !loan.MonthlyPayment.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected monthly payment 100, got %s", loan.MonthlyPayment.String())
+	}
+	if !loan.DownPaymentAmount.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected loan down payment 100, got %s", loan.DownPaymentAmount.String())
 	}
 
-	if loan.SettlementIntent != nil {
-		t.Errorf("Expected SettlementIntent nil, got %v", loan.SettlementIntent)
+	if len(transactionRepo.Transactions) != 1 {
+		t.Fatalf("Expected 1 down payment transaction, got %d", len(transactionRepo.Transactions))
+	}
+	for _, tx := range transactionRepo.Transactions {
+		if tx.AccountID != 1 {
+			t.Errorf("Expected down payment on account 1, got %d", tx.AccountID)
+		}
+		if !tx.Amount.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("Expected down payment 100, got %s", tx.Amount.String())
+		}
+		if !tx.IsPaid {
+			t.Error("Expected down payment to be paid")
+		}
+		if tx.Type != domain.TransactionTypeExpense {
+			t.Errorf("Expected expense, got %s", tx.Type)
+		}
+		if tx.LoanID == nil || *tx.LoanID != loan.ID {
+			t.Errorf("Expected down payment linked to loan %d, got %v", loan.ID, tx.LoanID)
+		}
+		if tx.Source != domain.TransactionSourceLoanDownPayment {
+			t.Errorf("Expected source %q, got %q", domain.TransactionSourceLoanDownPayment, tx.Source)
+		}
+		if tx.SettlementIntent != nil {
+			t.Errorf("Expected no settlement intent on a bank down payment, got %v", *tx.SettlementIntent)
+		}
 	}
 }
 
-func TestCreateLoan_WithNotes(t *testing.T) {
+func TestCreateLoan_DownPaymentOnCCIsPending(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
 	providerRepo.AddLoanProvider(&domain.LoanProvider{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		Name:        "Provider",
-		CutoffDay:   25,
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
 	})
 
-	notes := "This is a test note"
-	input := CreateLoanInput{
-		ProviderID:   1,
-		ItemName:     "Test",
-		TotalAmount:  decimal.NewFromInt(100),
-		NumMonths:    3,
-		PurchaseDate: time.Now(),
-		Notes:        &notes,
-		AccountID:    1,
-	}
-
-	loan, err := service.CreateLoan(workspaceID, input)
+	// Down payment charged to the same CC as the loan, which is settled immediately
+	immediate := string(domain.SettlementIntentImmediate)
+	loan, err := service.CreateLoan(workspaceID, CreateLoanInput{
+		ProviderID:        1,
+		ItemName:          "Headphones",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         2,
+		PurchaseDate:      time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:         2,
+		SettlementIntent:  &immediate,
+		DownPaymentAmount: decimal.NewFromInt(100),
+	})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if loan.Notes == nil || *loan.Notes != notes {
-		t.Errorf("Expected notes '%s', got %v", notes, loan.Notes)
+	if len(transactionRepo.Transactions) != 1 {
+		t.Fatalf("Expected 1 down payment transaction, got %d", len(transactionRepo.Transactions))
+	}
+	for _, tx := range transactionRepo.Transactions {
+		if tx.AccountID != 2 {
+			t.Errorf("Expected down payment on account 2, got %d", tx.AccountID)
+		}
+		if tx.IsPaid || tx.BilledAt != nil {
+			t.Error("Expected the CC down payment to start pending")
+		}
+		if tx.SettlementIntent == nil || *tx.SettlementIntent != domain.SettlementIntentImmediate {
+			t.Errorf("Expected the loan's immediate intent, got %v", tx.SettlementIntent)
+		}
+		if tx.LoanID == nil || *tx.LoanID != loan.ID {
+			t.Errorf("Expected down payment linked to loan %d, got %v", loan.ID, tx.LoanID)
+		}
 	}
 }
 
-// PreviewLoan tests
+func TestCreateLoan_DownPaymentNotLessThanTotal(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
-func TestPreviewLoan_Success(t *testing.T) {
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	input := CreateLoanInput{
+		ProviderID:        1,
+		ItemName:          "Headphones",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         2,
+		PurchaseDate:      time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:         1,
+		DownPaymentAmount: decimal.NewFromInt(300),
+	}
+
+	_, err := service.CreateLoan(workspaceID, input)
+	if !errors.Is(err, domain.ErrLoanDownPaymentInvalid) {
+		t.Errorf("Expected ErrLoanDownPaymentInvalid, got %v", err)
+	}
+}
+
+func TestCreateLoanWithDuplicateCheck_WarnsUnlessConfirmed(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
@@ -634,619 +951,784 @@ func TestPreviewLoan_Success(t *testing.T) {
 		DefaultInterestRate: decimal.Zero,
 	})
 
-	input := PreviewLoanInput{
+	input := CreateLoanInput{
 		ProviderID:   1,
+		ItemName:     "Headphones",
 		TotalAmount:  decimal.NewFromInt(300),
 		NumMonths:    3,
 		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    1,
 	}
-
-	result, err := service.PreviewLoan(workspaceID, input)
+	existing, similar, err := service.CreateLoanWithDuplicateCheck(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	expectedMonthly := decimal.NewFromInt(100)
-	if !result.MonthlyPayment.Equal(expectedMonthly) {
-		t.Errorf("Expected monthly payment %s, got %s", expectedMonthly.String(), result.MonthlyPayment.String())
+	if len(similar) != 0 {
+		t.Errorf("Expected no similar loans for the first entry, got %d", len(similar))
 	}
 
-	if result.FirstPaymentYear != 2024 || result.FirstPaymentMonth != 3 {
-		t.Errorf("Expected first payment 2024-03, got %d-%d", result.FirstPaymentYear, result.FirstPaymentMonth)
+	// Re-entering the same purchase is created but flagged
+	reentry := input
+	reentry.ItemName = "  headphones "
+	created, similar, err := service.CreateLoanWithDuplicateCheck(workspaceID, reentry)
+	if err != nil {
+		t.Fatalf("Expected the re-entry to be created, got %v", err)
+	}
+	if created == nil || created.ID == existing.ID {
+		t.Fatalf("Expected a new loan, got %+v", created)
+	}
+	if len(similar) != 1 || similar[0].ID != existing.ID {
+		t.Errorf("Expected the existing loan to be reported, got %v", similar)
 	}
-}
-
-func TestPreviewLoan_InvalidProvider(t *testing.T) {
-	loanRepo := testutil.NewMockLoanRepository()
-	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
 
-	input := PreviewLoanInput{
-		ProviderID:   999,
-		TotalAmount:  decimal.NewFromInt(100),
-		NumMonths:    3,
-		PurchaseDate: time.Now(),
+	// A different amount is a different purchase
+	differentAmount := input
+	differentAmount.TotalAmount = decimal.NewFromInt(450)
+	similar, err = service.FindSimilarLoans(workspaceID, differentAmount)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(similar) != 0 {
+		t.Errorf("Expected no similar loans for a different amount, got %d", len(similar))
 	}
 
-	_, err := service.PreviewLoan(1, input)
-	if err != domain.ErrLoanProviderInvalid {
-		t.Errorf("Expected ErrLoanProviderInvalid, got %v", err)
+	// Confirming skips the warning
+	reentry.ConfirmDuplicate = true
+	_, similar, err = service.CreateLoanWithDuplicateCheck(workspaceID, reentry)
+	if err != nil {
+		t.Fatalf("Expected confirmed duplicate to be created, got %v", err)
+	}
+	if len(similar) != 0 {
+		t.Errorf("Expected no warning once confirmed, got %d similar loans", len(similar))
+	}
+	loans, _ := loanRepo.GetAllByWorkspace(workspaceID)
+	if len(loans) != 3 {
+		t.Errorf("Expected 3 loans, got %d", len(loans))
 	}
 }
 
-// GetLoans tests
-
-func TestGetLoans_Success(t *testing.T) {
+func TestFindSimilarLoans_ComparesFinancedAmount(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ItemName:    "Loan 1",
-	})
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          2,
-		WorkspaceID: workspaceID,
-		ItemName:    "Loan 2",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
 	})
 
-	loans, err := service.GetLoans(workspaceID)
+	// RM300 with RM100 down stores a financed total of RM200
+	input := CreateLoanInput{
+		ProviderID:        1,
+		ItemName:          "Headphones",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         2,
+		PurchaseDate:      time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:         1,
+		DownPaymentAmount: decimal.NewFromInt(100),
+	}
+	existing, err := service.CreateLoan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(loans) != 2 {
-		t.Errorf("Expected 2 loans, got %d", len(loans))
+	similar, err := service.FindSimilarLoans(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(similar) != 1 || similar[0].ID != existing.ID {
+		t.Errorf("Expected the loan with the same down payment to be reported, got %v", similar)
 	}
 }
 
-func TestGetLoans_Empty(t *testing.T) {
+func TestCreateLoan_WithAccountNoSettlementIntent(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	loans, err := service.GetLoans(1)
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test Item",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    1, // Bank account from helper, no settlement intent needed
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(loans) != 0 {
-		t.Errorf("Expected 0 loans, got %d", len(loans))
+	if loan.AccountID != 1 {
+		t.Errorf("Expected AccountID 1, got %d", loan.AccountID)
 	}
-}
 
-// GetLoanByID tests
+	if loan.SettlementIntent != nil {
+		t.Errorf("Expected SettlementIntent nil, got %v", loan.SettlementIntent)
+	}
+}
 
-func TestGetLoanByID_Success(t *testing.T) {
+func TestCreateLoan_WithNotes(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
 		ID:          1,
 		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
+		Name:        "Provider",
+		CutoffDay:   25,
 	})
 
-	loan, err := service.GetLoanByID(workspaceID, 1)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	notes := "This is a test note"
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test",
+		TotalAmount:  decimal.NewFromInt(100),
+		NumMonths:    3,
+		PurchaseDate: time.Now(),
+		Notes:        &notes,
+		AccountID:    1,
 	}
 
-	if loan.ItemName != "Test Loan" {
-		t.Errorf("Expected 'Test Loan', got '%s'", loan.ItemName)
+	loan, err := service.CreateLoan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
-}
-
-func TestGetLoanByID_NotFound(t *testing.T) {
-	loanRepo := testutil.NewMockLoanRepository()
-	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
 
-	_, err := service.GetLoanByID(1, 999)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	if loan.Notes == nil || *loan.Notes != notes {
+		t.Errorf("Expected notes '%s', got %v", notes, loan.Notes)
 	}
 }
 
-// DeleteLoan tests
+// PreviewLoan tests
 
-func TestDeleteLoan_Success(t *testing.T) {
+func TestPreviewLoan_Success(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
 	})
 
-	err := service.DeleteLoan(workspaceID, 1)
+	input := PreviewLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	result, err := service.PreviewLoan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify loan is deleted
-	_, err = service.GetLoanByID(workspaceID, 1)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound after delete, got %v", err)
+	expectedMonthly := decimal.NewFromInt(100)
+	if !result.MonthlyPayment.Equal(expectedMonthly) {
+		t.Errorf("Expected monthly payment %s, got %s", expectedMonthly.String(), result.MonthlyPayment.String())
+	}
+
+	if result.FirstPaymentYear != 2024 || result.FirstPaymentMonth != 3 {
+		t.Errorf("Expected first payment 2024-03, got %d-%d", result.FirstPaymentYear, result.FirstPaymentMonth)
 	}
 }
 
-func TestDeleteLoan_NotFound(t *testing.T) {
+func TestPreviewLoan_MaxMonths(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	err := service.DeleteLoan(1, 999)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         1,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	input := PreviewLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(1200),
+		NumMonths:    domain.DefaultMaxLoanMonths,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+	if _, err := service.PreviewLoan(1, input); err != nil {
+		t.Fatalf("Expected no error at max months, got %v", err)
 	}
-}
 
-// GetLoansWithStats tests
+	input.NumMonths = domain.DefaultMaxLoanMonths + 1
+	if _, err := service.PreviewLoan(1, input); !errors.Is(err, domain.ErrLoanMonthsExceedsMax) {
+		t.Errorf("Expected ErrLoanMonthsExceedsMax, got %v", err)
+	}
+}
 
-func TestGetLoansWithStats_AllFilter(t *testing.T) {
+func TestPreviewLoan_InvalidProvider(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	workspaceID := int32(1)
-	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
-		{
-			Loan:             domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Active Loan"},
-			TotalCount:       6,
-			PaidCount:        2,
-			RemainingBalance: decimal.NewFromInt(400),
-			Progress:         33.33,
-		},
-		{
-			Loan:             domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Completed Loan"},
-			TotalCount:       3,
-			PaidCount:        3,
-			RemainingBalance: decimal.Zero,
-			Progress:         100,
-		},
-	})
-
-	loans, err := service.GetLoansWithStats(workspaceID, domain.LoanFilterAll)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	input := PreviewLoanInput{
+		ProviderID:   999,
+		TotalAmount:  decimal.NewFromInt(100),
+		NumMonths:    3,
+		PurchaseDate: time.Now(),
 	}
 
-	if len(loans) != 2 {
-		t.Errorf("Expected 2 loans with 'all' filter, got %d", len(loans))
+	_, err := service.PreviewLoan(1, input)
+	if err != domain.ErrLoanProviderInvalid {
+		t.Errorf("Expected ErrLoanProviderInvalid, got %v", err)
 	}
 }
 
-func TestGetLoansWithStats_ActiveFilter(t *testing.T) {
+func TestPreviewLoan_PromoPeriodThenDefaultRate(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.SetActiveWithStats([]*domain.LoanWithStats{
-		{
-			Loan:             domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Active Loan"},
-			TotalCount:       6,
-			PaidCount:        2,
-			RemainingBalance: decimal.NewFromInt(400),
-			Progress:         33.33,
-		},
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "Kredivo",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(10),
+		PromoInterestRate:   decimal.Zero,
+		PromoMonths:         3,
 	})
 
-	loans, err := service.GetLoansWithStats(workspaceID, domain.LoanFilterActive)
+	result, err := service.PreviewLoan(workspaceID, PreviewLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(600),
+		NumMonths:    6,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(loans) != 1 {
-		t.Errorf("Expected 1 loan with 'active' filter, got %d", len(loans))
+	if result.PromoMonths != 3 {
+		t.Errorf("Expected 3 promo months, got %d", result.PromoMonths)
 	}
-
-	if loans[0].ItemName != "Active Loan" {
-		t.Errorf("Expected 'Active Loan', got '%s'", loans[0].ItemName)
+	if !result.PromoMonthlyPayment.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected promo monthly payment 100, got %s", result.PromoMonthlyPayment.String())
+	}
+	if !result.MonthlyPayment.Equal(decimal.NewFromInt(110)) {
+		t.Errorf("Expected monthly payment 110, got %s", result.MonthlyPayment.String())
+	}
+	if len(result.Schedule) != 6 {
+		t.Fatalf("Expected 6 schedule entries, got %d", len(result.Schedule))
+	}
+	// 600 / 6 = 100 principal per month; 10% of 600 spread over 6 months adds 10
+	for i, entry := range result.Schedule {
+		expected := decimal.NewFromInt(110)
+		if i < 3 {
+			expected = decimal.NewFromInt(100)
+		}
+		if !entry.Payment.Equal(expected) {
+			t.Errorf("Expected payment %d to be %s, got %s", entry.PaymentNumber, expected, entry.Payment)
+		}
 	}
+}
 
-	if !loans[0].RemainingBalance.GreaterThan(decimal.Zero) {
-		t.Errorf("Active loan should have remaining balance > 0")
+func TestCalculatePaymentAmounts_PromoThenDefaultRate(t *testing.T) {
+	// RM 600 over 6 months: 0% for the first 3 payments, then 10%
+	amounts := CalculatePaymentAmounts(decimal.NewFromInt(600), decimal.NewFromInt(10), decimal.Zero, 3, 6)
+
+	if len(amounts) != 6 {
+		t.Fatalf("Expected 6 amounts, got %d", len(amounts))
+	}
+	for i, amount := range amounts {
+		expected := decimal.NewFromInt(110)
+		if i < 3 {
+			expected = decimal.NewFromInt(100)
+		}
+		if !amount.Equal(expected) {
+			t.Errorf("Expected payment %d to be %s, got %s", i+1, expected, amount)
+		}
 	}
 }
 
-func TestGetLoansWithStats_CompletedFilter(t *testing.T) {
+func TestPreviewLoan_MonthlyFeeRaisesEffectivePayment(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.SetCompletedWithStats([]*domain.LoanWithStats{
-		{
-			Loan:             domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Completed Loan"},
-			TotalCount:       3,
-			PaidCount:        3,
-			RemainingBalance: decimal.Zero,
-			Progress:         100,
-		},
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "Atome",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+		MonthlyFee:          decimal.NewFromInt(5),
+		FeeMode:             domain.FeeModeSeparate,
 	})
 
-	loans, err := service.GetLoansWithStats(workspaceID, domain.LoanFilterCompleted)
+	result, err := service.PreviewLoan(workspaceID, PreviewLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(loans) != 1 {
-		t.Errorf("Expected 1 loan with 'completed' filter, got %d", len(loans))
+	if !result.MonthlyPayment.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected monthly payment 100, got %s", result.MonthlyPayment.String())
 	}
-
-	if loans[0].ItemName != "Completed Loan" {
-		t.Errorf("Expected 'Completed Loan', got '%s'", loans[0].ItemName)
+	if !result.EffectiveMonthlyPayment.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("Expected effective monthly payment 105, got %s", result.EffectiveMonthlyPayment.String())
 	}
-
-	if loans[0].Progress != 100 {
-		t.Errorf("Completed loan should have 100%% progress, got %.2f", loans[0].Progress)
+	if len(result.Schedule) != 3 {
+		t.Fatalf("Expected 3 schedule entries, got %d", len(result.Schedule))
+	}
+	for _, entry := range result.Schedule {
+		if !entry.Fee.Equal(decimal.NewFromInt(5)) || !entry.Total.Equal(decimal.NewFromInt(105)) {
+			t.Errorf("Expected fee 5 and total 105 in payment %d, got fee %s total %s", entry.PaymentNumber, entry.Fee, entry.Total)
+		}
+	}
+	last := result.Schedule[2]
+	if last.Year != 2024 || last.Month != 5 {
+		t.Errorf("Expected last payment 2024-05, got %d-%d", last.Year, last.Month)
 	}
 }
 
-func TestGetLoansWithStats_EmptyResult(t *testing.T) {
+func TestCreateLoan_FirstPaymentOverride(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	loans, err := service.GetLoansWithStats(1, domain.LoanFilterAll)
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "SPayLater",
+		CutoffDay:   25,
+	})
+
+	purchaseDate := time.Now()
+	override := time.Date(purchaseDate.Year(), purchaseDate.Month()+3, 1, 0, 0, 0, 0, time.UTC)
+	input := CreateLoanInput{
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         3,
+		PurchaseDate:      purchaseDate,
+		AccountID:         1,
+		FirstPaymentYear:  override.Year(),
+		FirstPaymentMonth: int(override.Month()),
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
+	if int(loan.FirstPaymentYear) != override.Year() || int(loan.FirstPaymentMonth) != int(override.Month()) {
+		t.Errorf("Expected first payment %s, got %d-%02d", override.Format("2006-01"), loan.FirstPaymentYear, loan.FirstPaymentMonth)
+	}
 
-	if len(loans) != 0 {
-		t.Errorf("Expected 0 loans, got %d", len(loans))
+	plan, err := service.ValidateLoanPlan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if plan.Schedule[0].Year != override.Year() || plan.Schedule[0].Month != int(override.Month()) {
+		t.Errorf("Expected schedule to start %s, got %d-%02d", override.Format("2006-01"), plan.Schedule[0].Year, plan.Schedule[0].Month)
 	}
 }
 
-func TestGetLoansWithStats_DefaultsToAll(t *testing.T) {
+func TestCreateLoan_FirstPaymentDefaultsToCutoff(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
-		{Loan: domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Loan 1"}},
-		{Loan: domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Loan 2"}},
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "SPayLater",
+		CutoffDay:   25,
 	})
 
-	// Empty string should default to all
-	loans, err := service.GetLoansWithStats(workspaceID, domain.LoanFilter(""))
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Phone",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC),
+		AccountID:    1,
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	if len(loans) != 2 {
-		t.Errorf("Expected 2 loans with empty filter (defaults to all), got %d", len(loans))
+	// Purchased after the cutoff day, so payments start the following month
+	if loan.FirstPaymentYear != 2024 || loan.FirstPaymentMonth != 4 {
+		t.Errorf("Expected first payment 2024-04, got %d-%02d", loan.FirstPaymentYear, loan.FirstPaymentMonth)
 	}
 }
 
-// Domain method tests
+func TestCreateLoan_FirstPaymentOverrideTooFarInPast(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
-func TestLoan_IsActive(t *testing.T) {
-	tests := []struct {
-		name              string
-		firstPaymentYear  int32
-		firstPaymentMonth int32
-		numMonths         int32
-		currentYear       int
-		currentMonth      int
-		expectedActive    bool
-	}{
-		{
-			name:              "Active - current month is first payment month",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         3,
-			currentYear:       2024,
-			currentMonth:      3,
-			expectedActive:    true,
-		},
-		{
-			name:              "Active - in the middle of payments",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         3,
-			currentYear:       2024,
-			currentMonth:      4,
-			expectedActive:    true,
-		},
-		{
-			name:              "Active - last payment month",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         3,
-			currentYear:       2024,
-			currentMonth:      5,
-			expectedActive:    true,
-		},
-		{
-			name:              "Completed - one month after last payment",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         3,
-			currentYear:       2024,
-			currentMonth:      6,
-			expectedActive:    false,
-		},
-		{
-			name:              "Active - crosses year boundary",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 11,
-			numMonths:         4, // Nov, Dec, Jan, Feb
-			currentYear:       2025,
-			currentMonth:      1,
-			expectedActive:    true,
-		},
-		{
-			name:              "Completed - after year boundary",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 11,
-			numMonths:         4, // Nov, Dec, Jan, Feb
-			currentYear:       2025,
-			currentMonth:      3,
-			expectedActive:    false,
-		},
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "SPayLater",
+		CutoffDay:   25,
+	})
+
+	past := time.Now().AddDate(0, -(domain.FirstPaymentOverrideToleranceMonths + 2), 0)
+	input := CreateLoanInput{
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         3,
+		PurchaseDate:      time.Now(),
+		AccountID:         1,
+		FirstPaymentYear:  past.Year(),
+		FirstPaymentMonth: int(past.Month()),
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			loan := &domain.Loan{
-				FirstPaymentYear:  tt.firstPaymentYear,
-				FirstPaymentMonth: tt.firstPaymentMonth,
-				NumMonths:         tt.numMonths,
-			}
-			result := loan.IsActive(tt.currentYear, tt.currentMonth)
-			if result != tt.expectedActive {
-				t.Errorf("Expected IsActive=%v, got %v", tt.expectedActive, result)
-			}
-		})
+	if _, err := service.CreateLoan(workspaceID, input); err != domain.ErrLoanFirstPaymentInvalid {
+		t.Errorf("Expected ErrLoanFirstPaymentInvalid, got %v", err)
 	}
 }
 
-// UpdateLoan tests
+// ValidateLoanPlan tests
 
-func TestUpdateLoan_Success(t *testing.T) {
+func TestValidateLoanPlan_ValidPlan(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	providerID := int32(1)
-
-	// Add provider that the loan references
-	providerRepo.AddProvider(&domain.LoanProvider{
-		ID:          providerID,
-		WorkspaceID: workspaceID,
-		Name:        "Test Provider",
-	})
-
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ProviderID:  providerID,
-		ItemName:    "Original Name",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(10),
 	})
 
-	notes := "Updated notes"
-	input := UpdateLoanInput{
-		ItemName: "Updated Name",
-		Notes:    &notes,
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Phone",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    1,
 	}
 
-	loan, err := service.UpdateLoan(workspaceID, 1, input)
+	plan, err := service.ValidateLoanPlan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if loan.ItemName != "Updated Name" {
-		t.Errorf("Expected 'Updated Name', got '%s'", loan.ItemName)
+	if !plan.Valid() {
+		t.Errorf("Expected no issues, got %v", plan.Issues)
 	}
-
-	if loan.Notes == nil || *loan.Notes != "Updated notes" {
-		t.Errorf("Expected notes 'Updated notes', got %v", loan.Notes)
+	if len(plan.Schedule) != 3 {
+		t.Fatalf("Expected 3 scheduled payments, got %d", len(plan.Schedule))
+	}
+	if !plan.Schedule[0].Amount.Equal(decimal.NewFromInt(110)) {
+		t.Errorf("Expected payment 110, got %s", plan.Schedule[0].Amount)
+	}
+	if plan.Schedule[2].Year != 2024 || plan.Schedule[2].Month != 5 {
+		t.Errorf("Expected last payment 2024-05, got %d-%d", plan.Schedule[2].Year, plan.Schedule[2].Month)
+	}
+	if len(loanRepo.Loans) != 0 {
+		t.Errorf("Expected validation not to persist a loan, got %d", len(loanRepo.Loans))
 	}
 }
 
-func TestUpdateLoan_EmptyItemName(t *testing.T) {
+func TestCompareInterestMethods_NonzeroRateDiffers(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ItemName:    "Original Name",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(12),
 	})
 
-	input := UpdateLoanInput{
-		ItemName: "",
+	comparison, err := service.CompareInterestMethods(workspaceID, CreateLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(1200),
+		NumMonths:    12,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	_, err := service.UpdateLoan(workspaceID, 1, input)
-	if err != domain.ErrLoanItemNameEmpty {
-		t.Errorf("Expected ErrLoanItemNameEmpty, got %v", err)
+	if !comparison.Flat.TotalInterest.Equal(decimal.NewFromInt(144)) {
+		t.Errorf("Expected flat interest 144, got %s", comparison.Flat.TotalInterest)
+	}
+	if comparison.Flat.TotalInterest.Equal(comparison.ReducingBalance.TotalInterest) {
+		t.Errorf("Expected reducing-balance interest to differ from flat, both %s", comparison.Flat.TotalInterest)
+	}
+	if !comparison.ReducingBalance.TotalInterest.IsPositive() {
+		t.Errorf("Expected positive reducing-balance interest, got %s", comparison.ReducingBalance.TotalInterest)
+	}
+	if len(loanRepo.Loans) != 0 {
+		t.Errorf("Expected comparison not to persist a loan, got %d", len(loanRepo.Loans))
 	}
 }
 
-func TestUpdateLoan_ItemNameTooLong(t *testing.T) {
+func TestCompareInterestMethods_ZeroRateHasNoInterest(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ItemName:    "Original Name",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(12),
 	})
 
-	// Create a name that's 201 characters long
-	longName := ""
-	for i := 0; i < 201; i++ {
-		longName += "A"
+	zero := decimal.Zero
+	comparison, err := service.CompareInterestMethods(workspaceID, CreateLoanInput{
+		ProviderID:   1,
+		TotalAmount:  decimal.NewFromInt(1200),
+		NumMonths:    12,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		InterestRate: &zero,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	input := UpdateLoanInput{
-		ItemName: longName,
+	if !comparison.Flat.TotalInterest.IsZero() || !comparison.ReducingBalance.TotalInterest.IsZero() {
+		t.Errorf("Expected no interest at 0%%, got flat %s and reducing %s", comparison.Flat.TotalInterest, comparison.ReducingBalance.TotalInterest)
 	}
-
-	_, err := service.UpdateLoan(workspaceID, 1, input)
-	if err != domain.ErrLoanItemNameTooLong {
-		t.Errorf("Expected ErrLoanItemNameTooLong, got %v", err)
+	if !comparison.Flat.MonthlyPayment.Equal(decimal.NewFromInt(100)) || !comparison.ReducingBalance.MonthlyPayment.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected both monthly payments to be 100, got %s and %s", comparison.Flat.MonthlyPayment, comparison.ReducingBalance.MonthlyPayment)
 	}
 }
 
-func TestUpdateLoan_LoanNotFound(t *testing.T) {
+func TestValidateLoanPlan_CustomAmountsSumMismatch(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	input := UpdateLoanInput{
-		ItemName: "New Name",
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Phone",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		PaymentAmounts: []decimal.Decimal{
+			decimal.NewFromInt(100),
+			decimal.NewFromInt(100),
+			decimal.NewFromInt(90),
+		},
+		AccountID: 1,
 	}
 
-	_, err := service.UpdateLoan(1, 999, input)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	plan, err := service.ValidateLoanPlan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(plan.Issues) != 1 || plan.Issues[0] != domain.ErrLoanPaymentAmountsSum {
+		t.Fatalf("Expected a single ErrLoanPaymentAmountsSum issue, got %v", plan.Issues)
+	}
+	if len(plan.Schedule) != 3 || !plan.Schedule[2].Amount.Equal(decimal.NewFromInt(90)) {
+		t.Errorf("Expected the schedule to reflect the custom amounts, got %+v", plan.Schedule)
+	}
+
+	// CreateLoan rejects the same plan
+	if _, err := service.CreateLoan(workspaceID, input); err != domain.ErrLoanPaymentAmountsSum {
+		t.Errorf("Expected CreateLoan to return ErrLoanPaymentAmountsSum, got %v", err)
 	}
 }
 
-func TestUpdateLoan_ClearsNotes(t *testing.T) {
+func TestCreateLoan_CustomAmountsCheckedAgainstPromoTotal(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
+	// RM 600 over 6 months: 0% for the first 3 payments, then 10%, so 630 is repaid rather than 660
 	workspaceID := int32(1)
-	providerID := int32(1)
-	originalNotes := "Original notes"
-
-	// Add provider that the loan references
-	providerRepo.AddProvider(&domain.LoanProvider{
-		ID:          providerID,
-		WorkspaceID: workspaceID,
-		Name:        "Test Provider",
-	})
-
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		ProviderID:  providerID,
-		ItemName:    "Original Name",
-		Notes:       &originalNotes,
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "Kredivo",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(10),
+		PromoInterestRate:   decimal.Zero,
+		PromoMonths:         3,
 	})
 
-	// Update with nil notes (clear notes)
-	input := UpdateLoanInput{
-		ItemName: "Updated Name",
-		Notes:    nil,
+	amounts := make([]decimal.Decimal, 6)
+	for i := range amounts {
+		amounts[i] = decimal.NewFromInt(105)
+	}
+	input := CreateLoanInput{
+		ProviderID:     1,
+		ItemName:       "Phone",
+		TotalAmount:    decimal.NewFromInt(600),
+		NumMonths:      6,
+		PurchaseDate:   time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		PaymentAmounts: amounts,
+		AccountID:      1,
 	}
 
-	loan, err := service.UpdateLoan(workspaceID, 1, input)
+	plan, err := service.ValidateLoanPlan(workspaceID, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
+	if !plan.ExpectedTotal.Equal(decimal.NewFromInt(630)) {
+		t.Errorf("Expected promo-aware total 630, got %s", plan.ExpectedTotal)
+	}
+	if len(plan.Issues) != 0 {
+		t.Errorf("Expected no issues, got %v", plan.Issues)
+	}
 
-	if loan.Notes != nil {
-		t.Errorf("Expected notes to be nil, got %v", loan.Notes)
+	if _, err := service.CreateLoan(workspaceID, input); err != nil {
+		t.Errorf("Expected CreateLoan to accept amounts matching the promo total, got %v", err)
 	}
 }
 
-// GetDeleteStats tests
-// NOTE: GetDeleteStats is currently stubbed (v2 migration). Tests verify stub behavior.
-
-func TestGetDeleteStats_Success(t *testing.T) {
-	loanRepo := testutil.NewMockLoanRepository()
-	providerRepo := testutil.NewMockLoanProviderRepository()
-	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+// GenerateLoanTransactions tests
 
-	workspaceID := int32(1)
-	loanID := int32(1)
-	loanRepo.AddLoan(&domain.Loan{
-		ID:             loanID,
-		WorkspaceID:    workspaceID,
-		ItemName:       "Test Loan",
-		TotalAmount:    decimal.NewFromInt(300),
-		NumMonths:      3,
-		MonthlyPayment: decimal.NewFromInt(100),
-	})
+func TestGenerateLoanTransactions_SeparateFeeLine(t *testing.T) {
+	fee := decimal.NewFromInt(5)
+	transactions := GenerateLoanTransactions(1, 7, 1, "Phone", decimal.NewFromInt(100), 3, 2024, 11, false, nil, nil, fee, domain.FeeModeSeparate)
 
-	// Add transactions for the loan: 1 paid, 2 unpaid
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:          1,
-		WorkspaceID: workspaceID,
-		LoanID:      &loanID,
-		Amount:      decimal.NewFromInt(-100),
-		IsPaid:      true,
-		Name:        "Test Loan Payment 1",
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:          2,
-		WorkspaceID: workspaceID,
-		LoanID:      &loanID,
-		Amount:      decimal.NewFromInt(-100),
-		IsPaid:      false,
-		Name:        "Test Loan Payment 2",
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:          3,
-		WorkspaceID: workspaceID,
-		LoanID:      &loanID,
-		Amount:      decimal.NewFromInt(-100),
-		IsPaid:      false,
-		Name:        "Test Loan Payment 3",
-	})
-
-	loan, stats, err := service.GetDeleteStats(workspaceID, loanID)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	if len(transactions) != 6 {
+		t.Fatalf("Expected 6 transactions (3 payments + 3 fees), got %d", len(transactions))
 	}
-
-	if loan.ItemName != "Test Loan" {
-		t.Errorf("Expected 'Test Loan', got '%s'", loan.ItemName)
+	for i := 0; i < len(transactions); i += 2 {
+		payment, feeLine := transactions[i], transactions[i+1]
+		if !payment.Amount.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("Expected payment amount 100, got %s", payment.Amount.String())
+		}
+		if !feeLine.Amount.Equal(fee) || feeLine.Name != "Phone (fee)" {
+			t.Errorf("Expected fee line 'Phone (fee)' of 5, got %q of %s", feeLine.Name, feeLine.Amount.String())
+		}
+		if !feeLine.TransactionDate.Equal(payment.TransactionDate) {
+			t.Errorf("Expected fee on %s, got %s", payment.TransactionDate, feeLine.TransactionDate)
+		}
+		if feeLine.LoanID == nil || *feeLine.LoanID != 7 {
+			t.Error("Expected fee line to be linked to the loan")
+		}
+		if !feeLine.IsLoanFee || payment.IsLoanFee {
+			t.Error("Expected only the fee line to be marked as a loan fee")
+		}
 	}
+}
 
-	// v2: Stats come from transactions table
-	if stats.TotalCount != 3 {
-		t.Errorf("Expected total count 3, got %d", stats.TotalCount)
+func TestGenerateLoanTransactions_FoldedFee(t *testing.T) {
+	transactions := GenerateLoanTransactions(1, 7, 1, "Phone", decimal.NewFromInt(100), 3, 2024, 11, false, nil, nil, decimal.NewFromInt(5), domain.FeeModeFolded)
+
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+	for _, tx := range transactions {
+		if !tx.Amount.Equal(decimal.NewFromInt(105)) {
+			t.Errorf("Expected folded amount 105, got %s", tx.Amount.String())
+		}
 	}
+}
 
-	if stats.PaidCount != 1 {
-		t.Errorf("Expected paid count 1, got %d", stats.PaidCount)
+func TestGenerateLoanTransactions_NoFee(t *testing.T) {
+	transactions := GenerateLoanTransactions(1, 7, 1, "Phone", decimal.NewFromInt(100), 3, 2024, 11, false, nil, nil, decimal.Zero, domain.FeeModeSeparate)
+
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
 	}
+}
 
-	if stats.UnpaidCount != 2 {
-		t.Errorf("Expected unpaid count 2, got %d", stats.UnpaidCount)
+// GetLoans tests
+
+func TestGetLoans_Success(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ItemName:    "Loan 1",
+	})
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          2,
+		WorkspaceID: workspaceID,
+		ItemName:    "Loan 2",
+	})
+
+	loans, err := service.GetLoans(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	expectedTotal := decimal.NewFromInt(300)
-	if !stats.TotalAmount.Equal(expectedTotal) {
-		t.Errorf("Expected total amount %s, got %s", expectedTotal.String(), stats.TotalAmount.String())
+	if len(loans) != 2 {
+		t.Errorf("Expected 2 loans, got %d", len(loans))
 	}
 }
 
-func TestGetDeleteStats_LoanNotFound(t *testing.T) {
+func TestGetLoans_Empty(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
 
-	_, _, err := service.GetDeleteStats(1, 999)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	loans, err := service.GetLoans(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loans) != 0 {
+		t.Errorf("Expected 0 loans, got %d", len(loans))
 	}
 }
 
-func TestGetDeleteStats_NoPayments(t *testing.T) {
+// GetLoanByID tests
+
+func TestGetLoanByID_Success(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
 	service := createTestLoanService(loanRepo, providerRepo)
@@ -1258,7 +1740,7 @@ func TestGetDeleteStats_NoPayments(t *testing.T) {
 		ItemName:    "Test Loan",
 	})
 
-	loan, stats, err := service.GetDeleteStats(workspaceID, 1)
+	loan, err := service.GetLoanByID(workspaceID, 1)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -1266,1255 +1748,3623 @@ func TestGetDeleteStats_NoPayments(t *testing.T) {
 	if loan.ItemName != "Test Loan" {
 		t.Errorf("Expected 'Test Loan', got '%s'", loan.ItemName)
 	}
-
-	if stats.TotalCount != 0 {
-		t.Errorf("Expected total count 0, got %d", stats.TotalCount)
-	}
-
-	if stats.PaidCount != 0 {
-		t.Errorf("Expected paid count 0, got %d", stats.PaidCount)
-	}
 }
 
-func TestLoan_GetLastPaymentYearMonth(t *testing.T) {
-	tests := []struct {
-		name              string
-		firstPaymentYear  int32
-		firstPaymentMonth int32
-		numMonths         int32
-		expectedYear      int
-		expectedMonth     int
-	}{
-		{
-			name:              "Single month",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         1,
-			expectedYear:      2024,
-			expectedMonth:     3,
-		},
-		{
-			name:              "Three months same year",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 3,
-			numMonths:         3,
-			expectedYear:      2024,
-			expectedMonth:     5,
-		},
-		{
-			name:              "Crosses year boundary",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 11,
-			numMonths:         4,
-			expectedYear:      2025,
-			expectedMonth:     2,
-		},
-		{
-			name:              "12 months",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 1,
-			numMonths:         12,
-			expectedYear:      2024,
-			expectedMonth:     12,
-		},
-		{
-			name:              "24 months",
-			firstPaymentYear:  2024,
-			firstPaymentMonth: 1,
-			numMonths:         24,
-			expectedYear:      2025,
-			expectedMonth:     12,
-		},
-	}
+func TestGetLoanByID_NotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			loan := &domain.Loan{
-				FirstPaymentYear:  tt.firstPaymentYear,
-				FirstPaymentMonth: tt.firstPaymentMonth,
-				NumMonths:         tt.numMonths,
-			}
-			year, month := loan.GetLastPaymentYearMonth()
-			if year != tt.expectedYear || month != tt.expectedMonth {
-				t.Errorf("Expected %d-%02d, got %d-%02d", tt.expectedYear, tt.expectedMonth, year, month)
-			}
-		})
+	_, err := service.GetLoanByID(1, 999)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
 	}
 }
 
-// GetTrend tests
-// NOTE: GetTrend stub in v2 returns months with zero totals and empty providers.
-// The stub preserves month count logic (defaults, caps) but returns empty data.
+// GetLoanDetail tests
 
-func TestGetTrend_ReturnsZeroTotalMonths(t *testing.T) {
+func TestGetLoanDetail_BundlesLoanScheduleAndStats(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	result, err := service.GetTrend(1, 6)
+	workspaceID := int32(1)
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Test Provider",
+	})
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                1,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         3,
+		MonthlyPayment:    decimal.NewFromInt(100),
+		FirstPaymentYear:  2025,
+		FirstPaymentMonth: 11,
+		AccountID:         1,
+	})
+
+	loanID := int32(1)
+	// Added out of order to verify the schedule is sorted by due date
+	for i, month := range []time.Month{time.December, time.November, time.January} {
+		year := 2025
+		if month == time.January {
+			year = 2026
+		}
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionDate: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
+			IsPaid:          month != time.January,
+			LoanID:          &loanID,
+		})
+	}
+
+	detail, err := service.GetLoanDetail(workspaceID, loanID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Stub returns requested number of months with zero data
-	if len(result.Months) != 6 {
-		t.Errorf("Expected 6 months, got %d", len(result.Months))
+	if detail.Loan.ItemName != "Phone" {
+		t.Errorf("Expected loan 'Phone', got '%s'", detail.Loan.ItemName)
 	}
-
-	// All months should have zero totals and no providers
-	for i, month := range result.Months {
-		if !month.Total.Equal(decimal.Zero) {
-			t.Errorf("Month %d: expected total 0, got %s", i, month.Total.String())
-		}
-		if len(month.Providers) != 0 {
-			t.Errorf("Month %d: expected 0 providers, got %d", i, len(month.Providers))
-		}
+	if detail.Provider == nil || detail.Provider.Name != "Test Provider" {
+		t.Errorf("Expected provider 'Test Provider', got %+v", detail.Provider)
+	}
+	if len(detail.Schedule) != 3 {
+		t.Fatalf("Expected 3 scheduled payments, got %d", len(detail.Schedule))
+	}
+	if detail.Schedule[0].TransactionDate.Month() != time.November || detail.Schedule[2].TransactionDate.Month() != time.January {
+		t.Errorf("Expected schedule ordered by due date, got %v .. %v", detail.Schedule[0].TransactionDate, detail.Schedule[2].TransactionDate)
+	}
+	if detail.Loan.TotalCount != 3 || detail.Loan.PaidCount != 2 {
+		t.Errorf("Expected 2/3 paid, got %d/%d", detail.Loan.PaidCount, detail.Loan.TotalCount)
+	}
+	if !detail.Loan.RemainingBalance.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected remaining balance 100, got %s", detail.Loan.RemainingBalance)
+	}
+	if detail.Loan.LastPaymentYear != 2026 || detail.Loan.LastPaymentMonth != 1 {
+		t.Errorf("Expected last payment 2026-01, got %d-%02d", detail.Loan.LastPaymentYear, detail.Loan.LastPaymentMonth)
 	}
 }
 
-func TestGetTrend_DefaultsTo12Months(t *testing.T) {
+func TestGetLoanDetail_FeeLinesNotCountedAsPayments(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	// months = 0 should default to 12
-	result, err := service.GetTrend(1, 0)
+	workspaceID := int32(1)
+	loanID := int32(1)
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Test Provider"})
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		NumMonths:         2,
+		FirstPaymentYear:  2025,
+		FirstPaymentMonth: 11,
+		AccountID:         1,
+	})
+
+	// Two payments of 100, each with a separate fee line of 5; November is paid
+	txns := GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 2, 2025, 11, false, nil, nil, decimal.NewFromInt(5), domain.FeeModeSeparate)
+	for i, txn := range txns {
+		txn.ID = int32(i + 1)
+		txn.IsPaid = txn.TransactionDate.Month() == time.November
+		transactionRepo.AddTransaction(txn)
+	}
+
+	detail, err := service.GetLoanDetail(workspaceID, loanID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(result.Months) != 12 {
-		t.Errorf("Expected 12 months (default), got %d", len(result.Months))
+	if detail.Loan.TotalCount != 2 || detail.Loan.PaidCount != 1 {
+		t.Errorf("Expected 1/2 paid, got %d/%d", detail.Loan.PaidCount, detail.Loan.TotalCount)
+	}
+	if !detail.Loan.RemainingBalance.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("Expected remaining balance 105 including the fee, got %s", detail.Loan.RemainingBalance)
+	}
+	if len(detail.Schedule) != 4 {
+		t.Errorf("Expected the schedule to keep all 4 rows, got %d", len(detail.Schedule))
 	}
 }
 
-func TestGetTrend_MaxCapsAt24Months(t *testing.T) {
+func TestGetLoanDetail_DownPaymentNotCountedAsPayment(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	service := createTestLoanService(loanRepo, providerRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	// months > 24 should cap at 24
-	result, err := service.GetTrend(1, 36)
+	workspaceID := int32(1)
+	loanID := int32(1)
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Test Provider"})
+	loan := &domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(200),
+		NumMonths:         2,
+		PurchaseDate:      time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC),
+		FirstPaymentYear:  2025,
+		FirstPaymentMonth: 11,
+		AccountID:         1,
+		DownPaymentAmount: decimal.NewFromInt(100),
+	}
+	loanRepo.AddLoan(loan)
+
+	// Two unpaid payments of 100 plus the paid down payment
+	txns := GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 2, 2025, 11, false, nil, nil, decimal.Zero, domain.FeeModeFolded)
+	txns = append(txns, newDownPaymentTransaction(loan, &domain.Account{ID: 1, Template: domain.TemplateBank}))
+	for i, txn := range txns {
+		txn.ID = int32(i + 1)
+		transactionRepo.AddTransaction(txn)
+	}
+
+	detail, err := service.GetLoanDetail(workspaceID, loanID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if len(result.Months) != 24 {
-		t.Errorf("Expected 24 months (max), got %d", len(result.Months))
+	if detail.Loan.TotalCount != 2 || detail.Loan.PaidCount != 0 {
+		t.Errorf("Expected 0/2 paid, got %d/%d", detail.Loan.PaidCount, detail.Loan.TotalCount)
+	}
+	if !detail.Loan.RemainingBalance.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected remaining balance 200, got %s", detail.Loan.RemainingBalance)
+	}
+	if len(detail.Schedule) != 3 {
+		t.Errorf("Expected the schedule to list the down payment too, got %d rows", len(detail.Schedule))
 	}
-}
 
-// ============================================================================
-// CC Loan Integration Tests (cl-v2-2-3)
-// Tests verifying CC-backed loan transactions integrate with CC settlement workflow
-// ============================================================================
+	_, unpaid, err := service.GetUnpaidSchedule(workspaceID, loanID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(unpaid) != 2 {
+		t.Errorf("Expected 2 unpaid payments, got %d", len(unpaid))
+	}
+}
 
-// TestCreateLoan_CCAccount_HasCorrectSettlementIntent verifies that when a loan
-// is created with a CC account, the loan has proper settlement_intent (AC: #1)
-func TestCreateLoan_CCAccount_HasCorrectSettlementIntent(t *testing.T) {
+func TestGetLoanDetail_NotFound(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
+	service, _ := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	// Add CC account
-	accountRepo.AddAccount(&domain.Account{
-		ID:          2,
-		WorkspaceID: 1,
-		Name:        "Test Credit Card",
-		Template:    domain.TemplateCreditCard,
-		AccountType: domain.AccountTypeLiability,
-	})
+	_, err := service.GetLoanDetail(1, 999)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
 
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+// DeleteLoan tests
+
+func TestDeleteLoan_Success(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	providerRepo.AddLoanProvider(&domain.LoanProvider{
-		ID:                  1,
-		WorkspaceID:         workspaceID,
-		Name:                "SPayLater",
-		CutoffDay:           25,
-		DefaultInterestRate: decimal.Zero,
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
 	})
 
-	// Create loan with CC account and immediate intent
-	immediateIntent := "immediate"
-	input := CreateLoanInput{
-		ProviderID:       1,
-		ItemName:         "Test CC Loan",
-		TotalAmount:      decimal.NewFromInt(300),
-		NumMonths:        3,
-		PurchaseDate:     time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
-		AccountID:        2, // CC account
-		SettlementIntent: &immediateIntent,
-	}
-
-	loan, err := service.CreateLoan(workspaceID, input)
+	err := service.DeleteLoan(workspaceID, 1)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify loan has correct settlement intent
-	if loan.SettlementIntent == nil || *loan.SettlementIntent != "immediate" {
-		t.Errorf("Expected loan SettlementIntent 'immediate', got %v", loan.SettlementIntent)
-	}
-
-	// Verify loan has CC account
-	if loan.AccountID != 2 {
-		t.Errorf("Expected AccountID 2, got %d", loan.AccountID)
+	// Verify loan is deleted
+	_, err = service.GetLoanByID(workspaceID, 1)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound after delete, got %v", err)
 	}
 }
 
-// TestCreateLoan_CCAccount_DefaultsToDeferredIntent verifies that CC loans without
-// explicit intent default to "deferred" (AC: #1)
-func TestCreateLoan_CCAccount_DefaultsToDeferredIntent(t *testing.T) {
+func TestDeleteLoan_NotFound(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
-	// Add CC account
-	accountRepo.AddAccount(&domain.Account{
-		ID:          2,
-		WorkspaceID: 1,
-		Name:        "Test Credit Card",
-		Template:    domain.TemplateCreditCard,
-		AccountType: domain.AccountTypeLiability,
-	})
+	err := service.DeleteLoan(1, 999)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
 
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+// Trash / RestoreLoan tests
+
+func TestListDeletedLoans_ReturnsOnlyDeleted(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
-	providerRepo.AddLoanProvider(&domain.LoanProvider{
-		ID:                  1,
-		WorkspaceID:         workspaceID,
-		Name:                "SPayLater",
-		CutoffDay:           25,
-		DefaultInterestRate: decimal.Zero,
-	})
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Kept"})
+	loanRepo.AddLoan(&domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Trashed"})
 
-	// Create loan with CC account but NO explicit intent
-	input := CreateLoanInput{
-		ProviderID:   1,
-		ItemName:     "Test CC Loan",
-		TotalAmount:  decimal.NewFromInt(200),
-		NumMonths:    2,
-		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
-		AccountID:    2, // CC account
-		// SettlementIntent NOT set - should default to deferred
+	if err := service.DeleteLoan(workspaceID, 2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	loan, err := service.CreateLoan(workspaceID, input)
+	deleted, err := service.ListDeletedLoans(workspaceID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	// Verify loan defaults to deferred
-	if loan.SettlementIntent == nil || *loan.SettlementIntent != "deferred" {
-		t.Errorf("Expected loan SettlementIntent 'deferred' (default), got %v", loan.SettlementIntent)
-	}
-
-	// Verify transactions have deferred intent
-	for id, tx := range transactionRepo.Transactions {
-		if tx.SettlementIntent == nil || *tx.SettlementIntent != domain.SettlementIntentDeferred {
-			t.Errorf("Transaction %d: expected SettlementIntent 'deferred', got %v", id, tx.SettlementIntent)
-		}
+	if len(deleted) != 1 || deleted[0].ID != 2 {
+		t.Fatalf("Expected only loan 2 in trash, got %+v", deleted)
 	}
 }
 
-// TestCCLoanTransactions_AppearInDeferredSettlementQuery verifies that billed CC loan
-// transactions with deferred intent appear in GetDeferredForSettlement (AC: #4)
-func TestCCLoanTransactions_AppearInDeferredSettlementQuery(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
-	workspaceID := int32(1)
-	loanID := int32(1)
+func TestRestoreLoan_RelinksTransactions(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	// Simulate CC loan transactions that would be created by loan service
-	// These have loan_id set and deferred settlement intent
-	deferredIntent := domain.SettlementIntentDeferred
-	billedState := domain.CCStateBilled
+	workspaceID := int32(1)
 	now := time.Now()
-
-	// Add two billed deferred CC loan transactions
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment 1",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               2,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment 2",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Laptop",
+		FirstPaymentYear:  int32(now.Year()),
+		FirstPaymentMonth: int32(now.Month()),
 	})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 10, WorkspaceID: workspaceID, LoanID: &loanID, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 11, WorkspaceID: workspaceID, LoanID: &loanID, IsPaid: false})
 
-	// Query deferred transactions for settlement
-	deferredTxs, err := transactionRepo.GetDeferredForSettlement(workspaceID)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	if err := service.DeleteLoan(workspaceID, loanID); err != nil {
+		t.Fatalf("Expected no error on delete, got %v", err)
+	}
+	if _, err := transactionRepo.GetByID(workspaceID, 11); err != domain.ErrTransactionNotFound {
+		t.Fatalf("Expected unpaid transaction to be deleted, got %v", err)
 	}
 
-	// Verify loan transactions appear in the results (NOT excluded by loan_id)
-	if len(deferredTxs) != 2 {
-		t.Errorf("Expected 2 deferred transactions (including loan txns), got %d", len(deferredTxs))
+	restored, err := service.RestoreLoan(workspaceID, loanID)
+	if err != nil {
+		t.Fatalf("Expected no error on restore, got %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("Expected restored loan to have no DeletedAt")
+	}
+	if _, err := service.GetLoanByID(workspaceID, loanID); err != nil {
+		t.Errorf("Expected restored loan to be active, got %v", err)
 	}
 
-	// Verify they have loan_id set
-	for _, tx := range deferredTxs {
+	for _, id := range []int32{10, 11} {
+		tx, err := transactionRepo.GetByID(workspaceID, id)
+		if err != nil {
+			t.Fatalf("Expected transaction %d to be active, got %v", id, err)
+		}
 		if tx.LoanID == nil || *tx.LoanID != loanID {
-			t.Errorf("Expected transaction to have LoanID %d, got %v", loanID, tx.LoanID)
+			t.Errorf("Expected transaction %d to be re-linked to loan %d, got %v", id, loanID, tx.LoanID)
+		}
+		if tx.DeletedLoanID != nil {
+			t.Errorf("Expected transaction %d DeletedLoanID to be cleared", id)
 		}
 	}
 }
 
-// TestCCLoanTransactions_AppearInImmediateSettlementQuery verifies that billed CC loan
-// transactions with immediate intent appear in GetImmediateForSettlement (AC: #3)
-func TestCCLoanTransactions_AppearInImmediateSettlementQuery(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
+func TestRefundLoanPayment_PartialRefundReducesBalance(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
 	workspaceID := int32(1)
 	loanID := int32(1)
-
-	// Simulate CC loan transactions that would be created by loan service
-	// These have loan_id set and immediate settlement intent
-	immediateIntent := domain.SettlementIntentImmediate
-	billedState := domain.CCStateBilled
-	now := time.Now()
-
-	// Add two billed immediate CC loan transactions
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment 1",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &immediateIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               2,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment 2",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &immediateIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Headphones",
+		NumMonths:         3,
+		FirstPaymentYear:  2026,
+		FirstPaymentMonth: 11,
 	})
+	// Payments for Nov, Dec and Jan; the second payment's month also carries a fee
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, LoanID: &loanID, Amount: decimal.NewFromInt(100), TransactionDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, LoanID: &loanID, Amount: decimal.NewFromInt(100), TransactionDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: workspaceID, LoanID: &loanID, Amount: decimal.NewFromInt(5), TransactionDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 103, WorkspaceID: workspaceID, LoanID: &loanID, Amount: decimal.NewFromInt(100), TransactionDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)})
 
-	// Query immediate transactions for settlement - use date range that covers the transactions
-	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
-	immediateTxs, err := transactionRepo.GetImmediateForSettlement(workspaceID, startDate, endDate)
-	if err != nil {
+	if _, err := service.RefundLoanPayment(workspaceID, loanID, 2, decimal.NewFromInt(30)); err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify loan transactions appear in the results (NOT excluded by loan_id)
-	if len(immediateTxs) != 2 {
-		t.Errorf("Expected 2 immediate transactions (including loan txns), got %d", len(immediateTxs))
+	payment, _ := transactionRepo.GetByID(workspaceID, 101)
+	if !payment.Amount.Equal(decimal.NewFromInt(70)) {
+		t.Errorf("Expected payment 2 reduced to 70, got %s", payment.Amount)
+	}
+	fee, _ := transactionRepo.GetByID(workspaceID, 102)
+	if !fee.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Expected fee to be untouched, got %s", fee.Amount)
+	}
+	stats, _ := transactionRepo.GetLoanTransactionStats(workspaceID, loanID)
+	if !stats.UnpaidTotal.Equal(decimal.NewFromInt(275)) {
+		t.Errorf("Expected remaining balance 275, got %s", stats.UnpaidTotal)
 	}
 
-	// Verify they have loan_id set
-	for _, tx := range immediateTxs {
-		if tx.LoanID == nil || *tx.LoanID != loanID {
-			t.Errorf("Expected transaction to have LoanID %d, got %v", loanID, tx.LoanID)
-		}
+	// Refunding the rest voids the payment
+	if _, err := service.RefundLoanPayment(workspaceID, loanID, 2, decimal.NewFromInt(70)); err != nil {
+		t.Fatalf("Expected no error on full refund, got %v", err)
+	}
+	if _, err := transactionRepo.GetByID(workspaceID, 101); err != domain.ErrTransactionNotFound {
+		t.Errorf("Expected fully refunded payment to be voided, got %v", err)
 	}
 }
 
-// TestLoanStats_UpdatesWhenTransactionSettled verifies that loan stats
-// (paidCount, remainingBalance) reflect transaction is_paid status (AC: #6, #8)
-func TestLoanStats_UpdatesWhenTransactionSettled(t *testing.T) {
+func TestRefundLoanPayment_ExceedsPaymentRejected(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
 	loanID := int32(1)
-
-	// Setup: loan with 3 payments, 1 paid (settled), 2 unpaid
-	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
-		{
-			Loan: domain.Loan{
-				ID:          loanID,
-				WorkspaceID: workspaceID,
-				ItemName:    "CC Loan",
-			},
-			TotalCount:       3,
-			PaidCount:        1,  // 1 transaction settled (is_paid=true)
-			RemainingBalance: decimal.NewFromInt(200), // 2 * 100
-			Progress:         33.33,
-		},
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Headphones",
+		NumMonths:         1,
+		FirstPaymentYear:  2026,
+		FirstPaymentMonth: 11,
 	})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, LoanID: &loanID, Amount: decimal.NewFromInt(100), TransactionDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)})
 
-	// Get loan stats
-	loans, err := service.GetLoansWithStats(workspaceID, domain.LoanFilterAll)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	_, err := service.RefundLoanPayment(workspaceID, loanID, 1, decimal.NewFromInt(150))
+	if err != domain.ErrRefundExceedsPayment {
+		t.Errorf("Expected ErrRefundExceedsPayment, got %v", err)
+	}
+
+	payment, _ := transactionRepo.GetByID(workspaceID, 100)
+	if !payment.Amount.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected payment to be unchanged, got %s", payment.Amount)
+	}
+}
+
+func TestRestoreLoan_RunsInOneDBTransaction(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	txBeginner := &testutil.MockTxBeginner{}
+	service.pool = txBeginner
+
+	workspaceID := int32(1)
+	now := time.Now()
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Laptop",
+		FirstPaymentYear:  int32(now.Year()),
+		FirstPaymentMonth: int32(now.Month()),
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 10, WorkspaceID: workspaceID, LoanID: &loanID, IsPaid: false})
+
+	if err := service.DeleteLoan(workspaceID, loanID); err != nil {
+		t.Fatalf("Expected no error on delete, got %v", err)
+	}
+
+	if _, err := service.RestoreLoan(workspaceID, loanID); err != nil {
+		t.Fatalf("Expected no error on restore, got %v", err)
+	}
+	if len(txBeginner.Txs) != 1 || !txBeginner.Txs[0].Committed {
+		t.Fatalf("Expected the restore to run in one committed DB transaction, got %+v", txBeginner.Txs)
+	}
+
+	tx, err := transactionRepo.GetByID(workspaceID, 10)
+	if err != nil {
+		t.Fatalf("Expected transaction to be restored, got %v", err)
+	}
+	if tx.LoanID == nil || *tx.LoanID != loanID {
+		t.Errorf("Expected transaction to be re-linked to loan %d, got %v", loanID, tx.LoanID)
+	}
+}
+
+func TestRestoreLoan_ClosedMonthRefused(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	past := time.Now().AddDate(0, -2, 0)
+	closedAt := time.Now()
+	monthRepo := testutil.NewMockMonthRepository()
+	monthRepo.AddMonth(&domain.Month{ID: 1, WorkspaceID: workspaceID, Year: past.Year(), Month: int(past.Month()), ClosedAt: &closedAt})
+	service.SetMonthRepository(monthRepo)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                1,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Old Loan",
+		FirstPaymentYear:  int32(past.Year()),
+		FirstPaymentMonth: int32(past.Month()),
+	})
+
+	if err := service.DeleteLoan(workspaceID, 1); err != nil {
+		t.Fatalf("Expected no error on delete, got %v", err)
+	}
+
+	_, err := service.RestoreLoan(workspaceID, 1)
+	if err != domain.ErrLoanRestoreClosedMonth {
+		t.Errorf("Expected ErrLoanRestoreClosedMonth, got %v", err)
+	}
+}
+
+func TestRestoreLoan_NotDeleted(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: 1, ItemName: "Active"})
+
+	_, err := service.RestoreLoan(1, 1)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
+
+// GetLoansWithStats tests
+
+func TestGetLoansWithStats_AllFilter(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{
+			Loan:             domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Active Loan"},
+			TotalCount:       6,
+			PaidCount:        2,
+			RemainingBalance: decimal.NewFromInt(400),
+			Progress:         33.33,
+		},
+		{
+			Loan:             domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Completed Loan"},
+			TotalCount:       3,
+			PaidCount:        3,
+			RemainingBalance: decimal.Zero,
+			Progress:         100,
+		},
+	})
+
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterAll, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loans) != 2 {
+		t.Errorf("Expected 2 loans with 'all' filter, got %d", len(loans))
+	}
+}
+
+func TestGetLoansWithStats_IncludeDeleted(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	deletedAt := time.Now()
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{Loan: domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Live Loan"}, TotalCount: 3},
+		{Loan: domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Deleted Loan", DeletedAt: &deletedAt}},
+	})
+
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterAll, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(loans) != 1 || loans[0].ID != 1 {
+		t.Errorf("Expected only the live loan by default, got %d loans", len(loans))
+	}
+
+	loans, err = service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterAll, domain.ListOptions{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(loans) != 2 {
+		t.Errorf("Expected deleted loan to be listed with IncludeDeleted, got %d loans", len(loans))
+	}
+}
+
+func TestGetLoansGroupedByProvider(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	deletedAt := time.Now()
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Kredivo"})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 2, WorkspaceID: workspaceID, Name: "Akulaku"})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 3, WorkspaceID: workspaceID, Name: "Empty"})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 4, WorkspaceID: workspaceID, Name: "Gone", DeletedAt: &deletedAt})
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{Loan: domain.Loan{ID: 1, WorkspaceID: workspaceID, ProviderID: 2, ItemName: "Phone"}, RemainingBalance: decimal.NewFromInt(300)},
+		{Loan: domain.Loan{ID: 2, WorkspaceID: workspaceID, ProviderID: 1, ItemName: "Laptop"}, RemainingBalance: decimal.NewFromInt(500)},
+		{Loan: domain.Loan{ID: 3, WorkspaceID: workspaceID, ProviderID: 2, ItemName: "Headphones"}, RemainingBalance: decimal.NewFromInt(150)},
+		{Loan: domain.Loan{ID: 4, WorkspaceID: workspaceID, ProviderID: 4, ItemName: "Chair"}, RemainingBalance: decimal.NewFromInt(80)},
+	})
+
+	groups, err := service.GetLoansGroupedByProvider(context.Background(), workspaceID, domain.LoanFilterAll)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups (two providers plus deleted), got %d", len(groups))
+	}
+
+	expected := []struct {
+		providerID int32
+		name       string
+		deleted    bool
+		loans      int
+		total      int64
+	}{
+		{providerID: 1, name: "Kredivo", loans: 1, total: 500},
+		{providerID: 2, name: "Akulaku", loans: 2, total: 450},
+		{providerID: 0, deleted: true, loans: 1, total: 80},
+	}
+	for i, want := range expected {
+		group := groups[i]
+		if group.ProviderID != want.providerID || group.ProviderName != want.name || group.ProviderDeleted != want.deleted {
+			t.Errorf("Group %d: expected provider %d %q (deleted=%v), got %d %q (deleted=%v)",
+				i, want.providerID, want.name, want.deleted, group.ProviderID, group.ProviderName, group.ProviderDeleted)
+		}
+		if len(group.Loans) != want.loans {
+			t.Errorf("Group %d: expected %d loans, got %d", i, want.loans, len(group.Loans))
+		}
+		if !group.TotalOutstanding.Equal(decimal.NewFromInt(want.total)) {
+			t.Errorf("Group %d: expected outstanding %d, got %s", i, want.total, group.TotalOutstanding)
+		}
+	}
+}
+
+func TestGetLoansWithStats_ActiveFilter(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.SetActiveWithStats([]*domain.LoanWithStats{
+		{
+			Loan:             domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Active Loan"},
+			TotalCount:       6,
+			PaidCount:        2,
+			RemainingBalance: decimal.NewFromInt(400),
+			Progress:         33.33,
+		},
+	})
+
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterActive, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
 	if len(loans) != 1 {
-		t.Fatalf("Expected 1 loan, got %d", len(loans))
+		t.Errorf("Expected 1 loan with 'active' filter, got %d", len(loans))
 	}
 
-	loan := loans[0]
+	if loans[0].ItemName != "Active Loan" {
+		t.Errorf("Expected 'Active Loan', got '%s'", loans[0].ItemName)
+	}
 
-	// Verify stats reflect transaction is_paid status
-	if loan.TotalCount != 3 {
-		t.Errorf("Expected TotalCount 3, got %d", loan.TotalCount)
+	if !loans[0].RemainingBalance.GreaterThan(decimal.Zero) {
+		t.Errorf("Active loan should have remaining balance > 0")
 	}
-	if loan.PaidCount != 1 {
-		t.Errorf("Expected PaidCount 1 (1 settled transaction), got %d", loan.PaidCount)
+}
+
+func TestGetLoansWithStats_CompletedFilter(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.SetCompletedWithStats([]*domain.LoanWithStats{
+		{
+			Loan:             domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Completed Loan"},
+			TotalCount:       3,
+			PaidCount:        3,
+			RemainingBalance: decimal.Zero,
+			Progress:         100,
+		},
+	})
+
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterCompleted, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	expectedRemaining := decimal.NewFromInt(200)
-	if !loan.RemainingBalance.Equal(expectedRemaining) {
-		t.Errorf("Expected RemainingBalance %s, got %s", expectedRemaining.String(), loan.RemainingBalance.String())
+	if len(loans) != 1 {
+		t.Errorf("Expected 1 loan with 'completed' filter, got %d", len(loans))
+	}
+
+	if loans[0].ItemName != "Completed Loan" {
+		t.Errorf("Expected 'Completed Loan', got '%s'", loans[0].ItemName)
+	}
+
+	if loans[0].Progress != 100 {
+		t.Errorf("Completed loan should have 100%% progress, got %.2f", loans[0].Progress)
+	}
+}
+
+func TestGetLoansWithStats_EmptyResult(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	loans, err := service.GetLoansWithStats(context.Background(), 1, domain.LoanFilterAll, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loans) != 0 {
+		t.Errorf("Expected 0 loans, got %d", len(loans))
+	}
+}
+
+func TestGetLoansWithStats_DefaultsToAll(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{Loan: domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Loan 1"}},
+		{Loan: domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Loan 2"}},
+	})
+
+	// Empty string should default to all
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilter(""), domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loans) != 2 {
+		t.Errorf("Expected 2 loans with empty filter (defaults to all), got %d", len(loans))
 	}
+}
+
+// Domain method tests
+
+func TestLoan_IsActive(t *testing.T) {
+	tests := []struct {
+		name              string
+		firstPaymentYear  int32
+		firstPaymentMonth int32
+		numMonths         int32
+		currentYear       int
+		currentMonth      int
+		expectedActive    bool
+	}{
+		{
+			name:              "Active - current month is first payment month",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         3,
+			currentYear:       2024,
+			currentMonth:      3,
+			expectedActive:    true,
+		},
+		{
+			name:              "Active - in the middle of payments",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         3,
+			currentYear:       2024,
+			currentMonth:      4,
+			expectedActive:    true,
+		},
+		{
+			name:              "Active - last payment month",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         3,
+			currentYear:       2024,
+			currentMonth:      5,
+			expectedActive:    true,
+		},
+		{
+			name:              "Completed - one month after last payment",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         3,
+			currentYear:       2024,
+			currentMonth:      6,
+			expectedActive:    false,
+		},
+		{
+			name:              "Active - crosses year boundary",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 11,
+			numMonths:         4, // Nov, Dec, Jan, Feb
+			currentYear:       2025,
+			currentMonth:      1,
+			expectedActive:    true,
+		},
+		{
+			name:              "Completed - after year boundary",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 11,
+			numMonths:         4, // Nov, Dec, Jan, Feb
+			currentYear:       2025,
+			currentMonth:      3,
+			expectedActive:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &domain.Loan{
+				FirstPaymentYear:  tt.firstPaymentYear,
+				FirstPaymentMonth: tt.firstPaymentMonth,
+				NumMonths:         tt.numMonths,
+			}
+			result := loan.IsActive(tt.currentYear, tt.currentMonth)
+			if result != tt.expectedActive {
+				t.Errorf("Expected IsActive=%v, got %v", tt.expectedActive, result)
+			}
+		})
+	}
+}
+
+// UpdateLoan tests
+
+func TestUpdateLoan_Success(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerID := int32(1)
+
+	// Add provider that the loan references
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          providerID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Provider",
+	})
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ProviderID:  providerID,
+		ItemName:    "Original Name",
+	})
+
+	notes := "Updated notes"
+	input := UpdateLoanInput{
+		ItemName: "Updated Name",
+		Notes:    &notes,
+	}
+
+	loan, err := service.UpdateLoan(workspaceID, 1, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if loan.ItemName != "Updated Name" {
+		t.Errorf("Expected 'Updated Name', got '%s'", loan.ItemName)
+	}
+
+	if loan.Notes == nil || *loan.Notes != "Updated notes" {
+		t.Errorf("Expected notes 'Updated notes', got %v", loan.Notes)
+	}
+}
+
+func TestUpdateLoan_EmptyItemName(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ItemName:    "Original Name",
+	})
+
+	input := UpdateLoanInput{
+		ItemName: "",
+	}
+
+	_, err := service.UpdateLoan(workspaceID, 1, input)
+	if err != domain.ErrLoanItemNameEmpty {
+		t.Errorf("Expected ErrLoanItemNameEmpty, got %v", err)
+	}
+}
+
+func TestUpdateLoan_ItemNameTooLong(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ItemName:    "Original Name",
+	})
+
+	// Create a name that's 201 characters long
+	longName := ""
+	for i := 0; i < 201; i++ {
+		longName += "A"
+	}
+
+	input := UpdateLoanInput{
+		ItemName: longName,
+	}
+
+	_, err := service.UpdateLoan(workspaceID, 1, input)
+	if err != domain.ErrLoanItemNameTooLong {
+		t.Errorf("Expected ErrLoanItemNameTooLong, got %v", err)
+	}
+}
+
+func TestUpdateLoan_LoanNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	input := UpdateLoanInput{
+		ItemName: "New Name",
+	}
+
+	_, err := service.UpdateLoan(1, 999, input)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
+
+func TestUpdateLoan_ClearsNotes(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerID := int32(1)
+	originalNotes := "Original notes"
+
+	// Add provider that the loan references
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          providerID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Provider",
+	})
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ProviderID:  providerID,
+		ItemName:    "Original Name",
+		Notes:       &originalNotes,
+	})
+
+	// Update with nil notes (clear notes)
+	input := UpdateLoanInput{
+		ItemName: "Updated Name",
+		Notes:    nil,
+	}
+
+	loan, err := service.UpdateLoan(workspaceID, 1, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if loan.Notes != nil {
+		t.Errorf("Expected notes to be nil, got %v", loan.Notes)
+	}
+}
+
+func TestUpdateLoan_ProviderChangeRegeneratesSchedule(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Old Provider"})
+	providerRepo.AddProvider(&domain.LoanProvider{
+		ID:          2,
+		WorkspaceID: workspaceID,
+		Name:        "New Provider",
+		MonthlyFee:  decimal.NewFromInt(5),
+		FeeMode:     domain.FeeModeSeparate,
+	})
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(200),
+		NumMonths:         2,
+		MonthlyPayment:    decimal.NewFromInt(100),
+		FirstPaymentYear:  2025,
+		FirstPaymentMonth: 11,
+		AccountID:         1,
+	})
+	for i, txn := range GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 2, 2025, 11, false, nil, nil, decimal.Zero, domain.FeeModeFolded) {
+		txn.ID = int32(i + 1)
+		transactionRepo.AddTransaction(txn)
+	}
+	transactionRepo.NextID = 10
+
+	newProviderID := int32(2)
+	if _, err := service.UpdateLoan(workspaceID, loanID, UpdateLoanInput{ItemName: "Phone", ProviderID: &newProviderID}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The new provider's separate fee adds a fee line to each month
+	transactions, _ := transactionRepo.GetByLoanID(workspaceID, loanID)
+	if len(transactions) != 4 {
+		t.Fatalf("Expected 2 payments and 2 fee lines, got %d transactions", len(transactions))
+	}
+	fees := 0
+	for _, tx := range transactions {
+		if tx.ID < 10 {
+			t.Errorf("Expected the old unpaid transaction %d to be replaced", tx.ID)
+		}
+		if tx.IsLoanFee {
+			fees++
+		}
+		if tx.Name != "New Provider (Phone)" {
+			t.Errorf("Expected payee 'New Provider (Phone)', got '%s'", tx.Name)
+		}
+	}
+	if fees != 2 {
+		t.Errorf("Expected 2 fee lines, got %d", fees)
+	}
+}
+
+// GetDeleteStats tests
+// NOTE: GetDeleteStats is currently stubbed (v2 migration). Tests verify stub behavior.
+
+func TestGetDeleteStats_Success(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:             loanID,
+		WorkspaceID:    workspaceID,
+		ItemName:       "Test Loan",
+		TotalAmount:    decimal.NewFromInt(300),
+		NumMonths:      3,
+		MonthlyPayment: decimal.NewFromInt(100),
+	})
+
+	// Add transactions for the loan: 1 paid, 2 unpaid
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		LoanID:      &loanID,
+		Amount:      decimal.NewFromInt(-100),
+		IsPaid:      true,
+		Name:        "Test Loan Payment 1",
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:          2,
+		WorkspaceID: workspaceID,
+		LoanID:      &loanID,
+		Amount:      decimal.NewFromInt(-100),
+		IsPaid:      false,
+		Name:        "Test Loan Payment 2",
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:          3,
+		WorkspaceID: workspaceID,
+		LoanID:      &loanID,
+		Amount:      decimal.NewFromInt(-100),
+		IsPaid:      false,
+		Name:        "Test Loan Payment 3",
+	})
+
+	loan, stats, err := service.GetDeleteStats(workspaceID, loanID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if loan.ItemName != "Test Loan" {
+		t.Errorf("Expected 'Test Loan', got '%s'", loan.ItemName)
+	}
+
+	// v2: Stats come from transactions table
+	if stats.TotalCount != 3 {
+		t.Errorf("Expected total count 3, got %d", stats.TotalCount)
+	}
+
+	if stats.PaidCount != 1 {
+		t.Errorf("Expected paid count 1, got %d", stats.PaidCount)
+	}
+
+	if stats.UnpaidCount != 2 {
+		t.Errorf("Expected unpaid count 2, got %d", stats.UnpaidCount)
+	}
+
+	expectedTotal := decimal.NewFromInt(300)
+	if !stats.TotalAmount.Equal(expectedTotal) {
+		t.Errorf("Expected total amount %s, got %s", expectedTotal.String(), stats.TotalAmount.String())
+	}
+}
+
+func TestGetDeleteStats_LoanNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	_, _, err := service.GetDeleteStats(1, 999)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
+
+func TestGetDeleteStats_NoPayments(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+
+	loan, stats, err := service.GetDeleteStats(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if loan.ItemName != "Test Loan" {
+		t.Errorf("Expected 'Test Loan', got '%s'", loan.ItemName)
+	}
+
+	if stats.TotalCount != 0 {
+		t.Errorf("Expected total count 0, got %d", stats.TotalCount)
+	}
+
+	if stats.PaidCount != 0 {
+		t.Errorf("Expected paid count 0, got %d", stats.PaidCount)
+	}
+}
+
+func TestLoan_GetLastPaymentYearMonth(t *testing.T) {
+	tests := []struct {
+		name              string
+		firstPaymentYear  int32
+		firstPaymentMonth int32
+		numMonths         int32
+		expectedYear      int
+		expectedMonth     int
+	}{
+		{
+			name:              "Single month",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         1,
+			expectedYear:      2024,
+			expectedMonth:     3,
+		},
+		{
+			name:              "Three months same year",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 3,
+			numMonths:         3,
+			expectedYear:      2024,
+			expectedMonth:     5,
+		},
+		{
+			name:              "Crosses year boundary",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 11,
+			numMonths:         4,
+			expectedYear:      2025,
+			expectedMonth:     2,
+		},
+		{
+			name:              "12 months",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 1,
+			numMonths:         12,
+			expectedYear:      2024,
+			expectedMonth:     12,
+		},
+		{
+			name:              "24 months",
+			firstPaymentYear:  2024,
+			firstPaymentMonth: 1,
+			numMonths:         24,
+			expectedYear:      2025,
+			expectedMonth:     12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &domain.Loan{
+				FirstPaymentYear:  tt.firstPaymentYear,
+				FirstPaymentMonth: tt.firstPaymentMonth,
+				NumMonths:         tt.numMonths,
+			}
+			year, month := loan.GetLastPaymentYearMonth()
+			if year != tt.expectedYear || month != tt.expectedMonth {
+				t.Errorf("Expected %d-%02d, got %d-%02d", tt.expectedYear, tt.expectedMonth, year, month)
+			}
+		})
+	}
+}
+
+// GetTrend tests
+// NOTE: GetTrend stub in v2 returns months with zero totals and empty providers.
+// The stub preserves month count logic (defaults, caps) but returns empty data.
+
+func TestGetTrend_ReturnsZeroTotalMonths(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	result, err := service.GetTrend(1, 6)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Stub returns requested number of months with zero data
+	if len(result.Months) != 6 {
+		t.Errorf("Expected 6 months, got %d", len(result.Months))
+	}
+
+	// All months should have zero totals and no providers
+	for i, month := range result.Months {
+		if !month.Total.Equal(decimal.Zero) {
+			t.Errorf("Month %d: expected total 0, got %s", i, month.Total.String())
+		}
+		if len(month.Providers) != 0 {
+			t.Errorf("Month %d: expected 0 providers, got %d", i, len(month.Providers))
+		}
+	}
+}
+
+func TestGetTrend_DefaultsTo12Months(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	// months = 0 should default to 12
+	result, err := service.GetTrend(1, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Months) != 12 {
+		t.Errorf("Expected 12 months (default), got %d", len(result.Months))
+	}
+}
+
+func TestGetTrend_MaxCapsAt24Months(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	// months > 24 should cap at 24
+	result, err := service.GetTrend(1, 36)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Months) != 24 {
+		t.Errorf("Expected 24 months (max), got %d", len(result.Months))
+	}
+}
+
+func TestGetProviderTrend_OnlyIncludesTargetProvider(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: 1, Name: "Provider A"})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 2, WorkspaceID: 1, Name: "Provider B"})
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	now := time.Now()
+	transactionRepo.GetLoanTrendDataFn = func(workspaceID int32, startYear, startMonth, endYear, endMonth int32) ([]*domain.LoanTrendDataRow, error) {
+		return []*domain.LoanTrendDataRow{
+			{Year: int32(now.Year()), Month: int32(now.Month()), ProviderID: 1, ProviderName: "Provider A", TotalAmount: decimal.NewFromInt(100), AllPaid: true},
+			{Year: int32(now.Year()), Month: int32(now.Month()), ProviderID: 2, ProviderName: "Provider B", TotalAmount: decimal.NewFromInt(250), AllPaid: false},
+		}, nil
+	}
+
+	result, err := service.GetProviderTrend(1, 1, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Months) != 3 {
+		t.Fatalf("Expected 3 months, got %d", len(result.Months))
+	}
+
+	current := result.Months[0]
+	if len(current.Providers) != 1 || current.Providers[0].ID != 1 {
+		t.Fatalf("Expected only provider 1 in current month, got %+v", current.Providers)
+	}
+	if !current.Total.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected total 100, got %s", current.Total.String())
+	}
+	if !current.IsPaid {
+		t.Error("Expected current month to be paid (provider B's unpaid month excluded)")
+	}
+	for _, m := range result.Months[1:] {
+		if len(m.Providers) != 0 {
+			t.Errorf("Month %s: expected no providers, got %d", m.Month, len(m.Providers))
+		}
+	}
+}
+
+func TestGetProviderTrend_ProviderNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	_, err := service.GetProviderTrend(1, 999, 12)
+	if err != domain.ErrLoanProviderNotFound {
+		t.Errorf("Expected ErrLoanProviderNotFound, got %v", err)
+	}
+}
+
+// GetProviderPayoff tests
+
+func TestGetProviderPayoff_SumsActiveLoans(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Test Provider"})
+	completedAt := time.Now()
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{
+			Loan:             domain.Loan{ID: 1, WorkspaceID: workspaceID, ProviderID: 1, ItemName: "Phone"},
+			TotalCount:       6,
+			PaidCount:        2,
+			RemainingBalance: decimal.NewFromInt(400),
+		},
+		{
+			Loan:             domain.Loan{ID: 2, WorkspaceID: workspaceID, ProviderID: 1, ItemName: "Laptop"},
+			TotalCount:       12,
+			PaidCount:        9,
+			RemainingBalance: decimal.NewFromFloat(750.50),
+		},
+		{
+			Loan:             domain.Loan{ID: 3, WorkspaceID: workspaceID, ProviderID: 1, ItemName: "Paid Off", CompletedAt: &completedAt},
+			TotalCount:       3,
+			PaidCount:        3,
+			RemainingBalance: decimal.Zero,
+		},
+		{
+			Loan:             domain.Loan{ID: 4, WorkspaceID: workspaceID, ProviderID: 2, ItemName: "Other Provider"},
+			TotalCount:       3,
+			RemainingBalance: decimal.NewFromInt(900),
+		},
+	})
+
+	payoff, err := service.GetProviderPayoff(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if payoff.ProviderName != "Test Provider" {
+		t.Errorf("Expected provider 'Test Provider', got '%s'", payoff.ProviderName)
+	}
+	if !payoff.TotalPayoff.Equal(decimal.NewFromFloat(1150.50)) {
+		t.Errorf("Expected total payoff 1150.50, got %s", payoff.TotalPayoff)
+	}
+	if len(payoff.Loans) != 2 {
+		t.Fatalf("Expected 2 active loans, got %d", len(payoff.Loans))
+	}
+	for _, loan := range payoff.Loans {
+		if loan.ID == 3 {
+			t.Error("Expected completed loan to be excluded")
+		}
+	}
+}
+
+func TestGetProviderPayoff_ProviderNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
+
+	_, err := service.GetProviderPayoff(1, 999)
+	if err != domain.ErrLoanProviderNotFound {
+		t.Errorf("Expected ErrLoanProviderNotFound, got %v", err)
+	}
+}
+
+// ============================================================================
+// CC Loan Integration Tests (cl-v2-2-3)
+// Tests verifying CC-backed loan transactions integrate with CC settlement workflow
+// ============================================================================
+
+// TestCreateLoan_CCAccount_HasCorrectSettlementIntent verifies that when a loan
+// is created with a CC account, the loan has proper settlement_intent (AC: #1)
+func TestCreateLoan_CCAccount_HasCorrectSettlementIntent(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	// Add CC account
+	accountRepo.AddAccount(&domain.Account{
+		ID:          2,
+		WorkspaceID: 1,
+		Name:        "Test Credit Card",
+		Template:    domain.TemplateCreditCard,
+		AccountType: domain.AccountTypeLiability,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	// Create loan with CC account and immediate intent
+	immediateIntent := "immediate"
+	input := CreateLoanInput{
+		ProviderID:       1,
+		ItemName:         "Test CC Loan",
+		TotalAmount:      decimal.NewFromInt(300),
+		NumMonths:        3,
+		PurchaseDate:     time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:        2, // CC account
+		SettlementIntent: &immediateIntent,
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan has correct settlement intent
+	if loan.SettlementIntent == nil || *loan.SettlementIntent != "immediate" {
+		t.Errorf("Expected loan SettlementIntent 'immediate', got %v", loan.SettlementIntent)
+	}
+
+	// Verify loan has CC account
+	if loan.AccountID != 2 {
+		t.Errorf("Expected AccountID 2, got %d", loan.AccountID)
+	}
+}
+
+// TestCreateLoan_CCAccount_DefaultsToDeferredIntent verifies that CC loans without
+// explicit intent default to "deferred" (AC: #1)
+func TestCreateLoan_CCAccount_DefaultsToDeferredIntent(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	// Add CC account
+	accountRepo.AddAccount(&domain.Account{
+		ID:          2,
+		WorkspaceID: 1,
+		Name:        "Test Credit Card",
+		Template:    domain.TemplateCreditCard,
+		AccountType: domain.AccountTypeLiability,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "SPayLater",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	// Create loan with CC account but NO explicit intent
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test CC Loan",
+		TotalAmount:  decimal.NewFromInt(200),
+		NumMonths:    2,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    2, // CC account
+		// SettlementIntent NOT set - should default to deferred
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan defaults to deferred
+	if loan.SettlementIntent == nil || *loan.SettlementIntent != "deferred" {
+		t.Errorf("Expected loan SettlementIntent 'deferred' (default), got %v", loan.SettlementIntent)
+	}
+
+	// Verify transactions have deferred intent
+	for id, tx := range transactionRepo.Transactions {
+		if tx.SettlementIntent == nil || *tx.SettlementIntent != domain.SettlementIntentDeferred {
+			t.Errorf("Transaction %d: expected SettlementIntent 'deferred', got %v", id, tx.SettlementIntent)
+		}
+	}
+}
+
+// TestCCLoanTransactions_AppearInDeferredSettlementQuery verifies that billed CC loan
+// transactions with deferred intent appear in GetDeferredForSettlement (AC: #4)
+func TestCCLoanTransactions_AppearInDeferredSettlementQuery(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate CC loan transactions that would be created by loan service
+	// These have loan_id set and deferred settlement intent
+	deferredIntent := domain.SettlementIntentDeferred
+	billedState := domain.CCStateBilled
+	now := time.Now()
+
+	// Add two billed deferred CC loan transactions
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               2,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 2",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+
+	// Query deferred transactions for settlement
+	deferredTxs, err := transactionRepo.GetDeferredForSettlement(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan transactions appear in the results (NOT excluded by loan_id)
+	if len(deferredTxs) != 2 {
+		t.Errorf("Expected 2 deferred transactions (including loan txns), got %d", len(deferredTxs))
+	}
+
+	// Verify they have loan_id set
+	for _, tx := range deferredTxs {
+		if tx.LoanID == nil || *tx.LoanID != loanID {
+			t.Errorf("Expected transaction to have LoanID %d, got %v", loanID, tx.LoanID)
+		}
+	}
+}
+
+// TestCCLoanTransactions_AppearInImmediateSettlementQuery verifies that billed CC loan
+// transactions with immediate intent appear in GetImmediateForSettlement (AC: #3)
+func TestCCLoanTransactions_AppearInImmediateSettlementQuery(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate CC loan transactions that would be created by loan service
+	// These have loan_id set and immediate settlement intent
+	immediateIntent := domain.SettlementIntentImmediate
+	billedState := domain.CCStateBilled
+	now := time.Now()
+
+	// Add two billed immediate CC loan transactions
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &immediateIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               2,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 2",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &immediateIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+
+	// Query immediate transactions for settlement - use date range that covers the transactions
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	immediateTxs, err := transactionRepo.GetImmediateForSettlement(workspaceID, startDate, endDate)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan transactions appear in the results (NOT excluded by loan_id)
+	if len(immediateTxs) != 2 {
+		t.Errorf("Expected 2 immediate transactions (including loan txns), got %d", len(immediateTxs))
+	}
+
+	// Verify they have loan_id set
+	for _, tx := range immediateTxs {
+		if tx.LoanID == nil || *tx.LoanID != loanID {
+			t.Errorf("Expected transaction to have LoanID %d, got %v", loanID, tx.LoanID)
+		}
+	}
+}
+
+// TestLoanStats_UpdatesWhenTransactionSettled verifies that loan stats
+// (paidCount, remainingBalance) reflect transaction is_paid status (AC: #6, #8)
+func TestLoanStats_UpdatesWhenTransactionSettled(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Setup: loan with 3 payments, 1 paid (settled), 2 unpaid
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{
+		{
+			Loan: domain.Loan{
+				ID:          loanID,
+				WorkspaceID: workspaceID,
+				ItemName:    "CC Loan",
+			},
+			TotalCount:       3,
+			PaidCount:        1,  // 1 transaction settled (is_paid=true)
+			RemainingBalance: decimal.NewFromInt(200), // 2 * 100
+			Progress:         33.33,
+		},
+	})
+
+	// Get loan stats
+	loans, err := service.GetLoansWithStats(context.Background(), workspaceID, domain.LoanFilterAll, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loans) != 1 {
+		t.Fatalf("Expected 1 loan, got %d", len(loans))
+	}
+
+	loan := loans[0]
+
+	// Verify stats reflect transaction is_paid status
+	if loan.TotalCount != 3 {
+		t.Errorf("Expected TotalCount 3, got %d", loan.TotalCount)
+	}
+	if loan.PaidCount != 1 {
+		t.Errorf("Expected PaidCount 1 (1 settled transaction), got %d", loan.PaidCount)
+	}
+
+	expectedRemaining := decimal.NewFromInt(200)
+	if !loan.RemainingBalance.Equal(expectedRemaining) {
+		t.Errorf("Expected RemainingBalance %s, got %s", expectedRemaining.String(), loan.RemainingBalance.String())
+	}
+
+	// Progress = paid / total * 100
+	if loan.Progress < 33 || loan.Progress > 34 {
+		t.Errorf("Expected Progress ~33.33%%, got %.2f%%", loan.Progress)
+	}
+}
+
+// TestBulkSettle_IncludesLoanTransactions verifies that BulkSettle
+// works with loan transactions (AC: #6)
+func TestBulkSettle_IncludesLoanTransactions(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate billed CC loan transactions (ready for settlement)
+	billedState := domain.CCStateBilled
+	deferredIntent := domain.SettlementIntentDeferred
+	now := time.Now()
+
+	// Add billed loan transaction
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+	// Add billed regular CC transaction
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               2,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Regular CC Purchase",
+		Amount:           decimal.NewFromInt(50),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "manual",
+		LoanID:           nil,
+		SettlementIntent: &deferredIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	})
+
+	// Custom BulkSettleFn that simulates the DB behavior
+	transactionRepo.BulkSettleFn = func(wsID int32, ids []int32) ([]*domain.Transaction, error) {
+		var result []*domain.Transaction
+		settledState := domain.CCStateSettled
+		for _, id := range ids {
+			if tx, ok := transactionRepo.Transactions[id]; ok && tx.WorkspaceID == wsID && tx.BilledAt != nil && !tx.IsPaid {
+				tx.IsPaid = true
+				tx.CCState = &settledState
+				tx.UpdatedAt = time.Now()
+				result = append(result, tx)
+			}
+		}
+		return result, nil
+	}
+
+	// Bulk settle both transactions (including the loan transaction)
+	settledTxs, err := transactionRepo.BulkSettle(workspaceID, []int32{1, 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify both transactions were settled (loan tx NOT excluded)
+	if len(settledTxs) != 2 {
+		t.Errorf("Expected 2 settled transactions, got %d", len(settledTxs))
+	}
+
+	// Verify loan transaction was settled
+	loanTx := transactionRepo.Transactions[1]
+	if !loanTx.IsPaid {
+		t.Error("Expected loan transaction to have IsPaid=true")
+	}
+	if loanTx.CCState == nil || *loanTx.CCState != domain.CCStateSettled {
+		t.Errorf("Expected loan transaction CCState 'settled', got %v", loanTx.CCState)
+	}
+
+	// Verify loan_id is preserved
+	if loanTx.LoanID == nil || *loanTx.LoanID != loanID {
+		t.Errorf("Expected loan transaction to retain LoanID %d, got %v", loanID, loanTx.LoanID)
+	}
+}
+
+// TestBatchBilling_IncludesLoanTransactions verifies that BatchToggleToBilled
+// works with loan transactions (AC: #5)
+func TestBatchBilling_IncludesLoanTransactions(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate pending CC loan transactions
+	pendingState := domain.CCStatePending
+	deferredIntent := domain.SettlementIntentDeferred
+
+	// Add pending loan transactions
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &pendingState,
+		BilledAt:         nil,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               2,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Regular CC Purchase",
+		Amount:           decimal.NewFromInt(50),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "manual",
+		LoanID:           nil, // Not a loan transaction
+		SettlementIntent: &deferredIntent,
+		CCState:          &pendingState,
+		BilledAt:         nil,
+	})
+
+	// Custom BatchToggleToBilledFn that simulates the DB behavior
+	transactionRepo.BatchToggleToBilledFn = func(wsID int32, ids []int32) ([]*domain.Transaction, error) {
+		var result []*domain.Transaction
+		now := time.Now()
+		billedState := domain.CCStateBilled
+		for _, id := range ids {
+			if tx, ok := transactionRepo.Transactions[id]; ok && tx.WorkspaceID == wsID {
+				tx.BilledAt = &now
+				tx.CCState = &billedState
+				tx.UpdatedAt = now
+				result = append(result, tx)
+			}
+		}
+		return result, nil
+	}
+
+	// Batch bill both transactions (including the loan transaction)
+	billedTxs, err := transactionRepo.BatchToggleToBilled(workspaceID, []int32{1, 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify both transactions were billed (loan tx NOT excluded)
+	if len(billedTxs) != 2 {
+		t.Errorf("Expected 2 billed transactions, got %d", len(billedTxs))
+	}
+
+	// Verify loan transaction was billed
+	loanTx := transactionRepo.Transactions[1]
+	if loanTx.BilledAt == nil {
+		t.Error("Expected loan transaction to have BilledAt set")
+	}
+	if loanTx.CCState == nil || *loanTx.CCState != domain.CCStateBilled {
+		t.Errorf("Expected loan transaction CCState 'billed', got %v", loanTx.CCState)
+	}
+
+	// Verify loan_id is preserved
+	if loanTx.LoanID == nil || *loanTx.LoanID != loanID {
+		t.Errorf("Expected loan transaction to retain LoanID %d, got %v", loanID, loanTx.LoanID)
+	}
+}
+
+// TestCCMetrics_IncludeLoanTransactions verifies that GetCCMetrics includes
+// loan transactions in its calculations (AC: #3, #4)
+func TestCCMetrics_IncludeLoanTransactions(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate CC loan transactions with different states
+	pendingState := domain.CCStatePending
+	immediateIntent := domain.SettlementIntentImmediate
+
+	// Add a pending loan transaction
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Loan Payment - Pending",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &immediateIntent,
+		CCState:          &pendingState,
+		BilledAt:         nil,
+	})
+
+	// Custom GetCCMetricsFn that calculates based on transactions in mock
+	transactionRepo.GetCCMetricsFn = func(wsID int32, start, end time.Time) (*domain.CCMetrics, error) {
+		var pending, outstanding, purchases decimal.Decimal
+		for _, tx := range transactionRepo.Transactions {
+			if tx.WorkspaceID != wsID || tx.Type != domain.TransactionTypeExpense {
+				continue
+			}
+			// Purchases: all CC expenses
+			purchases = purchases.Add(tx.Amount)
+			// Pending: billed_at IS NULL AND is_paid = false
+			if tx.BilledAt == nil && !tx.IsPaid {
+				pending = pending.Add(tx.Amount)
+			}
+			// Outstanding: billed AND is_paid = false
+			if tx.BilledAt != nil && !tx.IsPaid {
+				outstanding = outstanding.Add(tx.Amount)
+			}
+		}
+		return &domain.CCMetrics{
+			Pending:     pending,
+			Outstanding: outstanding,
+			Purchases:   purchases,
+		}, nil
+	}
+
+	// Get CC metrics - should include the loan transaction
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	metrics, err := transactionRepo.GetCCMetrics(workspaceID, startDate, endDate)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan transaction is included in pending (not excluded by loan_id)
+	expectedPending := decimal.NewFromInt(100)
+	if !metrics.Pending.Equal(expectedPending) {
+		t.Errorf("Expected pending %s, got %s - loan transaction may be excluded", expectedPending.String(), metrics.Pending.String())
+	}
+
+	// Verify loan transaction is included in purchases
+	expectedPurchases := decimal.NewFromInt(100)
+	if !metrics.Purchases.Equal(expectedPurchases) {
+		t.Errorf("Expected purchases %s, got %s - loan transaction may be excluded", expectedPurchases.String(), metrics.Purchases.String())
+	}
+}
+
+// TestCCLoanFullLifecycle_DeferredIntent tests the complete lifecycle of a CC loan
+// with deferred intent: create → pending → billed → settled (AC: #1-8)
+func TestCCLoanFullLifecycle_DeferredIntent(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate CC loan transactions created by loan service
+	deferredIntent := domain.SettlementIntentDeferred
+	pendingState := domain.CCStatePending
+
+	// STEP 1: Loan created - transactions are PENDING
+	tx1 := &domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "CC Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &pendingState,
+		BilledAt:         nil,
+	}
+	tx2 := &domain.Transaction{
+		ID:               2,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "CC Loan Payment 2",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &deferredIntent,
+		CCState:          &pendingState,
+		BilledAt:         nil,
+	}
+	transactionRepo.AddTransaction(tx1)
+	transactionRepo.AddTransaction(tx2)
+
+	// Verify: Pending state
+	if tx1.CCState == nil || *tx1.CCState != domain.CCStatePending {
+		t.Error("Step 1 failed: Expected pending state after loan creation")
+	}
+
+	// Verify: Appears in GetPendingDeferredCC (not in settlement yet)
+	pendingTxs, _ := transactionRepo.GetPendingDeferredCC(workspaceID, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	if len(pendingTxs) != 2 {
+		t.Errorf("Step 1 failed: Expected 2 pending deferred txns, got %d", len(pendingTxs))
+	}
+
+	// STEP 2: Batch bill - transactions become BILLED
+	billedState := domain.CCStateBilled
+	now := time.Now()
+	for _, tx := range transactionRepo.Transactions {
+		tx.CCState = &billedState
+		tx.BilledAt = &now
+	}
+
+	// Verify: Billed state
+	if tx1.CCState == nil || *tx1.CCState != domain.CCStateBilled {
+		t.Error("Step 2 failed: Expected billed state after billing")
+	}
+
+	// Verify: Appears in GetDeferredForSettlement
+	deferredTxs, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
+	if len(deferredTxs) != 2 {
+		t.Errorf("Step 2 failed: Expected 2 deferred-to-settle txns, got %d", len(deferredTxs))
+	}
+	// Verify loan transactions included (not filtered by loan_id)
+	for _, tx := range deferredTxs {
+		if tx.LoanID == nil {
+			t.Error("Step 2 failed: Expected loan transaction to have LoanID set")
+		}
+	}
+
+	// STEP 3: Settle - transactions become SETTLED (is_paid = true)
+	settledState := domain.CCStateSettled
+	for _, tx := range transactionRepo.Transactions {
+		tx.CCState = &settledState
+		tx.IsPaid = true
+	}
+
+	// Verify: Settled state
+	if tx1.CCState == nil || *tx1.CCState != domain.CCStateSettled {
+		t.Error("Step 3 failed: Expected settled state after settlement")
+	}
+	if !tx1.IsPaid {
+		t.Error("Step 3 failed: Expected is_paid=true after settlement")
+	}
+
+	// Verify: No longer appears in settlement queries (is_paid=true)
+	deferredAfterSettle, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
+	if len(deferredAfterSettle) != 0 {
+		t.Errorf("Step 3 failed: Expected 0 deferred txns after settle, got %d", len(deferredAfterSettle))
+	}
+}
+
+// TestCCLoanFullLifecycle_ImmediateIntent tests CC loan with immediate intent
+// (billed immediately for current month settlement) (AC: #3)
+func TestCCLoanFullLifecycle_ImmediateIntent(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Simulate CC loan transactions with immediate intent
+	immediateIntent := domain.SettlementIntentImmediate
+	billedState := domain.CCStateBilled
+	now := time.Now()
+
+	// Create transactions already billed (immediate = bill same month)
+	tx1 := &domain.Transaction{
+		ID:               1,
+		WorkspaceID:      workspaceID,
+		AccountID:        2,
+		Name:             "Immediate CC Loan Payment 1",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		TransactionDate:  time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:           false,
+		Source:           "loan",
+		LoanID:           &loanID,
+		SettlementIntent: &immediateIntent,
+		CCState:          &billedState,
+		BilledAt:         &now,
+	}
+	transactionRepo.AddTransaction(tx1)
+
+	// Verify: Appears in GetImmediateForSettlement
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	immediateTxs, _ := transactionRepo.GetImmediateForSettlement(workspaceID, startDate, endDate)
+	if len(immediateTxs) != 1 {
+		t.Errorf("Expected 1 immediate-to-settle txn, got %d", len(immediateTxs))
+	}
+	if immediateTxs[0].LoanID == nil {
+		t.Error("Expected loan transaction to have LoanID set")
+	}
+
+	// Verify: Does NOT appear in GetDeferredForSettlement
+	deferredTxs, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
+	if len(deferredTxs) != 0 {
+		t.Errorf("Expected 0 deferred txns for immediate loan, got %d", len(deferredTxs))
+	}
+}
+
+// TestBankLoan_NoSettlementIntent verifies that bank-backed loans don't have
+// settlement_intent set on transactions (AC: #1 - negative case)
+func TestBankLoan_NoSettlementIntent(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	// Add Bank account
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Test Bank",
+		Template:    domain.TemplateBank,
+		AccountType: domain.AccountTypeAsset,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "BankLoan",
+		CutoffDay:           15,
+		DefaultInterestRate: decimal.Zero,
+	})
+
+	// Create loan with Bank account (should NOT have settlement intent)
+	input := CreateLoanInput{
+		ProviderID:   1,
+		ItemName:     "Test Bank Loan",
+		TotalAmount:  decimal.NewFromInt(300),
+		NumMonths:    3,
+		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		AccountID:    1, // Bank account
+	}
+
+	loan, err := service.CreateLoan(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify loan has NO settlement intent
+	if loan.SettlementIntent != nil {
+		t.Errorf("Expected loan SettlementIntent nil for bank account, got %v", loan.SettlementIntent)
+	}
+
+	// Verify transactions have NO settlement intent
+	for id, tx := range transactionRepo.Transactions {
+		if tx.SettlementIntent != nil {
+			t.Errorf("Transaction %d: expected SettlementIntent nil for bank account, got %v", id, tx.SettlementIntent)
+		}
+	}
+}
+
+// ============================================================================
+// PayLoanMonth tests (cl-v2-3-1)
+// Tests for paying a loan month by settling transactions
+// ============================================================================
+
+// TestPayLoanMonth_Success verifies basic payment flow for bank account loans
+func TestPayLoanMonth_Success(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	// Add Bank account
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Test Bank",
+		Template:    domain.TemplateBank,
+		AccountType: domain.AccountTypeAsset,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Setup loan
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+
+	// Add unpaid transactions for March 2024
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan Payment 1",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          false,
+		LoanID:          &loanID,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              2,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan Payment 2",
+		Amount:          decimal.NewFromInt(50),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		IsPaid:          false,
+		LoanID:          &loanID,
+	})
+
+	// Pay March 2024
+	input := PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	}
+
+	result, err := service.PayLoanMonth(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify result
+	if len(result.SettledTransactions) != 2 {
+		t.Errorf("Expected 2 settled transactions, got %d", len(result.SettledTransactions))
+	}
+
+	expectedTotal := decimal.NewFromInt(150)
+	if !result.TotalAmount.Equal(expectedTotal) {
+		t.Errorf("Expected total %s, got %s", expectedTotal.String(), result.TotalAmount.String())
+	}
+
+	// Verify transactions are marked as paid
+	for _, tx := range result.SettledTransactions {
+		if !tx.IsPaid {
+			t.Errorf("Transaction %d should be marked as paid", tx.ID)
+		}
+	}
+}
+
+// TestPayLoanMonth_NoTransactionsToSettle verifies error when no unpaid transactions
+func TestPayLoanMonth_NoTransactionsToSettle(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	// Setup loan
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+
+	// No transactions added - or add already paid ones
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Already Paid",
+		Amount:          decimal.NewFromInt(100),
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true, // Already paid
+		LoanID:          &loanID,
+	})
+
+	input := PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	}
+
+	_, err := service.PayLoanMonth(workspaceID, input)
+	if err != domain.ErrNoTransactionsToSettle {
+		t.Errorf("Expected ErrNoTransactionsToSettle, got %v", err)
+	}
+}
+
+// TestPayLoanMonth_LoanNotFound verifies error when loan doesn't exist
+func TestPayLoanMonth_LoanNotFound(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	input := PayLoanMonthInput{
+		LoanID: 999, // Non-existent
+		Year:   2024,
+		Month:  3,
+	}
+
+	_, err := service.PayLoanMonth(1, input)
+	if err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	}
+}
+
+// TestPayLoanMonth_CustomPaidDate verifies the supplied paid date is recorded on settled transactions
+func TestPayLoanMonth_CustomPaidDate(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan Payment 1",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		LoanID:          &loanID,
+	})
+
+	paidDate := time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)
+	result, err := service.PayLoanMonth(workspaceID, PayLoanMonthInput{
+		LoanID:   loanID,
+		Year:     2024,
+		Month:    3,
+		PaidDate: &paidDate,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.SettledTransactions) != 1 {
+		t.Fatalf("Expected 1 settled transaction, got %d", len(result.SettledTransactions))
+	}
+	tx := result.SettledTransactions[0]
+	if tx.PaidAt == nil || !tx.PaidAt.Equal(paidDate) {
+		t.Errorf("Expected PaidAt %v, got %v", paidDate, tx.PaidAt)
+	}
+}
+
+// TestPayLoanMonth_DefaultsPaidDateToNow verifies PaidAt is set to the current time when omitted
+func TestPayLoanMonth_DefaultsPaidDateToNow(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan Payment 1",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		LoanID:          &loanID,
+	})
+
+	before := time.Now()
+	result, err := service.PayLoanMonth(workspaceID, PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tx := result.SettledTransactions[0]
+	if tx.PaidAt == nil || tx.PaidAt.Before(before) {
+		t.Errorf("Expected PaidAt to default to now, got %v", tx.PaidAt)
+	}
+}
+
+// TestPayLoanMonth_FuturePaidDateRejected verifies a paid date after today is rejected
+func TestPayLoanMonth_FuturePaidDateRejected(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan Payment 1",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		LoanID:          &loanID,
+	})
+
+	paidDate := time.Now().AddDate(0, 0, 2)
+	_, err := service.PayLoanMonth(workspaceID, PayLoanMonthInput{
+		LoanID:   loanID,
+		Year:     2024,
+		Month:    3,
+		PaidDate: &paidDate,
+	})
+	if err != domain.ErrLoanPaidDateInFuture {
+		t.Errorf("Expected ErrLoanPaidDateInFuture, got %v", err)
+	}
+
+	// Transaction must remain unpaid
+	txs, _ := transactionRepo.GetByLoanID(workspaceID, loanID)
+	if len(txs) != 1 || txs[0].IsPaid {
+		t.Error("Expected transaction to remain unpaid after rejected payment")
+	}
+}
+
+// TestPayLoanMonth_CCLoan_MarksIsPaidTrue verifies CC transactions get is_paid=true
+func TestPayLoanMonth_CCLoan_MarksIsPaidTrue(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	// Add CC account
+	accountRepo.AddAccount(&domain.Account{
+		ID:          2,
+		WorkspaceID: 1,
+		Name:        "Test CC",
+		Template:    domain.TemplateCreditCard,
+		AccountType: domain.AccountTypeLiability,
+	})
 
-	// Progress = paid / total * 100
-	if loan.Progress < 33 || loan.Progress > 34 {
-		t.Errorf("Expected Progress ~33.33%%, got %.2f%%", loan.Progress)
-	}
-}
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
 
-// TestBulkSettle_IncludesLoanTransactions verifies that BulkSettle
-// works with loan transactions (AC: #6)
-func TestBulkSettle_IncludesLoanTransactions(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
 	workspaceID := int32(1)
 	loanID := int32(1)
+	deferredIntent := domain.SettlementIntentDeferred
 
-	// Simulate billed CC loan transactions (ready for settlement)
+	// Setup loan with CC settlement intent
+	settlementIntent := "deferred"
+	loanRepo.AddLoan(&domain.Loan{
+		ID:               loanID,
+		WorkspaceID:      workspaceID,
+		ItemName:         "CC Loan",
+		AccountID:        2,
+		SettlementIntent: &settlementIntent,
+	})
+
+	// Add CC loan transaction (billed)
 	billedState := domain.CCStateBilled
-	deferredIntent := domain.SettlementIntentDeferred
 	now := time.Now()
-
-	// Add billed loan transaction
 	transactionRepo.AddTransaction(&domain.Transaction{
 		ID:               1,
 		WorkspaceID:      workspaceID,
 		AccountID:        2,
-		Name:             "Loan Payment 1",
+		Name:             "CC Loan Payment",
 		Amount:           decimal.NewFromInt(100),
 		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		TransactionDate:  time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
 		IsPaid:           false,
-		Source:           "loan",
 		LoanID:           &loanID,
 		SettlementIntent: &deferredIntent,
 		CCState:          &billedState,
 		BilledAt:         &now,
 	})
-	// Add billed regular CC transaction
+
+	input := PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	}
+
+	result, err := service.PayLoanMonth(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify CC transaction is marked as paid
+	if len(result.SettledTransactions) != 1 {
+		t.Fatalf("Expected 1 settled transaction, got %d", len(result.SettledTransactions))
+	}
+
+	tx := result.SettledTransactions[0]
+	if !tx.IsPaid {
+		t.Error("Expected CC transaction to have IsPaid=true")
+	}
+}
+
+// TestPayLoanMonth_MultipleTransactionsInMonth verifies all transactions for month are settled
+func TestPayLoanMonth_MultipleTransactionsInMonth(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Test Bank",
+		Template:    domain.TemplateBank,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+
+	// Add 3 transactions for same month
+	for i := 1; i <= 3; i++ {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Loan Payment",
+			Amount:          decimal.NewFromInt(int64(i * 100)),
+			TransactionDate: time.Date(2024, 3, i*5, 0, 0, 0, 0, time.UTC),
+			IsPaid:          false,
+			LoanID:          &loanID,
+		})
+	}
+
+	input := PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	}
+
+	result, err := service.PayLoanMonth(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify all 3 transactions settled
+	if len(result.SettledTransactions) != 3 {
+		t.Errorf("Expected 3 settled transactions, got %d", len(result.SettledTransactions))
+	}
+
+	// Verify total: 100 + 200 + 300 = 600
+	expectedTotal := decimal.NewFromInt(600)
+	if !result.TotalAmount.Equal(expectedTotal) {
+		t.Errorf("Expected total %s, got %s", expectedTotal.String(), result.TotalAmount.String())
+	}
+}
+
+// TestPayLoanMonth_OnlyPaysTargetMonth verifies only target month transactions are paid
+func TestPayLoanMonth_OnlyPaysTargetMonth(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Test Bank",
+		Template:    domain.TemplateBank,
+	})
+
+	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+
+	workspaceID := int32(1)
+	loanID := int32(1)
+
+	loanRepo.AddLoan(&domain.Loan{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+		ItemName:    "Test Loan",
+	})
+
+	// Add transactions for March and April
 	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               2,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Regular CC Purchase",
-		Amount:           decimal.NewFromInt(50),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "manual",
-		LoanID:           nil,
-		SettlementIntent: &deferredIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "March Payment",
+		Amount:          decimal.NewFromInt(100),
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          false,
+		LoanID:          &loanID,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              2,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "April Payment",
+		Amount:          decimal.NewFromInt(100),
+		TransactionDate: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          false,
+		LoanID:          &loanID,
 	})
 
-	// Custom BulkSettleFn that simulates the DB behavior
-	transactionRepo.BulkSettleFn = func(wsID int32, ids []int32) ([]*domain.Transaction, error) {
-		var result []*domain.Transaction
-		settledState := domain.CCStateSettled
-		for _, id := range ids {
-			if tx, ok := transactionRepo.Transactions[id]; ok && tx.WorkspaceID == wsID && tx.BilledAt != nil && !tx.IsPaid {
-				tx.IsPaid = true
-				tx.CCState = &settledState
-				tx.UpdatedAt = time.Now()
-				result = append(result, tx)
-			}
-		}
-		return result, nil
+	// Pay only March
+	input := PayLoanMonthInput{
+		LoanID: loanID,
+		Year:   2024,
+		Month:  3,
+	}
+
+	result, err := service.PayLoanMonth(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Verify only March transaction settled
+	if len(result.SettledTransactions) != 1 {
+		t.Errorf("Expected 1 settled transaction, got %d", len(result.SettledTransactions))
+	}
+
+	// Verify April transaction is still unpaid
+	aprilTx := transactionRepo.Transactions[2]
+	if aprilTx.IsPaid {
+		t.Error("April transaction should still be unpaid")
+	}
+}
+
+// createCommitmentsTestService sets up a loan with two transactions in March 2024
+func createCommitmentsTestService(firstPaid, secondPaid bool) *LoanService {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       1,
+		ItemName:          "Phone",
+		NumMonths:         6,
+		FirstPaymentYear:  2024,
+		FirstPaymentMonth: 2,
+	})
+
+	for i, paid := range []bool{firstPaid, secondPaid} {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            "Phone",
+			Amount:          decimal.NewFromInt(50),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2024, 3, i+1, 0, 0, 0, 0, time.UTC),
+			IsPaid:          paid,
+			LoanID:          &loanID,
+		})
+	}
+
+	return svc
+}
+
+func TestGetMonthlyCommitments_FullyPaid(t *testing.T) {
+	svc := createCommitmentsTestService(true, true)
+
+	result, err := svc.GetMonthlyCommitments(1, 2024, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Status != domain.PaymentStatusPaid {
+		t.Errorf("Expected month status paid, got %s", result.Status)
+	}
+	if len(result.Payments) != 1 {
+		t.Fatalf("Expected 1 payment, got %d", len(result.Payments))
+	}
+	payment := result.Payments[0]
+	if payment.Status != domain.PaymentStatusPaid || !payment.Paid {
+		t.Errorf("Expected payment status paid, got %s (paid=%v)", payment.Status, payment.Paid)
+	}
+	if payment.PaymentNumber != 2 || payment.TotalPayments != 6 {
+		t.Errorf("Expected payment 2/6, got %d/%d", payment.PaymentNumber, payment.TotalPayments)
+	}
+	if !result.TotalPaid.Equal(decimal.NewFromInt(100)) || !result.TotalUnpaid.IsZero() {
+		t.Errorf("Expected totals paid=100 unpaid=0, got paid=%s unpaid=%s", result.TotalPaid, result.TotalUnpaid)
+	}
+}
+
+func TestGetMonthlyCommitments_FullyUnpaid(t *testing.T) {
+	svc := createCommitmentsTestService(false, false)
+
+	result, err := svc.GetMonthlyCommitments(1, 2024, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Status != domain.PaymentStatusUnpaid {
+		t.Errorf("Expected month status unpaid, got %s", result.Status)
+	}
+	if len(result.Payments) != 1 || result.Payments[0].Status != domain.PaymentStatusUnpaid {
+		t.Errorf("Expected single unpaid payment, got %+v", result.Payments)
+	}
+	if !result.TotalUnpaid.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected total unpaid 100, got %s", result.TotalUnpaid)
+	}
+}
+
+func TestGetMonthlyCommitments_NothingDue(t *testing.T) {
+	svc := createCommitmentsTestService(true, true)
+
+	result, err := svc.GetMonthlyCommitments(1, 2024, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Bulk settle both transactions (including the loan transaction)
-	settledTxs, err := transactionRepo.BulkSettle(workspaceID, []int32{1, 2})
+	if result.Status != domain.PaymentStatusNone {
+		t.Errorf("Expected month status none, got %s", result.Status)
+	}
+	if len(result.Payments) != 0 {
+		t.Errorf("Expected no payments, got %+v", result.Payments)
+	}
+}
+
+func TestGetMonthlyCommitments_PartiallyPaid(t *testing.T) {
+	svc := createCommitmentsTestService(true, false)
+
+	result, err := svc.GetMonthlyCommitments(1, 2024, 3)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify both transactions were settled (loan tx NOT excluded)
-	if len(settledTxs) != 2 {
-		t.Errorf("Expected 2 settled transactions, got %d", len(settledTxs))
+	if result.Status != domain.PaymentStatusPartial {
+		t.Errorf("Expected month status partial, got %s", result.Status)
 	}
-
-	// Verify loan transaction was settled
-	loanTx := transactionRepo.Transactions[1]
-	if !loanTx.IsPaid {
-		t.Error("Expected loan transaction to have IsPaid=true")
+	if len(result.Payments) != 1 {
+		t.Fatalf("Expected 1 payment, got %d", len(result.Payments))
 	}
-	if loanTx.CCState == nil || *loanTx.CCState != domain.CCStateSettled {
-		t.Errorf("Expected loan transaction CCState 'settled', got %v", loanTx.CCState)
+	if result.Payments[0].Status != domain.PaymentStatusPartial || result.Payments[0].Paid {
+		t.Errorf("Expected partial unpaid payment, got %s (paid=%v)", result.Payments[0].Status, result.Payments[0].Paid)
 	}
-
-	// Verify loan_id is preserved
-	if loanTx.LoanID == nil || *loanTx.LoanID != loanID {
-		t.Errorf("Expected loan transaction to retain LoanID %d, got %v", loanID, loanTx.LoanID)
+	if !result.TotalPaid.Equal(decimal.NewFromInt(50)) || !result.TotalUnpaid.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("Expected totals paid=50 unpaid=50, got paid=%s unpaid=%s", result.TotalPaid, result.TotalUnpaid)
 	}
 }
 
-// TestBatchBilling_IncludesLoanTransactions verifies that BatchToggleToBilled
-// works with loan transactions (AC: #5)
-func TestBatchBilling_IncludesLoanTransactions(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
-	workspaceID := int32(1)
-	loanID := int32(1)
-
-	// Simulate pending CC loan transactions
-	pendingState := domain.CCStatePending
-	deferredIntent := domain.SettlementIntentDeferred
-
-	// Add pending loan transactions
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment 1",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &pendingState,
-		BilledAt:         nil,
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               2,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Regular CC Purchase",
-		Amount:           decimal.NewFromInt(50),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "manual",
-		LoanID:           nil, // Not a loan transaction
-		SettlementIntent: &deferredIntent,
-		CCState:          &pendingState,
-		BilledAt:         nil,
-	})
-
-	// Custom BatchToggleToBilledFn that simulates the DB behavior
-	transactionRepo.BatchToggleToBilledFn = func(wsID int32, ids []int32) ([]*domain.Transaction, error) {
-		var result []*domain.Transaction
-		now := time.Now()
-		billedState := domain.CCStateBilled
-		for _, id := range ids {
-			if tx, ok := transactionRepo.Transactions[id]; ok && tx.WorkspaceID == wsID {
-				tx.BilledAt = &now
-				tx.CCState = &billedState
-				tx.UpdatedAt = now
-				result = append(result, tx)
-			}
-		}
-		return result, nil
+func TestGetMonthlyCommitments_ProviderBreakdown(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	svc, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	// Provider names come joined onto the workspace loan listing
+	phoneID, laptopID, chairID := int32(1), int32(2), int32(3)
+	loanRepo.AddLoan(&domain.Loan{ID: phoneID, WorkspaceID: 1, ProviderID: 1, ProviderName: "SPayLater", ItemName: "Phone", NumMonths: 6, FirstPaymentYear: 2024, FirstPaymentMonth: 3})
+	loanRepo.AddLoan(&domain.Loan{ID: laptopID, WorkspaceID: 1, ProviderID: 1, ProviderName: "SPayLater", ItemName: "Laptop", NumMonths: 12, FirstPaymentYear: 2024, FirstPaymentMonth: 1})
+	loanRepo.AddLoan(&domain.Loan{ID: chairID, WorkspaceID: 1, ProviderID: 2, ProviderName: "Atome", ItemName: "Chair", NumMonths: 3, FirstPaymentYear: 2024, FirstPaymentMonth: 3})
+
+	for i, tx := range []struct {
+		loanID *int32
+		amount int64
+		paid   bool
+	}{
+		{&phoneID, 120, false},
+		{&laptopID, 80, false},
+		{&chairID, 30, true},
+		{&chairID, 45, false},
+	} {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            "Loan payment",
+			Amount:          decimal.NewFromInt(tx.amount),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2024, 3, i+1, 0, 0, 0, 0, time.UTC),
+			IsPaid:          tx.paid,
+			LoanID:          tx.loanID,
+		})
 	}
 
-	// Batch bill both transactions (including the loan transaction)
-	billedTxs, err := transactionRepo.BatchToggleToBilled(workspaceID, []int32{1, 2})
+	result, err := svc.GetMonthlyCommitments(1, 2024, 3)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify both transactions were billed (loan tx NOT excluded)
-	if len(billedTxs) != 2 {
-		t.Errorf("Expected 2 billed transactions, got %d", len(billedTxs))
+	if len(result.Providers) != 2 {
+		t.Fatalf("Expected 2 providers, got %d", len(result.Providers))
+	}
+	byID := make(map[int32]*domain.ProviderCommitment)
+	for _, p := range result.Providers {
+		byID[p.ProviderID] = p
 	}
 
-	// Verify loan transaction was billed
-	loanTx := transactionRepo.Transactions[1]
-	if loanTx.BilledAt == nil {
-		t.Error("Expected loan transaction to have BilledAt set")
+	spay := byID[1]
+	if spay == nil || spay.ProviderName != "SPayLater" {
+		t.Fatalf("Expected SPayLater breakdown, got %+v", spay)
 	}
-	if loanTx.CCState == nil || *loanTx.CCState != domain.CCStateBilled {
-		t.Errorf("Expected loan transaction CCState 'billed', got %v", loanTx.CCState)
+	if !spay.TotalUnpaid.Equal(decimal.NewFromInt(200)) || !spay.TotalPaid.IsZero() {
+		t.Errorf("Expected SPayLater unpaid=200 paid=0, got unpaid=%s paid=%s", spay.TotalUnpaid, spay.TotalPaid)
 	}
 
-	// Verify loan_id is preserved
-	if loanTx.LoanID == nil || *loanTx.LoanID != loanID {
-		t.Errorf("Expected loan transaction to retain LoanID %d, got %v", loanID, loanTx.LoanID)
+	atome := byID[2]
+	if atome == nil || atome.ProviderName != "Atome" {
+		t.Fatalf("Expected Atome breakdown, got %+v", atome)
+	}
+	if !atome.TotalUnpaid.Equal(decimal.NewFromInt(45)) || !atome.TotalPaid.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("Expected Atome unpaid=45 paid=30, got unpaid=%s paid=%s", atome.TotalUnpaid, atome.TotalPaid)
 	}
 }
 
-// TestCCMetrics_IncludeLoanTransactions verifies that GetCCMetrics includes
-// loan transactions in its calculations (AC: #3, #4)
-func TestCCMetrics_IncludeLoanTransactions(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
+// createEarlyPayoffTestService sets up a 3-month loan scheduled well in the future,
+// with one unpaid transaction per scheduled month
+func createEarlyPayoffTestService() (*LoanService, *testutil.MockLoanRepository, *testutil.MockTransactionRepository) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
 	workspaceID := int32(1)
 	loanID := int32(1)
+	loan := &domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         3,
+		FirstPaymentYear:  2099,
+		FirstPaymentMonth: 1,
+		AccountID:         1,
+	}
+	loanRepo.AddLoan(loan)
+	loanRepo.SetLoansWithStats([]*domain.LoanWithStats{{
+		Loan:             *loan,
+		TotalCount:       3,
+		RemainingBalance: decimal.NewFromInt(300),
+	}})
+
+	for month := 1; month <= 3; month++ {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(month),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Phone installment",
+			Amount:          decimal.NewFromInt(100),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2099, time.Month(month), 1, 0, 0, 0, 0, time.UTC),
+			LoanID:          &loanID,
+		})
+	}
 
-	// Simulate CC loan transactions with different states
-	pendingState := domain.CCStatePending
-	immediateIntent := domain.SettlementIntentImmediate
+	return service, loanRepo, transactionRepo
+}
 
-	// Add a pending loan transaction
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Loan Payment - Pending",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &immediateIntent,
-		CCState:          &pendingState,
-		BilledAt:         nil,
-	})
+func TestPayLoanMonth_EarlyPayoffMarksCompleted(t *testing.T) {
+	service, loanRepo, _ := createEarlyPayoffTestService()
 
-	// Custom GetCCMetricsFn that calculates based on transactions in mock
-	transactionRepo.GetCCMetricsFn = func(wsID int32, start, end time.Time) (*domain.CCMetrics, error) {
-		var pending, outstanding, purchases decimal.Decimal
-		for _, tx := range transactionRepo.Transactions {
-			if tx.WorkspaceID != wsID || tx.Type != domain.TransactionTypeExpense {
-				continue
-			}
-			// Purchases: all CC expenses
-			purchases = purchases.Add(tx.Amount)
-			// Pending: billed_at IS NULL AND is_paid = false
-			if tx.BilledAt == nil && !tx.IsPaid {
-				pending = pending.Add(tx.Amount)
-			}
-			// Outstanding: billed AND is_paid = false
-			if tx.BilledAt != nil && !tx.IsPaid {
-				outstanding = outstanding.Add(tx.Amount)
-			}
+	// Pay every month ahead of schedule
+	for month := 1; month <= 3; month++ {
+		if _, err := service.PayLoanMonth(1, PayLoanMonthInput{LoanID: 1, Year: 2099, Month: month}); err != nil {
+			t.Fatalf("Expected no error paying month %d, got %v", month, err)
 		}
-		return &domain.CCMetrics{
-			Pending:     pending,
-			Outstanding: outstanding,
-			Purchases:   purchases,
-		}, nil
 	}
 
-	// Get CC metrics - should include the loan transaction
-	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
-	metrics, err := transactionRepo.GetCCMetrics(workspaceID, startDate, endDate)
+	loan, _ := loanRepo.GetByID(1, 1)
+	if loan.CompletedAt == nil {
+		t.Fatal("Expected CompletedAt to be set after all payments settled")
+	}
+	now := time.Now()
+	if loan.IsActive(now.Year(), int(now.Month())) {
+		t.Error("Expected completed loan to be inactive despite remaining scheduled months")
+	}
+
+	completed, err := service.GetLoansWithStats(context.Background(), 1, domain.LoanFilterCompleted, domain.ListOptions{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	// Verify loan transaction is included in pending (not excluded by loan_id)
-	expectedPending := decimal.NewFromInt(100)
-	if !metrics.Pending.Equal(expectedPending) {
-		t.Errorf("Expected pending %s, got %s - loan transaction may be excluded", expectedPending.String(), metrics.Pending.String())
+	if len(completed) != 1 || completed[0].ID != 1 {
+		t.Errorf("Expected loan 1 under completed filter, got %d loans", len(completed))
 	}
 
-	// Verify loan transaction is included in purchases
-	expectedPurchases := decimal.NewFromInt(100)
-	if !metrics.Purchases.Equal(expectedPurchases) {
-		t.Errorf("Expected purchases %s, got %s - loan transaction may be excluded", expectedPurchases.String(), metrics.Purchases.String())
+	active, err := service.GetLoansWithStats(context.Background(), 1, domain.LoanFilterActive, domain.ListOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("Expected no active loans, got %d", len(active))
 	}
 }
 
-// TestCCLoanFullLifecycle_DeferredIntent tests the complete lifecycle of a CC loan
-// with deferred intent: create → pending → billed → settled (AC: #1-8)
-func TestCCLoanFullLifecycle_DeferredIntent(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
-	workspaceID := int32(1)
+func TestPayLoanMonth_RoundsUpPaidInstallments(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+	loanRepo := testutil.NewMockLoanRepository()
+	service := NewLoanService(nil, loanRepo, testutil.NewMockLoanProviderRepository(), transactionRepo, transactionService.accountRepo)
+	service.SetTransactionService(transactionService)
+
 	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: 1, ItemName: "Phone"})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              100,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Phone installment",
+		Amount:          decimal.RequireFromString("83.40"),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		LoanID:          &loanID,
+	})
 
-	// Simulate CC loan transactions created by loan service
-	deferredIntent := domain.SettlementIntentDeferred
-	pendingState := domain.CCStatePending
+	if _, err := service.PayLoanMonth(1, PayLoanMonthInput{LoanID: loanID, Year: 2024, Month: 3}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	legs := liveRoundUps(transactionRepo, 100)
+	if len(legs) != 2 {
+		t.Fatalf("Expected the paid installment to be rounded up, got %d legs", len(legs))
+	}
+	if !legs[0].Amount.Equal(decimal.RequireFromString("0.60")) {
+		t.Errorf("Expected round-up amount 0.60, got %s", legs[0].Amount.String())
+	}
+}
 
-	// STEP 1: Loan created - transactions are PENDING
-	tx1 := &domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "CC Loan Payment 1",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &pendingState,
-		BilledAt:         nil,
+func TestPayLoanMonth_PartialPayoffStaysActive(t *testing.T) {
+	service, loanRepo, _ := createEarlyPayoffTestService()
+
+	if _, err := service.PayLoanMonth(1, PayLoanMonthInput{LoanID: 1, Year: 2099, Month: 1}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
-	tx2 := &domain.Transaction{
-		ID:               2,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "CC Loan Payment 2",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &pendingState,
-		BilledAt:         nil,
+
+	loan, _ := loanRepo.GetByID(1, 1)
+	if loan.CompletedAt != nil {
+		t.Error("Expected CompletedAt to remain nil while payments are outstanding")
 	}
-	transactionRepo.AddTransaction(tx1)
-	transactionRepo.AddTransaction(tx2)
 
-	// Verify: Pending state
-	if tx1.CCState == nil || *tx1.CCState != domain.CCStatePending {
-		t.Error("Step 1 failed: Expected pending state after loan creation")
+	active, _ := service.GetLoansWithStats(context.Background(), 1, domain.LoanFilterActive, domain.ListOptions{})
+	if len(active) != 1 {
+		t.Errorf("Expected loan to remain active, got %d active loans", len(active))
 	}
+}
 
-	// Verify: Appears in GetPendingDeferredCC (not in settlement yet)
-	pendingTxs, _ := transactionRepo.GetPendingDeferredCC(workspaceID, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
-	if len(pendingTxs) != 2 {
-		t.Errorf("Step 1 failed: Expected 2 pending deferred txns, got %d", len(pendingTxs))
+func TestSyncLoanCompletion_ClearsWhenPaymentReverted(t *testing.T) {
+	service, loanRepo, transactionRepo := createEarlyPayoffTestService()
+
+	for month := 1; month <= 3; month++ {
+		if _, err := service.PayLoanMonth(1, PayLoanMonthInput{LoanID: 1, Year: 2099, Month: month}); err != nil {
+			t.Fatalf("Expected no error paying month %d, got %v", month, err)
+		}
 	}
 
-	// STEP 2: Batch bill - transactions become BILLED
-	billedState := domain.CCStateBilled
-	now := time.Now()
-	for _, tx := range transactionRepo.Transactions {
-		tx.CCState = &billedState
-		tx.BilledAt = &now
+	// Revert the last payment
+	if _, err := transactionRepo.TogglePaid(1, 3); err != nil {
+		t.Fatalf("Expected no error toggling paid, got %v", err)
+	}
+
+	completedAt, err := service.SyncLoanCompletion(1, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if completedAt != nil {
+		t.Error("Expected completion to be cleared")
+	}
+	loan, _ := loanRepo.GetByID(1, 1)
+	if loan.CompletedAt != nil {
+		t.Error("Expected loan CompletedAt to be cleared")
+	}
+}
+
+func TestSettleLoanExternally_PaysRemainingAndCompletes(t *testing.T) {
+	service, loanRepo, transactionRepo := createEarlyPayoffTestService()
+
+	// One payment already made in the app; the rest were cleared with the provider
+	if _, err := service.PayLoanMonth(1, PayLoanMonthInput{LoanID: 1, Year: 2099, Month: 1}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify: Billed state
-	if tx1.CCState == nil || *tx1.CCState != domain.CCStateBilled {
-		t.Error("Step 2 failed: Expected billed state after billing")
+	loan, err := service.SettleLoanExternally(1, 1, "  Paid off at the provider counter  ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loan.CompletedAt == nil {
+		t.Error("Expected loan to be completed")
 	}
 
-	// Verify: Appears in GetDeferredForSettlement
-	deferredTxs, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
-	if len(deferredTxs) != 2 {
-		t.Errorf("Step 2 failed: Expected 2 deferred-to-settle txns, got %d", len(deferredTxs))
+	transactions, _ := transactionRepo.GetByLoanID(1, 1)
+	if len(transactions) != 3 {
+		t.Fatalf("Expected no new transactions, got %d", len(transactions))
 	}
-	// Verify loan transactions included (not filtered by loan_id)
-	for _, tx := range deferredTxs {
-		if tx.LoanID == nil {
-			t.Error("Step 2 failed: Expected loan transaction to have LoanID set")
+	for _, tx := range transactions {
+		if !tx.IsPaid {
+			t.Errorf("Expected transaction %d to be paid", tx.ID)
+		}
+		if tx.ID == 1 {
+			if tx.Source == domain.TransactionSourceExternal {
+				t.Error("Expected the in-app payment to keep its source")
+			}
+			continue
+		}
+		if tx.Source != domain.TransactionSourceExternal {
+			t.Errorf("Expected transaction %d source external, got %q", tx.ID, tx.Source)
+		}
+		if tx.Notes == nil || *tx.Notes != "Paid off at the provider counter" {
+			t.Errorf("Expected transaction %d to carry the note, got %v", tx.ID, tx.Notes)
 		}
 	}
 
-	// STEP 3: Settle - transactions become SETTLED (is_paid = true)
-	settledState := domain.CCStateSettled
-	for _, tx := range transactionRepo.Transactions {
-		tx.CCState = &settledState
-		tx.IsPaid = true
+	stored, _ := loanRepo.GetByID(1, 1)
+	if stored.CompletedAt == nil {
+		t.Error("Expected stored loan to be completed")
 	}
 
-	// Verify: Settled state
-	if tx1.CCState == nil || *tx1.CCState != domain.CCStateSettled {
-		t.Error("Step 3 failed: Expected settled state after settlement")
-	}
-	if !tx1.IsPaid {
-		t.Error("Step 3 failed: Expected is_paid=true after settlement")
+	if _, err := service.SettleLoanExternally(1, 1, ""); err != domain.ErrLoanAlreadySettled {
+		t.Errorf("Expected ErrLoanAlreadySettled, got %v", err)
 	}
+}
 
-	// Verify: No longer appears in settlement queries (is_paid=true)
-	deferredAfterSettle, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
-	if len(deferredAfterSettle) != 0 {
-		t.Errorf("Step 3 failed: Expected 0 deferred txns after settle, got %d", len(deferredAfterSettle))
+func TestSettleLoanExternally_NotFound(t *testing.T) {
+	service, _, _ := createEarlyPayoffTestService()
+
+	if _, err := service.SettleLoanExternally(1, 99, ""); err != domain.ErrLoanNotFound {
+		t.Errorf("Expected ErrLoanNotFound, got %v", err)
 	}
 }
 
-// TestCCLoanFullLifecycle_ImmediateIntent tests CC loan with immediate intent
-// (billed immediately for current month settlement) (AC: #3)
-func TestCCLoanFullLifecycle_ImmediateIntent(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
+func TestFindAndRepairOrphanedLoanTransactions(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	transactionRepo.LoanRepo = loanRepo
+
 	workspaceID := int32(1)
-	loanID := int32(1)
+	deletedAt := time.Now()
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "Active"})
+	loanRepo.AddLoan(&domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Deleted", DeletedAt: &deletedAt})
 
-	// Simulate CC loan transactions with immediate intent
-	immediateIntent := domain.SettlementIntentImmediate
-	billedState := domain.CCStateBilled
-	now := time.Now()
+	activeLoanID, deletedLoanID, missingLoanID := int32(1), int32(2), int32(99)
+	for i, loanID := range []*int32{&activeLoanID, &deletedLoanID, &missingLoanID} {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(i + 1),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Installment",
+			Amount:          decimal.NewFromInt(100),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			IsPaid:          true,
+			LoanID:          loanID,
+		})
+	}
 
-	// Create transactions already billed (immediate = bill same month)
-	tx1 := &domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "Immediate CC Loan Payment 1",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		Source:           "loan",
-		LoanID:           &loanID,
-		SettlementIntent: &immediateIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
+	orphans, err := service.FindOrphanedLoanTransactions(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("Expected 2 orphaned transactions, got %d", len(orphans))
+	}
+	for _, tx := range orphans {
+		if *tx.LoanID == activeLoanID {
+			t.Errorf("Transaction %d linked to an active loan reported as orphaned", tx.ID)
+		}
 	}
-	transactionRepo.AddTransaction(tx1)
 
-	// Verify: Appears in GetImmediateForSettlement
-	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
-	immediateTxs, _ := transactionRepo.GetImmediateForSettlement(workspaceID, startDate, endDate)
-	if len(immediateTxs) != 1 {
-		t.Errorf("Expected 1 immediate-to-settle txn, got %d", len(immediateTxs))
+	repaired, err := service.ReattachOrClearOrphans(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
-	if immediateTxs[0].LoanID == nil {
-		t.Error("Expected loan transaction to have LoanID set")
+	if len(repaired) != 2 {
+		t.Errorf("Expected 2 repaired transactions, got %d", len(repaired))
+	}
+	for _, tx := range repaired {
+		if tx.LoanID != nil {
+			t.Errorf("Expected LoanID to be cleared on transaction %d", tx.ID)
+		}
 	}
 
-	// Verify: Does NOT appear in GetDeferredForSettlement
-	deferredTxs, _ := transactionRepo.GetDeferredForSettlement(workspaceID)
-	if len(deferredTxs) != 0 {
-		t.Errorf("Expected 0 deferred txns for immediate loan, got %d", len(deferredTxs))
+	// Transactions are kept, only unlinked; the active loan link is untouched
+	if tx, _ := transactionRepo.GetByID(workspaceID, 1); tx.LoanID == nil || *tx.LoanID != activeLoanID {
+		t.Error("Expected transaction linked to active loan to keep its LoanID")
+	}
+	if tx, err := transactionRepo.GetByID(workspaceID, 2); err != nil || tx.DeletedAt != nil {
+		t.Error("Expected repaired transaction to be kept")
+	}
+
+	orphans, _ = service.FindOrphanedLoanTransactions(workspaceID)
+	if len(orphans) != 0 {
+		t.Errorf("Expected no orphaned transactions after repair, got %d", len(orphans))
 	}
 }
 
-// TestBankLoan_NoSettlementIntent verifies that bank-backed loans don't have
-// settlement_intent set on transactions (AC: #1 - negative case)
-func TestBankLoan_NoSettlementIntent(t *testing.T) {
+func TestGenerateMissingSchedules_OnlyFillsLoansWithoutTransactions(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-
-	// Add Bank account
-	accountRepo.AddAccount(&domain.Account{
-		ID:          1,
-		WorkspaceID: 1,
-		Name:        "Test Bank",
-		Template:    domain.TemplateBank,
-		AccountType: domain.AccountTypeAsset,
-	})
-
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	service.pool = &testutil.MockTxBeginner{}
 
 	workspaceID := int32(1)
 	providerRepo.AddLoanProvider(&domain.LoanProvider{
-		ID:                  1,
-		WorkspaceID:         workspaceID,
-		Name:                "BankLoan",
-		CutoffDay:           15,
-		DefaultInterestRate: decimal.Zero,
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Atome",
+		CutoffDay:   25,
 	})
-
-	// Create loan with Bank account (should NOT have settlement intent)
-	input := CreateLoanInput{
-		ProviderID:   1,
-		ItemName:     "Test Bank Loan",
-		TotalAmount:  decimal.NewFromInt(300),
-		NumMonths:    3,
-		PurchaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
-		AccountID:    1, // Bank account
+	newLoan := func(id int32, name string) *domain.Loan {
+		return &domain.Loan{
+			ID:                id,
+			WorkspaceID:       workspaceID,
+			ProviderID:        1,
+			ItemName:          name,
+			TotalAmount:       decimal.NewFromInt(300),
+			NumMonths:         3,
+			MonthlyPayment:    decimal.NewFromInt(100),
+			FirstPaymentYear:  2024,
+			FirstPaymentMonth: 11,
+			AccountID:         1,
+		}
 	}
+	// Imported without a schedule
+	loanRepo.AddLoan(newLoan(1, "Imported Phone"))
+	// Already has its schedule
+	scheduledLoanID := int32(2)
+	loanRepo.AddLoan(newLoan(scheduledLoanID, "Laptop"))
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              100,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Laptop",
+		Amount:          decimal.NewFromInt(300),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+		LoanID:          &scheduledLoanID,
+	})
 
-	loan, err := service.CreateLoan(workspaceID, input)
+	fixed, err := service.GenerateMissingSchedules(workspaceID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	// Verify loan has NO settlement intent
-	if loan.SettlementIntent != nil {
-		t.Errorf("Expected loan SettlementIntent nil for bank account, got %v", loan.SettlementIntent)
+	if fixed != 1 {
+		t.Errorf("Expected 1 loan fixed, got %d", fixed)
 	}
 
-	// Verify transactions have NO settlement intent
-	for id, tx := range transactionRepo.Transactions {
-		if tx.SettlementIntent != nil {
-			t.Errorf("Transaction %d: expected SettlementIntent nil for bank account, got %v", id, tx.SettlementIntent)
+	generated, _ := transactionRepo.GetByLoanID(workspaceID, 1)
+	if len(generated) != 3 {
+		t.Fatalf("Expected 3 generated transactions, got %d", len(generated))
+	}
+	for _, tx := range generated {
+		if tx.IsPaid || !tx.Amount.Equal(decimal.NewFromInt(100)) || tx.Source != "loan" {
+			t.Errorf("Expected unpaid 100 loan transaction, got paid=%v amount=%s source=%s", tx.IsPaid, tx.Amount, tx.Source)
 		}
 	}
-}
+	if last := generated[2].TransactionDate; last.Year() != 2025 || last.Month() != time.January {
+		t.Errorf("Expected last payment in 2025-01, got %s", last.Format("2006-01"))
+	}
 
-// ============================================================================
-// PayLoanMonth tests (cl-v2-3-1)
-// Tests for paying a loan month by settling transactions
-// ============================================================================
+	untouched, _ := transactionRepo.GetByLoanID(workspaceID, scheduledLoanID)
+	if len(untouched) != 1 || untouched[0].ID != 100 {
+		t.Errorf("Expected loan with existing schedule to be left untouched, got %d transactions", len(untouched))
+	}
 
-// TestPayLoanMonth_Success verifies basic payment flow for bank account loans
-func TestPayLoanMonth_Success(t *testing.T) {
+	// Running again finds nothing left to fix
+	fixed, _ = service.GenerateMissingSchedules(workspaceID)
+	if fixed != 0 {
+		t.Errorf("Expected 0 loans fixed on second run, got %d", fixed)
+	}
+}
+
+func TestGenerateMissingSchedules_SkipsFullyRefundedLoan(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-
-	// Add Bank account
-	accountRepo.AddAccount(&domain.Account{
-		ID:          1,
-		WorkspaceID: 1,
-		Name:        "Test Bank",
-		Template:    domain.TemplateBank,
-		AccountType: domain.AccountTypeAsset,
-	})
-
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
 	workspaceID := int32(1)
 	loanID := int32(1)
-
-	// Setup loan
+	providerRepo.AddLoanProvider(&domain.LoanProvider{ID: 1, WorkspaceID: workspaceID, Name: "Atome", CutoffDay: 25})
 	loanRepo.AddLoan(&domain.Loan{
-		ID:          loanID,
-		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
-	})
-
-	// Add unpaid transactions for March 2024
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              1,
-		WorkspaceID:     workspaceID,
-		AccountID:       1,
-		Name:            "Loan Payment 1",
-		Amount:          decimal.NewFromInt(100),
-		Type:            domain.TransactionTypeExpense,
-		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:          false,
-		LoanID:          &loanID,
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              2,
-		WorkspaceID:     workspaceID,
-		AccountID:       1,
-		Name:            "Loan Payment 2",
-		Amount:          decimal.NewFromInt(50),
-		Type:            domain.TransactionTypeExpense,
-		TransactionDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
-		IsPaid:          false,
-		LoanID:          &loanID,
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(200),
+		NumMonths:         2,
+		MonthlyPayment:    decimal.NewFromInt(100),
+		FirstPaymentYear:  2024,
+		FirstPaymentMonth: 11,
+		AccountID:         1,
 	})
+	for i, txn := range GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 2, 2024, 11, false, nil, nil, decimal.Zero, domain.FeeModeFolded) {
+		txn.ID = int32(i + 1)
+		transactionRepo.AddTransaction(txn)
+	}
 
-	// Pay March 2024
-	input := PayLoanMonthInput{
-		LoanID: loanID,
-		Year:   2024,
-		Month:  3,
+	// Refunding both payments in full soft-deletes every live transaction of the loan
+	for _, paymentNumber := range []int32{1, 2} {
+		if _, err := service.RefundLoanPayment(workspaceID, loanID, paymentNumber, decimal.NewFromInt(100)); err != nil {
+			t.Fatalf("Expected refund of payment %d to succeed, got %v", paymentNumber, err)
+		}
+	}
+	if live, _ := transactionRepo.GetByLoanID(workspaceID, loanID); len(live) != 0 {
+		t.Fatalf("Expected no live transactions after the refunds, got %d", len(live))
 	}
 
-	result, err := service.PayLoanMonth(workspaceID, input)
+	fixed, err := service.GenerateMissingSchedules(workspaceID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
+	if fixed != 0 {
+		t.Errorf("Expected the refunded loan to be left alone, got %d fixed", fixed)
+	}
+	if live, _ := transactionRepo.GetByLoanID(workspaceID, loanID); len(live) != 0 {
+		t.Errorf("Expected no schedule to be regenerated, got %d transactions", len(live))
+	}
+}
 
-	// Verify result
-	if len(result.SettledTransactions) != 2 {
-		t.Errorf("Expected 2 settled transactions, got %d", len(result.SettledTransactions))
+func TestSyncLoanTransactions_KeepsPaidAndRegeneratesUnpaid(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	txBeginner := &testutil.MockTxBeginner{}
+	service.pool = txBeginner
+
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Name:        "Atome",
+		CutoffDay:   25,
+	})
+	loanID := int32(7)
+	loan := &domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(300),
+		NumMonths:         3,
+		MonthlyPayment:    decimal.NewFromInt(100),
+		FirstPaymentYear:  2024,
+		FirstPaymentMonth: 1,
+		AccountID:         1,
+	}
+	loanRepo.AddLoan(loan)
+
+	for i, paid := range []bool{true, false, false} {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(100 + i),
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Phone",
+			Amount:          decimal.NewFromInt(100),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2024, time.Month(1+i), 1, 0, 0, 0, 0, time.UTC),
+			IsPaid:          paid,
+			LoanID:          &loanID,
+		})
 	}
 
-	expectedTotal := decimal.NewFromInt(150)
-	if !result.TotalAmount.Equal(expectedTotal) {
-		t.Errorf("Expected total %s, got %s", expectedTotal.String(), result.TotalAmount.String())
+	// Restructured schedule: later payments raised to 150
+	customAmounts := []decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(150), decimal.NewFromInt(150)}
+	if err := service.SyncLoanTransactions(loan, customAmounts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(txBeginner.Txs) != 1 || !txBeginner.Txs[0].Committed {
+		t.Fatalf("Expected the sync to run in one committed DB transaction, got %+v", txBeginner.Txs)
 	}
 
-	// Verify transactions are marked as paid
-	for _, tx := range result.SettledTransactions {
-		if !tx.IsPaid {
-			t.Errorf("Transaction %d should be marked as paid", tx.ID)
+	transactions, _ := transactionRepo.GetByLoanID(workspaceID, loanID)
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 loan transactions, got %d", len(transactions))
+	}
+
+	byMonth := make(map[time.Month]*domain.Transaction)
+	for _, tx := range transactions {
+		byMonth[tx.TransactionDate.Month()] = tx
+	}
+
+	paid := byMonth[time.January]
+	if paid == nil || paid.ID != 100 || !paid.IsPaid || !paid.Amount.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected paid January transaction 100 to persist unchanged, got %+v", paid)
+	}
+	for _, month := range []time.Month{time.February, time.March} {
+		tx := byMonth[month]
+		if tx == nil {
+			t.Fatalf("Expected a transaction in %s", month)
+		}
+		if tx.ID >= 100 {
+			t.Errorf("Expected %s transaction to be regenerated, got original ID %d", month, tx.ID)
+		}
+		if tx.IsPaid || !tx.Amount.Equal(decimal.NewFromInt(150)) {
+			t.Errorf("Expected unpaid %s payment of 150, got paid=%v amount %s", month, tx.IsPaid, tx.Amount)
+		}
+		if tx.TransactionDate.Day() != 1 || tx.TransactionDate.Year() != 2024 {
+			t.Errorf("Expected %s payment on the 1st of 2024, got %s", month, tx.TransactionDate.Format("2006-01-02"))
 		}
 	}
-}
 
-// TestPayLoanMonth_NoTransactionsToSettle verifies error when no unpaid transactions
-func TestPayLoanMonth_NoTransactionsToSettle(t *testing.T) {
+	for _, id := range []int32{101, 102} {
+		if old := transactionRepo.Transactions[id]; old.DeletedAt == nil {
+			t.Errorf("Expected old unpaid transaction %d to be deleted", id)
+		}
+	}
+}
+
+func TestSyncLoanTransactions_FollowsProviderPromoWithoutPool(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
+	// RM 600 over 6 months: 0% for the first 3 payments, then 10%
 	workspaceID := int32(1)
-	loanID := int32(1)
-
-	// Setup loan
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          loanID,
-		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                  1,
+		WorkspaceID:         workspaceID,
+		Name:                "Kredivo",
+		CutoffDay:           25,
+		DefaultInterestRate: decimal.NewFromInt(10),
+		PromoInterestRate:   decimal.Zero,
+		PromoMonths:         3,
 	})
-
-	// No transactions added - or add already paid ones
+	loanID := int32(7)
+	loan := &domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Phone",
+		TotalAmount:       decimal.NewFromInt(600),
+		NumMonths:         6,
+		InterestRate:      decimal.NewFromInt(10),
+		MonthlyPayment:    decimal.NewFromInt(110),
+		FirstPaymentYear:  2024,
+		FirstPaymentMonth: 4,
+		AccountID:         1,
+	}
+	loanRepo.AddLoan(loan)
 	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              1,
+		ID:              100,
 		WorkspaceID:     workspaceID,
 		AccountID:       1,
-		Name:            "Already Paid",
-		Amount:          decimal.NewFromInt(100),
-		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:          true, // Already paid
+		Name:            "Phone",
+		Amount:          decimal.NewFromInt(110),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
 		LoanID:          &loanID,
 	})
 
-	input := PayLoanMonthInput{
-		LoanID: loanID,
-		Year:   2024,
-		Month:  3,
+	if err := service.SyncLoanTransactions(loan, nil); err != nil {
+		t.Fatalf("Expected no error without a pool, got %v", err)
 	}
 
-	_, err := service.PayLoanMonth(workspaceID, input)
-	if err != domain.ErrNoTransactionsToSettle {
-		t.Errorf("Expected ErrNoTransactionsToSettle, got %v", err)
+	transactions, _ := transactionRepo.GetByLoanID(workspaceID, loanID)
+	if len(transactions) != 6 {
+		t.Fatalf("Expected 6 loan transactions, got %d", len(transactions))
+	}
+	for _, tx := range transactions {
+		expected := decimal.NewFromInt(110)
+		if tx.TransactionDate.Month() < time.July {
+			expected = decimal.NewFromInt(100)
+		}
+		if !tx.Amount.Equal(expected) {
+			t.Errorf("Expected %s payment of %s, got %s", tx.TransactionDate.Format("2006-01"), expected, tx.Amount)
+		}
+	}
+	if transactionRepo.Transactions[100].DeletedAt == nil {
+		t.Error("Expected the old unpaid transaction to be deleted")
 	}
 }
 
-// TestPayLoanMonth_LoanNotFound verifies error when loan doesn't exist
-func TestPayLoanMonth_LoanNotFound(t *testing.T) {
+func TestSyncLoanTransactions_UsesLoanInterestMethod(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	// The provider now defaults to reducing balance, but the loan was created as flat
+	workspaceID := int32(1)
+	providerRepo.AddLoanProvider(&domain.LoanProvider{
+		ID:                    1,
+		WorkspaceID:           workspaceID,
+		Name:                  "Bank",
+		CutoffDay:             25,
+		DefaultInterestRate:   decimal.NewFromInt(12),
+		DefaultInterestMethod: domain.InterestMethodReducingBalance,
+		PromoInterestRate:     decimal.Zero,
+		PromoMonths:           3,
+	})
+	loanID := int32(7)
+	loan := &domain.Loan{
+		ID:                loanID,
+		WorkspaceID:       workspaceID,
+		ProviderID:        1,
+		ItemName:          "Laptop",
+		TotalAmount:       decimal.NewFromInt(1200),
+		NumMonths:         12,
+		InterestRate:      decimal.NewFromInt(12),
+		InterestMethod:    domain.InterestMethodFlat,
+		FirstPaymentYear:  2024,
+		FirstPaymentMonth: 4,
+		AccountID:         1,
+	}
+	loanRepo.AddLoan(loan)
+
+	if err := service.SyncLoanTransactions(loan, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
-	input := PayLoanMonthInput{
-		LoanID: 999, // Non-existent
-		Year:   2024,
-		Month:  3,
+	flat := CalculatePaymentAmountsForMethod(domain.InterestMethodFlat, loan.TotalAmount, loan.InterestRate, decimal.Zero, 3, 12)
+	reducing := CalculatePaymentAmountsForMethod(domain.InterestMethodReducingBalance, loan.TotalAmount, loan.InterestRate, decimal.Zero, 3, 12)
+	if flat[11].Equal(reducing[11]) {
+		t.Fatal("Expected the two methods to give different payments")
 	}
 
-	_, err := service.PayLoanMonth(1, input)
-	if err != domain.ErrLoanNotFound {
-		t.Errorf("Expected ErrLoanNotFound, got %v", err)
+	transactions, _ := transactionRepo.GetByLoanID(workspaceID, loanID)
+	if len(transactions) != 12 {
+		t.Fatalf("Expected 12 loan transactions, got %d", len(transactions))
+	}
+	for _, tx := range transactions {
+		index := (tx.TransactionDate.Year()-2024)*12 + int(tx.TransactionDate.Month()) - 4
+		if !tx.Amount.Equal(flat[index]) {
+			t.Errorf("Expected %s payment of %s under the flat method, got %s", tx.TransactionDate.Format("2006-01"), flat[index], tx.Amount)
+		}
 	}
 }
 
-// TestPayLoanMonth_CCLoan_MarksIsPaidTrue verifies CC transactions get is_paid=true
-func TestPayLoanMonth_CCLoan_MarksIsPaidTrue(t *testing.T) {
+// addPayAllLoanTransaction adds a loan payment transaction for PayAllDue tests
+func addPayAllLoanTransaction(repo *testutil.MockTransactionRepository, id, loanID int32, amount int64, date time.Time, isPaid bool) {
+	repo.AddTransaction(&domain.Transaction{
+		ID:              id,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Loan Payment",
+		Amount:          decimal.NewFromInt(amount),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: date,
+		IsPaid:          isPaid,
+		LoanID:          &loanID,
+		Source:          domain.TransactionSourceLoan,
+	})
+}
+
+func TestPayAllDue_SettlesEveryLoanTogether(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
 
-	// Add CC account
-	accountRepo.AddAccount(&domain.Account{
-		ID:          2,
-		WorkspaceID: 1,
-		Name:        "Test CC",
-		Template:    domain.TemplateCreditCard,
-		AccountType: domain.AccountTypeLiability,
-	})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: 1, Name: "Bank", PaymentMode: domain.PaymentModePerItem})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 2, WorkspaceID: 1, Name: "Store", PaymentMode: domain.PaymentModePerItem})
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: 1, ProviderID: 1, ItemName: "Laptop"})
+	loanRepo.AddLoan(&domain.Loan{ID: 2, WorkspaceID: 1, ProviderID: 2, ItemName: "Phone"})
 
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	jan := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	addPayAllLoanTransaction(transactionRepo, 100, 1, 300, jan, false)
+	addPayAllLoanTransaction(transactionRepo, 101, 2, 120, jan.AddDate(0, 0, 5), false)
+	// Next month stays unpaid
+	addPayAllLoanTransaction(transactionRepo, 102, 1, 300, jan.AddDate(0, 1, 0), false)
 
-	workspaceID := int32(1)
-	loanID := int32(1)
-	deferredIntent := domain.SettlementIntentDeferred
+	result, err := service.PayAllDue(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
-	// Setup loan with CC settlement intent
-	settlementIntent := "deferred"
-	loanRepo.AddLoan(&domain.Loan{
-		ID:               loanID,
-		WorkspaceID:      workspaceID,
-		ItemName:         "CC Loan",
-		AccountID:        2,
-		SettlementIntent: &settlementIntent,
-	})
+	if len(result.Loans) != 2 {
+		t.Fatalf("Expected 2 loan results, got %d", len(result.Loans))
+	}
+	if result.Loans[0].LoanID != 1 || !result.Loans[0].TotalAmount.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("Expected loan 1 settled for 300, got loan %d for %s", result.Loans[0].LoanID, result.Loans[0].TotalAmount)
+	}
+	if result.Loans[1].LoanID != 2 || !result.Loans[1].TotalAmount.Equal(decimal.NewFromInt(120)) {
+		t.Errorf("Expected loan 2 settled for 120, got loan %d for %s", result.Loans[1].LoanID, result.Loans[1].TotalAmount)
+	}
+	if !result.TotalAmount.Equal(decimal.NewFromInt(420)) {
+		t.Errorf("Expected grand total 420, got %s", result.TotalAmount)
+	}
 
-	// Add CC loan transaction (billed)
-	billedState := domain.CCStateBilled
-	now := time.Now()
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:               1,
-		WorkspaceID:      workspaceID,
-		AccountID:        2,
-		Name:             "CC Loan Payment",
-		Amount:           decimal.NewFromInt(100),
-		Type:             domain.TransactionTypeExpense,
-		TransactionDate:  time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:           false,
-		LoanID:           &loanID,
-		SettlementIntent: &deferredIntent,
-		CCState:          &billedState,
-		BilledAt:         &now,
-	})
+	for _, tx := range transactionRepo.ByWorkspace[1] {
+		inJanuary := tx.TransactionDate.Month() == time.January
+		if tx.IsPaid != inJanuary {
+			t.Errorf("Transaction %d: expected paid=%v, got %v", tx.ID, inJanuary, tx.IsPaid)
+		}
+	}
+}
 
-	input := PayLoanMonthInput{
-		LoanID: loanID,
-		Year:   2024,
-		Month:  3,
+func TestPayAllDue_ConsolidatedProviderRequiresEarlierMonth(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 1, WorkspaceID: 1, Name: "Bank", PaymentMode: domain.PaymentModePerItem})
+	providerRepo.AddProvider(&domain.LoanProvider{ID: 2, WorkspaceID: 1, Name: "PayLater", PaymentMode: domain.PaymentModeConsolidatedMonthly})
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: 1, ProviderID: 1, ItemName: "Laptop"})
+	loanRepo.AddLoan(&domain.Loan{ID: 2, WorkspaceID: 1, ProviderID: 2, ItemName: "Headphones"})
+	// Another loan from the consolidated provider still owes December
+	loanRepo.AddLoan(&domain.Loan{ID: 3, WorkspaceID: 1, ProviderID: 2, ItemName: "Shoes"})
+
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	addPayAllLoanTransaction(transactionRepo, 100, 1, 300, jan, false)
+	addPayAllLoanTransaction(transactionRepo, 101, 2, 80, jan, false)
+	addPayAllLoanTransaction(transactionRepo, 102, 3, 50, jan.AddDate(0, -1, 0), false)
+	earliest := &domain.EarliestUnpaidMonth{Year: 2025, Month: 12}
+	paymentRepo := testutil.NewMockLoanPaymentRepository()
+	paymentRepo.GetEarliestUnpaidMonthFn = func(workspaceID, providerID int32) (*domain.EarliestUnpaidMonth, error) {
+		if providerID != 2 {
+			t.Errorf("Expected only the consolidated provider to be checked, got provider %d", providerID)
+		}
+		return earliest, nil
 	}
+	service.SetPaymentRepository(paymentRepo)
 
-	result, err := service.PayLoanMonth(workspaceID, input)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	_, err := service.PayAllDue(1, 2026, 1)
+	var mustPayErr domain.ErrMustPayEarlierMonth
+	if !errors.As(err, &mustPayErr) {
+		t.Fatalf("Expected ErrMustPayEarlierMonth, got %v", err)
+	}
+	if mustPayErr.Expected != "2025-12" || mustPayErr.Requested != "2026-01" {
+		t.Errorf("Expected 2025-12 before 2026-01, got %s before %s", mustPayErr.Expected, mustPayErr.Requested)
 	}
 
-	// Verify CC transaction is marked as paid
-	if len(result.SettledTransactions) != 1 {
-		t.Fatalf("Expected 1 settled transaction, got %d", len(result.SettledTransactions))
+	// Nothing is settled when the rule blocks the run
+	for _, tx := range transactionRepo.ByWorkspace[1] {
+		if tx.IsPaid {
+			t.Errorf("Expected transaction %d to stay unpaid", tx.ID)
+		}
 	}
 
-	tx := result.SettledTransactions[0]
-	if !tx.IsPaid {
-		t.Error("Expected CC transaction to have IsPaid=true")
+	// Once December is paid, January settles for every loan
+	transactionRepo.ByWorkspace[1][2].IsPaid = true
+	earliest = &domain.EarliestUnpaidMonth{Year: 2026, Month: 1}
+	result, err := service.PayAllDue(1, 2026, 1)
+	if err != nil {
+		t.Fatalf("Expected no error after paying December, got %v", err)
+	}
+	if !result.TotalAmount.Equal(decimal.NewFromInt(380)) {
+		t.Errorf("Expected grand total 380, got %s", result.TotalAmount)
 	}
 }
 
-// TestPayLoanMonth_MultipleTransactionsInMonth verifies all transactions for month are settled
-func TestPayLoanMonth_MultipleTransactionsInMonth(t *testing.T) {
+func TestPayAllDue_NothingDue(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
+	service := createTestLoanService(loanRepo, providerRepo)
 
-	accountRepo.AddAccount(&domain.Account{
-		ID:          1,
-		WorkspaceID: 1,
-		Name:        "Test Bank",
-		Template:    domain.TemplateBank,
-	})
+	_, err := service.PayAllDue(1, 2026, 1)
+	if !errors.Is(err, domain.ErrNoTransactionsToSettle) {
+		t.Errorf("Expected ErrNoTransactionsToSettle, got %v", err)
+	}
+}
 
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+func TestGetLoanPaymentStatusBuckets(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	transactionRepo.LoanRepo = loanRepo
 
 	workspaceID := int32(1)
 	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: workspaceID, ItemName: "Phone"})
 
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          loanID,
-		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
-	})
-
-	// Add 3 transactions for same month
-	for i := 1; i <= 3; i++ {
+	addPayment := func(id int32, date time.Time, amount int64, isPaid bool) {
 		transactionRepo.AddTransaction(&domain.Transaction{
-			ID:              int32(i),
+			ID:              id,
 			WorkspaceID:     workspaceID,
 			AccountID:       1,
-			Name:            "Loan Payment",
-			Amount:          decimal.NewFromInt(int64(i * 100)),
-			TransactionDate: time.Date(2024, 3, i*5, 0, 0, 0, 0, time.UTC),
-			IsPaid:          false,
+			Name:            "Phone",
+			Amount:          decimal.NewFromInt(amount),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: date,
+			IsPaid:          isPaid,
 			LoanID:          &loanID,
 		})
 	}
+	addPayment(1, time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC), 100, false) // past due
+	addPayment(2, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), 100, true)   // paid, ignored
+	addPayment(3, time.Date(2025, 5, 28, 0, 0, 0, 0, time.UTC), 150, false) // due this month
+	addPayment(4, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 200, false)  // upcoming
 
-	input := PayLoanMonthInput{
-		LoanID: loanID,
-		Year:   2024,
-		Month:  3,
-	}
-
-	result, err := service.PayLoanMonth(workspaceID, input)
+	buckets, err := service.GetLoanPaymentStatusBuckets(workspaceID, time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC))
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Verify all 3 transactions settled
-	if len(result.SettledTransactions) != 3 {
-		t.Errorf("Expected 3 settled transactions, got %d", len(result.SettledTransactions))
+	tests := []struct {
+		name   string
+		bucket domain.LoanPaymentBucket
+		id     int32
+		total  int64
+	}{
+		{"overdue", buckets.Overdue, 1, 100},
+		{"due this month", buckets.DueThisMonth, 3, 150},
+		{"upcoming", buckets.Upcoming, 4, 200},
 	}
-
-	// Verify total: 100 + 200 + 300 = 600
-	expectedTotal := decimal.NewFromInt(600)
-	if !result.TotalAmount.Equal(expectedTotal) {
-		t.Errorf("Expected total %s, got %s", expectedTotal.String(), result.TotalAmount.String())
+	for _, tt := range tests {
+		if tt.bucket.Count != 1 || len(tt.bucket.Payments) != 1 {
+			t.Fatalf("Expected one %s payment, got %d", tt.name, tt.bucket.Count)
+		}
+		if tt.bucket.Payments[0].ID != tt.id {
+			t.Errorf("Expected transaction %d in %s, got %d", tt.id, tt.name, tt.bucket.Payments[0].ID)
+		}
+		if !tt.bucket.Total.Equal(decimal.NewFromInt(tt.total)) {
+			t.Errorf("Expected %s total %d, got %s", tt.name, tt.total, tt.bucket.Total.String())
+		}
 	}
 }
 
-// TestPayLoanMonth_OnlyPaysTargetMonth verifies only target month transactions are paid
-func TestPayLoanMonth_OnlyPaysTargetMonth(t *testing.T) {
+func TestGetLoanPaymentStatusBuckets_FeeLinesAddToTotalOnly(t *testing.T) {
 	loanRepo := testutil.NewMockLoanRepository()
 	providerRepo := testutil.NewMockLoanProviderRepository()
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-
-	accountRepo.AddAccount(&domain.Account{
-		ID:          1,
-		WorkspaceID: 1,
-		Name:        "Test Bank",
-		Template:    domain.TemplateBank,
-	})
-
-	service := NewLoanService(nil, loanRepo, providerRepo, transactionRepo, accountRepo)
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	transactionRepo.LoanRepo = loanRepo
 
 	workspaceID := int32(1)
 	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: workspaceID, ItemName: "Phone"})
 
-	loanRepo.AddLoan(&domain.Loan{
-		ID:          loanID,
-		WorkspaceID: workspaceID,
-		ItemName:    "Test Loan",
-	})
+	txns := GenerateLoanTransactions(workspaceID, loanID, 1, "Phone", decimal.NewFromInt(100), 1, 2025, 5, false, nil, nil, decimal.NewFromInt(5), domain.FeeModeSeparate)
+	for i, txn := range txns {
+		txn.ID = int32(i + 1)
+		transactionRepo.AddTransaction(txn)
+	}
 
-	// Add transactions for March and April
+	buckets, err := service.GetLoanPaymentStatusBuckets(workspaceID, time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	due := buckets.DueThisMonth
+	if due.Count != 1 || len(due.Payments) != 1 || due.Payments[0].IsLoanFee {
+		t.Fatalf("Expected only the payment to be counted, got count %d with %d payments", due.Count, len(due.Payments))
+	}
+	if !due.Total.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("Expected total 105 including the fee, got %s", due.Total.String())
+	}
+}
+
+func TestGetLoanPaymentStatusBuckets_UsesWorkspaceTimezone(t *testing.T) {
+	loanRepo := testutil.NewMockLoanRepository()
+	providerRepo := testutil.NewMockLoanProviderRepository()
+	service, transactionRepo := createTestLoanServiceWithTransactionRepo(loanRepo, providerRepo)
+	transactionRepo.LoanRepo = loanRepo
+
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: 1, ItemName: "Laptop"})
 	transactionRepo.AddTransaction(&domain.Transaction{
 		ID:              1,
-		WorkspaceID:     workspaceID,
+		WorkspaceID:     1,
 		AccountID:       1,
-		Name:            "March Payment",
-		Amount:          decimal.NewFromInt(100),
-		TransactionDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:          false,
-		LoanID:          &loanID,
-	})
-	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              2,
-		WorkspaceID:     workspaceID,
-		AccountID:       1,
-		Name:            "April Payment",
-		Amount:          decimal.NewFromInt(100),
-		TransactionDate: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
-		IsPaid:          false,
+		Name:            "Laptop",
+		Amount:          decimal.NewFromInt(300),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC),
 		LoanID:          &loanID,
 	})
 
-	// Pay only March
-	input := PayLoanMonthInput{
-		LoanID: loanID,
-		Year:   2024,
-		Month:  3,
+	// 31 May 20:00 UTC is already 1 June in Kuala Lumpur, so the May payment is overdue there
+	kl, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
 	}
+	asOf := time.Date(2025, 5, 31, 20, 0, 0, 0, time.UTC).In(kl)
 
-	result, err := service.PayLoanMonth(workspaceID, input)
+	buckets, err := service.GetLoanPaymentStatusBuckets(1, asOf)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-
-	// Verify only March transaction settled
-	if len(result.SettledTransactions) != 1 {
-		t.Errorf("Expected 1 settled transaction, got %d", len(result.SettledTransactions))
-	}
-
-	// Verify April transaction is still unpaid
-	aprilTx := transactionRepo.Transactions[2]
-	if aprilTx.IsPaid {
-		t.Error("April transaction should still be unpaid")
+	if buckets.Overdue.Count != 1 || buckets.DueThisMonth.Count != 0 {
+		t.Errorf("Expected the payment to be overdue in the workspace timezone, got overdue=%d dueThisMonth=%d", buckets.Overdue.Count, buckets.DueThisMonth.Count)
 	}
 }
@@ -3,9 +3,11 @@ package service
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -14,6 +16,7 @@ type MonthService struct {
 	monthRepo       domain.MonthRepository
 	transactionRepo domain.TransactionRepository
 	calcService     *CalculationService
+	loanRepo        domain.LoanRepository
 }
 
 // NewMonthService creates a new MonthService
@@ -25,6 +28,11 @@ func NewMonthService(monthRepo domain.MonthRepository, transactionRepo domain.Tr
 	}
 }
 
+// SetLoanRepository sets the loan repository used to keep loan completion in sync when deleting loan payments
+func (s *MonthService) SetLoanRepository(loanRepo domain.LoanRepository) {
+	s.loanRepo = loanRepo
+}
+
 // GetOrCreateMonth ensures a month record exists, creating if needed
 func (s *MonthService) GetOrCreateMonth(workspaceID int32, year, month int) (*domain.CalculatedMonth, error) {
 	// Validate month
@@ -88,18 +96,11 @@ func (s *MonthService) GetAllMonths(workspaceID int32) ([]*domain.CalculatedMont
 	}
 
 	// Batch fetch all monthly summaries in a single query (N+1 prevention)
-	summaries, err := s.transactionRepo.GetMonthlyTransactionSummaries(workspaceID)
+	summaryMap, err := s.getMonthlySummaryMap(workspaceID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build lookup map: "year-month" -> summary
-	summaryMap := make(map[string]*domain.MonthlyTransactionSummary)
-	for _, s := range summaries {
-		key := monthSummaryKey(s.Year, s.Month)
-		summaryMap[key] = s
-	}
-
 	// Enrich months with pre-fetched summaries
 	result := make([]*domain.CalculatedMonth, len(months))
 	for i, m := range months {
@@ -123,6 +124,199 @@ func (s *MonthService) GetAllMonths(workspaceID int32) ([]*domain.CalculatedMont
 	return result, nil
 }
 
+// ListMonths returns the workspace's most recent months (newest first, at most limit)
+// with income, expense and net totals for month navigation. A non-positive limit defaults to 12.
+func (s *MonthService) ListMonths(workspaceID int32, limit int) ([]domain.MonthSummary, error) {
+	if limit <= 0 {
+		limit = 12
+	}
+
+	months, err := s.monthRepo.GetAll(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(months, func(a, b *domain.Month) int {
+		return (b.Year*12 + b.Month) - (a.Year*12 + a.Month)
+	})
+	if len(months) > limit {
+		months = months[:limit]
+	}
+
+	summaryMap, err := s.getMonthlySummaryMap(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.MonthSummary, len(months))
+	for i, m := range months {
+		income := decimal.Zero
+		expenses := decimal.Zero
+		if summary := summaryMap[monthSummaryKey(m.Year, m.Month)]; summary != nil {
+			income = summary.TotalIncome
+			expenses = summary.TotalExpenses
+		}
+
+		result[i] = domain.MonthSummary{
+			Year:          m.Year,
+			Month:         m.Month,
+			TotalIncome:   income,
+			TotalExpenses: expenses,
+			Net:           income.Sub(expenses),
+			IsClosed:      m.IsClosed(),
+		}
+	}
+	return result, nil
+}
+
+// DeleteMonthOptions controls which of a month's transactions DeleteMonthTransactions removes
+type DeleteMonthOptions struct {
+	OnlyUnpaid       bool // Keep paid transactions
+	IncludeGenerated bool // Allow removing loan and recurring transactions
+}
+
+// DeleteMonthTransactions soft-deletes a month's transactions and returns how many were removed.
+// Account balances are derived from transactions, so they adjust with the delete. A transfer is
+// only removed when both legs are selected; if the other leg is paid or dated in another
+// (possibly closed) month, the whole pair is kept. Loan and recurring transactions are only
+// removed with IncludeGenerated, after which the affected loans' completion is re-synced.
+// Closed months cannot be cleared.
+func (s *MonthService) DeleteMonthTransactions(workspaceID int32, year, month int, opts DeleteMonthOptions) (int, error) {
+	if month < 1 || month > 12 {
+		return 0, domain.ErrInvalidInput
+	}
+	if year < 2000 || year > 2100 {
+		return 0, domain.ErrInvalidInput
+	}
+	closed, err := isMonthClosed(s.monthRepo, workspaceID, year, month)
+	if err != nil {
+		return 0, err
+	}
+	if closed {
+		return 0, domain.ErrMonthClosed
+	}
+
+	startDate, endDate := getMonthBoundaries(year, month)
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+
+	selected := make(map[int32]bool, len(transactions))
+	pairIDs := make(map[uuid.UUID]bool)
+	for _, tx := range transactions {
+		if opts.OnlyUnpaid && tx.IsPaid {
+			continue
+		}
+		if isGeneratedTransaction(tx) && !opts.IncludeGenerated {
+			return 0, domain.ErrGeneratedTransactionsInMonth
+		}
+		selected[tx.ID] = true
+		if tx.TransferPairID != nil {
+			pairIDs[*tx.TransferPairID] = true
+		}
+	}
+
+	// Keep transfers whose other leg falls outside the selection rather than half-deleting them
+	for pairID := range pairIDs {
+		legs, err := s.transactionRepo.GetTransferPair(workspaceID, pairID)
+		if err != nil {
+			return 0, err
+		}
+		if slices.ContainsFunc(legs, func(leg *domain.Transaction) bool { return !selected[leg.ID] }) {
+			for _, leg := range legs {
+				delete(selected, leg.ID)
+			}
+		}
+	}
+
+	ids := make([]int32, 0, len(selected))
+	deleted := make([]*domain.Transaction, 0, len(selected))
+	for _, tx := range transactions {
+		if selected[tx.ID] {
+			ids = append(ids, tx.ID)
+			deleted = append(deleted, tx)
+		}
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	count, err := s.transactionRepo.SoftDeleteByIDs(workspaceID, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, transactionLoanIDs(deleted)); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// AutoCloseStaleMonths closes the workspace's open months that ended more than olderThanMonths
+// months before the current one, returning how many were closed. Already-closed months are skipped.
+func (s *MonthService) AutoCloseStaleMonths(workspaceID int32, olderThanMonths int) (int, error) {
+	if olderThanMonths < 1 {
+		return 0, domain.ErrInvalidInput
+	}
+
+	months, err := s.monthRepo.GetAll(workspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoffYear, cutoffMonth := staleMonthCutoff(time.Now(), olderThanMonths)
+	closed := 0
+	for _, m := range months {
+		if m.ClosedAt != nil || m.Year*12+m.Month >= cutoffYear*12+cutoffMonth {
+			continue
+		}
+		ok, err := s.monthRepo.Close(workspaceID, m.ID)
+		if err != nil {
+			return closed, err
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// AutoCloseAllStaleMonths closes stale months in every workspace (for the background task)
+func (s *MonthService) AutoCloseAllStaleMonths(olderThanMonths int) (int64, error) {
+	if olderThanMonths < 1 {
+		return 0, domain.ErrInvalidInput
+	}
+	cutoffYear, cutoffMonth := staleMonthCutoff(time.Now(), olderThanMonths)
+	return s.monthRepo.CloseAllBefore(cutoffYear, cutoffMonth)
+}
+
+// staleMonthCutoff returns the earliest month that is still recent: months before it are
+// more than olderThanMonths months older than the month containing now
+func staleMonthCutoff(now time.Time, olderThanMonths int) (int, int) {
+	cutoff := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -olderThanMonths, 0)
+	return cutoff.Year(), int(cutoff.Month())
+}
+
+// isGeneratedTransaction reports whether a transaction was created by a loan or recurring template
+func isGeneratedTransaction(tx *domain.Transaction) bool {
+	return tx.LoanID != nil || tx.TemplateID != nil ||
+		tx.Source == domain.TransactionSourceLoan || tx.Source == domain.TransactionSourceRecurring
+}
+
+// getMonthlySummaryMap aggregates income/expenses per month, keyed by monthSummaryKey
+func (s *MonthService) getMonthlySummaryMap(workspaceID int32) (map[string]*domain.MonthlyTransactionSummary, error) {
+	summaries, err := s.transactionRepo.GetMonthlyTransactionSummaries(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaryMap := make(map[string]*domain.MonthlyTransactionSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryMap[monthSummaryKey(summary.Year, summary.Month)] = summary
+	}
+	return summaryMap, nil
+}
+
 // monthSummaryKey generates a lookup key for monthly summaries
 func monthSummaryKey(year, month int) string {
 	return fmt.Sprintf("%d-%d", year, month)
@@ -218,3 +412,18 @@ func getMonthBoundaries(year, month int) (time.Time, time.Time) {
 	endDate := startDate.AddDate(0, 1, -1) // Last day of month
 	return startDate, endDate
 }
+
+// isMonthClosed reports whether year/month has been closed; a month with no record is still open
+func isMonthClosed(monthRepo domain.MonthRepository, workspaceID int32, year, month int) (bool, error) {
+	if monthRepo == nil {
+		return false, nil
+	}
+	m, err := monthRepo.GetByYearMonth(workspaceID, year, month)
+	if errors.Is(err, domain.ErrMonthNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return m.IsClosed(), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -184,6 +185,71 @@ func TestMonthService_GetOrCreateMonth_CalculatesTransactions(t *testing.T) {
 	assert.Equal(t, "5700.00", result.ClosingBalance.StringFixed(2)) // 1000 + 5000 - 300
 }
 
+func TestMonthService_RefundIsNotIncome(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	monthRepo.AddMonth(&domain.Month{
+		ID:              1,
+		WorkspaceID:     1,
+		Year:            2025,
+		Month:           1,
+		StartDate:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		StartingBalance: decimal.NewFromInt(1000),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Salary",
+		Amount:          decimal.NewFromInt(5000),
+		Type:            domain.TransactionTypeIncome,
+		TransactionDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              2,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Headphones",
+		Amount:          decimal.NewFromInt(300),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              3,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Headphones refund",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeIncome,
+		TransactionDate: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+		IsRefund:        true,
+	})
+
+	result, err := svc.GetOrCreateMonth(1, 2025, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "5000.00", result.TotalIncome.StringFixed(2))
+	assert.Equal(t, "200.00", result.TotalExpenses.StringFixed(2))
+	assert.Equal(t, "5800.00", result.ClosingBalance.StringFixed(2)) // 1000 + 5000 - 200
+
+	// The batched month list must agree with the single-month calculation
+	months, err := svc.GetAllMonths(1)
+	require.NoError(t, err)
+	require.Len(t, months, 1)
+	assert.Equal(t, "5000.00", months[0].TotalIncome.StringFixed(2))
+	assert.Equal(t, "200.00", months[0].TotalExpenses.StringFixed(2))
+}
+
 func TestMonthService_GetOrCreateMonth_InvalidMonth(t *testing.T) {
 	monthRepo := testutil.NewMockMonthRepository()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -212,6 +278,76 @@ func TestMonthService_GetOrCreateMonth_InvalidYear(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrInvalidInput)
 }
 
+func TestMonthService_ListMonths(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	// Current month plus the two before it, added oldest last to check ordering
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	// Only the oldest month has been closed; last month is past but still open
+	monthStarts := []time.Time{current, current.AddDate(0, -2, 0), current.AddDate(0, -1, 0)}
+	closedAt := current
+	for i, start := range monthStarts {
+		m := &domain.Month{
+			ID:          int32(i + 1),
+			WorkspaceID: 1,
+			Year:        start.Year(),
+			Month:       int(start.Month()),
+			StartDate:   start,
+			EndDate:     start.AddDate(0, 1, -1),
+		}
+		if i == 1 {
+			m.ClosedAt = &closedAt
+		}
+		monthRepo.AddMonth(m)
+	}
+
+	addTx := func(id int32, start time.Time, txType domain.TransactionType, amount int64) {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              id,
+			WorkspaceID:     1,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(amount),
+			Type:            txType,
+			TransactionDate: start.AddDate(0, 0, 4),
+			IsPaid:          true,
+		})
+	}
+	addTx(1, current, domain.TransactionTypeIncome, 3000)
+	addTx(2, current, domain.TransactionTypeExpense, 1200)
+	addTx(3, current.AddDate(0, -1, 0), domain.TransactionTypeExpense, 500)
+	addTx(4, current.AddDate(0, -2, 0), domain.TransactionTypeIncome, 800)
+	addTx(5, current.AddDate(0, -2, 0), domain.TransactionTypeExpense, 300)
+
+	months, err := svc.ListMonths(1, 12)
+	require.NoError(t, err)
+	require.Len(t, months, 3)
+
+	// Newest first
+	assert.Equal(t, current.Year(), months[0].Year)
+	assert.Equal(t, int(current.Month()), months[0].Month)
+	assert.Equal(t, "1800.00", months[0].Net.StringFixed(2))
+	assert.False(t, months[0].IsClosed)
+
+	assert.Equal(t, "-500.00", months[1].Net.StringFixed(2))
+	assert.False(t, months[1].IsClosed)
+
+	assert.Equal(t, "800.00", months[2].TotalIncome.StringFixed(2))
+	assert.Equal(t, "300.00", months[2].TotalExpenses.StringFixed(2))
+	assert.Equal(t, "500.00", months[2].Net.StringFixed(2))
+	assert.True(t, months[2].IsClosed)
+
+	// The limit keeps only the most recent months
+	months, err = svc.ListMonths(1, 2)
+	require.NoError(t, err)
+	require.Len(t, months, 2)
+	assert.Equal(t, int(current.AddDate(0, -1, 0).Month()), months[1].Month)
+}
+
 func TestGetMonthBoundaries(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -281,3 +417,190 @@ func TestGetPreviousMonth(t *testing.T) {
 		})
 	}
 }
+
+func TestMonthService_DeleteMonthTransactions_OnlyUnpaid(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Paid", Amount: decimal.NewFromInt(50), Type: domain.TransactionTypeExpense, TransactionDate: date, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Unpaid", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeExpense, TransactionDate: date})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: 1, AccountID: 1, Name: "Next month", Amount: decimal.NewFromInt(10), Type: domain.TransactionTypeExpense, TransactionDate: date.AddDate(0, 1, 0)})
+
+	deleted, err := svc.DeleteMonthTransactions(1, now.Year(), int(now.Month()), DeleteMonthOptions{OnlyUnpaid: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Nil(t, transactionRepo.Transactions[100].DeletedAt)
+	assert.NotNil(t, transactionRepo.Transactions[101].DeletedAt)
+	assert.Nil(t, transactionRepo.Transactions[102].DeletedAt)
+}
+
+func TestMonthService_DeleteMonthTransactions_GeneratedRequiresFlag(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	loanID := int32(5)
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Manual", Amount: decimal.NewFromInt(50), Type: domain.TransactionTypeExpense, TransactionDate: date, Source: domain.TransactionSourceManual})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Loan payment", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeExpense, TransactionDate: date, Source: domain.TransactionSourceLoan, LoanID: &loanID})
+
+	_, err := svc.DeleteMonthTransactions(1, now.Year(), int(now.Month()), DeleteMonthOptions{})
+	assert.ErrorIs(t, err, domain.ErrGeneratedTransactionsInMonth)
+	assert.Nil(t, transactionRepo.Transactions[100].DeletedAt, "nothing should be deleted when refused")
+
+	deleted, err := svc.DeleteMonthTransactions(1, now.Year(), int(now.Month()), DeleteMonthOptions{IncludeGenerated: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestMonthService_DeleteMonthTransactions_SyncsLoanCompletion(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+	svc.SetLoanRepository(loanRepo)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	loanID := int32(5)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: 1, ItemName: "Phone"})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Phone 1/2", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeExpense, TransactionDate: date.AddDate(0, -1, 0), IsPaid: true, Source: domain.TransactionSourceLoan, LoanID: &loanID})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: 1, AccountID: 1, Name: "Phone 2/2", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeExpense, TransactionDate: date, Source: domain.TransactionSourceLoan, LoanID: &loanID})
+
+	deleted, err := svc.DeleteMonthTransactions(1, now.Year(), int(now.Month()), DeleteMonthOptions{IncludeGenerated: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NotNil(t, loanRepo.Loans[loanID].CompletedAt, "loan with only paid payments left should be completed")
+}
+
+func TestMonthService_DeleteMonthTransactions_KeepsTransferSpanningMonths(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	spanningPair := uuid.New()
+	localPair := uuid.New()
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Transfer to Savings", Amount: decimal.NewFromInt(30), Type: domain.TransactionTypeExpense, TransactionDate: date, IsPaid: true, TransferPairID: &spanningPair})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: 1, AccountID: 2, Name: "Transfer from Checking", Amount: decimal.NewFromInt(30), Type: domain.TransactionTypeIncome, TransactionDate: date.AddDate(0, -1, 0), IsPaid: true, TransferPairID: &spanningPair})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: 1, AccountID: 1, Name: "Transfer to Savings", Amount: decimal.NewFromInt(10), Type: domain.TransactionTypeExpense, TransactionDate: date, IsPaid: true, TransferPairID: &localPair})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 103, WorkspaceID: 1, AccountID: 2, Name: "Transfer from Checking", Amount: decimal.NewFromInt(10), Type: domain.TransactionTypeIncome, TransactionDate: date, IsPaid: true, TransferPairID: &localPair})
+
+	deleted, err := svc.DeleteMonthTransactions(1, now.Year(), int(now.Month()), DeleteMonthOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Nil(t, transactionRepo.Transactions[100].DeletedAt, "transfer with a leg in another month should be kept")
+	assert.Nil(t, transactionRepo.Transactions[101].DeletedAt, "leg in another month must not be touched")
+	assert.NotNil(t, transactionRepo.Transactions[102].DeletedAt)
+	assert.NotNil(t, transactionRepo.Transactions[103].DeletedAt)
+}
+
+func TestMonthService_DeleteMonthTransactions_RejectsClosedMonth(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	closedAt := now
+	monthRepo.AddMonth(&domain.Month{ID: 1, WorkspaceID: 1, Year: date.Year(), Month: int(date.Month()), StartDate: date, EndDate: date.AddDate(0, 1, -1), ClosedAt: &closedAt})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Old", Amount: decimal.NewFromInt(50), Type: domain.TransactionTypeExpense, TransactionDate: date})
+
+	_, err := svc.DeleteMonthTransactions(1, date.Year(), int(date.Month()), DeleteMonthOptions{IncludeGenerated: true})
+
+	assert.ErrorIs(t, err, domain.ErrMonthClosed)
+	assert.Nil(t, transactionRepo.Transactions[100].DeletedAt)
+}
+
+func TestMonthService_DeleteMonthTransactions_PastOpenMonthAllowed(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	// A past month that was never closed can still be cleared
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	monthRepo.AddMonth(&domain.Month{ID: 1, WorkspaceID: 1, Year: date.Year(), Month: int(date.Month()), StartDate: date, EndDate: date.AddDate(0, 1, -1)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: 1, AccountID: 1, Name: "Old", Amount: decimal.NewFromInt(50), Type: domain.TransactionTypeExpense, TransactionDate: date})
+
+	deleted, err := svc.DeleteMonthTransactions(1, date.Year(), int(date.Month()), DeleteMonthOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NotNil(t, transactionRepo.Transactions[100].DeletedAt)
+}
+
+func TestMonthService_AutoCloseStaleMonths(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	// Current month plus 1, 2, 4, 5 and 6 months ago; 6 months ago is already closed
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	closedAt := current.AddDate(0, -1, 0)
+	for i, monthsAgo := range []int{0, 1, 2, 4, 5, 6} {
+		start := current.AddDate(0, -monthsAgo, 0)
+		m := &domain.Month{
+			ID:          int32(i + 1),
+			WorkspaceID: 1,
+			Year:        start.Year(),
+			Month:       int(start.Month()),
+			StartDate:   start,
+			EndDate:     start.AddDate(0, 1, -1),
+		}
+		if monthsAgo == 6 {
+			m.ClosedAt = &closedAt
+		}
+		monthRepo.AddMonth(m)
+	}
+
+	closed, err := svc.AutoCloseStaleMonths(1, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, closed)
+	assert.Nil(t, monthRepo.Months[1].ClosedAt)
+	assert.Nil(t, monthRepo.Months[2].ClosedAt)
+	assert.Nil(t, monthRepo.Months[3].ClosedAt)
+	assert.NotNil(t, monthRepo.Months[4].ClosedAt)
+	assert.NotNil(t, monthRepo.Months[5].ClosedAt)
+	assert.Equal(t, closedAt, *monthRepo.Months[6].ClosedAt)
+
+	// Lowering the threshold closes the next stale month too; running again is a no-op
+	closed, err = svc.AutoCloseStaleMonths(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	assert.NotNil(t, monthRepo.Months[3].ClosedAt)
+	assert.Nil(t, monthRepo.Months[2].ClosedAt)
+
+	closed, err = svc.AutoCloseStaleMonths(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, closed)
+}
+
+func TestMonthService_AutoCloseStaleMonths_InvalidThreshold(t *testing.T) {
+	monthRepo := testutil.NewMockMonthRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	calcService := NewCalculationService(testutil.NewMockAccountRepository(), transactionRepo)
+	svc := NewMonthService(monthRepo, transactionRepo, calcService)
+
+	_, err := svc.AutoCloseStaleMonths(1, 0)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ type ProjectionSyncService struct {
 	templateRepo    domain.RecurringTemplateRepository
 	transactionRepo domain.TransactionRepository
 	exclusionRepo   domain.ProjectionExclusionRepository
+	categoryRepo    domain.BudgetCategoryRepository
 	eventPublisher  websocket.EventPublisher
 }
 
@@ -29,6 +31,11 @@ func NewProjectionSyncService(
 	}
 }
 
+// SetBudgetCategoryRepository sets the category repository used for the tax-deductible default
+func (s *ProjectionSyncService) SetBudgetCategoryRepository(categoryRepo domain.BudgetCategoryRepository) {
+	s.categoryRepo = categoryRepo
+}
+
 // SetExclusionRepository sets the exclusion repository for projection exclusion tracking
 func (s *ProjectionSyncService) SetExclusionRepository(exclusionRepo domain.ProjectionExclusionRepository) {
 	s.exclusionRepo = exclusionRepo
@@ -123,8 +130,10 @@ func (s *ProjectionSyncService) syncTemplate(template *domain.RecurringTemplate)
 // generateUpToMonth creates any missing projections up to the target month
 // Returns the number of projections created
 func (s *ProjectionSyncService) generateUpToMonth(template *domain.RecurringTemplate, targetEnd time.Time) (int, error) {
+	ctx := context.Background()
+
 	// Get existing projections to check for duplicates
-	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(template.WorkspaceID, template.ID)
+	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(ctx, template.WorkspaceID, template.ID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get existing projections: %w", err)
 	}
@@ -151,6 +160,8 @@ func (s *ProjectionSyncService) generateUpToMonth(template *domain.RecurringTemp
 		}
 	}
 
+	isTaxDeductible := categoryTaxDeductible(ctx, s.categoryRepo, template.WorkspaceID, template.CategoryID)
+
 	// Generate projections month by month
 	current := startDate
 	created := 0
@@ -168,7 +179,7 @@ func (s *ProjectionSyncService) generateUpToMonth(template *domain.RecurringTemp
 		// Check if this month is excluded (user explicitly deleted a projection)
 		if s.exclusionRepo != nil {
 			monthStart := time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.UTC)
-			excluded, err := s.exclusionRepo.IsExcluded(template.WorkspaceID, template.ID, monthStart)
+			excluded, err := s.exclusionRepo.IsExcluded(ctx, template.WorkspaceID, template.ID, monthStart)
 			if err == nil && excluded {
 				current = current.AddDate(0, 1, 0)
 				continue
@@ -189,9 +200,10 @@ func (s *ProjectionSyncService) generateUpToMonth(template *domain.RecurringTemp
 			IsProjected:     true,
 			IsPaid:          false,
 			Notes:           template.Notes,
+			IsTaxDeductible: isTaxDeductible,
 		}
 
-		if _, err := s.transactionRepo.Create(transaction); err != nil {
+		if _, err := s.transactionRepo.Create(ctx, transaction); err != nil {
 			return created, fmt.Errorf("failed to create projection for %s: %w", monthKey, err)
 		}
 
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -53,7 +54,7 @@ func TestSyncAllActive_CreatesProjections(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify projections were created
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	require.NoError(t, err)
 	assert.NotEmpty(t, projections)
 
@@ -95,7 +96,7 @@ func TestSyncAllActive_RespectsEndDate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify only 3 projections were created (not 12)
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	require.NoError(t, err)
 	assert.LessOrEqual(t, len(projections), 3)
 }
@@ -143,7 +144,7 @@ func TestSyncAllActive_DeletesProjectionsBeyondEndDate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify the projection beyond end_date was deleted
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, templateID)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, templateID)
 	require.NoError(t, err)
 
 	for _, proj := range projections {
@@ -177,14 +178,14 @@ func TestSyncAllActive_IdempotentDoesNotCreateDuplicates(t *testing.T) {
 	err := syncService.SyncAllActive()
 	require.NoError(t, err)
 
-	projections1, _ := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections1, _ := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	count1 := len(projections1)
 
 	// Run sync second time
 	err = syncService.SyncAllActive()
 	require.NoError(t, err)
 
-	projections2, _ := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections2, _ := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	count2 := len(projections2)
 
 	// Count should be the same (idempotent)
@@ -226,11 +227,11 @@ func TestSyncAllActive_MultipleWorkspaces(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify projections for workspace 1
-	projections1, _ := transactionRepo.GetProjectionsByTemplate(1, 1)
+	projections1, _ := transactionRepo.GetProjectionsByTemplate(context.Background(), 1, 1)
 	assert.NotEmpty(t, projections1)
 
 	// Verify projections for workspace 2
-	projections2, _ := transactionRepo.GetProjectionsByTemplate(2, 2)
+	projections2, _ := transactionRepo.GetProjectionsByTemplate(context.Background(), 2, 2)
 	assert.NotEmpty(t, projections2)
 }
 
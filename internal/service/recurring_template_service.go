@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
@@ -9,6 +11,9 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// errExclusionsUnavailable is returned when skipping occurrences without an exclusion repository
+var errExclusionsUnavailable = errors.New("projection exclusion repository not configured")
+
 // RecurringTemplateServiceImpl handles recurring template business logic (v2)
 type RecurringTemplateServiceImpl struct {
 	templateRepo    domain.RecurringTemplateRepository
@@ -16,6 +21,7 @@ type RecurringTemplateServiceImpl struct {
 	accountRepo     domain.AccountRepository
 	categoryRepo    domain.BudgetCategoryRepository
 	exclusionRepo   domain.ProjectionExclusionRepository
+	workspaceRepo   domain.WorkspaceRepository
 	eventPublisher  websocket.EventPublisher
 }
 
@@ -39,6 +45,11 @@ func (s *RecurringTemplateServiceImpl) SetExclusionRepository(exclusionRepo doma
 	s.exclusionRepo = exclusionRepo
 }
 
+// SetWorkspaceRepository sets the workspace repository used to read the generation horizon
+func (s *RecurringTemplateServiceImpl) SetWorkspaceRepository(workspaceRepo domain.WorkspaceRepository) {
+	s.workspaceRepo = workspaceRepo
+}
+
 // SetEventPublisher sets the event publisher for real-time updates
 func (s *RecurringTemplateServiceImpl) SetEventPublisher(publisher websocket.EventPublisher) {
 	s.eventPublisher = publisher
@@ -66,7 +77,7 @@ func (s *RecurringTemplateServiceImpl) CreateTemplate(workspaceID int32, input d
 
 	// Validate category exists and belongs to workspace (if provided)
 	if input.CategoryID != nil {
-		_, err = s.categoryRepo.GetByID(workspaceID, *input.CategoryID)
+		_, err = s.categoryRepo.GetByID(context.Background(), workspaceID, *input.CategoryID)
 		if err != nil {
 			return nil, domain.ErrBudgetCategoryNotFound
 		}
@@ -130,19 +141,10 @@ func (s *RecurringTemplateServiceImpl) linkTransactionToTemplate(workspaceID int
 	}
 
 	// Update the transaction with template link
-	updateData := &domain.UpdateTransactionData{
-		Name:            existingTx.Name,
-		Amount:          existingTx.Amount,
-		Type:            existingTx.Type,
-		TransactionDate: existingTx.TransactionDate,
-		AccountID:       existingTx.AccountID,
-		Notes:           existingTx.Notes,
-		CategoryID:      existingTx.CategoryID,
-		// Link to template
-		Source:      "recurring",
-		TemplateID:  &templateID,
-		IsProjected: false, // This is an actual transaction, not a projection
-	}
+	updateData := existingTx.ToUpdateData()
+	updateData.Source = "recurring"
+	updateData.TemplateID = &templateID
+	updateData.IsProjected = false // This is an actual transaction, not a projection
 
 	_, err = s.transactionRepo.Update(workspaceID, transactionID, updateData)
 	return err
@@ -169,7 +171,7 @@ func (s *RecurringTemplateServiceImpl) UpdateTemplate(workspaceID int32, id int3
 
 	// Validate category exists and belongs to workspace (if provided)
 	if input.CategoryID != nil {
-		_, err = s.categoryRepo.GetByID(workspaceID, *input.CategoryID)
+		_, err = s.categoryRepo.GetByID(context.Background(), workspaceID, *input.CategoryID)
 		if err != nil {
 			return nil, domain.ErrBudgetCategoryNotFound
 		}
@@ -194,7 +196,8 @@ func (s *RecurringTemplateServiceImpl) UpdateTemplate(workspaceID int32, id int3
 }
 
 // DeleteTemplate deletes a template with cascade logic
-func (s *RecurringTemplateServiceImpl) DeleteTemplate(workspaceID int32, id int32) error {
+// When keepGenerated is false, future unpaid generated transactions are deleted instead of being detached
+func (s *RecurringTemplateServiceImpl) DeleteTemplate(workspaceID int32, id int32, keepGenerated bool) error {
 	// Verify template exists
 	_, err := s.templateRepo.GetByID(workspaceID, id)
 	if err != nil {
@@ -206,12 +209,19 @@ func (s *RecurringTemplateServiceImpl) DeleteTemplate(workspaceID int32, id int3
 		return err
 	}
 
-	// 2. Orphan actual transactions (is_projected=false) by setting template_id=NULL
+	// 2. Optionally delete future unpaid actual transactions; paid and past ones are kept
+	if !keepGenerated {
+		if err := s.transactionRepo.DeleteFutureUnpaidActualsByTemplate(workspaceID, id, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	// 3. Orphan remaining actual transactions (is_projected=false) by setting template_id=NULL
 	if err := s.transactionRepo.OrphanActualsByTemplate(workspaceID, id); err != nil {
 		return err
 	}
 
-	// 3. Delete the template
+	// 4. Delete the template
 	if err := s.templateRepo.Delete(workspaceID, id); err != nil {
 		return err
 	}
@@ -227,9 +237,98 @@ func (s *RecurringTemplateServiceImpl) GetTemplate(workspaceID int32, id int32)
 	return s.templateRepo.GetByID(workspaceID, id)
 }
 
-// ListTemplates retrieves all templates for a workspace
-func (s *RecurringTemplateServiceImpl) ListTemplates(workspaceID int32) ([]*domain.RecurringTemplate, error) {
-	return s.templateRepo.ListByWorkspace(workspaceID)
+// ListTemplates retrieves templates for a workspace, narrowed by the optional filter
+// Filter account/category must belong to the workspace
+func (s *RecurringTemplateServiceImpl) ListTemplates(workspaceID int32, filter *domain.RecurringFilter) ([]*domain.RecurringTemplate, error) {
+	if filter != nil {
+		if filter.AccountID != nil {
+			if _, err := s.accountRepo.GetByID(workspaceID, *filter.AccountID); err != nil {
+				return nil, domain.ErrAccountNotFound
+			}
+		}
+		if filter.CategoryID != nil {
+			if _, err := s.categoryRepo.GetByID(context.Background(), workspaceID, *filter.CategoryID); err != nil {
+				return nil, domain.ErrBudgetCategoryNotFound
+			}
+		}
+	}
+	return s.templateRepo.ListByWorkspace(workspaceID, filter)
+}
+
+// GetTransactionsByRecurring retrieves all transactions generated from a template, newest first
+// Includes projections and actuals; returns ErrRecurringTemplateNotFound for unknown templates
+func (s *RecurringTemplateServiceImpl) GetTransactionsByRecurring(workspaceID int32, recurringID int32) ([]*domain.Transaction, error) {
+	if _, err := s.templateRepo.GetByID(workspaceID, recurringID); err != nil {
+		return nil, err
+	}
+	return s.transactionRepo.GetByTemplate(workspaceID, recurringID)
+}
+
+// SkipOccurrence skips a single month of a template without deactivating it
+// The month is recorded as a projection exclusion so generation never recreates it,
+// and an unpaid projection already generated for that month is removed
+func (s *RecurringTemplateServiceImpl) SkipOccurrence(workspaceID int32, recurringID int32, year int, month time.Month) error {
+	if month < time.January || month > time.December {
+		return domain.ErrInvalidOccurrenceMonth
+	}
+	if s.exclusionRepo == nil {
+		return errExclusionsUnavailable
+	}
+
+	template, err := s.templateRepo.GetByID(workspaceID, recurringID)
+	if err != nil {
+		return err
+	}
+
+	monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.exclusionRepo.Create(workspaceID, recurringID, monthStart); err != nil {
+		return err
+	}
+
+	projections, err := s.transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, recurringID)
+	if err != nil {
+		return err
+	}
+	for _, projection := range projections {
+		if projection.IsPaid || projection.TransactionDate.Year() != year || projection.TransactionDate.Month() != month {
+			continue
+		}
+		if err := s.transactionRepo.SoftDelete(workspaceID, projection.ID); err != nil {
+			return err
+		}
+	}
+
+	s.publishEvent(workspaceID, websocket.RecurringUpdated(template))
+
+	return nil
+}
+
+// UnskipOccurrence removes a month's skip marker and regenerates its projection if it is still upcoming
+func (s *RecurringTemplateServiceImpl) UnskipOccurrence(workspaceID int32, recurringID int32, year int, month time.Month) error {
+	if month < time.January || month > time.December {
+		return domain.ErrInvalidOccurrenceMonth
+	}
+	if s.exclusionRepo == nil {
+		return errExclusionsUnavailable
+	}
+
+	template, err := s.templateRepo.GetByID(workspaceID, recurringID)
+	if err != nil {
+		return err
+	}
+
+	monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.exclusionRepo.Delete(workspaceID, recurringID, monthStart); err != nil {
+		return err
+	}
+
+	if err := s.generateProjections(workspaceID, template); err != nil {
+		return err
+	}
+
+	s.publishEvent(workspaceID, websocket.RecurringUpdated(template))
+
+	return nil
 }
 
 // validateCreateInput validates input for creating a template
@@ -304,10 +403,24 @@ func (s *RecurringTemplateServiceImpl) getSettlementIntentForTemplate(workspaceI
 
 // generateProjections creates projected transactions for a template
 func (s *RecurringTemplateServiceImpl) generateProjections(workspaceID int32, template *domain.RecurringTemplate) error {
+	return s.generateProjectionsWithSkips(workspaceID, template, nil)
+}
+
+// projectionPlan is one month of a template's projection window: the date generation
+// uses and, when nothing would be created, why
+type projectionPlan struct {
+	date       time.Time
+	skipReason domain.GenerateSkipReason // Empty when a projection would be created
+}
+
+// planProjections is generation's planning phase: it walks the projection window and
+// decides month by month what would be created, without persisting anything.
+// Months in skipMonths (user-edited or existing during recalculation) are never created.
+func (s *RecurringTemplateServiceImpl) planProjections(workspaceID int32, template *domain.RecurringTemplate, skipMonths map[string]bool) ([]projectionPlan, error) {
 	// Check for existing projections (idempotency check)
-	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Build a set of existing projection months to avoid duplicates (month precision)
@@ -317,11 +430,40 @@ func (s *RecurringTemplateServiceImpl) generateProjections(workspaceID int32, te
 		existingMonths[monthKey] = true
 	}
 
-	// Calculate projection range
-	now := time.Now()
+	startDate, endDate := s.projectionWindow(template, time.Now())
+
+	// One plan entry per month
+	plans := []projectionPlan{}
+	current := startDate
+	for !current.After(endDate) {
+		// Calculate the actual day for this month (handle months with fewer days)
+		actualDate := s.calculateActualDate(current.Year(), current.Month(), template.StartDate.Day())
+		monthKey := actualDate.Format("2006-01")
+
+		plan := projectionPlan{date: actualDate}
+		switch {
+		case skipMonths[monthKey]:
+			plan.skipReason = domain.GenerateSkipPreserved
+		case existingMonths[monthKey]:
+			plan.skipReason = domain.GenerateSkipAlreadyGenerated
+		case s.isExcludedMonth(workspaceID, template.ID, current):
+			// User explicitly skipped the occurrence or deleted its projection
+			plan.skipReason = domain.GenerateSkipExcluded
+		}
+		plans = append(plans, plan)
+
+		// Move to next month
+		current = current.AddDate(0, 1, 0)
+	}
+
+	return plans, nil
+}
+
+// projectionWindow returns the first and last dates projections are generated for:
+// from the template's next occurrence after now through MIN(end date, start + 12 months)
+func (s *RecurringTemplateServiceImpl) projectionWindow(template *domain.RecurringTemplate, now time.Time) (time.Time, time.Time) {
 	targetDay := template.StartDate.Day()
 
-	// Calculate start date for projections
 	var startDate time.Time
 	templateMonth := time.Date(template.StartDate.Year(), template.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
 	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
@@ -340,71 +482,133 @@ func (s *RecurringTemplateServiceImpl) generateProjections(workspaceID int32, te
 		}
 	}
 
-	// End date: MIN(template.EndDate, startDate + 12 months)
 	endDate := startDate.AddDate(0, 12, 0)
 	if template.EndDate != nil && template.EndDate.Before(endDate) {
 		endDate = *template.EndDate
 	}
 
-	// Generate one transaction per month
-	current := startDate
-	for !current.After(endDate) {
-		// Calculate the actual day for this month (handle months with fewer days)
-		targetDay := template.StartDate.Day()
-		actualDate := s.calculateActualDate(current.Year(), current.Month(), targetDay)
-		monthKey := actualDate.Format("2006-01")
+	return startDate, endDate
+}
 
-		// Skip if projection already exists for this month (idempotency)
-		if existingMonths[monthKey] {
-			current = current.AddDate(0, 1, 0)
-			continue
+// isExcludedMonth reports whether the template's occurrence in date's month has a projection exclusion
+// Lookup errors are treated as not excluded so generation is never blocked by them
+func (s *RecurringTemplateServiceImpl) isExcludedMonth(workspaceID, templateID int32, date time.Time) bool {
+	if s.exclusionRepo == nil {
+		return false
+	}
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	excluded, err := s.exclusionRepo.IsExcluded(context.Background(), workspaceID, templateID, monthStart)
+	return err == nil && excluded
+}
+
+// PreviewGeneration reports what projection generation would create and skip in a month
+// across the workspace's active templates, using the same planning phase without persisting
+func (s *RecurringTemplateServiceImpl) PreviewGeneration(workspaceID int32, year int, month time.Month) (*domain.GeneratePreview, error) {
+	if month < time.January || month > time.December {
+		return nil, domain.ErrInvalidOccurrenceMonth
+	}
+
+	templates, err := s.templateRepo.GetActive(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.GeneratePreview{
+		Year:        year,
+		Month:       month,
+		WouldCreate: []*domain.GeneratePreviewItem{},
+		WouldSkip:   []*domain.GeneratePreviewSkip{},
+	}
+
+	for _, template := range templates {
+		plans, err := s.planProjections(workspaceID, template, nil)
+		if err != nil {
+			return nil, err
 		}
 
-		// Check if this month is excluded (user explicitly deleted a projection)
-		if s.exclusionRepo != nil {
-			monthStart := time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.UTC)
-			excluded, err := s.exclusionRepo.IsExcluded(workspaceID, template.ID, monthStart)
-			if err == nil && excluded {
-				current = current.AddDate(0, 1, 0)
-				continue
+		date := s.calculateActualDate(year, month, template.StartDate.Day())
+		reason := domain.GenerateSkipOutsideWindow
+		for _, plan := range plans {
+			if plan.date.Year() == year && plan.date.Month() == month {
+				date = plan.date
+				reason = plan.skipReason
+				break
 			}
 		}
-
-		// Get settlement intent if this is a CC account
-		settlementIntent := s.getSettlementIntentForTemplate(workspaceID, template)
-
-		transaction := &domain.Transaction{
-			WorkspaceID:      workspaceID,
-			Name:             template.Description,
-			Amount:           template.Amount,
-			Type:             domain.TransactionTypeExpense, // Default to expense
-			CategoryID:       template.CategoryID,
-			AccountID:        template.AccountID,
-			TransactionDate:  actualDate,
-			Source:           "recurring",
-			TemplateID:       &template.ID,
-			IsProjected:      true,
-			IsPaid:           false, // CCState computed from isPaid and billedAt (both nil = pending)
-			SettlementIntent: settlementIntent,
-			Notes:            template.Notes,
+		// Not planned at all: say whether the template's own dates rule the month out
+		if reason == domain.GenerateSkipOutsideWindow {
+			if date.Before(template.StartDate) {
+				reason = domain.GenerateSkipNotStarted
+			} else if template.EndDate != nil && date.After(*template.EndDate) {
+				reason = domain.GenerateSkipEnded
+			}
 		}
 
-		if _, err := s.transactionRepo.Create(transaction); err != nil {
-			return err
+		if reason == "" {
+			preview.WouldCreate = append(preview.WouldCreate, &domain.GeneratePreviewItem{
+				TemplateID: template.ID,
+				Name:       template.Description,
+				Amount:     template.Amount,
+				Date:       date,
+			})
+			continue
 		}
+		preview.WouldSkip = append(preview.WouldSkip, &domain.GeneratePreviewSkip{
+			TemplateID: template.ID,
+			Name:       template.Description,
+			Date:       date,
+			Reason:     reason,
+		})
+	}
 
-		// Move to next month
-		current = current.AddDate(0, 1, 0)
+	return preview, nil
+}
+
+// GetSubscriptions lists the workspace's active recurring expenses with normalized monthly and annual costs
+// Templates only generate expense projections, so every active template counts as a subscription.
+func (s *RecurringTemplateServiceImpl) GetSubscriptions(workspaceID int32) (*domain.SubscriptionSummary, error) {
+	templates, err := s.templateRepo.GetActive(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	summary := &domain.SubscriptionSummary{
+		Subscriptions: []*domain.Subscription{},
+		MonthlyTotal:  decimal.Zero,
+		AnnualTotal:   decimal.Zero,
+	}
+	for _, template := range templates {
+		annual := annualCost(template.Frequency, template.Amount)
+		summary.Subscriptions = append(summary.Subscriptions, &domain.Subscription{
+			TemplateID:  template.ID,
+			Description: template.Description,
+			Frequency:   template.Frequency,
+			Amount:      template.Amount,
+			MonthlyCost: annual.Div(decimal.NewFromInt(12)).Round(2),
+			AnnualCost:  annual,
+		})
+		summary.AnnualTotal = summary.AnnualTotal.Add(annual)
+	}
+	summary.MonthlyTotal = summary.AnnualTotal.Div(decimal.NewFromInt(12)).Round(2)
+
+	return summary, nil
+}
+
+// annualCost scales a per-occurrence amount to a year; unknown frequencies are treated as monthly
+func annualCost(frequency string, amount decimal.Decimal) decimal.Decimal {
+	if frequency == "yearly" {
+		return amount
+	}
+	return amount.Mul(decimal.NewFromInt(12))
 }
 
 // recalculateProjections updates existing projections when template changes
 // User-edited projections are PRESERVED, unedited ones are updated with new template values
 func (s *RecurringTemplateServiceImpl) recalculateProjections(workspaceID int32, oldTemplate, newTemplate *domain.RecurringTemplate) error {
+	ctx := context.Background()
+
 	// Get existing projections to check for user edits
-	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(workspaceID, newTemplate.ID)
+	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(ctx, workspaceID, newTemplate.ID)
 	if err != nil {
 		return err
 	}
@@ -418,29 +622,30 @@ func (s *RecurringTemplateServiceImpl) recalculateProjections(workspaceID int32,
 
 	// Get settlement intent based on new template
 	settlementIntent := s.getSettlementIntentForTemplate(workspaceID, newTemplate)
+	isTaxDeductible := categoryTaxDeductible(ctx, s.categoryRepo, workspaceID, newTemplate.CategoryID)
 
 	// Process each existing projection
 	for _, proj := range existingProjections {
-		if s.isUserEdited(proj, oldTemplate) {
+		if proj.ModifiedFromTemplate || s.isUserEdited(proj, oldTemplate) {
 			// PRESERVE user-edited projection - don't modify it
 			continue
 		}
 
-		// Update unedited projection with new template values
-		updateData := &domain.UpdateTransactionData{
-			Name:             newTemplate.Description,
-			Amount:           newTemplate.Amount,
-			Type:             domain.TransactionTypeExpense,
-			TransactionDate:  proj.TransactionDate,
-			AccountID:        newTemplate.AccountID,
-			CategoryID:       newTemplate.CategoryID,
-			Source:           "recurring",
-			TemplateID:       &newTemplate.ID,
-			IsProjected:      true,
-			IsPaid:           proj.IsPaid, // Preserve current isPaid status
-			BilledAt:         proj.BilledAt, // Preserve current billedAt
-			SettlementIntent: settlementIntent,
-			Notes:            newTemplate.Notes,
+		// Update unedited projection with new template values; paid/billed state
+		// and per-row flags (payee, report exclusion, tax) are preserved
+		updateData := proj.ToUpdateData()
+		updateData.Name = newTemplate.Description
+		updateData.Amount = newTemplate.Amount
+		updateData.Type = domain.TransactionTypeExpense
+		updateData.AccountID = newTemplate.AccountID
+		updateData.CategoryID = newTemplate.CategoryID
+		updateData.Source = "recurring"
+		updateData.TemplateID = &newTemplate.ID
+		updateData.IsProjected = true
+		updateData.SettlementIntent = settlementIntent
+		updateData.Notes = newTemplate.Notes
+		if !sameCategory(proj.CategoryID, newTemplate.CategoryID) {
+			updateData.IsTaxDeductible = isTaxDeductible
 		}
 		if _, err := s.transactionRepo.Update(workspaceID, proj.ID, updateData); err != nil {
 			return err
@@ -479,102 +684,152 @@ func (s *RecurringTemplateServiceImpl) isUserEdited(projection *domain.Transacti
 
 // generateProjectionsWithSkips creates projections but skips specified months
 func (s *RecurringTemplateServiceImpl) generateProjectionsWithSkips(workspaceID int32, template *domain.RecurringTemplate, skipMonths map[string]bool) error {
-	// Get existing projections for idempotency check
-	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	ctx := context.Background()
+
+	plans, err := s.planProjections(workspaceID, template, skipMonths)
 	if err != nil {
 		return err
 	}
 
-	// Build set of existing projection months
-	existingMonths := make(map[string]bool)
-	for _, proj := range existingProjections {
-		monthKey := proj.TransactionDate.Format("2006-01")
-		existingMonths[monthKey] = true
-	}
-
-	// Calculate projection range
-	now := time.Now()
-	targetDay := template.StartDate.Day()
+	// Get settlement intent if this is a CC account
+	settlementIntent := s.getSettlementIntentForTemplate(workspaceID, template)
+	isTaxDeductible := categoryTaxDeductible(ctx, s.categoryRepo, workspaceID, template.CategoryID)
 
-	// Calculate start date for projections
-	var startDate time.Time
-	templateMonth := time.Date(template.StartDate.Year(), template.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, plan := range plans {
+		if plan.skipReason != "" {
+			continue
+		}
 
-	if !templateMonth.Before(currentMonth) {
-		// Template starts this month or in the future - use template start date
-		startDate = template.StartDate
-	} else {
-		// Template starts in a previous month - find the next valid projection date
-		// Use calculateActualDate to properly handle month-end edge cases (e.g., 31st in Feb -> Feb 28/29)
-		startDate = s.calculateActualDate(now.Year(), now.Month(), targetDay)
-		if startDate.Before(now) || startDate.Equal(now) {
-			// If we've passed that day this month (or it's today), start next month
-			nextMonth := now.AddDate(0, 1, 0)
-			startDate = s.calculateActualDate(nextMonth.Year(), nextMonth.Month(), targetDay)
+		if _, err := s.transactionRepo.Create(ctx, projectionTransaction(workspaceID, template, plan.date, settlementIntent, isTaxDeductible)); err != nil {
+			return err
 		}
 	}
 
-	endDate := startDate.AddDate(0, 12, 0)
-	if template.EndDate != nil && template.EndDate.Before(endDate) {
-		endDate = *template.EndDate
+	return nil
+}
+
+// projectionTransaction builds the projected transaction a template generates for date
+func projectionTransaction(workspaceID int32, template *domain.RecurringTemplate, date time.Time, settlementIntent *domain.SettlementIntent, isTaxDeductible bool) *domain.Transaction {
+	return &domain.Transaction{
+		WorkspaceID:      workspaceID,
+		Name:             template.Description,
+		Amount:           template.Amount,
+		Type:             domain.TransactionTypeExpense, // Default to expense
+		CategoryID:       template.CategoryID,
+		AccountID:        template.AccountID,
+		TransactionDate:  date,
+		Source:           "recurring",
+		TemplateID:       &template.ID,
+		IsProjected:      true,
+		IsPaid:           false, // CCState computed from isPaid and billedAt (both nil = pending)
+		SettlementIntent: settlementIntent,
+		Notes:            template.Notes,
+		IsTaxDeductible:  isTaxDeductible,
 	}
+}
 
-	current := startDate
-	for !current.After(endDate) {
-		targetDay := template.StartDate.Day()
-		actualDate := s.calculateActualDate(current.Year(), current.Month(), targetDay)
-		monthKey := actualDate.Format("2006-01")
+// GenerateAhead creates the missing projections of every active template from the current month
+// through the workspace's generation horizon. It uses the same planning phase as generation, so
+// months that already have a projection or were skipped are left alone and repeated calls are no-ops.
+func (s *RecurringTemplateServiceImpl) GenerateAhead(workspaceID int32) (*domain.GenerateResult, error) {
+	ctx := context.Background()
 
-		// Skip if this month was passed in (user-edited or existing)
-		if skipMonths[monthKey] {
-			current = current.AddDate(0, 1, 0)
-			continue
+	var horizon int32
+	if s.workspaceRepo != nil {
+		workspace, err := s.workspaceRepo.GetByID(workspaceID)
+		if err != nil {
+			return nil, err
 		}
+		horizon = min(max(workspace.GenerationHorizonMonths, 0), domain.MaxGenerationHorizonMonths)
+	}
 
-		// Idempotency check: skip if projection already exists in database
-		if existingMonths[monthKey] {
-			current = current.AddDate(0, 1, 0)
-			continue
+	now := time.Now()
+	fromMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	toMonth := fromMonth.AddDate(0, int(horizon), 0)
+	result := &domain.GenerateResult{
+		FromMonth: fromMonth.Format("2006-01"),
+		ToMonth:   toMonth.Format("2006-01"),
+		Created:   []*domain.GeneratePreviewItem{},
+		Skipped:   []*domain.GeneratePreviewSkip{},
+	}
+
+	templates, err := s.templateRepo.GetActive(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		plans, err := s.planProjections(workspaceID, template, nil)
+		if err != nil {
+			return nil, err
 		}
 
-		// Check if this month is excluded (user explicitly deleted a projection)
-		if s.exclusionRepo != nil {
-			monthStart := time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.UTC)
-			excluded, err := s.exclusionRepo.IsExcluded(workspaceID, template.ID, monthStart)
-			if err == nil && excluded {
-				current = current.AddDate(0, 1, 0)
+		settlementIntent := s.getSettlementIntentForTemplate(workspaceID, template)
+		isTaxDeductible := categoryTaxDeductible(ctx, s.categoryRepo, workspaceID, template.CategoryID)
+		for _, plan := range plans {
+			month := plan.date.Format("2006-01")
+			if month < result.FromMonth || month > result.ToMonth {
+				continue
+			}
+			if plan.skipReason != "" {
+				result.Skipped = append(result.Skipped, &domain.GeneratePreviewSkip{
+					TemplateID: template.ID,
+					Name:       template.Description,
+					Date:       plan.date,
+					Reason:     plan.skipReason,
+				})
 				continue
 			}
+
+			if _, err := s.transactionRepo.Create(ctx, projectionTransaction(workspaceID, template, plan.date, settlementIntent, isTaxDeductible)); err != nil {
+				return nil, err
+			}
+			result.Created = append(result.Created, &domain.GeneratePreviewItem{
+				TemplateID: template.ID,
+				Name:       template.Description,
+				Amount:     template.Amount,
+				Date:       plan.date,
+			})
 		}
+	}
 
-		// Get settlement intent if this is a CC account
-		settlementIntent := s.getSettlementIntentForTemplate(workspaceID, template)
+	return result, nil
+}
 
-		transaction := &domain.Transaction{
-			WorkspaceID:      workspaceID,
-			Name:             template.Description,
-			Amount:           template.Amount,
-			Type:             domain.TransactionTypeExpense,
-			CategoryID:       template.CategoryID,
-			AccountID:        template.AccountID,
-			TransactionDate:  actualDate,
-			Source:           "recurring",
-			TemplateID:       &template.ID,
-			IsProjected:      true,
-			IsPaid:           false, // CCState computed from isPaid and billedAt
-			SettlementIntent: settlementIntent,
-			Notes:            template.Notes,
+// PreviewOccurrences returns up to count occurrence dates of a template on or after the day of from
+// Dates follow the generator's rule: the start date's day each month, clamped to short months.
+// Occurrences stop at the template's end date, so an ended template yields none.
+func PreviewOccurrences(template *domain.RecurringTemplate, from time.Time, count int) []time.Time {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	startDay := time.Date(template.StartDate.Year(), template.StartDate.Month(), template.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	if startDay.After(fromDay) {
+		fromDay = startDay
+	}
+
+	occurrences := []time.Time{}
+	month := time.Date(fromDay.Year(), fromDay.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for len(occurrences) < count {
+		date := util.CalculateActualDate(month.Year(), month.Month(), template.StartDate.Day())
+		month = month.AddDate(0, 1, 0)
+		if date.Before(fromDay) {
+			continue
 		}
-
-		if _, err := s.transactionRepo.Create(transaction); err != nil {
-			return err
+		if template.EndDate != nil && date.After(*template.EndDate) {
+			break
 		}
-
-		current = current.AddDate(0, 1, 0)
+		occurrences = append(occurrences, date)
 	}
+	return occurrences
+}
 
-	return nil
+// NextDueDate returns the next occurrence of a template on or after today, or nil if it has ended
+// today is the calendar day in the workspace timezone, expressed as a UTC date
+func NextDueDate(template *domain.RecurringTemplate, today time.Time) *time.Time {
+	occurrences := PreviewOccurrences(template, today, 1)
+	if len(occurrences) == 0 {
+		return nil
+	}
+	return &occurrences[0]
 }
 
 // calculateActualDate returns the actual date for a target day in a given month,
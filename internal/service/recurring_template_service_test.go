@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -199,7 +200,7 @@ func TestDeleteTemplate_CascadesBehavior(t *testing.T) {
 
 	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
 
-	err := service.DeleteTemplate(workspaceID, templateID)
+	err := service.DeleteTemplate(workspaceID, templateID, true)
 
 	require.NoError(t, err)
 
@@ -208,7 +209,7 @@ func TestDeleteTemplate_CascadesBehavior(t *testing.T) {
 	assert.Error(t, err)
 
 	// Verify projected transactions are deleted
-	projections, _ := transactionRepo.GetProjectionsByTemplate(workspaceID, templateID)
+	projections, _ := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, templateID)
 	assert.Empty(t, projections)
 
 	// Verify actual transaction exists but is orphaned (template_id = nil)
@@ -217,6 +218,102 @@ func TestDeleteTemplate_CascadesBehavior(t *testing.T) {
 	assert.Nil(t, actual.TemplateID)
 }
 
+// addGeneratedTransactions seeds a template with a past paid, a future paid and a future unpaid actual transaction
+func addGeneratedTransactions(transactionRepo *testutil.MockTransactionRepository, workspaceID, templateID int32) {
+	now := time.Now()
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		TemplateID:      &templateID,
+		Name:            "Monthly Rent",
+		Amount:          decimal.NewFromInt(1500),
+		TransactionDate: now.AddDate(0, -1, 0),
+		IsPaid:          true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              2,
+		WorkspaceID:     workspaceID,
+		TemplateID:      &templateID,
+		Name:            "Monthly Rent",
+		Amount:          decimal.NewFromInt(1500),
+		TransactionDate: now.AddDate(0, 1, 0),
+		IsPaid:          true,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              3,
+		WorkspaceID:     workspaceID,
+		TemplateID:      &templateID,
+		Name:            "Monthly Rent",
+		Amount:          decimal.NewFromInt(1500),
+		TransactionDate: now.AddDate(0, 1, 0),
+		IsPaid:          false,
+	})
+}
+
+func TestDeleteTemplate_KeepGenerated_DetachesTransactions(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	workspaceID := int32(1)
+	templateID := int32(1)
+
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          templateID,
+		WorkspaceID: workspaceID,
+		Description: "Monthly Rent",
+		Amount:      decimal.NewFromInt(1500),
+	})
+	addGeneratedTransactions(transactionRepo, workspaceID, templateID)
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	err := service.DeleteTemplate(workspaceID, templateID, true)
+	require.NoError(t, err)
+
+	// All generated transactions remain, unlinked from the template
+	for _, id := range []int32{1, 2, 3} {
+		tx, err := transactionRepo.GetByID(workspaceID, id)
+		require.NoError(t, err, "transaction %d should be kept", id)
+		assert.Nil(t, tx.TemplateID, "transaction %d should be detached", id)
+	}
+}
+
+func TestDeleteTemplate_WithoutKeepGenerated_RemovesFutureUnpaid(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	workspaceID := int32(1)
+	templateID := int32(1)
+
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          templateID,
+		WorkspaceID: workspaceID,
+		Description: "Monthly Rent",
+		Amount:      decimal.NewFromInt(1500),
+	})
+	addGeneratedTransactions(transactionRepo, workspaceID, templateID)
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	err := service.DeleteTemplate(workspaceID, templateID, false)
+	require.NoError(t, err)
+
+	// Future unpaid transaction is removed
+	_, err = transactionRepo.GetByID(workspaceID, 3)
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+
+	// Paid transactions are kept and detached
+	for _, id := range []int32{1, 2} {
+		tx, err := transactionRepo.GetByID(workspaceID, id)
+		require.NoError(t, err, "paid transaction %d should be kept", id)
+		assert.Nil(t, tx.TemplateID, "transaction %d should be detached", id)
+	}
+}
+
 func TestGetTemplate_Found(t *testing.T) {
 	templateRepo := testutil.NewMockRecurringTemplateRepository()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -276,7 +373,7 @@ func TestListTemplates(t *testing.T) {
 
 	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
 
-	templates, err := service.ListTemplates(workspaceID)
+	templates, err := service.ListTemplates(workspaceID, nil)
 
 	require.NoError(t, err)
 	assert.Len(t, templates, 2)
@@ -303,13 +400,80 @@ func TestListTemplates_WorkspaceIsolation(t *testing.T) {
 	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
 
 	// List templates for workspace 1
-	templates, err := service.ListTemplates(1)
+	templates, err := service.ListTemplates(1, nil)
 
 	require.NoError(t, err)
 	assert.Len(t, templates, 1)
 	assert.Equal(t, "Workspace 1 Template", templates[0].Description)
 }
 
+func TestListTemplates_FilterByAccount(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: workspaceID, Name: "Credit Card"})
+
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 1, WorkspaceID: workspaceID, AccountID: 1, Description: "Rent"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 2, WorkspaceID: workspaceID, AccountID: 2, Description: "Netflix"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 3, WorkspaceID: workspaceID, AccountID: 1, Description: "Utilities"})
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	accountID := int32(1)
+	templates, err := service.ListTemplates(workspaceID, &domain.RecurringFilter{AccountID: &accountID})
+
+	require.NoError(t, err)
+	assert.Len(t, templates, 2)
+	for _, template := range templates {
+		assert.Equal(t, accountID, template.AccountID)
+	}
+}
+
+func TestListTemplates_FilterByAccountAndActive(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+
+	ended := time.Now().AddDate(0, -1, 0)
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 1, WorkspaceID: workspaceID, AccountID: 1, Description: "Rent"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 2, WorkspaceID: workspaceID, AccountID: 1, Description: "Old Gym", EndDate: &ended})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 3, WorkspaceID: workspaceID, AccountID: 2, Description: "Netflix"})
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	accountID := int32(1)
+	active := true
+	templates, err := service.ListTemplates(workspaceID, &domain.RecurringFilter{AccountID: &accountID, Active: &active})
+
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "Rent", templates[0].Description)
+}
+
+func TestListTemplates_FilterAccountNotInWorkspace(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 2, Name: "Other Workspace"})
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	accountID := int32(1)
+	_, err := service.ListTemplates(1, &domain.RecurringFilter{AccountID: &accountID})
+
+	assert.Equal(t, domain.ErrAccountNotFound, err)
+}
+
 func TestUpdateTemplate_ValidInput(t *testing.T) {
 	templateRepo := testutil.NewMockRecurringTemplateRepository()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -356,6 +520,70 @@ func TestUpdateTemplate_ValidInput(t *testing.T) {
 	assert.True(t, updated.Amount.Equal(decimal.NewFromInt(200)))
 }
 
+func TestUpdateTemplate_ProjectionsKeepFlagsAndApplyTaxDefault(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID})
+	rent, _ := categoryRepo.Create(&domain.BudgetCategory{WorkspaceID: workspaceID, Name: "Rent"})
+	medical, _ := categoryRepo.Create(&domain.BudgetCategory{WorkspaceID: workspaceID, Name: "Medical", IsTaxDeductible: true})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          1,
+		WorkspaceID: workspaceID,
+		Description: "Therapy",
+		Amount:      decimal.NewFromInt(100),
+		CategoryID:  &rent.ID,
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   time.Now(),
+	})
+	payee := "Clinic"
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:                 50,
+		WorkspaceID:        workspaceID,
+		AccountID:          1,
+		Name:               "Therapy",
+		Amount:             decimal.NewFromInt(100),
+		Type:               domain.TransactionTypeExpense,
+		TransactionDate:    time.Now().AddDate(0, 1, 0),
+		CategoryID:         &rent.ID,
+		Source:             "recurring",
+		TemplateID:         int32Ptr(1),
+		IsProjected:        true,
+		Payee:              &payee,
+		ExcludeFromReports: true,
+	})
+
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	_, err := service.UpdateTemplate(workspaceID, 1, domain.UpdateRecurringTemplateInput{
+		Description: "Therapy",
+		Amount:      decimal.NewFromInt(120),
+		CategoryID:  &medical.ID,
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   time.Now(),
+	})
+	require.NoError(t, err)
+
+	existing := transactionRepo.Transactions[50]
+	assert.True(t, existing.Amount.Equal(decimal.NewFromInt(120)))
+	assert.True(t, existing.ExcludeFromReports, "per-row flags must survive a template edit")
+	require.NotNil(t, existing.Payee)
+	assert.Equal(t, "Clinic", *existing.Payee)
+	assert.True(t, existing.IsTaxDeductible, "moving to a deductible category applies its default")
+
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
+	require.NoError(t, err)
+	require.Greater(t, len(projections), 1)
+	for _, proj := range projections {
+		assert.True(t, proj.IsTaxDeductible, "generated projections inherit the category default")
+	}
+}
+
 func TestUpdateTemplate_NotFound(t *testing.T) {
 	templateRepo := testutil.NewMockRecurringTemplateRepository()
 	transactionRepo := testutil.NewMockTransactionRepository()
@@ -416,7 +644,7 @@ func TestCreateTemplate_GeneratesProjections(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify projections were created
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	require.NoError(t, err)
 
 	// Should have 12 months of projections
@@ -466,7 +694,7 @@ func TestCreateTemplate_MonthEndEdgeCase(t *testing.T) {
 	template, err := service.CreateTemplate(workspaceID, input)
 	require.NoError(t, err)
 
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	require.NoError(t, err)
 	require.NotEmpty(t, projections)
 
@@ -514,7 +742,7 @@ func TestCreateTemplate_EndDateLimitsProjections(t *testing.T) {
 	template, err := service.CreateTemplate(workspaceID, input)
 	require.NoError(t, err)
 
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	require.NoError(t, err)
 
 	// Should have only 3 projections (months 0, 1, 2 from start)
@@ -688,7 +916,7 @@ func TestCreateTemplate_IdempotentProjections(t *testing.T) {
 	require.NoError(t, err)
 
 	// Get initial projection count
-	projections1, err := transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	projections1, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	require.NoError(t, err)
 	initialCount := len(projections1)
 
@@ -697,7 +925,341 @@ func TestCreateTemplate_IdempotentProjections(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify no duplicate projections were created
-	projections2, err := transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	projections2, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
 	require.NoError(t, err)
 	assert.Equal(t, initialCount, len(projections2), "Idempotency check failed - duplicate projections created")
 }
+
+func TestNextDueDate_MonthlyMidMonth(t *testing.T) {
+	template := &domain.RecurringTemplate{
+		ID:        1,
+		Frequency: "monthly",
+		StartDate: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Before this month's due day: due later this month
+	next := NextDueDate(template, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), *next)
+
+	// After this month's due day: rolls to next month
+	next = NextDueDate(template, time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC), *next)
+}
+
+func TestNextDueDate_ClampsToShortMonth(t *testing.T) {
+	template := &domain.RecurringTemplate{
+		ID:        1,
+		Frequency: "monthly",
+		StartDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	next := NextDueDate(template, time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), *next)
+}
+
+func TestNextDueDate_InactiveTemplateIsNil(t *testing.T) {
+	endDate := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	template := &domain.RecurringTemplate{
+		ID:        1,
+		Frequency: "monthly",
+		StartDate: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC),
+		EndDate:   &endDate,
+	}
+
+	assert.Nil(t, NextDueDate(template, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGetTransactionsByRecurring(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 1, WorkspaceID: workspaceID, Description: "Rent", Frequency: "monthly"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 2, WorkspaceID: workspaceID, Description: "Gym", Frequency: "monthly"})
+
+	addTx := func(id int32, templateID *int32, date time.Time, projected bool) {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              id,
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Generated",
+			Type:            domain.TransactionTypeExpense,
+			Amount:          decimal.NewFromInt(100),
+			TransactionDate: date,
+			TemplateID:      templateID,
+			IsProjected:     projected,
+		})
+	}
+	addTx(100, int32Ptr(1), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), false)
+	addTx(101, int32Ptr(1), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), true)
+	addTx(102, int32Ptr(2), time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), false)
+	addTx(103, nil, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), false)
+
+	transactions, err := service.GetTransactionsByRecurring(workspaceID, 1)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	// Newest first
+	assert.Equal(t, int32(101), transactions[0].ID)
+	assert.Equal(t, int32(100), transactions[1].ID)
+
+	_, err = service.GetTransactionsByRecurring(workspaceID, 99)
+	assert.ErrorIs(t, err, domain.ErrRecurringTemplateNotFound)
+}
+
+func TestPreviewGeneration(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month()+1, 10, 0, 0, 0, 0, time.UTC)
+
+	// Created through the service, so its projections are already generated
+	generated, err := service.CreateTemplate(workspaceID, domain.CreateRecurringTemplateInput{
+		WorkspaceID: workspaceID,
+		Description: "Gym",
+		Amount:      decimal.NewFromInt(50),
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   startDate,
+	})
+	require.NoError(t, err)
+
+	// Active but never generated
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          50,
+		WorkspaceID: workspaceID,
+		Description: "Streaming",
+		Amount:      decimal.NewFromInt(15),
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   time.Date(now.Year(), now.Month()+1, 28, 0, 0, 0, 0, time.UTC),
+	})
+
+	before, err := transactionRepo.GetByTemplate(workspaceID, 50)
+	require.NoError(t, err)
+
+	target := startDate.AddDate(0, 2, 0)
+	preview, err := service.PreviewGeneration(workspaceID, target.Year(), target.Month())
+	require.NoError(t, err)
+
+	require.Len(t, preview.WouldCreate, 1)
+	created := preview.WouldCreate[0]
+	assert.Equal(t, int32(50), created.TemplateID)
+	assert.Equal(t, "Streaming", created.Name)
+	assert.True(t, created.Amount.Equal(decimal.NewFromInt(15)))
+	assert.Equal(t, time.Date(target.Year(), target.Month(), 28, 0, 0, 0, 0, time.UTC), created.Date)
+
+	require.Len(t, preview.WouldSkip, 1)
+	assert.Equal(t, generated.ID, preview.WouldSkip[0].TemplateID)
+	assert.Equal(t, domain.GenerateSkipAlreadyGenerated, preview.WouldSkip[0].Reason)
+
+	// Nothing is persisted
+	after, err := transactionRepo.GetByTemplate(workspaceID, 50)
+	require.NoError(t, err)
+	assert.Len(t, after, len(before))
+
+	// A month before the template starts is reported as such
+	early := startDate.AddDate(0, -2, 0)
+	preview, err = service.PreviewGeneration(workspaceID, early.Year(), early.Month())
+	require.NoError(t, err)
+	assert.Empty(t, preview.WouldCreate)
+	for _, skip := range preview.WouldSkip {
+		assert.Equal(t, domain.GenerateSkipNotStarted, skip.Reason)
+	}
+}
+
+func TestGenerateAhead_GeneratesThroughHorizon(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+	service.SetWorkspaceRepository(workspaceRepo)
+
+	workspaceID := int32(1)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: workspaceID, GenerationHorizonMonths: 3}, "")
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+
+	// Starts next month, so the horizon covers its first three occurrences
+	now := time.Now()
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          50,
+		WorkspaceID: workspaceID,
+		Description: "Streaming",
+		Amount:      decimal.NewFromInt(15),
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   time.Date(now.Year(), now.Month()+1, 10, 0, 0, 0, 0, time.UTC),
+	})
+
+	result, err := service.GenerateAhead(workspaceID)
+	require.NoError(t, err)
+
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, currentMonth.Format("2006-01"), result.FromMonth)
+	assert.Equal(t, currentMonth.AddDate(0, 3, 0).Format("2006-01"), result.ToMonth)
+	require.Len(t, result.Created, 3)
+	assert.Equal(t, time.Date(now.Year(), now.Month()+3, 10, 0, 0, 0, 0, time.UTC), result.Created[2].Date)
+
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 50)
+	require.NoError(t, err)
+	assert.Len(t, projections, 3)
+
+	// Running again creates nothing new
+	result, err = service.GenerateAhead(workspaceID)
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Len(t, result.Skipped, 3)
+	projections, err = transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 50)
+	require.NoError(t, err)
+	assert.Len(t, projections, 3)
+}
+
+func TestGenerateAhead_SkipsAlreadyGenerated(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+	service.SetWorkspaceRepository(workspaceRepo)
+
+	workspaceID := int32(1)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: workspaceID, GenerationHorizonMonths: 3}, "")
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+
+	now := time.Now()
+	templateID := int32(50)
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          templateID,
+		WorkspaceID: workspaceID,
+		Description: "Streaming",
+		Amount:      decimal.NewFromInt(15),
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   time.Date(now.Year(), now.Month()+1, 10, 0, 0, 0, 0, time.UTC),
+	})
+	existingDate := time.Date(now.Year(), now.Month()+2, 10, 0, 0, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              900,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Streaming",
+		Amount:          decimal.NewFromInt(15),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: existingDate,
+		TemplateID:      &templateID,
+		IsProjected:     true,
+	})
+
+	result, err := service.GenerateAhead(workspaceID)
+	require.NoError(t, err)
+
+	require.Len(t, result.Created, 2)
+	for _, created := range result.Created {
+		assert.NotEqual(t, existingDate, created.Date)
+	}
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, domain.GenerateSkipAlreadyGenerated, result.Skipped[0].Reason)
+	assert.Equal(t, existingDate, result.Skipped[0].Date)
+
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, templateID)
+	require.NoError(t, err)
+	assert.Len(t, projections, 3)
+}
+
+func TestSkipOccurrence(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	exclusionRepo := testutil.NewMockProjectionExclusionRepository()
+	service := NewRecurringTemplateService(templateRepo, transactionRepo, accountRepo, categoryRepo)
+	service.SetExclusionRepository(exclusionRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Checking"})
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month()+1, 10, 0, 0, 0, 0, time.UTC)
+	template, err := service.CreateTemplate(workspaceID, domain.CreateRecurringTemplateInput{
+		WorkspaceID: workspaceID,
+		Description: "Gym",
+		Amount:      decimal.NewFromInt(50),
+		AccountID:   1,
+		Frequency:   "monthly",
+		StartDate:   startDate,
+	})
+	require.NoError(t, err)
+
+	skipped := startDate.AddDate(0, 1, 0)
+	projectionMonths := func() map[string]bool {
+		projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, template.ID)
+		require.NoError(t, err)
+		months := make(map[string]bool)
+		for _, p := range projections {
+			months[p.TransactionDate.Format("2006-01")] = true
+		}
+		return months
+	}
+	require.True(t, projectionMonths()[skipped.Format("2006-01")])
+
+	require.NoError(t, service.SkipOccurrence(workspaceID, template.ID, skipped.Year(), skipped.Month()))
+
+	// Regeneration leaves the skipped month empty while neighbouring months still generate
+	require.NoError(t, service.generateProjections(workspaceID, template))
+	months := projectionMonths()
+	assert.False(t, months[skipped.Format("2006-01")], "skipped month should not be generated")
+	assert.True(t, months[startDate.Format("2006-01")])
+	assert.True(t, months[skipped.AddDate(0, 1, 0).Format("2006-01")])
+
+	updated, err := templateRepo.GetByID(workspaceID, template.ID)
+	require.NoError(t, err)
+	assert.Nil(t, updated.EndDate, "skipping must not end the template")
+
+	// Unskipping restores the occurrence
+	require.NoError(t, service.UnskipOccurrence(workspaceID, template.ID, skipped.Year(), skipped.Month()))
+	assert.True(t, projectionMonths()[skipped.Format("2006-01")])
+
+	assert.ErrorIs(t, service.SkipOccurrence(workspaceID, 99, skipped.Year(), skipped.Month()), domain.ErrRecurringTemplateNotFound)
+	assert.ErrorIs(t, service.SkipOccurrence(workspaceID, template.ID, 2026, 13), domain.ErrInvalidOccurrenceMonth)
+}
+
+func TestGetSubscriptions_NormalizesToAnnualTotal(t *testing.T) {
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
+	service := NewRecurringTemplateService(templateRepo, testutil.NewMockTransactionRepository(), testutil.NewMockAccountRepository(), testutil.NewMockBudgetCategoryRepository())
+
+	workspaceID := int32(1)
+	ended := time.Now().AddDate(0, -1, 0)
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 1, WorkspaceID: workspaceID, Description: "Streaming", Amount: decimal.NewFromInt(30), Frequency: "monthly"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 2, WorkspaceID: workspaceID, Description: "Domain", Amount: decimal.NewFromInt(120), Frequency: "yearly"})
+	templateRepo.AddTemplate(&domain.RecurringTemplate{ID: 3, WorkspaceID: workspaceID, Description: "Cancelled", Amount: decimal.NewFromInt(50), Frequency: "monthly", EndDate: &ended})
+
+	summary, err := service.GetSubscriptions(workspaceID)
+
+	require.NoError(t, err)
+	require.Len(t, summary.Subscriptions, 2)
+	costs := map[int32]*domain.Subscription{}
+	for _, sub := range summary.Subscriptions {
+		costs[sub.TemplateID] = sub
+	}
+	assert.True(t, costs[1].MonthlyCost.Equal(decimal.NewFromInt(30)))
+	assert.True(t, costs[1].AnnualCost.Equal(decimal.NewFromInt(360)))
+	assert.True(t, costs[2].MonthlyCost.Equal(decimal.NewFromInt(10)))
+	assert.True(t, costs[2].AnnualCost.Equal(decimal.NewFromInt(120)))
+	assert.True(t, summary.AnnualTotal.Equal(decimal.NewFromInt(480)))
+	assert.True(t, summary.MonthlyTotal.Equal(decimal.NewFromInt(40)))
+}
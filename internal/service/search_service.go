@@ -0,0 +1,96 @@
+package service
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+)
+
+// SearchService handles workspace-wide search across transactions, loans, and categories
+type SearchService struct {
+	transactionRepo domain.TransactionRepository
+	loanRepo        domain.LoanRepository
+	categoryRepo    domain.BudgetCategoryRepository
+}
+
+// NewSearchService creates a new SearchService
+func NewSearchService(transactionRepo domain.TransactionRepository, loanRepo domain.LoanRepository, categoryRepo domain.BudgetCategoryRepository) *SearchService {
+	return &SearchService{
+		transactionRepo: transactionRepo,
+		loanRepo:        loanRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// GlobalSearch returns case-insensitive substring matches grouped by type.
+// Each type is capped at MaxSearchResultsPerType and the combined total at limit
+// (DefaultSearchLimit when <= 0, at most MaxSearchLimit). When the total cap bites,
+// categories and loans are kept ahead of transactions since there are far fewer of them.
+func (s *SearchService) GlobalSearch(workspaceID int32, query string, limit int) (*domain.SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.ErrEmptySearchQuery
+	}
+	if utf8.RuneCountInString(query) > domain.MaxSearchQueryLength {
+		return nil, domain.ErrSearchQueryTooLong
+	}
+
+	if limit <= 0 {
+		limit = domain.DefaultSearchLimit
+	}
+	if limit > domain.MaxSearchLimit {
+		limit = domain.MaxSearchLimit
+	}
+	remaining := limit
+	take := func() int {
+		return min(remaining, domain.MaxSearchResultsPerType)
+	}
+
+	needle := strings.ToLower(query)
+	results := &domain.SearchResults{
+		Query:        query,
+		Transactions: []*domain.Transaction{},
+		Loans:        []*domain.Loan{},
+		Categories:   []*domain.BudgetCategory{},
+	}
+
+	categories, err := s.categoryRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if len(results.Categories) == take() {
+			break
+		}
+		if strings.Contains(strings.ToLower(category.Name), needle) {
+			results.Categories = append(results.Categories, category)
+		}
+	}
+	remaining -= len(results.Categories)
+
+	loans, err := s.loanRepo.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, loan := range loans {
+		if len(results.Loans) == take() {
+			break
+		}
+		if strings.Contains(strings.ToLower(loan.ItemName), needle) ||
+			(loan.Notes != nil && strings.Contains(strings.ToLower(*loan.Notes), needle)) {
+			results.Loans = append(results.Loans, loan)
+		}
+	}
+	remaining -= len(results.Loans)
+
+	if n := take(); n > 0 {
+		transactions, err := s.transactionRepo.Search(workspaceID, query, int32(n))
+		if err != nil {
+			return nil, err
+		}
+		results.Transactions = transactions
+	}
+
+	return results, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/shopspring/decimal"
+)
+
+func TestGlobalSearch_GroupsMatchesByType(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	searchService := NewSearchService(transactionRepo, loanRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: 1, WorkspaceID: workspaceID, ItemName: "iPhone 17 Pro", TotalAmount: decimal.NewFromInt(1200)})
+	loanRepo.AddLoan(&domain.Loan{ID: 2, WorkspaceID: workspaceID, ItemName: "Laptop", TotalAmount: decimal.NewFromInt(2000)})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 1, WorkspaceID: workspaceID, Name: "Phone case",
+		Amount: decimal.NewFromInt(25), TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 2, WorkspaceID: workspaceID, Name: "Groceries",
+		Amount: decimal.NewFromInt(80), TransactionDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+	})
+	// Other workspace must not leak into results
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID: 3, WorkspaceID: 2, Name: "Phone bill",
+		Amount: decimal.NewFromInt(40), TransactionDate: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+	})
+	categoryRepo.Create(&domain.BudgetCategory{WorkspaceID: workspaceID, Name: "Utilities"})
+
+	results, err := searchService.GlobalSearch(workspaceID, "  PHONE ", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if results.Query != "PHONE" {
+		t.Errorf("Expected trimmed query 'PHONE', got %q", results.Query)
+	}
+	if len(results.Loans) != 1 || results.Loans[0].ID != 1 {
+		t.Errorf("Expected loan 1 to match, got %v", results.Loans)
+	}
+	if len(results.Transactions) != 1 || results.Transactions[0].ID != 1 {
+		t.Errorf("Expected transaction 1 to match, got %v", results.Transactions)
+	}
+	if len(results.Categories) != 0 {
+		t.Errorf("Expected no category matches, got %d", len(results.Categories))
+	}
+	if results.Total() != 2 {
+		t.Errorf("Expected 2 total results, got %d", results.Total())
+	}
+}
+
+func TestGlobalSearch_CapsResults(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	searchService := NewSearchService(transactionRepo, loanRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	for i := 1; i <= 15; i++ {
+		loanRepo.AddLoan(&domain.Loan{ID: int32(i), WorkspaceID: workspaceID, ItemName: fmt.Sprintf("Phone %d", i)})
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID: int32(i), WorkspaceID: workspaceID, Name: fmt.Sprintf("Phone payment %d", i),
+			Amount: decimal.NewFromInt(10), TransactionDate: time.Date(2026, 1, i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+
+	results, err := searchService.GlobalSearch(workspaceID, "phone", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results.Loans) != domain.MaxSearchResultsPerType {
+		t.Errorf("Expected %d loans, got %d", domain.MaxSearchResultsPerType, len(results.Loans))
+	}
+	if len(results.Transactions) != domain.MaxSearchResultsPerType {
+		t.Errorf("Expected %d transactions, got %d", domain.MaxSearchResultsPerType, len(results.Transactions))
+	}
+
+	results, err = searchService.GlobalSearch(workspaceID, "phone", 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if results.Total() != 12 {
+		t.Errorf("Expected total capped at 12, got %d", results.Total())
+	}
+	if len(results.Loans) != 10 || len(results.Transactions) != 2 {
+		t.Errorf("Expected 10 loans and 2 transactions, got %d and %d", len(results.Loans), len(results.Transactions))
+	}
+	// Newest transactions come first
+	if results.Transactions[0].ID != 15 {
+		t.Errorf("Expected newest transaction first, got %d", results.Transactions[0].ID)
+	}
+}
+
+func TestGlobalSearch_EmptyQuery(t *testing.T) {
+	searchService := NewSearchService(testutil.NewMockTransactionRepository(), testutil.NewMockLoanRepository(), testutil.NewMockBudgetCategoryRepository())
+
+	_, err := searchService.GlobalSearch(1, "   ", 0)
+	if err != domain.ErrEmptySearchQuery {
+		t.Errorf("Expected ErrEmptySearchQuery, got %v", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 type SettlementService struct {
 	transactionRepo domain.TransactionRepository
 	accountRepo     domain.AccountRepository
+	loanRepo        domain.LoanRepository
 	eventPublisher  websocket.EventPublisher
 }
 
@@ -24,6 +25,11 @@ func NewSettlementService(transactionRepo domain.TransactionRepository, accountR
 	}
 }
 
+// SetLoanRepository sets the loan repository used to keep loan completion in sync when settling
+func (s *SettlementService) SetLoanRepository(loanRepo domain.LoanRepository) {
+	s.loanRepo = loanRepo
+}
+
 // SetEventPublisher sets the event publisher for real-time updates
 func (s *SettlementService) SetEventPublisher(publisher websocket.EventPublisher) {
 	s.eventPublisher = publisher
@@ -138,6 +144,11 @@ func (s *SettlementService) Settle(workspaceID int32, input domain.SettlementInp
 		return nil, domain.ErrTransactionsNotFound
 	}
 
+	// Settling a loan's last billed payment completes the loan
+	if err := syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, transactionLoanIDs(transactions)); err != nil {
+		return nil, err
+	}
+
 	// 8. Build settlement result
 	result := &domain.SettlementResult{
 		TransferID:   createdTransfer.ID,
@@ -632,3 +632,59 @@ func TestSettlementService_Settle_PartialSettleCountMismatch(t *testing.T) {
 		t.Errorf("expected ErrTransactionsNotFound for count mismatch, got %v", err)
 	}
 }
+
+func TestSettlementService_Settle_CompletesLoanOnLastPayment(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	loanRepo := testutil.NewMockLoanRepository()
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Template: domain.TemplateBank})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Template: domain.TemplateCreditCard})
+
+	loanID := int32(1)
+	loanRepo.AddLoan(&domain.Loan{ID: loanID, WorkspaceID: 1, ItemName: "Phone", NumMonths: 2, AccountID: 2})
+
+	billedState := domain.CCStateBilled
+	deferredIntent := domain.SettlementIntentDeferred
+	// First installment already settled, the second is billed and due
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:          1,
+		WorkspaceID: 1,
+		AccountID:   2,
+		Name:        "Phone installment",
+		Amount:      decimal.NewFromInt(100),
+		Type:        domain.TransactionTypeExpense,
+		IsPaid:      true,
+		LoanID:      &loanID,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:               2,
+		WorkspaceID:      1,
+		AccountID:        2,
+		Name:             "Phone installment",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		CCState:          &billedState,
+		SettlementIntent: &deferredIntent,
+		LoanID:           &loanID,
+	})
+
+	transactionRepo.NextID = 3 // Keep the settlement transfer clear of the installment IDs
+
+	service := NewSettlementService(transactionRepo, accountRepo)
+	service.SetLoanRepository(loanRepo)
+
+	_, err := service.Settle(1, domain.SettlementInput{
+		TransactionIDs:    []int32{2},
+		SourceAccountID:   1,
+		TargetCCAccountID: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loan, _ := loanRepo.GetByID(1, loanID)
+	if loan.CompletedAt == nil {
+		t.Error("expected CompletedAt to be set once the last installment is settled")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/websocket"
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 )
 
 // WebSocket event payloads for transaction group operations
@@ -193,6 +194,82 @@ func (s *TransactionGroupService) AddTransactionsToGroup(workspaceID int32, grou
 	return updated, nil
 }
 
+// AddMonthTransactionsToGroup assigns every ungrouped transaction in the group's month that
+// matches filter to the group and returns how many were added
+func (s *TransactionGroupService) AddMonthTransactionsToGroup(workspaceID int32, groupID int32, filter domain.TransactionFilter) (int, error) {
+	if filter.IsEmpty() {
+		return 0, domain.ErrEmptyGroupFilter
+	}
+
+	// Validate group exists and belongs to workspace
+	group, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	if err != nil {
+		return 0, err
+	}
+
+	monthStart, err := time.Parse("2006-01", group.Month)
+	if err != nil {
+		return 0, domain.ErrInvalidMonthFormat
+	}
+	candidates, err := s.transactionGroupRepo.GetUngroupedTransactionsByMonth(workspaceID, monthStart, monthStart.AddDate(0, 1, 0))
+	if err != nil {
+		return 0, err
+	}
+
+	var providerTxIDs map[int32]bool
+	if filter.LoanProviderID != nil {
+		ids, err := s.transactionGroupRepo.GetUngroupedTransactionIDsByProviderMonth(workspaceID, *filter.LoanProviderID, group.Month)
+		if err != nil {
+			return 0, err
+		}
+		providerTxIDs = make(map[int32]bool, len(ids))
+		for _, id := range ids {
+			providerTxIDs[id] = true
+		}
+	}
+
+	transactionIDs := []int32{}
+	for _, tx := range candidates {
+		// Same month and ungrouped checks as AddTransactionsToGroup
+		if tx.GroupID != nil || tx.TransactionDate.Format("2006-01") != group.Month {
+			continue
+		}
+		if providerTxIDs != nil && !providerTxIDs[tx.ID] {
+			continue
+		}
+		if filter.Matches(tx) {
+			transactionIDs = append(transactionIDs, tx.ID)
+		}
+	}
+	if len(transactionIDs) == 0 {
+		return 0, nil
+	}
+
+	if err := s.transactionGroupRepo.AssignGroupToTransactions(workspaceID, groupID, transactionIDs); err != nil {
+		return 0, err
+	}
+
+	// Fetch updated group with recalculated totals
+	updated, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().
+		Int32("workspace_id", workspaceID).
+		Int32("group_id", groupID).
+		Int("added_count", len(transactionIDs)).
+		Msg("Transactions added to group by filter")
+
+	s.publishEvent(workspaceID, websocket.TransactionGroupChildrenChanged(GroupChildrenChangedPayload{
+		ID:          updated.ID,
+		ChildCount:  updated.ChildCount,
+		TotalAmount: updated.TotalAmount.StringFixed(2),
+	}))
+
+	return len(transactionIDs), nil
+}
+
 // RemoveTransactionsFromGroup removes transactions from a group and auto-deletes if empty
 func (s *TransactionGroupService) RemoveTransactionsFromGroup(workspaceID int32, groupID int32, transactionIDs []int32) (*domain.TransactionGroup, bool, error) {
 	// Validate group exists and belongs to workspace
@@ -304,14 +381,36 @@ func (s *TransactionGroupService) UngroupGroup(workspaceID int32, groupID int32)
 	}, nil
 }
 
-// DeleteGroupWithChildren atomically soft-deletes all children and hard-deletes the group
-func (s *TransactionGroupService) DeleteGroupWithChildren(workspaceID int32, groupID int32) (*domain.GroupOperationResult, error) {
+// GetGroupDeletePreview returns the child count and total that DeleteGroupWithChildren would remove.
+// Clients echo the child count back as confirmation when deleting.
+func (s *TransactionGroupService) GetGroupDeletePreview(workspaceID int32, groupID int32) (*domain.GroupDeletePreview, error) {
+	group, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GroupDeletePreview{
+		GroupID:     group.ID,
+		ChildCount:  group.ChildCount,
+		TotalAmount: group.TotalAmount,
+	}, nil
+}
+
+// DeleteGroupWithChildren atomically soft-deletes all children and hard-deletes the group.
+// confirmChildCount must match the group's current child count (see GetGroupDeletePreview),
+// otherwise ErrDeleteConfirmationMismatch is returned and nothing is deleted.
+func (s *TransactionGroupService) DeleteGroupWithChildren(workspaceID int32, groupID int32, confirmChildCount int32) (*domain.GroupOperationResult, error) {
 	// Validate group exists and belongs to workspace
-	_, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	group, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Guard against deleting more than the user confirmed (e.g. children added since the preview)
+	if group.ChildCount != confirmChildCount {
+		return nil, domain.ErrDeleteConfirmationMismatch
+	}
+
 	// Atomic delete: soft-delete children + hard-delete group in single transaction
 	count, err := s.transactionGroupRepo.DeleteGroupAndChildren(workspaceID, groupID)
 	if err != nil {
@@ -337,11 +436,85 @@ func (s *TransactionGroupService) DeleteGroupWithChildren(workspaceID int32, gro
 	}, nil
 }
 
+// RecalculateGroup re-derives ChildCount and TotalAmount from the group's current members
+// and broadcasts the corrected values so clients holding drifted totals resync.
+// An empty group is auto-deleted, returning a nil group.
+func (s *TransactionGroupService) RecalculateGroup(workspaceID int32, groupID int32) (*domain.TransactionGroup, error) {
+	group, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := s.transactionGroupRepo.GetGroupChildren(workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(children) == 0 {
+		if err := s.transactionGroupRepo.Delete(workspaceID, groupID); err != nil {
+			return nil, err
+		}
+
+		log.Info().
+			Int32("workspace_id", workspaceID).
+			Int32("group_id", groupID).
+			Msg("Empty group auto-deleted during recalculation")
+
+		s.publishEvent(workspaceID, websocket.TransactionGroupDeleted(GroupDeletedPayload{
+			ID:   groupID,
+			Mode: "auto_empty",
+		}))
+
+		return nil, nil
+	}
+
+	total := decimal.Zero
+	for _, tx := range children {
+		total = total.Add(tx.Amount)
+	}
+	group.ChildCount = int32(len(children))
+	group.TotalAmount = total
+
+	log.Info().
+		Int32("workspace_id", workspaceID).
+		Int32("group_id", groupID).
+		Int32("child_count", group.ChildCount).
+		Str("total_amount", group.TotalAmount.StringFixed(2)).
+		Msg("Transaction group totals recalculated")
+
+	s.publishEvent(workspaceID, websocket.TransactionGroupChildrenChanged(GroupChildrenChangedPayload{
+		ID:          group.ID,
+		ChildCount:  group.ChildCount,
+		TotalAmount: group.TotalAmount.StringFixed(2),
+	}))
+
+	return group, nil
+}
+
 // GetGroupsByMonth returns all groups for a workspace and month
 func (s *TransactionGroupService) GetGroupsByMonth(workspaceID int32, month string) ([]*domain.TransactionGroup, error) {
 	return s.transactionGroupRepo.GetGroupsByMonth(workspaceID, month)
 }
 
+// GetGroupWithChildren returns a group and its member transactions
+// Returns ErrGroupNotFound when the group does not exist in the workspace
+func (s *TransactionGroupService) GetGroupWithChildren(workspaceID int32, groupID int32) (*domain.GroupDetail, error) {
+	group, err := s.transactionGroupRepo.GetByID(workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := s.transactionGroupRepo.GetGroupChildren(workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if children == nil {
+		children = []*domain.Transaction{}
+	}
+
+	return &domain.GroupDetail{Group: group, Children: children}, nil
+}
+
 // EnsureAutoGroups detects consolidated_monthly providers with >=2 ungrouped transactions
 // in the given month and auto-creates groups for them. This is fire-and-forget:
 // errors are logged but never propagated to the caller.
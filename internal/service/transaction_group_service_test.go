@@ -343,6 +343,102 @@ func TestTransactionGroupService_AddTransactionsToGroup_AlreadyGrouped(t *testin
 	}
 }
 
+// ==================== AddMonthTransactionsToGroup ====================
+
+// ungroupedByMonth mimics the repository query over a fixed set of transactions
+func ungroupedByMonth(transactions []*domain.Transaction) func(int32, time.Time, time.Time) ([]*domain.Transaction, error) {
+	return func(wsID int32, start, end time.Time) ([]*domain.Transaction, error) {
+		var result []*domain.Transaction
+		for _, tx := range transactions {
+			if tx.WorkspaceID == wsID && tx.GroupID == nil && !tx.TransactionDate.Before(start) && tx.TransactionDate.Before(end) {
+				result = append(result, tx)
+			}
+		}
+		return result, nil
+	}
+}
+
+func TestTransactionGroupService_AddMonthTransactionsToGroup_AddsMatchingUngrouped(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Coffee",
+		Month:       "2026-01",
+	})
+
+	groupRepo.GetUngroupedTransactionsByMonthFn = ungroupedByMonth([]*domain.Transaction{
+		{ID: 1, WorkspaceID: 1, Name: "Starbucks Coffee", TransactionDate: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, WorkspaceID: 1, Name: "coffee beans", TransactionDate: time.Date(2026, 1, 28, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, WorkspaceID: 1, Name: "Groceries", TransactionDate: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, WorkspaceID: 1, Name: "Coffee", TransactionDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	var assigned []int32
+	groupRepo.AssignGroupToTransactionsFn = func(wsID int32, gID int32, txIDs []int32) error {
+		assigned = txIDs
+		return nil
+	}
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	name := "COFFEE"
+	added, err := svc.AddMonthTransactionsToGroup(1, 1, domain.TransactionFilter{NameContains: &name})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if added != 2 || len(assigned) != 2 || assigned[0] != 1 || assigned[1] != 2 {
+		t.Errorf("expected transactions 1 and 2 to be added, got %d: %v", added, assigned)
+	}
+}
+
+func TestTransactionGroupService_AddMonthTransactionsToGroup_SkipsAlreadyGrouped(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Card",
+		Month:       "2026-01",
+	})
+
+	otherGroupID := int32(2)
+	accountID := int32(7)
+	groupRepo.GetUngroupedTransactionsByMonthFn = ungroupedByMonth([]*domain.Transaction{
+		{ID: 1, WorkspaceID: 1, AccountID: accountID, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), GroupID: &otherGroupID},
+		{ID: 2, WorkspaceID: 1, AccountID: accountID, TransactionDate: time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)},
+	})
+	var assigned []int32
+	groupRepo.AssignGroupToTransactionsFn = func(wsID int32, gID int32, txIDs []int32) error {
+		assigned = txIDs
+		return nil
+	}
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	added, err := svc.AddMonthTransactionsToGroup(1, 1, domain.TransactionFilter{AccountID: &accountID})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if added != 1 || len(assigned) != 1 || assigned[0] != 2 {
+		t.Errorf("expected only the ungrouped transaction to be added, got %d: %v", added, assigned)
+	}
+}
+
+func TestTransactionGroupService_AddMonthTransactionsToGroup_EmptyFilter(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	_, err := svc.AddMonthTransactionsToGroup(1, 1, domain.TransactionFilter{})
+	if err != domain.ErrEmptyGroupFilter {
+		t.Errorf("expected ErrEmptyGroupFilter, got %v", err)
+	}
+}
+
 // ==================== RemoveTransactionsFromGroup ====================
 
 func TestTransactionGroupService_RemoveTransactionsFromGroup_Success(t *testing.T) {
@@ -663,7 +759,7 @@ func TestTransactionGroupService_DeleteGroupWithChildren_Success(t *testing.T) {
 
 	svc := NewTransactionGroupService(groupRepo, transactionRepo)
 
-	result, err := svc.DeleteGroupWithChildren(1, 1)
+	result, err := svc.DeleteGroupWithChildren(1, 1, 5)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -684,7 +780,7 @@ func TestTransactionGroupService_DeleteGroupWithChildren_NotFound(t *testing.T)
 
 	svc := NewTransactionGroupService(groupRepo, transactionRepo)
 
-	_, err := svc.DeleteGroupWithChildren(1, 999)
+	_, err := svc.DeleteGroupWithChildren(1, 999, 0)
 	if err != domain.ErrGroupNotFound {
 		t.Errorf("expected ErrGroupNotFound, got %v", err)
 	}
@@ -703,12 +799,68 @@ func TestTransactionGroupService_DeleteGroupWithChildren_WrongWorkspace(t *testi
 
 	svc := NewTransactionGroupService(groupRepo, transactionRepo)
 
-	_, err := svc.DeleteGroupWithChildren(1, 1)
+	_, err := svc.DeleteGroupWithChildren(1, 1, 0)
 	if err != domain.ErrGroupNotFound {
 		t.Errorf("expected ErrGroupNotFound, got %v", err)
 	}
 }
 
+func TestTransactionGroupService_GetGroupDeletePreview(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Preview Me",
+		Month:       "2026-01",
+		ChildCount:  4,
+		TotalAmount: decimal.NewFromFloat(180.25),
+	})
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	preview, err := svc.GetGroupDeletePreview(1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if preview.ChildCount != 4 {
+		t.Errorf("expected childCount 4, got %d", preview.ChildCount)
+	}
+	if !preview.TotalAmount.Equal(decimal.NewFromFloat(180.25)) {
+		t.Errorf("expected totalAmount 180.25, got %s", preview.TotalAmount)
+	}
+}
+
+func TestTransactionGroupService_DeleteGroupWithChildren_ConfirmationMismatch(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Grew Since Preview",
+		Month:       "2026-01",
+		ChildCount:  6,
+	})
+
+	deleteCalled := false
+	groupRepo.DeleteGroupAndChildrenFn = func(wsID int32, gID int32) (int32, error) {
+		deleteCalled = true
+		return 6, nil
+	}
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	_, err := svc.DeleteGroupWithChildren(1, 1, 5)
+	if err != domain.ErrDeleteConfirmationMismatch {
+		t.Errorf("expected ErrDeleteConfirmationMismatch, got %v", err)
+	}
+	if deleteCalled {
+		t.Error("expected nothing to be deleted on a confirmation mismatch")
+	}
+}
+
 // ==================== EnsureAutoGroups ====================
 
 func TestTransactionGroupService_EnsureAutoGroups_CreatesNewGroup(t *testing.T) {
@@ -1018,7 +1170,7 @@ func TestTransactionGroupService_DeleteGroupWithChildren_PublishesDeletedEvent(t
 	svc := NewTransactionGroupService(groupRepo, transactionRepo)
 	svc.SetEventPublisher(mockPublisher)
 
-	_, err := svc.DeleteGroupWithChildren(1, 1)
+	_, err := svc.DeleteGroupWithChildren(1, 1, 3)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -1272,3 +1424,150 @@ func TestTransactionGroupService_CreateGroup_WrongWorkspace(t *testing.T) {
 		t.Errorf("expected ErrTransactionNotFound, got %v", err)
 	}
 }
+
+func TestTransactionGroupService_GetGroupWithChildren_Success(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Atome",
+		Month:       "2026-01",
+		ChildCount:  3,
+		TotalAmount: decimal.NewFromFloat(90.00),
+	})
+	groupID := int32(1)
+	groupRepo.SetGroupChildren(1, []*domain.Transaction{
+		{ID: 1, WorkspaceID: 1, Amount: decimal.NewFromFloat(40.00), GroupID: &groupID},
+		{ID: 2, WorkspaceID: 1, Amount: decimal.NewFromFloat(30.00), GroupID: &groupID},
+		{ID: 3, WorkspaceID: 1, Amount: decimal.NewFromFloat(20.00), GroupID: &groupID},
+	})
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	detail, err := svc.GetGroupWithChildren(1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if detail.Group.ID != 1 || detail.Group.Name != "Atome" {
+		t.Errorf("expected group 1 'Atome', got %d %q", detail.Group.ID, detail.Group.Name)
+	}
+	if len(detail.Children) != 3 {
+		t.Fatalf("expected 3 children, got %d", len(detail.Children))
+	}
+	for i, child := range detail.Children {
+		if child.ID != int32(i+1) {
+			t.Errorf("expected child %d to have ID %d, got %d", i, i+1, child.ID)
+		}
+	}
+}
+
+func TestTransactionGroupService_GetGroupWithChildren_ForeignWorkspace(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 2,
+		Name:        "Other workspace",
+		Month:       "2026-01",
+	})
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	_, err := svc.GetGroupWithChildren(1, 1)
+	if err != domain.ErrGroupNotFound {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestTransactionGroupService_RecalculateGroup_CorrectsStaleTotals(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	mockPublisher := testutil.NewMockEventPublisher()
+
+	// Stored totals have drifted from the actual members
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Atome",
+		Month:       "2026-01",
+		ChildCount:  5,
+		TotalAmount: decimal.NewFromFloat(999.00),
+	})
+	groupID := int32(1)
+	groupRepo.SetGroupChildren(1, []*domain.Transaction{
+		{ID: 1, WorkspaceID: 1, Amount: decimal.NewFromFloat(40.00), GroupID: &groupID},
+		{ID: 2, WorkspaceID: 1, Amount: decimal.NewFromFloat(25.50), GroupID: &groupID},
+	})
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+	svc.SetEventPublisher(mockPublisher)
+
+	group, err := svc.RecalculateGroup(1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if group.ChildCount != 2 {
+		t.Errorf("expected childCount 2, got %d", group.ChildCount)
+	}
+	if !group.TotalAmount.Equal(decimal.NewFromFloat(65.50)) {
+		t.Errorf("expected totalAmount 65.50, got %s", group.TotalAmount.StringFixed(2))
+	}
+
+	if len(mockPublisher.Events) != 1 {
+		t.Fatalf("expected 1 event published, got %d", len(mockPublisher.Events))
+	}
+	if mockPublisher.Events[0].Event.Type != "transaction_group.children_changed" {
+		t.Errorf("expected event type 'transaction_group.children_changed', got %q", mockPublisher.Events[0].Event.Type)
+	}
+}
+
+func TestTransactionGroupService_RecalculateGroup_AutoDeletesEmptyGroup(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	mockPublisher := testutil.NewMockEventPublisher()
+
+	groupRepo.AddGroup(&domain.TransactionGroup{
+		ID:          1,
+		WorkspaceID: 1,
+		Name:        "Stale",
+		Month:       "2026-01",
+		ChildCount:  2,
+		TotalAmount: decimal.NewFromFloat(80.00),
+	})
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+	svc.SetEventPublisher(mockPublisher)
+
+	group, err := svc.RecalculateGroup(1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if group != nil {
+		t.Errorf("expected nil group after auto-delete, got %+v", group)
+	}
+	if _, exists := groupRepo.Groups[1]; exists {
+		t.Error("expected empty group to be deleted")
+	}
+
+	if len(mockPublisher.Events) != 1 {
+		t.Fatalf("expected 1 event published, got %d", len(mockPublisher.Events))
+	}
+	if mockPublisher.Events[0].Event.Type != "transaction_group.deleted" {
+		t.Errorf("expected event type 'transaction_group.deleted', got %q", mockPublisher.Events[0].Event.Type)
+	}
+}
+
+func TestTransactionGroupService_RecalculateGroup_NotFound(t *testing.T) {
+	groupRepo := testutil.NewMockTransactionGroupRepository()
+	transactionRepo := testutil.NewMockTransactionRepository()
+
+	svc := NewTransactionGroupService(groupRepo, transactionRepo)
+
+	_, err := svc.RecalculateGroup(1, 999)
+	if err != domain.ErrGroupNotFound {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// ImportRow represents a single parsed row of a transaction import
+type ImportRow struct {
+	AccountID int32
+	Name      string
+	Amount    decimal.Decimal
+	Type      domain.TransactionType
+	Date      time.Time
+	Notes     *string
+	// TransferAccountID is the counterpart account from the transfer column, if present
+	TransferAccountID *int32
+}
+
+// DetectTransferPairs merges matching outflow/inflow rows into transfers
+// Rows pair when amount and date match and the accounts are flipped; each row
+// joins at most one transfer so overlapping legs are never double-counted.
+// Returns the detected transfers and the rows that should import normally.
+func DetectTransferPairs(rows []ImportRow) ([]CreateTransferInput, []ImportRow) {
+	transfers := []CreateTransferInput{}
+	matched := make([]bool, len(rows))
+
+	for i, out := range rows {
+		if matched[i] || out.Type != domain.TransactionTypeExpense || out.TransferAccountID == nil {
+			continue
+		}
+		for j, in := range rows {
+			if matched[j] || i == j || !isTransferCounterpart(out, in) {
+				continue
+			}
+			matched[i], matched[j] = true, true
+			transfers = append(transfers, CreateTransferInput{
+				FromAccountID: out.AccountID,
+				ToAccountID:   in.AccountID,
+				Amount:        out.Amount,
+				Date:          out.Date,
+				Notes:         out.Notes,
+			})
+			break
+		}
+	}
+
+	remaining := []ImportRow{}
+	for i, row := range rows {
+		if !matched[i] {
+			remaining = append(remaining, row)
+		}
+	}
+
+	return transfers, remaining
+}
+
+// isTransferCounterpart reports whether in is the inflow leg matching the outflow row out
+func isTransferCounterpart(out, in ImportRow) bool {
+	if in.Type != domain.TransactionTypeIncome || in.TransferAccountID == nil {
+		return false
+	}
+	if in.AccountID != *out.TransferAccountID || *in.TransferAccountID != out.AccountID {
+		return false
+	}
+	if in.AccountID == out.AccountID || !in.Amount.Equal(out.Amount) {
+		return false
+	}
+	return !domain.IsAfterDay(in.Date, out.Date) && !domain.IsAfterDay(out.Date, in.Date)
+}
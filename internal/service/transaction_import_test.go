@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestDetectTransferPairs_MergesMatchingRows(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	rows := []ImportRow{
+		{AccountID: 1, Name: "To savings", Amount: decimal.NewFromInt(500), Type: domain.TransactionTypeExpense, Date: date, TransferAccountID: int32Ptr(2)},
+		{AccountID: 3, Name: "Groceries", Amount: decimal.NewFromInt(80), Type: domain.TransactionTypeExpense, Date: date},
+		{AccountID: 2, Name: "From checking", Amount: decimal.NewFromInt(500), Type: domain.TransactionTypeIncome, Date: date.Add(3 * time.Hour), TransferAccountID: int32Ptr(1)},
+	}
+
+	transfers, remaining := DetectTransferPairs(rows)
+
+	if len(transfers) != 1 {
+		t.Fatalf("Expected 1 transfer, got %d", len(transfers))
+	}
+	transfer := transfers[0]
+	if transfer.FromAccountID != 1 || transfer.ToAccountID != 2 {
+		t.Errorf("Expected transfer 1 -> 2, got %d -> %d", transfer.FromAccountID, transfer.ToAccountID)
+	}
+	if !transfer.Amount.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("Expected amount 500, got %s", transfer.Amount.String())
+	}
+
+	if len(remaining) != 1 || remaining[0].Name != "Groceries" {
+		t.Errorf("Expected only Groceries to remain, got %+v", remaining)
+	}
+}
+
+func TestDetectTransferPairs_NonMatchingRowsImportNormally(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	rows := []ImportRow{
+		// Amount differs
+		{AccountID: 1, Name: "Out", Amount: decimal.NewFromInt(500), Type: domain.TransactionTypeExpense, Date: date, TransferAccountID: int32Ptr(2)},
+		{AccountID: 2, Name: "In", Amount: decimal.NewFromInt(450), Type: domain.TransactionTypeIncome, Date: date, TransferAccountID: int32Ptr(1)},
+		// Date differs
+		{AccountID: 1, Name: "Out later", Amount: decimal.NewFromInt(100), Type: domain.TransactionTypeExpense, Date: date, TransferAccountID: int32Ptr(2)},
+		{AccountID: 2, Name: "In later", Amount: decimal.NewFromInt(100), Type: domain.TransactionTypeIncome, Date: date.AddDate(0, 0, 1), TransferAccountID: int32Ptr(1)},
+		// No transfer column
+		{AccountID: 1, Name: "Plain out", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeExpense, Date: date},
+		{AccountID: 2, Name: "Plain in", Amount: decimal.NewFromInt(20), Type: domain.TransactionTypeIncome, Date: date},
+	}
+
+	transfers, remaining := DetectTransferPairs(rows)
+
+	if len(transfers) != 0 {
+		t.Errorf("Expected no transfers, got %d", len(transfers))
+	}
+	if len(remaining) != len(rows) {
+		t.Errorf("Expected all %d rows to remain, got %d", len(rows), len(remaining))
+	}
+}
+
+func TestDetectTransferPairs_OverlappingLegsPairOnce(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	rows := []ImportRow{
+		{AccountID: 1, Name: "Out A", Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeExpense, Date: date, TransferAccountID: int32Ptr(2)},
+		{AccountID: 1, Name: "Out B", Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeExpense, Date: date, TransferAccountID: int32Ptr(2)},
+		{AccountID: 2, Name: "In", Amount: decimal.NewFromInt(200), Type: domain.TransactionTypeIncome, Date: date, TransferAccountID: int32Ptr(1)},
+	}
+
+	transfers, remaining := DetectTransferPairs(rows)
+
+	if len(transfers) != 1 {
+		t.Fatalf("Expected 1 transfer, got %d", len(transfers))
+	}
+	if len(remaining) != 1 || remaining[0].Name != "Out B" {
+		t.Errorf("Expected the unmatched outflow to remain, got %+v", remaining)
+	}
+}
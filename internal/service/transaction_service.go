@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,18 +23,39 @@ type TransactionService struct {
 	templateRepo         domain.RecurringTemplateRepository
 	exclusionRepo        domain.ProjectionExclusionRepository
 	transactionGroupRepo domain.TransactionGroupRepository
+	workspaceRepo        domain.WorkspaceRepository
+	loanRepo             domain.LoanRepository
 	eventPublisher       websocket.EventPublisher
+
+	maxDecimalPlaces int32
+	allowZeroAmount  bool
 }
 
 // NewTransactionService creates a new TransactionService
 func NewTransactionService(transactionRepo domain.TransactionRepository, accountRepo domain.AccountRepository, categoryRepo domain.BudgetCategoryRepository) *TransactionService {
 	return &TransactionService{
-		transactionRepo: transactionRepo,
-		accountRepo:     accountRepo,
-		categoryRepo:    categoryRepo,
+		transactionRepo:  transactionRepo,
+		accountRepo:      accountRepo,
+		categoryRepo:     categoryRepo,
+		maxDecimalPlaces: domain.DefaultMaxDecimalPlaces,
 	}
 }
 
+// SetMaxDecimalPlaces sets the decimal places accepted for transaction amounts
+func (s *TransactionService) SetMaxDecimalPlaces(places int32) {
+	s.maxDecimalPlaces = places
+}
+
+// SetAllowZeroAmount lets transactions be recorded with a zero amount (e.g. placeholders)
+func (s *TransactionService) SetAllowZeroAmount(allow bool) {
+	s.allowZeroAmount = allow
+}
+
+// SetLoanRepository sets the loan repository used to keep loan completion in sync with payments
+func (s *TransactionService) SetLoanRepository(loanRepo domain.LoanRepository) {
+	s.loanRepo = loanRepo
+}
+
 // SetRecurringTemplateRepository sets the template repository for on-access projection generation
 func (s *TransactionService) SetRecurringTemplateRepository(templateRepo domain.RecurringTemplateRepository) {
 	s.templateRepo = templateRepo
@@ -48,6 +71,11 @@ func (s *TransactionService) SetTransactionGroupRepository(groupRepo domain.Tran
 	s.transactionGroupRepo = groupRepo
 }
 
+// SetWorkspaceRepository sets the workspace repository for round-up savings settings
+func (s *TransactionService) SetWorkspaceRepository(workspaceRepo domain.WorkspaceRepository) {
+	s.workspaceRepo = workspaceRepo
+}
+
 // SetEventPublisher sets the event publisher for real-time updates
 func (s *TransactionService) SetEventPublisher(publisher websocket.EventPublisher) {
 	s.eventPublisher = publisher
@@ -62,19 +90,31 @@ func (s *TransactionService) publishEvent(workspaceID int32, event websocket.Eve
 
 // CreateTransactionInput holds the input for creating a transaction
 type CreateTransactionInput struct {
-	AccountID        int32
-	Name             string
-	Amount           decimal.Decimal
-	Type             domain.TransactionType
-	TransactionDate  *time.Time
-	IsPaid           *bool
-	Notes            *string
-	CategoryID       *int32
-	SettlementIntent *domain.SettlementIntent
+	AccountID          int32
+	Name               string
+	Amount             decimal.Decimal
+	Type               domain.TransactionType
+	TransactionDate    *time.Time
+	IsPaid             *bool
+	Notes              *string
+	CategoryID         *int32
+	SettlementIntent   *domain.SettlementIntent
+	IsScheduled        bool // Exclude from balances until TransactionDate (future dates only)
+	Merchant           *string
+	Payee              *string // Counterparty who was paid
+	IsRefund           bool    // Income that reduces spending instead of counting as income
+	ExcludeFromReports bool    // Keep out of insights; balances still include it
+	IsTaxDeductible    *bool   // Optional: defaults to the category's flag if nil
+	// Optional geo metadata; coordinates must be set together
+	Latitude  *decimal.Decimal
+	Longitude *decimal.Decimal
+	Location  *string
 }
 
 // CreateTransaction creates a new transaction with validation
 func (s *TransactionService) CreateTransaction(workspaceID int32, input CreateTransactionInput) (*domain.Transaction, error) {
+	ctx := context.Background()
+
 	// Validate name
 	name := strings.TrimSpace(input.Name)
 	if name == "" {
@@ -84,15 +124,21 @@ func (s *TransactionService) CreateTransaction(workspaceID int32, input CreateTr
 		return nil, domain.ErrNameTooLong
 	}
 
-	// Validate amount (must be positive)
-	if input.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, domain.ErrInvalidAmount
+	// Validate amount (must be positive, or zero when placeholders are allowed)
+	if err := domain.ValidateTransactionAmount(input.Amount, s.allowZeroAmount); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, input.Amount); err != nil {
+		return nil, err
 	}
 
 	// Validate transaction type
 	if input.Type != domain.TransactionTypeIncome && input.Type != domain.TransactionTypeExpense {
 		return nil, domain.ErrInvalidTransactionType
 	}
+	if input.IsRefund && input.Type != domain.TransactionTypeIncome {
+		return nil, domain.ErrInvalidRefund
+	}
 
 	// Validate account exists and belongs to workspace
 	account, err := s.accountRepo.GetByID(workspaceID, input.AccountID)
@@ -124,6 +170,21 @@ func (s *TransactionService) CreateTransaction(workspaceID int32, input CreateTr
 		}
 	}
 
+	merchant, err := normalizeMerchant(input.Merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := normalizePayee(input.Payee)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := normalizeLocation(input.Latitude, input.Longitude, input.Location)
+	if err != nil {
+		return nil, err
+	}
+
 	// Handle CC lifecycle fields
 	// v2 simplified: CCState is computed from isPaid and billedAt
 	// - pending: billedAt IS NULL AND isPaid = false (default for new CC transactions)
@@ -148,11 +209,17 @@ func (s *TransactionService) CreateTransaction(workspaceID int32, input CreateTr
 	// For non-CC accounts, all CC lifecycle fields remain nil
 
 	// Validate category exists and belongs to workspace if provided
+	// The category supplies the tax-deductible default unless the caller set it
+	isTaxDeductible := false
 	if input.CategoryID != nil {
-		_, err := s.categoryRepo.GetByID(workspaceID, *input.CategoryID)
+		category, err := s.categoryRepo.GetByID(ctx, workspaceID, *input.CategoryID)
 		if err != nil {
 			return nil, domain.ErrBudgetCategoryNotFound
 		}
+		isTaxDeductible = category.IsTaxDeductible
+	}
+	if input.IsTaxDeductible != nil {
+		isTaxDeductible = *input.IsTaxDeductible
 	}
 
 	transaction := &domain.Transaction{
@@ -167,32 +234,240 @@ func (s *TransactionService) CreateTransaction(workspaceID int32, input CreateTr
 		CategoryID:       input.CategoryID,
 		SettlementIntent: v2SettlementIntent,
 		// CCState is computed from billedAt and isPaid (nil billedAt + false isPaid = pending)
+		// Scheduling only applies to future-dated transactions
+		IsScheduled:        input.IsScheduled && domain.IsAfterDay(transactionDate, time.Now()),
+		Merchant:           merchant,
+		Payee:              payee,
+		Latitude:           input.Latitude,
+		Longitude:          input.Longitude,
+		Location:           location,
+		IsRefund:           input.IsRefund,
+		ExcludeFromReports: input.ExcludeFromReports,
+		IsTaxDeductible:    isTaxDeductible,
+	}
+
+	// A paid expense's round-up is created in the same database transaction as the expense
+	roundUpFrom, roundUpTo, err := s.roundUpLegs(workspaceID, transaction, account)
+	if err != nil {
+		return nil, err
 	}
 
-	created, err := s.transactionRepo.Create(transaction)
+	var created *domain.Transaction
+	var roundUp *domain.TransferResult
+	if roundUpFrom != nil {
+		created, roundUp, err = s.transactionRepo.CreateWithRoundUp(ctx, transaction, roundUpFrom, roundUpTo)
+	} else {
+		created, err = s.transactionRepo.Create(ctx, transaction)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// Publish event for real-time updates
 	s.publishEvent(workspaceID, websocket.TransactionCreated(created))
+	s.publishRoundUp(workspaceID, roundUp)
 
 	return created, nil
 }
 
+// ComputeRoundUp returns the amount needed to round amount up to the next whole unit,
+// or zero when it is already whole (RM4.30 rounds up by RM0.70)
+func ComputeRoundUp(amount decimal.Decimal) decimal.Decimal {
+	return amount.Ceil().Sub(amount)
+}
+
+// roundUpLegs builds the transfer moving an expense's round-up to the workspace's savings account.
+// It returns nil legs when round-up savings is off or the expense doesn't qualify: only paid,
+// unscheduled, non-credit-card expenses that aren't whole amounts are rounded up.
+// account may be nil, in which case it is loaded only when needed.
+func (s *TransactionService) roundUpLegs(workspaceID int32, expense *domain.Transaction, account *domain.Account) (*domain.Transaction, *domain.Transaction, error) {
+	if s.workspaceRepo == nil || expense.Type != domain.TransactionTypeExpense || !expense.IsPaid || expense.IsScheduled || expense.TransferPairID != nil {
+		return nil, nil, nil
+	}
+	roundUp := ComputeRoundUp(expense.Amount)
+	if roundUp.IsZero() {
+		return nil, nil, nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	savingsAccountID := workspace.RoundUpSavingsAccountID
+	if savingsAccountID == nil || *savingsAccountID == expense.AccountID {
+		return nil, nil, nil
+	}
+
+	if account == nil {
+		account, err = s.accountRepo.GetByID(workspaceID, expense.AccountID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if account.Template == domain.TemplateCreditCard {
+		return nil, nil, nil
+	}
+
+	notes := fmt.Sprintf("Round-up of %s", expense.Name)
+	return s.buildTransferLegs(workspaceID, CreateTransferInput{
+		FromAccountID: expense.AccountID,
+		ToAccountID:   *savingsAccountID,
+		Amount:        roundUp,
+		Date:          expense.TransactionDate,
+		Notes:         &notes,
+	})
+}
+
+// syncRoundUp replaces an existing expense's round-up transfer with one recomputed from its
+// current state, removing it when the expense no longer qualifies
+func (s *TransactionService) syncRoundUp(ctx context.Context, workspaceID int32, expense *domain.Transaction) error {
+	roundUpFrom, roundUpTo, err := s.roundUpLegs(workspaceID, expense, nil)
+	if err != nil {
+		return err
+	}
+	roundUp, err := s.transactionRepo.ReplaceRoundUp(ctx, workspaceID, expense.ID, roundUpFrom, roundUpTo)
+	if err != nil {
+		return err
+	}
+	s.publishRoundUp(workspaceID, roundUp)
+	return nil
+}
+
+// SyncRoundUps re-syncs the round-ups of transactions whose paid state was changed outside
+// TransactionService, such as loan installments settled in bulk
+func (s *TransactionService) SyncRoundUps(workspaceID int32, transactions []*domain.Transaction) error {
+	ctx := context.Background()
+	for _, tx := range transactions {
+		if err := s.syncRoundUp(ctx, workspaceID, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roundUpAffected reports whether an update changes anything an expense's round-up depends on
+func roundUpAffected(existing *domain.Transaction, data *domain.UpdateTransactionData) bool {
+	return !existing.Amount.Equal(data.Amount) ||
+		existing.Type != data.Type ||
+		existing.AccountID != data.AccountID ||
+		existing.IsPaid != data.IsPaid ||
+		existing.IsScheduled != data.IsScheduled ||
+		!existing.TransactionDate.Equal(data.TransactionDate)
+}
+
+// publishRoundUp publishes creation events for both legs of a round-up transfer, if any
+func (s *TransactionService) publishRoundUp(workspaceID int32, roundUp *domain.TransferResult) {
+	if roundUp == nil {
+		return
+	}
+	s.publishEvent(workspaceID, websocket.TransactionCreated(roundUp.FromTransaction))
+	s.publishEvent(workspaceID, websocket.TransactionCreated(roundUp.ToTransaction))
+}
+
+// TransactionCloneOverrides contains optional replacements applied when cloning a transaction
+type TransactionCloneOverrides struct {
+	TransactionDate *time.Time       // Optional: defaults to the source transaction's date
+	Amount          *decimal.Decimal // Optional: defaults to the source transaction's amount
+}
+
+// CloneTransaction creates a copy of a transaction for quickly re-entering a similar one.
+// The clone keeps the account, category, notes and flags, but starts unpaid and is not
+// linked to any group, loan, template or transfer. It is validated like a normal create.
+func (s *TransactionService) CloneTransaction(workspaceID int32, id int32, overrides TransactionCloneOverrides) (*domain.Transaction, error) {
+	source, err := s.transactionRepo.GetByID(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := source.Amount
+	if overrides.Amount != nil {
+		amount = *overrides.Amount
+	}
+	transactionDate := source.TransactionDate
+	if overrides.TransactionDate != nil {
+		transactionDate = *overrides.TransactionDate
+	}
+
+	isPaid := false
+	isTaxDeductible := source.IsTaxDeductible
+	return s.CreateTransaction(workspaceID, CreateTransactionInput{
+		AccountID:          source.AccountID,
+		Name:               source.Name,
+		Amount:             amount,
+		Type:               source.Type,
+		TransactionDate:    &transactionDate,
+		IsPaid:             &isPaid,
+		Notes:              source.Notes,
+		CategoryID:         source.CategoryID,
+		SettlementIntent:   source.SettlementIntent,
+		Merchant:           source.Merchant,
+		Payee:              source.Payee,
+		Latitude:           source.Latitude,
+		Longitude:          source.Longitude,
+		Location:           source.Location,
+		IsRefund:           source.IsRefund,
+		ExcludeFromReports: source.ExcludeFromReports,
+		IsTaxDeductible:    &isTaxDeductible,
+	})
+}
+
 // GetTransactions retrieves transactions for a workspace with optional filters and pagination
 // If requesting future dates, ensures projections exist (on-access projection generation)
-func (s *TransactionService) GetTransactions(workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
+func (s *TransactionService) GetTransactions(ctx context.Context, workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
+	if filters != nil && filters.Source != nil {
+		valid, err := s.isValidSource(ctx, workspaceID, *filters.Source)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, domain.ErrInvalidTransactionSource
+		}
+	}
+
 	// Check if requesting future dates and ensure projections exist
 	if filters != nil && filters.EndDate != nil && s.templateRepo != nil {
 		now := time.Now()
 		if filters.EndDate.After(now) {
 			// Ensure projections exist for the requested date range
-			s.ensureProjectionsForDateRange(workspaceID, *filters.EndDate)
+			s.ensureProjectionsForDateRange(ctx, workspaceID, *filters.EndDate)
 		}
 	}
 
-	return s.transactionRepo.GetByWorkspace(workspaceID, filters)
+	return s.transactionRepo.GetByWorkspace(ctx, workspaceID, filters)
+}
+
+// ListByCursor returns one keyset page of transactions ordered by (transaction_date, id)
+// An empty cursor starts from the beginning; the returned cursor is empty on the last page.
+func (s *TransactionService) ListByCursor(workspaceID int32, cursor string, limit int) ([]*domain.Transaction, string, error) {
+	var after *domain.TransactionCursor
+	if cursor != "" {
+		decoded, err := domain.DecodeTransactionCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = decoded
+	}
+
+	if limit < 1 {
+		limit = domain.DefaultCursorPageSize
+	}
+	if limit > domain.MaxCursorPageSize {
+		limit = domain.MaxCursorPageSize
+	}
+
+	// Fetch one extra row to know whether another page exists
+	transactions, err := s.transactionRepo.ListAfterCursor(workspaceID, after, int32(limit+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(transactions) <= limit {
+		return transactions, "", nil
+	}
+
+	page := transactions[:limit]
+	last := page[len(page)-1]
+	next := domain.TransactionCursor{TransactionDate: last.TransactionDate, ID: last.ID}
+	return page, next.Encode(), nil
 }
 
 // GetTransactionByID retrieves a transaction by ID within a workspace
@@ -207,6 +482,16 @@ func (s *TransactionService) TogglePaidStatus(workspaceID int32, id int32) (*dom
 		return nil, err
 	}
 
+	// Only paid expenses are rounded up, so paying adds the round-up and un-paying removes it
+	if err := s.syncRoundUp(context.Background(), workspaceID, updated); err != nil {
+		return nil, err
+	}
+
+	// Paying the last (or un-paying any) loan payment changes the loan's completion
+	if err := syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, transactionLoanIDs([]*domain.Transaction{updated})); err != nil {
+		return nil, err
+	}
+
 	// Publish event for real-time updates
 	s.publishEvent(workspaceID, websocket.TransactionUpdated(updated))
 
@@ -246,18 +531,9 @@ func (s *TransactionService) ToggleBilled(workspaceID int32, id int32) (*domain.
 	}
 
 	// Update the transaction with new billedAt
-	updated, err := s.transactionRepo.Update(workspaceID, id, &domain.UpdateTransactionData{
-		Name:             txn.Name,
-		Amount:           txn.Amount,
-		Type:             txn.Type,
-		TransactionDate:  txn.TransactionDate,
-		AccountID:        txn.AccountID,
-		Notes:            txn.Notes,
-		CategoryID:       txn.CategoryID,
-		IsPaid:           txn.IsPaid, // Preserve isPaid (should be false here)
-		BilledAt:         newBilledAt,
-		SettlementIntent: txn.SettlementIntent,
-	})
+	updateData := txn.ToUpdateData()
+	updateData.BilledAt = newBilledAt
+	updated, err := s.transactionRepo.Update(workspaceID, id, updateData)
 	if err != nil {
 		return nil, err
 	}
@@ -268,16 +544,88 @@ func (s *TransactionService) ToggleBilled(workspaceID int32, id int32) (*domain.
 	return updated, nil
 }
 
+// BatchTransitionCCState moves CC transactions to the target state, one transaction at a time
+// Each move must be a legal transition (see domain.IsValidCCStateTransition). Transactions that
+// cannot move are skipped and reported in a *domain.CCTransitionError alongside the ones that did.
+func (s *TransactionService) BatchTransitionCCState(workspaceID int32, ids []int32, to domain.CCState) ([]*domain.Transaction, error) {
+	if to != domain.CCStatePending && to != domain.CCStateBilled && to != domain.CCStateSettled {
+		return nil, domain.ErrInvalidCCStateTransition
+	}
+
+	updated := []*domain.Transaction{}
+	failures := map[int32]error{}
+	now := time.Now()
+
+	for _, id := range ids {
+		txn, err := s.transactionRepo.GetByID(workspaceID, id)
+		if err != nil {
+			failures[id] = err
+			continue
+		}
+		if txn.SettlementIntent == nil {
+			failures[id] = domain.ErrNotCCTransaction
+			continue
+		}
+		if !domain.IsValidCCStateTransition(*domain.ComputeCCState(txn.IsPaid, txn.BilledAt), to) {
+			failures[id] = domain.ErrInvalidCCStateTransition
+			continue
+		}
+
+		// Settled keeps its billing date; billed keeps an existing one when reverting from settled
+		billedAt := txn.BilledAt
+		switch to {
+		case domain.CCStatePending:
+			billedAt = nil
+		case domain.CCStateBilled:
+			if billedAt == nil {
+				billedAt = &now
+			}
+		}
+
+		updateData := txn.ToUpdateData()
+		updateData.IsPaid = to == domain.CCStateSettled
+		updateData.BilledAt = billedAt
+		result, err := s.transactionRepo.Update(workspaceID, id, updateData)
+		if err != nil {
+			failures[id] = err
+			continue
+		}
+
+		updated = append(updated, result)
+		s.publishEvent(workspaceID, websocket.TransactionUpdated(result))
+	}
+
+	// Settling or un-settling a loan payment on a CC changes the loan's completion
+	if err := syncLoansCompletion(s.loanRepo, s.transactionRepo, workspaceID, transactionLoanIDs(updated)); err != nil {
+		return updated, err
+	}
+
+	if len(failures) > 0 {
+		return updated, &domain.CCTransitionError{Failures: failures}
+	}
+	return updated, nil
+}
+
 // UpdateTransactionInput holds the input for updating a transaction
 type UpdateTransactionInput struct {
-	Name             string
-	Amount           decimal.Decimal
-	Type             domain.TransactionType
-	TransactionDate  time.Time
-	AccountID        int32
-	Notes            *string
-	CategoryID       *int32
-	SettlementIntent *domain.SettlementIntent // Only for CC transactions
+	Name               string
+	Amount             decimal.Decimal
+	Type               domain.TransactionType
+	TransactionDate    time.Time
+	AccountID          int32
+	Notes              *string
+	CategoryID         *int32
+	SettlementIntent   *domain.SettlementIntent // Only for CC transactions
+	IsScheduled        *bool                    // Optional: preserves current value if nil
+	Merchant           *string
+	Payee              *string
+	IsRefund           *bool // Optional: preserves current value if nil
+	ExcludeFromReports *bool // Optional: preserves current value if nil
+	IsTaxDeductible    *bool // Optional: preserves current value if nil
+	// Geo metadata replaces the current values; nil clears them
+	Latitude  *decimal.Decimal
+	Longitude *decimal.Decimal
+	Location  *string
 }
 
 // UpdateTransaction updates an existing transaction with validation
@@ -297,9 +645,12 @@ func (s *TransactionService) UpdateTransaction(workspaceID int32, id int32, inpu
 		return nil, domain.ErrNameTooLong
 	}
 
-	// Validate amount (must be positive)
-	if input.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, domain.ErrInvalidAmount
+	// Validate amount (must be positive, or zero when placeholders are allowed)
+	if err := domain.ValidateTransactionAmount(input.Amount, s.allowZeroAmount); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, input.Amount); err != nil {
+		return nil, err
 	}
 
 	// Validate transaction type
@@ -325,9 +676,25 @@ func (s *TransactionService) UpdateTransaction(workspaceID int32, id int32, inpu
 		}
 	}
 
+	merchant, err := normalizeMerchant(input.Merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := normalizePayee(input.Payee)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := normalizeLocation(input.Latitude, input.Longitude, input.Location)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate category exists and belongs to workspace if provided
+	var category *domain.BudgetCategory
 	if input.CategoryID != nil {
-		_, err := s.categoryRepo.GetByID(workspaceID, *input.CategoryID)
+		category, err = s.categoryRepo.GetByID(context.Background(), workspaceID, *input.CategoryID)
 		if err != nil {
 			return nil, domain.ErrBudgetCategoryNotFound
 		}
@@ -339,27 +706,70 @@ func (s *TransactionService) UpdateTransaction(workspaceID int32, id int32, inpu
 		settlementIntent = input.SettlementIntent
 	}
 
-	updated, err := s.transactionRepo.Update(workspaceID, id, &domain.UpdateTransactionData{
-		Name:             name,
-		Amount:           input.Amount,
-		Type:             input.Type,
-		TransactionDate:  input.TransactionDate,
-		AccountID:        input.AccountID,
-		Notes:            notes,
-		CategoryID:       input.CategoryID,
-		// Preserve CC lifecycle fields (v2 simplified)
-		IsPaid:           existing.IsPaid,
-		BilledAt:         existing.BilledAt,
-		SettlementIntent: settlementIntent,
-		// Preserve recurring/projection fields
-		Source:      existing.Source,
-		TemplateID:  existing.TemplateID,
-		IsProjected: existing.IsProjected,
-	})
+	// Scheduling only applies while the (possibly new) date is in the future
+	isScheduled := existing.IsScheduled
+	if input.IsScheduled != nil {
+		isScheduled = *input.IsScheduled
+	}
+	isScheduled = isScheduled && domain.IsAfterDay(input.TransactionDate, time.Now())
+
+	// Refunds must stay income and can't be transfer legs
+	isRefund := existing.IsRefund
+	if input.IsRefund != nil {
+		isRefund = *input.IsRefund
+	}
+	if isRefund && (input.Type != domain.TransactionTypeIncome || existing.TransferPairID != nil) {
+		return nil, domain.ErrInvalidRefund
+	}
+
+	excludeFromReports := existing.ExcludeFromReports
+	if input.ExcludeFromReports != nil {
+		excludeFromReports = *input.ExcludeFromReports
+	}
+
+	// Moving to another category re-applies that category's tax-deductible default
+	isTaxDeductible := existing.IsTaxDeductible
+	if !sameCategory(existing.CategoryID, input.CategoryID) {
+		isTaxDeductible = category != nil && category.IsTaxDeductible
+	}
+	if input.IsTaxDeductible != nil {
+		isTaxDeductible = *input.IsTaxDeductible
+	}
+
+	// CC lifecycle and recurring/projection fields are preserved from existing
+	updateData := existing.ToUpdateData()
+	updateData.Name = name
+	updateData.Amount = input.Amount
+	updateData.Type = input.Type
+	updateData.TransactionDate = input.TransactionDate
+	updateData.AccountID = input.AccountID
+	updateData.Notes = notes
+	updateData.CategoryID = input.CategoryID
+	updateData.SettlementIntent = settlementIntent
+	updateData.IsScheduled = isScheduled
+	updateData.Merchant = merchant
+	updateData.Payee = payee
+	updateData.Latitude = input.Latitude
+	updateData.Longitude = input.Longitude
+	updateData.Location = location
+	updateData.IsRefund = isRefund
+	updateData.ExcludeFromReports = excludeFromReports
+	updateData.IsTaxDeductible = isTaxDeductible
+	// One-off edits are flagged on the transaction and never written back to the template
+	updateData.ModifiedFromTemplate = s.divergesFromTemplate(workspaceID, existing, input.Amount, input.CategoryID)
+	resyncRoundUp := roundUpAffected(existing, updateData)
+
+	updated, err := s.transactionRepo.Update(workspaceID, id, updateData)
 	if err != nil {
 		return nil, err
 	}
 
+	if resyncRoundUp {
+		if err := s.syncRoundUp(context.Background(), workspaceID, updated); err != nil {
+			return nil, err
+		}
+	}
+
 	// Auto-ungroup if date changed to a different month (AC #5)
 	if updated.GroupID != nil && s.transactionGroupRepo != nil {
 		group, groupErr := s.transactionGroupRepo.GetByID(workspaceID, *updated.GroupID)
@@ -435,6 +845,11 @@ func (s *TransactionService) DeleteTransaction(workspaceID int32, id int32) erro
 		return err
 	}
 
+	// An expense's round-up goes with it
+	if _, err := s.transactionRepo.ReplaceRoundUp(context.Background(), workspaceID, id, nil, nil); err != nil {
+		return err
+	}
+
 	// Publish event for real-time updates
 	s.publishEvent(workspaceID, websocket.TransactionDeleted(map[string]any{"id": id}))
 
@@ -452,29 +867,51 @@ type CreateTransferInput struct {
 
 // CreateTransfer creates a transfer between two accounts
 func (s *TransactionService) CreateTransfer(workspaceID int32, input CreateTransferInput) (*domain.TransferResult, error) {
+	fromTx, toTx, err := s.buildTransferLegs(workspaceID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.transactionRepo.CreateTransferPair(fromTx, toTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish events for both created transactions
+	s.publishEvent(workspaceID, websocket.TransactionCreated(result.FromTransaction))
+	s.publishEvent(workspaceID, websocket.TransactionCreated(result.ToTransaction))
+
+	return result, nil
+}
+
+// buildTransferLegs validates a transfer and builds its linked expense and income legs
+func (s *TransactionService) buildTransferLegs(workspaceID int32, input CreateTransferInput) (*domain.Transaction, *domain.Transaction, error) {
 	// Validate same account
 	if input.FromAccountID == input.ToAccountID {
-		return nil, domain.ErrSameAccountTransfer
+		return nil, nil, domain.ErrSameAccountTransfer
 	}
 
 	// Validate amount
 	if input.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, domain.ErrInvalidAmount
+		return nil, nil, domain.ErrInvalidAmount
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, input.Amount); err != nil {
+		return nil, nil, err
 	}
 
 	// Validate both accounts exist and belong to workspace
 	fromAccount, err := s.accountRepo.GetByID(workspaceID, input.FromAccountID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	toAccount, err := s.accountRepo.GetByID(workspaceID, input.ToAccountID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Validate notes length if provided
 	if input.Notes != nil && len(*input.Notes) > domain.MaxTransactionNotesLength {
-		return nil, domain.ErrNotesTooLong
+		return nil, nil, domain.ErrNotesTooLong
 	}
 
 	// Generate transfer pair ID
@@ -510,18 +947,110 @@ func (s *TransactionService) CreateTransfer(workspaceID int32, input CreateTrans
 		Notes:           input.Notes,
 	}
 
-	result, err := s.transactionRepo.CreateTransferPair(fromTx, toTx)
+	return fromTx, toTx, nil
+}
+
+// ReverseTransfer undoes a transfer by creating a mirrored counter-transfer rather than
+// deleting the original, so both sides of the audit trail are kept. Either leg's ID may be given.
+func (s *TransactionService) ReverseTransfer(workspaceID int32, transactionID int32) (*domain.TransferResult, error) {
+	original, err := s.transactionRepo.GetByID(workspaceID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if original.TransferPairID == nil {
+		return nil, domain.ErrNotTransfer
+	}
+	pairID := *original.TransferPairID
+
+	reversed, err := s.transactionRepo.IsTransferReversed(workspaceID, pairID)
+	if err != nil {
+		return nil, err
+	}
+	if reversed {
+		return nil, domain.ErrTransferAlreadyReversed
+	}
+
+	legs, err := s.transactionRepo.GetTransferPair(workspaceID, pairID)
+	if err != nil {
+		return nil, err
+	}
+	var fromLeg, toLeg *domain.Transaction
+	for _, leg := range legs {
+		switch leg.Type {
+		case domain.TransactionTypeExpense:
+			fromLeg = leg
+		case domain.TransactionTypeIncome:
+			toLeg = leg
+		}
+	}
+	if fromLeg == nil || toLeg == nil {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	fromAccount, err := s.accountRepo.GetByID(workspaceID, fromLeg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	toAccount, err := s.accountRepo.GetByID(workspaceID, toLeg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Money flows back from the original destination to the original source
+	reversalPairID := uuid.New()
+	reversalDate := time.Now().UTC().Truncate(24 * time.Hour)
+
+	reverseFrom := &domain.Transaction{
+		WorkspaceID:            workspaceID,
+		AccountID:              toAccount.ID,
+		Name:                   fmt.Sprintf("Reversal: transfer to %s", fromAccount.Name),
+		Amount:                 toLeg.Amount,
+		Type:                   domain.TransactionTypeExpense,
+		TransactionDate:        reversalDate,
+		IsPaid:                 true,
+		TransferPairID:         &reversalPairID,
+		ReversesTransferPairID: &pairID,
+	}
+	reverseTo := &domain.Transaction{
+		WorkspaceID:            workspaceID,
+		AccountID:              fromAccount.ID,
+		Name:                   fmt.Sprintf("Reversal: transfer from %s", toAccount.Name),
+		Amount:                 fromLeg.Amount,
+		Type:                   domain.TransactionTypeIncome,
+		TransactionDate:        reversalDate,
+		IsPaid:                 true,
+		TransferPairID:         &reversalPairID,
+		ReversesTransferPairID: &pairID,
+	}
+
+	result, err := s.transactionRepo.CreateTransferPair(reverseFrom, reverseTo)
 	if err != nil {
 		return nil, err
 	}
 
-	// Publish events for both created transactions
 	s.publishEvent(workspaceID, websocket.TransactionCreated(result.FromTransaction))
 	s.publishEvent(workspaceID, websocket.TransactionCreated(result.ToTransaction))
 
 	return result, nil
 }
 
+// DistinctSources returns the transaction sources in use in the workspace, for the source filter
+func (s *TransactionService) DistinctSources(workspaceID int32) ([]string, error) {
+	return s.transactionRepo.GetDistinctSources(context.Background(), workspaceID)
+}
+
+// isValidSource reports whether source is a built-in source or a custom one already present in the workspace
+func (s *TransactionService) isValidSource(ctx context.Context, workspaceID int32, source string) (bool, error) {
+	if slices.Contains(domain.KnownTransactionSources, source) {
+		return true, nil
+	}
+	sources, err := s.transactionRepo.GetDistinctSources(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(sources, source), nil
+}
+
 // GetRecentlyUsedCategories returns recently used categories for suggestions dropdown
 func (s *TransactionService) GetRecentlyUsedCategories(workspaceID int32) ([]*domain.RecentCategory, error) {
 	return s.transactionRepo.GetRecentlyUsedCategories(workspaceID)
@@ -529,13 +1058,13 @@ func (s *TransactionService) GetRecentlyUsedCategories(workspaceID int32) ([]*do
 
 // ensureProjectionsForDateRange ensures projections exist up to the target date (on-access generation)
 // This is transparent to the user - projections are generated within the same API call
-func (s *TransactionService) ensureProjectionsForDateRange(workspaceID int32, targetDate time.Time) {
+func (s *TransactionService) ensureProjectionsForDateRange(ctx context.Context, workspaceID int32, targetDate time.Time) {
 	if s.templateRepo == nil {
 		return
 	}
 
 	// Get all active templates for this workspace
-	templates, err := s.templateRepo.GetActive(workspaceID)
+	templates, err := s.templateRepo.GetActive(ctx, workspaceID)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -551,14 +1080,14 @@ func (s *TransactionService) ensureProjectionsForDateRange(workspaceID int32, ta
 		}
 
 		// Generate projections up to target date
-		s.generateProjectionsUpTo(workspaceID, template, targetDate)
+		s.generateProjectionsUpTo(ctx, workspaceID, template, targetDate)
 	}
 }
 
 // generateProjectionsUpTo creates missing projections for a template up to the target date
-func (s *TransactionService) generateProjectionsUpTo(workspaceID int32, template *domain.RecurringTemplate, targetDate time.Time) {
+func (s *TransactionService) generateProjectionsUpTo(ctx context.Context, workspaceID int32, template *domain.RecurringTemplate, targetDate time.Time) {
 	// Get existing projections to check what's missing
-	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(workspaceID, template.ID)
+	existingProjections, err := s.transactionRepo.GetProjectionsByTemplate(ctx, workspaceID, template.ID)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -598,6 +1127,8 @@ func (s *TransactionService) generateProjectionsUpTo(workspaceID int32, template
 		endOfTargetMonth = *template.EndDate
 	}
 
+	isTaxDeductible := categoryTaxDeductible(ctx, s.categoryRepo, workspaceID, template.CategoryID)
+
 	// Generate projections month by month
 	current := startDate
 	for !current.After(endOfTargetMonth) {
@@ -613,7 +1144,7 @@ func (s *TransactionService) generateProjectionsUpTo(workspaceID int32, template
 		// Check if this month is excluded (user explicitly deleted)
 		if s.exclusionRepo != nil {
 			monthStart := time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.UTC)
-			excluded, err := s.exclusionRepo.IsExcluded(workspaceID, template.ID, monthStart)
+			excluded, err := s.exclusionRepo.IsExcluded(ctx, workspaceID, template.ID, monthStart)
 			if err == nil && excluded {
 				current = current.AddDate(0, 1, 0)
 				continue
@@ -634,9 +1165,10 @@ func (s *TransactionService) generateProjectionsUpTo(workspaceID int32, template
 			IsProjected:     true,
 			IsPaid:          false,
 			Notes:           template.Notes,
+			IsTaxDeductible: isTaxDeductible,
 		}
 
-		if _, err := s.transactionRepo.Create(transaction); err != nil {
+		if _, err := s.transactionRepo.Create(ctx, transaction); err != nil {
 			log.Error().
 				Err(err).
 				Int32("workspaceID", workspaceID).
@@ -695,14 +1227,47 @@ func (s *TransactionService) EnrichWithModificationStatus(workspaceID int32, tra
 		}
 
 		// Check if transaction differs from template
-		categoryDiffers := (tx.CategoryID == nil) != (template.CategoryID == nil) ||
-			(tx.CategoryID != nil && template.CategoryID != nil && *tx.CategoryID != *template.CategoryID)
-		tx.IsModified = !tx.Amount.Equal(template.Amount) ||
-			categoryDiffers ||
+		tx.IsModified = differsFromTemplate(tx.Amount, tx.CategoryID, template) ||
 			tx.Name != template.Description
 	}
 }
 
+// divergesFromTemplate reports whether an edit to a template-sourced transaction leaves its amount or
+// category different from the template; keeps the existing flag when the template can't be loaded
+func (s *TransactionService) divergesFromTemplate(workspaceID int32, existing *domain.Transaction, amount decimal.Decimal, categoryID *int32) bool {
+	if existing.TemplateID == nil || s.templateRepo == nil {
+		return existing.ModifiedFromTemplate
+	}
+	template, err := s.templateRepo.GetByID(workspaceID, *existing.TemplateID)
+	if err != nil {
+		return existing.ModifiedFromTemplate
+	}
+	return differsFromTemplate(amount, categoryID, template)
+}
+
+// differsFromTemplate reports whether an amount or category differs from the template's
+func differsFromTemplate(amount decimal.Decimal, categoryID *int32, template *domain.RecurringTemplate) bool {
+	return !amount.Equal(template.Amount) || !sameCategory(categoryID, template.CategoryID)
+}
+
+// sameCategory reports whether two optional category IDs refer to the same category (or both are unset)
+func sameCategory(a, b *int32) bool {
+	return (a == nil) == (b == nil) && (a == nil || *a == *b)
+}
+
+// categoryTaxDeductible returns the tax-deductible default of a category.
+// Uncategorized rows, unknown categories and a missing repository default to false.
+func categoryTaxDeductible(ctx context.Context, categoryRepo domain.BudgetCategoryRepository, workspaceID int32, categoryID *int32) bool {
+	if categoryID == nil || categoryRepo == nil {
+		return false
+	}
+	category, err := categoryRepo.GetByID(ctx, workspaceID, *categoryID)
+	if err != nil {
+		return false
+	}
+	return category.IsTaxDeductible
+}
+
 // GetCCMetrics returns CC metrics (pending, billed, month total) for a workspace and month
 func (s *TransactionService) GetCCMetrics(workspaceID int32, month time.Time) (*domain.CCMetrics, error) {
 	// Calculate start and end of month
@@ -731,6 +1296,63 @@ func (s *TransactionService) BatchToggleToBilled(workspaceID int32, ids []int32)
 	return transactions, nil
 }
 
+// BulkMoveAccount reassigns many transactions to another account in one atomic update
+// Balances are derived from transactions, so the source and destination accounts adjust
+// together. Transfer legs are skipped; the returned count covers only the moved rows.
+func (s *TransactionService) BulkMoveAccount(workspaceID int32, ids []int32, toAccountID int32) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Archived accounts are soft-deleted, so GetByID rejects them along with foreign ones
+	target, err := s.accountRepo.GetByID(workspaceID, toAccountID)
+	if err != nil {
+		return 0, domain.ErrAccountNotFound
+	}
+
+	// Billing state only means something on credit cards, and loan payments follow loan.AccountID,
+	// so refuse the whole batch rather than leave either out of step
+	transactions, err := s.transactionRepo.GetByIDs(workspaceID, ids)
+	if err != nil {
+		return 0, err
+	}
+	accounts, err := s.accountRepo.GetAllByWorkspace(workspaceID, domain.ListOptions{IncludeDeleted: true})
+	if err != nil {
+		return 0, err
+	}
+	isCreditCard := make(map[int32]bool, len(accounts))
+	for _, account := range accounts {
+		isCreditCard[account.ID] = account.Template == domain.TemplateCreditCard
+	}
+	for _, tx := range transactions {
+		if tx.TransferPairID != nil || tx.AccountID == toAccountID {
+			continue
+		}
+		if tx.LoanID != nil {
+			return 0, domain.ErrCannotMoveLoanTransaction
+		}
+		if isCreditCard[tx.AccountID] != (target.Template == domain.TemplateCreditCard) {
+			return 0, domain.ErrCannotMoveAcrossCreditCard
+		}
+	}
+
+	moved, err := s.transactionRepo.BulkUpdateAccount(workspaceID, ids, toAccountID)
+	if err != nil {
+		return 0, err
+	}
+
+	// A round-up is drawn from the expense's account, so moved expenses get theirs rebuilt
+	if err := s.SyncRoundUps(workspaceID, moved); err != nil {
+		return 0, err
+	}
+
+	for _, tx := range moved {
+		s.publishEvent(workspaceID, websocket.TransactionUpdated(tx))
+	}
+
+	return len(moved), nil
+}
+
 // GetDeferredForSettlement returns all billed+deferred transactions that need settlement
 func (s *TransactionService) GetDeferredForSettlement(workspaceID int32) ([]*domain.Transaction, error) {
 	return s.transactionRepo.GetDeferredForSettlement(workspaceID)
@@ -753,9 +1375,12 @@ func (s *TransactionService) GetPendingDeferredCC(workspaceID int32, month time.
 // UpdateAmount updates only the amount field of a transaction
 // This is used for overdue items where only amount adjustment (for interest/fees) is allowed
 func (s *TransactionService) UpdateAmount(workspaceID int32, id int32, amount decimal.Decimal) (*domain.Transaction, error) {
-	// Validate amount is positive
-	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, domain.ErrInvalidAmount
+	// Validate amount is positive (or zero when placeholders are allowed)
+	if err := domain.ValidateTransactionAmount(amount, s.allowZeroAmount); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePrecision(s.maxDecimalPlaces, amount); err != nil {
+		return nil, err
 	}
 
 	// Get existing transaction
@@ -764,21 +1389,100 @@ func (s *TransactionService) UpdateAmount(workspaceID int32, id int32, amount de
 		return nil, err
 	}
 
-	// Only update the amount, preserve everything else
-	updateData := &domain.UpdateTransactionData{
-		Name:             existing.Name,
-		Amount:           amount,
-		Type:             existing.Type,
-		TransactionDate:  existing.TransactionDate,
-		AccountID:        existing.AccountID,
-		Notes:            existing.Notes,
-		CategoryID:       existing.CategoryID,
-		IsPaid:           existing.IsPaid,
-		BilledAt:         existing.BilledAt,
-		SettlementIntent: existing.SettlementIntent,
+	// Only update the amount, preserve everything else (including the template link)
+	updateData := existing.ToUpdateData()
+	updateData.Amount = amount
+	updateData.ModifiedFromTemplate = s.divergesFromTemplate(workspaceID, existing, amount, existing.CategoryID)
+	resyncRoundUp := roundUpAffected(existing, updateData)
+
+	updated, err := s.transactionRepo.Update(workspaceID, id, updateData)
+	if err != nil {
+		return nil, err
+	}
+
+	if resyncRoundUp {
+		if err := s.syncRoundUp(context.Background(), workspaceID, updated); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// PromoteScheduled activates the workspace's scheduled transactions whose date has arrived
+func (s *TransactionService) PromoteScheduled(workspaceID int32) ([]*domain.Transaction, error) {
+	promoted, err := s.transactionRepo.PromoteDueScheduled(workspaceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Scheduled expenses aren't rounded up until they become active
+	if err := s.SyncRoundUps(workspaceID, promoted); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range promoted {
+		s.publishEvent(workspaceID, websocket.TransactionUpdated(tx))
+	}
+
+	return promoted, nil
+}
+
+// PromoteAllScheduled activates due scheduled transactions across all workspaces
+// Used by the daily background job
+func (s *TransactionService) PromoteAllScheduled() (int64, error) {
+	promoted, err := s.transactionRepo.PromoteAllDueScheduled(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	// Scheduled expenses aren't rounded up until they become active. The promotion is
+	// already committed, so a failed round-up is logged and the rest still get theirs.
+	for _, tx := range promoted {
+		if err := s.syncRoundUp(context.Background(), tx.WorkspaceID, tx); err != nil {
+			log.Error().Err(err).
+				Int32("workspace_id", tx.WorkspaceID).
+				Int32("transaction_id", tx.ID).
+				Msg("Failed to sync round-up for promoted transaction")
+		}
+	}
+
+	return int64(len(promoted)), nil
+}
+
+// GetOverdueTransactions returns unpaid expenses dated before asOf, oldest first,
+// so users can be reminded of payments they forgot to mark as paid.
+// Future-dated, paid, transfer and deferred CC transactions are excluded.
+func (s *TransactionService) GetOverdueTransactions(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error) {
+	asOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	return s.transactionRepo.GetOverdueTransactions(workspaceID, asOfDay)
+}
+
+// ListUncategorized returns the month's expenses that have no category, so users can be prompted
+// to categorize them. month is "YYYY-MM". Income, transfers, CC payments and projections are left out.
+func (s *TransactionService) ListUncategorized(workspaceID int32, month string) ([]*domain.Transaction, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, domain.ErrInvalidMonthFormat
+	}
+	end := start.AddDate(0, 1, -1)
+
+	transactions, err := s.transactionRepo.GetByDateRangeForAggregation(workspaceID, start, end)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.transactionRepo.Update(workspaceID, id, updateData)
+	uncategorized := []*domain.Transaction{}
+	for _, txn := range transactions {
+		if txn.CategoryID != nil || txn.Type != domain.TransactionTypeExpense {
+			continue
+		}
+		if txn.TransferPairID != nil || txn.IsCCPayment || txn.IsProjected {
+			continue
+		}
+		uncategorized = append(uncategorized, txn)
+	}
+	return uncategorized, nil
 }
 
 // GetOverdue returns overdue CC transactions grouped by month
@@ -857,3 +1561,58 @@ func calculateMonthsOverdue(billedAt *time.Time) int {
 	}
 	return months
 }
+
+// normalizeMerchant trims the merchant and validates its length; blank values become nil
+func normalizeMerchant(merchant *string) (*string, error) {
+	if merchant == nil {
+		return nil, nil
+	}
+	trimmed := strings.TrimSpace(*merchant)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if len(trimmed) > domain.MaxMerchantLength {
+		return nil, domain.ErrMerchantTooLong
+	}
+	return &trimmed, nil
+}
+
+// normalizeLocation validates geo metadata: coordinates must be set together and within range.
+// The location name is trimmed like the payee; blank values become nil.
+func normalizeLocation(latitude, longitude *decimal.Decimal, location *string) (*string, error) {
+	if (latitude == nil) != (longitude == nil) {
+		return nil, domain.ErrInvalidCoordinates
+	}
+	if latitude != nil {
+		if latitude.Abs().GreaterThan(decimal.NewFromInt(90)) || longitude.Abs().GreaterThan(decimal.NewFromInt(180)) {
+			return nil, domain.ErrInvalidCoordinates
+		}
+	}
+	if location == nil {
+		return nil, nil
+	}
+	normalized := strings.Join(strings.Fields(*location), " ")
+	if normalized == "" {
+		return nil, nil
+	}
+	if len(normalized) > domain.MaxLocationLength {
+		return nil, domain.ErrLocationTooLong
+	}
+	return &normalized, nil
+}
+
+// normalizePayee trims the payee and collapses inner whitespace so spend groups
+// under one name; blank values become nil
+func normalizePayee(payee *string) (*string, error) {
+	if payee == nil {
+		return nil, nil
+	}
+	normalized := strings.Join(strings.Fields(*payee), " ")
+	if normalized == "" {
+		return nil, nil
+	}
+	if len(normalized) > domain.MaxPayeeLength {
+		return nil, domain.ErrPayeeTooLong
+	}
+	return &normalized, nil
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -68,6 +72,279 @@ func TestCreateTransaction_Success(t *testing.T) {
 	}
 }
 
+func TestComputeRoundUp(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"4.30", "0.70"},
+		{"5.00", "0"},
+		{"12.01", "0.99"},
+	}
+	for _, tt := range tests {
+		got := ComputeRoundUp(decimal.RequireFromString(tt.amount))
+		if !got.Equal(decimal.RequireFromString(tt.expected)) {
+			t.Errorf("ComputeRoundUp(%s): expected %s, got %s", tt.amount, tt.expected, got.String())
+		}
+	}
+}
+
+// setupRoundUpTest creates a transaction service with round-up savings enabled
+// from account 1 (spending) to account 2 (savings)
+func setupRoundUpTest() (*TransactionService, *testutil.MockTransactionRepository) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	transactionService.SetWorkspaceRepository(workspaceRepo)
+
+	savingsAccountID := int32(2)
+	workspaceRepo.AddWorkspace(&domain.Workspace{
+		ID:                      1,
+		UserID:                  uuid.New(),
+		Name:                    "Test Workspace",
+		RoundUpSavingsAccountID: &savingsAccountID,
+	}, "")
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Spending", Template: domain.TemplateBank})
+	accountRepo.AddAccount(&domain.Account{ID: savingsAccountID, WorkspaceID: 1, Name: "Savings", Template: domain.TemplateBank})
+
+	return transactionService, transactionRepo
+}
+
+func TestCreateTransaction_RoundUpCreatesSavingsTransfer(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	_, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Coffee",
+		Amount:    decimal.RequireFromString("4.30"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(transactionRepo.ByTransferPairID) != 1 {
+		t.Fatalf("Expected 1 round-up transfer, got %d", len(transactionRepo.ByTransferPairID))
+	}
+	for _, pair := range transactionRepo.ByTransferPairID {
+		for _, tx := range pair {
+			if !tx.Amount.Equal(decimal.RequireFromString("0.70")) {
+				t.Errorf("Expected round-up amount 0.70, got %s", tx.Amount.String())
+			}
+			if tx.Type == domain.TransactionTypeIncome && tx.AccountID != 2 {
+				t.Errorf("Expected round-up to land in savings account 2, got %d", tx.AccountID)
+			}
+		}
+	}
+}
+
+// liveRoundUps returns the undeleted round-up legs created for an expense
+func liveRoundUps(transactionRepo *testutil.MockTransactionRepository, sourceID int32) []*domain.Transaction {
+	var legs []*domain.Transaction
+	for _, tx := range transactionRepo.Transactions {
+		if tx.RoundUpSourceID != nil && *tx.RoundUpSourceID == sourceID && tx.DeletedAt == nil {
+			legs = append(legs, tx)
+		}
+	}
+	return legs
+}
+
+func TestCreateTransaction_RoundUpLinkedToExpense(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	expense, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Coffee",
+		Amount:    decimal.RequireFromString("4.30"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if legs := liveRoundUps(transactionRepo, expense.ID); len(legs) != 2 {
+		t.Errorf("Expected both round-up legs linked to expense %d, got %d", expense.ID, len(legs))
+	}
+}
+
+func TestCreateTransaction_RoundUpSkipsUnpaid(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	isPaid := false
+	expense, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Electricity",
+		Amount:    decimal.RequireFromString("84.30"),
+		Type:      domain.TransactionTypeExpense,
+		IsPaid:    &isPaid,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactionRepo.ByTransferPairID) != 0 {
+		t.Fatalf("Expected no round-up for an unpaid expense, got %d", len(transactionRepo.ByTransferPairID))
+	}
+
+	// Paying the expense rounds it up
+	if _, err := transactionService.TogglePaidStatus(1, expense.ID); err != nil {
+		t.Fatalf("Expected no error paying, got %v", err)
+	}
+	legs := liveRoundUps(transactionRepo, expense.ID)
+	if len(legs) != 2 {
+		t.Fatalf("Expected round-up once paid, got %d legs", len(legs))
+	}
+	if !legs[0].Amount.Equal(decimal.RequireFromString("0.70")) {
+		t.Errorf("Expected round-up amount 0.70, got %s", legs[0].Amount.String())
+	}
+
+	// Un-paying removes it again
+	if _, err := transactionService.TogglePaidStatus(1, expense.ID); err != nil {
+		t.Fatalf("Expected no error un-paying, got %v", err)
+	}
+	if legs := liveRoundUps(transactionRepo, expense.ID); len(legs) != 0 {
+		t.Errorf("Expected round-up removed once unpaid, got %d legs", len(legs))
+	}
+}
+
+func TestDeleteTransaction_RemovesRoundUp(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	expense, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Coffee",
+		Amount:    decimal.RequireFromString("4.30"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := transactionService.DeleteTransaction(1, expense.ID); err != nil {
+		t.Fatalf("Expected no error deleting, got %v", err)
+	}
+	if legs := liveRoundUps(transactionRepo, expense.ID); len(legs) != 0 {
+		t.Errorf("Expected round-up deleted with the expense, got %d legs", len(legs))
+	}
+}
+
+func TestUpdateAmount_RecomputesRoundUp(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	expense, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Coffee",
+		Amount:    decimal.RequireFromString("4.30"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := transactionService.UpdateAmount(1, expense.ID, decimal.RequireFromString("4.80")); err != nil {
+		t.Fatalf("Expected no error updating amount, got %v", err)
+	}
+	legs := liveRoundUps(transactionRepo, expense.ID)
+	if len(legs) != 2 {
+		t.Fatalf("Expected one recomputed round-up transfer, got %d legs", len(legs))
+	}
+	for _, leg := range legs {
+		if !leg.Amount.Equal(decimal.RequireFromString("0.20")) {
+			t.Errorf("Expected recomputed round-up 0.20, got %s", leg.Amount.String())
+		}
+	}
+
+	// A whole amount needs no round-up
+	if _, err := transactionService.UpdateAmount(1, expense.ID, decimal.RequireFromString("5.00")); err != nil {
+		t.Fatalf("Expected no error updating amount, got %v", err)
+	}
+	if legs := liveRoundUps(transactionRepo, expense.ID); len(legs) != 0 {
+		t.Errorf("Expected round-up removed for a whole amount, got %d legs", len(legs))
+	}
+}
+
+func TestCreateTransaction_RoundUpSkipsWholeAmount(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	_, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Lunch",
+		Amount:    decimal.RequireFromString("5.00"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(transactionRepo.ByTransferPairID) != 0 {
+		t.Errorf("Expected no round-up transfer for a whole amount, got %d", len(transactionRepo.ByTransferPairID))
+	}
+}
+
+func TestBulkMoveAccount_RebuildsRoundUp(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	expense, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Coffee",
+		Amount:    decimal.RequireFromString("4.30"),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Moved into the savings account itself, the expense no longer has anything to round up into
+	if _, err := transactionService.BulkMoveAccount(1, []int32{expense.ID}, 2); err != nil {
+		t.Fatalf("Expected no error moving, got %v", err)
+	}
+	if legs := liveRoundUps(transactionRepo, expense.ID); len(legs) != 0 {
+		t.Errorf("Expected round-up from the old account removed, got %d legs", len(legs))
+	}
+
+	// Moved back, it is rounded up from the spending account again
+	if _, err := transactionService.BulkMoveAccount(1, []int32{expense.ID}, 1); err != nil {
+		t.Fatalf("Expected no error moving back, got %v", err)
+	}
+	legs := liveRoundUps(transactionRepo, expense.ID)
+	if len(legs) != 2 {
+		t.Fatalf("Expected round-up rebuilt after moving back, got %d legs", len(legs))
+	}
+	for _, leg := range legs {
+		if leg.Type == domain.TransactionTypeExpense && leg.AccountID != 1 {
+			t.Errorf("Expected round-up drawn from account 1, got %d", leg.AccountID)
+		}
+	}
+}
+
+func TestPromoteScheduled_AddsRoundUp(t *testing.T) {
+	transactionService, transactionRepo := setupRoundUpTest()
+
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              100,
+		WorkspaceID:     1,
+		AccountID:       1,
+		Name:            "Gym",
+		Amount:          decimal.RequireFromString("29.90"),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now().AddDate(0, 0, -1),
+		IsPaid:          true,
+		IsScheduled:     true,
+	})
+
+	if _, err := transactionService.PromoteScheduled(1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	legs := liveRoundUps(transactionRepo, 100)
+	if len(legs) != 2 {
+		t.Fatalf("Expected round-up once the expense is promoted, got %d legs", len(legs))
+	}
+	if !legs[0].Amount.Equal(decimal.RequireFromString("0.10")) {
+		t.Errorf("Expected round-up amount 0.10, got %s", legs[0].Amount.String())
+	}
+}
+
 func TestCreateTransaction_WithCustomDate(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -319,6 +596,68 @@ func TestCreateTransaction_ZeroAmount(t *testing.T) {
 	}
 }
 
+func TestCreateTransaction_WithLocation(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Test Account"})
+
+	lat := decimal.RequireFromString("-6.200000")
+	lng := decimal.RequireFromString("106.816666")
+	location := "  Grand   Indonesia "
+	transaction, err := transactionService.CreateTransaction(workspaceID, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Lunch",
+		Amount:    decimal.NewFromInt(50),
+		Type:      domain.TransactionTypeExpense,
+		Latitude:  &lat,
+		Longitude: &lng,
+		Location:  &location,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if transaction.Latitude == nil || !transaction.Latitude.Equal(lat) || transaction.Longitude == nil || !transaction.Longitude.Equal(lng) {
+		t.Errorf("Expected coordinates to be stored, got %v, %v", transaction.Latitude, transaction.Longitude)
+	}
+	if transaction.Location == nil || *transaction.Location != "Grand Indonesia" {
+		t.Errorf("Expected normalized location, got %v", transaction.Location)
+	}
+}
+
+func TestCreateTransaction_InvalidCoordinates(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: workspaceID, Name: "Test Account"})
+
+	outOfRange := decimal.NewFromInt(91)
+	valid := decimal.NewFromInt(10)
+	cases := map[string][2]*decimal.Decimal{
+		"latitude out of range": {&outOfRange, &valid},
+		"missing longitude":     {&valid, nil},
+	}
+	for name, coords := range cases {
+		_, err := transactionService.CreateTransaction(workspaceID, CreateTransactionInput{
+			AccountID: 1,
+			Name:      "Lunch",
+			Amount:    decimal.NewFromInt(50),
+			Type:      domain.TransactionTypeExpense,
+			Latitude:  coords[0],
+			Longitude: coords[1],
+		})
+		if !errors.Is(err, domain.ErrInvalidCoordinates) {
+			t.Errorf("%s: expected ErrInvalidCoordinates, got %v", name, err)
+		}
+	}
+}
+
 func TestCreateTransaction_NegativeAmount(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -347,6 +686,180 @@ func TestCreateTransaction_NegativeAmount(t *testing.T) {
 	}
 }
 
+func TestCreateTransaction_ZeroAmountAllowedByConfig(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	transactionService.SetAllowZeroAmount(true)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+	transactionID := int32(10)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+		Template:    domain.TemplateBank,
+	})
+
+	input := CreateTransactionInput{
+		AccountID: accountID,
+		Name:      "Placeholder",
+		Amount:    decimal.Zero,
+		Type:      domain.TransactionTypeExpense,
+	}
+
+	created, err := transactionService.CreateTransaction(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected zero amount to be accepted, got %v", err)
+	}
+	if !created.Amount.IsZero() {
+		t.Errorf("Expected zero amount, got %s", created.Amount)
+	}
+
+	// Updates follow the same rule; negative amounts stay invalid
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              transactionID,
+		WorkspaceID:     workspaceID,
+		AccountID:       accountID,
+		Name:            "Lunch",
+		Amount:          decimal.NewFromFloat(15.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		IsPaid:          true,
+	})
+	update := UpdateTransactionInput{
+		AccountID:       accountID,
+		Name:            "Lunch",
+		Amount:          decimal.Zero,
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+	}
+	if _, err := transactionService.UpdateTransaction(workspaceID, transactionID, update); err != nil {
+		t.Errorf("Expected zero amount update to be accepted, got %v", err)
+	}
+	update.Amount = decimal.NewFromFloat(-5.00)
+	if _, err := transactionService.UpdateTransaction(workspaceID, transactionID, update); err != domain.ErrInvalidAmount {
+		t.Errorf("Expected ErrInvalidAmount for negative amount, got %v", err)
+	}
+}
+
+func TestCreateTransaction_AmountPrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		wantErr error
+	}{
+		{"two decimal places", "100.25", nil},
+		{"trailing zeros", "100.2500", nil},
+		{"four decimal places", "100.2575", domain.ErrExcessivePrecision},
+		{"eight decimal places", "100.25750001", domain.ErrExcessivePrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := testutil.NewMockTransactionRepository()
+			accountRepo := testutil.NewMockAccountRepository()
+			categoryRepo := testutil.NewMockBudgetCategoryRepository()
+			transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+			accountRepo.AddAccount(&domain.Account{
+				ID:          1,
+				WorkspaceID: 1,
+				Name:        "Test Account",
+			})
+
+			input := CreateTransactionInput{
+				AccountID: 1,
+				Name:      "Precise Transaction",
+				Amount:    decimal.RequireFromString(tt.amount),
+				Type:      domain.TransactionTypeExpense,
+			}
+
+			_, err := transactionService.CreateTransaction(1, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransaction_ConfiguredPrecision(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	transactionService.SetMaxDecimalPlaces(4)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Test Account"})
+
+	input := CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Precise Transaction",
+		Amount:    decimal.RequireFromString("100.2575"),
+		Type:      domain.TransactionTypeExpense,
+	}
+	if _, err := transactionService.CreateTransaction(1, input); err != nil {
+		t.Fatalf("Expected 4 decimal places to be accepted, got %v", err)
+	}
+
+	input.Amount = decimal.RequireFromString("100.25751")
+	var precisionErr domain.ErrPrecisionExceeded
+	_, err := transactionService.CreateTransaction(1, input)
+	if !errors.As(err, &precisionErr) || precisionErr.MaxPlaces != 4 {
+		t.Errorf("Expected ErrPrecisionExceeded with 4 places, got %v", err)
+	}
+}
+
+func TestCreateTransaction_Merchant(t *testing.T) {
+	tests := []struct {
+		name         string
+		merchant     *string
+		wantMerchant *string
+		wantErr      error
+	}{
+		{"trims merchant", strPtr("  Grab  "), strPtr("Grab"), nil},
+		{"blank merchant is cleared", strPtr("   "), nil, nil},
+		{"no merchant", nil, nil, nil},
+		{"merchant too long", strPtr(strings.Repeat("m", domain.MaxMerchantLength+1)), nil, domain.ErrMerchantTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := testutil.NewMockTransactionRepository()
+			accountRepo := testutil.NewMockAccountRepository()
+			categoryRepo := testutil.NewMockBudgetCategoryRepository()
+			transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+			accountRepo.AddAccount(&domain.Account{
+				ID:          1,
+				WorkspaceID: 1,
+				Name:        "Test Account",
+			})
+
+			created, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+				AccountID: 1,
+				Name:      "Ride",
+				Amount:    decimal.NewFromInt(15),
+				Type:      domain.TransactionTypeExpense,
+				Merchant:  tt.merchant,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if (created.Merchant == nil) != (tt.wantMerchant == nil) ||
+				(created.Merchant != nil && *created.Merchant != *tt.wantMerchant) {
+				t.Errorf("Expected merchant %v, got %v", tt.wantMerchant, created.Merchant)
+			}
+		})
+	}
+}
+
 func TestCreateTransaction_InvalidType(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -375,6 +888,44 @@ func TestCreateTransaction_InvalidType(t *testing.T) {
 	}
 }
 
+func TestCreateTransaction_RefundMustBeIncome(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+	})
+
+	input := CreateTransactionInput{
+		AccountID: accountID,
+		Name:      "Refund",
+		Amount:    decimal.NewFromFloat(40.00),
+		Type:      domain.TransactionTypeExpense,
+		IsRefund:  true,
+	}
+
+	_, err := transactionService.CreateTransaction(workspaceID, input)
+	if err != domain.ErrInvalidRefund {
+		t.Errorf("Expected ErrInvalidRefund, got %v", err)
+	}
+
+	input.Type = domain.TransactionTypeIncome
+	transaction, err := transactionService.CreateTransaction(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !transaction.IsRefund {
+		t.Error("Expected transaction to be marked as refund")
+	}
+}
+
 func TestCreateTransaction_AccountNotFound(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -584,7 +1135,7 @@ func TestGetTransactions_Success(t *testing.T) {
 		Type:        domain.TransactionTypeIncome,
 	})
 
-	result, err := transactionService.GetTransactions(workspaceID, nil)
+	result, err := transactionService.GetTransactions(context.Background(), workspaceID, nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -606,7 +1157,7 @@ func TestGetTransactions_EmptyList(t *testing.T) {
 
 	workspaceID := int32(1)
 
-	result, err := transactionService.GetTransactions(workspaceID, nil)
+	result, err := transactionService.GetTransactions(context.Background(), workspaceID, nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -783,15 +1334,41 @@ func TestTogglePaidStatus_WrongWorkspace(t *testing.T) {
 	}
 }
 
-// ============================================
-// Transfer Tests
-// ============================================
+func TestTogglePaidStatus_SyncsLoanCompletion(t *testing.T) {
+	_, loanRepo, transactionRepo := createEarlyPayoffTestService()
+	transactionService := NewTransactionService(transactionRepo, testutil.NewMockAccountRepository(), testutil.NewMockBudgetCategoryRepository())
+	transactionService.SetLoanRepository(loanRepo)
 
-func TestCreateTransfer_Success(t *testing.T) {
-	transactionRepo := testutil.NewMockTransactionRepository()
-	accountRepo := testutil.NewMockAccountRepository()
-	categoryRepo := testutil.NewMockBudgetCategoryRepository()
-	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	// Paying the last installment from the transaction list completes the loan
+	for id := int32(1); id <= 3; id++ {
+		if _, err := transactionService.TogglePaidStatus(1, id); err != nil {
+			t.Fatalf("Expected no error paying transaction %d, got %v", id, err)
+		}
+	}
+	loan, _ := loanRepo.GetByID(1, 1)
+	if loan.CompletedAt == nil {
+		t.Fatal("Expected CompletedAt to be set once every installment is paid")
+	}
+
+	// Un-paying any installment reopens it
+	if _, err := transactionService.TogglePaidStatus(1, 2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	loan, _ = loanRepo.GetByID(1, 1)
+	if loan.CompletedAt != nil {
+		t.Error("Expected CompletedAt to be cleared after un-paying an installment")
+	}
+}
+
+// ============================================
+// Transfer Tests
+// ============================================
+
+func TestCreateTransfer_Success(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
 
 	workspaceID := int32(1)
 
@@ -1089,6 +1666,123 @@ func TestCreateTransfer_WorkspaceIsolation(t *testing.T) {
 	}
 }
 
+// ============================================================
+// Transfer Reversal Tests
+// ============================================================
+
+// createReverseTransferTestService sets up two bank accounts with a 500 transfer from 1 to 2
+func createReverseTransferTestService(t *testing.T) (*TransactionService, *CalculationService, *domain.TransferResult) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    1,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		AccountType:    domain.AccountTypeAsset,
+		InitialBalance: decimal.NewFromInt(1000),
+	})
+	accountRepo.AddAccount(&domain.Account{
+		ID:             2,
+		WorkspaceID:    1,
+		Name:           "Savings Account",
+		Template:       domain.TemplateBank,
+		AccountType:    domain.AccountTypeAsset,
+		InitialBalance: decimal.NewFromInt(200),
+	})
+
+	transfer, err := transactionService.CreateTransfer(1, CreateTransferInput{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        decimal.NewFromInt(500),
+		Date:          time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create transfer: %v", err)
+	}
+	return transactionService, calcService, transfer
+}
+
+func TestReverseTransfer_RestoresBalances(t *testing.T) {
+	transactionService, calcService, transfer := createReverseTransferTestService(t)
+
+	balances, err := calcService.CalculateAccountBalances(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !balances[1].CalculatedBalance.Equal(decimal.NewFromInt(500)) || !balances[2].CalculatedBalance.Equal(decimal.NewFromInt(700)) {
+		t.Fatalf("Expected balances 500/700 after transfer, got %s/%s", balances[1].CalculatedBalance, balances[2].CalculatedBalance)
+	}
+
+	// Reverse using the income leg's ID
+	reversal, err := transactionService.ReverseTransfer(1, transfer.ToTransaction.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if reversal.FromTransaction.AccountID != 2 || reversal.ToTransaction.AccountID != 1 {
+		t.Errorf("Expected reversal from account 2 to account 1, got %d -> %d", reversal.FromTransaction.AccountID, reversal.ToTransaction.AccountID)
+	}
+	originalPair := *transfer.FromTransaction.TransferPairID
+	for _, leg := range []*domain.Transaction{reversal.FromTransaction, reversal.ToTransaction} {
+		if leg.ReversesTransferPairID == nil || *leg.ReversesTransferPairID != originalPair {
+			t.Errorf("Expected reversal leg %d to link to original pair %s", leg.ID, originalPair)
+		}
+		if leg.TransferPairID == nil || *leg.TransferPairID == originalPair {
+			t.Errorf("Expected reversal leg %d to have its own transfer pair ID", leg.ID)
+		}
+	}
+
+	// Original transfer is kept for the audit trail
+	if _, err := transactionService.transactionRepo.GetByID(1, transfer.FromTransaction.ID); err != nil {
+		t.Errorf("Expected original transfer to be retained, got %v", err)
+	}
+
+	balances, err = calcService.CalculateAccountBalances(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !balances[1].CalculatedBalance.Equal(decimal.NewFromInt(1000)) || !balances[2].CalculatedBalance.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("Expected balances restored to 1000/200, got %s/%s", balances[1].CalculatedBalance, balances[2].CalculatedBalance)
+	}
+}
+
+func TestReverseTransfer_AlreadyReversed(t *testing.T) {
+	transactionService, _, transfer := createReverseTransferTestService(t)
+
+	if _, err := transactionService.ReverseTransfer(1, transfer.FromTransaction.ID); err != nil {
+		t.Fatalf("Expected first reversal to succeed, got %v", err)
+	}
+
+	_, err := transactionService.ReverseTransfer(1, transfer.ToTransaction.ID)
+	if err != domain.ErrTransferAlreadyReversed {
+		t.Errorf("Expected ErrTransferAlreadyReversed, got %v", err)
+	}
+}
+
+func TestReverseTransfer_NotTransfer(t *testing.T) {
+	transactionService, _, _ := createReverseTransferTestService(t)
+
+	created, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+		AccountID: 1,
+		Name:      "Groceries",
+		Amount:    decimal.NewFromInt(50),
+		Type:      domain.TransactionTypeExpense,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	_, err = transactionService.ReverseTransfer(1, created.ID)
+	if err != domain.ErrNotTransfer {
+		t.Errorf("Expected ErrNotTransfer, got %v", err)
+	}
+}
+
 // ============================================================
 // Category Assignment Tests (Story 4.2)
 // ============================================================
@@ -1139,6 +1833,206 @@ func TestCreateTransaction_WithCategory(t *testing.T) {
 	}
 }
 
+func TestCreateTransaction_InheritsCategoryTaxDeductible(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+	categoryID := int32(7)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+		Template:    domain.TemplateBank,
+	})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:              categoryID,
+		WorkspaceID:     workspaceID,
+		Name:            "Medical",
+		IsTaxDeductible: true,
+	})
+
+	input := CreateTransactionInput{
+		AccountID:  accountID,
+		Name:       "Clinic visit",
+		Amount:     decimal.NewFromFloat(120.00),
+		Type:       domain.TransactionTypeExpense,
+		CategoryID: &categoryID,
+	}
+	transaction, err := transactionService.CreateTransaction(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !transaction.IsTaxDeductible {
+		t.Error("Expected transaction to inherit the category's tax-deductible default")
+	}
+
+	// An explicit flag overrides the category default
+	notDeductible := false
+	input.IsTaxDeductible = &notDeductible
+	transaction, err = transactionService.CreateTransaction(workspaceID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if transaction.IsTaxDeductible {
+		t.Error("Expected explicit IsTaxDeductible=false to override the category default")
+	}
+}
+
+func TestUpdateTransaction_CategoryChangeAppliesTaxDefault(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+	groceriesID := int32(6)
+	medicalID := int32(7)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+		Template:    domain.TemplateBank,
+	})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: groceriesID, WorkspaceID: workspaceID, Name: "Groceries"})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: medicalID, WorkspaceID: workspaceID, Name: "Medical", IsTaxDeductible: true})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       accountID,
+		Name:            "Pharmacy",
+		Amount:          decimal.NewFromInt(40),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		CategoryID:      &groceriesID,
+	})
+
+	input := UpdateTransactionInput{
+		AccountID:       accountID,
+		Name:            "Pharmacy",
+		Amount:          decimal.NewFromInt(40),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		CategoryID:      &medicalID,
+	}
+	updated, err := transactionService.UpdateTransaction(workspaceID, 1, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !updated.IsTaxDeductible {
+		t.Error("Expected recategorizing to apply the new category's tax-deductible default")
+	}
+
+	// Moving back to a non-deductible category clears it again
+	input.CategoryID = &groceriesID
+	updated, err = transactionService.UpdateTransaction(workspaceID, 1, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.IsTaxDeductible {
+		t.Error("Expected recategorizing to a non-deductible category to clear the flag")
+	}
+}
+
+func newCloneTestService(t *testing.T) (*TransactionService, *testutil.MockTransactionRepository, *domain.Transaction) {
+	t.Helper()
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	categoryID := int32(7)
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Wallet", Template: domain.TemplateBank})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{ID: categoryID, WorkspaceID: 1, Name: "Food"})
+
+	groupID := int32(3)
+	loanID := int32(4)
+	notes := "Lunch with team"
+	source := &domain.Transaction{
+		ID:              50,
+		WorkspaceID:     1,
+		AccountID:       2,
+		Name:            "Lunch",
+		Amount:          decimal.NewFromFloat(42.50),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+		Notes:           &notes,
+		CategoryID:      &categoryID,
+		GroupID:         &groupID,
+		LoanID:          &loanID,
+	}
+	transactionRepo.AddTransaction(source)
+
+	return transactionService, transactionRepo, source
+}
+
+func TestCloneTransaction_InheritsCategoryAndAccount(t *testing.T) {
+	transactionService, _, source := newCloneTestService(t)
+
+	clone, err := transactionService.CloneTransaction(1, source.ID, TransactionCloneOverrides{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if clone.ID == source.ID {
+		t.Fatal("Expected a new transaction")
+	}
+	if clone.AccountID != source.AccountID {
+		t.Errorf("Expected account %d, got %d", source.AccountID, clone.AccountID)
+	}
+	if clone.CategoryID == nil || *clone.CategoryID != *source.CategoryID {
+		t.Errorf("Expected category %d, got %v", *source.CategoryID, clone.CategoryID)
+	}
+	if clone.Name != "Lunch" || !clone.Amount.Equal(source.Amount) || !clone.TransactionDate.Equal(source.TransactionDate) {
+		t.Errorf("Expected name/amount/date copied, got %s %s %v", clone.Name, clone.Amount, clone.TransactionDate)
+	}
+	if clone.Notes == nil || *clone.Notes != *source.Notes {
+		t.Errorf("Expected notes copied, got %v", clone.Notes)
+	}
+	if clone.IsPaid {
+		t.Error("Expected clone to be unpaid")
+	}
+	if clone.GroupID != nil || clone.LoanID != nil {
+		t.Errorf("Expected clone unlinked from group and loan, got group=%v loan=%v", clone.GroupID, clone.LoanID)
+	}
+}
+
+func TestCloneTransaction_WithOverrides(t *testing.T) {
+	transactionService, _, source := newCloneTestService(t)
+
+	newDate := time.Date(2026, time.April, 10, 0, 0, 0, 0, time.UTC)
+	newAmount := decimal.NewFromFloat(38.00)
+	clone, err := transactionService.CloneTransaction(1, source.ID, TransactionCloneOverrides{
+		TransactionDate: &newDate,
+		Amount:          &newAmount,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !clone.TransactionDate.Equal(newDate) {
+		t.Errorf("Expected date %v, got %v", newDate, clone.TransactionDate)
+	}
+	if !clone.Amount.Equal(newAmount) {
+		t.Errorf("Expected amount %s, got %s", newAmount, clone.Amount)
+	}
+
+	// Overrides are validated like a normal create
+	negative := decimal.NewFromInt(-5)
+	if _, err := transactionService.CloneTransaction(1, source.ID, TransactionCloneOverrides{Amount: &negative}); err != domain.ErrInvalidAmount {
+		t.Errorf("Expected ErrInvalidAmount, got %v", err)
+	}
+	if _, err := transactionService.CloneTransaction(1, 999, TransactionCloneOverrides{}); err != domain.ErrTransactionNotFound {
+		t.Errorf("Expected ErrTransactionNotFound, got %v", err)
+	}
+}
+
 func TestCreateTransaction_WithoutCategory(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
@@ -1304,16 +2198,17 @@ func TestUpdateTransaction_AddCategory(t *testing.T) {
 	}
 }
 
-func TestUpdateTransaction_RemoveCategory(t *testing.T) {
+func TestUpdateTransaction_FlagsEditFromTemplate(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
 	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
 	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	transactionService.SetRecurringTemplateRepository(templateRepo)
 
 	workspaceID := int32(1)
 	accountID := int32(1)
-	transactionID := int32(10)
-	categoryID := int32(5)
+	templateID := int32(3)
 
 	accountRepo.AddAccount(&domain.Account{
 		ID:          accountID,
@@ -1321,85 +2216,238 @@ func TestUpdateTransaction_RemoveCategory(t *testing.T) {
 		Name:        "Test Account",
 		Template:    domain.TemplateBank,
 	})
-
-	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
-		ID:          categoryID,
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          templateID,
 		WorkspaceID: workspaceID,
-		Name:        "Food & Dining",
+		Description: "Rent",
+		Amount:      decimal.NewFromInt(1500),
+		AccountID:   accountID,
+		Frequency:   "monthly",
 	})
-
-	// Add transaction WITH category
 	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              transactionID,
+		ID:              10,
 		WorkspaceID:     workspaceID,
 		AccountID:       accountID,
-		Name:            "Lunch",
-		Amount:          decimal.NewFromFloat(15.00),
+		Name:            "Rent",
+		Amount:          decimal.NewFromInt(1500),
 		Type:            domain.TransactionTypeExpense,
 		TransactionDate: time.Now(),
-		IsPaid:          true,
-		CategoryID:      &categoryID,
+		Source:          "recurring",
+		TemplateID:      &templateID,
 	})
 
 	input := UpdateTransactionInput{
 		AccountID:       accountID,
-		Name:            "Lunch",
-		Amount:          decimal.NewFromFloat(15.00),
+		Name:            "Rent",
+		Amount:          decimal.NewFromInt(1650), // Rent went up this month only
 		Type:            domain.TransactionTypeExpense,
 		TransactionDate: time.Now(),
-		CategoryID:      nil, // Removing category
 	}
 
-	updated, err := transactionService.UpdateTransaction(workspaceID, transactionID, input)
+	updated, err := transactionService.UpdateTransaction(workspaceID, 10, input)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
+	if !updated.ModifiedFromTemplate {
+		t.Error("Expected transaction to be flagged as modified from template")
+	}
+	if updated.TemplateID == nil || *updated.TemplateID != templateID {
+		t.Error("Expected transaction to stay linked to its template")
+	}
 
-	if updated.CategoryID != nil {
-		t.Errorf("Expected CategoryID to be nil after removal, got %d", *updated.CategoryID)
+	template, _ := templateRepo.GetByID(workspaceID, templateID)
+	if !template.Amount.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("Expected template amount to stay 1500, got %s", template.Amount.String())
+	}
+
+	// Editing back to the template amount clears the flag
+	input.Amount = decimal.NewFromInt(1500)
+	updated, err = transactionService.UpdateTransaction(workspaceID, 10, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.ModifiedFromTemplate {
+		t.Error("Expected flag to clear once the transaction matches its template again")
 	}
 }
 
-func TestUpdateTransaction_ChangeCategory(t *testing.T) {
+func TestUpdateAmount_KeepsTemplateLinkAndFlagsEdit(t *testing.T) {
 	transactionRepo := testutil.NewMockTransactionRepository()
 	accountRepo := testutil.NewMockAccountRepository()
 	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	templateRepo := testutil.NewMockRecurringTemplateRepository()
 	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	transactionService.SetRecurringTemplateRepository(templateRepo)
 
 	workspaceID := int32(1)
-	accountID := int32(1)
-	transactionID := int32(10)
-	oldCategoryID := int32(5)
-	newCategoryID := int32(6)
-
-	accountRepo.AddAccount(&domain.Account{
-		ID:          accountID,
-		WorkspaceID: workspaceID,
-		Name:        "Test Account",
-		Template:    domain.TemplateBank,
-	})
+	templateID := int32(3)
 
-	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
-		ID:          oldCategoryID,
-		WorkspaceID: workspaceID,
-		Name:        "Food & Dining",
-	})
-	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
-		ID:          newCategoryID,
+	templateRepo.AddTemplate(&domain.RecurringTemplate{
+		ID:          templateID,
 		WorkspaceID: workspaceID,
-		Name:        "Transportation",
+		Description: "Internet",
+		Amount:      decimal.NewFromInt(50),
+		AccountID:   1,
+		Frequency:   "monthly",
 	})
-
-	// Add transaction with old category
 	transactionRepo.AddTransaction(&domain.Transaction{
-		ID:              transactionID,
+		ID:              10,
 		WorkspaceID:     workspaceID,
-		AccountID:       accountID,
-		Name:            "Expense",
-		Amount:          decimal.NewFromFloat(25.00),
+		AccountID:       1,
+		Name:            "Internet",
+		Amount:          decimal.NewFromInt(50),
 		Type:            domain.TransactionTypeExpense,
-		TransactionDate: time.Now(),
-		IsPaid:          true,
+		TransactionDate: time.Now().AddDate(0, 1, 0),
+		Source:          "recurring",
+		TemplateID:      &templateID,
+		IsProjected:     true,
+	})
+
+	updated, err := transactionService.UpdateAmount(workspaceID, 10, decimal.NewFromInt(65))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.TemplateID == nil || *updated.TemplateID != templateID {
+		t.Error("Expected amount-only update to keep the template link")
+	}
+	if updated.Source != "recurring" || !updated.IsProjected {
+		t.Errorf("Expected source 'recurring' and projected, got %q and %v", updated.Source, updated.IsProjected)
+	}
+	if !updated.ModifiedFromTemplate {
+		t.Error("Expected amount-only update to flag the transaction as modified from template")
+	}
+}
+
+func TestToggleBilled_KeepsTemplateLink(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	templateID := int32(3)
+	deferredIntent := domain.SettlementIntentDeferred
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:                   1,
+		WorkspaceID:          workspaceID,
+		AccountID:            1,
+		Name:                 "Streaming",
+		Amount:               decimal.NewFromInt(15),
+		Type:                 domain.TransactionTypeExpense,
+		SettlementIntent:     &deferredIntent,
+		Source:               "recurring",
+		TemplateID:           &templateID,
+		ModifiedFromTemplate: true,
+	})
+
+	updated, err := transactionService.ToggleBilled(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.TemplateID == nil || *updated.TemplateID != templateID || updated.Source != "recurring" {
+		t.Error("Expected billing to keep the template link")
+	}
+	if !updated.ModifiedFromTemplate {
+		t.Error("Expected billing to keep the modified-from-template flag")
+	}
+}
+
+func TestUpdateTransaction_RemoveCategory(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+	transactionID := int32(10)
+	categoryID := int32(5)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+		Template:    domain.TemplateBank,
+	})
+
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          categoryID,
+		WorkspaceID: workspaceID,
+		Name:        "Food & Dining",
+	})
+
+	// Add transaction WITH category
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              transactionID,
+		WorkspaceID:     workspaceID,
+		AccountID:       accountID,
+		Name:            "Lunch",
+		Amount:          decimal.NewFromFloat(15.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		IsPaid:          true,
+		CategoryID:      &categoryID,
+	})
+
+	input := UpdateTransactionInput{
+		AccountID:       accountID,
+		Name:            "Lunch",
+		Amount:          decimal.NewFromFloat(15.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		CategoryID:      nil, // Removing category
+	}
+
+	updated, err := transactionService.UpdateTransaction(workspaceID, transactionID, input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated.CategoryID != nil {
+		t.Errorf("Expected CategoryID to be nil after removal, got %d", *updated.CategoryID)
+	}
+}
+
+func TestUpdateTransaction_ChangeCategory(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	accountID := int32(1)
+	transactionID := int32(10)
+	oldCategoryID := int32(5)
+	newCategoryID := int32(6)
+
+	accountRepo.AddAccount(&domain.Account{
+		ID:          accountID,
+		WorkspaceID: workspaceID,
+		Name:        "Test Account",
+		Template:    domain.TemplateBank,
+	})
+
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          oldCategoryID,
+		WorkspaceID: workspaceID,
+		Name:        "Food & Dining",
+	})
+	categoryRepo.AddBudgetCategory(&domain.BudgetCategory{
+		ID:          newCategoryID,
+		WorkspaceID: workspaceID,
+		Name:        "Transportation",
+	})
+
+	// Add transaction with old category
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              transactionID,
+		WorkspaceID:     workspaceID,
+		AccountID:       accountID,
+		Name:            "Expense",
+		Amount:          decimal.NewFromFloat(25.00),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		IsPaid:          true,
 		CategoryID:      &oldCategoryID,
 	})
 
@@ -1538,13 +2586,13 @@ func TestGetTransactions_OnAccessProjectionGeneration(t *testing.T) {
 	}
 
 	// This should trigger on-access projection generation
-	_, err := transactionService.GetTransactions(workspaceID, filters)
+	_, err := transactionService.GetTransactions(context.Background(), workspaceID, filters)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
 	// Verify projections were created
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	if err != nil {
 		t.Fatalf("Failed to get projections: %v", err)
 	}
@@ -1586,13 +2634,13 @@ func TestGetTransactions_NoProjectionsForPastDates(t *testing.T) {
 	}
 
 	// This should NOT trigger projection generation (past dates)
-	_, err := transactionService.GetTransactions(workspaceID, filters)
+	_, err := transactionService.GetTransactions(context.Background(), workspaceID, filters)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
 	// Verify no projections were created
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	if err != nil {
 		t.Fatalf("Failed to get projections: %v", err)
 	}
@@ -1634,13 +2682,13 @@ func TestGetTransactions_RespectsTemplateEndDate(t *testing.T) {
 		EndDate: &futureDate,
 	}
 
-	_, err := transactionService.GetTransactions(workspaceID, filters)
+	_, err := transactionService.GetTransactions(context.Background(), workspaceID, filters)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
 	// Verify projections don't go beyond end_date
-	projections, err := transactionRepo.GetProjectionsByTemplate(workspaceID, 1)
+	projections, err := transactionRepo.GetProjectionsByTemplate(context.Background(), workspaceID, 1)
 	if err != nil {
 		t.Fatalf("Failed to get projections: %v", err)
 	}
@@ -1670,7 +2718,7 @@ func TestGetTransactions_WithoutTemplateRepo_NoError(t *testing.T) {
 	}
 
 	// Should not error even without template repo
-	_, err := transactionService.GetTransactions(workspaceID, filters)
+	_, err := transactionService.GetTransactions(context.Background(), workspaceID, filters)
 	if err != nil {
 		t.Errorf("Expected no error without template repo, got %v", err)
 	}
@@ -2018,6 +3066,153 @@ func TestToggleBilled_WrongWorkspace_Error(t *testing.T) {
 	}
 }
 
+func TestGetTransactions_IncludeDeleted(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Now()
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, AccountID: 1, Name: "Live", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(10), TransactionDate: date, IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, AccountID: 1, Name: "Deleted", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(20), TransactionDate: date, IsPaid: true, DeletedAt: &deletedAt})
+
+	result, err := transactionService.GetTransactions(context.Background(), workspaceID, &domain.TransactionFilters{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != 100 {
+		t.Errorf("Expected only the live transaction by default, got %d", len(result.Data))
+	}
+
+	result, err = transactionService.GetTransactions(context.Background(), workspaceID, &domain.TransactionFilters{ListOptions: domain.ListOptions{IncludeDeleted: true}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Data) != 2 {
+		t.Errorf("Expected deleted transaction to be listed with IncludeDeleted, got %d", len(result.Data))
+	}
+}
+
+func TestGetTransactions_PaidRange(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	paidAt := time.Date(2026, 4, 3, 14, 30, 0, 0, time.UTC)
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              100,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Loan installment settled late",
+		Type:            domain.TransactionTypeExpense,
+		Amount:          decimal.NewFromInt(250),
+		TransactionDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		IsPaid:          true,
+		PaidAt:          &paidAt,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              101,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Still unpaid",
+		Type:            domain.TransactionTypeExpense,
+		Amount:          decimal.NewFromInt(40),
+		TransactionDate: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC),
+	})
+
+	paidRange := func(from, to time.Time) *domain.TransactionFilters {
+		return &domain.TransactionFilters{PaidStart: &from, PaidEnd: &to}
+	}
+
+	april, err := transactionService.GetTransactions(context.Background(), workspaceID,
+		paidRange(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(april.Data) != 1 || april.Data[0].ID != 100 {
+		t.Errorf("Expected the March transaction paid in April in the April paid range, got %d results", len(april.Data))
+	}
+
+	march, err := transactionService.GetTransactions(context.Background(), workspaceID,
+		paidRange(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(march.Data) != 0 {
+		t.Errorf("Expected nothing paid in March, got %d results", len(march.Data))
+	}
+
+	// The paid end date is inclusive of the whole day
+	sameDay, err := transactionService.GetTransactions(context.Background(), workspaceID,
+		paidRange(time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sameDay.Data) != 1 {
+		t.Errorf("Expected transaction paid during the end day to be included, got %d results", len(sameDay.Data))
+	}
+}
+
+// ========================================
+// GetOverdueTransactions Tests
+// ========================================
+
+func TestGetOverdueTransactions_ReturnsPastUnpaidOldestFirst(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	asOf := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, Name: "Electricity", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(80), TransactionDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, Name: "Rent", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(900), TransactionDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+	// Future-dated, due today, paid, and income are excluded
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: workspaceID, Name: "Internet", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(40), TransactionDate: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 103, WorkspaceID: workspaceID, Name: "Phone", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(30), TransactionDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 104, WorkspaceID: workspaceID, Name: "Water", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(25), TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), IsPaid: true})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 105, WorkspaceID: workspaceID, Name: "Salary", Type: domain.TransactionTypeIncome, Amount: decimal.NewFromInt(3000), TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)})
+
+	overdue, err := transactionService.GetOverdueTransactions(workspaceID, asOf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(overdue) != 2 {
+		t.Fatalf("Expected 2 overdue transactions, got %d", len(overdue))
+	}
+	if overdue[0].ID != 101 || overdue[1].ID != 100 {
+		t.Errorf("Expected oldest first [101 100], got [%d %d]", overdue[0].ID, overdue[1].ID)
+	}
+}
+
+func TestGetOverdueTransactions_ExcludesFutureAndPaid(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	asOf := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, Name: "Future", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(40), TransactionDate: asOf.AddDate(0, 0, 5)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, Name: "Paid", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(25), TransactionDate: asOf.AddDate(0, 0, -5), IsPaid: true})
+
+	overdue, err := transactionService.GetOverdueTransactions(workspaceID, asOf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(overdue) != 0 {
+		t.Errorf("Expected no overdue transactions, got %d", len(overdue))
+	}
+}
+
 // ========================================
 // GetOverdue Tests
 // ========================================
@@ -2418,3 +3613,596 @@ func TestUpdateTransaction_AutoDeleteEmptyGroupOnDateChange(t *testing.T) {
 		t.Error("Expected group to be auto-deleted when last child is ungrouped")
 	}
 }
+
+func TestCreateTransaction_Scheduled(t *testing.T) {
+	tests := []struct {
+		name          string
+		date          time.Time
+		wantScheduled bool
+	}{
+		{"future date stays scheduled", time.Now().AddDate(0, 0, 3), true},
+		{"today is not scheduled", time.Now(), false},
+		{"past date is not scheduled", time.Now().AddDate(0, 0, -3), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionRepo := testutil.NewMockTransactionRepository()
+			accountRepo := testutil.NewMockAccountRepository()
+			categoryRepo := testutil.NewMockBudgetCategoryRepository()
+			transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+			accountRepo.AddAccount(&domain.Account{
+				ID:          1,
+				WorkspaceID: 1,
+				Name:        "Test Account",
+			})
+
+			date := tt.date
+			transaction, err := transactionService.CreateTransaction(1, CreateTransactionInput{
+				AccountID:       1,
+				Name:            "Rent",
+				Amount:          decimal.NewFromInt(400),
+				Type:            domain.TransactionTypeExpense,
+				TransactionDate: &date,
+				IsScheduled:     true,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if transaction.IsScheduled != tt.wantScheduled {
+				t.Errorf("Expected IsScheduled %v, got %v", tt.wantScheduled, transaction.IsScheduled)
+			}
+		})
+	}
+}
+
+func TestPromoteScheduled(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	calculationService := NewCalculationService(accountRepo, transactionRepo)
+
+	workspaceID := int32(1)
+	accountRepo.AddAccount(&domain.Account{
+		ID:             1,
+		WorkspaceID:    workspaceID,
+		Name:           "Checking Account",
+		Template:       domain.TemplateBank,
+		InitialBalance: decimal.NewFromInt(1000),
+	})
+
+	due := &domain.Transaction{
+		ID:              1,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Due today",
+		Amount:          decimal.NewFromInt(100),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now(),
+		IsPaid:          true,
+		IsScheduled:     true,
+	}
+	future := &domain.Transaction{
+		ID:              2,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Next month",
+		Amount:          decimal.NewFromInt(200),
+		Type:            domain.TransactionTypeExpense,
+		TransactionDate: time.Now().AddDate(0, 1, 0),
+		IsPaid:          true,
+		IsScheduled:     true,
+	}
+	transactionRepo.AddTransaction(due)
+	transactionRepo.AddTransaction(future)
+
+	promoted, err := transactionService.PromoteScheduled(workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(promoted) != 1 || promoted[0].ID != due.ID {
+		t.Fatalf("Expected only the due transaction to be promoted, got %d", len(promoted))
+	}
+	if due.IsScheduled {
+		t.Error("Expected due transaction to no longer be scheduled")
+	}
+	if !future.IsScheduled {
+		t.Error("Expected future transaction to remain scheduled")
+	}
+
+	result, err := calculationService.CalculateAccountBalance(workspaceID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.CalculatedBalance.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("Expected balance 900 after promotion, got %s", result.CalculatedBalance.String())
+	}
+}
+
+func TestListByCursor_PagesThroughAllTransactions(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 250 transactions spread over 10 days, inserted out of order so many share a date
+	const total = 250
+	for i := 0; i < total; i++ {
+		id := int32((i*37)%total + 1)
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              id,
+			WorkspaceID:     workspaceID,
+			AccountID:       1,
+			Name:            "Fixture",
+			Amount:          decimal.NewFromInt(10),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: base.AddDate(0, 0, int(id)%10),
+		})
+	}
+	// Another workspace's and deleted transactions never appear
+	deletedAt := time.Now()
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 900, WorkspaceID: workspaceID, TransactionDate: base, DeletedAt: &deletedAt})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 901, WorkspaceID: 2, TransactionDate: base})
+
+	seen := make(map[int32]bool)
+	var previous *domain.Transaction
+	cursor := ""
+	pages := 0
+	for {
+		page, next, err := transactionService.ListByCursor(workspaceID, cursor, 100)
+		if err != nil {
+			t.Fatalf("Expected no error on page %d, got %v", pages+1, err)
+		}
+		pages++
+
+		for _, tx := range page {
+			if seen[tx.ID] {
+				t.Fatalf("Transaction %d returned twice", tx.ID)
+			}
+			seen[tx.ID] = true
+			if previous != nil {
+				if tx.TransactionDate.Before(previous.TransactionDate) ||
+					(tx.TransactionDate.Equal(previous.TransactionDate) && tx.ID < previous.ID) {
+					t.Fatalf("Transaction %d out of order after %d", tx.ID, previous.ID)
+				}
+			}
+			previous = tx
+		}
+
+		if next == "" {
+			break
+		}
+		if pages > 10 {
+			t.Fatal("Pagination did not terminate")
+		}
+		cursor = next
+	}
+
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+	if len(seen) != total {
+		t.Errorf("Expected %d transactions, got %d", total, len(seen))
+	}
+	if seen[900] || seen[901] {
+		t.Error("Expected deleted and foreign transactions to be excluded")
+	}
+}
+
+func TestListByCursor_InvalidCursor(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	_, _, err := transactionService.ListByCursor(1, "not-a-cursor", 100)
+	if err != domain.ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func addCCTransaction(repo *testutil.MockTransactionRepository, id int32, isPaid bool, billedAt *time.Time) {
+	intent := domain.SettlementIntentDeferred
+	repo.AddTransaction(&domain.Transaction{
+		ID:               id,
+		WorkspaceID:      1,
+		AccountID:        1,
+		Name:             "CC Purchase",
+		Amount:           decimal.NewFromInt(100),
+		Type:             domain.TransactionTypeExpense,
+		IsPaid:           isPaid,
+		BilledAt:         billedAt,
+		CCState:          domain.ComputeCCState(isPaid, billedAt),
+		SettlementIntent: &intent,
+	})
+}
+
+func TestBatchTransitionCCState_PendingToBilled(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	addCCTransaction(transactionRepo, 1, false, nil)
+	addCCTransaction(transactionRepo, 2, false, nil)
+
+	updated, err := transactionService.BatchTransitionCCState(1, []int32{1, 2}, domain.CCStateBilled)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("Expected 2 updated transactions, got %d", len(updated))
+	}
+	for _, tx := range updated {
+		if tx.BilledAt == nil || tx.IsPaid {
+			t.Errorf("Expected transaction %d to be billed and unpaid", tx.ID)
+		}
+		if *tx.CCState != domain.CCStateBilled {
+			t.Errorf("Expected transaction %d state billed, got %s", tx.ID, *tx.CCState)
+		}
+	}
+}
+
+func TestBatchTransitionCCState_SettledToPendingRejected(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	billedAt := time.Now().AddDate(0, 0, -10)
+	addCCTransaction(transactionRepo, 1, true, &billedAt)
+
+	updated, err := transactionService.BatchTransitionCCState(1, []int32{1}, domain.CCStatePending)
+	if !errors.Is(err, domain.ErrInvalidCCStateTransition) {
+		t.Fatalf("Expected ErrInvalidCCStateTransition, got %v", err)
+	}
+	if len(updated) != 0 {
+		t.Errorf("Expected no updated transactions, got %d", len(updated))
+	}
+
+	tx, _ := transactionRepo.GetByID(1, 1)
+	if !tx.IsPaid || tx.BilledAt == nil {
+		t.Error("Expected settled transaction to be left unchanged")
+	}
+}
+
+func TestBatchTransitionCCState_MixedOutcomesPerID(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	billedAt := time.Now().AddDate(0, 0, -10)
+	addCCTransaction(transactionRepo, 1, false, &billedAt) // billed -> settled: legal
+	addCCTransaction(transactionRepo, 2, false, nil)       // pending -> settled: skips billed
+	transactionRepo.AddTransaction(&domain.Transaction{    // not a CC transaction
+		ID:          3,
+		WorkspaceID: 1,
+		AccountID:   2,
+		Name:        "Groceries",
+		Amount:      decimal.NewFromInt(40),
+		Type:        domain.TransactionTypeExpense,
+	})
+
+	updated, err := transactionService.BatchTransitionCCState(1, []int32{1, 2, 3, 99}, domain.CCStateSettled)
+
+	var transitionErr *domain.CCTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected CCTransitionError, got %v", err)
+	}
+	if len(updated) != 1 || updated[0].ID != 1 || !updated[0].IsPaid {
+		t.Fatalf("Expected only transaction 1 to be settled, got %+v", updated)
+	}
+
+	expected := map[int32]error{
+		2:  domain.ErrInvalidCCStateTransition,
+		3:  domain.ErrNotCCTransaction,
+		99: domain.ErrTransactionNotFound,
+	}
+	if len(transitionErr.Failures) != len(expected) {
+		t.Fatalf("Expected %d failures, got %d", len(expected), len(transitionErr.Failures))
+	}
+	for id, want := range expected {
+		if transitionErr.Failures[id] != want {
+			t.Errorf("Expected failure %v for transaction %d, got %v", want, id, transitionErr.Failures[id])
+		}
+	}
+}
+
+func addSourcedTransaction(repo *testutil.MockTransactionRepository, id, workspaceID int32, source string) {
+	repo.AddTransaction(&domain.Transaction{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		AccountID:   1,
+		Name:        "Sourced",
+		Amount:      decimal.NewFromInt(50),
+		Type:        domain.TransactionTypeExpense,
+		Source:      source,
+	})
+}
+
+func TestGetTransactions_FilterByHasLocation(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	location := "Airport"
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              100,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Taxi",
+		Type:            domain.TransactionTypeExpense,
+		Amount:          decimal.NewFromInt(30),
+		TransactionDate: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC),
+		Location:        &location,
+	})
+	transactionRepo.AddTransaction(&domain.Transaction{
+		ID:              101,
+		WorkspaceID:     workspaceID,
+		AccountID:       1,
+		Name:            "Subscription",
+		Type:            domain.TransactionTypeExpense,
+		Amount:          decimal.NewFromInt(10),
+		TransactionDate: time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC),
+	})
+
+	hasLocation := true
+	result, err := transactionService.GetTransactions(context.Background(), workspaceID, &domain.TransactionFilters{HasLocation: &hasLocation})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != 100 {
+		t.Errorf("Expected only the geo-tagged transaction, got %d results", len(result.Data))
+	}
+}
+
+func TestGetTransactions_FilterBySource(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	addSourcedTransaction(transactionRepo, 100, 1, domain.TransactionSourceManual)
+	addSourcedTransaction(transactionRepo, 101, 1, domain.TransactionSourceLoan)
+	addSourcedTransaction(transactionRepo, 102, 1, domain.TransactionSourceRecurring)
+	addSourcedTransaction(transactionRepo, 103, 1, domain.TransactionSourceLoan)
+
+	source := domain.TransactionSourceLoan
+	result, err := transactionService.GetTransactions(context.Background(), 1, &domain.TransactionFilters{Source: &source})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.TotalItems != 2 {
+		t.Fatalf("Expected 2 loan transactions, got %d", result.TotalItems)
+	}
+	for _, txn := range result.Data {
+		if txn.Source != domain.TransactionSourceLoan {
+			t.Errorf("Expected only loan transactions, got source %q", txn.Source)
+		}
+	}
+}
+
+func TestGetTransactions_FilterByCustomSource(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	addSourcedTransaction(transactionRepo, 100, 1, "import")
+	addSourcedTransaction(transactionRepo, 101, 1, domain.TransactionSourceManual)
+
+	source := "import"
+	result, err := transactionService.GetTransactions(context.Background(), 1, &domain.TransactionFilters{Source: &source})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.TotalItems != 1 {
+		t.Errorf("Expected 1 imported transaction, got %d", result.TotalItems)
+	}
+
+	unknown := "spreadsheet"
+	_, err = transactionService.GetTransactions(context.Background(), 1, &domain.TransactionFilters{Source: &unknown})
+	if err != domain.ErrInvalidTransactionSource {
+		t.Errorf("Expected ErrInvalidTransactionSource, got %v", err)
+	}
+}
+
+func TestDistinctSources(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	addSourcedTransaction(transactionRepo, 100, 1, domain.TransactionSourceRecurring)
+	addSourcedTransaction(transactionRepo, 101, 1, domain.TransactionSourceManual)
+	addSourcedTransaction(transactionRepo, 102, 1, "import")
+	addSourcedTransaction(transactionRepo, 103, 1, domain.TransactionSourceManual)
+	// Other workspace's sources are not listed
+	addSourcedTransaction(transactionRepo, 104, 2, domain.TransactionSourceLoan)
+
+	sources, err := transactionService.DistinctSources(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"import", "manual", "recurring"}
+	if len(sources) != len(expected) {
+		t.Fatalf("Expected sources %v, got %v", expected, sources)
+	}
+	for i, source := range expected {
+		if sources[i] != source {
+			t.Errorf("Expected sources %v, got %v", expected, sources)
+			break
+		}
+	}
+}
+
+// createBulkMoveTestService sets up two bank accounts in workspace 1 (1000 and 500 initial) and
+// three expenses of 100, 200 and 300 on account 1
+func createBulkMoveTestService() (*TransactionService, *CalculationService, *testutil.MockAccountRepository) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+	calcService := NewCalculationService(accountRepo, transactionRepo)
+
+	accountRepo.AddAccount(&domain.Account{ID: 1, WorkspaceID: 1, Name: "Checking", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, InitialBalance: decimal.NewFromInt(1000)})
+	accountRepo.AddAccount(&domain.Account{ID: 2, WorkspaceID: 1, Name: "Savings", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, InitialBalance: decimal.NewFromInt(500)})
+
+	for i, amount := range []int64{100, 200, 300} {
+		transactionRepo.AddTransaction(&domain.Transaction{
+			ID:              int32(101 + i),
+			WorkspaceID:     1,
+			AccountID:       1,
+			Name:            "Groceries",
+			Amount:          decimal.NewFromInt(amount),
+			Type:            domain.TransactionTypeExpense,
+			TransactionDate: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			IsPaid:          true,
+		})
+	}
+
+	return transactionService, calcService, accountRepo
+}
+
+func TestBulkMoveAccount_UpdatesBothBalances(t *testing.T) {
+	transactionService, calcService, _ := createBulkMoveTestService()
+
+	count, err := transactionService.BulkMoveAccount(1, []int32{101, 102, 103}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 transactions moved, got %d", count)
+	}
+
+	source, err := calcService.CalculateAccountBalance(1, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !source.CalculatedBalance.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("Expected source balance 1000, got %s", source.CalculatedBalance.String())
+	}
+
+	destination, err := calcService.CalculateAccountBalance(1, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !destination.CalculatedBalance.Equal(decimal.NewFromInt(-100)) {
+		t.Errorf("Expected destination balance -100, got %s", destination.CalculatedBalance.String())
+	}
+}
+
+func TestBulkMoveAccount_RejectsForeignOrArchivedTarget(t *testing.T) {
+	transactionService, calcService, accountRepo := createBulkMoveTestService()
+
+	deletedAt := time.Now()
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 2, Name: "Other workspace", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset})
+	accountRepo.AddAccount(&domain.Account{ID: 4, WorkspaceID: 1, Name: "Archived", Template: domain.TemplateBank, AccountType: domain.AccountTypeAsset, DeletedAt: &deletedAt})
+
+	for _, target := range []int32{3, 4} {
+		count, err := transactionService.BulkMoveAccount(1, []int32{101, 102, 103}, target)
+		if !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Errorf("Expected ErrAccountNotFound for account %d, got %v", target, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected nothing moved to account %d, got %d", target, count)
+		}
+	}
+
+	source, _ := calcService.CalculateAccountBalance(1, 1)
+	if !source.CalculatedBalance.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("Expected source balance to stay 400, got %s", source.CalculatedBalance.String())
+	}
+}
+
+func TestBulkMoveAccount_RejectsCreditCardBoundary(t *testing.T) {
+	transactionService, calcService, accountRepo := createBulkMoveTestService()
+
+	accountRepo.AddAccount(&domain.Account{ID: 3, WorkspaceID: 1, Name: "Visa", Template: domain.TemplateCreditCard, AccountType: domain.AccountTypeLiability})
+
+	count, err := transactionService.BulkMoveAccount(1, []int32{101, 102, 103}, 3)
+	if !errors.Is(err, domain.ErrCannotMoveAcrossCreditCard) {
+		t.Errorf("Expected ErrCannotMoveAcrossCreditCard, got %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected nothing moved, got %d", count)
+	}
+
+	source, _ := calcService.CalculateAccountBalance(1, 1)
+	if !source.CalculatedBalance.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("Expected source balance to stay 400, got %s", source.CalculatedBalance.String())
+	}
+}
+
+func TestBulkMoveAccount_RejectsLoanTransactions(t *testing.T) {
+	transactionService, calcService, _ := createBulkMoveTestService()
+
+	loanID := int32(9)
+	loanPayment, _ := transactionService.transactionRepo.GetByID(1, 102)
+	loanPayment.LoanID = &loanID
+
+	count, err := transactionService.BulkMoveAccount(1, []int32{101, 102, 103}, 2)
+	if !errors.Is(err, domain.ErrCannotMoveLoanTransaction) {
+		t.Errorf("Expected ErrCannotMoveLoanTransaction, got %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected nothing moved, got %d", count)
+	}
+
+	source, _ := calcService.CalculateAccountBalance(1, 1)
+	if !source.CalculatedBalance.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("Expected source balance to stay 400, got %s", source.CalculatedBalance.String())
+	}
+}
+
+func TestListUncategorized_ReturnsMonthsUncategorizedExpenses(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	workspaceID := int32(1)
+	categoryID := int32(7)
+	pairID := uuid.New()
+
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 100, WorkspaceID: workspaceID, Name: "Coffee", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(12), TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 101, WorkspaceID: workspaceID, Name: "Parking", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(4), TransactionDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)})
+	// Categorized, income, transfer and other-month transactions are excluded
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 102, WorkspaceID: workspaceID, Name: "Groceries", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(150), TransactionDate: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), CategoryID: &categoryID})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 103, WorkspaceID: workspaceID, Name: "Salary", Type: domain.TransactionTypeIncome, Amount: decimal.NewFromInt(3000), TransactionDate: time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC)})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 104, WorkspaceID: workspaceID, Name: "To savings", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(500), TransactionDate: time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC), TransferPairID: &pairID})
+	transactionRepo.AddTransaction(&domain.Transaction{ID: 105, WorkspaceID: workspaceID, Name: "Snacks", Type: domain.TransactionTypeExpense, Amount: decimal.NewFromInt(8), TransactionDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+
+	uncategorized, err := transactionService.ListUncategorized(workspaceID, "2026-01")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ids := map[int32]bool{}
+	for _, txn := range uncategorized {
+		ids[txn.ID] = true
+	}
+	if len(uncategorized) != 2 || !ids[100] || !ids[101] {
+		t.Errorf("Expected uncategorized expenses 100 and 101, got %v", ids)
+	}
+}
+
+func TestListUncategorized_InvalidMonth(t *testing.T) {
+	transactionRepo := testutil.NewMockTransactionRepository()
+	accountRepo := testutil.NewMockAccountRepository()
+	categoryRepo := testutil.NewMockBudgetCategoryRepository()
+	transactionService := NewTransactionService(transactionRepo, accountRepo, categoryRepo)
+
+	if _, err := transactionService.ListUncategorized(1, "2026-13"); err != domain.ErrInvalidMonthFormat {
+		t.Errorf("Expected ErrInvalidMonthFormat, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// WorkspaceService handles workspace sharing: invites and memberships
+type WorkspaceService struct {
+	workspaceRepo domain.WorkspaceRepository
+	inviteRepo    domain.WorkspaceInviteRepository
+	userRepo      domain.UserRepository
+	accountRepo   domain.AccountRepository
+}
+
+// NewWorkspaceService creates a new WorkspaceService
+func NewWorkspaceService(workspaceRepo domain.WorkspaceRepository, inviteRepo domain.WorkspaceInviteRepository, userRepo domain.UserRepository) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo: workspaceRepo,
+		inviteRepo:    inviteRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// SetAccountRepository sets the account repository used to validate the round-up savings account
+func (s *WorkspaceService) SetAccountRepository(accountRepo domain.AccountRepository) {
+	s.accountRepo = accountRepo
+}
+
+// CreateInviteInput contains the fields for inviting a collaborator
+type CreateInviteInput struct {
+	Email string
+	Role  domain.WorkspaceRole
+}
+
+// CreateInvite invites someone to the workspace. Only owners may invite.
+func (s *WorkspaceService) CreateInvite(workspaceID int32, inviterAuth0ID string, input CreateInviteInput) (*domain.WorkspaceInvite, error) {
+	email := strings.TrimSpace(input.Email)
+	if email == "" {
+		return nil, domain.ErrInviteEmailRequired
+	}
+	if input.Role != domain.WorkspaceRoleEditor && input.Role != domain.WorkspaceRoleViewer {
+		return nil, domain.ErrInvalidInviteRole
+	}
+
+	inviter, err := s.userRepo.GetByAuth0ID(inviterAuth0ID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.workspaceRepo.GetMemberRole(workspaceID, inviter.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWorkspaceMemberNotFound) {
+			return nil, domain.ErrForbidden
+		}
+		return nil, err
+	}
+	if role != domain.WorkspaceRoleOwner {
+		return nil, domain.ErrForbidden
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &domain.WorkspaceInvite{
+		WorkspaceID: workspaceID,
+		Email:       strings.ToLower(email),
+		Role:        input.Role,
+		Token:       token,
+		InvitedBy:   inviter.ID,
+		ExpiresAt:   time.Now().Add(domain.InviteExpiry),
+	}
+	return s.inviteRepo.Create(invite)
+}
+
+// AcceptInvite adds the authenticated user to the invite's workspace.
+// The user's email must match the address the invite was sent to.
+func (s *WorkspaceService) AcceptInvite(token string, auth0ID string) (*domain.WorkspaceMember, error) {
+	invite, err := s.inviteRepo.GetByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if invite.AcceptedAt != nil {
+		return nil, domain.ErrInviteAlreadyAccepted
+	}
+	if invite.IsExpired(time.Now()) {
+		return nil, domain.ErrInviteExpired
+	}
+
+	user, err := s.userRepo.GetByAuth0ID(auth0ID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invite.Email) {
+		return nil, domain.ErrInviteEmailMismatch
+	}
+
+	member, err := s.inviteRepo.Accept(invite.ID, &domain.WorkspaceMember{
+		WorkspaceID: invite.WorkspaceID,
+		UserID:      user.ID,
+		Role:        invite.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int32("workspace_id", invite.WorkspaceID).Str("user_id", user.ID.String()).Str("role", string(invite.Role)).Msg("Workspace invite accepted")
+	return member, nil
+}
+
+// GetSettings returns the workspace's display settings
+func (s *WorkspaceService) GetSettings(workspaceID int32) (*domain.WorkspaceSettings, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return workspaceSettings(workspace), nil
+}
+
+// UpdateCurrency sets the workspace's ISO 4217 currency code
+func (s *WorkspaceService) UpdateCurrency(workspaceID int32, currency string) (*domain.WorkspaceSettings, error) {
+	code, err := domain.NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.UpdateCurrency(workspaceID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Str("currency", code).Msg("Workspace currency updated")
+	return workspaceSettings(workspace), nil
+}
+
+// UpdateRoundUpSavingsAccount sets the account each expense's round-up is transferred to.
+// A nil accountID disables round-up savings. The account must belong to the workspace and
+// cannot be a credit card.
+func (s *WorkspaceService) UpdateRoundUpSavingsAccount(workspaceID int32, accountID *int32) (*domain.WorkspaceSettings, error) {
+	if accountID != nil {
+		account, err := s.accountRepo.GetByID(workspaceID, *accountID)
+		if err != nil {
+			return nil, err
+		}
+		if account.Template == domain.TemplateCreditCard {
+			return nil, domain.ErrInvalidRoundUpAccount
+		}
+	}
+
+	workspace, err := s.workspaceRepo.UpdateRoundUpSavingsAccount(workspaceID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Bool("enabled", accountID != nil).Msg("Workspace round-up savings updated")
+	return workspaceSettings(workspace), nil
+}
+
+// UpdateGenerationHorizon sets how many months past the current one recurring generation covers,
+// from 0 (current month only) to MaxGenerationHorizonMonths
+func (s *WorkspaceService) UpdateGenerationHorizon(workspaceID int32, months int32) (*domain.WorkspaceSettings, error) {
+	if months < 0 || months > domain.MaxGenerationHorizonMonths {
+		return nil, domain.ErrInvalidGenerationHorizon
+	}
+
+	workspace, err := s.workspaceRepo.UpdateGenerationHorizon(workspaceID, months)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int32("workspace_id", workspaceID).Int32("months", months).Msg("Workspace generation horizon updated")
+	return workspaceSettings(workspace), nil
+}
+
+func workspaceSettings(workspace *domain.Workspace) *domain.WorkspaceSettings {
+	currency := workspace.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	return &domain.WorkspaceSettings{
+		Currency:                currency,
+		CurrencySymbol:          domain.CurrencySymbol(currency),
+		RoundUpSavingsAccountID: workspace.RoundUpSavingsAccountID,
+		GenerationHorizonMonths: workspace.GenerationHorizonMonths,
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
+	"github.com/dafibh/fortuna/fortuna-backend/internal/testutil"
+	"github.com/google/uuid"
+)
+
+// createWorkspaceTestService sets up an owner with workspace 1 and an invitee user
+func createWorkspaceTestService() (*WorkspaceService, *testutil.MockWorkspaceRepository, *testutil.MockWorkspaceInviteRepository, *domain.User, *domain.User) {
+	userRepo := testutil.NewMockUserRepository()
+	workspaceRepo := testutil.NewMockWorkspaceRepository()
+	inviteRepo := testutil.NewMockWorkspaceInviteRepository(workspaceRepo)
+
+	owner := &domain.User{ID: uuid.New(), Auth0ID: "auth0|owner", Email: "owner@example.com"}
+	invitee := &domain.User{ID: uuid.New(), Auth0ID: "auth0|invitee", Email: "friend@example.com"}
+	userRepo.AddUser(owner)
+	userRepo.AddUser(invitee)
+	workspaceRepo.AddWorkspace(&domain.Workspace{ID: 1, UserID: owner.ID, Name: "Household"}, owner.Auth0ID)
+
+	return NewWorkspaceService(workspaceRepo, inviteRepo, userRepo), workspaceRepo, inviteRepo, owner, invitee
+}
+
+func TestCreateInvite_Success(t *testing.T) {
+	svc, _, _, owner, _ := createWorkspaceTestService()
+
+	invite, err := svc.CreateInvite(1, owner.Auth0ID, CreateInviteInput{Email: "Friend@Example.com", Role: domain.WorkspaceRoleEditor})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if invite.Token == "" {
+		t.Error("Expected token to be generated")
+	}
+	if invite.Email != "friend@example.com" {
+		t.Errorf("Expected normalized email, got %s", invite.Email)
+	}
+	if invite.Role != domain.WorkspaceRoleEditor {
+		t.Errorf("Expected role editor, got %s", invite.Role)
+	}
+	if !invite.ExpiresAt.After(time.Now()) {
+		t.Error("Expected expiry in the future")
+	}
+}
+
+func TestCreateInvite_InvalidRole(t *testing.T) {
+	svc, _, _, owner, _ := createWorkspaceTestService()
+
+	_, err := svc.CreateInvite(1, owner.Auth0ID, CreateInviteInput{Email: "friend@example.com", Role: domain.WorkspaceRoleOwner})
+	if !errors.Is(err, domain.ErrInvalidInviteRole) {
+		t.Errorf("Expected ErrInvalidInviteRole, got %v", err)
+	}
+}
+
+func TestCreateInvite_NonOwnerForbidden(t *testing.T) {
+	svc, workspaceRepo, _, _, invitee := createWorkspaceTestService()
+	workspaceRepo.AddMember(&domain.WorkspaceMember{WorkspaceID: 1, UserID: invitee.ID, Role: domain.WorkspaceRoleEditor})
+
+	_, err := svc.CreateInvite(1, invitee.Auth0ID, CreateInviteInput{Email: "other@example.com", Role: domain.WorkspaceRoleViewer})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAcceptInvite_AddsMember(t *testing.T) {
+	svc, workspaceRepo, _, owner, invitee := createWorkspaceTestService()
+
+	invite, err := svc.CreateInvite(1, owner.Auth0ID, CreateInviteInput{Email: invitee.Email, Role: domain.WorkspaceRoleViewer})
+	if err != nil {
+		t.Fatalf("Expected no error creating invite, got %v", err)
+	}
+
+	member, err := svc.AcceptInvite(invite.Token, invitee.Auth0ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if member.WorkspaceID != 1 || member.UserID != invitee.ID {
+		t.Errorf("Unexpected member: %+v", member)
+	}
+
+	role, err := workspaceRepo.GetMemberRole(1, invitee.ID)
+	if err != nil {
+		t.Fatalf("Expected invitee to be a member, got %v", err)
+	}
+	if role != domain.WorkspaceRoleViewer {
+		t.Errorf("Expected role viewer, got %s", role)
+	}
+
+	// Token is single-use
+	_, err = svc.AcceptInvite(invite.Token, invitee.Auth0ID)
+	if !errors.Is(err, domain.ErrInviteAlreadyAccepted) {
+		t.Errorf("Expected ErrInviteAlreadyAccepted on reuse, got %v", err)
+	}
+}
+
+func TestAcceptInvite_OwnerKeepsRole(t *testing.T) {
+	svc, workspaceRepo, _, owner, _ := createWorkspaceTestService()
+
+	invite, err := svc.CreateInvite(1, owner.Auth0ID, CreateInviteInput{Email: owner.Email, Role: domain.WorkspaceRoleViewer})
+	if err != nil {
+		t.Fatalf("Expected no error creating invite, got %v", err)
+	}
+
+	member, err := svc.AcceptInvite(invite.Token, owner.Auth0ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if member.Role != domain.WorkspaceRoleOwner {
+		t.Errorf("Expected returned role owner, got %s", member.Role)
+	}
+
+	role, err := workspaceRepo.GetMemberRole(1, owner.ID)
+	if err != nil {
+		t.Fatalf("Expected owner to remain a member, got %v", err)
+	}
+	if role != domain.WorkspaceRoleOwner {
+		t.Errorf("Expected owner to stay owner after accepting a viewer invite, got %s", role)
+	}
+}
+
+func TestAcceptInvite_ExpiredToken(t *testing.T) {
+	svc, workspaceRepo, inviteRepo, owner, invitee := createWorkspaceTestService()
+	inviteRepo.AddInvite(&domain.WorkspaceInvite{
+		ID:          1,
+		WorkspaceID: 1,
+		Email:       invitee.Email,
+		Role:        domain.WorkspaceRoleEditor,
+		Token:       "expired-token",
+		InvitedBy:   owner.ID,
+		ExpiresAt:   time.Now().Add(-time.Hour),
+	})
+
+	_, err := svc.AcceptInvite("expired-token", invitee.Auth0ID)
+	if !errors.Is(err, domain.ErrInviteExpired) {
+		t.Errorf("Expected ErrInviteExpired, got %v", err)
+	}
+	if _, err := workspaceRepo.GetMemberRole(1, invitee.ID); !errors.Is(err, domain.ErrWorkspaceMemberNotFound) {
+		t.Errorf("Expected invitee not to be a member, got %v", err)
+	}
+}
+
+func TestAcceptInvite_EmailMismatch(t *testing.T) {
+	svc, _, _, owner, invitee := createWorkspaceTestService()
+
+	invite, err := svc.CreateInvite(1, owner.Auth0ID, CreateInviteInput{Email: "someone-else@example.com", Role: domain.WorkspaceRoleEditor})
+	if err != nil {
+		t.Fatalf("Expected no error creating invite, got %v", err)
+	}
+
+	_, err = svc.AcceptInvite(invite.Token, invitee.Auth0ID)
+	if !errors.Is(err, domain.ErrInviteEmailMismatch) {
+		t.Errorf("Expected ErrInviteEmailMismatch, got %v", err)
+	}
+}
+
+func TestUpdateCurrency_ValidCode(t *testing.T) {
+	svc, workspaceRepo, _, _, _ := createWorkspaceTestService()
+
+	settings, err := svc.UpdateCurrency(1, " usd ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings.Currency != "USD" {
+		t.Errorf("Expected normalized currency USD, got %s", settings.Currency)
+	}
+	if settings.CurrencySymbol != "$" {
+		t.Errorf("Expected symbol $, got %s", settings.CurrencySymbol)
+	}
+
+	workspace, _ := workspaceRepo.GetByID(1)
+	if workspace.Currency != "USD" {
+		t.Errorf("Expected stored currency USD, got %s", workspace.Currency)
+	}
+}
+
+func TestUpdateCurrency_InvalidCode(t *testing.T) {
+	svc, workspaceRepo, _, _, _ := createWorkspaceTestService()
+
+	for _, code := range []string{"RM", "XYZ", ""} {
+		if _, err := svc.UpdateCurrency(1, code); !errors.Is(err, domain.ErrInvalidCurrency) {
+			t.Errorf("Expected ErrInvalidCurrency for %q, got %v", code, err)
+		}
+	}
+
+	workspace, _ := workspaceRepo.GetByID(1)
+	if workspace.Currency != domain.DefaultCurrency {
+		t.Errorf("Expected currency to stay %s, got %s", domain.DefaultCurrency, workspace.Currency)
+	}
+}
+
+func TestUpdateGenerationHorizon_CappedAtTwelve(t *testing.T) {
+	svc, workspaceRepo, _, _, _ := createWorkspaceTestService()
+
+	settings, err := svc.UpdateGenerationHorizon(1, 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings.GenerationHorizonMonths != 12 {
+		t.Errorf("Expected horizon 12, got %d", settings.GenerationHorizonMonths)
+	}
+
+	for _, months := range []int32{-1, 13} {
+		if _, err := svc.UpdateGenerationHorizon(1, months); !errors.Is(err, domain.ErrInvalidGenerationHorizon) {
+			t.Errorf("Expected ErrInvalidGenerationHorizon for %d, got %v", months, err)
+		}
+	}
+
+	workspace, _ := workspaceRepo.GetByID(1)
+	if workspace.GenerationHorizonMonths != 12 {
+		t.Errorf("Expected stored horizon to stay 12, got %d", workspace.GenerationHorizonMonths)
+	}
+}
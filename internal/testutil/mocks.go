@@ -3,14 +3,51 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dafibh/fortuna/fortuna-backend/internal/domain"
 	"github.com/dafibh/fortuna/fortuna-backend/internal/websocket"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 )
 
+// MockTx is a no-op pgx.Tx for running transactional service paths against the mock
+// repositories, which ignore the tx they are given. Only Commit and Rollback are implemented.
+type MockTx struct {
+	pgx.Tx
+	Committed  bool
+	RolledBack bool
+}
+
+// Commit marks the transaction committed
+func (t *MockTx) Commit(ctx context.Context) error {
+	t.Committed = true
+	return nil
+}
+
+// Rollback marks the transaction rolled back; like pgx, it is a no-op after Commit
+func (t *MockTx) Rollback(ctx context.Context) error {
+	if !t.Committed {
+		t.RolledBack = true
+	}
+	return nil
+}
+
+// MockTxBeginner hands out MockTx transactions in place of a *pgxpool.Pool
+type MockTxBeginner struct {
+	Txs []*MockTx
+}
+
+// Begin starts a new MockTx
+func (b *MockTxBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx := &MockTx{}
+	b.Txs = append(b.Txs, tx)
+	return tx, nil
+}
+
 // MockUserRepository is a mock implementation of domain.UserRepository
 type MockUserRepository struct {
 	Users    map[string]*domain.User
@@ -101,6 +138,8 @@ type MockWorkspaceRepository struct {
 	Workspaces    map[int32]*domain.Workspace
 	ByUserID      map[uuid.UUID]*domain.Workspace
 	ByUserAuth0ID map[string]*domain.Workspace
+	AllByAuth0ID  map[string][]*domain.Workspace
+	Members       map[int32]map[uuid.UUID]domain.WorkspaceRole
 	NextID        int32
 	GetByUserIDFn func(userID uuid.UUID) (*domain.Workspace, error)
 }
@@ -111,6 +150,8 @@ func NewMockWorkspaceRepository() *MockWorkspaceRepository {
 		Workspaces:    make(map[int32]*domain.Workspace),
 		ByUserID:      make(map[uuid.UUID]*domain.Workspace),
 		ByUserAuth0ID: make(map[string]*domain.Workspace),
+		AllByAuth0ID:  make(map[string][]*domain.Workspace),
+		Members:       make(map[int32]map[uuid.UUID]domain.WorkspaceRole),
 		NextID:        1,
 	}
 }
@@ -142,12 +183,21 @@ func (m *MockWorkspaceRepository) GetByUserAuth0ID(auth0ID string) (*domain.Work
 	return nil, domain.ErrWorkspaceNotFound
 }
 
+// ListByUserAuth0ID retrieves all workspaces for a user's Auth0 ID
+func (m *MockWorkspaceRepository) ListByUserAuth0ID(auth0ID string) ([]*domain.Workspace, error) {
+	return m.AllByAuth0ID[auth0ID], nil
+}
+
 // Create creates a new workspace
 func (m *MockWorkspaceRepository) Create(workspace *domain.Workspace) (*domain.Workspace, error) {
 	workspace.ID = m.NextID
 	m.NextID++
+	if workspace.Currency == "" {
+		workspace.Currency = domain.DefaultCurrency
+	}
 	m.Workspaces[workspace.ID] = workspace
 	m.ByUserID[workspace.UserID] = workspace
+	m.setMember(workspace.ID, workspace.UserID, domain.WorkspaceRoleOwner)
 	return workspace, nil
 }
 
@@ -161,6 +211,36 @@ func (m *MockWorkspaceRepository) Update(workspace *domain.Workspace) (*domain.W
 	return workspace, nil
 }
 
+// UpdateCurrency sets a workspace's currency
+func (m *MockWorkspaceRepository) UpdateCurrency(id int32, currency string) (*domain.Workspace, error) {
+	ws, ok := m.Workspaces[id]
+	if !ok {
+		return nil, domain.ErrWorkspaceNotFound
+	}
+	ws.Currency = currency
+	return ws, nil
+}
+
+// UpdateRoundUpSavingsAccount sets a workspace's round-up savings account
+func (m *MockWorkspaceRepository) UpdateRoundUpSavingsAccount(id int32, accountID *int32) (*domain.Workspace, error) {
+	ws, ok := m.Workspaces[id]
+	if !ok {
+		return nil, domain.ErrWorkspaceNotFound
+	}
+	ws.RoundUpSavingsAccountID = accountID
+	return ws, nil
+}
+
+// UpdateGenerationHorizon sets a workspace's recurring generation horizon
+func (m *MockWorkspaceRepository) UpdateGenerationHorizon(id int32, months int32) (*domain.Workspace, error) {
+	ws, ok := m.Workspaces[id]
+	if !ok {
+		return nil, domain.ErrWorkspaceNotFound
+	}
+	ws.GenerationHorizonMonths = months
+	return ws, nil
+}
+
 // Delete deletes a workspace by ID
 func (m *MockWorkspaceRepository) Delete(id int32) error {
 	ws, ok := m.Workspaces[id]
@@ -172,15 +252,102 @@ func (m *MockWorkspaceRepository) Delete(id int32) error {
 	return nil
 }
 
+// AddMember adds or updates a workspace member
+func (m *MockWorkspaceRepository) AddMember(member *domain.WorkspaceMember) (*domain.WorkspaceMember, error) {
+	if current, ok := m.Members[member.WorkspaceID][member.UserID]; ok && current.Outranks(member.Role) {
+		member.Role = current
+	}
+	m.setMember(member.WorkspaceID, member.UserID, member.Role)
+	return member, nil
+}
+
+// GetMemberRole retrieves a user's role in a workspace
+func (m *MockWorkspaceRepository) GetMemberRole(workspaceID int32, userID uuid.UUID) (domain.WorkspaceRole, error) {
+	if role, ok := m.Members[workspaceID][userID]; ok {
+		return role, nil
+	}
+	return "", domain.ErrWorkspaceMemberNotFound
+}
+
+func (m *MockWorkspaceRepository) setMember(workspaceID int32, userID uuid.UUID, role domain.WorkspaceRole) {
+	if m.Members[workspaceID] == nil {
+		m.Members[workspaceID] = make(map[uuid.UUID]domain.WorkspaceRole)
+	}
+	m.Members[workspaceID][userID] = role
+}
+
 // AddWorkspace adds a workspace to the mock repository (helper for tests)
+// The workspace's UserID is recorded as its owner.
 func (m *MockWorkspaceRepository) AddWorkspace(workspace *domain.Workspace, auth0ID string) {
+	if workspace.Currency == "" {
+		workspace.Currency = domain.DefaultCurrency
+	}
 	m.Workspaces[workspace.ID] = workspace
 	m.ByUserID[workspace.UserID] = workspace
+	m.setMember(workspace.ID, workspace.UserID, domain.WorkspaceRoleOwner)
 	if auth0ID != "" {
-		m.ByUserAuth0ID[auth0ID] = workspace
+		// The first workspace added for a user acts as their default
+		if _, ok := m.ByUserAuth0ID[auth0ID]; !ok {
+			m.ByUserAuth0ID[auth0ID] = workspace
+		}
+		m.AllByAuth0ID[auth0ID] = append(m.AllByAuth0ID[auth0ID], workspace)
 	}
 }
 
+// MockWorkspaceInviteRepository is a mock implementation of domain.WorkspaceInviteRepository
+type MockWorkspaceInviteRepository struct {
+	Invites       map[string]*domain.WorkspaceInvite
+	WorkspaceRepo *MockWorkspaceRepository // receives members on Accept
+	NextID        int32
+}
+
+// NewMockWorkspaceInviteRepository creates a new MockWorkspaceInviteRepository
+func NewMockWorkspaceInviteRepository(workspaceRepo *MockWorkspaceRepository) *MockWorkspaceInviteRepository {
+	return &MockWorkspaceInviteRepository{
+		Invites:       make(map[string]*domain.WorkspaceInvite),
+		WorkspaceRepo: workspaceRepo,
+		NextID:        1,
+	}
+}
+
+// Create stores a new invite
+func (m *MockWorkspaceInviteRepository) Create(invite *domain.WorkspaceInvite) (*domain.WorkspaceInvite, error) {
+	invite.ID = m.NextID
+	m.NextID++
+	invite.CreatedAt = time.Now()
+	m.Invites[invite.Token] = invite
+	return invite, nil
+}
+
+// GetByToken retrieves an invite by token
+func (m *MockWorkspaceInviteRepository) GetByToken(token string) (*domain.WorkspaceInvite, error) {
+	if invite, ok := m.Invites[token]; ok {
+		return invite, nil
+	}
+	return nil, domain.ErrInviteNotFound
+}
+
+// Accept marks the invite accepted and adds the member
+func (m *MockWorkspaceInviteRepository) Accept(inviteID int32, member *domain.WorkspaceMember) (*domain.WorkspaceMember, error) {
+	for _, invite := range m.Invites {
+		if invite.ID != inviteID {
+			continue
+		}
+		if invite.AcceptedAt != nil {
+			return nil, domain.ErrInviteAlreadyAccepted
+		}
+		now := time.Now()
+		invite.AcceptedAt = &now
+		return m.WorkspaceRepo.AddMember(member)
+	}
+	return nil, domain.ErrInviteNotFound
+}
+
+// AddInvite adds an invite to the mock repository (helper for tests)
+func (m *MockWorkspaceInviteRepository) AddInvite(invite *domain.WorkspaceInvite) {
+	m.Invites[invite.Token] = invite
+}
+
 // MockAccountRepository is a mock implementation of domain.AccountRepository
 type MockAccountRepository struct {
 	Accounts                   map[int32]*domain.Account
@@ -188,12 +355,18 @@ type MockAccountRepository struct {
 	NextID                     int32
 	CreateFn                   func(account *domain.Account) (*domain.Account, error)
 	GetByIDFn                  func(workspaceID int32, id int32) (*domain.Account, error)
-	GetAllFn                   func(workspaceID int32, includeArchived bool) ([]*domain.Account, error)
+	GetAllFn                   func(workspaceID int32, opts domain.ListOptions) ([]*domain.Account, error)
 	UpdateFn                   func(workspaceID int32, id int32, name string) (*domain.Account, error)
 	SoftDeleteFn               func(workspaceID int32, id int32) error
 	HardDeleteFn               func(workspaceID int32, id int32) error
 	GetCCOutstandingSummaryFn  func(workspaceID int32) (*domain.CCOutstandingSummary, error)
 	GetPerAccountOutstandingFn func(workspaceID int32) ([]*domain.PerAccountOutstanding, error)
+	SetGroupFn                 func(workspaceID int32, id int32, groupID *int32) (*domain.Account, error)
+	UngroupByGroupFn           func(workspaceID int32, groupID int32) error
+	UpdateTemplateFn           func(workspaceID int32, id int32, template domain.AccountTemplate, accountType domain.AccountType) (*domain.Account, error)
+	CountTransactionsFn        func(workspaceID int32, id int32) (int64, error)
+	SetExcludeFromNetWorthFn   func(workspaceID int32, id int32, exclude bool) (*domain.Account, error)
+	TransactionCounts          map[int32]int64 // Per account ID, returned by CountTransactions
 }
 
 // NewMockAccountRepository creates a new MockAccountRepository
@@ -234,15 +407,15 @@ func (m *MockAccountRepository) GetByID(workspaceID int32, id int32) (*domain.Ac
 }
 
 // GetAllByWorkspace retrieves all accounts for a workspace
-func (m *MockAccountRepository) GetAllByWorkspace(workspaceID int32, includeArchived bool) ([]*domain.Account, error) {
+func (m *MockAccountRepository) GetAllByWorkspace(workspaceID int32, opts domain.ListOptions) ([]*domain.Account, error) {
 	if m.GetAllFn != nil {
-		return m.GetAllFn(workspaceID, includeArchived)
+		return m.GetAllFn(workspaceID, opts)
 	}
 	accounts := m.ByWorkspace[workspaceID]
 	if accounts == nil {
 		return []*domain.Account{}, nil
 	}
-	if includeArchived {
+	if opts.IncludeDeleted {
 		return accounts, nil
 	}
 	// Filter out soft-deleted accounts
@@ -271,6 +444,28 @@ func (m *MockAccountRepository) Update(workspaceID int32, id int32, name string)
 	return account, nil
 }
 
+// UpdateTemplate changes an account's template and account type
+func (m *MockAccountRepository) UpdateTemplate(workspaceID int32, id int32, template domain.AccountTemplate, accountType domain.AccountType) (*domain.Account, error) {
+	if m.UpdateTemplateFn != nil {
+		return m.UpdateTemplateFn(workspaceID, id, template, accountType)
+	}
+	account, ok := m.Accounts[id]
+	if !ok || account.WorkspaceID != workspaceID || account.DeletedAt != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	account.Template = template
+	account.AccountType = accountType
+	return account, nil
+}
+
+// CountTransactions returns the count set in TransactionCounts for the account
+func (m *MockAccountRepository) CountTransactions(workspaceID int32, id int32) (int64, error) {
+	if m.CountTransactionsFn != nil {
+		return m.CountTransactionsFn(workspaceID, id)
+	}
+	return m.TransactionCounts[id], nil
+}
+
 // SoftDelete marks an account as deleted
 func (m *MockAccountRepository) SoftDelete(workspaceID int32, id int32) error {
 	if m.SoftDeleteFn != nil {
@@ -333,6 +528,150 @@ func (m *MockAccountRepository) GetPerAccountOutstanding(workspaceID int32) ([]*
 	return []*domain.PerAccountOutstanding{}, nil
 }
 
+// SetGroup assigns an account to a group (nil moves it to ungrouped)
+func (m *MockAccountRepository) SetGroup(workspaceID int32, id int32, groupID *int32) (*domain.Account, error) {
+	if m.SetGroupFn != nil {
+		return m.SetGroupFn(workspaceID, id, groupID)
+	}
+	account, ok := m.Accounts[id]
+	if !ok || account.WorkspaceID != workspaceID || account.DeletedAt != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	account.AccountGroupID = groupID
+	return account, nil
+}
+
+// UngroupByGroup moves all accounts of a group to ungrouped
+func (m *MockAccountRepository) UngroupByGroup(workspaceID int32, groupID int32) error {
+	if m.UngroupByGroupFn != nil {
+		return m.UngroupByGroupFn(workspaceID, groupID)
+	}
+	for _, acc := range m.ByWorkspace[workspaceID] {
+		if acc.AccountGroupID != nil && *acc.AccountGroupID == groupID {
+			acc.AccountGroupID = nil
+		}
+	}
+	return nil
+}
+
+// SetExcludeFromNetWorth includes or excludes an account's balance from net worth
+func (m *MockAccountRepository) SetExcludeFromNetWorth(workspaceID int32, id int32, exclude bool) (*domain.Account, error) {
+	if m.SetExcludeFromNetWorthFn != nil {
+		return m.SetExcludeFromNetWorthFn(workspaceID, id, exclude)
+	}
+	account, ok := m.Accounts[id]
+	if !ok || account.WorkspaceID != workspaceID || account.DeletedAt != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	account.ExcludeFromNetWorth = exclude
+	return account, nil
+}
+
+// MockAccountGroupRepository is a mock implementation of domain.AccountGroupRepository
+type MockAccountGroupRepository struct {
+	Groups       map[int32]*domain.AccountGroup
+	NextID       int32
+	CreateFn     func(group *domain.AccountGroup) (*domain.AccountGroup, error)
+	GetByIDFn    func(workspaceID int32, id int32) (*domain.AccountGroup, error)
+	GetAllFn     func(workspaceID int32) ([]*domain.AccountGroup, error)
+	UpdateFn     func(group *domain.AccountGroup) (*domain.AccountGroup, error)
+	SoftDeleteFn func(workspaceID int32, id int32) error
+}
+
+// NewMockAccountGroupRepository creates a new MockAccountGroupRepository
+func NewMockAccountGroupRepository() *MockAccountGroupRepository {
+	return &MockAccountGroupRepository{
+		Groups: make(map[int32]*domain.AccountGroup),
+		NextID: 1,
+	}
+}
+
+// nameTaken reports whether an active group in the workspace already uses name
+func (m *MockAccountGroupRepository) nameTaken(workspaceID int32, name string, excludeID int32) bool {
+	for _, g := range m.Groups {
+		if g.WorkspaceID == workspaceID && g.Name == name && g.ID != excludeID && g.DeletedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Create creates a new account group
+func (m *MockAccountGroupRepository) Create(group *domain.AccountGroup) (*domain.AccountGroup, error) {
+	if m.CreateFn != nil {
+		return m.CreateFn(group)
+	}
+	if m.nameTaken(group.WorkspaceID, group.Name, 0) {
+		return nil, domain.ErrAccountGroupAlreadyExists
+	}
+	group.ID = m.NextID
+	m.NextID++
+	m.Groups[group.ID] = group
+	return group, nil
+}
+
+// GetByID retrieves an account group by ID within a workspace
+func (m *MockAccountGroupRepository) GetByID(workspaceID int32, id int32) (*domain.AccountGroup, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(workspaceID, id)
+	}
+	group, ok := m.Groups[id]
+	if !ok || group.WorkspaceID != workspaceID || group.DeletedAt != nil {
+		return nil, domain.ErrAccountGroupNotFound
+	}
+	return group, nil
+}
+
+// GetAllByWorkspace retrieves all active account groups for a workspace, ordered by name
+func (m *MockAccountGroupRepository) GetAllByWorkspace(workspaceID int32) ([]*domain.AccountGroup, error) {
+	if m.GetAllFn != nil {
+		return m.GetAllFn(workspaceID)
+	}
+	groups := []*domain.AccountGroup{}
+	for _, g := range m.Groups {
+		if g.WorkspaceID == workspaceID && g.DeletedAt == nil {
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// Update renames an account group
+func (m *MockAccountGroupRepository) Update(group *domain.AccountGroup) (*domain.AccountGroup, error) {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(group)
+	}
+	existing, ok := m.Groups[group.ID]
+	if !ok || existing.WorkspaceID != group.WorkspaceID || existing.DeletedAt != nil {
+		return nil, domain.ErrAccountGroupNotFound
+	}
+	if m.nameTaken(group.WorkspaceID, group.Name, group.ID) {
+		return nil, domain.ErrAccountGroupAlreadyExists
+	}
+	existing.Name = group.Name
+	return existing, nil
+}
+
+// SoftDelete marks an account group as deleted
+func (m *MockAccountGroupRepository) SoftDelete(workspaceID int32, id int32) error {
+	if m.SoftDeleteFn != nil {
+		return m.SoftDeleteFn(workspaceID, id)
+	}
+	group, ok := m.Groups[id]
+	if !ok || group.WorkspaceID != workspaceID || group.DeletedAt != nil {
+		return domain.ErrAccountGroupNotFound
+	}
+	now := time.Now()
+	group.DeletedAt = &now
+	return nil
+}
+
+// AddGroup adds an account group directly to the mock (helper for tests)
+func (m *MockAccountGroupRepository) AddGroup(group *domain.AccountGroup) {
+	m.Groups[group.ID] = group
+}
+
 // MockTransactionRepository is a mock implementation of domain.TransactionRepository
 type MockTransactionRepository struct {
 	Transactions               map[int32]*domain.Transaction
@@ -350,16 +689,19 @@ type MockTransactionRepository struct {
 	SoftDeleteTransferPairFn          func(workspaceID int32, pairID uuid.UUID) error
 	GetAccountTransactionSummariesFn  func(workspaceID int32) ([]*domain.TransactionSummary, error)
 	SumByTypeAndDateRangeFn           func(workspaceID int32, startDate, endDate time.Time, txType domain.TransactionType) (decimal.Decimal, error)
-	SumPaidExpensesByDateRangeFn        func(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
+	SumPaidExpensesByDateRangeFn        func(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
 	SumUnpaidExpensesByDateRangeFn      func(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
-	SumUnpaidExpensesForDisposableFn    func(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
-	SumDeferredCCByDateRangeFn          func(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error)
+	SumUnpaidExpensesForDisposableFn    func(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
+	SumDeferredCCByDateRangeFn          func(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error)
 	GetRecentlyUsedCategoriesFn         func(workspaceID int32) ([]*domain.RecentCategory, error)
 	GetProjectionsByTemplateFn        func(workspaceID int32, templateID int32) ([]*domain.Transaction, error)
+	GetByTemplateFn                   func(workspaceID int32, templateID int32) ([]*domain.Transaction, error)
 	DeleteProjectionsByTemplateFn     func(workspaceID int32, templateID int32) error
 	DeleteProjectionsBeyondDateFn     func(workspaceID int32, templateID int32, date time.Time) error
+	DeleteFutureUnpaidActualsByTemplateFn func(workspaceID int32, templateID int32, after time.Time) error
 	OrphanActualsByTemplateFn         func(workspaceID int32, templateID int32) error
 	GetCCMetricsFn                    func(workspaceID int32, startDate, endDate time.Time) (*domain.CCMetrics, error)
+	GetCCStateBreakdownFn             func(workspaceID, accountID int32) (*domain.CCStateBreakdown, error)
 	BatchToggleToBilledFn             func(workspaceID int32, ids []int32) ([]*domain.Transaction, error)
 	GetByIDsFn                        func(workspaceID int32, ids []int32) ([]*domain.Transaction, error)
 	BulkSettleFn                      func(workspaceID int32, ids []int32) ([]*domain.Transaction, error)
@@ -368,6 +710,10 @@ type MockTransactionRepository struct {
 	GetPendingDeferredCCFn            func(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error)
 	AtomicSettleFn                    func(fromTx, toTx *domain.Transaction, settleIDs []int32) (*domain.Transaction, int, error)
 	GetOverdueCCFn                    func(workspaceID int32) ([]*domain.Transaction, error)
+	PromoteDueScheduledFn             func(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error)
+	GetLoanTrendDataFn                func(workspaceID int32, startYear, startMonth, endYear, endMonth int32) ([]*domain.LoanTrendDataRow, error)
+	// LoanRepo resolves loan links for orphan and unpaid-payment lookups; nil means no loans exist
+	LoanRepo domain.LoanRepository
 }
 
 // NewMockTransactionRepository creates a new MockTransactionRepository
@@ -381,7 +727,7 @@ func NewMockTransactionRepository() *MockTransactionRepository {
 }
 
 // Create creates a new transaction
-func (m *MockTransactionRepository) Create(transaction *domain.Transaction) (*domain.Transaction, error) {
+func (m *MockTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
 	if m.CreateFn != nil {
 		return m.CreateFn(transaction)
 	}
@@ -403,7 +749,7 @@ func (m *MockTransactionRepository) CreateBatchTx(tx interface{}, transactions [
 	}
 	created := make([]*domain.Transaction, len(transactions))
 	for i, t := range transactions {
-		result, err := m.Create(t)
+		result, err := m.Create(context.Background(), t)
 		if err != nil {
 			return nil, err
 		}
@@ -427,8 +773,41 @@ func (m *MockTransactionRepository) GetByID(workspaceID int32, id int32) (*domai
 	return transaction, nil
 }
 
+// ListAfterCursor returns up to limit transactions ordered by (transaction_date, id) after the cursor
+func (m *MockTransactionRepository) ListAfterCursor(workspaceID int32, after *domain.TransactionCursor, limit int32) ([]*domain.Transaction, error) {
+	var active []*domain.Transaction
+	for _, t := range m.ByWorkspace[workspaceID] {
+		if t.DeletedAt == nil {
+			active = append(active, t)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if !active[i].TransactionDate.Equal(active[j].TransactionDate) {
+			return active[i].TransactionDate.Before(active[j].TransactionDate)
+		}
+		return active[i].ID < active[j].ID
+	})
+
+	result := []*domain.Transaction{}
+	for _, t := range active {
+		if int32(len(result)) >= limit {
+			break
+		}
+		if after != nil {
+			if t.TransactionDate.Before(after.TransactionDate) {
+				continue
+			}
+			if t.TransactionDate.Equal(after.TransactionDate) && t.ID <= after.ID {
+				continue
+			}
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
 // GetByWorkspace retrieves all transactions for a workspace with optional filters and pagination
-func (m *MockTransactionRepository) GetByWorkspace(workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
+func (m *MockTransactionRepository) GetByWorkspace(ctx context.Context, workspaceID int32, filters *domain.TransactionFilters) (*domain.PaginatedTransactions, error) {
 	if m.GetByWSFn != nil {
 		return m.GetByWSFn(workspaceID, filters)
 	}
@@ -440,7 +819,7 @@ func (m *MockTransactionRepository) GetByWorkspace(workspaceID int32, filters *d
 	// Filter out soft-deleted and apply filters
 	var filtered []*domain.Transaction
 	for _, t := range transactions {
-		if t.DeletedAt != nil {
+		if t.DeletedAt != nil && (filters == nil || !filters.IncludeDeleted) {
 			continue
 		}
 		if filters != nil {
@@ -456,6 +835,18 @@ func (m *MockTransactionRepository) GetByWorkspace(workspaceID int32, filters *d
 			if filters.Type != nil && t.Type != *filters.Type {
 				continue
 			}
+			if filters.Source != nil && t.Source != *filters.Source {
+				continue
+			}
+			if filters.PaidStart != nil && (t.PaidAt == nil || t.PaidAt.Before(*filters.PaidStart)) {
+				continue
+			}
+			if filters.PaidEnd != nil && (t.PaidAt == nil || !t.PaidAt.Before(filters.PaidEnd.AddDate(0, 0, 1))) {
+				continue
+			}
+			if filters.HasLocation != nil && (t.Latitude != nil || t.Location != nil) != *filters.HasLocation {
+				continue
+			}
 		}
 		filtered = append(filtered, t)
 	}
@@ -515,6 +906,11 @@ func (m *MockTransactionRepository) TogglePaid(workspaceID int32, id int32) (*do
 		return nil, domain.ErrTransactionNotFound
 	}
 	transaction.IsPaid = !transaction.IsPaid
+	transaction.PaidAt = nil
+	if transaction.IsPaid {
+		now := time.Now()
+		transaction.PaidAt = &now
+	}
 	return transaction, nil
 }
 
@@ -538,9 +934,32 @@ func (m *MockTransactionRepository) Update(workspaceID int32, id int32, data *do
 	transaction.Notes = data.Notes
 	transaction.CategoryID = data.CategoryID
 	// Update CC lifecycle fields (v2 simplified)
+	if !data.IsPaid {
+		transaction.PaidAt = nil
+	} else if !transaction.IsPaid {
+		now := time.Now()
+		transaction.PaidAt = &now
+	}
 	transaction.IsPaid = data.IsPaid
 	transaction.BilledAt = data.BilledAt
 	transaction.SettlementIntent = data.SettlementIntent
+	// Mirrors the repository: an empty source is written as "manual"
+	transaction.Source = data.Source
+	if transaction.Source == "" {
+		transaction.Source = "manual"
+	}
+	transaction.TemplateID = data.TemplateID
+	transaction.IsProjected = data.IsProjected
+	transaction.IsScheduled = data.IsScheduled
+	transaction.Merchant = data.Merchant
+	transaction.Payee = data.Payee
+	transaction.Latitude = data.Latitude
+	transaction.Longitude = data.Longitude
+	transaction.Location = data.Location
+	transaction.ModifiedFromTemplate = data.ModifiedFromTemplate
+	transaction.IsRefund = data.IsRefund
+	transaction.ExcludeFromReports = data.ExcludeFromReports
+	transaction.IsTaxDeductible = data.IsTaxDeductible
 	// Compute CCState from isPaid and billedAt
 	if transaction.SettlementIntent != nil {
 		transaction.CCState = domain.ComputeCCState(transaction.IsPaid, transaction.BilledAt)
@@ -565,6 +984,39 @@ func (m *MockTransactionRepository) SoftDelete(workspaceID int32, id int32) erro
 	return nil
 }
 
+// SoftDeleteByIDs soft deletes every matching transaction
+func (m *MockTransactionRepository) SoftDeleteByIDs(workspaceID int32, ids []int32) (int, error) {
+	now := time.Now()
+	count := 0
+	for _, id := range ids {
+		transaction, ok := m.Transactions[id]
+		if !ok || transaction.WorkspaceID != workspaceID || transaction.DeletedAt != nil {
+			continue
+		}
+		transaction.DeletedAt = &now
+		count++
+	}
+	return count, nil
+}
+
+// BulkUpdateAccount moves transactions to another account, skipping transfer legs
+func (m *MockTransactionRepository) BulkUpdateAccount(workspaceID int32, ids []int32, accountID int32) ([]*domain.Transaction, error) {
+	result := []*domain.Transaction{}
+	for _, id := range ids {
+		transaction, ok := m.Transactions[id]
+		if !ok || transaction.WorkspaceID != workspaceID || transaction.DeletedAt != nil {
+			continue
+		}
+		if transaction.TransferPairID != nil || transaction.AccountID == accountID {
+			continue
+		}
+		transaction.AccountID = accountID
+		transaction.UpdatedAt = time.Now()
+		result = append(result, transaction)
+	}
+	return result, nil
+}
+
 // AddTransaction adds a transaction to the mock repository (helper for tests)
 func (m *MockTransactionRepository) AddTransaction(transaction *domain.Transaction) {
 	m.Transactions[transaction.ID] = transaction
@@ -600,6 +1052,58 @@ func (m *MockTransactionRepository) CreateTransferPair(fromTx, toTx *domain.Tran
 	}, nil
 }
 
+// CreateWithRoundUp creates an expense and links the round-up transfer legs to it
+func (m *MockTransactionRepository) CreateWithRoundUp(ctx context.Context, expense, roundUpFrom, roundUpTo *domain.Transaction) (*domain.Transaction, *domain.TransferResult, error) {
+	created, err := m.Create(ctx, expense)
+	if err != nil {
+		return nil, nil, err
+	}
+	roundUpFrom.RoundUpSourceID = &created.ID
+	roundUpTo.RoundUpSourceID = &created.ID
+	result, err := m.CreateTransferPair(roundUpFrom, roundUpTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return created, result, nil
+}
+
+// ReplaceRoundUp soft deletes an expense's round-up legs and creates the given legs in their place
+func (m *MockTransactionRepository) ReplaceRoundUp(ctx context.Context, workspaceID int32, sourceID int32, roundUpFrom, roundUpTo *domain.Transaction) (*domain.TransferResult, error) {
+	now := time.Now()
+	for _, tx := range m.Transactions {
+		if tx.WorkspaceID == workspaceID && tx.RoundUpSourceID != nil && *tx.RoundUpSourceID == sourceID && tx.DeletedAt == nil {
+			tx.DeletedAt = &now
+		}
+	}
+	if roundUpFrom == nil || roundUpTo == nil {
+		return nil, nil
+	}
+	roundUpFrom.RoundUpSourceID = &sourceID
+	roundUpTo.RoundUpSourceID = &sourceID
+	return m.CreateTransferPair(roundUpFrom, roundUpTo)
+}
+
+// GetTransferPair retrieves both transactions of a transfer pair
+func (m *MockTransactionRepository) GetTransferPair(workspaceID int32, pairID uuid.UUID) ([]*domain.Transaction, error) {
+	var result []*domain.Transaction
+	for _, tx := range m.ByTransferPairID[pairID] {
+		if tx.WorkspaceID == workspaceID && tx.DeletedAt == nil {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
+// IsTransferReversed checks whether a reversal already exists for a transfer pair
+func (m *MockTransactionRepository) IsTransferReversed(workspaceID int32, pairID uuid.UUID) (bool, error) {
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt == nil && tx.ReversesTransferPairID != nil && *tx.ReversesTransferPairID == pairID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // SoftDeleteTransferPair soft deletes both transactions in a transfer pair
 func (m *MockTransactionRepository) SoftDeleteTransferPair(workspaceID int32, pairID uuid.UUID) error {
 	if m.SoftDeleteTransferPairFn != nil {
@@ -624,17 +1128,23 @@ func (m *MockTransactionRepository) SoftDeleteTransferPair(workspaceID int32, pa
 // - SumExpenses: paid expenses only (for regular accounts)
 // - SumUnpaidExpenses: unpaid expenses
 // - SumAllExpenses: all expenses regardless of isPaid (for CC accounts)
+// - Scheduled transactions dated after today are skipped
 func (m *MockTransactionRepository) GetAccountTransactionSummaries(workspaceID int32) ([]*domain.TransactionSummary, error) {
 	if m.GetAccountTransactionSummariesFn != nil {
 		return m.GetAccountTransactionSummariesFn(workspaceID)
 	}
 
 	// Aggregate transactions by account
+	now := time.Now()
 	summaryMap := make(map[int32]*domain.TransactionSummary)
 	for _, tx := range m.ByWorkspace[workspaceID] {
 		if tx.DeletedAt != nil {
 			continue
 		}
+		// Scheduled transactions are excluded until their date arrives
+		if tx.IsPendingSchedule(now) {
+			continue
+		}
 		summary, ok := summaryMap[tx.AccountID]
 		if !ok {
 			summary = &domain.TransactionSummary{AccountID: tx.AccountID}
@@ -665,24 +1175,64 @@ func (m *MockTransactionRepository) GetAccountTransactionSummaries(workspaceID i
 	return summaries, nil
 }
 
+// GetAccountTransactionSummaryAsOf aggregates one account's transactions dated on or before asOf
+func (m *MockTransactionRepository) GetAccountTransactionSummaryAsOf(workspaceID, accountID int32, asOf time.Time) (*domain.TransactionSummary, error) {
+	now := time.Now()
+	summary := &domain.TransactionSummary{AccountID: accountID}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.AccountID != accountID {
+			continue
+		}
+		if domain.IsAfterDay(tx.TransactionDate, asOf) || tx.IsPendingSchedule(now) {
+			continue
+		}
+		if tx.Type == domain.TransactionTypeIncome {
+			if tx.IsPaid {
+				summary.SumIncome = summary.SumIncome.Add(tx.Amount)
+			}
+		} else if tx.Type == domain.TransactionTypeExpense {
+			summary.SumAllExpenses = summary.SumAllExpenses.Add(tx.Amount)
+			if tx.IsPaid {
+				summary.SumExpenses = summary.SumExpenses.Add(tx.Amount)
+			} else {
+				summary.SumUnpaidExpenses = summary.SumUnpaidExpenses.Add(tx.Amount)
+			}
+		}
+	}
+	return summary, nil
+}
+
 // SumByTypeAndDateRange sums transactions by type within a date range
 func (m *MockTransactionRepository) SumByTypeAndDateRange(workspaceID int32, startDate, endDate time.Time, txType domain.TransactionType) (decimal.Decimal, error) {
 	if m.SumByTypeAndDateRangeFn != nil {
 		return m.SumByTypeAndDateRangeFn(workspaceID, startDate, endDate, txType)
 	}
+	return m.SumByTypeAndDateRangeInAccounts(workspaceID, startDate, endDate, txType, nil)
+}
 
+// SumByTypeAndDateRangeInAccounts sums transactions by type within a date range on the given accounts
+func (m *MockTransactionRepository) SumByTypeAndDateRangeInAccounts(workspaceID int32, startDate, endDate time.Time, txType domain.TransactionType, accountIDs []int32) (decimal.Decimal, error) {
 	total := decimal.Zero
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || !inAccounts(accountIDs, tx.AccountID) {
 			continue
 		}
-		if tx.Type != txType {
+		// Refunds never count as income; they reduce the expense total
+		if tx.IsRefund {
+			if txType != domain.TransactionTypeExpense {
+				continue
+			}
+		} else if tx.Type != txType {
 			continue
 		}
 		// Check if transaction date is within range (inclusive)
 		if (tx.TransactionDate.Equal(startDate) || tx.TransactionDate.After(startDate)) &&
 			(tx.TransactionDate.Equal(endDate) || tx.TransactionDate.Before(endDate)) {
-			total = total.Add(tx.Amount)
+			if tx.IsRefund {
+				total = total.Sub(tx.Amount)
+			} else {
+				total = total.Add(tx.Amount)
+			}
 		}
 	}
 	return total, nil
@@ -707,7 +1257,9 @@ func (m *MockTransactionRepository) GetMonthlyTransactionSummaries(workspaceID i
 			summary = &domain.MonthlyTransactionSummary{Year: key.year, Month: key.month}
 			summaryMap[key] = summary
 		}
-		if tx.Type == domain.TransactionTypeIncome {
+		if tx.IsRefund {
+			summary.TotalExpenses = summary.TotalExpenses.Sub(tx.Amount)
+		} else if tx.Type == domain.TransactionTypeIncome {
 			summary.TotalIncome = summary.TotalIncome.Add(tx.Amount)
 		} else if tx.Type == domain.TransactionTypeExpense {
 			summary.TotalExpenses = summary.TotalExpenses.Add(tx.Amount)
@@ -722,17 +1274,17 @@ func (m *MockTransactionRepository) GetMonthlyTransactionSummaries(workspaceID i
 }
 
 // SumPaidExpensesByDateRange sums paid expenses within a date range
-func (m *MockTransactionRepository) SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+func (m *MockTransactionRepository) SumPaidExpensesByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	if m.SumPaidExpensesByDateRangeFn != nil {
-		return m.SumPaidExpensesByDateRangeFn(workspaceID, startDate, endDate)
+		return m.SumPaidExpensesByDateRangeFn(workspaceID, startDate, endDate, accountIDs)
 	}
 
 	total := decimal.Zero
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || !inAccounts(accountIDs, tx.AccountID) {
 			continue
 		}
-		if tx.Type != domain.TransactionTypeExpense {
+		if tx.Type != domain.TransactionTypeExpense && !tx.IsRefund {
 			continue
 		}
 		if !tx.IsPaid {
@@ -741,7 +1293,11 @@ func (m *MockTransactionRepository) SumPaidExpensesByDateRange(workspaceID int32
 		// Check if transaction date is within range (inclusive)
 		if (tx.TransactionDate.Equal(startDate) || tx.TransactionDate.After(startDate)) &&
 			(tx.TransactionDate.Equal(endDate) || tx.TransactionDate.Before(endDate)) {
-			total = total.Add(tx.Amount)
+			if tx.IsRefund {
+				total = total.Sub(tx.Amount)
+			} else {
+				total = total.Add(tx.Amount)
+			}
 		}
 	}
 	return total, nil
@@ -775,14 +1331,14 @@ func (m *MockTransactionRepository) SumUnpaidExpensesByDateRange(workspaceID int
 
 // SumUnpaidExpensesForDisposable sums unpaid expenses for disposable income
 // EXCLUDES deferred CC transactions (they're for next month)
-func (m *MockTransactionRepository) SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+func (m *MockTransactionRepository) SumUnpaidExpensesForDisposable(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	if m.SumUnpaidExpensesForDisposableFn != nil {
-		return m.SumUnpaidExpensesForDisposableFn(workspaceID, startDate, endDate)
+		return m.SumUnpaidExpensesForDisposableFn(workspaceID, startDate, endDate, accountIDs)
 	}
 
 	total := decimal.Zero
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || !inAccounts(accountIDs, tx.AccountID) {
 			continue
 		}
 		if tx.Type != domain.TransactionTypeExpense {
@@ -805,14 +1361,14 @@ func (m *MockTransactionRepository) SumUnpaidExpensesForDisposable(workspaceID i
 }
 
 // SumDeferredCCByDateRange sums deferred CC expenses within a date range
-func (m *MockTransactionRepository) SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time) (decimal.Decimal, error) {
+func (m *MockTransactionRepository) SumDeferredCCByDateRange(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) (decimal.Decimal, error) {
 	if m.SumDeferredCCByDateRangeFn != nil {
-		return m.SumDeferredCCByDateRangeFn(workspaceID, startDate, endDate)
+		return m.SumDeferredCCByDateRangeFn(workspaceID, startDate, endDate, accountIDs)
 	}
 
 	total := decimal.Zero
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || !inAccounts(accountIDs, tx.AccountID) {
 			continue
 		}
 		if tx.Type != domain.TransactionTypeExpense {
@@ -834,6 +1390,21 @@ func (m *MockTransactionRepository) SumDeferredCCByDateRange(workspaceID int32,
 	return total, nil
 }
 
+// GetDistinctSources returns the sorted source values in use across the workspace
+func (m *MockTransactionRepository) GetDistinctSources(ctx context.Context, workspaceID int32) ([]string, error) {
+	seen := make(map[string]bool)
+	sources := []string{}
+	for _, t := range m.ByWorkspace[workspaceID] {
+		if t.DeletedAt != nil || t.Source == "" || seen[t.Source] {
+			continue
+		}
+		seen[t.Source] = true
+		sources = append(sources, t.Source)
+	}
+	sort.Strings(sources)
+	return sources, nil
+}
+
 // GetRecentlyUsedCategories returns recently used categories for suggestions
 func (m *MockTransactionRepository) GetRecentlyUsedCategories(workspaceID int32) ([]*domain.RecentCategory, error) {
 	if m.GetRecentlyUsedCategoriesFn != nil {
@@ -844,7 +1415,7 @@ func (m *MockTransactionRepository) GetRecentlyUsedCategories(workspaceID int32)
 }
 
 // GetProjectionsByTemplate retrieves all projected transactions for a specific template
-func (m *MockTransactionRepository) GetProjectionsByTemplate(workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
+func (m *MockTransactionRepository) GetProjectionsByTemplate(ctx context.Context, workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
 	if m.GetProjectionsByTemplateFn != nil {
 		return m.GetProjectionsByTemplateFn(workspaceID, templateID)
 	}
@@ -861,6 +1432,30 @@ func (m *MockTransactionRepository) GetProjectionsByTemplate(workspaceID int32,
 	return result, nil
 }
 
+// GetByTemplate retrieves all transactions generated from a template, newest first
+func (m *MockTransactionRepository) GetByTemplate(workspaceID int32, templateID int32) ([]*domain.Transaction, error) {
+	if m.GetByTemplateFn != nil {
+		return m.GetByTemplateFn(workspaceID, templateID)
+	}
+
+	var result []*domain.Transaction
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil {
+			continue
+		}
+		if tx.TemplateID != nil && *tx.TemplateID == templateID {
+			result = append(result, tx)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].TransactionDate.Equal(result[j].TransactionDate) {
+			return result[i].TransactionDate.After(result[j].TransactionDate)
+		}
+		return result[i].ID > result[j].ID
+	})
+	return result, nil
+}
+
 // DeleteProjectionsByTemplate deletes all projected transactions for a template
 func (m *MockTransactionRepository) DeleteProjectionsByTemplate(workspaceID int32, templateID int32) error {
 	if m.DeleteProjectionsByTemplateFn != nil {
@@ -899,6 +1494,22 @@ func (m *MockTransactionRepository) DeleteProjectionsBeyondDate(workspaceID int3
 	return nil
 }
 
+// DeleteFutureUnpaidActualsByTemplate soft deletes unpaid actual transactions of a template dated after the given day
+func (m *MockTransactionRepository) DeleteFutureUnpaidActualsByTemplate(workspaceID int32, templateID int32, after time.Time) error {
+	if m.DeleteFutureUnpaidActualsByTemplateFn != nil {
+		return m.DeleteFutureUnpaidActualsByTemplateFn(workspaceID, templateID, after)
+	}
+
+	now := time.Now()
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.TemplateID != nil && *tx.TemplateID == templateID && !tx.IsProjected && !tx.IsPaid &&
+			tx.DeletedAt == nil && domain.IsAfterDay(tx.TransactionDate, after) {
+			tx.DeletedAt = &now
+		}
+	}
+	return nil
+}
+
 // OrphanActualsByTemplate unlinks actual transactions from a template
 func (m *MockTransactionRepository) OrphanActualsByTemplate(workspaceID int32, templateID int32) error {
 	if m.OrphanActualsByTemplateFn != nil {
@@ -926,6 +1537,29 @@ func (m *MockTransactionRepository) GetCCMetrics(workspaceID int32, startDate, e
 	}, nil
 }
 
+// GetCCStateBreakdown returns counts and totals of a CC account's purchases per CC state
+func (m *MockTransactionRepository) GetCCStateBreakdown(workspaceID, accountID int32) (*domain.CCStateBreakdown, error) {
+	if m.GetCCStateBreakdownFn != nil {
+		return m.GetCCStateBreakdownFn(workspaceID, accountID)
+	}
+	breakdown := &domain.CCStateBreakdown{AccountID: accountID}
+	for _, tx := range m.Transactions {
+		if tx.WorkspaceID != workspaceID || tx.AccountID != accountID || tx.DeletedAt != nil || tx.Type != domain.TransactionTypeExpense {
+			continue
+		}
+		bucket := &breakdown.Pending
+		switch *domain.ComputeCCState(tx.IsPaid, tx.BilledAt) {
+		case domain.CCStateBilled:
+			bucket = &breakdown.Billed
+		case domain.CCStateSettled:
+			bucket = &breakdown.Settled
+		}
+		bucket.Count++
+		bucket.Total = bucket.Total.Add(tx.Amount)
+	}
+	return breakdown, nil
+}
+
 // BatchToggleToBilled toggles multiple pending transactions to billed state
 func (m *MockTransactionRepository) BatchToggleToBilled(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
 	if m.BatchToggleToBilledFn != nil {
@@ -935,6 +1569,24 @@ func (m *MockTransactionRepository) BatchToggleToBilled(workspaceID int32, ids [
 	return []*domain.Transaction{}, nil
 }
 
+// BillPendingUpTo bills pending expenses on the account dated on/before cutoff
+func (m *MockTransactionRepository) BillPendingUpTo(workspaceID, accountID int32, cutoff time.Time) ([]*domain.Transaction, error) {
+	billedAt := time.Date(cutoff.Year(), cutoff.Month(), cutoff.Day(), 0, 0, 0, 0, time.UTC)
+	result := []*domain.Transaction{}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.AccountID != accountID || tx.Type != domain.TransactionTypeExpense {
+			continue
+		}
+		if tx.BilledAt != nil || tx.IsPaid || tx.TransactionDate.After(billedAt) {
+			continue
+		}
+		billed := billedAt
+		tx.BilledAt = &billed
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
 // GetByIDs retrieves multiple transactions by their IDs
 func (m *MockTransactionRepository) GetByIDs(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
 	if m.GetByIDsFn != nil {
@@ -973,12 +1625,16 @@ func (m *MockTransactionRepository) GetDeferredForSettlement(workspaceID int32)
 	if m.GetDeferredForSettlementFn != nil {
 		return m.GetDeferredForSettlementFn(workspaceID)
 	}
-	// Default: find billed+deferred transactions
+	return m.GetDeferredForSettlementInAccounts(workspaceID, nil)
+}
+
+// GetDeferredForSettlementInAccounts retrieves billed+deferred transactions on the given accounts
+func (m *MockTransactionRepository) GetDeferredForSettlementInAccounts(workspaceID int32, accountIDs []int32) ([]*domain.Transaction, error) {
 	billedState := domain.CCStateBilled
 	deferredIntent := domain.SettlementIntentDeferred
 	var result []*domain.Transaction
 	for _, tx := range m.Transactions {
-		if tx.WorkspaceID == workspaceID &&
+		if tx.WorkspaceID == workspaceID && inAccounts(accountIDs, tx.AccountID) &&
 			tx.CCState != nil && *tx.CCState == billedState &&
 			tx.SettlementIntent != nil && *tx.SettlementIntent == deferredIntent {
 			result = append(result, tx)
@@ -1081,6 +1737,31 @@ func (m *MockTransactionRepository) GetOverdueCC(workspaceID int32) ([]*domain.T
 	return result, nil
 }
 
+// GetOverdueTransactions returns unpaid expenses dated before asOf, oldest first
+func (m *MockTransactionRepository) GetOverdueTransactions(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error) {
+	asOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	var result []*domain.Transaction
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.IsPaid || tx.Type != domain.TransactionTypeExpense || tx.TransferPairID != nil {
+			continue
+		}
+		if tx.SettlementIntent != nil && *tx.SettlementIntent == domain.SettlementIntentDeferred {
+			continue
+		}
+		if !tx.TransactionDate.Before(asOfDay) {
+			continue
+		}
+		result = append(result, tx)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].TransactionDate.Equal(result[j].TransactionDate) {
+			return result[i].TransactionDate.Before(result[j].TransactionDate)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
 // GetByDateRangeForAggregation retrieves all transactions in a date range for aggregation (no pagination)
 func (m *MockTransactionRepository) GetByDateRangeForAggregation(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error) {
 	if m.GetByWSFn != nil {
@@ -1093,14 +1774,48 @@ func (m *MockTransactionRepository) GetByDateRangeForAggregation(workspaceID int
 		}
 		return result.Data, nil
 	}
+	return m.GetByDateRangeForAggregationInAccounts(workspaceID, startDate, endDate, nil)
+}
 
-	// Filter transactions by workspace and date range
+// GetByDateRangeForAggregationInAccounts retrieves transactions in a date range on the given accounts
+func (m *MockTransactionRepository) GetByDateRangeForAggregationInAccounts(workspaceID int32, startDate, endDate time.Time, accountIDs []int32) ([]*domain.Transaction, error) {
 	var result []*domain.Transaction
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || !inAccounts(accountIDs, tx.AccountID) {
+			continue
+		}
+		if tx.TransactionDate.Before(startDate) || tx.TransactionDate.After(endDate) {
+			continue
+		}
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
+// inAccounts reports whether accountID is in accountIDs; an empty list matches every account
+func inAccounts(accountIDs []int32, accountID int32) bool {
+	if len(accountIDs) == 0 {
+		return true
+	}
+	for _, id := range accountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLoanTransactionsByMonth returns unpaid transactions for a specific loan and month
+func (m *MockTransactionRepository) GetLoanTransactionsByMonth(workspaceID int32, loanID int32, year, month int) ([]*domain.Transaction, error) {
+	var result []*domain.Transaction
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.IsPaid || tx.IsLoanDownPayment() {
+			continue
+		}
+		if tx.LoanID == nil || *tx.LoanID != loanID {
 			continue
 		}
-		if tx.TransactionDate.Before(startDate) || tx.TransactionDate.After(endDate) {
+		if tx.TransactionDate.Year() != year || int(tx.TransactionDate.Month()) != month {
 			continue
 		}
 		result = append(result, tx)
@@ -1108,14 +1823,11 @@ func (m *MockTransactionRepository) GetByDateRangeForAggregation(workspaceID int
 	return result, nil
 }
 
-// GetLoanTransactionsByMonth returns unpaid transactions for a specific loan and month
-func (m *MockTransactionRepository) GetLoanTransactionsByMonth(workspaceID int32, loanID int32, year, month int) ([]*domain.Transaction, error) {
+// GetAllLoanTransactionsByMonth returns all loan transactions (paid and unpaid) for a month
+func (m *MockTransactionRepository) GetAllLoanTransactionsByMonth(workspaceID int32, year, month int) ([]*domain.Transaction, error) {
 	var result []*domain.Transaction
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil || tx.IsPaid {
-			continue
-		}
-		if tx.LoanID == nil || *tx.LoanID != loanID {
+		if tx.DeletedAt != nil || tx.LoanID == nil || tx.IsLoanDownPayment() {
 			continue
 		}
 		if tx.TransactionDate.Year() != year || int(tx.TransactionDate.Month()) != month {
@@ -1127,7 +1839,7 @@ func (m *MockTransactionRepository) GetLoanTransactionsByMonth(workspaceID int32
 }
 
 // BulkMarkPaid marks multiple transactions as paid by IDs
-func (m *MockTransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32) ([]*domain.Transaction, error) {
+func (m *MockTransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32, paidAt time.Time) ([]*domain.Transaction, error) {
 	var result []*domain.Transaction
 	idSet := make(map[int32]bool)
 	for _, id := range ids {
@@ -1140,12 +1852,19 @@ func (m *MockTransactionRepository) BulkMarkPaid(workspaceID int32, ids []int32)
 		}
 		if idSet[tx.ID] {
 			tx.IsPaid = true
+			paid := paidAt
+			tx.PaidAt = &paid
 			result = append(result, tx)
 		}
 	}
 	return result, nil
 }
 
+// BulkMarkPaidTx marks multiple transactions as paid by IDs (mock ignores the transaction)
+func (m *MockTransactionRepository) BulkMarkPaidTx(tx interface{}, workspaceID int32, ids []int32, paidAt time.Time) ([]*domain.Transaction, error) {
+	return m.BulkMarkPaid(workspaceID, ids, paidAt)
+}
+
 // GetByLoanID retrieves all transactions for a specific loan
 func (m *MockTransactionRepository) GetByLoanID(workspaceID int32, loanID int32) ([]*domain.Transaction, error) {
 	var result []*domain.Transaction
@@ -1160,12 +1879,17 @@ func (m *MockTransactionRepository) GetByLoanID(workspaceID int32, loanID int32)
 	return result, nil
 }
 
+func (m *MockTransactionRepository) GetByLoanIDTx(tx interface{}, workspaceID int32, loanID int32) ([]*domain.Transaction, error) {
+	return m.GetByLoanID(workspaceID, loanID)
+}
+
 func (m *MockTransactionRepository) OrphanPaidTransactionsByLoan(workspaceID int32, loanID int32) error {
 	for _, tx := range m.ByWorkspace[workspaceID] {
 		if tx.DeletedAt != nil {
 			continue
 		}
 		if tx.LoanID != nil && *tx.LoanID == loanID && tx.IsPaid {
+			tx.DeletedLoanID = tx.LoanID
 			tx.LoanID = nil
 		}
 	}
@@ -1173,24 +1897,55 @@ func (m *MockTransactionRepository) OrphanPaidTransactionsByLoan(workspaceID int
 }
 
 func (m *MockTransactionRepository) DeleteUnpaidTransactionsByLoan(workspaceID int32, loanID int32) error {
-	var remaining []*domain.Transaction
+	now := time.Now()
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		// Keep transactions that are not for this loan or are paid
-		if tx.LoanID == nil || *tx.LoanID != loanID || tx.IsPaid {
-			remaining = append(remaining, tx)
-		} else {
-			// Remove from main map as well
-			delete(m.Transactions, tx.ID)
+		if tx.DeletedAt != nil || tx.IsPaid {
+			continue
+		}
+		if tx.LoanID != nil && *tx.LoanID == loanID {
+			tx.DeletedAt = &now
+			tx.DeletedLoanID = tx.LoanID
+			tx.LoanID = nil
+		}
+	}
+	return nil
+}
+
+func (m *MockTransactionRepository) ClearUnpaidTransactionsByLoanTx(tx interface{}, workspaceID int32, loanID int32) error {
+	now := time.Now()
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.IsPaid || tx.IsLoanDownPayment() {
+			continue
+		}
+		if tx.LoanID != nil && *tx.LoanID == loanID {
+			tx.DeletedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockTransactionRepository) RestoreTransactionsByDeletedLoan(workspaceID int32, loanID int32) error {
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedLoanID == nil || *tx.DeletedLoanID != loanID {
+			continue
+		}
+		tx.LoanID = tx.DeletedLoanID
+		tx.DeletedLoanID = nil
+		if !tx.IsPaid {
+			tx.DeletedAt = nil
 		}
 	}
-	m.ByWorkspace[workspaceID] = remaining
 	return nil
 }
 
+func (m *MockTransactionRepository) RestoreTransactionsByDeletedLoanTx(tx interface{}, workspaceID int32, loanID int32) error {
+	return m.RestoreTransactionsByDeletedLoan(workspaceID, loanID)
+}
+
 func (m *MockTransactionRepository) GetLoanTransactionStats(workspaceID int32, loanID int32) (*domain.LoanTransactionStats, error) {
 	stats := &domain.LoanTransactionStats{}
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || tx.IsLoanDownPayment() {
 			continue
 		}
 		if tx.LoanID != nil && *tx.LoanID == loanID {
@@ -1209,7 +1964,7 @@ func (m *MockTransactionRepository) GetLoanTransactionStats(workspaceID int32, l
 func (m *MockTransactionRepository) UpdatePayeesByLoan(workspaceID int32, loanID int32, newPayee string) (int64, error) {
 	var count int64
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || tx.IsLoanDownPayment() {
 			continue
 		}
 		if tx.LoanID != nil && *tx.LoanID == loanID {
@@ -1220,9 +1975,31 @@ func (m *MockTransactionRepository) UpdatePayeesByLoan(workspaceID int32, loanID
 	return count, nil
 }
 
+// SettleUnpaidByLoanExternally marks the loan's unpaid transactions paid with the external source
+func (m *MockTransactionRepository) SettleUnpaidByLoanExternally(workspaceID int32, loanID int32, note string, paidAt time.Time) ([]*domain.Transaction, error) {
+	var result []*domain.Transaction
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.IsPaid || tx.IsLoanDownPayment() {
+			continue
+		}
+		if tx.LoanID != nil && *tx.LoanID == loanID {
+			tx.IsPaid = true
+			paid := paidAt
+			tx.PaidAt = &paid
+			tx.Source = domain.TransactionSourceExternal
+			if note != "" {
+				notes := note
+				tx.Notes = &notes
+			}
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
 func (m *MockTransactionRepository) HasPaidTransactionsByLoan(workspaceID int32, loanID int32) (bool, error) {
 	for _, tx := range m.ByWorkspace[workspaceID] {
-		if tx.DeletedAt != nil {
+		if tx.DeletedAt != nil || tx.IsLoanDownPayment() {
 			continue
 		}
 		if tx.LoanID != nil && *tx.LoanID == loanID && tx.IsPaid {
@@ -1233,10 +2010,117 @@ func (m *MockTransactionRepository) HasPaidTransactionsByLoan(workspaceID int32,
 }
 
 func (m *MockTransactionRepository) GetLoanTrendData(workspaceID int32, startYear, startMonth, endYear, endMonth int32) ([]*domain.LoanTrendDataRow, error) {
+	if m.GetLoanTrendDataFn != nil {
+		return m.GetLoanTrendDataFn(workspaceID, startYear, startMonth, endYear, endMonth)
+	}
 	// Mock implementation returns empty slice for tests
 	return []*domain.LoanTrendDataRow{}, nil
 }
 
+// PromoteDueScheduled clears the scheduled flag on a workspace's transactions dated on or before asOf
+func (m *MockTransactionRepository) PromoteDueScheduled(workspaceID int32, asOf time.Time) ([]*domain.Transaction, error) {
+	if m.PromoteDueScheduledFn != nil {
+		return m.PromoteDueScheduledFn(workspaceID, asOf)
+	}
+	promoted := []*domain.Transaction{}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt == nil && tx.IsScheduled && !tx.IsPendingSchedule(asOf) {
+			tx.IsScheduled = false
+			promoted = append(promoted, tx)
+		}
+	}
+	return promoted, nil
+}
+
+// GetOrphanedLoanTransactions returns transactions whose loan is missing from LoanRepo
+func (m *MockTransactionRepository) GetOrphanedLoanTransactions(workspaceID int32) ([]*domain.Transaction, error) {
+	orphans := []*domain.Transaction{}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.LoanID == nil {
+			continue
+		}
+		if m.LoanRepo != nil {
+			if _, err := m.LoanRepo.GetByID(workspaceID, *tx.LoanID); err == nil {
+				continue
+			}
+		}
+		orphans = append(orphans, tx)
+	}
+	return orphans, nil
+}
+
+// GetUnpaidLoanTransactions returns unpaid loan transactions whose loan is still in LoanRepo
+func (m *MockTransactionRepository) GetUnpaidLoanTransactions(workspaceID int32) ([]*domain.Transaction, error) {
+	result := []*domain.Transaction{}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil || tx.LoanID == nil || tx.IsPaid || m.LoanRepo == nil || tx.IsLoanDownPayment() {
+			continue
+		}
+		if _, err := m.LoanRepo.GetByID(workspaceID, *tx.LoanID); err != nil {
+			continue
+		}
+		result = append(result, tx)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].TransactionDate.Equal(result[j].TransactionDate) {
+			return result[i].TransactionDate.Before(result[j].TransactionDate)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// Search returns transactions whose name, merchant, or payee contains query, newest first
+func (m *MockTransactionRepository) Search(workspaceID int32, query string, limit int32) ([]*domain.Transaction, error) {
+	needle := strings.ToLower(query)
+	contains := func(s *string) bool {
+		return s != nil && strings.Contains(strings.ToLower(*s), needle)
+	}
+	result := []*domain.Transaction{}
+	for _, tx := range m.ByWorkspace[workspaceID] {
+		if tx.DeletedAt != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(tx.Name), needle) || contains(tx.Merchant) || contains(tx.Payee) {
+			result = append(result, tx)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].TransactionDate.Equal(result[j].TransactionDate) {
+			return result[i].TransactionDate.After(result[j].TransactionDate)
+		}
+		return result[i].ID > result[j].ID
+	})
+	if len(result) > int(limit) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockTransactionRepository) ClearOrphanedLoanLinks(workspaceID int32) ([]*domain.Transaction, error) {
+	orphans, err := m.GetOrphanedLoanTransactions(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range orphans {
+		tx.LoanID = nil
+	}
+	return orphans, nil
+}
+
+// PromoteAllDueScheduled clears the scheduled flag on due transactions across all workspaces
+func (m *MockTransactionRepository) PromoteAllDueScheduled(asOf time.Time) ([]*domain.Transaction, error) {
+	all := []*domain.Transaction{}
+	for workspaceID := range m.ByWorkspace {
+		promoted, err := m.PromoteDueScheduled(workspaceID, asOf)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, promoted...)
+	}
+	return all, nil
+}
+
 // MockMonthRepository is a mock implementation of domain.MonthRepository
 type MockMonthRepository struct {
 	Months                             map[int32]*domain.Month
@@ -1247,6 +2131,7 @@ type MockMonthRepository struct {
 	GetLatestFn                        func(workspaceID int32) (*domain.Month, error)
 	GetAllFn                           func(workspaceID int32) ([]*domain.Month, error)
 	UpdateStartingBalanceFn            func(workspaceID, id int32, balance decimal.Decimal) error
+	CloseFn                            func(workspaceID, id int32) (bool, error)
 }
 
 // NewMockMonthRepository creates a new MockMonthRepository
@@ -1348,6 +2233,33 @@ func (m *MockMonthRepository) UpdateStartingBalance(workspaceID, id int32, balan
 	return nil
 }
 
+// Close marks a month closed; returns false if it was already closed
+func (m *MockMonthRepository) Close(workspaceID, id int32) (bool, error) {
+	if m.CloseFn != nil {
+		return m.CloseFn(workspaceID, id)
+	}
+	mon, ok := m.Months[id]
+	if !ok || mon.WorkspaceID != workspaceID || mon.ClosedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	mon.ClosedAt = &now
+	return true, nil
+}
+
+// CloseAllBefore closes every open month before year/month across all workspaces
+func (m *MockMonthRepository) CloseAllBefore(year, month int) (int64, error) {
+	var closed int64
+	now := time.Now()
+	for _, mon := range m.Months {
+		if mon.ClosedAt == nil && mon.Year*12+mon.Month < year*12+month {
+			mon.ClosedAt = &now
+			closed++
+		}
+	}
+	return closed, nil
+}
+
 // AddMonth adds a month to the mock repository (helper for tests)
 func (m *MockMonthRepository) AddMonth(month *domain.Month) {
 	m.Months[month.ID] = month
@@ -1365,9 +2277,10 @@ type MockBudgetCategoryRepository struct {
 	GetByIDFn        func(workspaceID int32, id int32) (*domain.BudgetCategory, error)
 	GetByNameFn      func(workspaceID int32, name string) (*domain.BudgetCategory, error)
 	GetAllFn         func(workspaceID int32) ([]*domain.BudgetCategory, error)
-	UpdateFn         func(workspaceID int32, id int32, name string) (*domain.BudgetCategory, error)
+	UpdateFn         func(category *domain.BudgetCategory) (*domain.BudgetCategory, error)
 	SoftDeleteFn     func(workspaceID int32, id int32) error
 	HasTransactionsFn func(workspaceID int32, id int32) (bool, error)
+	Usage            map[int32]domain.CategoryUsage // Per-category usage returned by GetAllWithUsage
 }
 
 // NewMockBudgetCategoryRepository creates a new MockBudgetCategoryRepository
@@ -1376,6 +2289,7 @@ func NewMockBudgetCategoryRepository() *MockBudgetCategoryRepository {
 		Categories:  make(map[int32]*domain.BudgetCategory),
 		ByWorkspace: make(map[int32][]*domain.BudgetCategory),
 		ByName:      make(map[string]*domain.BudgetCategory),
+		Usage:       make(map[int32]domain.CategoryUsage),
 		NextID:      1,
 	}
 }
@@ -1405,7 +2319,7 @@ func (m *MockBudgetCategoryRepository) Create(category *domain.BudgetCategory) (
 }
 
 // GetByID retrieves a budget category by its ID within a workspace
-func (m *MockBudgetCategoryRepository) GetByID(workspaceID int32, id int32) (*domain.BudgetCategory, error) {
+func (m *MockBudgetCategoryRepository) GetByID(ctx context.Context, workspaceID int32, id int32) (*domain.BudgetCategory, error) {
 	if m.GetByIDFn != nil {
 		return m.GetByIDFn(workspaceID, id)
 	}
@@ -1454,11 +2368,12 @@ func (m *MockBudgetCategoryRepository) GetAllByWorkspace(workspaceID int32) ([]*
 	return active, nil
 }
 
-// Update updates a budget category's name
-func (m *MockBudgetCategoryRepository) Update(workspaceID int32, id int32, name string) (*domain.BudgetCategory, error) {
+// Update updates a budget category's name, description, color and parent
+func (m *MockBudgetCategoryRepository) Update(updated *domain.BudgetCategory) (*domain.BudgetCategory, error) {
 	if m.UpdateFn != nil {
-		return m.UpdateFn(workspaceID, id, name)
+		return m.UpdateFn(updated)
 	}
+	workspaceID, id, name := updated.WorkspaceID, updated.ID, updated.Name
 	category, ok := m.Categories[id]
 	if !ok || category.WorkspaceID != workspaceID || category.DeletedAt != nil {
 		return nil, domain.ErrBudgetCategoryNotFound
@@ -1473,6 +2388,10 @@ func (m *MockBudgetCategoryRepository) Update(workspaceID int32, id int32, name
 	delete(m.ByName, oldKey)
 	// Update
 	category.Name = name
+	category.Description = updated.Description
+	category.Color = updated.Color
+	category.ParentID = updated.ParentID
+	category.IsTaxDeductible = updated.IsTaxDeductible
 	category.UpdatedAt = time.Now()
 	m.ByName[key] = category
 	return category, nil
@@ -1489,6 +2408,12 @@ func (m *MockBudgetCategoryRepository) SoftDelete(workspaceID int32, id int32) e
 	}
 	now := time.Now()
 	category.DeletedAt = &now
+	// Subcategories are promoted to top level
+	for _, child := range m.ByWorkspace[workspaceID] {
+		if child.ParentID != nil && *child.ParentID == id {
+			child.ParentID = nil
+		}
+	}
 	return nil
 }
 
@@ -1501,6 +2426,21 @@ func (m *MockBudgetCategoryRepository) HasTransactions(workspaceID int32, id int
 	return false, nil
 }
 
+// GetAllWithUsage returns active categories with usage from m.Usage; categories without an entry report zero
+func (m *MockBudgetCategoryRepository) GetAllWithUsage(workspaceID int32) ([]domain.CategoryUsage, error) {
+	categories, err := m.GetAllByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.CategoryUsage, len(categories))
+	for i, cat := range categories {
+		usage := m.Usage[cat.ID]
+		usage.Category = cat
+		result[i] = usage
+	}
+	return result, nil
+}
+
 // AddBudgetCategory adds a budget category to the mock repository (helper for tests)
 func (m *MockBudgetCategoryRepository) AddBudgetCategory(category *domain.BudgetCategory) {
 	m.Categories[category.ID] = category
@@ -1673,6 +2613,39 @@ func (m *MockBudgetAllocationRepository) SetSpendingByCategory(workspaceID int32
 	m.SpendingByCategory[key] = spending
 }
 
+// SetSpendingFromTransactions derives per-category spending from transactions the
+// same way the query does: expenses add, refunds subtract and report exclusions are
+// skipped (helper for tests)
+func (m *MockBudgetAllocationRepository) SetSpendingFromTransactions(workspaceID int32, year, month int, transactions []*domain.Transaction) {
+	spentMap := make(map[int32]decimal.Decimal)
+	order := []int32{}
+	for _, tx := range transactions {
+		if tx.CategoryID == nil || tx.DeletedAt != nil || tx.ExcludeFromReports {
+			continue
+		}
+		if tx.Type != domain.TransactionTypeExpense && !tx.IsRefund {
+			continue
+		}
+		if tx.TransactionDate.Year() != year || int(tx.TransactionDate.Month()) != month {
+			continue
+		}
+		if _, ok := spentMap[*tx.CategoryID]; !ok {
+			order = append(order, *tx.CategoryID)
+		}
+		if tx.IsRefund {
+			spentMap[*tx.CategoryID] = spentMap[*tx.CategoryID].Sub(tx.Amount)
+		} else {
+			spentMap[*tx.CategoryID] = spentMap[*tx.CategoryID].Add(tx.Amount)
+		}
+	}
+
+	spending := make([]*domain.CategorySpending, 0, len(order))
+	for _, categoryID := range order {
+		spending = append(spending, &domain.CategorySpending{CategoryID: categoryID, Spent: spentMap[categoryID]})
+	}
+	m.SetSpendingByCategory(workspaceID, year, month, spending)
+}
+
 // GetCategoryTransactions retrieves transactions for a specific category and month
 func (m *MockBudgetAllocationRepository) GetCategoryTransactions(workspaceID int32, categoryID int32, year, month int) ([]*domain.CategoryTransaction, error) {
 	if m.GetCategoryTransactionsFn != nil {
@@ -1743,7 +2716,7 @@ type MockRecurringTemplateRepository struct {
 	UpdateFn    func(workspaceID int32, id int32, input *domain.UpdateRecurringTemplateInput) (*domain.RecurringTemplate, error)
 	DeleteFn    func(workspaceID int32, id int32) error
 	GetByIDFn   func(workspaceID int32, id int32) (*domain.RecurringTemplate, error)
-	ListFn      func(workspaceID int32) ([]*domain.RecurringTemplate, error)
+	ListFn      func(workspaceID int32, filter *domain.RecurringFilter) ([]*domain.RecurringTemplate, error)
 	GetActiveFn func(workspaceID int32) ([]*domain.RecurringTemplate, error)
 }
 
@@ -1820,23 +2793,46 @@ func (m *MockRecurringTemplateRepository) GetByID(workspaceID int32, id int32) (
 	if !ok || template.WorkspaceID != workspaceID {
 		return nil, domain.ErrRecurringTemplateNotFound
 	}
-	return template, nil
+	// Return a copy like a database read, so callers can compare before/after an update
+	copied := *template
+	return &copied, nil
 }
 
-// ListByWorkspace retrieves all recurring templates for a workspace
-func (m *MockRecurringTemplateRepository) ListByWorkspace(workspaceID int32) ([]*domain.RecurringTemplate, error) {
+// ListByWorkspace retrieves recurring templates for a workspace, narrowed by the optional filter
+func (m *MockRecurringTemplateRepository) ListByWorkspace(workspaceID int32, filter *domain.RecurringFilter) ([]*domain.RecurringTemplate, error) {
 	if m.ListFn != nil {
-		return m.ListFn(workspaceID)
+		return m.ListFn(workspaceID, filter)
 	}
 	templates := m.ByWorkspace[workspaceID]
 	if templates == nil {
 		return []*domain.RecurringTemplate{}, nil
 	}
-	return templates, nil
+	if filter == nil {
+		return templates, nil
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	result := []*domain.RecurringTemplate{}
+	for _, t := range templates {
+		if filter.AccountID != nil && t.AccountID != *filter.AccountID {
+			continue
+		}
+		if filter.CategoryID != nil && (t.CategoryID == nil || *t.CategoryID != *filter.CategoryID) {
+			continue
+		}
+		if filter.Active != nil {
+			active := t.EndDate == nil || !t.EndDate.Before(today)
+			if active != *filter.Active {
+				continue
+			}
+		}
+		result = append(result, t)
+	}
+	return result, nil
 }
 
 // GetActive retrieves all active recurring templates (no end_date or end_date >= today)
-func (m *MockRecurringTemplateRepository) GetActive(workspaceID int32) ([]*domain.RecurringTemplate, error) {
+func (m *MockRecurringTemplateRepository) GetActive(ctx context.Context, workspaceID int32) ([]*domain.RecurringTemplate, error) {
 	if m.GetActiveFn != nil {
 		return m.GetActiveFn(workspaceID)
 	}
@@ -1880,6 +2876,78 @@ func (m *MockRecurringTemplateRepository) AddTemplate(template *domain.Recurring
 	m.ByWorkspace[template.WorkspaceID] = append(m.ByWorkspace[template.WorkspaceID], template)
 }
 
+// MockProjectionExclusionRepository is a mock implementation of domain.ProjectionExclusionRepository
+type MockProjectionExclusionRepository struct {
+	// Excluded is keyed by workspace, template and the first day of the excluded month
+	Excluded map[string]*domain.ProjectionExclusion
+	NextID   int32
+}
+
+// NewMockProjectionExclusionRepository creates a new MockProjectionExclusionRepository
+func NewMockProjectionExclusionRepository() *MockProjectionExclusionRepository {
+	return &MockProjectionExclusionRepository{
+		Excluded: make(map[string]*domain.ProjectionExclusion),
+		NextID:   1,
+	}
+}
+
+func exclusionKey(workspaceID int32, templateID int32, excludedMonth time.Time) string {
+	return fmt.Sprintf("%d:%d:%s", workspaceID, templateID, excludedMonth.Format("2006-01-02"))
+}
+
+// Create creates a new exclusion record (idempotent)
+func (m *MockProjectionExclusionRepository) Create(workspaceID int32, templateID int32, excludedMonth time.Time) error {
+	key := exclusionKey(workspaceID, templateID, excludedMonth)
+	if _, ok := m.Excluded[key]; ok {
+		return nil
+	}
+	m.Excluded[key] = &domain.ProjectionExclusion{
+		ID:            m.NextID,
+		WorkspaceID:   workspaceID,
+		TemplateID:    templateID,
+		ExcludedMonth: excludedMonth,
+		CreatedAt:     time.Now(),
+	}
+	m.NextID++
+	return nil
+}
+
+// IsExcluded checks if a specific month is excluded for a template
+func (m *MockProjectionExclusionRepository) IsExcluded(ctx context.Context, workspaceID int32, templateID int32, excludedMonth time.Time) (bool, error) {
+	_, ok := m.Excluded[exclusionKey(workspaceID, templateID, excludedMonth)]
+	return ok, nil
+}
+
+// Delete removes a single month's exclusion for a template
+func (m *MockProjectionExclusionRepository) Delete(workspaceID int32, templateID int32, excludedMonth time.Time) error {
+	delete(m.Excluded, exclusionKey(workspaceID, templateID, excludedMonth))
+	return nil
+}
+
+// DeleteByTemplate removes all exclusions for a template
+func (m *MockProjectionExclusionRepository) DeleteByTemplate(templateID int32) error {
+	for key, exclusion := range m.Excluded {
+		if exclusion.TemplateID == templateID {
+			delete(m.Excluded, key)
+		}
+	}
+	return nil
+}
+
+// GetByTemplate gets all exclusions for a template ordered by month
+func (m *MockProjectionExclusionRepository) GetByTemplate(workspaceID int32, templateID int32) ([]*domain.ProjectionExclusion, error) {
+	var result []*domain.ProjectionExclusion
+	for _, exclusion := range m.Excluded {
+		if exclusion.WorkspaceID == workspaceID && exclusion.TemplateID == templateID {
+			result = append(result, exclusion)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ExcludedMonth.Before(result[j].ExcludedMonth)
+	})
+	return result, nil
+}
+
 // MockLoanProviderRepository is a mock implementation of domain.LoanProviderRepository
 type MockLoanProviderRepository struct {
 	Providers   map[int32]*domain.LoanProvider
@@ -2020,9 +3088,12 @@ type MockLoanRepository struct {
 	GetAllFn           func(workspaceID int32) ([]*domain.Loan, error)
 	GetActiveFn        func(workspaceID int32, currentYear, currentMonth int) ([]*domain.Loan, error)
 	GetCompletedFn     func(workspaceID int32, currentYear, currentMonth int) ([]*domain.Loan, error)
+	GetAllWithStatsFn  func(ctx context.Context, workspaceID int32, opts domain.ListOptions) ([]*domain.LoanWithStats, error)
 	UpdateFn           func(loan *domain.Loan) (*domain.Loan, error)
 	DeleteFn           func(workspaceID int32, id int32) error
 	CountActiveFn      func(workspaceID int32, providerID int32, currentYear, currentMonth int) (int64, error)
+	// TransactionRepo resolves loan schedules for GetWithoutSchedule; nil means no loan has any
+	TransactionRepo *MockTransactionRepository
 }
 
 // NewMockLoanRepository creates a new MockLoanRepository
@@ -2101,6 +3172,25 @@ func (m *MockLoanRepository) GetAllByWorkspace(workspaceID int32) ([]*domain.Loa
 }
 
 // GetActiveByWorkspace retrieves active loans for a workspace
+// GetWithoutSchedule returns the workspace's loans with no scheduled transaction, soft-deleted ones included
+func (m *MockLoanRepository) GetWithoutSchedule(workspaceID int32) ([]*domain.Loan, error) {
+	scheduled := make(map[int32]bool)
+	if m.TransactionRepo != nil {
+		for _, tx := range m.TransactionRepo.ByWorkspace[workspaceID] {
+			if tx.LoanID != nil && !tx.IsLoanDownPayment() {
+				scheduled[*tx.LoanID] = true
+			}
+		}
+	}
+	var result []*domain.Loan
+	for _, loan := range m.ByWorkspace[workspaceID] {
+		if loan.DeletedAt == nil && !scheduled[loan.ID] {
+			result = append(result, loan)
+		}
+	}
+	return result, nil
+}
+
 func (m *MockLoanRepository) GetActiveByWorkspace(workspaceID int32, currentYear, currentMonth int) ([]*domain.Loan, error) {
 	if m.GetActiveFn != nil {
 		return m.GetActiveFn(workspaceID, currentYear, currentMonth)
@@ -2218,6 +3308,59 @@ func (m *MockLoanRepository) SoftDelete(workspaceID int32, id int32) error {
 	return nil
 }
 
+// GetDeletedByID retrieves a soft-deleted loan by ID
+func (m *MockLoanRepository) GetDeletedByID(workspaceID int32, id int32) (*domain.Loan, error) {
+	loan, ok := m.Loans[id]
+	if !ok || loan.WorkspaceID != workspaceID || loan.DeletedAt == nil {
+		return nil, domain.ErrLoanNotFound
+	}
+	return loan, nil
+}
+
+// GetDeletedByWorkspace retrieves soft-deleted loans, most recently deleted first
+func (m *MockLoanRepository) GetDeletedByWorkspace(workspaceID int32) ([]*domain.Loan, error) {
+	result := []*domain.Loan{}
+	for _, loan := range m.ByWorkspace[workspaceID] {
+		if loan.DeletedAt != nil {
+			result = append(result, loan)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DeletedAt.After(*result[j].DeletedAt)
+	})
+	return result, nil
+}
+
+// Restore clears DeletedAt on a soft-deleted loan
+func (m *MockLoanRepository) Restore(workspaceID int32, id int32) (*domain.Loan, error) {
+	loan, err := m.GetDeletedByID(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+	loan.DeletedAt = nil
+	return loan, nil
+}
+
+// RestoreTx restores a soft-deleted loan; the mock ignores the transaction
+func (m *MockLoanRepository) RestoreTx(tx interface{}, workspaceID int32, id int32) (*domain.Loan, error) {
+	return m.Restore(workspaceID, id)
+}
+
+// SetCompletedAt records when a loan was fully paid (nil clears it)
+func (m *MockLoanRepository) SetCompletedAt(workspaceID int32, id int32, completedAt *time.Time) error {
+	loan, ok := m.Loans[id]
+	if !ok || loan.WorkspaceID != workspaceID || loan.DeletedAt != nil {
+		return domain.ErrLoanNotFound
+	}
+	loan.CompletedAt = completedAt
+	for _, l := range m.LoansWithStats {
+		if l.ID == id {
+			l.CompletedAt = completedAt
+		}
+	}
+	return nil
+}
+
 // CountActiveLoansByProvider counts active loans for a provider
 func (m *MockLoanRepository) CountActiveLoansByProvider(workspaceID int32, providerID int32, currentYear, currentMonth int) (int64, error) {
 	if m.CountActiveFn != nil {
@@ -2263,31 +3406,51 @@ func (m *MockLoanRepository) SetActiveLoanCount(workspaceID, providerID int32, y
 }
 
 // GetAllWithStats retrieves all loans with payment statistics
-func (m *MockLoanRepository) GetAllWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
-	if m.LoansWithStats != nil {
-		return m.LoansWithStats, nil
+func (m *MockLoanRepository) GetAllWithStats(ctx context.Context, workspaceID int32, opts domain.ListOptions) ([]*domain.LoanWithStats, error) {
+	if m.GetAllWithStatsFn != nil {
+		return m.GetAllWithStatsFn(ctx, workspaceID, opts)
 	}
-	return []*domain.LoanWithStats{}, nil
+	result := []*domain.LoanWithStats{}
+	for _, l := range m.LoansWithStats {
+		if l.DeletedAt == nil || opts.IncludeDeleted {
+			result = append(result, l)
+		}
+	}
+	return result, nil
 }
 
 // GetActiveWithStats retrieves active loans with payment statistics
-func (m *MockLoanRepository) GetActiveWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
+func (m *MockLoanRepository) GetActiveWithStats(ctx context.Context, workspaceID int32) ([]*domain.LoanWithStats, error) {
 	if m.ActiveWithStats != nil {
 		return m.ActiveWithStats, nil
 	}
-	return []*domain.LoanWithStats{}, nil
+	// Derive from LoansWithStats using the same completion rule as the SQL
+	result := []*domain.LoanWithStats{}
+	for _, l := range m.LoansWithStats {
+		if !l.IsCompleted() {
+			result = append(result, l)
+		}
+	}
+	return result, nil
 }
 
 // GetCompletedWithStats retrieves completed loans with payment statistics
-func (m *MockLoanRepository) GetCompletedWithStats(workspaceID int32) ([]*domain.LoanWithStats, error) {
+func (m *MockLoanRepository) GetCompletedWithStats(ctx context.Context, workspaceID int32) ([]*domain.LoanWithStats, error) {
 	if m.CompletedWithStats != nil {
 		return m.CompletedWithStats, nil
 	}
-	return []*domain.LoanWithStats{}, nil
+	// Derive from LoansWithStats using the same completion rule as the SQL
+	result := []*domain.LoanWithStats{}
+	for _, l := range m.LoansWithStats {
+		if l.IsCompleted() {
+			result = append(result, l)
+		}
+	}
+	return result, nil
 }
 
 // GetByProviderWithStats retrieves all loans for a provider with payment statistics
-func (m *MockLoanRepository) GetByProviderWithStats(workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
+func (m *MockLoanRepository) GetByProviderWithStats(ctx context.Context, workspaceID int32, providerID int32) ([]*domain.LoanWithStats, error) {
 	// Filter LoansWithStats by providerID if available
 	if m.LoansWithStats != nil {
 		var result []*domain.LoanWithStats
@@ -3270,6 +4433,8 @@ type MockTransactionGroupRepository struct {
 	UnassignAllFromGroupFn          func(workspaceID int32, groupID int32) (int64, error)
 	DeleteGroupAndChildrenFn        func(workspaceID int32, groupID int32) (int32, error)
 	CountGroupChildrenFn            func(workspaceID int32, groupID int32) (int32, error)
+	GetGroupChildrenFn              func(workspaceID int32, groupID int32) ([]*domain.Transaction, error)
+	Children                        map[int32][]*domain.Transaction
 	GetUngroupedTransactionsByMonthFn          func(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error)
 	GetConsolidatedProvidersByMonthFn           func(workspaceID int32, month string) ([]domain.AutoDetectionCandidate, error)
 	GetUngroupedTransactionIDsByProviderMonthFn func(workspaceID int32, providerID int32, month string) ([]int32, error)
@@ -3279,8 +4444,9 @@ type MockTransactionGroupRepository struct {
 // NewMockTransactionGroupRepository creates a new MockTransactionGroupRepository
 func NewMockTransactionGroupRepository() *MockTransactionGroupRepository {
 	return &MockTransactionGroupRepository{
-		Groups: make(map[int32]*domain.TransactionGroup),
-		NextID: 1,
+		Groups:   make(map[int32]*domain.TransactionGroup),
+		Children: make(map[int32][]*domain.Transaction),
+		NextID:   1,
 	}
 }
 
@@ -3408,6 +4574,24 @@ func (m *MockTransactionGroupRepository) CountGroupChildren(workspaceID int32, g
 	return group.ChildCount, nil
 }
 
+// SetGroupChildren sets the member transactions returned by GetGroupChildren (helper for tests)
+func (m *MockTransactionGroupRepository) SetGroupChildren(groupID int32, transactions []*domain.Transaction) {
+	m.Children[groupID] = transactions
+}
+
+func (m *MockTransactionGroupRepository) GetGroupChildren(workspaceID int32, groupID int32) ([]*domain.Transaction, error) {
+	if m.GetGroupChildrenFn != nil {
+		return m.GetGroupChildrenFn(workspaceID, groupID)
+	}
+	var result []*domain.Transaction
+	for _, tx := range m.Children[groupID] {
+		if tx.WorkspaceID == workspaceID && tx.DeletedAt == nil {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
 func (m *MockTransactionGroupRepository) GetUngroupedTransactionsByMonth(workspaceID int32, startDate, endDate time.Time) ([]*domain.Transaction, error) {
 	if m.GetUngroupedTransactionsByMonthFn != nil {
 		return m.GetUngroupedTransactionsByMonthFn(workspaceID, startDate, endDate)
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"errors"
+	"time"
+)
+
+// Named date ranges accepted by list endpoints
+const (
+	RangeToday     = "today"
+	RangeThisWeek  = "this_week"
+	RangeThisMonth = "this_month"
+	RangeLastMonth = "last_month"
+)
+
+// ErrUnknownNamedRange is returned when a named range is not recognized
+var ErrUnknownNamedRange = errors.New("unknown named range")
+
+// ResolveNamedRange resolves a named range into inclusive start/end dates in tz.
+// Both returned times are midnight of their day; end is the last day included.
+// Weeks start on Monday. A nil tz is treated as UTC.
+func ResolveNamedRange(name string, now time.Time, tz *time.Location) (time.Time, time.Time, error) {
+	if tz == nil {
+		tz = time.UTC
+	}
+	local := now.In(tz)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, tz)
+
+	switch name {
+	case RangeToday:
+		return today, today, nil
+	case RangeThisWeek:
+		// time.Weekday has Sunday = 0; shift so Monday = 0
+		offset := (int(today.Weekday()) + 6) % 7
+		start := today.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 6), nil
+	case RangeThisMonth:
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, tz)
+		return start, start.AddDate(0, 1, -1), nil
+	case RangeLastMonth:
+		year, month := PreviousMonth(today.Year(), int(today.Month()))
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, tz)
+		return start, start.AddDate(0, 1, -1), nil
+	default:
+		return time.Time{}, time.Time{}, ErrUnknownNamedRange
+	}
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveNamedRange_ThisMonth(t *testing.T) {
+	now := time.Date(2026, 2, 14, 15, 30, 0, 0, time.UTC)
+
+	start, end, err := ResolveNamedRange(RangeThisMonth, now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	if !start.Equal(wantStart) {
+		t.Errorf("start = %v, want %v", start, wantStart)
+	}
+	if !end.Equal(wantEnd) {
+		t.Errorf("end = %v, want %v", end, wantEnd)
+	}
+}
+
+func TestResolveNamedRange_LastMonth_YearBoundary(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	start, end, err := ResolveNamedRange(RangeLastMonth, now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantStart := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	if !start.Equal(wantStart) {
+		t.Errorf("start = %v, want %v", start, wantStart)
+	}
+	if !end.Equal(wantEnd) {
+		t.Errorf("end = %v, want %v", end, wantEnd)
+	}
+}
+
+func TestResolveNamedRange_Today(t *testing.T) {
+	now := time.Date(2026, 3, 5, 23, 59, 0, 0, time.UTC)
+
+	start, end, err := ResolveNamedRange(RangeToday, now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	if !start.Equal(want) || !end.Equal(want) {
+		t.Errorf("got (%v, %v), want (%v, %v)", start, end, want, want)
+	}
+}
+
+func TestResolveNamedRange_ThisWeek_StartsMonday(t *testing.T) {
+	// 2026-03-08 is a Sunday
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	start, end, err := ResolveNamedRange(RangeThisWeek, now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantStart := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	if !start.Equal(wantStart) || !end.Equal(wantEnd) {
+		t.Errorf("got (%v, %v), want (%v, %v)", start, end, wantStart, wantEnd)
+	}
+}
+
+func TestResolveNamedRange_UsesTimezone(t *testing.T) {
+	tz := time.FixedZone("UTC+8", 8*60*60)
+	// 2026-01-31 20:00 UTC is already 2026-02-01 in UTC+8
+	now := time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC)
+
+	start, _, err := ResolveNamedRange(RangeThisMonth, now, tz)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if start.Year() != 2026 || start.Month() != time.February || start.Day() != 1 {
+		t.Errorf("Expected start 2026-02-01 in workspace timezone, got %v", start)
+	}
+}
+
+func TestResolveNamedRange_Unknown(t *testing.T) {
+	_, _, err := ResolveNamedRange("next_decade", time.Now(), time.UTC)
+	if err != ErrUnknownNamedRange {
+		t.Errorf("Expected ErrUnknownNamedRange, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+// Package version exposes build information injected at link time.
+//
+// Set the values with ldflags, e.g.:
+//
+//	go build -ldflags "-X github.com/dafibh/fortuna/fortuna-backend/internal/version.Version=v1.2.3 \
+//	  -X github.com/dafibh/fortuna/fortuna-backend/internal/version.GitCommit=$(git rev-parse --short HEAD) \
+//	  -X github.com/dafibh/fortuna/fortuna-backend/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/api
+package version
+
+// Build variables, overridden via ldflags; empty when built without them
+var (
+	Version   string
+	GitCommit string
+	BuildTime string
+)
+
+// Defaults reported when build variables are unset
+const (
+	DefaultVersion = "dev"
+	Unknown        = "unknown"
+)
+
+// Info holds the resolved build information
+type Info struct {
+	Version   string
+	GitCommit string
+	BuildTime string
+}
+
+// Get returns the build information, falling back to defaults for unset values
+func Get() Info {
+	return Info{
+		Version:   valueOr(Version, DefaultVersion),
+		GitCommit: valueOr(GitCommit, Unknown),
+		BuildTime: valueOr(BuildTime, Unknown),
+	}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}